@@ -21,10 +21,13 @@ type PageManager interface {
 	ShowCreateUserPage()
 	ShowCreateMediaPage(mediaType string)
 	ShowShelfPage()
+	ShowShelvingUnitsPage(unitID string)
+	ShowVideogameBacklogPage(platform string)
 	ShowParametersPage()
 	ShowCompartmentTreePage(mediaType string, mediaList []models.MediumWithRecord)
 	ShowUpdateMediaPage(mediaType, mediumID string, mediaList []models.MediumWithRecord)
 	ShowUpdateRecordPage(mediaType, mediumID string, mediaList []models.MediumWithRecord)
+	ShowEditReviewPage(mediaType string, mediumWithRecord models.MediumWithRecord)
 }
 
 // Create and configured the shared AppContext
@@ -46,6 +46,7 @@ type AppState struct {
 	LastUser      models.ClientUser `json:"last_user"`
 	ClientVersion string            `json:"client_version"`
 	ServerVersion string            `json:"server_version"`
+	RatingScale   string            `json:"rating_scale"`
 }
 
 // Check if appstate data exists and loads it
@@ -72,6 +73,7 @@ func (c *AppContext) LoadAppstate() {
 	c.APIClient.CurrentUser = appState.LastUser
 	c.APIClient.ClientVersion = appState.ClientVersion
 	c.APIClient.ServerVersion = appState.ServerVersion
+	c.APIClient.RatingScale = appState.RatingScale
 }
 
 // Store appstate data in local file
@@ -91,6 +93,7 @@ func (c *AppContext) SaveAppstate() {
 		LastUser:      c.APIClient.CurrentUser,
 		ClientVersion: c.APIClient.ClientVersion,
 		ServerVersion: c.APIClient.ServerVersion,
+		RatingScale:   c.APIClient.RatingScale,
 	}
 	data, err := json.Marshal(appState)
 	if err != nil {
@@ -86,6 +86,14 @@ func (pm *GuiPageManager) ShowUpdateRecordPage(mediaType, mediumID string, media
 	pm.mainWindow.Resize(fyne.NewSize(1024, 768))
 }
 
+func (pm *GuiPageManager) ShowEditReviewPage(mediaType string, mediumWithRecord models.MediumWithRecord) {
+	content := createEditReviewContent(pm.appCtxt, mediaType, mediumWithRecord)
+	pm.mainWindow.SetContent(content)
+	pm.mainWindow.SetTitle("Kallaxy - Review")
+	// Resize if needed
+	pm.mainWindow.Resize(fyne.NewSize(1024, 768))
+}
+
 func (pm *GuiPageManager) ShowShelfPage() {
 	mediaRecords, err := pm.appCtxt.APIClient.Media.GetMediaWithRecords()
 	if err != nil || len(mediaRecords.MediaRecords) == 0 {
@@ -99,6 +107,24 @@ func (pm *GuiPageManager) ShowShelfPage() {
 	pm.mainWindow.Resize(fyne.NewSize(1024, 768))
 }
 
+// Show a shelving unit's grid, the first unit if unitID is empty
+func (pm *GuiPageManager) ShowShelvingUnitsPage(unitID string) {
+	content := createShelvingUnitsContent(pm.appCtxt, unitID)
+	pm.mainWindow.SetContent(content)
+	pm.mainWindow.SetTitle("Kallaxy - My Shelving Units")
+	// Resize if needed
+	pm.mainWindow.Resize(fyne.NewSize(1024, 768))
+}
+
+// Show user's videogames still to play by platform, of every platform if platform is empty
+func (pm *GuiPageManager) ShowVideogameBacklogPage(platform string) {
+	content := createVideogameBacklogContent(pm.appCtxt, platform)
+	pm.mainWindow.SetContent(content)
+	pm.mainWindow.SetTitle("Kallaxy - My Videogame Backlog")
+	// Resize if needed
+	pm.mainWindow.Resize(fyne.NewSize(1024, 768))
+}
+
 func (pm *GuiPageManager) ShowCompartmentTreePage(mediaType string, mediaList []models.MediumWithRecord) {
 	content := createMediaTreeContent(pm.appCtxt, mediaType, mediaList)
 	pm.mainWindow.SetContent(content)
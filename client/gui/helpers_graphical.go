@@ -1,12 +1,14 @@
 package gui
 
 import (
+	"fmt"
 	"image/color"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/theme"
+	"github.com/VincNT21/kallaxy/client/models"
 )
 
 func customSeparatorForShelf() *canvas.Rectangle {
@@ -47,3 +49,17 @@ func createFallbackImage(title string) fyne.CanvasObject {
 		),
 	)
 }
+
+// Badge shown over a medium's image while it is lent out
+func createLentOutBadge(loan models.Loan) fyne.CanvasObject {
+	badgeColor := color.RGBA{R: 255, G: 120, B: 0, A: 230}
+	if loan.IsOverdue {
+		badgeColor = color.RGBA{R: 255, G: 0, B: 0, A: 230}
+	}
+	background := canvas.NewRectangle(badgeColor)
+	badgeText := canvas.NewText(fmt.Sprintf("Lent to %s", loan.BorrowerName), color.White)
+	badgeText.Alignment = fyne.TextAlignCenter
+	badgeText.TextStyle.Bold = true
+
+	return container.NewVBox(container.NewStack(background, container.NewPadded(badgeText)))
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"image/color"
 	"log"
+	"maps"
 	"time"
 
 	"fyne.io/fyne/v2"
@@ -119,10 +120,13 @@ func createMediaCreationContent(appCtxt *context.AppContext, mediaType string) *
 	// Metadata formItems will depend on the media_type
 	metadataForm, metadataEntryMap := createMetadataForm(appCtxt, mediaType)
 
+	// IDs on providers of the medium picked in online search, sent at creation
+	externalIDs := make(map[string]string)
+
 	// UI Buttons
 	// Get info online button ("linked" to ImagURL form)
 	buttonGetInfoOnline := widget.NewButtonWithIcon("Get Info Online\nfrom title", theme.DownloadIcon(), func() {
-		buttonFuncGetInfoOnline(appCtxt, mediaType, mediaForm, imageUrlForm, metadataForm, imageUrlEntry, titleEntry, mediumIdFromDB, metadataEntryMap)
+		buttonFuncGetInfoOnline(appCtxt, mediaType, mediaForm, imageUrlForm, metadataForm, imageUrlEntry, titleEntry, mediumIdFromDB, metadataEntryMap, externalIDs)
 	})
 	urlRow := container.NewBorder(nil, nil, nil, buttonGetInfoOnline, imageUrlForm)
 
@@ -135,7 +139,7 @@ func createMediaCreationContent(appCtxt *context.AppContext, mediaType string) *
 	})
 
 	submitButton := widget.NewButtonWithIcon("Submit", theme.ConfirmIcon(), func() {
-		buttonFuncSubmitCreateMedia(appCtxt, mediaTypeEntry, titleEntry, creatorEntry, pubDateEntry, imageUrlEntry, startDateEntry, endDateEntry, commentsEntry, mediumIdFromDB, metadataEntryMap)
+		buttonFuncSubmitCreateMedia(appCtxt, mediaTypeEntry, titleEntry, creatorEntry, pubDateEntry, imageUrlEntry, startDateEntry, endDateEntry, commentsEntry, mediumIdFromDB, metadataEntryMap, externalIDs)
 	})
 
 	// Group objects
@@ -150,31 +154,72 @@ func createMediaCreationContent(appCtxt *context.AppContext, mediaType string) *
 	return globalContainer
 }
 
-func buttonFuncGetInfoOnline(appCtxt *context.AppContext, mediaType string, mediaForm, imageUrlForm, metadataForm *widget.Form, imageUrlEntry, titleEntry, mediumIdFromDB *widget.Entry, metadataEntryMap map[string]*widget.Entry) {
-	// First, check if couple mediaTitle+mediaType already exists in server's DB
-	dbMedium, err := appCtxt.APIClient.Helpers.SearchMediumInDB(mediaType, titleEntry.Text)
-	if err == nil {
-		// Means we found medium in server's db
-		// Inform user
-		dialog.ShowInformation("Info", "This medium has been found on the server's database !\nYou can add your personal record info\nBut any update to medium's info will have no effects\nYou can do that later by going to your Shelf", appCtxt.MainWindow)
-		// Update data
-		updateFormWithSearchResult(dbMedium.Title, dbMedium.Creator, dbMedium.PubDate, mediaForm)
-		mediaForm.Refresh()
-		imageUrlEntry.SetText(dbMedium.ImageUrl)
-		imageUrlForm.Refresh()
-		updateMetadataForm(appCtxt, dbMedium.Metadata, metadataEntryMap)
-		metadataForm.Refresh()
-		// Disable fields modification (except for records)
-		mediaForm.Disable()
-		imageUrlForm.Disable()
-		metadataForm.Disable()
-		// Set the mediumIdFromDB variable with dbMedium's ID
-		mediumIdFromDB.SetText(dbMedium.ID)
+func buttonFuncGetInfoOnline(appCtxt *context.AppContext, mediaType string, mediaForm, imageUrlForm, metadataForm *widget.Form, imageUrlEntry, titleEntry, mediumIdFromDB *widget.Entry, metadataEntryMap map[string]*widget.Entry, externalIDs map[string]string) {
+	// First, check if some media with this title and type already exist in server's DB
+	dbMedia, err := appCtxt.APIClient.Helpers.SearchMediaInDB(mediaType, titleEntry.Text)
+	if err == nil && len(dbMedia) > 0 {
+		// Means we found media in server's db, let user pick the right one (several media can share a title)
+		showDBCandidatesDialog(appCtxt, dbMedia, func(dbMedium models.Medium) {
+			// Inform user
+			dialog.ShowInformation("Info", "This medium has been found on the server's database !\nYou can add your personal record info\nBut any update to medium's info will have no effects\nYou can do that later by going to your Shelf", appCtxt.MainWindow)
+			// Update data
+			updateFormWithSearchResult(dbMedium.Title, dbMedium.Creator, dbMedium.PubDate, mediaForm)
+			mediaForm.Refresh()
+			imageUrlEntry.SetText(dbMedium.ImageUrl)
+			imageUrlForm.Refresh()
+			updateMetadataForm(appCtxt, dbMedium.Metadata, metadataEntryMap)
+			metadataForm.Refresh()
+			// Disable fields modification (except for records)
+			mediaForm.Disable()
+			imageUrlForm.Disable()
+			metadataForm.Disable()
+			// Set the mediumIdFromDB variable with dbMedium's ID
+			mediumIdFromDB.SetText(dbMedium.ID)
+		}, func() {
+			// None of them is the right one, continue with online search
+			searchMediumOnline(appCtxt, mediaType, mediaForm, imageUrlForm, metadataForm, imageUrlEntry, titleEntry, metadataEntryMap, externalIDs)
+		})
 		return
 	}
 
-	// Otherwise, continue with online search
+	searchMediumOnline(appCtxt, mediaType, mediaForm, imageUrlForm, metadataForm, imageUrlEntry, titleEntry, metadataEntryMap, externalIDs)
+}
+
+// Let user choose among the server's media sharing the searched title, or none of them
+func showDBCandidatesDialog(appCtxt *context.AppContext, candidates []models.Medium, onPicked func(models.Medium), onNone func()) {
+	labels := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		// Numbered, as two labels must never be the same
+		labels[i] = fmt.Sprintf("%d. %s - %s (%s)", i+1, candidate.Title, candidate.Creator, candidate.PubDate)
+	}
+	choice := widget.NewRadioGroup(labels, nil)
+	choice.SetSelected(labels[0])
+
+	dialog.ShowCustomConfirm(
+		"Found on server",
+		"Use selected",
+		"None of these",
+		container.NewVBox(
+			widget.NewLabelWithStyle("Media with this title were found on the server's database\nWhich one do you want to add ?", fyne.TextAlignCenter, fyne.TextStyle{}),
+			choice,
+		),
+		func(b bool) {
+			if !b {
+				onNone()
+				return
+			}
+			for i, label := range labels {
+				if label == choice.Selected {
+					onPicked(candidates[i])
+					return
+				}
+			}
+		},
+		appCtxt.MainWindow,
+	)
+}
 
+func searchMediumOnline(appCtxt *context.AppContext, mediaType string, mediaForm, imageUrlForm, metadataForm *widget.Form, imageUrlEntry, titleEntry *widget.Entry, metadataEntryMap map[string]*widget.Entry, externalIDs map[string]string) {
 	// Book is a special media type, it's better to search by ISBN rather than by title
 	if mediaType == "book" {
 		// In case of book, ask for ISBN
@@ -206,6 +251,9 @@ func buttonFuncGetInfoOnline(appCtxt *context.AppContext, mediaType string, medi
 						imageUrlForm.Refresh()
 						updateMetadataForm(appCtxt, selectedMedium.Metadata, metadataEntryMap)
 						metadataForm.Refresh()
+						// Keep medium's IDs on provider
+						clear(externalIDs)
+						maps.Copy(externalIDs, selectedMedium.ExternalIDs)
 					})
 				} else {
 					if titleEntry.Text == "" {
@@ -221,6 +269,9 @@ func buttonFuncGetInfoOnline(appCtxt *context.AppContext, mediaType string, medi
 						imageUrlForm.Refresh()
 						updateMetadataForm(appCtxt, selectedMedium.Metadata, metadataEntryMap)
 						metadataForm.Refresh()
+						// Keep medium's IDs on provider
+						clear(externalIDs)
+						maps.Copy(externalIDs, selectedMedium.ExternalIDs)
 					})
 				}
 			},
@@ -244,11 +295,14 @@ func buttonFuncGetInfoOnline(appCtxt *context.AppContext, mediaType string, medi
 			imageUrlForm.Refresh()
 			updateMetadataForm(appCtxt, selectedMedium.Metadata, metadataEntryMap)
 			metadataForm.Refresh()
+			// Keep medium's IDs on provider
+			clear(externalIDs)
+			maps.Copy(externalIDs, selectedMedium.ExternalIDs)
 		})
 	}
 }
 
-func buttonFuncSubmitCreateMedia(appCtxt *context.AppContext, mediaTypeEntry, titleEntry, creatorEntry, pubDateEntry, imageUrlEntry, startDateEntry, endDateEntry, commentsEntry, mediumIdFromDB *widget.Entry, metadataEntryMap map[string]*widget.Entry) {
+func buttonFuncSubmitCreateMedia(appCtxt *context.AppContext, mediaTypeEntry, titleEntry, creatorEntry, pubDateEntry, imageUrlEntry, startDateEntry, endDateEntry, commentsEntry, mediumIdFromDB *widget.Entry, metadataEntryMap map[string]*widget.Entry, externalIDs map[string]string) {
 	// Confirm info dialog box
 	dialog.ShowCustomConfirm(
 		"Confirm",
@@ -314,6 +368,7 @@ func buttonFuncSubmitCreateMedia(appCtxt *context.AppContext, mediaTypeEntry, ti
 					endDateEntry.Text,
 					commentsEntry.Text,
 					metadataParsed,
+					externalIDs,
 				)
 				if err != nil {
 					switch err {
@@ -330,7 +385,7 @@ func buttonFuncSubmitCreateMedia(appCtxt *context.AppContext, mediaTypeEntry, ti
 					case models.ErrBadRequest:
 						dialog.ShowInformation("Error", "There is a problem with your request:\n- One field is missing in the form\nAND/OR\n- Start date is before end date\nPlease verify all fields", appCtxt.MainWindow)
 					case models.ErrConflict:
-						dialog.ShowInformation("Error", "A medium with the same couple title & media type already exists", appCtxt.MainWindow)
+						dialog.ShowInformation("Error", "A medium with the same title, creator and publication year already exists", appCtxt.MainWindow)
 					case models.ErrNotFound:
 						dialog.ShowInformation("Error", "Not found", appCtxt.MainWindow)
 					default:
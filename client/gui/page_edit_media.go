@@ -63,11 +63,19 @@ func createMediaEditingContent(appCtxt *context.AppContext, mediaType string, me
 		}, appCtxt.MainWindow)
 	})
 
+	// Refresh from source Button (review changes fetched from medium's provider before saving them)
+	buttonRefreshFromSource := widget.NewButtonWithIcon("Refresh from source", theme.ViewRefreshIcon(), func() {
+		buttonFuncRefreshFromSource(appCtxt, mediumWithRecord)
+	})
+
 	mediumIdFromDB := widget.NewEntry()
 
+	// IDs on providers of the medium picked in online search, replacing medium's ones on update
+	externalIDs := make(map[string]string)
+
 	// Get info online button (positionned right to ImagURL form)
 	buttonGetInfoOnline := widget.NewButtonWithIcon("Get Info Online\nfrom title", theme.DownloadIcon(), func() {
-		buttonFuncGetInfoOnline(appCtxt, mediaType, mediaForm, imageUrlForm, metadataForm, imageUrlEntry, titleEntry, mediumIdFromDB, metadataEntryMap)
+		buttonFuncGetInfoOnline(appCtxt, mediaType, mediaForm, imageUrlForm, metadataForm, imageUrlEntry, titleEntry, mediumIdFromDB, metadataEntryMap, externalIDs)
 	})
 	urlRow := container.NewBorder(nil, nil, nil, buttonGetInfoOnline, imageUrlForm)
 
@@ -80,7 +88,7 @@ func createMediaEditingContent(appCtxt *context.AppContext, mediaType string, me
 	})
 
 	submitButton := widget.NewButtonWithIcon("Update", theme.ConfirmIcon(), func() {
-		buttonFuncSubmitUpdate(appCtxt, mediumWithRecord, mediaTypeEntry, titleEntry, creatorEntry, pubDateEntry, imageUrlEntry, metadataEntryMap)
+		buttonFuncSubmitUpdate(appCtxt, mediumWithRecord, mediaTypeEntry, titleEntry, creatorEntry, pubDateEntry, imageUrlEntry, metadataEntryMap, externalIDs)
 	})
 
 	// Group objects
@@ -91,7 +99,7 @@ func createMediaEditingContent(appCtxt *context.AppContext, mediaType string, me
 
 	// Create the global frame
 	globalContainer := container.NewBorder(
-		container.NewBorder(nil, nil, nil, buttonUndoChanges, pageTitleText),
+		container.NewBorder(nil, nil, nil, container.NewHBox(buttonRefreshFromSource, buttonUndoChanges), pageTitleText),
 		exitButton,
 		nil, nil,
 		centralPart,
@@ -108,7 +116,7 @@ func setInitialValues(appCtxt *context.AppContext, titleEntry, creatorEntry, pub
 	updateMetadataForm(appCtxt, mediumWithRecord.Metadata, metadataEntryMap)
 }
 
-func buttonFuncSubmitUpdate(appCtxt *context.AppContext, mediumWithRecord models.MediumWithRecord, mediaTypeEntry, titleEntry, creatorEntry, pubDateEntry, imageUrlEntry *widget.Entry, metadataEntryMap map[string]*widget.Entry) {
+func buttonFuncSubmitUpdate(appCtxt *context.AppContext, mediumWithRecord models.MediumWithRecord, mediaTypeEntry, titleEntry, creatorEntry, pubDateEntry, imageUrlEntry *widget.Entry, metadataEntryMap map[string]*widget.Entry, externalIDs map[string]string) {
 	// Confirm info dialog box
 	dialog.ShowCustomConfirm(
 		"Confirm",
@@ -136,6 +144,7 @@ func buttonFuncSubmitUpdate(appCtxt *context.AppContext, mediumWithRecord models
 					pubDateEntry.Text,
 					imageUrlEntry.Text,
 					metadataParsed,
+					externalIDs,
 				)
 				if err != nil {
 					switch err {
@@ -152,9 +161,11 @@ func buttonFuncSubmitUpdate(appCtxt *context.AppContext, mediumWithRecord models
 					case models.ErrBadRequest:
 						dialog.ShowInformation("Error", "There is a problem with your request:\n- One field is missing in the form\nAND/OR\n- Start date is before end date\nPlease verify all fields", appCtxt.MainWindow)
 					case models.ErrConflict:
-						dialog.ShowInformation("Error", "A medium with the same couple title & media type already exists", appCtxt.MainWindow)
+						dialog.ShowInformation("Error", "A medium with the same title, creator and publication year,\nor with the same online source, already exists", appCtxt.MainWindow)
 					case models.ErrNotFound:
 						dialog.ShowInformation("Error", "Not found", appCtxt.MainWindow)
+					case models.ErrForbidden:
+						dialog.ShowInformation("Error", "Only the user who added this medium (or an admin) can edit it", appCtxt.MainWindow)
 					default:
 						dialog.ShowError(err, appCtxt.MainWindow)
 					}
@@ -167,3 +178,74 @@ func buttonFuncSubmitUpdate(appCtxt *context.AppContext, mediumWithRecord models
 		}, appCtxt.MainWindow,
 	)
 }
+
+func buttonFuncRefreshFromSource(appCtxt *context.AppContext, mediumWithRecord models.MediumWithRecord) {
+	// First, only ask server for changes
+	refresh, err := appCtxt.APIClient.Media.RefreshMedium(mediumWithRecord.MediaID, false)
+	if err != nil {
+		showRefreshMediumError(appCtxt, err)
+		return
+	}
+	if len(refresh.Changes) == 0 {
+		dialog.ShowInformation("Up to date", fmt.Sprintf("Medium's info are the same as on its source (%s)", refresh.Provider), appCtxt.MainWindow)
+		return
+	}
+
+	// List each change, so user can review them
+	changesBox := container.NewVBox()
+	for _, change := range refresh.Changes {
+		changeLabel := widget.NewLabel(fmt.Sprintf("Current: %v\nFetched: %v", change.Current, change.Fetched))
+		changeLabel.Wrapping = fyne.TextWrapWord
+		changesBox.Add(widget.NewLabelWithStyle(change.Field, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+		changesBox.Add(changeLabel)
+	}
+	changesScroll := container.NewVScroll(changesBox)
+	changesScroll.SetMinSize(fyne.NewSize(500, 300))
+
+	dialog.ShowCustomConfirm(
+		fmt.Sprintf("Changes from %s", refresh.Provider),
+		"Apply",
+		"Cancel",
+		changesScroll,
+		func(b bool) {
+			// If Confirmed, ask server to save changes
+			if !b {
+				return
+			}
+			_, err := appCtxt.APIClient.Media.RefreshMedium(mediumWithRecord.MediaID, true)
+			if err != nil {
+				showRefreshMediumError(appCtxt, err)
+				return
+			}
+			log.Println("--GUI-- RefreshMedium successful")
+			dialog.ShowInformation("Updated", "Media refresh successful !", appCtxt.MainWindow)
+			appCtxt.PageManager.ShowHomePage()
+		},
+		appCtxt.MainWindow,
+	)
+}
+
+func showRefreshMediumError(appCtxt *context.AppContext, err error) {
+	switch err {
+	case models.ErrUnauthorized:
+		if _, err2 := appCtxt.APIClient.Auth.RefreshTokens(); err2 != nil {
+			dialog.ShowConfirm("Authorization problem", "There is a problem with your authorization,\nyou'll be redirected to Login page", func(b bool) {
+				appCtxt.PageManager.ShowLoginPage()
+			}, appCtxt.MainWindow)
+		} else {
+			dialog.ShowInformation("Information", "Client needed to refresh your acess token\nSorry for the inconvenience\nPlease try again, it should work now !", appCtxt.MainWindow)
+		}
+	case models.ErrServerIssue:
+		dialog.ShowInformation("Error", "Error with server, please retry later", appCtxt.MainWindow)
+	case models.ErrBadRequest:
+		dialog.ShowInformation("Error", "This medium isn't linked to any online source\nUse 'Get Info Online' to pick one", appCtxt.MainWindow)
+	case models.ErrNotFound:
+		dialog.ShowInformation("Error", "Medium not found on its online source", appCtxt.MainWindow)
+	case models.ErrForbidden:
+		dialog.ShowInformation("Error", "Only the user who added this medium (or an admin) can edit it", appCtxt.MainWindow)
+	case models.ErrConflict:
+		dialog.ShowInformation("Error", "Fetched info clash with another medium on the server", appCtxt.MainWindow)
+	default:
+		dialog.ShowError(err, appCtxt.MainWindow)
+	}
+}
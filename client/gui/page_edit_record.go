@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"image/color"
 	"log"
+	"strconv"
+	"strings"
 	"time"
 
 	"fyne.io/fyne/v2"
@@ -117,7 +119,40 @@ func createEditRecordContent(appCtxt *context.AppContext, mediaType string, medi
 		commentsEntry.SetText(mediumWithRecord.Comments)
 	}
 
-	recordForm := widget.NewForm(startDateFormItem, endDateFormItem, commentsFormItem)
+	// Rating is picked among values of user's scale or typed, left empty if unrated
+	ratingEntry := widget.NewSelectEntry(appCtxt.APIClient.Helpers.GetRatingOptions())
+	ratingEntry.SetPlaceHolder("Not rated")
+	ratingFormItem := widget.NewFormItem(fmt.Sprintf("Rating (out of %s)", appCtxt.APIClient.Helpers.GetRatingScale()), ratingEntry)
+	ratingEntry.SetText(formatRatingEntry(appCtxt, mediumWithRecord.Rating))
+
+	// Status is picked among record's statuses, dates not matching the new status are cleared
+	abandonReasonEntry := widget.NewEntry()
+	abandonReasonEntry.SetPlaceHolder("Why did you give up ?")
+	abandonReasonEntry.SetText(mediumWithRecord.AbandonReason)
+	abandonReasonFormItem := widget.NewFormItem("Abandon reason", abandonReasonEntry)
+
+	statusOptions := make([]string, 0, len(recordStatuses))
+	for _, status := range recordStatuses {
+		statusOptions = append(statusOptions, recordStatusTitles[status])
+	}
+	statusSelect := widget.NewSelect(statusOptions, func(title string) {
+		switch recordStatusFromTitle(title) {
+		case "wishlist", "planned":
+			startDateEntry.SetText("")
+			endDateEntry.SetText("")
+		case "in_progress", "paused":
+			endDateEntry.SetText("")
+		}
+		if recordStatusFromTitle(title) == "abandoned" {
+			abandonReasonEntry.Enable()
+		} else {
+			abandonReasonEntry.Disable()
+		}
+	})
+	statusSelect.SetSelected(recordStatusTitles[mediumWithRecord.Status])
+	statusFormItem := widget.NewFormItem("Status", statusSelect)
+
+	recordForm := widget.NewForm(statusFormItem, startDateFormItem, endDateFormItem, abandonReasonFormItem, commentsFormItem, ratingFormItem)
 
 	// UI Buttons
 
@@ -140,6 +175,9 @@ func createEditRecordContent(appCtxt *context.AppContext, mediaType string, medi
 				}
 
 				commentsEntry.SetText(mediumWithRecord.Comments)
+				ratingEntry.SetText(formatRatingEntry(appCtxt, mediumWithRecord.Rating))
+				statusSelect.SetSelected(recordStatusTitles[mediumWithRecord.Status])
+				abandonReasonEntry.SetText(mediumWithRecord.AbandonReason)
 			}
 		}, appCtxt.MainWindow)
 	})
@@ -153,18 +191,26 @@ func createEditRecordContent(appCtxt *context.AppContext, mediaType string, medi
 	})
 
 	submitButton := widget.NewButtonWithIcon("Update", theme.ConfirmIcon(), func() {
-		buttonFuncSubmitEditRecord(appCtxt, mediumWithRecord, startDateEntry, endDateEntry, commentsEntry)
+		buttonFuncSubmitEditRecord(appCtxt, mediumWithRecord, statusSelect, startDateEntry, endDateEntry, abandonReasonEntry, commentsEntry, ratingEntry)
+	})
+
+	reviewButton := widget.NewButtonWithIcon("Review", theme.DocumentCreateIcon(), func() {
+		appCtxt.PageManager.ShowEditReviewPage(mediaType, mediumWithRecord)
 	})
 
 	// Group objects
 	groupForms := container.NewVBox(widget.NewSeparator(), recordForm, widget.NewSeparator())
+	if progressSection := createRecordProgressSection(appCtxt, mediaType, mediumWithRecord, commentsEntry); progressSection != nil {
+		groupForms.Add(progressSection)
+		groupForms.Add(widget.NewSeparator())
+	}
 	submitRow := container.NewBorder(nil, nil, customSpacerHorizontal(20), customSpacerHorizontal(20), submitButton)
 	statusRow := container.NewHBox(layout.NewSpacer(), statusLabel, layout.NewSpacer())
 	centralPart := container.NewVBox(groupForms, statusRow, submitRow)
 
 	// Create the global frame
 	globalContainer := container.NewBorder(
-		container.NewBorder(nil, nil, nil, buttonUndoChanges, pageTitleText),
+		container.NewBorder(nil, nil, reviewButton, buttonUndoChanges, pageTitleText),
 		exitButton,
 		nil, nil,
 		centralPart,
@@ -173,16 +219,38 @@ func createEditRecordContent(appCtxt *context.AppContext, mediaType string, medi
 	return globalContainer
 }
 
-func buttonFuncSubmitEditRecord(appCtxt *context.AppContext, mediumWithRecord models.MediumWithRecord, startDateEntry, endDateEntry, commentsEntry *widget.Entry) {
+func buttonFuncSubmitEditRecord(appCtxt *context.AppContext, mediumWithRecord models.MediumWithRecord, statusSelect *widget.Select, startDateEntry, endDateEntry, abandonReasonEntry, commentsEntry *widget.Entry, ratingEntry *widget.SelectEntry) {
+	// Check rating before asking confirmation, an empty one removes it
+	var rating *float64
+	ratingText := "Not rated"
+	if text := strings.TrimSpace(ratingEntry.Text); text != "" {
+		value, err := strconv.ParseFloat(strings.Replace(text, ",", ".", 1), 64)
+		if err != nil {
+			dialog.ShowInformation("Error", "Rating must be a number", appCtxt.MainWindow)
+			return
+		}
+		rating = &value
+		ratingText = fmt.Sprintf("%s/%s", text, appCtxt.APIClient.Helpers.GetRatingScale())
+	}
+
+	// Abandon reason is only sent for abandoned records
+	status := recordStatusFromTitle(statusSelect.Selected)
+	abandonReason := ""
+	if status == "abandoned" {
+		abandonReason = abandonReasonEntry.Text
+	}
+
 	// Confirm info dialog box
 	dialog.ShowCustomConfirm(
 		"Confirm",
 		"Create",
 		"Cancel",
 		container.NewVBox(
+			widget.NewLabelWithStyle(fmt.Sprintf("Status: %s", statusSelect.Selected), fyne.TextAlignLeading, fyne.TextStyle{}),
 			widget.NewLabelWithStyle(fmt.Sprintf("Start Date: %s", startDateEntry.Text), fyne.TextAlignLeading, fyne.TextStyle{}),
 			widget.NewLabelWithStyle(fmt.Sprintf("End Date: %s", endDateEntry.Text), fyne.TextAlignLeading, fyne.TextStyle{}),
 			widget.NewLabelWithStyle(fmt.Sprintf("Comments: %s", commentsEntry.Text), fyne.TextAlignLeading, fyne.TextStyle{}),
+			widget.NewLabelWithStyle(fmt.Sprintf("Rating: %s", ratingText), fyne.TextAlignLeading, fyne.TextStyle{}),
 		),
 		func(b bool) {
 			// If Confirmed. call the UpdateRecord client API function
@@ -192,6 +260,9 @@ func buttonFuncSubmitEditRecord(appCtxt *context.AppContext, mediumWithRecord mo
 					startDateEntry.Text,
 					endDateEntry.Text,
 					commentsEntry.Text,
+					status,
+					abandonReason,
+					rating,
 				)
 				if err != nil {
 					switch err {
@@ -206,7 +277,7 @@ func buttonFuncSubmitEditRecord(appCtxt *context.AppContext, mediumWithRecord mo
 					case models.ErrServerIssue:
 						dialog.ShowInformation("Error", "Error with server, please retry later", appCtxt.MainWindow)
 					case models.ErrBadRequest:
-						dialog.ShowInformation("Error", "There is a problem with your request:\n- One field is missing in the form\nAND/OR\n- Start date is before end date\nPlease verify all fields", appCtxt.MainWindow)
+						dialog.ShowInformation("Error", fmt.Sprintf("There is a problem with your request:\n- One field is missing in the form\nAND/OR\n- Start date is before end date\nAND/OR\n- Status can't be reached from current one or doesn't match dates\nAND/OR\n- Rating isn't one of %s scale's values\nPlease verify all fields", appCtxt.APIClient.Helpers.GetRatingScale()), appCtxt.MainWindow)
 					case models.ErrConflict:
 						dialog.ShowInformation("Error", "A medium with the same couple title & media type already exists", appCtxt.MainWindow)
 					case models.ErrNotFound:
@@ -223,3 +294,203 @@ func buttonFuncSubmitEditRecord(appCtxt *context.AppContext, mediumWithRecord mo
 		}, appCtxt.MainWindow,
 	)
 }
+
+// Status matching a title of status select, empty if none is selected
+func recordStatusFromTitle(title string) string {
+	for status, statusTitle := range recordStatusTitles {
+		if statusTitle == title {
+			return status
+		}
+	}
+	return ""
+}
+
+// Record's rating on user's scale, as displayed in rating entry
+func formatRatingEntry(appCtxt *context.AppContext, rating *int16) string {
+	if rating == nil {
+		return ""
+	}
+	return strconv.FormatFloat(appCtxt.APIClient.Helpers.RatingToScale(float64(*rating)), 'f', -1, 64)
+}
+
+// Progress fields user can log, by media type
+// Series follow their watched episodes, videogames' hours played are part of their play details
+var progressFieldsByMediaType = map[string][]string{
+	"book":      {"Pages read", "Percent read"},
+	"videogame": {"Completion percent"},
+}
+
+// Create the section to log progress of a record and follow its curve, nil if progress isn't tracked for media type
+func createRecordProgressSection(appCtxt *context.AppContext, mediaType string, mediumWithRecord models.MediumWithRecord, commentsEntry *widget.Entry) fyne.CanvasObject {
+	fields, ok := progressFieldsByMediaType[mediaType]
+	if !ok {
+		return nil
+	}
+
+	sectionTitle := widget.NewLabelWithStyle("Progress", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	summaryLabel := widget.NewLabel("")
+	chartContainer := container.NewStack()
+
+	// Reload the curve from server
+	refreshCurve := func() {
+		curve, err := appCtxt.APIClient.Records.GetRecordProgress(mediumWithRecord.ID)
+		if err != nil {
+			summaryLabel.SetText("Couldn't get progress from server")
+			return
+		}
+		summaryLabel.SetText(formatProgressSummary(appCtxt, curve))
+		chartContainer.Objects = []fyne.CanvasObject{createProgressChart(curve)}
+		chartContainer.Refresh()
+	}
+
+	// One entry per progress field of the media type
+	entries := make(map[string]*widget.Entry, len(fields))
+	progressForm := widget.NewForm()
+	for _, field := range fields {
+		entry := widget.NewEntry()
+		entries[field] = entry
+		progressForm.Append(field, entry)
+	}
+
+	logButton := widget.NewButtonWithIcon("Log progress", theme.ContentAddIcon(), func() {
+		update, err := parseProgressUpdate(entries)
+		if err != nil {
+			dialog.ShowInformation("Error", err.Error(), appCtxt.MainWindow)
+			return
+		}
+		_, err = appCtxt.APIClient.Records.LogRecordProgress(mediumWithRecord.ID, commentsEntry.Text, update)
+		switch err {
+		case nil:
+			for _, entry := range entries {
+				entry.SetText("")
+			}
+			refreshCurve()
+		case models.ErrBadRequest:
+			dialog.ShowInformation("Error", "This progress can't be logged:\nvalues must match the medium (e.g. not more pages than the book has)", appCtxt.MainWindow)
+		default:
+			dialog.ShowError(err, appCtxt.MainWindow)
+		}
+	})
+
+	refreshCurve()
+
+	return container.NewVBox(
+		sectionTitle,
+		progressForm,
+		container.NewHBox(layout.NewSpacer(), logButton),
+		summaryLabel,
+		container.NewCenter(chartContainer),
+	)
+}
+
+// Helper function to read progress entries, empty ones are left out
+func parseProgressUpdate(entries map[string]*widget.Entry) (models.ProgressUpdate, error) {
+	update := models.ProgressUpdate{}
+	count := 0
+	for field, entry := range entries {
+		text := strings.TrimSpace(entry.Text)
+		if text == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(strings.Replace(text, ",", ".", 1), 64)
+		if err != nil || value < 0 {
+			return models.ProgressUpdate{}, fmt.Errorf("%s must be a positive number", field)
+		}
+		count++
+		switch field {
+		case "Pages read":
+			pages := int32(value)
+			update.Pages = &pages
+		case "Percent read", "Completion percent":
+			update.Percent = &value
+		}
+	}
+	if count == 0 {
+		return models.ProgressUpdate{}, fmt.Errorf("fill at least one progress field")
+	}
+	return update, nil
+}
+
+// Helper function to describe latest progress, pace and estimated finish date
+func formatProgressSummary(appCtxt *context.AppContext, curve models.RecordProgressCurve) string {
+	if len(curve.Progress) == 0 {
+		return "No progress logged yet"
+	}
+	latest := curve.Progress[len(curve.Progress)-1]
+
+	var details []string
+	if latest.Pages != nil {
+		details = append(details, formatProgressCount(*latest.Pages, latest.Total, "pages"))
+	}
+	if latest.Completion != nil {
+		details = append(details, fmt.Sprintf("%.0f%%", *latest.Completion))
+	}
+	loggedAt, err := appCtxt.APIClient.Helpers.FormatDateToLocalFormat(latest.LoggedAt)
+	if err != nil {
+		loggedAt = latest.LoggedAt
+	}
+	summary := fmt.Sprintf("Latest: %s on %s", strings.Join(details, ", "), loggedAt)
+
+	if curve.Pace > 0 {
+		summary += fmt.Sprintf("\nPace: %.1f%% per day", curve.Pace)
+	}
+	if curve.EstimatedFinishDate != "" {
+		estimate, err := appCtxt.APIClient.Helpers.FormatDateToLocalFormat(curve.EstimatedFinishDate)
+		if err == nil {
+			summary += fmt.Sprintf("\nEstimated finish: %s", estimate)
+		}
+	}
+	return summary
+}
+
+func formatProgressCount(count int32, total *int32, unit string) string {
+	if total == nil {
+		return fmt.Sprintf("%d %s", count, unit)
+	}
+	return fmt.Sprintf("%d/%d %s", count, *total, unit)
+}
+
+// Draw completion over time, from first to latest progress update
+func createProgressChart(curve models.RecordProgressCurve) fyne.CanvasObject {
+	const width, height = 300, 100
+	chartSize := fyne.NewSize(width, height)
+
+	type point struct {
+		at         time.Time
+		completion float64
+	}
+	var points []point
+	for _, progress := range curve.Progress {
+		at, err := time.Parse("2006-01-02T15:04:05", progress.LoggedAt)
+		if err != nil || progress.Completion == nil {
+			continue
+		}
+		points = append(points, point{at: at, completion: *progress.Completion})
+	}
+	if len(points) < 2 {
+		return widget.NewLabel("Not enough progress to draw a curve")
+	}
+
+	background := canvas.NewRectangle(color.RGBA{R: 40, G: 40, B: 40, A: 255})
+	background.Resize(chartSize)
+	objects := []fyne.CanvasObject{background}
+
+	first, last := points[0].at, points[len(points)-1].at
+	span := last.Sub(first).Seconds()
+	position := func(p point) fyne.Position {
+		x := float32(0)
+		if span > 0 {
+			x = float32(p.at.Sub(first).Seconds() / span * width)
+		}
+		return fyne.NewPos(x, float32(height-p.completion/100*height))
+	}
+	for i := 1; i < len(points); i++ {
+		line := canvas.NewLine(color.RGBA{R: 128, G: 0, B: 128, A: 255})
+		line.StrokeWidth = 2
+		line.Position1 = position(points[i-1])
+		line.Position2 = position(points[i])
+		objects = append(objects, line)
+	}
+
+	return container.NewGridWrap(chartSize, container.NewWithoutLayout(objects...))
+}
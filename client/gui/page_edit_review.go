@@ -0,0 +1,179 @@
+package gui
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/VincNT21/kallaxy/client/context"
+	"github.com/VincNT21/kallaxy/client/models"
+)
+
+func createEditReviewContent(appCtxt *context.AppContext, mediaType string, mediumWithRecord models.MediumWithRecord) *fyne.Container {
+	// Get record's review, if there is one already
+	review, err := appCtxt.APIClient.Reviews.GetReview(mediumWithRecord.ID)
+	isNew := err == models.ErrNotFound
+	if err != nil && !isNew {
+		dialog.ShowError(err, appCtxt.MainWindow)
+	}
+
+	// Create UI objects
+	// Texts
+	pageTitleText := canvas.NewText(fmt.Sprintf("%s's review of %s (%s)", appCtxt.APIClient.CurrentUser.Username, mediumWithRecord.Title, mediaType), color.White)
+	pageTitleText.TextSize = 20
+	pageTitleText.Alignment = fyne.TextAlignCenter
+	pageTitleText.TextStyle.Bold = true
+
+	// Review's forms
+	titleEntry := widget.NewEntry()
+	titleEntry.SetPlaceHolder("A slow but rewarding read")
+	titleEntry.SetText(review.Title)
+
+	bodyEntry := widget.NewMultiLineEntry()
+	bodyEntry.Wrapping = fyne.TextWrapWord
+	bodyEntry.SetPlaceHolder("Markdown is supported\n\n:::spoiler the ending\nHidden to other users unless they ask\n:::")
+	bodyEntry.SetMinRowsVisible(15)
+	bodyEntry.SetText(review.Body)
+
+	publishedCheck := widget.NewCheck("Published (visible to other users)", nil)
+	publishedCheck.SetChecked(review.IsPublished)
+
+	// Live preview of the body, spoilers being shown as quotes
+	preview := widget.NewRichTextFromMarkdown(reviewPreviewMarkdown(review.Body))
+	preview.Wrapping = fyne.TextWrapWord
+	bodyEntry.OnChanged = func(text string) {
+		preview.ParseMarkdown(reviewPreviewMarkdown(text))
+	}
+
+	revisionLabel := widget.NewLabel("Not saved yet")
+	if !isNew {
+		revisionLabel.SetText(fmt.Sprintf("Revision %d", review.Revision))
+	}
+
+	// UI Buttons
+	backButton := widget.NewButtonWithIcon("Back to record", theme.NavigateBackIcon(), func() {
+		appCtxt.PageManager.ShowUpdateRecordPage(mediaType, mediumWithRecord.MediaID, []models.MediumWithRecord{mediumWithRecord})
+	})
+
+	revisionsButton := widget.NewButtonWithIcon("Revisions", theme.HistoryIcon(), func() {
+		buttonFuncShowReviewRevisions(appCtxt, mediumWithRecord.ID)
+	})
+
+	deleteButton := widget.NewButtonWithIcon("Delete", theme.DeleteIcon(), func() {
+		dialog.ShowConfirm("Confirm", "Are you sure you want to delete this review ?\nAll its revisions will be lost", func(b bool) {
+			if !b {
+				return
+			}
+			if err := appCtxt.APIClient.Reviews.DeleteReview(mediumWithRecord.ID); err != nil {
+				dialog.ShowError(err, appCtxt.MainWindow)
+				return
+			}
+			appCtxt.PageManager.ShowUpdateRecordPage(mediaType, mediumWithRecord.MediaID, []models.MediumWithRecord{mediumWithRecord})
+		}, appCtxt.MainWindow)
+	})
+	if isNew {
+		revisionsButton.Disable()
+		deleteButton.Disable()
+	}
+
+	submitButton := widget.NewButtonWithIcon("Save", theme.DocumentSaveIcon(), func() {
+		var err error
+		if isNew {
+			_, err = appCtxt.APIClient.Reviews.CreateReview(mediumWithRecord.ID, titleEntry.Text, bodyEntry.Text, publishedCheck.Checked)
+		} else {
+			_, err = appCtxt.APIClient.Reviews.UpdateReview(mediumWithRecord.ID, titleEntry.Text, bodyEntry.Text, publishedCheck.Checked)
+		}
+		switch err {
+		case nil:
+			log.Println("--GUI-- Review saved")
+			appCtxt.PageManager.ShowEditReviewPage(mediaType, mediumWithRecord)
+		case models.ErrBadRequest:
+			dialog.ShowInformation("Info", "There is a problem with your review:\n- It needs a title\nAND/OR\n- A published review needs a body\nAND/OR\n- Its body is too long", appCtxt.MainWindow)
+		case models.ErrConflict:
+			dialog.ShowInformation("Info", "This record already has a review", appCtxt.MainWindow)
+		default:
+			dialog.ShowError(err, appCtxt.MainWindow)
+		}
+	})
+
+	// Group objects
+	reviewForm := widget.NewForm(
+		widget.NewFormItem("Title", titleEntry),
+		widget.NewFormItem("Review", bodyEntry),
+		widget.NewFormItem("", publishedCheck),
+	)
+	previewPart := container.NewBorder(
+		widget.NewLabelWithStyle("Preview", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		nil, nil, nil,
+		container.NewVScroll(preview),
+	)
+	centralPart := container.NewGridWithColumns(2, reviewForm, previewPart)
+	bottomRow := container.NewBorder(nil, nil, backButton, container.NewHBox(revisionLabel, revisionsButton, deleteButton, submitButton))
+
+	// Create the global frame
+	globalContainer := container.NewBorder(
+		pageTitleText,
+		bottomRow,
+		nil, nil,
+		centralPart,
+	)
+
+	return globalContainer
+}
+
+// Button function
+func buttonFuncShowReviewRevisions(appCtxt *context.AppContext, recordID string) {
+	revisions, err := appCtxt.APIClient.Reviews.GetReviewRevisions(recordID)
+	if err != nil {
+		dialog.ShowError(err, appCtxt.MainWindow)
+		return
+	}
+
+	// Last revision first, each one with its changes from the previous one
+	items := container.NewVBox()
+	for i := len(revisions.Revisions) - 1; i >= 0; i-- {
+		revision := revisions.Revisions[i]
+		when, err := appCtxt.APIClient.Helpers.FormatDateToLocalFormat(revision.CreatedAt)
+		if err != nil {
+			when = revision.CreatedAt
+		}
+		diffLabel := widget.NewLabelWithStyle(revision.Diff, fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+		diffLabel.Wrapping = fyne.TextWrapWord
+		items.Add(widget.NewAccordion(widget.NewAccordionItem(fmt.Sprintf("Revision %d - %s", revision.Revision, when), diffLabel)))
+	}
+
+	scroll := container.NewVScroll(items)
+	scroll.SetMinSize(fyne.NewSize(700, 500))
+	dialog.ShowCustom("Review's revisions", "Close", scroll, appCtxt.MainWindow)
+}
+
+// Markdown shown in review's preview
+// Spoiler blocks, kept as is by Markdown, are turned into quotes headed with their label
+func reviewPreviewMarkdown(body string) string {
+	var lines []string
+	inSpoiler := false
+	for _, line := range strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case !inSpoiler && strings.HasPrefix(strings.ToLower(trimmed), ":::spoiler"):
+			inSpoiler = true
+			label := strings.TrimSpace(trimmed[len(":::spoiler"):])
+			lines = append(lines, "", strings.TrimSpace(fmt.Sprintf("> **Spoiler** %s", label)), ">")
+		case inSpoiler && trimmed == ":::":
+			inSpoiler = false
+			lines = append(lines, "")
+		case inSpoiler:
+			lines = append(lines, "> "+line)
+		default:
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -1,8 +1,12 @@
 package gui
 
 import (
+	"fmt"
 	"image/color"
 	"log"
+	"slices"
+	"strconv"
+	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
@@ -62,16 +66,217 @@ func createHomepageContent(appCtxt *context.AppContext) *fyne.Container {
 		customSpacerHorizontal(100),
 		centralbuttonsRow,
 	)
+	goalsRow := container.NewBorder(
+		nil,
+		nil,
+		customSpacerHorizontal(100),
+		customSpacerHorizontal(100),
+		createGoalsPanel(appCtxt),
+	)
 	exitButtons := container.NewVBox(logoutButton, exitButton)
 	bottomRow := container.NewHBox(manageButton, layout.NewSpacer(), exitButtons)
 
 	// Set the global frame container
-	globalContainer := container.NewVBox(layout.NewSpacer(), titleText, usernameText, layout.NewSpacer(), centralRow, layout.NewSpacer(), bottomRow)
+	globalContainer := container.NewVBox(layout.NewSpacer(), titleText, usernameText, layout.NewSpacer(), centralRow, layout.NewSpacer(), goalsRow, layout.NewSpacer(), bottomRow)
 
 	// Return global container
 	return globalContainer
 }
 
+// Panel with user's current goals, their progress and pace, and user's activity streak
+func createGoalsPanel(appCtxt *context.AppContext) fyne.CanvasObject {
+	titleLabel := widget.NewLabelWithStyle("My current goals", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	addGoalButton := widget.NewButtonWithIcon("Add goal", theme.ContentAddIcon(), func() {
+		buttonFuncAddGoal(appCtxt)
+	})
+	topRow := container.NewHBox(titleLabel, layout.NewSpacer(), addGoalButton)
+
+	goals, err := appCtxt.APIClient.Goals.GetGoals(true)
+	if err != nil {
+		dialog.ShowError(err, appCtxt.MainWindow)
+		return container.NewVBox(topRow, widget.NewLabel("Error while getting your goals"))
+	}
+	streak, err := appCtxt.APIClient.Goals.GetStreak("day", "")
+	if err != nil {
+		dialog.ShowError(err, appCtxt.MainWindow)
+	}
+
+	panel := container.NewVBox(topRow, widget.NewLabel(formatStreak(streak)))
+	if len(goals.Goals) == 0 {
+		panel.Add(widget.NewLabel("No goal for now, set one: 20 books this year, 100 hours of videogames..."))
+		return panel
+	}
+
+	// One row per goal, with a bar of its progress towards its target
+	goalsGrid := container.NewGridWithColumns(4)
+	for _, goal := range goals.Goals {
+		progressBar := widget.NewProgressBar()
+		progressBar.Max = float64(goal.Target)
+		progressBar.SetValue(min(goal.Progress, float64(goal.Target)))
+		progressBar.TextFormatter = func() string {
+			return fmt.Sprintf("%s / %d", formatGoalNumber(goal.Progress), goal.Target)
+		}
+		deleteButton := widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+			buttonFuncDeleteGoal(appCtxt, goal)
+		})
+		goalsGrid.Add(widget.NewLabel(formatGoalTitle(goal)))
+		goalsGrid.Add(progressBar)
+		goalsGrid.Add(widget.NewLabel(formatGoalPace(appCtxt, goal)))
+		goalsGrid.Add(container.NewHBox(layout.NewSpacer(), deleteButton))
+	}
+	panel.Add(goalsGrid)
+
+	return panel
+}
+
+// Metrics a goal can track, with what they're shown as
+var goalMetricLabels = map[string]string{
+	"count": "Finished media",
+	"pages": "Pages (books)",
+	"hours": "Hours (movies, videogames)",
+}
+
+// Goal's name, or what it's about if it has none
+func formatGoalTitle(goal models.Goal) string {
+	if goal.Name != "" {
+		return goal.Name
+	}
+	switch goal.Metric {
+	case "pages":
+		return fmt.Sprintf("%d pages of %s this %s", goal.Target, goal.MediaType, goal.Period)
+	case "hours":
+		return fmt.Sprintf("%d hours of %s this %s", goal.Target, goal.MediaType, goal.Period)
+	}
+	return fmt.Sprintf("%d %s to finish this %s", goal.Target, goal.MediaType, goal.Period)
+}
+
+// Format a goal's progress without useless decimals
+func formatGoalNumber(number float64) string {
+	return strconv.FormatFloat(number, 'f', -1, 64)
+}
+
+// Tell how far ahead or behind of a steady pace a goal is
+func formatGoalPace(appCtxt *context.AppContext, goal models.Goal) string {
+	switch goal.Status {
+	case "completed":
+		return "Completed !"
+	case "missed":
+		return "Missed"
+	case "upcoming":
+		start, err := appCtxt.APIClient.Helpers.FormatDateToLocalFormat(goal.PeriodStart)
+		if err != nil {
+			return "Not started yet"
+		}
+		return fmt.Sprintf("Starts on %s", start)
+	case "ahead":
+		return fmt.Sprintf("%s ahead of pace", formatGoalNumber(goal.Pace))
+	}
+	return fmt.Sprintf("%s behind pace", formatGoalNumber(-goal.Pace))
+}
+
+// Tell user's current streak of days with activity, and the longest one
+func formatStreak(streak models.Streak) string {
+	switch {
+	case streak.Longest == 0:
+		return "No activity yet, start or finish a medium to begin a streak"
+	case streak.Current == 0:
+		return fmt.Sprintf("No streak running, your longest was %d day(s)", streak.Longest)
+	case !streak.ActiveNow:
+		return fmt.Sprintf("%d day(s) streak, keep it going today ! (longest: %d)", streak.Current, streak.Longest)
+	}
+	return fmt.Sprintf("%d day(s) streak (longest: %d)", streak.Current, streak.Longest)
+}
+
+// Button function
+func buttonFuncAddGoal(appCtxt *context.AppContext) {
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder("Optional")
+	mediaTypeEntry := widget.NewSelectEntry([]string{"book", "movie", "series", "videogame", "boardgame"})
+	mediaTypeEntry.SetText("book")
+
+	metricOptions := []string{goalMetricLabels["count"], goalMetricLabels["pages"], goalMetricLabels["hours"]}
+	metricSelect := widget.NewSelect(metricOptions, nil)
+	metricSelect.SetSelected(goalMetricLabels["count"])
+	targetEntry := widget.NewEntry()
+	targetEntry.SetPlaceHolder("20")
+	periodSelect := widget.NewSelect([]string{"week", "month", "year"}, nil)
+	periodSelect.SetSelected("year")
+
+	// Goal only counts media having every checked tag, tags just aren't offered if they can't be fetched
+	tags, err := appCtxt.APIClient.Tags.GetTags()
+	if err != nil {
+		log.Printf("--GUI-- Couldn't get user's tags for goal: %v", err)
+	}
+	tagNames := []string{}
+	for _, tag := range tags.Tags {
+		tagNames = append(tagNames, tag.Name)
+	}
+	tagsCheck := widget.NewCheckGroup(tagNames, nil)
+	tagsCheck.Horizontal = true
+
+	formItems := []*widget.FormItem{
+		widget.NewFormItem("Name", nameEntry),
+		widget.NewFormItem("Media type", mediaTypeEntry),
+		widget.NewFormItem("Track", metricSelect),
+		widget.NewFormItem("Target", targetEntry),
+		widget.NewFormItem("This", periodSelect),
+	}
+	if len(tagNames) > 0 {
+		formItems = append(formItems, widget.NewFormItem("Only tagged", tagsCheck))
+	}
+
+	dialog.ShowForm("New goal", "Create", "Cancel", formItems, func(b bool) {
+		if !b {
+			return
+		}
+		// An invalid target is sent as 0, for the server to reject it
+		target, _ := strconv.Atoi(strings.TrimSpace(targetEntry.Text))
+		details := models.GoalDetails{
+			Name:      nameEntry.Text,
+			MediaType: mediaTypeEntry.Text,
+			Target:    target,
+			Period:    periodSelect.Selected,
+			TagIDs:    []string{},
+		}
+		for metric, label := range goalMetricLabels {
+			if label == metricSelect.Selected {
+				details.Metric = metric
+			}
+		}
+		for _, tag := range tags.Tags {
+			if slices.Contains(tagsCheck.Selected, tag.Name) {
+				details.TagIDs = append(details.TagIDs, tag.ID)
+			}
+		}
+
+		_, err := appCtxt.APIClient.Goals.CreateGoal(details)
+		switch err {
+		case nil:
+			appCtxt.PageManager.ShowHomePage()
+		case models.ErrBadRequest:
+			dialog.ShowInformation("Info", "There is a problem with your goal:\n- It needs a media type and a positive target\nAND/OR\n- Pages can only be tracked for books, hours for movies and videogames", appCtxt.MainWindow)
+		case models.ErrNotFound:
+			dialog.ShowInformation("Info", "One of the checked tags doesn't exist anymore", appCtxt.MainWindow)
+		default:
+			dialog.ShowError(err, appCtxt.MainWindow)
+		}
+	}, appCtxt.MainWindow)
+}
+
+// Button function
+func buttonFuncDeleteGoal(appCtxt *context.AppContext, goal models.Goal) {
+	dialog.ShowConfirm("Confirm", fmt.Sprintf("Are you sure you want to delete the goal \"%s\" ?", formatGoalTitle(goal)), func(b bool) {
+		if !b {
+			return
+		}
+		if err := appCtxt.APIClient.Goals.DeleteGoal(goal.ID); err != nil {
+			dialog.ShowError(err, appCtxt.MainWindow)
+			return
+		}
+		appCtxt.PageManager.ShowHomePage()
+	}, appCtxt.MainWindow)
+}
+
 func buttonFuncMediaTypeChoice(appCtxt *context.AppContext) {
 	// Create a custom dialog box to choose media_type
 	var mediaTypeDialog dialog.Dialog
@@ -89,6 +89,9 @@ func buttonFuncLogin(appCtxt *context.AppContext, usernameEntry, passwordEntry *
 		case models.ErrUnauthorized:
 			statusLabel.SetText("Bad username/password")
 			statusLabel.Refresh()
+		case models.ErrForbidden:
+			statusLabel.SetText("This account has been deactivated, please contact an admin")
+			statusLabel.Refresh()
 		case models.ErrServerIssue:
 			statusLabel.SetText("Error with server, please retry later")
 			statusLabel.Refresh()
@@ -12,6 +12,7 @@ import (
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 	"github.com/VincNT21/kallaxy/client/context"
+	kallaxyapi "github.com/VincNT21/kallaxy/client/internal/kallaxyAPI"
 	"github.com/VincNT21/kallaxy/client/models"
 )
 
@@ -63,8 +64,21 @@ func createParametersContent(appCtxt *context.AppContext) *fyne.Container {
 		buttonFuncDeleteUser(appCtxt)
 	})
 
+	// Rating scale, saved locally with app's state
+	ratingScaleLabels := map[string]string{"5": "5 stars (by halves)", "10": "Out of 10", "100": "Out of 100"}
+	ratingScaleSelect := widget.NewSelect([]string{ratingScaleLabels["5"], ratingScaleLabels["10"], ratingScaleLabels["100"]}, func(selected string) {
+		for _, scale := range kallaxyapi.RatingScales {
+			if ratingScaleLabels[scale] == selected && scale != appCtxt.APIClient.RatingScale {
+				appCtxt.APIClient.RatingScale = scale
+				appCtxt.SaveAppstate()
+			}
+		}
+	})
+	ratingScaleSelect.SetSelected(ratingScaleLabels[appCtxt.APIClient.Helpers.GetRatingScale()])
+	ratingScaleRow := container.NewHBox(widget.NewLabel("Rate media on:"), ratingScaleSelect)
+
 	// Group objects
-	textColumn := container.NewVBox(layout.NewSpacer(), clientVersion, serverVersion, usernameLabel, emailLabel, layout.NewSpacer(), statusLabel, updateButton, customSpacerVertical(100), deleteUserButton, layout.NewSpacer())
+	textColumn := container.NewVBox(layout.NewSpacer(), clientVersion, serverVersion, usernameLabel, emailLabel, layout.NewSpacer(), statusLabel, updateButton, ratingScaleRow, customSpacerVertical(100), deleteUserButton, layout.NewSpacer())
 	centerRow := container.NewHBox(layout.NewSpacer(), textColumn, layout.NewSpacer())
 
 	// Create the global frame
@@ -3,11 +3,13 @@ package gui
 import (
 	"fmt"
 	"image/color"
+	"log"
 	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
@@ -27,6 +29,15 @@ func createShelfContent(appCtxt *context.AppContext, mediaRecords models.MediaWi
 	exitButton := widget.NewButtonWithIcon("Homepage", theme.HomeIcon(), func() {
 		appCtxt.PageManager.ShowHomePage()
 	})
+	newShelfButton := widget.NewButtonWithIcon("New custom shelf", theme.ContentAddIcon(), func() {
+		buttonFuncNewShelf(appCtxt)
+	})
+	shelvingUnitsButton := widget.NewButtonWithIcon("Shelving units", theme.GridIcon(), func() {
+		appCtxt.PageManager.ShowShelvingUnitsPage("")
+	})
+	videogameBacklogButton := widget.NewButtonWithIcon("Videogame backlog", theme.ListIcon(), func() {
+		appCtxt.PageManager.ShowVideogameBacklogPage("")
+	})
 
 	// Create the Shelf
 	shelfContainer, err := buildMediaContainers(appCtxt, mediaRecords)
@@ -49,9 +60,9 @@ func createShelfContent(appCtxt *context.AppContext, mediaRecords models.MediaWi
 	// Create the global frame
 	globalContainer := container.NewBorder(
 		pageTitleText, // Top
-		container.NewHBox(layout.NewSpacer(), exitButton), // Bottom
-		customSpacerHorizontal(50),                        // Left
-		customSpacerHorizontal(50),                        // Right
+		container.NewHBox(newShelfButton, shelvingUnitsButton, videogameBacklogButton, layout.NewSpacer(), exitButton), // Bottom
+		customSpacerHorizontal(50), // Left
+		customSpacerHorizontal(50), // Right
 		shelfContainer,
 	)
 
@@ -65,6 +76,9 @@ func buildMediaContainers(appCtxt *context.AppContext, mediaRecords models.Media
 	// Get media types map
 	typesMap := appCtxt.APIClient.Helpers.GetMediaTypes(mediaRecords)
 
+	// Get user's media currently lent out, to badge them
+	lentOut := lentOutMedia(appCtxt)
+
 	// Iterate over each media type
 	for mediaType := range typesMap {
 		// Create the top separator
@@ -90,50 +104,30 @@ func buildMediaContainers(appCtxt *context.AppContext, mediaRecords models.Media
 			appCtxt.PageManager.ShowCompartmentTreePage(mediaType, mediaRecords.MediaRecords[mediaType])
 		})
 
-		topSeparator := container.NewBorder(
-			customSeparatorForShelf(),
-			customSeparatorForShelf(),
-			customSeparatorForShelf(),
-			customSeparatorForShelf(),
-			topTextButton,
-		)
+		addCompartment(appCtxt, shelf, topTextButton, mediaRecords.MediaRecords[mediaType], lentOut)
+	}
 
-		// Create all images for media of this type into a Grid Wrap
-		mediaDisplay := container.NewGridWrap(fyne.NewSize(200, 500))
-		for _, medium := range mediaRecords.MediaRecords[mediaType] {
-
-			// Inside function lo load image
-			loadImage := func() fyne.CanvasObject {
-				if medium.ImageUrl == "" {
-					return createFallbackImage(medium.Title)
-				}
-				// Fetch the image as a buffer
-				bufImage, err := appCtxt.APIClient.Helpers.GetImage(medium.ImageUrl)
-				if err != nil {
-					return createFallbackImage(medium.Title)
-				}
-				// Create the image component
-				image := canvas.NewImageFromReader(bufImage, medium.Title)
-				image.FillMode = canvas.ImageFillContain
-
-				return image
-			}
+	// Add user's custom shelves as more compartments, mixing media types
+	// The shelf is still shown without them if they can't be fetched
+	shelves, err := appCtxt.APIClient.Shelves.GetShelves()
+	if err != nil {
+		log.Printf("--GUI-- couldn't get custom shelves: %v", err)
+	}
+	for _, customShelf := range shelves.Shelves {
+		shelfMedia := appCtxt.APIClient.Helpers.GetShelfMedia(mediaRecords, customShelf.ID)
 
-			mediaDisplay.Add(loadImage())
+		topTextButton := widget.NewButton(customShelf.Name, func() {
+			appCtxt.PageManager.ShowCompartmentTreePage(customShelf.Name, shelfMedia)
+		})
+		deleteButton := widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+			buttonFuncDeleteShelf(appCtxt, customShelf)
+		})
+		topContent := container.NewBorder(nil, nil, nil, deleteButton, topTextButton)
+		if customShelf.Description != "" {
+			topContent = container.NewBorder(nil, widget.NewLabel(customShelf.Description), nil, deleteButton, topTextButton)
 		}
 
-		// Put the Grid Wrap inside a Border Container
-		mediaCompartment := container.NewBorder(
-			customSeparatorForShelf(),
-			customSeparatorForShelf(),
-			customSeparatorForShelf(),
-			customSeparatorForShelf(),
-			mediaDisplay,
-		)
-
-		// Add them to main shelf container
-		shelf.Add(topSeparator)
-		shelf.Add(mediaCompartment)
+		addCompartment(appCtxt, shelf, topContent, shelfMedia, lentOut)
 	}
 
 	// Make the shelf scrollable
@@ -144,6 +138,100 @@ func buildMediaContainers(appCtxt *context.AppContext, mediaRecords models.Media
 	return scrollableShelf, nil
 }
 
+// Add a compartment to the shelf: a top frame holding given content, then a frame with media images
+// Images of media lent out get a badge
+func addCompartment(appCtxt *context.AppContext, shelf *fyne.Container, topContent fyne.CanvasObject, mediaList []models.MediumWithRecord, lentOut map[string]models.Loan) {
+	topSeparator := container.NewBorder(
+		customSeparatorForShelf(),
+		customSeparatorForShelf(),
+		customSeparatorForShelf(),
+		customSeparatorForShelf(),
+		topContent,
+	)
+
+	// Create all images for media of this compartment into a Grid Wrap
+	mediaDisplay := container.NewGridWrap(fyne.NewSize(200, 500))
+	for _, medium := range mediaList {
+
+		// Inside function lo load image
+		loadImage := func() fyne.CanvasObject {
+			if medium.ImageUrl == "" {
+				return createFallbackImage(medium.Title)
+			}
+			// Fetch the image as a buffer
+			bufImage, err := appCtxt.APIClient.Helpers.GetImage(medium.ImageUrl)
+			if err != nil {
+				return createFallbackImage(medium.Title)
+			}
+			// Create the image component
+			image := canvas.NewImageFromReader(bufImage, medium.Title)
+			image.FillMode = canvas.ImageFillContain
+
+			return image
+		}
+
+		if loan, ok := lentOut[medium.MediaID]; ok {
+			mediaDisplay.Add(container.NewStack(loadImage(), createLentOutBadge(loan)))
+			continue
+		}
+		mediaDisplay.Add(loadImage())
+	}
+
+	// Put the Grid Wrap inside a Border Container
+	mediaCompartment := container.NewBorder(
+		customSeparatorForShelf(),
+		customSeparatorForShelf(),
+		customSeparatorForShelf(),
+		customSeparatorForShelf(),
+		mediaDisplay,
+	)
+
+	// Add them to main shelf container
+	shelf.Add(topSeparator)
+	shelf.Add(mediaCompartment)
+}
+
+// Button function
+func buttonFuncNewShelf(appCtxt *context.AppContext) {
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder("Summer 2025, Favourites...")
+	descriptionEntry := widget.NewEntry()
+
+	dialog.ShowForm("New custom shelf", "Create", "Cancel", []*widget.FormItem{
+		widget.NewFormItem("Name", nameEntry),
+		widget.NewFormItem("Description", descriptionEntry),
+	}, func(b bool) {
+		if !b {
+			return
+		}
+		_, err := appCtxt.APIClient.Shelves.CreateShelf(nameEntry.Text, descriptionEntry.Text)
+		switch err {
+		case nil:
+			appCtxt.PageManager.ShowShelfPage()
+		case models.ErrBadRequest:
+			dialog.ShowInformation("Info", "Please give a name to your shelf", appCtxt.MainWindow)
+		case models.ErrConflict:
+			dialog.ShowInformation("Info", "You already have a shelf with this name", appCtxt.MainWindow)
+		default:
+			dialog.ShowError(err, appCtxt.MainWindow)
+		}
+	}, appCtxt.MainWindow)
+}
+
+// Button function
+func buttonFuncDeleteShelf(appCtxt *context.AppContext, customShelf models.Shelf) {
+	dialog.ShowConfirm("Confirm", fmt.Sprintf("Are you sure you want to delete the shelf %s ?\nIts media and your records are kept", customShelf.Name), func(b bool) {
+		if !b {
+			return
+		}
+		if err := appCtxt.APIClient.Shelves.DeleteShelf(customShelf.ID); err != nil {
+			dialog.ShowError(err, appCtxt.MainWindow)
+			return
+		}
+		appCtxt.PageManager.ShowShelfPage()
+	}, appCtxt.MainWindow)
+}
+
 func createMediaTreeContent(appCtxt *context.AppContext, mediaType string, mediaList []models.MediumWithRecord) *fyne.Container {
 	// Get the tree populated from helper function
 	tree := createAndPopulateTree(appCtxt, mediaType, mediaList)
@@ -0,0 +1,439 @@
+package gui
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/VincNT21/kallaxy/client/context"
+	"github.com/VincNT21/kallaxy/client/models"
+)
+
+// Size of the covers shown in shelving units' cells
+var shelvingCoverSize = fyne.NewSize(60, 90)
+
+func createShelvingUnitsContent(appCtxt *context.AppContext, unitID string) *fyne.Container {
+	// Create UI Objects
+	// Texts
+	pageTitleText := canvas.NewText(fmt.Sprintf("%s's Shelving Units", appCtxt.APIClient.CurrentUser.Username), color.White)
+	pageTitleText.TextSize = 20
+	pageTitleText.Alignment = fyne.TextAlignCenter
+	pageTitleText.TextStyle.Bold = true
+
+	// Buttons
+	backButton := widget.NewButtonWithIcon("Back to Shelf", theme.ContentUndoIcon(), func() {
+		appCtxt.PageManager.ShowShelfPage()
+	})
+	newUnitButton := widget.NewButtonWithIcon("New unit", theme.ContentAddIcon(), func() {
+		buttonFuncEditShelvingUnit(appCtxt, models.ShelvingUnit{})
+	})
+	whereButton := widget.NewButtonWithIcon("Where is...", theme.SearchIcon(), func() {
+		buttonFuncWhereIsMedium(appCtxt)
+	})
+	bottomRow := container.NewHBox(newUnitButton, whereButton, layout.NewSpacer(), backButton)
+
+	units, err := appCtxt.APIClient.Shelving.GetShelvingUnits()
+	if err != nil {
+		dialog.ShowError(err, appCtxt.MainWindow)
+	}
+	if len(units.Units) == 0 {
+		emptyLabel := widget.NewLabelWithStyle("You have no shelving unit yet\nCreate one, a 4x4 Kallax for instance, then drag your media into its cells", fyne.TextAlignCenter, fyne.TextStyle{})
+		return container.NewBorder(pageTitleText, bottomRow, nil, nil, container.NewCenter(emptyLabel))
+	}
+
+	// Show the asked unit, or the first one
+	unit := units.Units[0]
+	unitNames := make([]string, 0, len(units.Units))
+	for _, u := range units.Units {
+		unitNames = append(unitNames, u.Name)
+		if u.ID == unitID {
+			unit = u
+		}
+	}
+	unitSelect := widget.NewSelect(unitNames, func(name string) {
+		for _, u := range units.Units {
+			if u.Name == name && u.ID != unit.ID {
+				appCtxt.PageManager.ShowShelvingUnitsPage(u.ID)
+			}
+		}
+	})
+	unitSelect.SetSelected(unit.Name)
+
+	editUnitButton := widget.NewButtonWithIcon("", theme.DocumentCreateIcon(), func() {
+		buttonFuncEditShelvingUnit(appCtxt, unit)
+	})
+	deleteUnitButton := widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+		buttonFuncDeleteShelvingUnit(appCtxt, unit)
+	})
+	unitInfo := widget.NewLabel(fmt.Sprintf("%d x %d cells of %d x %d x %d cm", unit.Rows, unit.Columns, unit.CellWidthCm, unit.CellHeightCm, unit.CellDepthCm))
+	topRow := container.NewVBox(
+		pageTitleText,
+		container.NewHBox(unitSelect, editUnitButton, deleteUnitButton, unitInfo),
+	)
+
+	grid, err := appCtxt.APIClient.Shelving.GetShelvingUnitGrid(unit.ID)
+	if err != nil {
+		dialog.ShowError(err, appCtxt.MainWindow)
+		return container.NewBorder(topRow, bottomRow, nil, nil, widget.NewLabel("Error while getting your shelving unit"))
+	}
+
+	// Media of the shelf in no unit yet can be dragged into a cell, and back out of it
+	mediaRecords, err := appCtxt.APIClient.Media.GetMediaWithRecords()
+	if err != nil {
+		dialog.ShowError(err, appCtxt.MainWindow)
+	}
+	placed := placedMedia(appCtxt, units)
+
+	board := newShelvingBoard(appCtxt, unit)
+	cellsGrid := container.NewGridWithColumns(unit.Columns)
+	for _, cell := range grid.Cells {
+		cellsGrid.Add(board.addCell(cell))
+	}
+
+	unplacedList := container.NewGridWrap(shelvingCoverSize)
+	for _, mediaList := range mediaRecords.MediaRecords {
+		for _, medium := range mediaList {
+			if _, ok := placed[medium.MediaID]; ok {
+				continue
+			}
+			unplacedList.Add(board.newCover(medium.MediaID, medium.Title, medium.ImageUrl))
+		}
+	}
+	unplacedBackground := canvas.NewRectangle(color.Transparent)
+	unplacedArea := container.NewStack(unplacedBackground, container.NewBorder(
+		widget.NewLabelWithStyle("Not in a unit", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		nil, nil, nil,
+		container.NewVScroll(unplacedList),
+	))
+	board.outside = &shelvingDropTarget{area: unplacedArea, highlight: unplacedBackground}
+
+	scrollableGrid := container.NewScroll(cellsGrid)
+	scrollableGrid.SetMinSize(fyne.NewSize(700, 550))
+	centralPart := container.NewHSplit(scrollableGrid, unplacedArea)
+	centralPart.SetOffset(0.75)
+
+	// Create the global frame
+	globalContainer := container.NewBorder(
+		topRow,
+		bottomRow,
+		nil, nil,
+		centralPart,
+	)
+
+	return globalContainer
+}
+
+// Media placed in any of user's units, by medium ID
+func placedMedia(appCtxt *context.AppContext, units models.ShelvingUnits) map[string]models.ShelvingItem {
+	placed := map[string]models.ShelvingItem{}
+	for _, unit := range units.Units {
+		if unit.ItemsCount == 0 {
+			continue
+		}
+		grid, err := appCtxt.APIClient.Shelving.GetShelvingUnitGrid(unit.ID)
+		if err != nil {
+			log.Printf("--GUI-- couldn't get shelving unit %s: %v", unit.Name, err)
+			continue
+		}
+		for _, cell := range grid.Cells {
+			for _, item := range cell.Items {
+				placed[item.MediumID] = item
+			}
+		}
+	}
+	return placed
+}
+
+// A place covers can be dropped on: a unit's cell, or the list of media in no unit
+type shelvingDropTarget struct {
+	row       int
+	column    int
+	area      fyne.CanvasObject
+	highlight *canvas.Rectangle
+}
+
+func (target *shelvingDropTarget) contains(pos fyne.Position) bool {
+	topLeft := fyne.CurrentApp().Driver().AbsolutePositionForObject(target.area)
+	size := target.area.Size()
+	return pos.X >= topLeft.X && pos.X <= topLeft.X+size.Width && pos.Y >= topLeft.Y && pos.Y <= topLeft.Y+size.Height
+}
+
+// Shown unit's cells, handling covers dragged between them
+type shelvingBoard struct {
+	appCtxt *context.AppContext
+	unit    models.ShelvingUnit
+	cells   []*shelvingDropTarget
+	outside *shelvingDropTarget
+}
+
+func newShelvingBoard(appCtxt *context.AppContext, unit models.ShelvingUnit) *shelvingBoard {
+	return &shelvingBoard{appCtxt: appCtxt, unit: unit}
+}
+
+// Build a cell with its media's covers, and register it as a drop target
+func (board *shelvingBoard) addCell(cell models.ShelvingCell) fyne.CanvasObject {
+	covers := container.NewGridWrap(shelvingCoverSize)
+	for _, item := range cell.Items {
+		covers.Add(board.newCover(item.MediumID, item.Title, item.ImageUrl))
+	}
+
+	highlight := canvas.NewRectangle(color.Transparent)
+	border := canvas.NewRectangle(color.Transparent)
+	border.StrokeColor = color.RGBA{R: 128, G: 0, B: 128, A: 255}
+	border.StrokeWidth = 2
+	cellLabel := canvas.NewText(fmt.Sprintf("%d-%d", cell.Row, cell.Column), color.Gray{Y: 160})
+	cellLabel.TextSize = 10
+
+	area := container.NewStack(highlight, border, container.NewBorder(cellLabel, nil, nil, nil, container.NewPadded(covers)))
+	board.cells = append(board.cells, &shelvingDropTarget{row: cell.Row, column: cell.Column, area: area, highlight: highlight})
+	return area
+}
+
+// Find the drop target under an absolute position, nil if there is none
+func (board *shelvingBoard) targetAt(pos fyne.Position) *shelvingDropTarget {
+	for _, target := range board.cells {
+		if target.contains(pos) {
+			return target
+		}
+	}
+	if board.outside != nil && board.outside.contains(pos) {
+		return board.outside
+	}
+	return nil
+}
+
+// Highlight the drop target under a dragged cover
+func (board *shelvingBoard) hover(pos fyne.Position) {
+	hovered := board.targetAt(pos)
+	for _, target := range append(board.cells, board.outside) {
+		if target == nil {
+			continue
+		}
+		fill := color.Color(color.Transparent)
+		if target == hovered {
+			fill = color.RGBA{R: 128, G: 0, B: 128, A: 90}
+		}
+		if target.highlight.FillColor != fill {
+			target.highlight.FillColor = fill
+			target.highlight.Refresh()
+		}
+	}
+}
+
+// Move a medium to the cell it was dropped on, or take it out of its unit
+func (board *shelvingBoard) drop(mediumID string, pos fyne.Position) {
+	target := board.targetAt(pos)
+	if target == nil {
+		board.hover(fyne.NewPos(-1, -1))
+		return
+	}
+
+	var err error
+	if target == board.outside {
+		err = board.appCtxt.APIClient.Shelving.RemoveMedium(mediumID)
+		if err == models.ErrNotFound {
+			// Medium was already in no unit
+			err = nil
+		}
+	} else {
+		_, err = board.appCtxt.APIClient.Shelving.PlaceMedium(mediumID, board.unit.ID, target.row, target.column)
+	}
+	switch err {
+	case nil:
+		log.Println("--GUI-- Medium moved in shelving units")
+	case models.ErrNotFound:
+		dialog.ShowInformation("Info", "This medium isn't on your shelf anymore", board.appCtxt.MainWindow)
+	default:
+		dialog.ShowError(err, board.appCtxt.MainWindow)
+	}
+	board.appCtxt.PageManager.ShowShelvingUnitsPage(board.unit.ID)
+}
+
+// Build a cover that can be dragged to a cell
+func (board *shelvingBoard) newCover(mediumID, title, imageUrl string) fyne.CanvasObject {
+	var image fyne.CanvasObject
+	if imageUrl != "" {
+		bufImage, err := board.appCtxt.APIClient.Helpers.GetImage(imageUrl)
+		if err == nil {
+			coverImage := canvas.NewImageFromReader(bufImage, title)
+			coverImage.FillMode = canvas.ImageFillContain
+			image = coverImage
+		}
+	}
+	if image == nil {
+		titleText := widget.NewLabelWithStyle(title, fyne.TextAlignCenter, fyne.TextStyle{Italic: true})
+		titleText.Wrapping = fyne.TextWrapWord
+		image = container.NewStack(canvas.NewRectangle(color.Gray{Y: 60}), titleText)
+	}
+
+	return newDraggableCover(image, func(pos fyne.Position) {
+		board.hover(pos)
+	}, func(pos fyne.Position) {
+		board.drop(mediumID, pos)
+	})
+}
+
+// A medium's cover which reports where it is dragged and dropped
+type draggableCover struct {
+	widget.BaseWidget
+	content   fyne.CanvasObject
+	onDragged func(pos fyne.Position)
+	onDropped func(pos fyne.Position)
+	lastPos   fyne.Position
+}
+
+func newDraggableCover(content fyne.CanvasObject, onDragged, onDropped func(pos fyne.Position)) *draggableCover {
+	cover := &draggableCover{content: content, onDragged: onDragged, onDropped: onDropped}
+	cover.ExtendBaseWidget(cover)
+	return cover
+}
+
+func (cover *draggableCover) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(cover.content)
+}
+
+func (cover *draggableCover) Dragged(event *fyne.DragEvent) {
+	cover.lastPos = event.AbsolutePosition
+	cover.onDragged(cover.lastPos)
+}
+
+func (cover *draggableCover) DragEnd() {
+	cover.onDropped(cover.lastPos)
+}
+
+// Button function
+func buttonFuncEditShelvingUnit(appCtxt *context.AppContext, unit models.ShelvingUnit) {
+	isNew := unit.ID == ""
+
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder("Living room Kallax")
+	nameEntry.SetText(unit.Name)
+	rowsEntry := widget.NewEntry()
+	columnsEntry := widget.NewEntry()
+	widthEntry := widget.NewEntry()
+	heightEntry := widget.NewEntry()
+	depthEntry := widget.NewEntry()
+	if isNew {
+		rowsEntry.SetText("4")
+		columnsEntry.SetText("4")
+	} else {
+		rowsEntry.SetText(strconv.Itoa(unit.Rows))
+		columnsEntry.SetText(strconv.Itoa(unit.Columns))
+		widthEntry.SetText(strconv.Itoa(unit.CellWidthCm))
+		heightEntry.SetText(strconv.Itoa(unit.CellHeightCm))
+		depthEntry.SetText(strconv.Itoa(unit.CellDepthCm))
+	}
+	for _, entry := range []*widget.Entry{widthEntry, heightEntry, depthEntry} {
+		entry.SetPlaceHolder("Kallax cube's by default")
+	}
+
+	title, confirm := "New shelving unit", "Create"
+	if !isNew {
+		title, confirm = fmt.Sprintf("Edit %s", unit.Name), "Save"
+	}
+	dialog.ShowForm(title, confirm, "Cancel", []*widget.FormItem{
+		widget.NewFormItem("Name", nameEntry),
+		widget.NewFormItem("Rows", rowsEntry),
+		widget.NewFormItem("Columns", columnsEntry),
+		widget.NewFormItem("Cell width (cm)", widthEntry),
+		widget.NewFormItem("Cell height (cm)", heightEntry),
+		widget.NewFormItem("Cell depth (cm)", depthEntry),
+	}, func(b bool) {
+		if !b {
+			return
+		}
+		// Invalid numbers are sent as 0, for the server to reject or default them
+		number := func(entry *widget.Entry) int {
+			n, _ := strconv.Atoi(strings.TrimSpace(entry.Text))
+			return n
+		}
+		details := models.ShelvingUnitDetails{
+			Name:         nameEntry.Text,
+			Rows:         number(rowsEntry),
+			Columns:      number(columnsEntry),
+			CellWidthCm:  number(widthEntry),
+			CellHeightCm: number(heightEntry),
+			CellDepthCm:  number(depthEntry),
+		}
+
+		var savedUnit models.ShelvingUnit
+		var err error
+		if isNew {
+			savedUnit, err = appCtxt.APIClient.Shelving.CreateShelvingUnit(details)
+		} else {
+			savedUnit, err = appCtxt.APIClient.Shelving.UpdateShelvingUnit(unit.ID, details)
+		}
+		switch err {
+		case nil:
+			appCtxt.PageManager.ShowShelvingUnitsPage(savedUnit.ID)
+		case models.ErrBadRequest:
+			dialog.ShowInformation("Info", "There is a problem with your unit:\n- It needs a name\nAND/OR\n- Rows and columns must be between 1 and 20\nAND/OR\n- Cell dimensions can't be negative", appCtxt.MainWindow)
+		case models.ErrConflict:
+			dialog.ShowInformation("Info", "You already have a unit with this name\nOR\nSome of its media are in cells it would lose: move them first", appCtxt.MainWindow)
+		default:
+			dialog.ShowError(err, appCtxt.MainWindow)
+		}
+	}, appCtxt.MainWindow)
+}
+
+// Button function
+func buttonFuncDeleteShelvingUnit(appCtxt *context.AppContext, unit models.ShelvingUnit) {
+	dialog.ShowConfirm("Confirm", fmt.Sprintf("Are you sure you want to delete the unit %s ?\nIts media stay on your shelf", unit.Name), func(b bool) {
+		if !b {
+			return
+		}
+		if err := appCtxt.APIClient.Shelving.DeleteShelvingUnit(unit.ID); err != nil {
+			dialog.ShowError(err, appCtxt.MainWindow)
+			return
+		}
+		appCtxt.PageManager.ShowShelvingUnitsPage("")
+	}, appCtxt.MainWindow)
+}
+
+// Button function
+func buttonFuncWhereIsMedium(appCtxt *context.AppContext) {
+	titleEntry := widget.NewEntry()
+	titleEntry.SetPlaceHolder("Part of a title")
+
+	dialog.ShowForm("Where is...", "Find", "Cancel", []*widget.FormItem{
+		widget.NewFormItem("Title", titleEntry),
+	}, func(b bool) {
+		if !b {
+			return
+		}
+		locations, err := appCtxt.APIClient.Shelving.WhereIsMedium("", titleEntry.Text)
+		switch err {
+		case nil:
+		case models.ErrBadRequest:
+			dialog.ShowInformation("Info", "Please give a title to look for", appCtxt.MainWindow)
+			return
+		default:
+			dialog.ShowError(err, appCtxt.MainWindow)
+			return
+		}
+		if len(locations.Locations) == 0 {
+			dialog.ShowInformation("Where is...", fmt.Sprintf("No medium matching \"%s\" is in your shelving units", titleEntry.Text), appCtxt.MainWindow)
+			return
+		}
+
+		// Each location leads to its unit
+		results := container.NewVBox()
+		for _, location := range locations.Locations {
+			results.Add(widget.NewButton(fmt.Sprintf("%s (%s): %s, row %d, column %d", location.Title, location.MediaType, location.UnitName, location.Row, location.Column), func() {
+				appCtxt.PageManager.ShowShelvingUnitsPage(location.UnitID)
+			}))
+		}
+		scroll := container.NewVScroll(results)
+		scroll.SetMinSize(fyne.NewSize(500, 300))
+		dialog.ShowCustom("Where is...", "Close", scroll, appCtxt.MainWindow)
+	}, appCtxt.MainWindow)
+}
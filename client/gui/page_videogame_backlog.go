@@ -0,0 +1,147 @@
+package gui
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/VincNT21/kallaxy/client/context"
+	"github.com/VincNT21/kallaxy/client/models"
+)
+
+// Name shown for games whose platform isn't known
+const unknownPlatformLabel = "Unknown platform"
+
+// Option of the platform select showing every platform
+const allPlatformsLabel = "All platforms"
+
+func createVideogameBacklogContent(appCtxt *context.AppContext, platform string) *fyne.Container {
+	// Create UI Objects
+	// Texts
+	pageTitleText := canvas.NewText(fmt.Sprintf("%s's Videogame Backlog", appCtxt.APIClient.CurrentUser.Username), color.White)
+	pageTitleText.TextSize = 20
+	pageTitleText.Alignment = fyne.TextAlignCenter
+	pageTitleText.TextStyle.Bold = true
+
+	// Buttons
+	backButton := widget.NewButtonWithIcon("Back to Shelf", theme.ContentUndoIcon(), func() {
+		appCtxt.PageManager.ShowShelfPage()
+	})
+	bottomRow := container.NewHBox(layout.NewSpacer(), backButton)
+
+	stats, err := appCtxt.APIClient.Videogames.GetPlatformStats()
+	if err != nil {
+		dialog.ShowError(err, appCtxt.MainWindow)
+		return container.NewBorder(pageTitleText, bottomRow, nil, nil, widget.NewLabel("Error while getting your videogames"))
+	}
+	if len(stats.Platforms) == 0 {
+		emptyLabel := widget.NewLabelWithStyle("You have no videogame on your shelf yet", fyne.TextAlignCenter, fyne.TextStyle{})
+		return container.NewBorder(pageTitleText, bottomRow, nil, nil, container.NewCenter(emptyLabel))
+	}
+
+	// Platform select, the page is shown again for the chosen one
+	platformOptions := []string{allPlatformsLabel}
+	for _, platformStats := range stats.Platforms {
+		if platformStats.Platform != "" {
+			platformOptions = append(platformOptions, platformStats.Platform)
+		}
+	}
+	platformSelect := widget.NewSelect(platformOptions, nil)
+	if platform == "" {
+		platformSelect.SetSelected(allPlatformsLabel)
+	} else {
+		platformSelect.SetSelected(platform)
+	}
+	platformSelect.OnChanged = func(choice string) {
+		if choice == allPlatformsLabel {
+			choice = ""
+		}
+		if choice != platform {
+			appCtxt.PageManager.ShowVideogameBacklogPage(choice)
+		}
+	}
+	topRow := container.NewVBox(
+		pageTitleText,
+		container.NewHBox(widget.NewLabel("Platform:"), platformSelect),
+	)
+
+	// Stats of each platform, most played first
+	statsBox := container.NewVBox(widget.NewLabelWithStyle("Stats by platform", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+	for _, platformStats := range stats.Platforms {
+		statsBox.Add(widget.NewLabel(platformStatsSummary(platformStats)))
+	}
+
+	// Games still to play, grouped by platform
+	backlog, err := appCtxt.APIClient.Videogames.GetBacklog(platform)
+	if err != nil {
+		dialog.ShowError(err, appCtxt.MainWindow)
+	}
+	backlogAccordion := widget.NewAccordion()
+	for _, platformBacklog := range backlog.Platforms {
+		gamesBox := container.NewVBox()
+		for _, game := range platformBacklog.Games {
+			gamesBox.Add(widget.NewLabel(backlogGameLine(game)))
+		}
+		name := platformBacklog.Platform
+		if name == "" {
+			name = unknownPlatformLabel
+		}
+		backlogAccordion.Append(widget.NewAccordionItem(fmt.Sprintf("%s (%d)", name, len(platformBacklog.Games)), gamesBox))
+	}
+	backlogAccordion.MultiOpen = true
+	backlogAccordion.OpenAll()
+
+	var backlogContent fyne.CanvasObject = backlogAccordion
+	if len(backlog.Platforms) == 0 {
+		backlogContent = widget.NewLabel("Nothing left to play here, well done!")
+	}
+	backlogBox := container.NewBorder(
+		widget.NewLabelWithStyle("Backlog", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		nil, nil, nil,
+		container.NewVScroll(backlogContent),
+	)
+
+	statsScroll := container.NewVScroll(statsBox)
+	split := container.NewHSplit(backlogBox, statsScroll)
+	split.SetOffset(0.55)
+
+	return container.NewBorder(
+		topRow,                     // Top
+		bottomRow,                  // Bottom
+		customSpacerHorizontal(50), // Left
+		customSpacerHorizontal(50), // Right
+		split,
+	)
+}
+
+// One line summary of a platform's stats
+func platformStatsSummary(platformStats models.PlatformStats) string {
+	name := platformStats.Platform
+	if name == "" {
+		name = unknownPlatformLabel
+	}
+	summary := fmt.Sprintf("%s: %d games, %d finished, %d to play, %sh played", name, platformStats.GamesCount, platformStats.FinishedCount, platformStats.BacklogCount, strconv.FormatFloat(platformStats.HoursPlayed, 'f', -1, 64))
+	if completed := platformStats.Completions["completionist"]; completed > 0 {
+		summary += fmt.Sprintf(", %d at 100%%", completed)
+	}
+	if platformStats.AchievementsTotal > 0 {
+		summary += fmt.Sprintf(", %d/%d achievements", platformStats.AchievementsEarned, platformStats.AchievementsTotal)
+	}
+	return summary
+}
+
+// Line of a game of the backlog, with its status and hours already played
+func backlogGameLine(game models.BacklogGame) string {
+	line := fmt.Sprintf("%s - %s", game.Title, recordStatusTitles[game.Status])
+	if game.HoursPlayed != nil {
+		line += fmt.Sprintf(" (%sh played)", strconv.FormatFloat(*game.HoursPlayed, 'f', -1, 64))
+	}
+	return line
+}
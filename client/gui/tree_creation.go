@@ -4,6 +4,10 @@ import (
 	"fmt"
 	"image/color"
 	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
@@ -59,40 +63,80 @@ Node Types to handle :
 		leaf value node, with multiple lines of text and a title
 		displayed as a multi line text, scrollable, with buttons for expand/collapse
 
+	- season
+		branch node with a series' season
+		check to mark/unmark all its episodes, button for episodes' titles
+
+	- episode
+		leaf node with an episode of a season
+		check to mark/unmark it as watched, with the date it was watched
+
 */
 
+// Records' statuses, in the order of tree's top-level branches, with their titles and colors
+var recordStatuses = []string{"in_progress", "paused", "planned", "wishlist", "finished", "abandoned"}
+
+var recordStatusTitles = map[string]string{
+	"wishlist":    "Wishlist",
+	"planned":     "Planned",
+	"in_progress": "In Progress",
+	"paused":      "Paused",
+	"finished":    "Finished",
+	"abandoned":   "Abandoned",
+}
+
+var recordStatusColors = map[string]color.Color{
+	"wishlist":    color.RGBA{R: 100, G: 180, B: 255, A: 255},
+	"planned":     color.RGBA{R: 255, G: 0, B: 0, A: 255},
+	"in_progress": color.RGBA{R: 255, G: 120, B: 0, A: 255},
+	"paused":      color.RGBA{R: 255, G: 220, B: 0, A: 255},
+	"finished":    color.RGBA{R: 0, G: 255, B: 0, A: 255},
+	"abandoned":   color.RGBA{R: 150, G: 150, B: 150, A: 255},
+}
+
 func createAndPopulateTree(appCtxt *context.AppContext, mediaType string, mediaList []models.MediumWithRecord) *widget.Tree {
 	// Prepare a map for Tree widget data
 	treeData := make(map[string][]string) // Parent -> Children IDs
 	nodes := make(map[string]TreeNode)    // NodeID -> TreeNode
 
-	// Create top-level nodes (by status)
-	treeData[""] = []string{"Finished", "In Progress", "Unstarted"}
-	nodes["Finished"] = TreeNode{ID: "Finished", Title: "Finished", NodeType: "main_title"}
-	nodes["In Progress"] = TreeNode{ID: "In Progress", Title: "In Progress", NodeType: "main_title"}
-	nodes["Unstarted"] = TreeNode{ID: "Unstarted", Title: "Unstarted", NodeType: "main_title"}
+	// Get user's media currently lent out, and how many times boardgames were played
+	lentOut := lentOutMedia(appCtxt)
+	gamePlays := playedGames(appCtxt, mediaList)
+
+	// Get episodes watched of series, seasons and episodes nodes keep the episode they stand for
+	seriesEpisodes := watchedEpisodes(appCtxt, mediaList)
+	episodeNodes := make(map[string]episodeNode) // NodeID -> episodeNode
+
+	// Create top-level nodes (by status), the node ID being the status itself
+	for _, status := range recordStatuses {
+		treeData[""] = append(treeData[""], status)
+		nodes[status] = TreeNode{ID: status, Title: recordStatusTitles[status], NodeType: "main_title"}
+	}
 
 	// Populate media into second and third level
 	for _, medium := range mediaList {
 		// Medium title branch node (2nd level)
 
-		// Determine parent based on IsFinished and StartDate
-		parent := "Unstarted"
-		if medium.StartDate != "" {
-			parent = "In Progress"
-		}
-		if medium.IsFinished {
-			parent = "Finished"
+		// Parent is the branch of record's status
+		parent := medium.Status
+		if _, ok := recordStatusTitles[parent]; !ok {
+			log.Printf("--GUI-- Tree unexpected record status: %v", medium.Status)
+			parent = "planned"
 		}
 		// Create a unique ID for this media node
 		mediaNodeID := fmt.Sprintf("media-%s", medium.ID)
 		treeData[parent] = append(treeData[parent], mediaNodeID)
 
-		// Add the media node with its title
+		// Add the media node with its title, flagged while the medium is lent out
+		mediumTitle := medium.Title
+		loan, isLentOut := lentOut[medium.MediaID]
+		if isLentOut {
+			mediumTitle = fmt.Sprintf("%s (lent out)", medium.Title)
+		}
 		nodes[mediaNodeID] = TreeNode{
 			ID:       mediaNodeID,
 			ParentID: parent,
-			Title:    medium.Title,
+			Title:    mediumTitle,
 			Value:    medium.MediaID, // Value field will hold MediaID for edit/delete functions
 			NodeType: "medium_title",
 		}
@@ -120,6 +164,59 @@ func createAndPopulateTree(appCtxt *context.AppContext, mediaType string, mediaL
 			NodeType: "single_line",
 		}
 
+		// Average rating Leaf node (3rd level), from all users who rated the medium
+		averageRatingNodeID := fmt.Sprintf("%s-average_rating", mediaNodeID)
+		treeData[detailsParent] = append(treeData[detailsParent], averageRatingNodeID)
+		nodes[averageRatingNodeID] = TreeNode{
+			ID:       averageRatingNodeID,
+			ParentID: detailsParent,
+			Value:    fmt.Sprintf("Average rating: %s", formatAverageRating(appCtxt, medium)),
+			NodeType: "single_line",
+		}
+
+		// Tags Leaf node (3rd level), only for tagged media
+		if len(medium.Tags) > 0 {
+			tagsNodeID := fmt.Sprintf("%s-tags", mediaNodeID)
+			treeData[detailsParent] = append(treeData[detailsParent], tagsNodeID)
+			nodes[tagsNodeID] = TreeNode{
+				ID:       tagsNodeID,
+				ParentID: detailsParent,
+				Value:    fmt.Sprintf("Tags: %s", strings.Join(medium.Tags, ", ")),
+				NodeType: "single_line",
+			}
+		}
+
+		// Loan Leaf node (3rd level), only for media lent out
+		if isLentOut {
+			loanNodeID := fmt.Sprintf("%s-loan", mediaNodeID)
+			treeData[detailsParent] = append(treeData[detailsParent], loanNodeID)
+			nodes[loanNodeID] = TreeNode{
+				ID:       loanNodeID,
+				ParentID: detailsParent,
+				Value:    fmt.Sprintf("Lent out: %s", formatLoan(appCtxt, loan)),
+				NodeType: "single_line",
+			}
+		}
+
+		// Plays Leaf node (3rd level), only for boardgames
+		if gamePlays != nil && medium.MediaType == "boardgame" {
+			playsNodeID := fmt.Sprintf("%s-plays", mediaNodeID)
+			treeData[detailsParent] = append(treeData[detailsParent], playsNodeID)
+			nodes[playsNodeID] = TreeNode{
+				ID:       playsNodeID,
+				ParentID: detailsParent,
+				Value:    fmt.Sprintf("Plays: %s", formatGamePlays(appCtxt, gamePlays[medium.MediaID])),
+				NodeType: "single_line",
+			}
+		}
+
+		// Episodes Branch node (3rd level), only for series, with seasons (4th level) and episodes (5th level)
+		if recordEpisodes, ok := seriesEpisodes[medium.ID]; ok {
+			episodesNodeID := fmt.Sprintf("%s-episodes", mediaNodeID)
+			treeData[detailsParent] = append(treeData[detailsParent], episodesNodeID)
+			addEpisodesNodes(appCtxt, treeData, nodes, episodeNodes, episodesNodeID, medium.MediaID, recordEpisodes)
+		}
+
 		// Personal record Branch node (3rd level)
 		persRecordNodeID := fmt.Sprintf("%s-personal_record", mediaNodeID)
 		treeData[detailsParent] = append(treeData[detailsParent], persRecordNodeID)
@@ -140,6 +237,15 @@ func createAndPopulateTree(appCtxt *context.AppContext, mediaType string, mediaL
 			Value:    fmt.Sprintf("%v", medium.StartDate),
 			NodeType: "single_line_with_title",
 		}
+		statusLeafID := fmt.Sprintf("%s-%s", persRecordNodeID, "status")
+		treeData[persRecordNodeID] = append(treeData[persRecordNodeID], statusLeafID)
+		nodes[statusLeafID] = TreeNode{
+			ID:       statusLeafID,
+			ParentID: persRecordNodeID,
+			Title:    "Status: ",
+			Value:    recordStatusTitles[medium.Status],
+			NodeType: "single_line_with_title",
+		}
 		endDateLeafID := fmt.Sprintf("%s-%s", persRecordNodeID, "end_date")
 		treeData[persRecordNodeID] = append(treeData[persRecordNodeID], endDateLeafID)
 		nodes[endDateLeafID] = TreeNode{
@@ -158,6 +264,26 @@ func createAndPopulateTree(appCtxt *context.AppContext, mediaType string, mediaL
 			Value:    fmt.Sprintf("%v", medium.Duration),
 			NodeType: "single_line_with_title",
 		}
+		ratingLeafID := fmt.Sprintf("%s-%s", persRecordNodeID, "rating")
+		treeData[persRecordNodeID] = append(treeData[persRecordNodeID], ratingLeafID)
+		nodes[ratingLeafID] = TreeNode{
+			ID:       ratingLeafID,
+			ParentID: persRecordNodeID,
+			Title:    "My rating: ",
+			Value:    formatRecordRating(appCtxt, medium.Rating),
+			NodeType: "single_line_with_title",
+		}
+		if medium.AbandonReason != "" {
+			abandonReasonLeafID := fmt.Sprintf("%s-%s", persRecordNodeID, "abandon_reason")
+			treeData[persRecordNodeID] = append(treeData[persRecordNodeID], abandonReasonLeafID)
+			nodes[abandonReasonLeafID] = TreeNode{
+				ID:       abandonReasonLeafID,
+				ParentID: persRecordNodeID,
+				Title:    "Abandoned because: ",
+				Value:    medium.AbandonReason,
+				NodeType: "multi_line_with_title",
+			}
+		}
 		commentsLeafID := fmt.Sprintf("%s-%s", persRecordNodeID, "comments")
 		treeData[persRecordNodeID] = append(treeData[persRecordNodeID], commentsLeafID)
 		nodes[commentsLeafID] = TreeNode{
@@ -168,6 +294,30 @@ func createAndPopulateTree(appCtxt *context.AppContext, mediaType string, mediaL
 			NodeType: "multi_line_with_title",
 		}
 
+		// Earlier consumptions Branch node (4th level), only for media consumed several times
+		if medium.ConsumptionCount > 1 {
+			consumptionsNodeID := fmt.Sprintf("%s-%s", persRecordNodeID, "consumptions")
+			treeData[persRecordNodeID] = append(treeData[persRecordNodeID], consumptionsNodeID)
+			nodes[consumptionsNodeID] = TreeNode{
+				ID:       consumptionsNodeID,
+				ParentID: persRecordNodeID,
+				Title:    fmt.Sprintf("Consumed %d times", medium.ConsumptionCount),
+				NodeType: "sub_title",
+			}
+			// Earlier consumptions Leaf nodes (5th level), the latest one is already displayed above
+			for i, record := range medium.History[:len(medium.History)-1] {
+				consumptionLeafID := fmt.Sprintf("%s-%d", consumptionsNodeID, i)
+				treeData[consumptionsNodeID] = append(treeData[consumptionsNodeID], consumptionLeafID)
+				nodes[consumptionLeafID] = TreeNode{
+					ID:       consumptionLeafID,
+					ParentID: consumptionsNodeID,
+					Title:    fmt.Sprintf("#%d: ", i+1),
+					Value:    formatConsumption(appCtxt, record),
+					NodeType: "single_line_with_title",
+				}
+			}
+		}
+
 		// Metadata Branch node (3rd level)
 		metadataNodeID := fmt.Sprintf("%s-metadata", mediaNodeID)
 		treeData[detailsParent] = append(treeData[detailsParent], metadataNodeID)
@@ -264,18 +414,10 @@ func createAndPopulateTree(appCtxt *context.AppContext, mediaType string, mediaL
 				branchContainer.Add(branchTextObject)
 			case "medium_title":
 				// Medium title will have standard text, edit/delete button, expand/collapse button
-				// color depends of category (finished, in progress, unstarted)
+				// color depends of category (record's status)
 				branchTextObject.TextSize = 14
-				switch node.ParentID {
-				case "Finished":
-					greenColor := color.RGBA{R: 0, G: 255, B: 0, A: 255}
-					branchTextObject.Color = greenColor
-				case "In Progress":
-					orangeColor := color.RGBA{R: 255, G: 120, B: 0, A: 255}
-					branchTextObject.Color = orangeColor
-				case "Unstarted":
-					redColor := color.RGBA{R: 255, G: 0, B: 0, A: 255}
-					branchTextObject.Color = redColor
+				if statusColor, ok := recordStatusColors[node.ParentID]; ok {
+					branchTextObject.Color = statusColor
 				}
 				branchContainer.Add(branchTextObject)
 				// Edit/delete buttons
@@ -283,7 +425,7 @@ func createAndPopulateTree(appCtxt *context.AppContext, mediaType string, mediaL
 					buttonFuncMediumEdit(appCtxt, node, mediaType, mediaList)
 				})
 				mediumDeleteButton := widget.NewButtonWithIcon("Delete", theme.DeleteIcon(), func() {
-					buttonFuncMediumDelete(appCtxt, node)
+					buttonFuncMediumDelete(appCtxt, node, mediaList)
 				})
 				expandButton := widget.NewButtonWithIcon("", theme.Icon(theme.IconNameArrowDropDown), func() {
 					buttonFuncExpandBranches(tree, treeData, node.ID)
@@ -312,6 +454,35 @@ func createAndPopulateTree(appCtxt *context.AppContext, mediaType string, mediaL
 				branchContainer.Add(collapseButton)
 				branchContainer.Add(expandButton)
 
+			case "season":
+				// Season title with a check to mark or unmark all its episodes at once
+				// and a button to read episodes' titles
+				branchTextObject.TextSize = 14
+				season := episodeNodes[node.ID]
+				seasonCheck := widget.NewCheck("", nil)
+				seasonCheck.Checked = season.Watched
+				seasonCheck.OnChanged = func(checked bool) {
+					buttonFuncToggleEpisodes(appCtxt, tree, treeData, nodes, episodeNodes, node.ID, checked)
+				}
+				titlesButton := widget.NewButtonWithIcon("", theme.SearchIcon(), func() {
+					buttonFuncSeasonTitles(appCtxt, season)
+				})
+
+				branchContainer.Add(seasonCheck)
+				branchContainer.Add(branchTextObject)
+				branchContainer.Add(titlesButton)
+
+			case "episode":
+				// Use a check with episode's title, and the date it was watched
+				episodeCheck := widget.NewCheck(node.Title, nil)
+				episodeCheck.Checked = episodeNodes[node.ID].Watched
+				episodeCheck.OnChanged = func(checked bool) {
+					buttonFuncToggleEpisodes(appCtxt, tree, treeData, nodes, episodeNodes, node.ID, checked)
+				}
+				leafValueObject := canvas.NewText(node.Value, color.White)
+				branchContainer.Add(episodeCheck)
+				branchContainer.Add(leafValueObject)
+
 			case "single_line":
 				// Use a single canvas.Text
 				leafTextObject := canvas.NewText(node.Value, color.White)
@@ -349,9 +520,9 @@ func createAndPopulateTree(appCtxt *context.AppContext, mediaType string, mediaL
 	)
 
 	// Expand first-level branches by default
-	tree.OpenBranch("Finished")
-	tree.OpenBranch("In Progress")
-	tree.OpenBranch("Unstarted")
+	for _, status := range recordStatuses {
+		tree.OpenBranch(status)
+	}
 
 	return tree
 }
@@ -393,6 +564,13 @@ func buttonFuncMediumEdit(appCtxt *context.AppContext, node TreeNode, mediaType
 	// Ask if user wants to edit their personal record or the medium itself
 	var editDialog dialog.Dialog
 
+	// A custom shelf compartment mixes media types, edit pages need the medium's own one
+	for _, medium := range mediaList {
+		if medium.MediaID == node.Value {
+			mediaType = medium.MediaType
+		}
+	}
+
 	line1 := canvas.NewText("What do you want to edit ?", color.White)
 	line1.Alignment = fyne.TextAlignCenter
 
@@ -406,27 +584,859 @@ func buttonFuncMediumEdit(appCtxt *context.AppContext, node TreeNode, mediaType
 		editDialog.Hide()
 	})
 
+	// A new record for a re-read, replay..., other consumptions are kept
+	newConsumptionButton := widget.NewButton("Log another consumption", func() {
+		editDialog.Hide()
+		buttonFuncLogConsumption(appCtxt, node, mediaType, mediaList)
+	})
+
+	groupsEditButton := widget.NewButton("Tags and shelves", func() {
+		editDialog.Hide()
+		buttonFuncMediumGroups(appCtxt, node, mediaList)
+	})
+
+	loanButton := widget.NewButton("Lend / Return", func() {
+		editDialog.Hide()
+		buttonFuncMediumLoan(appCtxt, node)
+	})
+
+	buttons := container.NewHBox(layout.NewSpacer(), mediumEditButton, layout.NewSpacer(), recordEditButton, layout.NewSpacer(), newConsumptionButton, layout.NewSpacer(), groupsEditButton, layout.NewSpacer(), loanButton, layout.NewSpacer())
+
+	// Boardgames are played again and again rather than consumed once
+	if mediaType == "boardgame" {
+		playButton := widget.NewButton("Log a play", func() {
+			editDialog.Hide()
+			buttonFuncLogPlay(appCtxt, node)
+		})
+		buttons.Add(playButton)
+		buttons.Add(layout.NewSpacer())
+	}
+
+	// Videogames keep track of the platform they are played on and how far they were completed
+	if mediaType == "videogame" {
+		videogameButton := widget.NewButton("Platform and completion", func() {
+			editDialog.Hide()
+			buttonFuncVideogameDetails(appCtxt, node, mediaList)
+		})
+		buttons.Add(videogameButton)
+		buttons.Add(layout.NewSpacer())
+	}
+
 	editDialog = dialog.NewCustom("Edit Medium", "Cancel", container.NewVBox(
 		line1,
-		container.NewHBox(layout.NewSpacer(), mediumEditButton, layout.NewSpacer(), recordEditButton, layout.NewSpacer()),
+		buttons,
 	), appCtxt.MainWindow)
 
 	editDialog.Show()
 }
 
 // Button function
-func buttonFuncMediumDelete(appCtxt *context.AppContext, node TreeNode) {
+func buttonFuncMediumGroups(appCtxt *context.AppContext, node TreeNode, mediaList []models.MediumWithRecord) {
+	var medium models.MediumWithRecord
+	for _, mediumRecord := range mediaList {
+		if mediumRecord.MediaID == node.Value {
+			medium = mediumRecord
+		}
+	}
+
+	// Get user's tags and custom shelves
+	tags, err := appCtxt.APIClient.Tags.GetTags()
+	if err != nil {
+		dialog.ShowError(err, appCtxt.MainWindow)
+		return
+	}
+	shelves, err := appCtxt.APIClient.Shelves.GetShelves()
+	if err != nil {
+		dialog.ShowError(err, appCtxt.MainWindow)
+		return
+	}
+
+	// One check per tag and per shelf, checked if the medium holds it or is on it
+	tagNames := []string{}
+	for _, tag := range tags.Tags {
+		tagNames = append(tagNames, tag.Name)
+	}
+	tagsCheck := widget.NewCheckGroup(tagNames, nil)
+	tagsCheck.Horizontal = true
+	tagsCheck.Selected = append([]string{}, medium.Tags...)
+	newTagEntry := widget.NewEntry()
+	newTagEntry.SetPlaceHolder("Owned in French, Gift...")
+
+	shelfNames := []string{}
+	shelvesOn := []string{}
+	for _, customShelf := range shelves.Shelves {
+		shelfNames = append(shelfNames, customShelf.Name)
+		if slices.Contains(medium.ShelfIDs, customShelf.ID) {
+			shelvesOn = append(shelvesOn, customShelf.Name)
+		}
+	}
+	shelvesCheck := widget.NewCheckGroup(shelfNames, nil)
+	shelvesCheck.Horizontal = true
+	shelvesCheck.Selected = shelvesOn
+
+	dialog.ShowForm(fmt.Sprintf("Tags and shelves of %s", medium.Title), "Save", "Cancel", []*widget.FormItem{
+		widget.NewFormItem("Tags", tagsCheck),
+		widget.NewFormItem("New tag", newTagEntry),
+		widget.NewFormItem("Custom shelves", shelvesCheck),
+	}, func(b bool) {
+		if !b {
+			return
+		}
+		mediumIDs := []string{medium.MediaID}
+
+		// Only changed tags are sent
+		toTag := []string{}
+		toUntag := []string{}
+		for _, tag := range tags.Tags {
+			wasOn := slices.Contains(medium.Tags, tag.Name)
+			isOn := slices.Contains(tagsCheck.Selected, tag.Name)
+			if isOn && !wasOn {
+				toTag = append(toTag, tag.ID)
+			} else if wasOn && !isOn {
+				toUntag = append(toUntag, tag.ID)
+			}
+		}
+		if newTagName := strings.TrimSpace(newTagEntry.Text); newTagName != "" {
+			newTag, err := appCtxt.APIClient.Tags.CreateTag(newTagName)
+			if err == models.ErrConflict {
+				dialog.ShowInformation("Info", "You already have a tag with this name, check it instead", appCtxt.MainWindow)
+				return
+			}
+			if err != nil {
+				dialog.ShowError(err, appCtxt.MainWindow)
+				return
+			}
+			toTag = append(toTag, newTag.ID)
+		}
+		if len(toTag) > 0 {
+			if _, err := appCtxt.APIClient.Tags.TagMedia(toTag, mediumIDs); err != nil {
+				dialog.ShowError(err, appCtxt.MainWindow)
+				return
+			}
+		}
+		if len(toUntag) > 0 {
+			if _, err := appCtxt.APIClient.Tags.UntagMedia(toUntag, mediumIDs); err != nil {
+				dialog.ShowError(err, appCtxt.MainWindow)
+				return
+			}
+		}
+
+		for _, customShelf := range shelves.Shelves {
+			wasOn := slices.Contains(medium.ShelfIDs, customShelf.ID)
+			isOn := slices.Contains(shelvesCheck.Selected, customShelf.Name)
+			if isOn && !wasOn {
+				_, err = appCtxt.APIClient.Shelves.AddMediaToShelf(customShelf.ID, mediumIDs)
+			} else if wasOn && !isOn {
+				_, err = appCtxt.APIClient.Shelves.RemoveMediaFromShelf(customShelf.ID, mediumIDs)
+			}
+			if err != nil {
+				dialog.ShowError(err, appCtxt.MainWindow)
+				return
+			}
+		}
+
+		// Reload the shelf to show changes
+		appCtxt.PageManager.ShowShelfPage()
+	}, appCtxt.MainWindow)
+}
+
+// Button function
+func buttonFuncMediumLoan(appCtxt *context.AppContext, node TreeNode) {
+	mediumLoans, err := appCtxt.APIClient.Loans.GetMediumLoans(node.Value)
+	if err != nil {
+		dialog.ShowError(err, appCtxt.MainWindow)
+		return
+	}
+
+	// Medium's loan history, last one first
+	history := container.NewVBox()
+	for _, loan := range mediumLoans.Loans {
+		history.Add(widget.NewLabel(formatLoan(appCtxt, loan)))
+	}
+	if len(mediumLoans.Loans) == 0 {
+		history.Add(widget.NewLabel("Never lent yet"))
+	}
+	historyScroll := container.NewVScroll(history)
+	historyScroll.SetMinSize(fyne.NewSize(500, 150))
+
+	// A lent out medium can be returned, its loan being the last one
+	if mediumLoans.IsLentOut {
+		openLoan := mediumLoans.Loans[0]
+		var loanDialog dialog.Dialog
+		returnButton := widget.NewButtonWithIcon("Returned today", theme.ConfirmIcon(), func() {
+			if _, err := appCtxt.APIClient.Loans.ReturnLoan(openLoan.ID, ""); err != nil {
+				dialog.ShowError(err, appCtxt.MainWindow)
+				return
+			}
+			loanDialog.Hide()
+			appCtxt.PageManager.ShowShelfPage()
+		})
+		loanDialog = dialog.NewCustom(fmt.Sprintf("Loans of %s", node.Title), "Close", container.NewVBox(
+			container.NewHBox(widget.NewLabel(fmt.Sprintf("Lent to %s", openLoan.BorrowerName)), layout.NewSpacer(), returnButton),
+			historyScroll,
+		), appCtxt.MainWindow)
+		loanDialog.Show()
+		return
+	}
+
+	// Otherwise it can be lent to another user or to anyone else
+	usernameEntry := widget.NewEntry()
+	usernameEntry.SetPlaceHolder("Kallaxy username")
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder("Or any name")
+	dueDateEntry := widget.NewEntry()
+	dueDateEntry.SetPlaceHolder("YYYY/MM/DD, optional")
+
+	dialog.ShowForm(fmt.Sprintf("Lend %s", node.Title), "Lend", "Cancel", []*widget.FormItem{
+		widget.NewFormItem("Borrower", usernameEntry),
+		widget.NewFormItem("", nameEntry),
+		widget.NewFormItem("Expected back", dueDateEntry),
+		widget.NewFormItem("History", historyScroll),
+	}, func(b bool) {
+		if !b {
+			return
+		}
+		dueDate := ""
+		if dueDateEntry.Text != "" {
+			dueDate, err = appCtxt.APIClient.Helpers.FormatDateToServerFormat(dueDateEntry.Text)
+			if err != nil {
+				dialog.ShowInformation("Info", "Expected return date must be in format YYYY/MM/DD", appCtxt.MainWindow)
+				return
+			}
+		}
+		_, err := appCtxt.APIClient.Loans.CreateLoan(node.Value, usernameEntry.Text, nameEntry.Text, "", dueDate)
+		switch err {
+		case nil:
+			appCtxt.PageManager.ShowShelfPage()
+		case models.ErrBadRequest:
+			dialog.ShowInformation("Info", "There is a problem with your loan:\n- Give either a username or a name\nAND/OR\n- You can't lend to yourself\nAND/OR\n- Expected return date can't be in the past", appCtxt.MainWindow)
+		case models.ErrNotFound:
+			dialog.ShowInformation("Info", "No user found with this username", appCtxt.MainWindow)
+		case models.ErrConflict:
+			dialog.ShowInformation("Info", "This medium is already lent out", appCtxt.MainWindow)
+		default:
+			dialog.ShowError(err, appCtxt.MainWindow)
+		}
+	}, appCtxt.MainWindow)
+}
+
+// A player's row of the play dialog
+type playerRow struct {
+	nameEntry   *widget.Entry
+	scoreEntry  *widget.Entry
+	winnerCheck *widget.Check
+}
+
+// Button function
+func buttonFuncLogPlay(appCtxt *context.AppContext, node TreeNode) {
+	plays, err := appCtxt.APIClient.Plays.GetPlays(node.Value)
+	if err != nil {
+		dialog.ShowError(err, appCtxt.MainWindow)
+		return
+	}
+
+	playedAtEntry := widget.NewEntry()
+	playedAtEntry.SetPlaceHolder("YYYY/MM/DD, today by default")
+	durationEntry := widget.NewEntry()
+	durationEntry.SetPlaceHolder("Minutes, optional")
+	locationEntry := widget.NewEntry()
+	locationEntry.SetPlaceHolder("Optional")
+	expansionsEntry := widget.NewEntry()
+	expansionsEntry.SetPlaceHolder("Comma separated, optional")
+
+	// One row per player, Kallaxy users being written as @username
+	playersBox := container.NewVBox()
+	rows := []playerRow{}
+	addPlayerRow := func(name string) {
+		row := playerRow{
+			nameEntry:   widget.NewEntry(),
+			scoreEntry:  widget.NewEntry(),
+			winnerCheck: widget.NewCheck("Won", nil),
+		}
+		row.nameEntry.SetPlaceHolder("Guest name or @username")
+		row.nameEntry.SetText(name)
+		row.scoreEntry.SetPlaceHolder("Score")
+		rows = append(rows, row)
+		playersBox.Add(container.NewBorder(nil, nil, nil, container.NewHBox(container.NewGridWrap(fyne.NewSize(80, row.scoreEntry.MinSize().Height), row.scoreEntry), row.winnerCheck), row.nameEntry))
+	}
+
+	// The same group often plays again: last play's players, location and expansions are filled in
+	summary := "First play!"
+	if len(plays.Plays) > 0 {
+		last := plays.Plays[0]
+		summary = fmt.Sprintf("Played %d times, last on %s", len(plays.Plays), formatPlayDate(appCtxt, last.PlayedAt))
+		for _, player := range last.Players {
+			if player.PlayerID != "" {
+				addPlayerRow("@" + player.PlayerName)
+			} else {
+				addPlayerRow(player.PlayerName)
+			}
+		}
+		locationEntry.SetText(last.Location)
+		expansionsEntry.SetText(strings.Join(last.Expansions, ", "))
+	}
+	if len(rows) == 0 {
+		addPlayerRow("@" + appCtxt.APIClient.CurrentUser.Username)
+	}
+	addPlayerButton := widget.NewButtonWithIcon("Add player", theme.ContentAddIcon(), func() {
+		addPlayerRow("")
+	})
+	playersScroll := container.NewVScroll(playersBox)
+	playersScroll.SetMinSize(fyne.NewSize(450, 160))
+
+	playDialog := dialog.NewForm(fmt.Sprintf("Log a play of %s", node.Title), "Log", "Cancel", []*widget.FormItem{
+		widget.NewFormItem("", widget.NewLabel(summary)),
+		widget.NewFormItem("Date", playedAtEntry),
+		widget.NewFormItem("Duration", durationEntry),
+		widget.NewFormItem("Location", locationEntry),
+		widget.NewFormItem("Expansions", expansionsEntry),
+		widget.NewFormItem("Players", container.NewBorder(nil, container.NewHBox(addPlayerButton, widget.NewLabel("Best score wins if no one is checked")), nil, nil, playersScroll)),
+	}, func(b bool) {
+		if !b {
+			return
+		}
+		details := models.PlayDetails{
+			PlayedAt: strings.TrimSpace(playedAtEntry.Text),
+			Location: locationEntry.Text,
+		}
+		if details.PlayedAt != "" {
+			if _, err := time.Parse("2006/01/02", details.PlayedAt); err != nil {
+				dialog.ShowInformation("Info", "Date must be in format YYYY/MM/DD", appCtxt.MainWindow)
+				return
+			}
+		}
+		if text := strings.TrimSpace(durationEntry.Text); text != "" {
+			duration, err := strconv.Atoi(text)
+			if err != nil {
+				dialog.ShowInformation("Info", "Duration must be a number of minutes", appCtxt.MainWindow)
+				return
+			}
+			details.DurationMinutes = &duration
+		}
+		details.Expansions = strings.Split(expansionsEntry.Text, ",")
+
+		// Rows left without a name are skipped
+		for _, row := range rows {
+			name := strings.TrimSpace(row.nameEntry.Text)
+			if name == "" {
+				continue
+			}
+			player := models.PlayerDetails{IsWinner: row.winnerCheck.Checked}
+			if username, ok := strings.CutPrefix(name, "@"); ok {
+				player.Username = username
+			} else {
+				player.Name = name
+			}
+			if text := strings.TrimSpace(row.scoreEntry.Text); text != "" {
+				score, err := strconv.Atoi(text)
+				if err != nil {
+					dialog.ShowInformation("Info", fmt.Sprintf("Score of %s must be a whole number", name), appCtxt.MainWindow)
+					return
+				}
+				player.Score = &score
+			}
+			details.Players = append(details.Players, player)
+		}
+
+		_, err := appCtxt.APIClient.Plays.CreatePlay(node.Value, details)
+		switch err {
+		case nil:
+			appCtxt.PageManager.ShowShelfPage()
+		case models.ErrBadRequest:
+			dialog.ShowInformation("Info", "There is a problem with your play:\n- Duration must be positive\nAND/OR\n- A player can't be written twice", appCtxt.MainWindow)
+		case models.ErrNotFound:
+			dialog.ShowInformation("Info", "No user found with one of the usernames", appCtxt.MainWindow)
+		default:
+			dialog.ShowError(err, appCtxt.MainWindow)
+		}
+	}, appCtxt.MainWindow)
+	playDialog.Resize(fyne.NewSize(650, 550))
+	playDialog.Show()
+}
+
+// Labels of videogame completion levels, as shown to the user
+var videogameCompletionLabels = map[string]string{
+	"":              "Not set",
+	"main_story":    "Main story",
+	"main_extras":   "Main story + extras",
+	"completionist": "100%",
+}
+
+// Button function
+func buttonFuncVideogameDetails(appCtxt *context.AppContext, node TreeNode, mediaList []models.MediumWithRecord) {
+	recordID := strings.TrimPrefix(node.ID, "media-")
+	current, err := appCtxt.APIClient.Videogames.GetRecordVideogame(recordID)
+	if err != nil {
+		dialog.ShowError(err, appCtxt.MainWindow)
+		return
+	}
+
+	// Platforms the game was released on are proposed, any other one can be typed
+	var platforms []string
+	for _, medium := range mediaList {
+		if medium.ID != recordID {
+			continue
+		}
+		values, _ := medium.Metadata["platforms"].([]interface{})
+		for _, value := range values {
+			if platform, ok := value.(string); ok {
+				platforms = append(platforms, platform)
+			}
+		}
+	}
+	platformEntry := widget.NewSelectEntry(platforms)
+	platformEntry.SetPlaceHolder("Platform played on")
+	platformEntry.SetText(current.Platform)
+
+	hoursEntry := widget.NewEntry()
+	hoursEntry.SetPlaceHolder("Optional")
+	if current.HoursPlayed != nil {
+		hoursEntry.SetText(strconv.FormatFloat(*current.HoursPlayed, 'f', -1, 64))
+	}
+
+	completions := []string{"", "main_story", "main_extras", "completionist"}
+	completionOptions := make([]string, 0, len(completions))
+	for _, completion := range completions {
+		completionOptions = append(completionOptions, videogameCompletionLabels[completion])
+	}
+	completionSelect := widget.NewSelect(completionOptions, nil)
+	completionSelect.SetSelected(videogameCompletionLabels[current.Completion])
+
+	earnedEntry := widget.NewEntry()
+	earnedEntry.SetPlaceHolder("Earned")
+	if current.AchievementsEarned != nil {
+		earnedEntry.SetText(strconv.Itoa(*current.AchievementsEarned))
+	}
+	totalEntry := widget.NewEntry()
+	totalEntry.SetPlaceHolder("Total")
+	if current.AchievementsTotal != nil {
+		totalEntry.SetText(strconv.Itoa(*current.AchievementsTotal))
+	}
+
+	videogameDialog := dialog.NewForm(fmt.Sprintf("%s: platform and completion", node.Title), "Save", "Cancel", []*widget.FormItem{
+		widget.NewFormItem("Platform", platformEntry),
+		widget.NewFormItem("Hours played", hoursEntry),
+		widget.NewFormItem("Completion", completionSelect),
+		widget.NewFormItem("Achievements", container.NewGridWithColumns(3, earnedEntry, widget.NewLabelWithStyle("out of", fyne.TextAlignCenter, fyne.TextStyle{}), totalEntry)),
+	}, func(b bool) {
+		if !b {
+			return
+		}
+		details := models.VideogameDetails{
+			Platform: strings.TrimSpace(platformEntry.Text),
+		}
+		if text := strings.TrimSpace(hoursEntry.Text); text != "" {
+			hours, err := strconv.ParseFloat(strings.Replace(text, ",", ".", 1), 64)
+			if err != nil {
+				dialog.ShowInformation("Info", "Hours played must be a number", appCtxt.MainWindow)
+				return
+			}
+			details.HoursPlayed = &hours
+		}
+		for _, completion := range completions {
+			if videogameCompletionLabels[completion] == completionSelect.Selected {
+				details.Completion = completion
+			}
+		}
+		if text := strings.TrimSpace(earnedEntry.Text); text != "" {
+			earned, err := strconv.Atoi(text)
+			if err != nil {
+				dialog.ShowInformation("Info", "Achievements earned must be a whole number", appCtxt.MainWindow)
+				return
+			}
+			details.AchievementsEarned = &earned
+		}
+		if text := strings.TrimSpace(totalEntry.Text); text != "" {
+			total, err := strconv.Atoi(text)
+			if err != nil {
+				dialog.ShowInformation("Info", "Total of achievements must be a whole number", appCtxt.MainWindow)
+				return
+			}
+			details.AchievementsTotal = &total
+		}
+
+		_, err := appCtxt.APIClient.Videogames.UpdateRecordVideogame(recordID, details)
+		switch err {
+		case nil:
+			appCtxt.PageManager.ShowShelfPage()
+		case models.ErrBadRequest:
+			dialog.ShowInformation("Info", "There is a problem with your details:\n- Hours and achievements can't be negative\nAND/OR\n- Achievements earned can't be more than their total", appCtxt.MainWindow)
+		default:
+			dialog.ShowError(err, appCtxt.MainWindow)
+		}
+	}, appCtxt.MainWindow)
+	videogameDialog.Resize(fyne.NewSize(500, 350))
+	videogameDialog.Show()
+}
+
+// Button function
+func buttonFuncLogConsumption(appCtxt *context.AppContext, node TreeNode, mediaType string, mediaList []models.MediumWithRecord) {
+	record, err := appCtxt.APIClient.Records.CreateRecord(node.Value, "", "", "")
+	if err != nil {
+		dialog.ShowError(err, appCtxt.MainWindow)
+		return
+	}
+
+	// Open the record page on the new, empty, consumption
+	for _, medium := range mediaList {
+		if medium.MediaID == node.Value {
+			medium.ID = record.ID
+			medium.IsFinished = false
+			medium.Status = record.Status
+			medium.AbandonReason = ""
+			medium.StartDate = ""
+			medium.EndDate = ""
+			medium.Duration = 0
+			medium.Comments = ""
+			appCtxt.PageManager.ShowUpdateRecordPage(mediaType, node.Value, []models.MediumWithRecord{medium})
+			return
+		}
+	}
+}
+
+// Helper function to display one consumption of a medium on a single line
+func formatConsumption(appCtxt *context.AppContext, record models.Record) string {
+	if record.StartDate == "" {
+		return fmt.Sprintf("not started yet (%s)", strings.ToLower(recordStatusTitles[record.Status]))
+	}
+	startDate, err := appCtxt.APIClient.Helpers.FormatDateToLocalFormat(record.StartDate)
+	if err != nil {
+		startDate = record.StartDate
+	}
+	if record.EndDate == "" {
+		return fmt.Sprintf("began %s (%s)", startDate, strings.ToLower(recordStatusTitles[record.Status]))
+	}
+	endDate, err := appCtxt.APIClient.Helpers.FormatDateToLocalFormat(record.EndDate)
+	if err != nil {
+		endDate = record.EndDate
+	}
+	if record.Status == "abandoned" {
+		return fmt.Sprintf("%s - %s, abandoned", startDate, endDate)
+	}
+	return fmt.Sprintf("%s - %s (%d days)", startDate, endDate, record.Duration)
+}
+
+// Helper function to display a loan on a single line
+func formatLoan(appCtxt *context.AppContext, loan models.Loan) string {
+	lentAt, err := appCtxt.APIClient.Helpers.FormatDateToLocalFormat(loan.LentAt)
+	if err != nil {
+		lentAt = loan.LentAt
+	}
+	text := fmt.Sprintf("to %s since %s", loan.BorrowerName, lentAt)
+	if loan.ReturnedAt != "" {
+		returnedAt, err := appCtxt.APIClient.Helpers.FormatDateToLocalFormat(loan.ReturnedAt)
+		if err != nil {
+			returnedAt = loan.ReturnedAt
+		}
+		return fmt.Sprintf("to %s, %s - %s", loan.BorrowerName, lentAt, returnedAt)
+	}
+	if loan.DueAt != "" {
+		dueAt, err := appCtxt.APIClient.Helpers.FormatDateToLocalFormat(loan.DueAt)
+		if err != nil {
+			dueAt = loan.DueAt
+		}
+		text = fmt.Sprintf("%s, expected back %s", text, dueAt)
+	}
+	switch {
+	case loan.DaysOverdue > 0:
+		text = fmt.Sprintf("%s (%d days overdue)", text, loan.DaysOverdue)
+	case loan.IsOverdue:
+		text = fmt.Sprintf("%s (overdue)", text)
+	}
+	return text
+}
+
+// Get user's media currently lent out, by medium's ID
+// Media are just not flagged if loans can't be fetched
+func lentOutMedia(appCtxt *context.AppContext) map[string]models.Loan {
+	lentOut := make(map[string]models.Loan)
+	loans, err := appCtxt.APIClient.Loans.GetLoans()
+	if err != nil {
+		log.Printf("--GUI-- couldn't get loans: %v", err)
+		return lentOut
+	}
+	for _, loan := range loans.LentOut {
+		lentOut[loan.MediumID] = loan
+	}
+	return lentOut
+}
+
+// Format a play's date in local format
+func formatPlayDate(appCtxt *context.AppContext, playedAt string) string {
+	date, err := appCtxt.APIClient.Helpers.FormatDateToLocalFormat(playedAt)
+	if err != nil {
+		return playedAt
+	}
+	return date
+}
+
+// Format how many times a boardgame was played, and when last
+func formatGamePlays(appCtxt *context.AppContext, game models.GamePlays) string {
+	switch game.PlaysCount {
+	case 0:
+		return "never played yet"
+	case 1:
+		return fmt.Sprintf("once, on %s", formatPlayDate(appCtxt, game.LastPlayedAt))
+	}
+	return fmt.Sprintf("%d times, last on %s", game.PlaysCount, formatPlayDate(appCtxt, game.LastPlayedAt))
+}
+
+// Get user's play counts by boardgame's ID, only fetched if the list holds boardgames
+// Boardgames just show no play count if counts can't be fetched
+func playedGames(appCtxt *context.AppContext, mediaList []models.MediumWithRecord) map[string]models.GamePlays {
+	if !slices.ContainsFunc(mediaList, func(medium models.MediumWithRecord) bool { return medium.MediaType == "boardgame" }) {
+		return nil
+	}
+	counts, err := appCtxt.APIClient.Plays.GetPlayCounts()
+	if err != nil {
+		log.Printf("--GUI-- couldn't get play counts: %v", err)
+		return nil
+	}
+	gamePlays := make(map[string]models.GamePlays)
+	for _, game := range counts.Games {
+		gamePlays[game.MediumID] = game
+	}
+	return gamePlays
+}
+
+// Episode of a series record a node of the tree stands for, the whole season for a season node
+type episodeNode struct {
+	RecordID string
+	MediumID string
+	Episode  models.EpisodeRef
+	Watched  bool
+}
+
+// Get episodes watched of user's series, by record's ID
+// Series just show no episodes if they can't be fetched
+func watchedEpisodes(appCtxt *context.AppContext, mediaList []models.MediumWithRecord) map[string]models.RecordEpisodes {
+	seriesEpisodes := make(map[string]models.RecordEpisodes)
+	for _, medium := range mediaList {
+		if medium.MediaType != "series" {
+			continue
+		}
+		recordEpisodes, err := appCtxt.APIClient.Episodes.GetRecordEpisodes(medium.ID)
+		if err != nil {
+			log.Printf("--GUI-- couldn't get episodes of %s: %v", medium.Title, err)
+			continue
+		}
+		seriesEpisodes[medium.ID] = recordEpisodes
+	}
+	return seriesEpisodes
+}
+
+// Fill the episodes branch of a series record with the next episode to watch, its seasons and their episodes
+// Nodes already there are replaced, so it is called again once episodes are marked or unmarked
+func addEpisodesNodes(appCtxt *context.AppContext, treeData map[string][]string, nodes map[string]TreeNode, episodeNodes map[string]episodeNode, episodesNodeID, mediumID string, recordEpisodes models.RecordEpisodes) {
+	parentID := strings.TrimSuffix(episodesNodeID, "-episodes")
+	nodes[episodesNodeID] = TreeNode{
+		ID:       episodesNodeID,
+		ParentID: parentID,
+		Title:    formatEpisodesProgress(recordEpisodes),
+		NodeType: "sub_title",
+	}
+	treeData[episodesNodeID] = []string{}
+
+	// Next episode Leaf node
+	if next := recordEpisodes.NextEpisode; next != nil {
+		nextNodeID := fmt.Sprintf("%s-next", episodesNodeID)
+		treeData[episodesNodeID] = append(treeData[episodesNodeID], nextNodeID)
+		nodes[nextNodeID] = TreeNode{
+			ID:       nextNodeID,
+			ParentID: episodesNodeID,
+			Value:    fmt.Sprintf("Next: season %d, episode %d", next.SeasonNumber, next.EpisodeNumber),
+			NodeType: "single_line",
+		}
+	}
+
+	watched := make(map[models.EpisodeRef]models.RecordEpisode)
+	for _, episode := range recordEpisodes.Episodes {
+		watched[models.EpisodeRef{SeasonNumber: episode.SeasonNumber, EpisodeNumber: episode.EpisodeNumber}] = episode
+	}
+
+	for _, season := range recordEpisodes.Seasons {
+		// Season Branch node
+		seasonNodeID := fmt.Sprintf("%s-%d", episodesNodeID, season.SeasonNumber)
+		treeData[episodesNodeID] = append(treeData[episodesNodeID], seasonNodeID)
+		treeData[seasonNodeID] = []string{}
+		title := fmt.Sprintf("Season %d (%d/%d)", season.SeasonNumber, season.WatchedCount, season.EpisodeCount)
+		if season.EpisodeCount == 0 {
+			title = fmt.Sprintf("Season %d (%d watched)", season.SeasonNumber, season.WatchedCount)
+		}
+		nodes[seasonNodeID] = TreeNode{
+			ID:       seasonNodeID,
+			ParentID: episodesNodeID,
+			Title:    title,
+			NodeType: "season",
+		}
+		episodeNodes[seasonNodeID] = episodeNode{
+			RecordID: recordEpisodes.RecordID,
+			MediumID: mediumID,
+			Episode:  models.EpisodeRef{SeasonNumber: season.SeasonNumber},
+			Watched:  season.EpisodeCount > 0 && season.WatchedCount >= season.EpisodeCount,
+		}
+
+		// Episodes watched beyond the count of medium's metadata are listed too
+		count := season.EpisodeCount
+		for ref := range watched {
+			if ref.SeasonNumber == season.SeasonNumber && ref.EpisodeNumber > count {
+				count = ref.EpisodeNumber
+			}
+		}
+
+		// Episode Leaf nodes
+		for number := 1; number <= count; number++ {
+			ref := models.EpisodeRef{SeasonNumber: season.SeasonNumber, EpisodeNumber: number}
+			episode, isWatched := watched[ref]
+			episodeNodeID := fmt.Sprintf("%s-%d", seasonNodeID, number)
+			treeData[seasonNodeID] = append(treeData[seasonNodeID], episodeNodeID)
+			value := ""
+			if isWatched {
+				value = formatWatchedEpisode(appCtxt, episode)
+			}
+			nodes[episodeNodeID] = TreeNode{
+				ID:       episodeNodeID,
+				ParentID: seasonNodeID,
+				Title:    fmt.Sprintf("Episode %d", number),
+				Value:    value,
+				NodeType: "episode",
+			}
+			episodeNodes[episodeNodeID] = episodeNode{
+				RecordID: recordEpisodes.RecordID,
+				MediumID: mediumID,
+				Episode:  ref,
+				Watched:  isWatched,
+			}
+		}
+	}
+}
+
+// Format how many episodes of a series were watched
+func formatEpisodesProgress(recordEpisodes models.RecordEpisodes) string {
+	if recordEpisodes.TotalEpisodes == nil {
+		return fmt.Sprintf("Episodes: %d watched", recordEpisodes.WatchedCount)
+	}
+	return fmt.Sprintf("Episodes: %d/%d watched", recordEpisodes.WatchedCount, *recordEpisodes.TotalEpisodes)
+}
+
+// Format when an episode was watched, with its rating on user's scale
+func formatWatchedEpisode(appCtxt *context.AppContext, episode models.RecordEpisode) string {
+	watchedOn, err := appCtxt.APIClient.Helpers.FormatDateToLocalFormat(episode.WatchedAt)
+	if err != nil {
+		watchedOn = episode.WatchedAt
+	}
+	if episode.Rating == nil {
+		return fmt.Sprintf("watched on %s", watchedOn)
+	}
+	return fmt.Sprintf("watched on %s, rated %s", watchedOn, formatRecordRating(appCtxt, episode.Rating))
+}
+
+// Button function
+// Checking a season marks its episodes not watched yet, unchecking it unmarks the watched ones
+func buttonFuncToggleEpisodes(appCtxt *context.AppContext, tree *widget.Tree, treeData map[string][]string, nodes map[string]TreeNode, episodeNodes map[string]episodeNode, nodeID string, checked bool) {
+	target := episodeNodes[nodeID]
+	var episodes []models.EpisodeRef
+	if nodes[nodeID].NodeType == "season" {
+		for _, childID := range treeData[nodeID] {
+			if child := episodeNodes[childID]; child.Watched != checked {
+				episodes = append(episodes, child.Episode)
+			}
+		}
+	} else {
+		episodes = append(episodes, target.Episode)
+	}
+	if len(episodes) == 0 {
+		tree.Refresh()
+		return
+	}
+
+	var recordEpisodes models.RecordEpisodes
+	var err error
+	if checked {
+		recordEpisodes, err = appCtxt.APIClient.Episodes.MarkEpisodes(target.RecordID, episodes, "", nil)
+	} else {
+		recordEpisodes, err = appCtxt.APIClient.Episodes.UnmarkEpisodes(target.RecordID, episodes)
+	}
+	switch err {
+	case nil:
+	case models.ErrBadRequest:
+		dialog.ShowInformation("Info", "These episodes aren't part of the series' seasons", appCtxt.MainWindow)
+		tree.Refresh()
+		return
+	default:
+		dialog.ShowError(err, appCtxt.MainWindow)
+		tree.Refresh()
+		return
+	}
+
+	// Record moved to another status branch, the whole shelf is shown again
+	mediaNodeID := fmt.Sprintf("media-%s", target.RecordID)
+	if recordEpisodes.Status != nodes[mediaNodeID].ParentID {
+		appCtxt.PageManager.ShowShelfPage()
+		return
+	}
+	addEpisodesNodes(appCtxt, treeData, nodes, episodeNodes, fmt.Sprintf("%s-episodes", mediaNodeID), target.MediumID, recordEpisodes)
+	tree.Refresh()
+}
+
+// Button function
+func buttonFuncSeasonTitles(appCtxt *context.AppContext, season episodeNode) {
+	details, err := appCtxt.APIClient.External.GetTvSeasonDetails(season.MediumID, season.Episode.SeasonNumber)
+	switch err {
+	case nil:
+	case models.ErrBadRequest, models.ErrNotFound:
+		dialog.ShowInformation("Info", "Episodes' titles can't be found for this series", appCtxt.MainWindow)
+		return
+	default:
+		dialog.ShowError(err, appCtxt.MainWindow)
+		return
+	}
+
+	titles := container.NewVBox()
+	for _, episode := range details.Episodes {
+		line := fmt.Sprintf("%d. %s", episode.EpisodeNumber, episode.Name)
+		if episode.AirDate != "" {
+			line += fmt.Sprintf(" (%s)", episode.AirDate)
+		}
+		titles.Add(widget.NewLabel(line))
+	}
+	titlesDialog := dialog.NewCustom(fmt.Sprintf("Season %d", season.Episode.SeasonNumber), "Close", container.NewVScroll(titles), appCtxt.MainWindow)
+	titlesDialog.Resize(fyne.NewSize(500, 500))
+	titlesDialog.Show()
+}
+
+// Format record's rating on user's scale
+func formatRecordRating(appCtxt *context.AppContext, rating *int16) string {
+	if rating == nil {
+		return "not rated"
+	}
+	return appCtxt.APIClient.Helpers.FormatRating(float64(*rating))
+}
+
+// Format medium's average rating on user's scale, with the number of users who rated it
+func formatAverageRating(appCtxt *context.AppContext, medium models.MediumWithRecord) string {
+	switch medium.RatingCount {
+	case 0:
+		return "not rated yet"
+	case 1:
+		return fmt.Sprintf("%s (1 rating)", appCtxt.APIClient.Helpers.FormatRating(medium.RatingAverage))
+	}
+	return fmt.Sprintf("%s (%d ratings)", appCtxt.APIClient.Helpers.FormatRating(medium.RatingAverage), medium.RatingCount)
+}
+
+// Button function
+func buttonFuncMediumDelete(appCtxt *context.AppContext, node TreeNode, mediaList []models.MediumWithRecord) {
 	// First dialog : sure to delete ?
 	dialog.ShowConfirm("Confirm", fmt.Sprintf("Are you sure you want to delete this medium: %s ?", node.Title), func(b bool) {
 		if b {
 			// If yes, second dialog : what to delete ?
 			line0 := canvas.NewText("What do you want to delete ?", color.White)
 			line0.Alignment = fyne.TextAlignCenter
-			line1 := canvas.NewText("This medium AND your personal record about it", color.White)
+			line1 := canvas.NewText("This medium AND your personal records about it", color.White)
 			line1.Alignment = fyne.TextAlignCenter
 			line2 := canvas.NewText("OR", color.White)
 			line2.Alignment = fyne.TextAlignCenter
-			line3 := canvas.NewText("Just your personal record", color.White)
+			line3 := canvas.NewText("Just your personal records (every consumption of it)", color.White)
 			line3.Alignment = fyne.TextAlignCenter
 			line4 := canvas.NewText("(This allows you or other user to retrieve this medium later from server's database)", color.White)
 			line4.Alignment = fyne.TextAlignCenter
@@ -441,15 +1451,32 @@ func buttonFuncMediumDelete(appCtxt *context.AppContext, node TreeNode) {
 					if b { // Medium and record delete
 						dialog.ShowConfirm("Last Warning", "Are you sure you want to delete both the medium and your record ?", func(b bool) {
 							if b {
-								appCtxt.APIClient.Media.DeleteMedium(node.Value) // Deleting a medium will automatically delete linked record on cascade
-								dialog.ShowInformation("Info", "Medium and Record deleted !", appCtxt.MainWindow)
+								// Server deletes user's record too, but keeps the medium if it isn't allowed to delete it
+								mediumDeleted, err := appCtxt.APIClient.Media.DeleteMedium(node.Value)
+								switch {
+								case err == models.ErrForbidden:
+									dialog.ShowInformation("Info", "You are not allowed to delete this medium:\nit was added by another user or is still in other users' shelves", appCtxt.MainWindow)
+									return
+								case err != nil:
+									dialog.ShowError(err, appCtxt.MainWindow)
+									return
+								case !mediumDeleted:
+									dialog.ShowInformation("Info", "Record deleted !\nThe medium is kept on server, as you are not its owner or other users still have it in their shelves", appCtxt.MainWindow)
+								default:
+									dialog.ShowInformation("Info", "Medium and Record deleted !", appCtxt.MainWindow)
+								}
 								appCtxt.PageManager.ShowHomePage()
 							}
 						}, appCtxt.MainWindow)
 					} else { // Record delete only
-						dialog.ShowConfirm("Last Warning", "Are you sure you want to delete your personal record about this medium ?", func(b bool) {
+						dialog.ShowConfirm("Last Warning", "Are you sure you want to delete your personal records about this medium ?", func(b bool) {
 							if b {
-								appCtxt.APIClient.Records.DeleteRecord(node.Value)
+								for _, recordID := range mediumRecordIDs(node.Value, mediaList) {
+									if err := appCtxt.APIClient.Records.DeleteRecord(recordID); err != nil {
+										dialog.ShowError(err, appCtxt.MainWindow)
+										return
+									}
+								}
 								dialog.ShowInformation("Info", "Personal Record deleted !", appCtxt.MainWindow)
 								appCtxt.PageManager.ShowHomePage()
 							}
@@ -460,3 +1487,20 @@ func buttonFuncMediumDelete(appCtxt *context.AppContext, node TreeNode) {
 		}
 	}, appCtxt.MainWindow)
 }
+
+// Helper function to get the IDs of every user's record about a medium
+func mediumRecordIDs(mediumID string, mediaList []models.MediumWithRecord) []string {
+	recordIDs := []string{}
+	for _, medium := range mediaList {
+		if medium.MediaID != mediumID {
+			continue
+		}
+		if len(medium.History) == 0 {
+			return []string{medium.ID}
+		}
+		for _, record := range medium.History {
+			recordIDs = append(recordIDs, record.ID)
+		}
+	}
+	return recordIDs
+}
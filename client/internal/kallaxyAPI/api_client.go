@@ -14,16 +14,28 @@ type APIClient struct {
 	CurrentUser   models.ClientUser
 	ClientVersion string
 	ServerVersion string
+	// Scale user rates media on: "5" (stars, by halves), "10" or "100"
+	RatingScale string
 
 	Cache *cache.Cache
 
-	Users    *UsersClient
-	Media    *MediaClient
-	Records  *RecordsClient
-	Auth     *AuthClient
-	External *ExternalAPIClient
-	Admin    *AdminClient
-	Helpers  *HelpersClient
+	Users      *UsersClient
+	Media      *MediaClient
+	Records    *RecordsClient
+	Tags       *TagsClient
+	Shelves    *ShelvesClient
+	Reviews    *ReviewsClient
+	Loans      *LoansClient
+	Copies     *CopiesClient
+	Shelving   *ShelvingClient
+	Plays      *PlaysClient
+	Videogames *VideogamesClient
+	Episodes   *EpisodesClient
+	Goals      *GoalsClient
+	Auth       *AuthClient
+	External   *ExternalAPIClient
+	Admin      *AdminClient
+	Helpers    *HelpersClient
 }
 
 type UsersClient struct {
@@ -38,6 +50,46 @@ type RecordsClient struct {
 	apiClient *APIClient // Reference back to the parent
 }
 
+type TagsClient struct {
+	apiClient *APIClient // Reference back to the parent
+}
+
+type ShelvesClient struct {
+	apiClient *APIClient // Reference back to the parent
+}
+
+type ReviewsClient struct {
+	apiClient *APIClient // Reference back to the parent
+}
+
+type LoansClient struct {
+	apiClient *APIClient // Reference back to the parent
+}
+
+type CopiesClient struct {
+	apiClient *APIClient // Reference back to the parent
+}
+
+type ShelvingClient struct {
+	apiClient *APIClient // Reference back to the parent
+}
+
+type PlaysClient struct {
+	apiClient *APIClient // Reference back to the parent
+}
+
+type VideogamesClient struct {
+	apiClient *APIClient // Reference back to the parent
+}
+
+type EpisodesClient struct {
+	apiClient *APIClient // Reference back to the parent
+}
+
+type GoalsClient struct {
+	apiClient *APIClient // Reference back to the parent
+}
+
 type AuthClient struct {
 	apiClient *APIClient // Reference back to the parent
 }
@@ -78,6 +130,16 @@ func NewApiClient(baseURL string) *APIClient {
 	apiClient.Users = &UsersClient{apiClient: apiClient}
 	apiClient.Media = &MediaClient{apiClient: apiClient}
 	apiClient.Records = &RecordsClient{apiClient: apiClient}
+	apiClient.Tags = &TagsClient{apiClient: apiClient}
+	apiClient.Shelves = &ShelvesClient{apiClient: apiClient}
+	apiClient.Reviews = &ReviewsClient{apiClient: apiClient}
+	apiClient.Loans = &LoansClient{apiClient: apiClient}
+	apiClient.Copies = &CopiesClient{apiClient: apiClient}
+	apiClient.Shelving = &ShelvingClient{apiClient: apiClient}
+	apiClient.Plays = &PlaysClient{apiClient: apiClient}
+	apiClient.Videogames = &VideogamesClient{apiClient: apiClient}
+	apiClient.Episodes = &EpisodesClient{apiClient: apiClient}
+	apiClient.Goals = &GoalsClient{apiClient: apiClient}
 	apiClient.Auth = &AuthClient{apiClient: apiClient}
 	apiClient.External = &ExternalAPIClient{apiClient: apiClient}
 	apiClient.Admin = &AdminClient{apiClient: apiClient}
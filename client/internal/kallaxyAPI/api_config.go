@@ -10,6 +10,16 @@ type Endpoints struct {
 	Users         UsersEndpoints
 	Media         MediaEndpoints
 	Records       RecordsEndpoints
+	Tags          TagsEndpoints
+	Shelves       ShelvesEndpoints
+	Reviews       ReviewsEndpoints
+	Loans         LoansEndpoints
+	Copies        CopiesEndpoints
+	Shelving      ShelvingEndpoints
+	Plays         PlaysEndpoints
+	Videogames    VideogamesEndpoints
+	Episodes      EpisodesEndpoints
+	Goals         GoalsEndpoints
 	Auth          AuthEndpoints
 	PasswordReset PasswordResetEndpoints
 	ExternalAPI   ExternalApiEndpoints
@@ -28,19 +38,110 @@ type UsersEndpoints struct {
 }
 
 type MediaEndpoints struct {
-	CreateMedia             Endpoint
-	GetMediumByTitleAndType Endpoint
-	GetMediaByType          Endpoint
-	GetMediaWithRecords     Endpoint
-	UpdateMedia             Endpoint
-	DeleteMedia             Endpoint
+	CreateMedia            Endpoint
+	GetMediaByTitleAndType Endpoint
+	GetMediaByType         Endpoint
+	GetMediaWithRecords    Endpoint
+	UpdateMedia            Endpoint
+	DeleteMedia            Endpoint
+	RefreshMedia           Endpoint
 }
 
 type RecordsEndpoints struct {
-	CreateRecord Endpoint
-	GetRecord    Endpoint
-	UpdateRecord Endpoint
-	DeleteRecord Endpoint
+	CreateRecord      Endpoint
+	GetRecord         Endpoint
+	UpdateRecord      Endpoint
+	DeleteRecord      Endpoint
+	GetRecordProgress Endpoint
+}
+
+type TagsEndpoints struct {
+	CreateTag  Endpoint
+	GetTags    Endpoint
+	UpdateTag  Endpoint
+	DeleteTag  Endpoint
+	TagMedia   Endpoint
+	UntagMedia Endpoint
+}
+
+type ShelvesEndpoints struct {
+	CreateShelf          Endpoint
+	GetShelves           Endpoint
+	UpdateShelf          Endpoint
+	DeleteShelf          Endpoint
+	AddMediaToShelf      Endpoint
+	RemoveMediaFromShelf Endpoint
+}
+
+type ReviewsEndpoints struct {
+	CreateReview       Endpoint
+	GetReview          Endpoint
+	UpdateReview       Endpoint
+	DeleteReview       Endpoint
+	GetReviewRevisions Endpoint
+	GetMediumReviews   Endpoint
+}
+
+type LoansEndpoints struct {
+	CreateLoan      Endpoint
+	GetLoans        Endpoint
+	GetOverdueLoans Endpoint
+	UpdateLoan      Endpoint
+	ReturnLoan      Endpoint
+	DeleteLoan      Endpoint
+	GetMediumLoans  Endpoint
+}
+
+type CopiesEndpoints struct {
+	CreateCopy         Endpoint
+	GetCopies          Endpoint
+	UpdateCopy         Endpoint
+	DeleteCopy         Endpoint
+	GetCollectionValue Endpoint
+	GetMediumCopies    Endpoint
+}
+
+type ShelvingEndpoints struct {
+	CreateShelvingUnit  Endpoint
+	GetShelvingUnits    Endpoint
+	UpdateShelvingUnit  Endpoint
+	DeleteShelvingUnit  Endpoint
+	GetShelvingUnitGrid Endpoint
+	PlaceMedium         Endpoint
+	RemoveMedium        Endpoint
+	WhereIsMedium       Endpoint
+}
+
+type PlaysEndpoints struct {
+	CreatePlay        Endpoint
+	GetPlays          Endpoint
+	UpdatePlay        Endpoint
+	DeletePlay        Endpoint
+	GetPlayCounts     Endpoint
+	GetPlayerWinRates Endpoint
+	GetPlaysHIndex    Endpoint
+}
+
+type VideogamesEndpoints struct {
+	UpdateRecordVideogame Endpoint
+	GetRecordVideogame    Endpoint
+	GetPlatformStats      Endpoint
+	GetBacklog            Endpoint
+}
+
+type EpisodesEndpoints struct {
+	MarkEpisodes      Endpoint
+	UnmarkEpisodes    Endpoint
+	GetRecordEpisodes Endpoint
+	GetNextEpisodes   Endpoint
+}
+
+type GoalsEndpoints struct {
+	CreateGoal Endpoint
+	GetGoals   Endpoint
+	UpdateGoal Endpoint
+	DeleteGoal Endpoint
+	GetStreak  Endpoint
 }
 
 type AuthEndpoints struct {
@@ -77,6 +178,7 @@ type MoviesTvProxy struct {
 	Search          Endpoint
 	GetDetails      Endpoint
 	GetMovieCredits Endpoint
+	GetTvSeason     Endpoint
 }
 
 type VideogamesProxy struct {
@@ -118,7 +220,7 @@ func initApiConfig(baseURL string) *APIConfig {
 					Method: "POST",
 					Path:   "/api/media",
 				},
-				GetMediumByTitleAndType: Endpoint{
+				GetMediaByTitleAndType: Endpoint{
 					Method: "GET",
 					Path:   "/api/media",
 				},
@@ -138,6 +240,10 @@ func initApiConfig(baseURL string) *APIConfig {
 					Method: "DELETE",
 					Path:   "/api/media",
 				},
+				RefreshMedia: Endpoint{
+					Method: "POST",
+					Path:   "/api/media/{id}/refresh",
+				},
 			},
 			Records: RecordsEndpoints{
 				CreateRecord: Endpoint{
@@ -156,6 +262,266 @@ func initApiConfig(baseURL string) *APIConfig {
 					Method: "DELETE",
 					Path:   "/api/records",
 				},
+				GetRecordProgress: Endpoint{
+					Method: "GET",
+					Path:   "/api/records/progress",
+				},
+			},
+			Tags: TagsEndpoints{
+				CreateTag: Endpoint{
+					Method: "POST",
+					Path:   "/api/tags",
+				},
+				GetTags: Endpoint{
+					Method: "GET",
+					Path:   "/api/tags",
+				},
+				UpdateTag: Endpoint{
+					Method: "PUT",
+					Path:   "/api/tags",
+				},
+				DeleteTag: Endpoint{
+					Method: "DELETE",
+					Path:   "/api/tags",
+				},
+				TagMedia: Endpoint{
+					Method: "POST",
+					Path:   "/api/tags/media",
+				},
+				UntagMedia: Endpoint{
+					Method: "DELETE",
+					Path:   "/api/tags/media",
+				},
+			},
+			Shelves: ShelvesEndpoints{
+				CreateShelf: Endpoint{
+					Method: "POST",
+					Path:   "/api/shelves",
+				},
+				GetShelves: Endpoint{
+					Method: "GET",
+					Path:   "/api/shelves",
+				},
+				UpdateShelf: Endpoint{
+					Method: "PUT",
+					Path:   "/api/shelves",
+				},
+				DeleteShelf: Endpoint{
+					Method: "DELETE",
+					Path:   "/api/shelves",
+				},
+				AddMediaToShelf: Endpoint{
+					Method: "POST",
+					Path:   "/api/shelves/media",
+				},
+				RemoveMediaFromShelf: Endpoint{
+					Method: "DELETE",
+					Path:   "/api/shelves/media",
+				},
+			},
+			Reviews: ReviewsEndpoints{
+				CreateReview: Endpoint{
+					Method: "POST",
+					Path:   "/api/reviews",
+				},
+				GetReview: Endpoint{
+					Method: "GET",
+					Path:   "/api/reviews",
+				},
+				UpdateReview: Endpoint{
+					Method: "PUT",
+					Path:   "/api/reviews",
+				},
+				DeleteReview: Endpoint{
+					Method: "DELETE",
+					Path:   "/api/reviews",
+				},
+				GetReviewRevisions: Endpoint{
+					Method: "GET",
+					Path:   "/api/reviews/revisions",
+				},
+				GetMediumReviews: Endpoint{
+					Method: "GET",
+					Path:   "/api/media/reviews",
+				},
+			},
+			Loans: LoansEndpoints{
+				CreateLoan: Endpoint{
+					Method: "POST",
+					Path:   "/api/loans",
+				},
+				GetLoans: Endpoint{
+					Method: "GET",
+					Path:   "/api/loans",
+				},
+				GetOverdueLoans: Endpoint{
+					Method: "GET",
+					Path:   "/api/loans/overdue",
+				},
+				UpdateLoan: Endpoint{
+					Method: "PUT",
+					Path:   "/api/loans",
+				},
+				ReturnLoan: Endpoint{
+					Method: "PUT",
+					Path:   "/api/loans/return",
+				},
+				DeleteLoan: Endpoint{
+					Method: "DELETE",
+					Path:   "/api/loans",
+				},
+				GetMediumLoans: Endpoint{
+					Method: "GET",
+					Path:   "/api/media/loans",
+				},
+			},
+			Copies: CopiesEndpoints{
+				CreateCopy: Endpoint{
+					Method: "POST",
+					Path:   "/api/copies",
+				},
+				GetCopies: Endpoint{
+					Method: "GET",
+					Path:   "/api/copies",
+				},
+				UpdateCopy: Endpoint{
+					Method: "PUT",
+					Path:   "/api/copies",
+				},
+				DeleteCopy: Endpoint{
+					Method: "DELETE",
+					Path:   "/api/copies",
+				},
+				GetCollectionValue: Endpoint{
+					Method: "GET",
+					Path:   "/api/copies/value",
+				},
+				GetMediumCopies: Endpoint{
+					Method: "GET",
+					Path:   "/api/media/copies",
+				},
+			},
+			Shelving: ShelvingEndpoints{
+				CreateShelvingUnit: Endpoint{
+					Method: "POST",
+					Path:   "/api/shelving_units",
+				},
+				GetShelvingUnits: Endpoint{
+					Method: "GET",
+					Path:   "/api/shelving_units",
+				},
+				UpdateShelvingUnit: Endpoint{
+					Method: "PUT",
+					Path:   "/api/shelving_units",
+				},
+				DeleteShelvingUnit: Endpoint{
+					Method: "DELETE",
+					Path:   "/api/shelving_units",
+				},
+				GetShelvingUnitGrid: Endpoint{
+					Method: "GET",
+					Path:   "/api/shelving_units/grid",
+				},
+				PlaceMedium: Endpoint{
+					Method: "PUT",
+					Path:   "/api/shelving_units/items",
+				},
+				RemoveMedium: Endpoint{
+					Method: "DELETE",
+					Path:   "/api/shelving_units/items",
+				},
+				WhereIsMedium: Endpoint{
+					Method: "GET",
+					Path:   "/api/shelving_units/where",
+				},
+			},
+			Plays: PlaysEndpoints{
+				CreatePlay: Endpoint{
+					Method: "POST",
+					Path:   "/api/plays",
+				},
+				GetPlays: Endpoint{
+					Method: "GET",
+					Path:   "/api/plays",
+				},
+				UpdatePlay: Endpoint{
+					Method: "PUT",
+					Path:   "/api/plays",
+				},
+				DeletePlay: Endpoint{
+					Method: "DELETE",
+					Path:   "/api/plays",
+				},
+				GetPlayCounts: Endpoint{
+					Method: "GET",
+					Path:   "/api/plays/counts",
+				},
+				GetPlayerWinRates: Endpoint{
+					Method: "GET",
+					Path:   "/api/plays/win_rates",
+				},
+				GetPlaysHIndex: Endpoint{
+					Method: "GET",
+					Path:   "/api/plays/h_index",
+				},
+			},
+			Videogames: VideogamesEndpoints{
+				UpdateRecordVideogame: Endpoint{
+					Method: "PUT",
+					Path:   "/api/records/videogame",
+				},
+				GetRecordVideogame: Endpoint{
+					Method: "GET",
+					Path:   "/api/records/videogame",
+				},
+				GetPlatformStats: Endpoint{
+					Method: "GET",
+					Path:   "/api/videogames/platforms",
+				},
+				GetBacklog: Endpoint{
+					Method: "GET",
+					Path:   "/api/videogames/backlog",
+				},
+			},
+			Episodes: EpisodesEndpoints{
+				MarkEpisodes: Endpoint{
+					Method: "PUT",
+					Path:   "/api/records/episodes",
+				},
+				UnmarkEpisodes: Endpoint{
+					Method: "DELETE",
+					Path:   "/api/records/episodes",
+				},
+				GetRecordEpisodes: Endpoint{
+					Method: "GET",
+					Path:   "/api/records/episodes",
+				},
+				GetNextEpisodes: Endpoint{
+					Method: "GET",
+					Path:   "/api/records/episodes/next",
+				},
+			},
+			Goals: GoalsEndpoints{
+				CreateGoal: Endpoint{
+					Method: "POST",
+					Path:   "/api/goals",
+				},
+				GetGoals: Endpoint{
+					Method: "GET",
+					Path:   "/api/goals",
+				},
+				UpdateGoal: Endpoint{
+					Method: "PUT",
+					Path:   "/api/goals",
+				},
+				DeleteGoal: Endpoint{
+					Method: "DELETE",
+					Path:   "/api/goals",
+				},
+				GetStreak: Endpoint{
+					Method: "GET",
+					Path:   "/api/goals/streak",
+				},
 			},
 			Auth: AuthEndpoints{
 				Login: Endpoint{
@@ -233,6 +599,10 @@ func initApiConfig(baseURL string) *APIConfig {
 						Method: "GET",
 						Path:   "/external_api/movie_tv/movie_credits",
 					},
+					GetTvSeason: Endpoint{
+						Method: "GET",
+						Path:   "/external_api/movie_tv/tv_season",
+					},
 				},
 				Videogames: VideogamesProxy{
 					Search: Endpoint{
@@ -0,0 +1,190 @@
+package kallaxyapi
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/VincNT21/kallaxy/client/models"
+)
+
+// Add a copy of a medium on user's shelf, only details.Format is required
+func (c *CopiesClient) CreateCopy(mediumID string, details models.CopyDetails) (models.OwnedCopy, error) {
+	type parametersCreateCopy struct {
+		MediumID string `json:"medium_id"`
+		models.CopyDetails
+	}
+
+	// Convert input data to match server's requirement
+	if details.PurchaseDate != "" {
+		parsedPurchaseDate, err := c.apiClient.Helpers.FormatDateToServerFormat(details.PurchaseDate)
+		if err != nil {
+			return models.OwnedCopy{}, err
+		}
+		details.PurchaseDate = parsedPurchaseDate
+	}
+
+	params := parametersCreateCopy{
+		MediumID:    mediumID,
+		CopyDetails: details,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Copies.CreateCopy, params)
+	if err != nil {
+		log.Printf("--ERROR-- with CreateCopy(): %v\n", err)
+		return models.OwnedCopy{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var ownedCopy models.OwnedCopy
+	err = json.NewDecoder(r.Body).Decode(&ownedCopy)
+	if err != nil {
+		log.Printf("--ERROR-- with CreateCopy(): %v\n", err)
+		return models.OwnedCopy{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- CreateCopy() OK")
+	return ownedCopy, nil
+}
+
+// All copies owned by the user, by media type then title
+func (c *CopiesClient) GetCopies() (models.OwnedCopies, error) {
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Copies.GetCopies, nil)
+	if err != nil {
+		log.Printf("--ERROR-- with GetCopies(): %v\n", err)
+		return models.OwnedCopies{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var copies models.OwnedCopies
+	err = json.NewDecoder(r.Body).Decode(&copies)
+	if err != nil {
+		log.Printf("--ERROR-- with GetCopies(): %v\n", err)
+		return models.OwnedCopies{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- GetCopies() OK")
+	return copies, nil
+}
+
+// Every detail is replaced, empty ones are cleared
+func (c *CopiesClient) UpdateCopy(copyID string, details models.CopyDetails) (models.OwnedCopy, error) {
+	type parametersUpdateCopy struct {
+		CopyID string `json:"copy_id"`
+		models.CopyDetails
+	}
+
+	// Convert input data to match server's requirement
+	if details.PurchaseDate != "" {
+		parsedPurchaseDate, err := c.apiClient.Helpers.FormatDateToServerFormat(details.PurchaseDate)
+		if err != nil {
+			return models.OwnedCopy{}, err
+		}
+		details.PurchaseDate = parsedPurchaseDate
+	}
+
+	params := parametersUpdateCopy{
+		CopyID:      copyID,
+		CopyDetails: details,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Copies.UpdateCopy, params)
+	if err != nil {
+		log.Printf("--ERROR-- with UpdateCopy(): %v\n", err)
+		return models.OwnedCopy{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var ownedCopy models.OwnedCopy
+	err = json.NewDecoder(r.Body).Decode(&ownedCopy)
+	if err != nil {
+		log.Printf("--ERROR-- with UpdateCopy(): %v\n", err)
+		return models.OwnedCopy{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- UpdateCopy() OK")
+	return ownedCopy, nil
+}
+
+func (c *CopiesClient) DeleteCopy(copyID string) error {
+	type parametersCopy struct {
+		CopyID string `json:"copy_id"`
+	}
+
+	params := parametersCopy{
+		CopyID: copyID,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Copies.DeleteCopy, params)
+	if err != nil {
+		log.Printf("--ERROR-- with DeleteCopy(): %v\n", err)
+		return err
+	}
+	defer r.Body.Close()
+
+	log.Println("--DEBUG-- DeleteCopy() OK")
+	return nil
+}
+
+// Value of user's copies by media type and currency, prices are never converted
+func (c *CopiesClient) GetCollectionValue() (models.CollectionValues, error) {
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Copies.GetCollectionValue, nil)
+	if err != nil {
+		log.Printf("--ERROR-- with GetCollectionValue(): %v\n", err)
+		return models.CollectionValues{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var values models.CollectionValues
+	err = json.NewDecoder(r.Body).Decode(&values)
+	if err != nil {
+		log.Printf("--ERROR-- with GetCollectionValue(): %v\n", err)
+		return models.CollectionValues{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- GetCollectionValue() OK")
+	return values, nil
+}
+
+// All copies of a medium owned by the user, first added first
+func (c *CopiesClient) GetMediumCopies(mediumID string) (models.MediumCopies, error) {
+	type parametersGetMediumCopies struct {
+		MediumID string `json:"medium_id"`
+	}
+
+	params := parametersGetMediumCopies{
+		MediumID: mediumID,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Copies.GetMediumCopies, params)
+	if err != nil {
+		log.Printf("--ERROR-- with GetMediumCopies(): %v\n", err)
+		return models.MediumCopies{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var mediumCopies models.MediumCopies
+	err = json.NewDecoder(r.Body).Decode(&mediumCopies)
+	if err != nil {
+		log.Printf("--ERROR-- with GetMediumCopies(): %v\n", err)
+		return models.MediumCopies{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- GetMediumCopies() OK")
+	return mediumCopies, nil
+}
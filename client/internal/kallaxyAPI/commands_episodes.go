@@ -0,0 +1,132 @@
+package kallaxyapi
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/VincNT21/kallaxy/client/models"
+)
+
+// Episodes are watched now if watchedAt is empty, rating is on user's rating scale and can be nil
+func (c *EpisodesClient) MarkEpisodes(recordID string, episodes []models.EpisodeRef, watchedAt string, rating *float64) (models.RecordEpisodes, error) {
+	type parametersMarkEpisodes struct {
+		RecordID    string              `json:"record_id"`
+		Episodes    []models.EpisodeRef `json:"episodes"`
+		WatchedAt   string              `json:"watched_at"`
+		Rating      *float64            `json:"rating"`
+		RatingScale string              `json:"rating_scale"`
+	}
+
+	params := parametersMarkEpisodes{
+		RecordID:    recordID,
+		Episodes:    episodes,
+		WatchedAt:   watchedAt,
+		Rating:      rating,
+		RatingScale: c.apiClient.RatingScale,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Episodes.MarkEpisodes, params)
+	if err != nil {
+		log.Printf("--ERROR-- with MarkEpisodes(): %v\n", err)
+		return models.RecordEpisodes{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var recordEpisodes models.RecordEpisodes
+	err = json.NewDecoder(r.Body).Decode(&recordEpisodes)
+	if err != nil {
+		log.Printf("--ERROR-- with MarkEpisodes(): %v\n", err)
+		return models.RecordEpisodes{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- MarkEpisodes() OK")
+	return recordEpisodes, nil
+}
+
+func (c *EpisodesClient) UnmarkEpisodes(recordID string, episodes []models.EpisodeRef) (models.RecordEpisodes, error) {
+	type parametersUnmarkEpisodes struct {
+		RecordID string              `json:"record_id"`
+		Episodes []models.EpisodeRef `json:"episodes"`
+	}
+
+	params := parametersUnmarkEpisodes{
+		RecordID: recordID,
+		Episodes: episodes,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Episodes.UnmarkEpisodes, params)
+	if err != nil {
+		log.Printf("--ERROR-- with UnmarkEpisodes(): %v\n", err)
+		return models.RecordEpisodes{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var recordEpisodes models.RecordEpisodes
+	err = json.NewDecoder(r.Body).Decode(&recordEpisodes)
+	if err != nil {
+		log.Printf("--ERROR-- with UnmarkEpisodes(): %v\n", err)
+		return models.RecordEpisodes{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- UnmarkEpisodes() OK")
+	return recordEpisodes, nil
+}
+
+func (c *EpisodesClient) GetRecordEpisodes(recordID string) (models.RecordEpisodes, error) {
+	type parametersGetRecordEpisodes struct {
+		RecordID string `json:"record_id"`
+	}
+
+	params := parametersGetRecordEpisodes{
+		RecordID: recordID,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Episodes.GetRecordEpisodes, params)
+	if err != nil {
+		log.Printf("--ERROR-- with GetRecordEpisodes(): %v\n", err)
+		return models.RecordEpisodes{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var recordEpisodes models.RecordEpisodes
+	err = json.NewDecoder(r.Body).Decode(&recordEpisodes)
+	if err != nil {
+		log.Printf("--ERROR-- with GetRecordEpisodes(): %v\n", err)
+		return models.RecordEpisodes{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- GetRecordEpisodes() OK")
+	return recordEpisodes, nil
+}
+
+// Next episode of each series in progress, the series watched last first
+func (c *EpisodesClient) GetNextEpisodes() (models.NextEpisodes, error) {
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Episodes.GetNextEpisodes, nil)
+	if err != nil {
+		log.Printf("--ERROR-- with GetNextEpisodes(): %v\n", err)
+		return models.NextEpisodes{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var nextEpisodes models.NextEpisodes
+	err = json.NewDecoder(r.Body).Decode(&nextEpisodes)
+	if err != nil {
+		log.Printf("--ERROR-- with GetNextEpisodes(): %v\n", err)
+		return models.NextEpisodes{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- GetNextEpisodes() OK")
+	return nextEpisodes, nil
+}
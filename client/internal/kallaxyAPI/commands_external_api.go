@@ -190,6 +190,40 @@ func (c *ExternalAPIClient) GetSeriesDetails(seriesID string) (models.ResponseTv
 	return seriesDetails, nil
 }
 
+// Episodes of a season from TMDB, the series being found from the medium's TMDB ID
+func (c *ExternalAPIClient) GetTvSeasonDetails(mediumID string, seasonNumber int) (models.ResponseTvSeason, error) {
+	type parametersGetTvSeason struct {
+		MediumID     string `json:"medium_id"`
+		SeasonNumber int    `json:"season_number"`
+		Language     string `json:"language"`
+	}
+
+	params := parametersGetTvSeason{
+		MediumID:     mediumID,
+		SeasonNumber: seasonNumber,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.ExternalAPI.MoviesTV.GetTvSeason, params)
+	if err != nil {
+		log.Printf("--ERROR-- with GetTvSeasonDetails(): %v\n", err)
+		return models.ResponseTvSeason{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var season models.ResponseTvSeason
+	err = json.NewDecoder(r.Body).Decode(&season)
+	if err != nil {
+		log.Printf("--ERROR-- with GetTvSeasonDetails(): %v\n", err)
+		return models.ResponseTvSeason{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- GetTvSeasonDetails() OK")
+	return season, nil
+}
+
 func (c *ExternalAPIClient) SearchForVideogameOnPlatformByTitle(videogameTitle, platform string) (models.ResponseVideogameSearch, error) {
 	// Get right platform ID (based on RAWG)
 	var platformID string
@@ -0,0 +1,148 @@
+package kallaxyapi
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/VincNT21/kallaxy/client/models"
+)
+
+func (c *GoalsClient) CreateGoal(details models.GoalDetails) (models.Goal, error) {
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Goals.CreateGoal, details)
+	if err != nil {
+		log.Printf("--ERROR-- with CreateGoal(): %v\n", err)
+		return models.Goal{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var goal models.Goal
+	err = json.NewDecoder(r.Body).Decode(&goal)
+	if err != nil {
+		log.Printf("--ERROR-- with CreateGoal(): %v\n", err)
+		return models.Goal{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- CreateGoal() OK")
+	return goal, nil
+}
+
+// Goals with their progress, only the ones whose period holds now if currentOnly
+func (c *GoalsClient) GetGoals(currentOnly bool) (models.Goals, error) {
+	type parametersGetGoals struct {
+		CurrentOnly bool `json:"current_only"`
+	}
+
+	params := parametersGetGoals{
+		CurrentOnly: currentOnly,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Goals.GetGoals, params)
+	if err != nil {
+		log.Printf("--ERROR-- with GetGoals(): %v\n", err)
+		return models.Goals{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var goals models.Goals
+	err = json.NewDecoder(r.Body).Decode(&goals)
+	if err != nil {
+		log.Printf("--ERROR-- with GetGoals(): %v\n", err)
+		return models.Goals{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- GetGoals() OK")
+	return goals, nil
+}
+
+// Details replace the goal's previous ones, tags included
+func (c *GoalsClient) UpdateGoal(goalID string, details models.GoalDetails) (models.Goal, error) {
+	type parametersUpdateGoal struct {
+		GoalID string `json:"goal_id"`
+		models.GoalDetails
+	}
+
+	params := parametersUpdateGoal{
+		GoalID:      goalID,
+		GoalDetails: details,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Goals.UpdateGoal, params)
+	if err != nil {
+		log.Printf("--ERROR-- with UpdateGoal(): %v\n", err)
+		return models.Goal{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var goal models.Goal
+	err = json.NewDecoder(r.Body).Decode(&goal)
+	if err != nil {
+		log.Printf("--ERROR-- with UpdateGoal(): %v\n", err)
+		return models.Goal{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- UpdateGoal() OK")
+	return goal, nil
+}
+
+func (c *GoalsClient) DeleteGoal(goalID string) error {
+	type parametersGoal struct {
+		GoalID string `json:"goal_id"`
+	}
+
+	params := parametersGoal{
+		GoalID: goalID,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Goals.DeleteGoal, params)
+	if err != nil {
+		log.Printf("--ERROR-- with DeleteGoal(): %v\n", err)
+		return err
+	}
+	defer r.Body.Close()
+
+	log.Println("--DEBUG-- DeleteGoal() OK")
+	return nil
+}
+
+// Unit is "day" or "week", an empty mediaType counts activity on every media type
+func (c *GoalsClient) GetStreak(unit, mediaType string) (models.Streak, error) {
+	type parametersGetStreak struct {
+		Unit      string `json:"unit"`
+		MediaType string `json:"media_type"`
+	}
+
+	params := parametersGetStreak{
+		Unit:      unit,
+		MediaType: mediaType,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Goals.GetStreak, params)
+	if err != nil {
+		log.Printf("--ERROR-- with GetStreak(): %v\n", err)
+		return models.Streak{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var streak models.Streak
+	err = json.NewDecoder(r.Body).Decode(&streak)
+	if err != nil {
+		log.Printf("--ERROR-- with GetStreak(): %v\n", err)
+		return models.Streak{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- GetStreak() OK")
+	return streak, nil
+}
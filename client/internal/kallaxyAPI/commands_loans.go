@@ -0,0 +1,212 @@
+package kallaxyapi
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/VincNT21/kallaxy/client/models"
+)
+
+// Lend a medium to another user (borrowerUsername) or to anyone else (borrowerName), leave the other one empty
+// lentAt defaults to now, dueAt is optional
+func (c *LoansClient) CreateLoan(mediumID, borrowerUsername, borrowerName, lentAt, dueAt string) (models.Loan, error) {
+	type parametersCreateLoan struct {
+		MediumID         string `json:"medium_id"`
+		BorrowerUsername string `json:"borrower_username"`
+		BorrowerName     string `json:"borrower_name"`
+		LentAt           string `json:"lent_at"`
+		DueAt            string `json:"due_at"`
+	}
+
+	params := parametersCreateLoan{
+		MediumID:         mediumID,
+		BorrowerUsername: borrowerUsername,
+		BorrowerName:     borrowerName,
+		LentAt:           lentAt,
+		DueAt:            dueAt,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Loans.CreateLoan, params)
+	if err != nil {
+		log.Printf("--ERROR-- with CreateLoan(): %v\n", err)
+		return models.Loan{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var loan models.Loan
+	err = json.NewDecoder(r.Body).Decode(&loan)
+	if err != nil {
+		log.Printf("--ERROR-- with CreateLoan(): %v\n", err)
+		return models.Loan{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- CreateLoan() OK")
+	return loan, nil
+}
+
+// Open loans made by the user and to them
+func (c *LoansClient) GetLoans() (models.Loans, error) {
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Loans.GetLoans, nil)
+	if err != nil {
+		log.Printf("--ERROR-- with GetLoans(): %v\n", err)
+		return models.Loans{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var loans models.Loans
+	err = json.NewDecoder(r.Body).Decode(&loans)
+	if err != nil {
+		log.Printf("--ERROR-- with GetLoans(): %v\n", err)
+		return models.Loans{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- GetLoans() OK")
+	return loans, nil
+}
+
+// Open loans past their expected return date, made by the user and to them
+func (c *LoansClient) GetOverdueLoans() (models.Loans, error) {
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Loans.GetOverdueLoans, nil)
+	if err != nil {
+		log.Printf("--ERROR-- with GetOverdueLoans(): %v\n", err)
+		return models.Loans{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var loans models.Loans
+	err = json.NewDecoder(r.Body).Decode(&loans)
+	if err != nil {
+		log.Printf("--ERROR-- with GetOverdueLoans(): %v\n", err)
+		return models.Loans{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- GetOverdueLoans() OK")
+	return loans, nil
+}
+
+// An empty dueAt removes the expected return date
+func (c *LoansClient) UpdateLoan(loanID, dueAt string) (models.Loan, error) {
+	type parametersUpdateLoan struct {
+		LoanID string `json:"loan_id"`
+		DueAt  string `json:"due_at"`
+	}
+
+	params := parametersUpdateLoan{
+		LoanID: loanID,
+		DueAt:  dueAt,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Loans.UpdateLoan, params)
+	if err != nil {
+		log.Printf("--ERROR-- with UpdateLoan(): %v\n", err)
+		return models.Loan{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var loan models.Loan
+	err = json.NewDecoder(r.Body).Decode(&loan)
+	if err != nil {
+		log.Printf("--ERROR-- with UpdateLoan(): %v\n", err)
+		return models.Loan{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- UpdateLoan() OK")
+	return loan, nil
+}
+
+// returnedAt defaults to now
+func (c *LoansClient) ReturnLoan(loanID, returnedAt string) (models.Loan, error) {
+	type parametersReturnLoan struct {
+		LoanID     string `json:"loan_id"`
+		ReturnedAt string `json:"returned_at"`
+	}
+
+	params := parametersReturnLoan{
+		LoanID:     loanID,
+		ReturnedAt: returnedAt,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Loans.ReturnLoan, params)
+	if err != nil {
+		log.Printf("--ERROR-- with ReturnLoan(): %v\n", err)
+		return models.Loan{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var loan models.Loan
+	err = json.NewDecoder(r.Body).Decode(&loan)
+	if err != nil {
+		log.Printf("--ERROR-- with ReturnLoan(): %v\n", err)
+		return models.Loan{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- ReturnLoan() OK")
+	return loan, nil
+}
+
+func (c *LoansClient) DeleteLoan(loanID string) error {
+	type parametersLoan struct {
+		LoanID string `json:"loan_id"`
+	}
+
+	params := parametersLoan{
+		LoanID: loanID,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Loans.DeleteLoan, params)
+	if err != nil {
+		log.Printf("--ERROR-- with DeleteLoan(): %v\n", err)
+		return err
+	}
+	defer r.Body.Close()
+
+	log.Println("--DEBUG-- DeleteLoan() OK")
+	return nil
+}
+
+// All loans of a medium made by the user, last one first
+func (c *LoansClient) GetMediumLoans(mediumID string) (models.MediumLoans, error) {
+	type parametersGetMediumLoans struct {
+		MediumID string `json:"medium_id"`
+	}
+
+	params := parametersGetMediumLoans{
+		MediumID: mediumID,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Loans.GetMediumLoans, params)
+	if err != nil {
+		log.Printf("--ERROR-- with GetMediumLoans(): %v\n", err)
+		return models.MediumLoans{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var mediumLoans models.MediumLoans
+	err = json.NewDecoder(r.Body).Decode(&mediumLoans)
+	if err != nil {
+		log.Printf("--ERROR-- with GetMediumLoans(): %v\n", err)
+		return models.MediumLoans{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- GetMediumLoans() OK")
+	return mediumLoans, nil
+}
@@ -4,14 +4,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/VincNT21/kallaxy/client/models"
 )
 
-func (c *MediaClient) CreateMediumAndRecord(title, mediaType, creator, pubDate, imageUrl, startDate, endDate, comments string, metadata map[string]interface{}) (models.Medium, models.Record, error) {
+func (c *MediaClient) CreateMediumAndRecord(title, mediaType, creator, pubDate, imageUrl, startDate, endDate, comments string, metadata map[string]interface{}, externalIDs map[string]string) (models.Medium, models.Record, error) {
 
 	// Make request for Medium creation
-	medium, err := c.apiClient.Media.CreateMedium(title, mediaType, creator, pubDate, imageUrl, metadata)
+	medium, err := c.apiClient.Media.CreateMedium(title, mediaType, creator, pubDate, imageUrl, metadata, externalIDs)
 	if err != nil {
 		log.Printf("--ERROR-- with CreateMediumAndRecord(): %v\n", err)
 		return models.Medium{}, models.Record{}, err
@@ -36,24 +37,26 @@ func (c *MediaClient) CreateMediumAndRecord(title, mediaType, creator, pubDate,
 	return medium, record, nil
 }
 
-func (c *MediaClient) CreateMedium(title, mediaType, creator, pubDate, imageUrl string, metadata map[string]interface{}) (models.Medium, error) {
+func (c *MediaClient) CreateMedium(title, mediaType, creator, pubDate, imageUrl string, metadata map[string]interface{}, externalIDs map[string]string) (models.Medium, error) {
 	type parametersCreateMedium struct {
-		Title     string                 `json:"title"`
-		MediaType string                 `json:"media_type"`
-		Creator   string                 `json:"creator"`
-		PubDate   string                 `json:"pub_date"`
-		ImageUrl  string                 `json:"image_url"`
-		Metadata  map[string]interface{} `json:"metadata"`
+		Title       string                 `json:"title"`
+		MediaType   string                 `json:"media_type"`
+		Creator     string                 `json:"creator"`
+		PubDate     string                 `json:"pub_date"`
+		ImageUrl    string                 `json:"image_url"`
+		Metadata    map[string]interface{} `json:"metadata"`
+		ExternalIDs map[string]string      `json:"external_ids"`
 	}
 
 	// Parameters for Create Medium request
 	params := parametersCreateMedium{
-		Title:     title,
-		MediaType: mediaType,
-		Creator:   creator,
-		PubDate:   pubDate,
-		ImageUrl:  imageUrl,
-		Metadata:  metadata,
+		Title:       title,
+		MediaType:   mediaType,
+		Creator:     creator,
+		PubDate:     pubDate,
+		ImageUrl:    imageUrl,
+		Metadata:    metadata,
+		ExternalIDs: externalIDs,
 	}
 
 	// Make request for Medium creation
@@ -100,23 +103,26 @@ func (c *MediaClient) GetMediaWithRecords() (models.MediaWithRecords, error) {
 	return mediaRecords, nil
 }
 
-func (c *MediaClient) UpdateMedium(mediumId, title, creator, pubDate, imageUrl string, metadata map[string]interface{}) (models.ClientMedium, error) {
+// Medium's external IDs are only replaced if some are given
+func (c *MediaClient) UpdateMedium(mediumId, title, creator, pubDate, imageUrl string, metadata map[string]interface{}, externalIDs map[string]string) (models.ClientMedium, error) {
 	type parametersUpdateMedium struct {
-		MediumID string                 `json:"medium_id"`
-		Title    string                 `json:"title"`
-		Creator  string                 `json:"creator"`
-		PubDate  string                 `json:"pub_date"`
-		ImageUrl string                 `json:"image_url"`
-		Metadata map[string]interface{} `json:"metadata"`
+		MediumID    string                 `json:"medium_id"`
+		Title       string                 `json:"title"`
+		Creator     string                 `json:"creator"`
+		PubDate     string                 `json:"pub_date"`
+		ImageUrl    string                 `json:"image_url"`
+		Metadata    map[string]interface{} `json:"metadata"`
+		ExternalIDs map[string]string      `json:"external_ids,omitempty"`
 	}
 
 	params := parametersUpdateMedium{
-		MediumID: mediumId,
-		Title:    title,
-		Creator:  creator,
-		PubDate:  pubDate,
-		ImageUrl: imageUrl,
-		Metadata: metadata,
+		MediumID:    mediumId,
+		Title:       title,
+		Creator:     creator,
+		PubDate:     pubDate,
+		ImageUrl:    imageUrl,
+		Metadata:    metadata,
+		ExternalIDs: externalIDs,
 	}
 
 	// Make request
@@ -135,6 +141,8 @@ func (c *MediaClient) UpdateMedium(mediumId, title, creator, pubDate, imageUrl s
 			return models.ClientMedium{}, models.ErrBadRequest
 		case 401:
 			return models.ClientMedium{}, models.ErrUnauthorized
+		case 403:
+			return models.ClientMedium{}, models.ErrForbidden
 		case 404:
 			return models.ClientMedium{}, models.ErrNotFound
 		case 409:
@@ -159,11 +167,50 @@ func (c *MediaClient) UpdateMedium(mediumId, title, creator, pubDate, imageUrl s
 	return updatedMedium, nil
 }
 
-func (c *MediaClient) DeleteMedium(mediumID string) error {
+// Fetch again medium's details from the provider it is linked to
+// Changes are only saved on server if apply is true, otherwise they are just listed
+func (c *MediaClient) RefreshMedium(mediumID string, apply bool) (models.RefreshMedium, error) {
+	type parametersRefreshMedium struct {
+		Apply bool `json:"apply"`
+	}
+
+	// Put medium's ID in endpoint's path
+	endpoint := c.apiClient.Config.Endpoints.Media.RefreshMedia
+	endpoint.Path = strings.Replace(endpoint.Path, "{id}", mediumID, 1)
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(endpoint, parametersRefreshMedium{Apply: apply})
+	if err != nil {
+		log.Printf("--ERROR-- with RefreshMedium(): %v\n", err)
+		return models.RefreshMedium{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var refresh models.RefreshMedium
+	err = json.NewDecoder(r.Body).Decode(&refresh)
+	if err != nil {
+		log.Printf("--ERROR-- with RefreshMedium(): %v\n", err)
+		return models.RefreshMedium{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- RefreshMedium() OK")
+	return refresh, nil
+}
+
+// Delete a medium, or only remove it from user's shelf when the server keeps it for other users
+// Return true if the medium itself was deleted
+func (c *MediaClient) DeleteMedium(mediumID string) (bool, error) {
 	type parametersDeleteMedium struct {
 		MediumID string `json:"medium_id"`
 	}
 
+	type responseDeleteMedium struct {
+		MediumDeleted bool `json:"medium_deleted"`
+		RecordDeleted bool `json:"record_deleted"`
+	}
+
 	params := parametersDeleteMedium{
 		MediumID: mediumID,
 	}
@@ -172,7 +219,7 @@ func (c *MediaClient) DeleteMedium(mediumID string) error {
 	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Media.DeleteMedia, params)
 	if err != nil {
 		log.Printf("--ERROR-- with DeleteMedium(): %v\n", err)
-		return err
+		return false, err
 	}
 	defer r.Body.Close()
 
@@ -181,21 +228,31 @@ func (c *MediaClient) DeleteMedium(mediumID string) error {
 		log.Printf("--ERROR-- with DeleteMedium(). Response status code: %v\n", r.StatusCode)
 		switch r.StatusCode {
 		case 400:
-			return models.ErrBadRequest
+			return false, models.ErrBadRequest
 		case 401:
-			return models.ErrUnauthorized
+			return false, models.ErrUnauthorized
+		case 403:
+			return false, models.ErrForbidden
 		case 404:
-			return models.ErrNotFound
+			return false, models.ErrNotFound
 		case 409:
-			return models.ErrConflict
+			return false, models.ErrConflict
 		case 500:
-			return models.ErrServerIssue
+			return false, models.ErrServerIssue
 		default:
-			return fmt.Errorf("unknown error status code: %v", r.StatusCode)
+			return false, fmt.Errorf("unknown error status code: %v", r.StatusCode)
 		}
 	}
 
+	// Decode response
+	var response responseDeleteMedium
+	err = json.NewDecoder(r.Body).Decode(&response)
+	if err != nil {
+		log.Printf("--ERROR-- with DeleteMedium(): %v\n", err)
+		return false, err
+	}
+
 	// Return
 	log.Println("--DEBUG-- DeleteMedium() OK")
-	return nil
+	return response.MediumDeleted, nil
 }
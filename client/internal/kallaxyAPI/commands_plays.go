@@ -0,0 +1,220 @@
+package kallaxyapi
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/VincNT21/kallaxy/client/models"
+)
+
+// Log a play of a boardgame on user's shelf, a play with no date happened now
+func (c *PlaysClient) CreatePlay(mediumID string, details models.PlayDetails) (models.Play, error) {
+	type parametersCreatePlay struct {
+		MediumID string `json:"medium_id"`
+		models.PlayDetails
+	}
+
+	// Convert input data to match server's requirement
+	if details.PlayedAt != "" {
+		parsedPlayedAt, err := c.apiClient.Helpers.FormatDateToServerFormat(details.PlayedAt)
+		if err != nil {
+			return models.Play{}, err
+		}
+		details.PlayedAt = parsedPlayedAt
+	}
+
+	params := parametersCreatePlay{
+		MediumID:    mediumID,
+		PlayDetails: details,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Plays.CreatePlay, params)
+	if err != nil {
+		log.Printf("--ERROR-- with CreatePlay(): %v\n", err)
+		return models.Play{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var play models.Play
+	err = json.NewDecoder(r.Body).Decode(&play)
+	if err != nil {
+		log.Printf("--ERROR-- with CreatePlay(): %v\n", err)
+		return models.Play{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- CreatePlay() OK")
+	return play, nil
+}
+
+// Plays logged by the user, last one first, of a single boardgame if mediumID isn't empty
+func (c *PlaysClient) GetPlays(mediumID string) (models.Plays, error) {
+	type parametersGetPlays struct {
+		MediumID string `json:"medium_id"`
+	}
+
+	params := parametersGetPlays{
+		MediumID: mediumID,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Plays.GetPlays, params)
+	if err != nil {
+		log.Printf("--ERROR-- with GetPlays(): %v\n", err)
+		return models.Plays{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var plays models.Plays
+	err = json.NewDecoder(r.Body).Decode(&plays)
+	if err != nil {
+		log.Printf("--ERROR-- with GetPlays(): %v\n", err)
+		return models.Plays{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- GetPlays() OK")
+	return plays, nil
+}
+
+// Every detail is replaced, the given players replace the play's previous ones
+func (c *PlaysClient) UpdatePlay(playID string, details models.PlayDetails) (models.Play, error) {
+	type parametersUpdatePlay struct {
+		PlayID string `json:"play_id"`
+		models.PlayDetails
+	}
+
+	// Convert input data to match server's requirement
+	if details.PlayedAt != "" {
+		parsedPlayedAt, err := c.apiClient.Helpers.FormatDateToServerFormat(details.PlayedAt)
+		if err != nil {
+			return models.Play{}, err
+		}
+		details.PlayedAt = parsedPlayedAt
+	}
+
+	params := parametersUpdatePlay{
+		PlayID:      playID,
+		PlayDetails: details,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Plays.UpdatePlay, params)
+	if err != nil {
+		log.Printf("--ERROR-- with UpdatePlay(): %v\n", err)
+		return models.Play{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var play models.Play
+	err = json.NewDecoder(r.Body).Decode(&play)
+	if err != nil {
+		log.Printf("--ERROR-- with UpdatePlay(): %v\n", err)
+		return models.Play{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- UpdatePlay() OK")
+	return play, nil
+}
+
+func (c *PlaysClient) DeletePlay(playID string) error {
+	type parametersPlay struct {
+		PlayID string `json:"play_id"`
+	}
+
+	params := parametersPlay{
+		PlayID: playID,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Plays.DeletePlay, params)
+	if err != nil {
+		log.Printf("--ERROR-- with DeletePlay(): %v\n", err)
+		return err
+	}
+	defer r.Body.Close()
+
+	log.Println("--DEBUG-- DeletePlay() OK")
+	return nil
+}
+
+// Plays of each boardgame, most played first
+func (c *PlaysClient) GetPlayCounts() (models.PlayCounts, error) {
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Plays.GetPlayCounts, nil)
+	if err != nil {
+		log.Printf("--ERROR-- with GetPlayCounts(): %v\n", err)
+		return models.PlayCounts{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var counts models.PlayCounts
+	err = json.NewDecoder(r.Body).Decode(&counts)
+	if err != nil {
+		log.Printf("--ERROR-- with GetPlayCounts(): %v\n", err)
+		return models.PlayCounts{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- GetPlayCounts() OK")
+	return counts, nil
+}
+
+// Wins of every player of user's plays, of a single boardgame if mediumID isn't empty
+func (c *PlaysClient) GetPlayerWinRates(mediumID string) (models.PlayerWinRates, error) {
+	type parametersGetPlays struct {
+		MediumID string `json:"medium_id"`
+	}
+
+	params := parametersGetPlays{
+		MediumID: mediumID,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Plays.GetPlayerWinRates, params)
+	if err != nil {
+		log.Printf("--ERROR-- with GetPlayerWinRates(): %v\n", err)
+		return models.PlayerWinRates{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var rates models.PlayerWinRates
+	err = json.NewDecoder(r.Body).Decode(&rates)
+	if err != nil {
+		log.Printf("--ERROR-- with GetPlayerWinRates(): %v\n", err)
+		return models.PlayerWinRates{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- GetPlayerWinRates() OK")
+	return rates, nil
+}
+
+func (c *PlaysClient) GetPlaysHIndex() (models.PlaysHIndex, error) {
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Plays.GetPlaysHIndex, nil)
+	if err != nil {
+		log.Printf("--ERROR-- with GetPlaysHIndex(): %v\n", err)
+		return models.PlaysHIndex{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var hIndex models.PlaysHIndex
+	err = json.NewDecoder(r.Body).Decode(&hIndex)
+	if err != nil {
+		log.Printf("--ERROR-- with GetPlaysHIndex(): %v\n", err)
+		return models.PlaysHIndex{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- GetPlaysHIndex() OK")
+	return hIndex, nil
+}
@@ -62,12 +62,19 @@ func (c *RecordsClient) CreateRecord(mediumID, startDate, endDate, comments stri
 	return record, nil
 }
 
-func (c *RecordsClient) UpdateRecord(recordID, startDate, endDate, comments string) (models.Record, error) {
+// Update a record, rating is given on user's rating scale and removed if nil
+// Status and abandon reason are kept by server when empty
+func (c *RecordsClient) UpdateRecord(recordID, startDate, endDate, comments, status, abandonReason string, rating *float64) (models.Record, error) {
 	type parametersUpdateRecord struct {
-		RecordID  string `json:"record_id"`
-		StartDate string `json:"start_date"`
-		EndDate   string `json:"end_date"`
-		Comments  string `json:"comments"`
+		RecordID      string   `json:"record_id"`
+		StartDate     string   `json:"start_date"`
+		EndDate       string   `json:"end_date"`
+		Comments      string   `json:"comments"`
+		Status        string   `json:"status,omitempty"`
+		AbandonReason string   `json:"abandon_reason,omitempty"`
+		Rating        *float64 `json:"rating,omitempty"`
+		RatingScale   string   `json:"rating_scale"`
+		RemoveRating  bool     `json:"remove_rating"`
 	}
 
 	// Convert input data to match server's requirement
@@ -88,10 +95,15 @@ func (c *RecordsClient) UpdateRecord(recordID, startDate, endDate, comments stri
 	}
 
 	params := parametersUpdateRecord{
-		RecordID:  recordID,
-		StartDate: startDate,
-		EndDate:   endDate,
-		Comments:  comments,
+		RecordID:      recordID,
+		StartDate:     startDate,
+		EndDate:       endDate,
+		Comments:      comments,
+		Status:        status,
+		AbandonReason: abandonReason,
+		Rating:        rating,
+		RatingScale:   c.apiClient.Helpers.GetRatingScale(),
+		RemoveRating:  rating == nil,
 	}
 
 	// Make request
@@ -115,13 +127,81 @@ func (c *RecordsClient) UpdateRecord(recordID, startDate, endDate, comments stri
 	return record, nil
 }
 
-func (c *RecordsClient) DeleteRecord(mediumID string) error {
+// Log a progress update of a record
+// Record's comments are sent again, as the server replaces them on every update
+func (c *RecordsClient) LogRecordProgress(recordID, comments string, progress models.ProgressUpdate) (models.RecordProgress, error) {
+	type parametersUpdateRecord struct {
+		RecordID string                `json:"record_id"`
+		Comments string                `json:"comments"`
+		Progress models.ProgressUpdate `json:"progress"`
+	}
+
+	type responseUpdateRecord struct {
+		models.Record
+		Progress models.RecordProgress `json:"progress"`
+	}
+
+	params := parametersUpdateRecord{
+		RecordID: recordID,
+		Comments: comments,
+		Progress: progress,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Records.UpdateRecord, params)
+	if err != nil {
+		log.Printf("--ERROR-- with LogRecordProgress(): %v\n", err)
+		return models.RecordProgress{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var response responseUpdateRecord
+	err = json.NewDecoder(r.Body).Decode(&response)
+	if err != nil {
+		log.Printf("--ERROR-- with LogRecordProgress(): %v\n", err)
+		return models.RecordProgress{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- LogRecordProgress() OK")
+	return response.Progress, nil
+}
+
+// Get a record's progress log, with user's pace and estimated finish date
+func (c *RecordsClient) GetRecordProgress(recordID string) (models.RecordProgressCurve, error) {
+	type parametersGetRecordProgress struct {
+		RecordID string `json:"record_id"`
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Records.GetRecordProgress, parametersGetRecordProgress{RecordID: recordID})
+	if err != nil {
+		log.Printf("--ERROR-- with GetRecordProgress(): %v\n", err)
+		return models.RecordProgressCurve{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var curve models.RecordProgressCurve
+	err = json.NewDecoder(r.Body).Decode(&curve)
+	if err != nil {
+		log.Printf("--ERROR-- with GetRecordProgress(): %v\n", err)
+		return models.RecordProgressCurve{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- GetRecordProgress() OK")
+	return curve, nil
+}
+
+func (c *RecordsClient) DeleteRecord(recordID string) error {
 	type parametersDeleteRecord struct {
-		MediumID string `json:"medium_id"`
+		RecordID string `json:"record_id"`
 	}
 
 	params := parametersDeleteRecord{
-		MediumID: mediumID,
+		RecordID: recordID,
 	}
 
 	// Make request
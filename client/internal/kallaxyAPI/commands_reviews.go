@@ -0,0 +1,196 @@
+package kallaxyapi
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/VincNT21/kallaxy/client/models"
+)
+
+func (c *ReviewsClient) CreateReview(recordID, title, body string, isPublished bool) (models.Review, error) {
+	type parametersCreateReview struct {
+		RecordID    string `json:"record_id"`
+		Title       string `json:"title"`
+		Body        string `json:"body"`
+		IsPublished bool   `json:"is_published"`
+	}
+
+	params := parametersCreateReview{
+		RecordID:    recordID,
+		Title:       title,
+		Body:        body,
+		IsPublished: isPublished,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Reviews.CreateReview, params)
+	if err != nil {
+		log.Printf("--ERROR-- with CreateReview(): %v\n", err)
+		return models.Review{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var review models.Review
+	err = json.NewDecoder(r.Body).Decode(&review)
+	if err != nil {
+		log.Printf("--ERROR-- with CreateReview(): %v\n", err)
+		return models.Review{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- CreateReview() OK")
+	return review, nil
+}
+
+// Returns models.ErrNotFound when the record has no review yet
+func (c *ReviewsClient) GetReview(recordID string) (models.Review, error) {
+	type parametersReview struct {
+		RecordID string `json:"record_id"`
+	}
+
+	params := parametersReview{
+		RecordID: recordID,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Reviews.GetReview, params)
+	if err != nil {
+		log.Printf("--ERROR-- with GetReview(): %v\n", err)
+		return models.Review{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var review models.Review
+	err = json.NewDecoder(r.Body).Decode(&review)
+	if err != nil {
+		log.Printf("--ERROR-- with GetReview(): %v\n", err)
+		return models.Review{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- GetReview() OK")
+	return review, nil
+}
+
+func (c *ReviewsClient) UpdateReview(recordID, title, body string, isPublished bool) (models.Review, error) {
+	type parametersUpdateReview struct {
+		RecordID    string `json:"record_id"`
+		Title       string `json:"title"`
+		Body        string `json:"body"`
+		IsPublished bool   `json:"is_published"`
+	}
+
+	params := parametersUpdateReview{
+		RecordID:    recordID,
+		Title:       title,
+		Body:        body,
+		IsPublished: isPublished,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Reviews.UpdateReview, params)
+	if err != nil {
+		log.Printf("--ERROR-- with UpdateReview(): %v\n", err)
+		return models.Review{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var review models.Review
+	err = json.NewDecoder(r.Body).Decode(&review)
+	if err != nil {
+		log.Printf("--ERROR-- with UpdateReview(): %v\n", err)
+		return models.Review{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- UpdateReview() OK")
+	return review, nil
+}
+
+// Review's revisions are deleted with it
+func (c *ReviewsClient) DeleteReview(recordID string) error {
+	type parametersReview struct {
+		RecordID string `json:"record_id"`
+	}
+
+	params := parametersReview{
+		RecordID: recordID,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Reviews.DeleteReview, params)
+	if err != nil {
+		log.Printf("--ERROR-- with DeleteReview(): %v\n", err)
+		return err
+	}
+	defer r.Body.Close()
+
+	log.Println("--DEBUG-- DeleteReview() OK")
+	return nil
+}
+
+func (c *ReviewsClient) GetReviewRevisions(recordID string) (models.ReviewRevisions, error) {
+	type parametersReview struct {
+		RecordID string `json:"record_id"`
+	}
+
+	params := parametersReview{
+		RecordID: recordID,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Reviews.GetReviewRevisions, params)
+	if err != nil {
+		log.Printf("--ERROR-- with GetReviewRevisions(): %v\n", err)
+		return models.ReviewRevisions{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var revisions models.ReviewRevisions
+	err = json.NewDecoder(r.Body).Decode(&revisions)
+	if err != nil {
+		log.Printf("--ERROR-- with GetReviewRevisions(): %v\n", err)
+		return models.ReviewRevisions{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- GetReviewRevisions() OK")
+	return revisions, nil
+}
+
+// Spoiler blocks are hidden unless showSpoilers is true
+func (c *ReviewsClient) GetMediumReviews(mediumID string, showSpoilers bool) (models.MediumReviews, error) {
+	type parametersGetMediumReviews struct {
+		MediumID     string `json:"medium_id"`
+		ShowSpoilers bool   `json:"show_spoilers"`
+	}
+
+	params := parametersGetMediumReviews{
+		MediumID:     mediumID,
+		ShowSpoilers: showSpoilers,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Reviews.GetMediumReviews, params)
+	if err != nil {
+		log.Printf("--ERROR-- with GetMediumReviews(): %v\n", err)
+		return models.MediumReviews{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var reviews models.MediumReviews
+	err = json.NewDecoder(r.Body).Decode(&reviews)
+	if err != nil {
+		log.Printf("--ERROR-- with GetMediumReviews(): %v\n", err)
+		return models.MediumReviews{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- GetMediumReviews() OK")
+	return reviews, nil
+}
@@ -0,0 +1,163 @@
+package kallaxyapi
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/VincNT21/kallaxy/client/models"
+)
+
+func (c *ShelvesClient) CreateShelf(name, description string) (models.Shelf, error) {
+	type parametersCreateShelf struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+
+	params := parametersCreateShelf{
+		Name:        name,
+		Description: description,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Shelves.CreateShelf, params)
+	if err != nil {
+		log.Printf("--ERROR-- with CreateShelf(): %v\n", err)
+		return models.Shelf{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var shelf models.Shelf
+	err = json.NewDecoder(r.Body).Decode(&shelf)
+	if err != nil {
+		log.Printf("--ERROR-- with CreateShelf(): %v\n", err)
+		return models.Shelf{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- CreateShelf() OK")
+	return shelf, nil
+}
+
+func (c *ShelvesClient) GetShelves() (models.Shelves, error) {
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Shelves.GetShelves, nil)
+	if err != nil {
+		log.Printf("--ERROR-- with GetShelves(): %v\n", err)
+		return models.Shelves{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var shelves models.Shelves
+	err = json.NewDecoder(r.Body).Decode(&shelves)
+	if err != nil {
+		log.Printf("--ERROR-- with GetShelves(): %v\n", err)
+		return models.Shelves{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- GetShelves() OK")
+	return shelves, nil
+}
+
+func (c *ShelvesClient) UpdateShelf(shelfID, name, description string) (models.Shelf, error) {
+	type parametersUpdateShelf struct {
+		ShelfID     string `json:"shelf_id"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+
+	params := parametersUpdateShelf{
+		ShelfID:     shelfID,
+		Name:        name,
+		Description: description,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Shelves.UpdateShelf, params)
+	if err != nil {
+		log.Printf("--ERROR-- with UpdateShelf(): %v\n", err)
+		return models.Shelf{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var shelf models.Shelf
+	err = json.NewDecoder(r.Body).Decode(&shelf)
+	if err != nil {
+		log.Printf("--ERROR-- with UpdateShelf(): %v\n", err)
+		return models.Shelf{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- UpdateShelf() OK")
+	return shelf, nil
+}
+
+// Media and their records are kept when their custom shelf is deleted
+func (c *ShelvesClient) DeleteShelf(shelfID string) error {
+	type parametersShelf struct {
+		ShelfID string `json:"shelf_id"`
+	}
+
+	params := parametersShelf{
+		ShelfID: shelfID,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Shelves.DeleteShelf, params)
+	if err != nil {
+		log.Printf("--ERROR-- with DeleteShelf(): %v\n", err)
+		return err
+	}
+	defer r.Body.Close()
+
+	log.Println("--DEBUG-- DeleteShelf() OK")
+	return nil
+}
+
+// Put given media on a custom shelf, return how many were put
+func (c *ShelvesClient) AddMediaToShelf(shelfID string, mediumIDs []string) (int64, error) {
+	return c.changeShelfMedia(c.apiClient.Config.Endpoints.Shelves.AddMediaToShelf, shelfID, mediumIDs)
+}
+
+// Take given media off a custom shelf, return how many were taken off
+func (c *ShelvesClient) RemoveMediaFromShelf(shelfID string, mediumIDs []string) (int64, error) {
+	return c.changeShelfMedia(c.apiClient.Config.Endpoints.Shelves.RemoveMediaFromShelf, shelfID, mediumIDs)
+}
+
+func (c *ShelvesClient) changeShelfMedia(endpoint Endpoint, shelfID string, mediumIDs []string) (int64, error) {
+	type parametersShelfMedia struct {
+		ShelfID   string   `json:"shelf_id"`
+		MediumIDs []string `json:"medium_ids"`
+	}
+
+	params := parametersShelfMedia{
+		ShelfID:   shelfID,
+		MediumIDs: mediumIDs,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(endpoint, params)
+	if err != nil {
+		log.Printf("--ERROR-- with %s %s: %v\n", endpoint.Method, endpoint.Path, err)
+		return 0, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var response struct {
+		Count int64 `json:"count"`
+	}
+	err = json.NewDecoder(r.Body).Decode(&response)
+	if err != nil {
+		log.Printf("--ERROR-- with %s %s: %v\n", endpoint.Method, endpoint.Path, err)
+		return 0, err
+	}
+
+	// Return data
+	log.Printf("--DEBUG-- %s %s OK\n", endpoint.Method, endpoint.Path)
+	return response.Count, nil
+}
@@ -0,0 +1,231 @@
+package kallaxyapi
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/VincNT21/kallaxy/client/models"
+)
+
+func (c *ShelvingClient) CreateShelvingUnit(details models.ShelvingUnitDetails) (models.ShelvingUnit, error) {
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Shelving.CreateShelvingUnit, details)
+	if err != nil {
+		log.Printf("--ERROR-- with CreateShelvingUnit(): %v\n", err)
+		return models.ShelvingUnit{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var unit models.ShelvingUnit
+	err = json.NewDecoder(r.Body).Decode(&unit)
+	if err != nil {
+		log.Printf("--ERROR-- with CreateShelvingUnit(): %v\n", err)
+		return models.ShelvingUnit{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- CreateShelvingUnit() OK")
+	return unit, nil
+}
+
+// All user's shelving units, by name
+func (c *ShelvingClient) GetShelvingUnits() (models.ShelvingUnits, error) {
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Shelving.GetShelvingUnits, nil)
+	if err != nil {
+		log.Printf("--ERROR-- with GetShelvingUnits(): %v\n", err)
+		return models.ShelvingUnits{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var units models.ShelvingUnits
+	err = json.NewDecoder(r.Body).Decode(&units)
+	if err != nil {
+		log.Printf("--ERROR-- with GetShelvingUnits(): %v\n", err)
+		return models.ShelvingUnits{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- GetShelvingUnits() OK")
+	return units, nil
+}
+
+// Every detail is replaced, the unit can't shrink over cells holding media
+func (c *ShelvingClient) UpdateShelvingUnit(unitID string, details models.ShelvingUnitDetails) (models.ShelvingUnit, error) {
+	type parametersUpdateShelvingUnit struct {
+		UnitID string `json:"unit_id"`
+		models.ShelvingUnitDetails
+	}
+
+	params := parametersUpdateShelvingUnit{
+		UnitID:              unitID,
+		ShelvingUnitDetails: details,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Shelving.UpdateShelvingUnit, params)
+	if err != nil {
+		log.Printf("--ERROR-- with UpdateShelvingUnit(): %v\n", err)
+		return models.ShelvingUnit{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var unit models.ShelvingUnit
+	err = json.NewDecoder(r.Body).Decode(&unit)
+	if err != nil {
+		log.Printf("--ERROR-- with UpdateShelvingUnit(): %v\n", err)
+		return models.ShelvingUnit{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- UpdateShelvingUnit() OK")
+	return unit, nil
+}
+
+// Media in the unit stay on user's shelf
+func (c *ShelvingClient) DeleteShelvingUnit(unitID string) error {
+	type parametersShelvingUnit struct {
+		UnitID string `json:"unit_id"`
+	}
+
+	params := parametersShelvingUnit{
+		UnitID: unitID,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Shelving.DeleteShelvingUnit, params)
+	if err != nil {
+		log.Printf("--ERROR-- with DeleteShelvingUnit(): %v\n", err)
+		return err
+	}
+	defer r.Body.Close()
+
+	log.Println("--DEBUG-- DeleteShelvingUnit() OK")
+	return nil
+}
+
+// Unit's cells row by row from the top left, with the media in each of them
+func (c *ShelvingClient) GetShelvingUnitGrid(unitID string) (models.ShelvingUnitGrid, error) {
+	type parametersShelvingUnit struct {
+		UnitID string `json:"unit_id"`
+	}
+
+	params := parametersShelvingUnit{
+		UnitID: unitID,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Shelving.GetShelvingUnitGrid, params)
+	if err != nil {
+		log.Printf("--ERROR-- with GetShelvingUnitGrid(): %v\n", err)
+		return models.ShelvingUnitGrid{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var grid models.ShelvingUnitGrid
+	err = json.NewDecoder(r.Body).Decode(&grid)
+	if err != nil {
+		log.Printf("--ERROR-- with GetShelvingUnitGrid(): %v\n", err)
+		return models.ShelvingUnitGrid{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- GetShelvingUnitGrid() OK")
+	return grid, nil
+}
+
+// Put a medium in a cell (rows and columns counted from 1), moving it from where it was
+func (c *ShelvingClient) PlaceMedium(mediumID, unitID string, row, column int) (models.ShelvingItem, error) {
+	type parametersPlaceShelvingItem struct {
+		MediumID string `json:"medium_id"`
+		UnitID   string `json:"unit_id"`
+		Row      int    `json:"row"`
+		Column   int    `json:"column"`
+	}
+
+	params := parametersPlaceShelvingItem{
+		MediumID: mediumID,
+		UnitID:   unitID,
+		Row:      row,
+		Column:   column,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Shelving.PlaceMedium, params)
+	if err != nil {
+		log.Printf("--ERROR-- with PlaceMedium(): %v\n", err)
+		return models.ShelvingItem{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var item models.ShelvingItem
+	err = json.NewDecoder(r.Body).Decode(&item)
+	if err != nil {
+		log.Printf("--ERROR-- with PlaceMedium(): %v\n", err)
+		return models.ShelvingItem{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- PlaceMedium() OK")
+	return item, nil
+}
+
+// Take a medium out of its shelving unit
+func (c *ShelvingClient) RemoveMedium(mediumID string) error {
+	type parametersShelvingItem struct {
+		MediumID string `json:"medium_id"`
+	}
+
+	params := parametersShelvingItem{
+		MediumID: mediumID,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Shelving.RemoveMedium, params)
+	if err != nil {
+		log.Printf("--ERROR-- with RemoveMedium(): %v\n", err)
+		return err
+	}
+	defer r.Body.Close()
+
+	log.Println("--DEBUG-- RemoveMedium() OK")
+	return nil
+}
+
+// Where user keeps a medium, by its ID or by a part of its title (mediumID wins if both are given)
+func (c *ShelvingClient) WhereIsMedium(mediumID, title string) (models.ShelvingLocations, error) {
+	type parametersWhereIsMedium struct {
+		MediumID string `json:"medium_id"`
+		Title    string `json:"title"`
+	}
+
+	params := parametersWhereIsMedium{
+		MediumID: mediumID,
+		Title:    title,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Shelving.WhereIsMedium, params)
+	if err != nil {
+		log.Printf("--ERROR-- with WhereIsMedium(): %v\n", err)
+		return models.ShelvingLocations{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var locations models.ShelvingLocations
+	err = json.NewDecoder(r.Body).Decode(&locations)
+	if err != nil {
+		log.Printf("--ERROR-- with WhereIsMedium(): %v\n", err)
+		return models.ShelvingLocations{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- WhereIsMedium() OK")
+	return locations, nil
+}
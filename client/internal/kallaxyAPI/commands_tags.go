@@ -0,0 +1,159 @@
+package kallaxyapi
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/VincNT21/kallaxy/client/models"
+)
+
+func (c *TagsClient) CreateTag(name string) (models.Tag, error) {
+	type parametersCreateTag struct {
+		Name string `json:"name"`
+	}
+
+	params := parametersCreateTag{
+		Name: name,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Tags.CreateTag, params)
+	if err != nil {
+		log.Printf("--ERROR-- with CreateTag(): %v\n", err)
+		return models.Tag{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var tag models.Tag
+	err = json.NewDecoder(r.Body).Decode(&tag)
+	if err != nil {
+		log.Printf("--ERROR-- with CreateTag(): %v\n", err)
+		return models.Tag{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- CreateTag() OK")
+	return tag, nil
+}
+
+func (c *TagsClient) GetTags() (models.Tags, error) {
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Tags.GetTags, nil)
+	if err != nil {
+		log.Printf("--ERROR-- with GetTags(): %v\n", err)
+		return models.Tags{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var tags models.Tags
+	err = json.NewDecoder(r.Body).Decode(&tags)
+	if err != nil {
+		log.Printf("--ERROR-- with GetTags(): %v\n", err)
+		return models.Tags{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- GetTags() OK")
+	return tags, nil
+}
+
+func (c *TagsClient) UpdateTag(tagID, name string) (models.Tag, error) {
+	type parametersUpdateTag struct {
+		TagID string `json:"tag_id"`
+		Name  string `json:"name"`
+	}
+
+	params := parametersUpdateTag{
+		TagID: tagID,
+		Name:  name,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Tags.UpdateTag, params)
+	if err != nil {
+		log.Printf("--ERROR-- with UpdateTag(): %v\n", err)
+		return models.Tag{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var tag models.Tag
+	err = json.NewDecoder(r.Body).Decode(&tag)
+	if err != nil {
+		log.Printf("--ERROR-- with UpdateTag(): %v\n", err)
+		return models.Tag{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- UpdateTag() OK")
+	return tag, nil
+}
+
+// Media lose a deleted tag, but are kept
+func (c *TagsClient) DeleteTag(tagID string) error {
+	type parametersTag struct {
+		TagID string `json:"tag_id"`
+	}
+
+	params := parametersTag{
+		TagID: tagID,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Tags.DeleteTag, params)
+	if err != nil {
+		log.Printf("--ERROR-- with DeleteTag(): %v\n", err)
+		return err
+	}
+	defer r.Body.Close()
+
+	log.Println("--DEBUG-- DeleteTag() OK")
+	return nil
+}
+
+// Put each given tag on each given medium, return how many tags were put
+func (c *TagsClient) TagMedia(tagIDs, mediumIDs []string) (int64, error) {
+	return c.changeMediaTags(c.apiClient.Config.Endpoints.Tags.TagMedia, tagIDs, mediumIDs)
+}
+
+// Take each given tag off each given medium, return how many tags were taken off
+func (c *TagsClient) UntagMedia(tagIDs, mediumIDs []string) (int64, error) {
+	return c.changeMediaTags(c.apiClient.Config.Endpoints.Tags.UntagMedia, tagIDs, mediumIDs)
+}
+
+func (c *TagsClient) changeMediaTags(endpoint Endpoint, tagIDs, mediumIDs []string) (int64, error) {
+	type parametersTagMedia struct {
+		TagIDs    []string `json:"tag_ids"`
+		MediumIDs []string `json:"medium_ids"`
+	}
+
+	params := parametersTagMedia{
+		TagIDs:    tagIDs,
+		MediumIDs: mediumIDs,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(endpoint, params)
+	if err != nil {
+		log.Printf("--ERROR-- with %s %s: %v\n", endpoint.Method, endpoint.Path, err)
+		return 0, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var response struct {
+		Count int64 `json:"count"`
+	}
+	err = json.NewDecoder(r.Body).Decode(&response)
+	if err != nil {
+		log.Printf("--ERROR-- with %s %s: %v\n", endpoint.Method, endpoint.Path, err)
+		return 0, err
+	}
+
+	// Return data
+	log.Printf("--DEBUG-- %s %s OK\n", endpoint.Method, endpoint.Path)
+	return response.Count, nil
+}
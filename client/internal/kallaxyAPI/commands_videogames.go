@@ -0,0 +1,126 @@
+package kallaxyapi
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/VincNT21/kallaxy/client/models"
+)
+
+// Every detail is replaced, a platform of the medium's metadata is spelled as there by the server
+func (c *VideogamesClient) UpdateRecordVideogame(recordID string, details models.VideogameDetails) (models.RecordVideogame, error) {
+	type parametersRecordVideogame struct {
+		RecordID string `json:"record_id"`
+		models.VideogameDetails
+	}
+
+	params := parametersRecordVideogame{
+		RecordID:         recordID,
+		VideogameDetails: details,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Videogames.UpdateRecordVideogame, params)
+	if err != nil {
+		log.Printf("--ERROR-- with UpdateRecordVideogame(): %v\n", err)
+		return models.RecordVideogame{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var videogame models.RecordVideogame
+	err = json.NewDecoder(r.Body).Decode(&videogame)
+	if err != nil {
+		log.Printf("--ERROR-- with UpdateRecordVideogame(): %v\n", err)
+		return models.RecordVideogame{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- UpdateRecordVideogame() OK")
+	return videogame, nil
+}
+
+// Details are empty if none were set yet
+func (c *VideogamesClient) GetRecordVideogame(recordID string) (models.RecordVideogame, error) {
+	type parametersGetRecordVideogame struct {
+		RecordID string `json:"record_id"`
+	}
+
+	params := parametersGetRecordVideogame{
+		RecordID: recordID,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Videogames.GetRecordVideogame, params)
+	if err != nil {
+		log.Printf("--ERROR-- with GetRecordVideogame(): %v\n", err)
+		return models.RecordVideogame{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var videogame models.RecordVideogame
+	err = json.NewDecoder(r.Body).Decode(&videogame)
+	if err != nil {
+		log.Printf("--ERROR-- with GetRecordVideogame(): %v\n", err)
+		return models.RecordVideogame{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- GetRecordVideogame() OK")
+	return videogame, nil
+}
+
+// User's videogames by platform, the platform with the most hours played first
+func (c *VideogamesClient) GetPlatformStats() (models.PlatformsStats, error) {
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Videogames.GetPlatformStats, nil)
+	if err != nil {
+		log.Printf("--ERROR-- with GetPlatformStats(): %v\n", err)
+		return models.PlatformsStats{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var stats models.PlatformsStats
+	err = json.NewDecoder(r.Body).Decode(&stats)
+	if err != nil {
+		log.Printf("--ERROR-- with GetPlatformStats(): %v\n", err)
+		return models.PlatformsStats{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- GetPlatformStats() OK")
+	return stats, nil
+}
+
+// Videogames left to play by platform, of a single platform if platform isn't empty
+func (c *VideogamesClient) GetBacklog(platform string) (models.VideogameBacklog, error) {
+	type parametersGetVideogameBacklog struct {
+		Platform string `json:"platform"`
+	}
+
+	params := parametersGetVideogameBacklog{
+		Platform: platform,
+	}
+
+	// Make request
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Videogames.GetBacklog, params)
+	if err != nil {
+		log.Printf("--ERROR-- with GetBacklog(): %v\n", err)
+		return models.VideogameBacklog{}, err
+	}
+	defer r.Body.Close()
+
+	// Decode response
+	var backlog models.VideogameBacklog
+	err = json.NewDecoder(r.Body).Decode(&backlog)
+	if err != nil {
+		log.Printf("--ERROR-- with GetBacklog(): %v\n", err)
+		return models.VideogameBacklog{}, err
+	}
+
+	// Return data
+	log.Println("--DEBUG-- GetBacklog() OK")
+	return backlog, nil
+}
@@ -6,7 +6,11 @@ import (
 	"image"
 	"io"
 	"log"
+	"math"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"image/gif"
@@ -31,6 +35,22 @@ func (c *HelpersClient) GetMediaTypes(mediaRecords models.MediaWithRecords) map[
 	return mediaTypes
 }
 
+// Get media of every type on a custom shelf, by title
+func (c *HelpersClient) GetShelfMedia(mediaRecords models.MediaWithRecords, shelfID string) []models.MediumWithRecord {
+	shelfMedia := []models.MediumWithRecord{}
+	for _, mediaList := range mediaRecords.MediaRecords {
+		for _, medium := range mediaList {
+			if slices.Contains(medium.ShelfIDs, shelfID) {
+				shelfMedia = append(shelfMedia, medium)
+			}
+		}
+	}
+	slices.SortFunc(shelfMedia, func(a, b models.MediumWithRecord) int {
+		return strings.Compare(a.Title, b.Title)
+	})
+	return shelfMedia
+}
+
 func (c *HelpersClient) GetImage(imageUrl string) (*bytes.Buffer, error) {
 	// Check if image exists in cache
 	buf, exists := c.apiClient.Helpers.GetFromCache(imageUrl)
@@ -151,3 +171,43 @@ func (c *HelpersClient) FormatDateToLocalFormat(dateServerFormat string) (string
 	}
 	return parsedDate.Format("2006/01/02"), nil
 }
+
+// Rating scales user can choose from, ratings are stored on a 0-100 scale by server
+var RatingScales = []string{"5", "10", "100"}
+
+// Values suggested to rate on user's scale, from the lowest (any integer can be given on a 100 scale)
+func (c *HelpersClient) GetRatingOptions() []string {
+	step := map[string]float64{"5": 0.5, "10": 1, "100": 10}[c.GetRatingScale()]
+	scale, _ := strconv.ParseFloat(c.GetRatingScale(), 64)
+	options := []string{}
+	for value := 0.0; value <= scale; value += step {
+		options = append(options, strconv.FormatFloat(value, 'f', -1, 64))
+	}
+	return options
+}
+
+// User's rating scale, 5 stars by default
+func (c *HelpersClient) GetRatingScale() string {
+	if !slices.Contains(RatingScales, c.apiClient.RatingScale) {
+		return "5"
+	}
+	return c.apiClient.RatingScale
+}
+
+// Convert a rating stored by server (0-100) to a value user can rate on their scale
+func (c *HelpersClient) RatingToScale(rating float64) float64 {
+	scale, _ := strconv.ParseFloat(c.GetRatingScale(), 64)
+	value := rating * scale / 100
+	if scale == 5 {
+		// Stars are rated by halves
+		return math.Round(value*2) / 2
+	}
+	return math.Round(value)
+}
+
+// Format a rating stored by server (0-100) on user's rating scale, e.g. "4.5/5", averages keep one decimal
+func (c *HelpersClient) FormatRating(rating float64) string {
+	scale, _ := strconv.ParseFloat(c.GetRatingScale(), 64)
+	value := math.Round(rating*scale/10) / 10
+	return fmt.Sprintf("%s/%s", strconv.FormatFloat(value, 'f', -1, 64), c.GetRatingScale())
+}
@@ -117,8 +117,10 @@ func (c *HelpersClient) SearchMediumDetailsOnExternalApi(mediaType, mediumID str
 	switch mediaType {
 	case "book":
 		var bookIsbn string
+		externalIDs := make(map[string]string)
 		// First, check if mediumID provided is an ISBN or a works/key
 		if strings.Contains(mediumID, "works") {
+			externalIDs["openlibrary_work"] = strings.TrimPrefix(mediumID, "/works/")
 			// If works/key need to get Book ISBN from selected work key
 			isbn, err := c.apiClient.Helpers.GetBookISBN(mediumID)
 			if err != nil {
@@ -140,6 +142,7 @@ func (c *HelpersClient) SearchMediumDetailsOnExternalApi(mediaType, mediumID str
 		metadata["publishers"] = bookDetails.Publishers
 		if len(bookDetails.Isbn13) != 0 {
 			metadata["isbn13"] = bookDetails.Isbn13[0]
+			externalIDs["isbn13"] = bookDetails.Isbn13[0]
 		} else {
 			metadata["isbn13"] = ""
 		}
@@ -164,12 +167,13 @@ func (c *HelpersClient) SearchMediumDetailsOnExternalApi(mediaType, mediumID str
 
 		// Create ClientMedium
 		results = models.ClientMedium{
-			Title:     bookDetails.FullTitle,
-			MediaType: "book",
-			Creator:   authors,
-			PubDate:   bookDetails.PublishDate,
-			ImageUrl:  "",
-			Metadata:  metadata,
+			Title:       bookDetails.FullTitle,
+			MediaType:   "book",
+			Creator:     authors,
+			PubDate:     bookDetails.PublishDate,
+			ImageUrl:    "",
+			Metadata:    metadata,
+			ExternalIDs: externalIDs,
 		}
 
 	case "movie":
@@ -206,12 +210,13 @@ func (c *HelpersClient) SearchMediumDetailsOnExternalApi(mediaType, mediumID str
 
 		// Create ClientMedium
 		results = models.ClientMedium{
-			Title:     movieDetails.Title,
-			MediaType: "movie",
-			Creator:   findMovieDirectors(movieCredits),
-			PubDate:   movieDetails.ReleaseDate,
-			ImageUrl:  "",
-			Metadata:  metadata,
+			Title:       movieDetails.Title,
+			MediaType:   "movie",
+			Creator:     findMovieDirectors(movieCredits),
+			PubDate:     movieDetails.ReleaseDate,
+			ImageUrl:    "",
+			Metadata:    metadata,
+			ExternalIDs: map[string]string{"tmdb_movie": mediumID},
 		}
 
 	case "series":
@@ -244,12 +249,13 @@ func (c *HelpersClient) SearchMediumDetailsOnExternalApi(mediaType, mediumID str
 
 		// Create ClientMedium
 		results = models.ClientMedium{
-			Title:     seriesDetails.Name,
-			MediaType: "series",
-			Creator:   findSeriesCreators(seriesDetails),
-			PubDate:   seriesDetails.FirstAirDate,
-			ImageUrl:  "",
-			Metadata:  metadata,
+			Title:       seriesDetails.Name,
+			MediaType:   "series",
+			Creator:     findSeriesCreators(seriesDetails),
+			PubDate:     seriesDetails.FirstAirDate,
+			ImageUrl:    "",
+			Metadata:    metadata,
+			ExternalIDs: map[string]string{"tmdb_tv": mediumID},
 		}
 
 	case "videogame":
@@ -279,12 +285,13 @@ func (c *HelpersClient) SearchMediumDetailsOnExternalApi(mediaType, mediumID str
 
 		// Create ClientMedium
 		results = models.ClientMedium{
-			Title:     vgDetails.Name,
-			MediaType: "videogame",
-			Creator:   findVideogameDevelopers(vgDetails),
-			PubDate:   vgDetails.Released,
-			ImageUrl:  "",
-			Metadata:  metadata,
+			Title:       vgDetails.Name,
+			MediaType:   "videogame",
+			Creator:     findVideogameDevelopers(vgDetails),
+			PubDate:     vgDetails.Released,
+			ImageUrl:    "",
+			Metadata:    metadata,
+			ExternalIDs: map[string]string{"rawg": mediumID},
 		}
 
 	case "boardgame":
@@ -309,12 +316,13 @@ func (c *HelpersClient) SearchMediumDetailsOnExternalApi(mediaType, mediumID str
 
 		// Create ClientMedium
 		results = models.ClientMedium{
-			Title:     bgDetails.Items.Item.Name[0].Value,
-			MediaType: "boardgame",
-			Creator:   strings.Join(addDetails["designers"], ", "),
-			PubDate:   bgDetails.Items.Item.Yearpublished.Value,
-			ImageUrl:  "",
-			Metadata:  metadata,
+			Title:       bgDetails.Items.Item.Name[0].Value,
+			MediaType:   "boardgame",
+			Creator:     strings.Join(addDetails["designers"], ", "),
+			PubDate:     bgDetails.Items.Item.Yearpublished.Value,
+			ImageUrl:    "",
+			Metadata:    metadata,
+			ExternalIDs: map[string]string{"bgg": mediumID},
 		}
 	default:
 		return models.ClientMedium{}, errors.New("no external API available for this media type")
@@ -323,37 +331,38 @@ func (c *HelpersClient) SearchMediumDetailsOnExternalApi(mediaType, mediumID str
 	return results, nil
 }
 
-func (c *HelpersClient) SearchMediumInDB(mediaType, mediumTitle string) (models.Medium, error) {
-	type parametersGetMediumByTitleAndType struct {
+// Get every medium of the server's database sharing the given title (e.g. a film and its remake), ordered by publication date
+func (c *HelpersClient) SearchMediaInDB(mediaType, mediumTitle string) ([]models.Medium, error) {
+	type parametersGetMediaByTitleAndType struct {
 		Title     string `json:"title"`
 		MediaType string `json:"media_type"`
 	}
 
 	// Parameters for request
-	params := parametersGetMediumByTitleAndType{
+	params := parametersGetMediaByTitleAndType{
 		Title:     mediumTitle,
 		MediaType: mediaType,
 	}
 
 	// Make request
-	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Media.GetMediumByTitleAndType, params)
+	r, err := c.apiClient.makeHttpRequest(c.apiClient.Config.Endpoints.Media.GetMediaByTitleAndType, params)
 	if err != nil {
-		log.Printf("--ERROR-- with SearchMediumInDB(): %v\n", err)
-		return models.Medium{}, err
+		log.Printf("--ERROR-- with SearchMediaInDB(): %v\n", err)
+		return nil, err
 	}
 	defer r.Body.Close()
 
 	// Decode response
-	var medium models.Medium
-	err = json.NewDecoder(r.Body).Decode(&medium)
+	var candidates models.ListMedia
+	err = json.NewDecoder(r.Body).Decode(&candidates)
 	if err != nil {
-		log.Printf("--ERROR-- with SearchMediumInDB(): %v\n", err)
-		return models.Medium{}, err
+		log.Printf("--ERROR-- with SearchMediaInDB(): %v\n", err)
+		return nil, err
 	}
 
 	// Return data
-	log.Println("--DEBUG-- SearchMediumInDB() OK")
-	return medium, nil
+	log.Println("--DEBUG-- SearchMediaInDB() OK")
+	return candidates.Media, nil
 }
 
 func (c *HelpersClient) GetBoardgameImageUrl(id string) (string, error) {
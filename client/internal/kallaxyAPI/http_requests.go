@@ -42,6 +42,8 @@ func (c *APIClient) makeHttpRequest(endpoint Endpoint, params interface{}) (*htt
 			return nil, models.ErrBadRequest
 		case 401:
 			return nil, models.ErrUnauthorized
+		case 403:
+			return nil, models.ErrForbidden
 		case 404:
 			return nil, models.ErrNotFound
 		case 409:
@@ -83,6 +85,8 @@ func (c *APIClient) makeHttpRequestWithResfreshToken(endpoint Endpoint) (*http.R
 			return nil, models.ErrBadRequest
 		case 401:
 			return nil, models.ErrUnauthorized
+		case 403:
+			return nil, models.ErrForbidden
 		case 404:
 			return nil, models.ErrNotFound
 		case 409:
@@ -123,6 +127,8 @@ func (c *APIClient) makeHttpRequestWithQueryParameters(endpoint Endpoint, queryP
 			return nil, models.ErrBadRequest
 		case 401:
 			return nil, models.ErrUnauthorized
+		case 403:
+			return nil, models.ErrForbidden
 		case 404:
 			return nil, models.ErrNotFound
 		case 409:
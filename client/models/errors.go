@@ -8,4 +8,5 @@ var (
 	ErrBadRequest   = errors.New("bad request: invalid input provided")
 	ErrConflict     = errors.New("conflict: data already exists with input provided")
 	ErrNotFound     = errors.New("not found: no data with input provided")
+	ErrForbidden    = errors.New("forbidden: you are not allowed to do this")
 )
@@ -16,6 +16,81 @@ type ClientMedium struct {
 	PubDate   string                 `json:"pubdate"`
 	ImageUrl  string                 `json:"image_url"`
 	Metadata  map[string]interface{} `json:"metadata"`
+	// IDs on providers medium's info come from, by provider
+	ExternalIDs map[string]string `json:"external_ids"`
+}
+
+// A progress update of a record, only fields matching medium's type are set
+type ProgressUpdate struct {
+	Pages   *int32   `json:"pages,omitempty"`
+	Percent *float64 `json:"percent,omitempty"`
+}
+
+// Details of an owned copy, as sent to the server
+type CopyDetails struct {
+	Format       string   `json:"format"`
+	Condition    string   `json:"condition"`
+	Language     string   `json:"language"`
+	PurchaseDate string   `json:"purchase_date"`
+	Price        *float64 `json:"price"`
+	Currency     string   `json:"currency"`
+	Store        string   `json:"store"`
+}
+
+// Details of a shelving unit, as sent to the server
+// Cell dimensions left to 0 are a Kallax cube's ones
+type ShelvingUnitDetails struct {
+	Name         string `json:"name"`
+	Rows         int    `json:"rows"`
+	Columns      int    `json:"columns"`
+	CellWidthCm  int    `json:"cell_width_cm"`
+	CellHeightCm int    `json:"cell_height_cm"`
+	CellDepthCm  int    `json:"cell_depth_cm"`
+}
+
+// A player of a play, as sent to the server: a Kallaxy user by Username OR a guest by Name
+type PlayerDetails struct {
+	Username string `json:"username,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Score    *int   `json:"score"`
+	IsWinner bool   `json:"is_winner"`
+}
+
+// Details of a play, as sent to the server
+type PlayDetails struct {
+	PlayedAt        string          `json:"played_at"`
+	DurationMinutes *int            `json:"duration_minutes"`
+	Location        string          `json:"location"`
+	Expansions      []string        `json:"expansions"`
+	Players         []PlayerDetails `json:"players"`
+}
+
+// How a videogame was played, as sent to the server
+type VideogameDetails struct {
+	Platform           string   `json:"platform"`
+	HoursPlayed        *float64 `json:"hours_played"`
+	Completion         string   `json:"completion"`
+	AchievementsEarned *int     `json:"achievements_earned"`
+	AchievementsTotal  *int     `json:"achievements_total"`
+}
+
+// An episode of a series, as sent to the server
+type EpisodeRef struct {
+	SeasonNumber  int `json:"season_number"`
+	EpisodeNumber int `json:"episode_number"`
+}
+
+// Details of a goal, as sent to the server
+type GoalDetails struct {
+	Name      string   `json:"name"`
+	MediaType string   `json:"media_type"`
+	Metric    string   `json:"metric"`
+	Target    int      `json:"target"`
+	Period    string   `json:"period"`
+	Date      string   `json:"date"`
+	From      string   `json:"from"`
+	To        string   `json:"to"`
+	TagIDs    []string `json:"tag_ids"`
 }
 
 type ShortOnlineSearchResult struct {
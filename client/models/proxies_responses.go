@@ -183,6 +183,20 @@ type ResponseTvDetails struct {
 	Tagline string `json:"tagline"`
 }
 
+type ResponseTvSeason struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	SeasonNumber int    `json:"season_number"`
+	Episodes     []struct {
+		AirDate       string `json:"air_date"`
+		EpisodeNumber int    `json:"episode_number"`
+		Name          string `json:"name"`
+		Overview      string `json:"overview"`
+		Runtime       int    `json:"runtime"`
+		SeasonNumber  int    `json:"season_number"`
+	} `json:"episodes"`
+}
+
 type ResponseMovieCredits struct {
 	ID   int `json:"id"`
 	Cast []struct {
@@ -24,15 +24,29 @@ type TokensAndUser struct {
 }
 
 type Medium struct {
-	ID        string                 `json:"id"`
-	MediaType string                 `json:"media_type"`
-	CreatedAt string                 `json:"created_at"`
-	UpdatedAt string                 `json:"updated_at"`
-	Title     string                 `json:"title"`
-	Creator   string                 `json:"creator"`
-	PubDate   string                 `json:"pub_date"`
-	ImageUrl  string                 `json:"image_url"`
-	Metadata  map[string]interface{} `json:"metadata"`
+	ID          string                 `json:"id"`
+	MediaType   string                 `json:"media_type"`
+	CreatedAt   string                 `json:"created_at"`
+	UpdatedAt   string                 `json:"updated_at"`
+	Title       string                 `json:"title"`
+	Creator     string                 `json:"creator"`
+	PubDate     string                 `json:"pub_date"`
+	ImageUrl    string                 `json:"image_url"`
+	Metadata    map[string]interface{} `json:"metadata"`
+	ExternalIDs map[string]string      `json:"external_ids"`
+}
+
+type RefreshMedium struct {
+	Medium   Medium          `json:"medium"`
+	Provider string          `json:"provider"`
+	Changes  []RefreshChange `json:"changes"`
+	Applied  bool            `json:"applied"`
+}
+
+type RefreshChange struct {
+	Field   string      `json:"field"`
+	Current interface{} `json:"current"`
+	Fetched interface{} `json:"fetched"`
 }
 
 type ListMedia struct {
@@ -46,16 +60,40 @@ type Record struct {
 	UserID     string `json:"user_id"`
 	MediaID    string `json:"media_id"`
 	IsFinished bool   `json:"is_finished"`
+	Status     string `json:"status"`
 	StartDate  string `json:"start_date"`
 	EndDate    string `json:"end_date"`
 	Duration   int32  `json:"duration"`
 	Comments   string `json:"comments"`
+	// Only set for abandoned records
+	AbandonReason *string `json:"abandon_reason"`
+	// On a 0-100 scale, nil if unrated
+	Rating *int16 `json:"rating"`
 }
 
 type Records struct {
 	Records []Record `json:"records"`
 }
 
+// One update of a record's progress, completion is the percent of the medium consumed when it can be told
+type RecordProgress struct {
+	ID         string   `json:"id"`
+	LoggedAt   string   `json:"logged_at"`
+	Pages      *int32   `json:"pages"`
+	Percent    *float64 `json:"percent"`
+	Total      *int32   `json:"total"`
+	Completion *float64 `json:"completion"`
+}
+
+type RecordProgressCurve struct {
+	RecordID  string           `json:"record_id"`
+	MediaType string           `json:"media_type"`
+	Progress  []RecordProgress `json:"progress"`
+	// Percent of the medium per day
+	Pace                float64 `json:"pace"`
+	EstimatedFinishDate string  `json:"estimated_finish_date"`
+}
+
 type ResponseVerifyResetToken struct {
 	Valid bool   `json:"valid"`
 	Email string `json:"email"`
@@ -66,22 +104,403 @@ type MediumWithRecord struct {
 	UserID     string                 `json:"user_id"`
 	MediaID    string                 `json:"medium_id"`
 	IsFinished bool                   `json:"is_finished"`
+	Status     string                 `json:"status"`
 	StartDate  string                 `json:"start_date"`
 	EndDate    string                 `json:"end_date"`
 	Duration   int32                  `json:"duration"`
 	Comments   string                 `json:"comments"`
+	Rating     *int16                 `json:"rating"`
 	MediaType  string                 `json:"media_type"`
 	Title      string                 `json:"title"`
 	Creator    string                 `json:"creator"`
 	PubDate    string                 `json:"pub_date"`
 	ImageUrl   string                 `json:"image_url"`
 	Metadata   map[string]interface{} `json:"metadata"`
+
+	// Only set for abandoned records
+	AbandonReason string `json:"abandon_reason"`
+
+	// Every consumption of the medium (re-read, replay...), oldest first
+	ConsumptionCount int      `json:"consumption_count"`
+	History          []Record `json:"history"`
+
+	// Average of all users' ratings of the medium (0-100 scale), each user counting once
+	RatingAverage float64 `json:"rating_average"`
+	RatingCount   int64   `json:"rating_count"`
+
+	// Names of user's tags on the medium, and IDs of user's custom shelves holding it
+	Tags     []string `json:"tags"`
+	ShelfIDs []string `json:"shelf_ids"`
 }
 
 type MediaWithRecords struct {
 	MediaRecords map[string][]MediumWithRecord `json:"records"`
 }
 
+type Tag struct {
+	ID         string `json:"id"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
+	Name       string `json:"name"`
+	MediaCount int64  `json:"media_count"`
+}
+
+type Tags struct {
+	Tags []Tag `json:"tags"`
+}
+
+type Shelf struct {
+	ID          string `json:"id"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	MediaCount  int64  `json:"media_count"`
+}
+
+type Shelves struct {
+	Shelves []Shelf `json:"shelves"`
+}
+
+type Review struct {
+	ID          string `json:"id"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+	RecordID    string `json:"record_id"`
+	Title       string `json:"title"`
+	Body        string `json:"body"`
+	HTML        string `json:"html"`
+	HasSpoilers bool   `json:"has_spoilers"`
+	IsPublished bool   `json:"is_published"`
+	PublishedAt string `json:"published_at"`
+	Revision    int32  `json:"revision"`
+}
+
+type ReviewRevision struct {
+	Revision  int32  `json:"revision"`
+	CreatedAt string `json:"created_at"`
+	Title     string `json:"title"`
+	Body      string `json:"body"`
+	// Changes from previous revision, lines starting with "+ " or "- "
+	Diff string `json:"diff"`
+}
+
+type ReviewRevisions struct {
+	ReviewID  string           `json:"review_id"`
+	Revisions []ReviewRevision `json:"revisions"`
+}
+
+// Review as other users see it, without its Markdown body
+type PublishedReview struct {
+	ID          string `json:"id"`
+	UpdatedAt   string `json:"updated_at"`
+	RecordID    string `json:"record_id"`
+	UserID      string `json:"user_id"`
+	Username    string `json:"username"`
+	Title       string `json:"title"`
+	HTML        string `json:"html"`
+	HasSpoilers bool   `json:"has_spoilers"`
+	PublishedAt string `json:"published_at"`
+}
+
+type MediumReviews struct {
+	MediumID string            `json:"medium_id"`
+	Reviews  []PublishedReview `json:"reviews"`
+}
+
+type Loan struct {
+	ID            string `json:"id"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
+	OwnerID       string `json:"owner_id"`
+	OwnerUsername string `json:"owner_username"`
+	MediumID      string `json:"medium_id"`
+	MediaType     string `json:"media_type"`
+	Title         string `json:"title"`
+	BorrowerID    string `json:"borrower_id"`
+	BorrowerName  string `json:"borrower_name"`
+	LentAt        string `json:"lent_at"`
+	DueAt         string `json:"due_at"`
+	ReturnedAt    string `json:"returned_at"`
+	IsOverdue     bool   `json:"is_overdue"`
+	DaysOverdue   int32  `json:"days_overdue"`
+}
+
+// Open loans, made by the user or to them
+type Loans struct {
+	LentOut  []Loan `json:"lent_out"`
+	Borrowed []Loan `json:"borrowed"`
+}
+
+type MediumLoans struct {
+	MediumID  string `json:"medium_id"`
+	IsLentOut bool   `json:"is_lent_out"`
+	Loans     []Loan `json:"loans"`
+}
+
+type OwnedCopy struct {
+	ID           string   `json:"id"`
+	CreatedAt    string   `json:"created_at"`
+	UpdatedAt    string   `json:"updated_at"`
+	UserID       string   `json:"user_id"`
+	MediumID     string   `json:"medium_id"`
+	MediaType    string   `json:"media_type"`
+	Title        string   `json:"title"`
+	Format       string   `json:"format"`
+	Condition    string   `json:"condition"`
+	Language     string   `json:"language"`
+	PurchaseDate string   `json:"purchase_date"`
+	Price        *float64 `json:"price"`
+	Currency     string   `json:"currency"`
+	Store        string   `json:"store"`
+}
+
+type OwnedCopies struct {
+	Copies []OwnedCopy `json:"copies"`
+}
+
+type MediumCopies struct {
+	MediumID string      `json:"medium_id"`
+	Copies   []OwnedCopy `json:"copies"`
+}
+
+type CollectionValue struct {
+	MediaType   string  `json:"media_type"`
+	Currency    string  `json:"currency"`
+	CopiesCount int     `json:"copies_count"`
+	Value       float64 `json:"value"`
+}
+
+type CollectionValues struct {
+	CopiesCount   int               `json:"copies_count"`
+	UnpricedCount int               `json:"unpriced_count"`
+	ByMediaType   []CollectionValue `json:"by_media_type"`
+	Totals        []CollectionValue `json:"totals"`
+}
+
+type ShelvingUnit struct {
+	ID           string `json:"id"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
+	Name         string `json:"name"`
+	Rows         int    `json:"rows"`
+	Columns      int    `json:"columns"`
+	CellWidthCm  int    `json:"cell_width_cm"`
+	CellHeightCm int    `json:"cell_height_cm"`
+	CellDepthCm  int    `json:"cell_depth_cm"`
+	ItemsCount   int    `json:"items_count"`
+}
+
+type ShelvingUnits struct {
+	Units []ShelvingUnit `json:"units"`
+}
+
+type ShelvingItem struct {
+	MediumID  string `json:"medium_id"`
+	MediaType string `json:"media_type"`
+	Title     string `json:"title"`
+	ImageUrl  string `json:"image_url"`
+	UnitID    string `json:"unit_id"`
+	UnitName  string `json:"unit_name"`
+	Row       int    `json:"row"`
+	Column    int    `json:"column"`
+	PlacedAt  string `json:"placed_at"`
+}
+
+type ShelvingCell struct {
+	Row    int            `json:"row"`
+	Column int            `json:"column"`
+	Items  []ShelvingItem `json:"items"`
+}
+
+type ShelvingUnitGrid struct {
+	Unit  ShelvingUnit   `json:"unit"`
+	Cells []ShelvingCell `json:"cells"`
+}
+
+type ShelvingLocations struct {
+	Locations []ShelvingItem `json:"locations"`
+}
+
+// A player of a play, PlayerID is empty for guests
+type PlayPlayer struct {
+	PlayerID   string `json:"player_id"`
+	PlayerName string `json:"player_name"`
+	Score      *int   `json:"score"`
+	IsWinner   bool   `json:"is_winner"`
+}
+
+type Play struct {
+	ID              string       `json:"id"`
+	CreatedAt       string       `json:"created_at"`
+	UpdatedAt       string       `json:"updated_at"`
+	MediumID        string       `json:"medium_id"`
+	Title           string       `json:"title"`
+	PlayedAt        string       `json:"played_at"`
+	DurationMinutes *int         `json:"duration_minutes"`
+	Location        string       `json:"location"`
+	Expansions      []string     `json:"expansions"`
+	Players         []PlayPlayer `json:"players"`
+}
+
+type Plays struct {
+	Plays []Play `json:"plays"`
+}
+
+type GamePlays struct {
+	MediumID     string `json:"medium_id"`
+	Title        string `json:"title"`
+	PlaysCount   int    `json:"plays_count"`
+	TotalMinutes int    `json:"total_minutes"`
+	LastPlayedAt string `json:"last_played_at"`
+}
+
+type PlayCounts struct {
+	PlaysCount   int         `json:"plays_count"`
+	TotalMinutes int         `json:"total_minutes"`
+	Games        []GamePlays `json:"games"`
+}
+
+// WinRate goes from 0 to 1
+type PlayerWinRate struct {
+	PlayerID   string  `json:"player_id"`
+	PlayerName string  `json:"player_name"`
+	PlaysCount int     `json:"plays_count"`
+	WinsCount  int     `json:"wins_count"`
+	WinRate    float64 `json:"win_rate"`
+}
+
+type PlayerWinRates struct {
+	PlaysCount int             `json:"plays_count"`
+	Players    []PlayerWinRate `json:"players"`
+}
+
+type PlaysHIndex struct {
+	HIndex      int `json:"h_index"`
+	PlaysToNext int `json:"plays_to_next"`
+	GamesCount  int `json:"games_count"`
+	PlaysCount  int `json:"plays_count"`
+}
+
+type RecordVideogame struct {
+	RecordID           string   `json:"record_id"`
+	UpdatedAt          string   `json:"updated_at"`
+	Platform           string   `json:"platform"`
+	HoursPlayed        *float64 `json:"hours_played"`
+	Completion         string   `json:"completion"`
+	AchievementsEarned *int     `json:"achievements_earned"`
+	AchievementsTotal  *int     `json:"achievements_total"`
+}
+
+type PlatformStats struct {
+	Platform           string         `json:"platform"`
+	GamesCount         int            `json:"games_count"`
+	FinishedCount      int            `json:"finished_count"`
+	BacklogCount       int            `json:"backlog_count"`
+	HoursPlayed        float64        `json:"hours_played"`
+	Completions        map[string]int `json:"completions"`
+	AchievementsEarned int            `json:"achievements_earned"`
+	AchievementsTotal  int            `json:"achievements_total"`
+}
+
+type PlatformsStats struct {
+	Platforms []PlatformStats `json:"platforms"`
+}
+
+type BacklogGame struct {
+	RecordID    string   `json:"record_id"`
+	MediumID    string   `json:"medium_id"`
+	Title       string   `json:"title"`
+	ImageUrl    string   `json:"image_url"`
+	Status      string   `json:"status"`
+	HoursPlayed *float64 `json:"hours_played"`
+}
+
+type PlatformBacklog struct {
+	Platform string        `json:"platform"`
+	Games    []BacklogGame `json:"games"`
+}
+
+type VideogameBacklog struct {
+	Platforms []PlatformBacklog `json:"platforms"`
+}
+
+type SeriesSeason struct {
+	SeasonNumber int `json:"season_number"`
+	EpisodeCount int `json:"episode_count"`
+	WatchedCount int `json:"watched_count"`
+}
+
+type RecordEpisode struct {
+	SeasonNumber  int    `json:"season_number"`
+	EpisodeNumber int    `json:"episode_number"`
+	WatchedAt     string `json:"watched_at"`
+	Rating        *int16 `json:"rating"`
+}
+
+type NextEpisode struct {
+	RecordID      string `json:"record_id"`
+	MediumID      string `json:"medium_id"`
+	Title         string `json:"title"`
+	ImageUrl      string `json:"image_url"`
+	SeasonNumber  int    `json:"season_number"`
+	EpisodeNumber int    `json:"episode_number"`
+	LastWatchedAt string `json:"last_watched_at"`
+}
+
+type RecordEpisodes struct {
+	RecordID      string          `json:"record_id"`
+	Status        string          `json:"status"`
+	WatchedCount  int             `json:"watched_count"`
+	TotalEpisodes *int            `json:"total_episodes"`
+	Seasons       []SeriesSeason  `json:"seasons"`
+	Episodes      []RecordEpisode `json:"episodes"`
+	NextEpisode   *NextEpisode    `json:"next_episode"`
+}
+
+type NextEpisodes struct {
+	NextEpisodes []NextEpisode `json:"next_episodes"`
+}
+
+type GoalTag struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type Goal struct {
+	ID           string    `json:"id"`
+	CreatedAt    string    `json:"created_at"`
+	UpdatedAt    string    `json:"updated_at"`
+	Name         string    `json:"name"`
+	MediaType    string    `json:"media_type"`
+	Metric       string    `json:"metric"`
+	Target       int       `json:"target"`
+	Period       string    `json:"period"`
+	PeriodStart  string    `json:"period_start"`
+	PeriodEnd    string    `json:"period_end"`
+	Tags         []GoalTag `json:"tags"`
+	RecordsCount int       `json:"records_count"`
+	Progress     float64   `json:"progress"`
+	Percent      float64   `json:"percent"`
+	Expected     float64   `json:"expected"`
+	Pace         float64   `json:"pace"`
+	Status       string    `json:"status"`
+}
+
+type Goals struct {
+	Goals []Goal `json:"goals"`
+}
+
+type Streak struct {
+	Unit         string `json:"unit"`
+	MediaType    string `json:"media_type"`
+	Current      int    `json:"current"`
+	ActiveNow    bool   `json:"active_now"`
+	Longest      int    `json:"longest"`
+	LongestStart string `json:"longest_start"`
+	LastActiveAt string `json:"last_active_at"`
+}
+
 type BookISBN struct {
 	ISBN10 string `json:"isbn10"`
 	ISBN13 string `json:"isbn13"`
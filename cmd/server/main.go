@@ -1,7 +1,30 @@
 package main
 
-import "github.com/VincNT21/kallaxy/server/server"
+import (
+	"log"
+	"os"
+
+	"github.com/VincNT21/kallaxy/server/server"
+)
 
 func main() {
-	server.Start()
+	// "server migrate up|down|status" only handles database schema
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err := server.Migrate(os.Args[2:])
+		if err != nil {
+			log.Fatalf("--FATAL ERROR-- %v", err)
+		}
+		return
+	}
+
+	// "server admin promote|demote <username>" manages admin role
+	if len(os.Args) > 1 && os.Args[1] == "admin" {
+		err := server.Admin(os.Args[2:])
+		if err != nil {
+			log.Fatalf("--FATAL ERROR-- %v", err)
+		}
+		return
+	}
+
+	server.Start(os.Args[1:])
 }
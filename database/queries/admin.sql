@@ -0,0 +1,14 @@
+-- name: GetInstanceCounts :one
+SELECT
+    (SELECT count(*) FROM users) AS users,
+    (SELECT count(*) FROM users WHERE deactivated_at IS NOT NULL) AS deactivated_users,
+    (SELECT count(*) FROM users WHERE role = 'admin') AS admins,
+    (SELECT count(*) FROM media) AS media,
+    (SELECT count(*) FROM users_media_records) AS records,
+    (SELECT count(*) FROM shares) AS shares;
+
+-- name: CountMediaByType :many
+SELECT media_type, count(*) AS count
+FROM media
+GROUP BY media_type
+ORDER BY media_type;
@@ -0,0 +1,66 @@
+-- name: MarkRecordEpisode :one
+-- An episode watched again keeps its last date and rating
+INSERT INTO records_episodes (record_id, season_number, episode_number, watched_at, rating)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5
+)
+ON CONFLICT (record_id, season_number, episode_number) DO UPDATE
+SET watched_at = EXCLUDED.watched_at,
+    rating = EXCLUDED.rating
+RETURNING *;
+
+-- name: UnmarkRecordEpisode :one
+WITH deleted AS (
+    DELETE FROM records_episodes
+    WHERE record_id = $1
+    AND season_number = $2
+    AND episode_number = $3
+    RETURNING *
+)
+SELECT count(*) FROM deleted;
+
+-- name: GetRecordEpisodes :many
+SELECT * FROM records_episodes
+WHERE record_id = $1
+ORDER BY season_number, episode_number;
+
+-- name: GetEpisodesByUserID :many
+-- Every episode watched by user, whatever the record
+SELECT records_episodes.* FROM records_episodes
+INNER JOIN users_media_records AS records
+ON records_episodes.record_id = records.id
+WHERE records.user_id = $1
+ORDER BY records_episodes.record_id, records_episodes.season_number, records_episodes.episode_number;
+
+-- name: GetSeriesRecordsByUserID :many
+-- Series user is watching, with their medium
+SELECT
+    records.id,
+    records.media_id,
+    records.status,
+    media.title,
+    media.image_url,
+    media.metadata
+FROM users_media_records AS records
+INNER JOIN media
+ON records.media_id = media.id
+WHERE records.user_id = $1
+AND media.media_type = 'series'
+AND records.status = 'in_progress'
+ORDER BY media.title, records.id;
+
+-- name: RepointEpisodesToRecord :exec
+-- Episodes of a record merged into another one follow it, unless the kept record already has them
+UPDATE records_episodes
+SET record_id = sqlc.arg(new_record_id)
+WHERE record_id = sqlc.arg(old_record_id)
+AND NOT EXISTS (
+    SELECT 1 FROM records_episodes AS existing
+    WHERE existing.record_id = sqlc.arg(new_record_id)
+    AND existing.season_number = records_episodes.season_number
+    AND existing.episode_number = records_episodes.episode_number
+);
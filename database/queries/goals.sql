@@ -0,0 +1,172 @@
+-- name: CreateGoal :one
+INSERT INTO goals (id, created_at, updated_at, user_id, name, media_type, metric, target, period, period_start, period_end)
+VALUES (
+    gen_random_uuid(),
+    NOW(),
+    NOW(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8
+)
+RETURNING *;
+
+-- name: GetUserGoalByID :one
+SELECT * FROM goals
+WHERE id = $1
+AND user_id = $2;
+
+-- name: GetGoalsByUserID :many
+-- Latest periods first
+SELECT * FROM goals
+WHERE user_id = $1
+ORDER BY period_start DESC, created_at, id;
+
+-- name: UpdateGoal :one
+UPDATE goals
+SET name = $3, media_type = $4, metric = $5, target = $6, period = $7, period_start = $8, period_end = $9, updated_at = NOW()
+WHERE id = $1
+AND user_id = $2
+RETURNING *;
+
+-- name: DeleteGoal :one
+WITH deleted AS (
+    DELETE FROM goals
+    WHERE id = $1
+    AND user_id = $2
+    RETURNING *
+)
+SELECT count(*) FROM deleted;
+
+-- name: CreateGoalTag :one
+INSERT INTO goals_tags (goal_id, tag_id)
+VALUES (
+    $1,
+    $2
+)
+RETURNING *;
+
+-- name: DeleteGoalTags :exec
+DELETE FROM goals_tags
+WHERE goal_id = $1;
+
+-- name: GetGoalTagsByUserID :many
+-- Tags of every goal of user, by name
+SELECT
+    goals_tags.goal_id,
+    tags.id AS tag_id,
+    tags.name
+FROM goals_tags
+INNER JOIN goals
+ON goals_tags.goal_id = goals.id
+INNER JOIN tags
+ON goals_tags.tag_id = tags.id
+WHERE goals.user_id = $1
+ORDER BY goals_tags.goal_id, lower(tags.name), tags.id;
+
+-- name: GetGoalRecords :many
+-- Records a goal counts: finished in its period, of its media type and, if it has tags, of a medium holding one of them
+SELECT
+    records.id,
+    records.media_id,
+    records.end_date,
+    media.media_type,
+    media.metadata,
+    records_videogames.hours_played
+FROM users_media_records AS records
+INNER JOIN media
+ON records.media_id = media.id
+LEFT JOIN records_videogames
+ON records_videogames.record_id = records.id
+WHERE records.user_id = sqlc.arg(user_id)
+AND records.is_finished
+AND records.end_date >= sqlc.arg(period_start)::timestamp
+AND records.end_date < sqlc.arg(period_end)::timestamp
+AND lower(media.media_type) = lower(sqlc.arg(media_type)::text)
+AND (
+    cardinality(sqlc.arg(tag_ids)::uuid[]) = 0
+    OR EXISTS (
+        SELECT 1 FROM media_tags
+        WHERE media_tags.media_id = records.media_id
+        AND media_tags.tag_id = ANY(sqlc.arg(tag_ids)::uuid[])
+    )
+)
+ORDER BY records.end_date, records.id;
+
+-- name: GetGoalRecordsByUserID :many
+-- Records each goal of user counts, by goal, as GetGoalRecords would for each of them
+SELECT
+    goals.id AS goal_id,
+    records.id,
+    records.media_id,
+    records.end_date,
+    media.media_type,
+    media.metadata,
+    records_videogames.hours_played
+FROM goals
+INNER JOIN users_media_records AS records
+ON records.user_id = goals.user_id
+AND records.is_finished
+AND records.end_date >= goals.period_start
+AND records.end_date < goals.period_end
+INNER JOIN media
+ON records.media_id = media.id
+AND lower(media.media_type) = lower(goals.media_type)
+LEFT JOIN records_videogames
+ON records_videogames.record_id = records.id
+WHERE goals.user_id = $1
+AND (
+    NOT EXISTS (
+        SELECT 1 FROM goals_tags
+        WHERE goals_tags.goal_id = goals.id
+    )
+    OR EXISTS (
+        SELECT 1 FROM media_tags
+        INNER JOIN goals_tags
+        ON media_tags.tag_id = goals_tags.tag_id
+        WHERE goals_tags.goal_id = goals.id
+        AND media_tags.media_id = records.media_id
+    )
+)
+ORDER BY goals.id, records.end_date, records.id;
+
+-- name: GetActivityDays :many
+-- Days user started or finished a record, logged progress, watched an episode or played a boardgame, for a single media type if given
+SELECT DISTINCT date_trunc('day', activity.at)::timestamp AS day
+FROM (
+    SELECT records.start_date AS at, records.media_id
+    FROM users_media_records AS records
+    WHERE records.user_id = sqlc.arg(user_id)
+    AND records.start_date IS NOT NULL
+    UNION ALL
+    SELECT records.end_date, records.media_id
+    FROM users_media_records AS records
+    WHERE records.user_id = sqlc.arg(user_id)
+    AND records.is_finished
+    AND records.end_date IS NOT NULL
+    UNION ALL
+    SELECT records_progress.logged_at, records.media_id
+    FROM records_progress
+    INNER JOIN users_media_records AS records
+    ON records_progress.record_id = records.id
+    WHERE records.user_id = sqlc.arg(user_id)
+    UNION ALL
+    SELECT records_episodes.watched_at, records.media_id
+    FROM records_episodes
+    INNER JOIN users_media_records AS records
+    ON records_episodes.record_id = records.id
+    WHERE records.user_id = sqlc.arg(user_id)
+    UNION ALL
+    SELECT plays.played_at, plays.media_id
+    FROM plays
+    WHERE plays.user_id = sqlc.arg(user_id)
+) AS activity
+INNER JOIN media
+ON activity.media_id = media.id
+WHERE sqlc.arg(media_type)::text = ''
+OR lower(media.media_type) = lower(sqlc.arg(media_type)::text)
+ORDER BY day DESC;
@@ -0,0 +1,116 @@
+-- name: CreateLoan :one
+INSERT INTO loans (id, created_at, updated_at, owner_id, media_id, borrower_id, borrower_name, lent_at, due_at)
+VALUES (
+    gen_random_uuid(),
+    NOW(),
+    NOW(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6
+)
+RETURNING *;
+
+-- name: GetUserLoanByID :one
+SELECT * FROM loans
+WHERE id = $1
+AND owner_id = $2;
+
+-- name: GetOpenLoansByUserID :many
+-- Items lent by user or to user, not returned yet
+SELECT
+    loans.id,
+    loans.created_at,
+    loans.updated_at,
+    loans.owner_id,
+    owners.username AS owner_username,
+    loans.media_id,
+    media.media_type,
+    media.title,
+    loans.borrower_id,
+    loans.borrower_name,
+    loans.lent_at,
+    loans.due_at,
+    loans.returned_at
+FROM loans
+INNER JOIN users AS owners
+ON loans.owner_id = owners.id
+INNER JOIN media
+ON loans.media_id = media.id
+WHERE (loans.owner_id = $1 OR loans.borrower_id = $1)
+AND loans.returned_at IS NULL
+ORDER BY loans.due_at NULLS LAST, loans.lent_at, loans.id;
+
+-- name: GetOverdueLoansByUserID :many
+-- Items lent by user or to user, not returned by their expected return date
+SELECT
+    loans.id,
+    loans.created_at,
+    loans.updated_at,
+    loans.owner_id,
+    owners.username AS owner_username,
+    loans.media_id,
+    media.media_type,
+    media.title,
+    loans.borrower_id,
+    loans.borrower_name,
+    loans.lent_at,
+    loans.due_at,
+    loans.returned_at
+FROM loans
+INNER JOIN users AS owners
+ON loans.owner_id = owners.id
+INNER JOIN media
+ON loans.media_id = media.id
+WHERE (loans.owner_id = $1 OR loans.borrower_id = $1)
+AND loans.returned_at IS NULL
+AND loans.due_at < NOW()
+ORDER BY loans.due_at, loans.lent_at, loans.id;
+
+-- name: GetMediumLoans :many
+-- Every time user lent a medium, last one first
+SELECT * FROM loans
+WHERE owner_id = $1
+AND media_id = $2
+ORDER BY lent_at DESC, id;
+
+-- name: UpdateLoan :one
+UPDATE loans
+SET due_at = $3, returned_at = $4, updated_at = NOW()
+WHERE id = $1
+AND owner_id = $2
+RETURNING *;
+
+-- name: DeleteLoan :one
+WITH deleted AS (
+    DELETE FROM loans
+    WHERE id = $1
+    AND owner_id = $2
+    RETURNING *
+)
+SELECT count(*) FROM deleted;
+
+-- name: CloseLoansOfMergedMedium :one
+-- A merged medium's open loans are closed when its owner has the kept one lent out already, as an item is lent to one borrower at a time
+WITH closed AS (
+    UPDATE loans
+    SET returned_at = GREATEST(NOW()::timestamp, lent_at), updated_at = NOW()
+    WHERE media_id = sqlc.arg(old_media_id)
+    AND returned_at IS NULL
+    AND EXISTS (
+        SELECT 1 FROM loans AS existing
+        WHERE existing.owner_id = loans.owner_id
+        AND existing.media_id = sqlc.arg(new_media_id)
+        AND existing.returned_at IS NULL
+    )
+    RETURNING *
+)
+SELECT count(*) FROM closed;
+
+-- name: RepointLoansToMedium :exec
+-- Loans of a merged medium go to the kept one
+UPDATE loans
+SET media_id = sqlc.arg(new_media_id), updated_at = NOW()
+WHERE media_id = sqlc.arg(old_media_id);
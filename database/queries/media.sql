@@ -1,5 +1,5 @@
 -- name: CreateMedium :one
-INSERT INTO media (id, media_type, created_at, updated_at, title, creator, pub_date, image_url, metadata)
+INSERT INTO media (id, media_type, created_at, updated_at, title, creator, pub_date, image_url, metadata, created_by, external_ids)
 VALUES (
     gen_random_uuid(),
     $1,
@@ -9,20 +9,35 @@ VALUES (
     $3,
     $4,
     $5,
-    $6
+    $6,
+    $7,
+    $8
 )
 RETURNING *;
 
 -- name: UpdateMedium :one
 UPDATE media
-SET title = $2, creator = $3, pub_date = $4, image_url = $5, metadata = $6, updated_at = NOW()
+SET title = $2, creator = $3, pub_date = $4, image_url = $5, metadata = $6, external_ids = $7, updated_at = NOW()
 WHERE id = $1
 RETURNING *;
 
--- name: GetMediumByTitleAndType :one
+-- name: GetMediaByTitleAndType :many
+-- Every medium sharing the title is a candidate, creator and publication year narrow the list when given
 SELECT * FROM media
-WHERE LOWER(title) = LOWER($1)
-AND LOWER(media_type) = LOWER($2);
+WHERE LOWER(title) = LOWER(sqlc.arg(title))
+AND LOWER(media_type) = LOWER(sqlc.arg(media_type))
+AND (sqlc.arg(creator)::text = '' OR LOWER(creator) = LOWER(sqlc.arg(creator)))
+AND (sqlc.arg(pub_year)::text = '' OR SUBSTRING(pub_date FROM '[0-9]{4}') = sqlc.arg(pub_year))
+ORDER BY pub_date, creator, id;
+
+-- name: GetMediaByExternalIDs :many
+-- Media linked to any of the given provider IDs, given as {"provider": "id"}
+SELECT * FROM media
+WHERE EXISTS (
+    SELECT 1 FROM jsonb_each_text(sqlc.arg(external_ids)::jsonb) AS given
+    WHERE media.external_ids->>given.key = given.value
+)
+ORDER BY pub_date, creator, id;
 
 -- name: GetMediaByType :many
 SELECT * FROM media
@@ -32,6 +47,11 @@ WHERE LOWER(media_type) = LOWER($1);
 SELECT * FROM media
 WHERE id = $1;
 
+-- name: GetMediumByIDForUpdate :one
+SELECT * FROM media
+WHERE id = $1
+FOR UPDATE;
+
 -- name: DeleteMedium :one
 WITH deleted AS (
     DELETE FROM media
@@ -41,4 +61,23 @@ WITH deleted AS (
 SELECT count(*) FROM deleted;
 
 -- name: ResetMedia :exec
-DELETE FROM media;
\ No newline at end of file
+DELETE FROM media;
+
+-- name: GetMediumRedirect :one
+SELECT media_id FROM media_redirects
+WHERE old_id = $1;
+
+-- name: CreateMediumRedirect :exec
+INSERT INTO media_redirects (old_id, media_id, created_at, merged_by)
+VALUES (
+    $1,
+    $2,
+    NOW(),
+    $3
+);
+
+-- name: RepointMediumRedirects :exec
+-- Redirects to a merged medium follow it to the medium it is merged into
+UPDATE media_redirects
+SET media_id = sqlc.arg(new_media_id)
+WHERE media_id = sqlc.arg(old_media_id);
\ No newline at end of file
@@ -0,0 +1,87 @@
+-- name: CreateOwnedCopy :one
+INSERT INTO owned_copies (id, created_at, updated_at, user_id, media_id, format, condition, language, purchase_date, price_cents, currency, store)
+VALUES (
+    gen_random_uuid(),
+    NOW(),
+    NOW(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9
+)
+RETURNING *;
+
+-- name: GetUserOwnedCopyByID :one
+SELECT * FROM owned_copies
+WHERE id = $1
+AND user_id = $2;
+
+-- name: GetOwnedCopiesByUserID :many
+SELECT
+    owned_copies.id,
+    owned_copies.created_at,
+    owned_copies.updated_at,
+    owned_copies.user_id,
+    owned_copies.media_id,
+    media.media_type,
+    media.title,
+    owned_copies.format,
+    owned_copies.condition,
+    owned_copies.language,
+    owned_copies.purchase_date,
+    owned_copies.price_cents,
+    owned_copies.currency,
+    owned_copies.store
+FROM owned_copies
+INNER JOIN media
+ON owned_copies.media_id = media.id
+WHERE owned_copies.user_id = $1
+ORDER BY media.media_type, lower(media.title), owned_copies.created_at, owned_copies.id;
+
+-- name: GetMediumOwnedCopies :many
+SELECT * FROM owned_copies
+WHERE user_id = $1
+AND media_id = $2
+ORDER BY created_at, id;
+
+-- name: UpdateOwnedCopy :one
+UPDATE owned_copies
+SET format = $3, condition = $4, language = $5, purchase_date = $6, price_cents = $7, currency = $8, store = $9, updated_at = NOW()
+WHERE id = $1
+AND user_id = $2
+RETURNING *;
+
+-- name: DeleteOwnedCopy :one
+WITH deleted AS (
+    DELETE FROM owned_copies
+    WHERE id = $1
+    AND user_id = $2
+    RETURNING *
+)
+SELECT count(*) FROM deleted;
+
+-- name: GetCollectionValueByUserID :many
+-- User's copies counted and their prices summed, by media type and currency
+SELECT
+    media.media_type,
+    owned_copies.currency,
+    count(*) AS copies_count,
+    count(owned_copies.price_cents) AS priced_count,
+    COALESCE(sum(owned_copies.price_cents), 0)::bigint AS total_cents
+FROM owned_copies
+INNER JOIN media
+ON owned_copies.media_id = media.id
+WHERE owned_copies.user_id = $1
+GROUP BY media.media_type, owned_copies.currency
+ORDER BY media.media_type, owned_copies.currency;
+
+-- name: RepointOwnedCopiesToMedium :exec
+-- Copies of a merged medium go to the kept one
+UPDATE owned_copies
+SET media_id = sqlc.arg(new_media_id), updated_at = NOW()
+WHERE media_id = sqlc.arg(old_media_id);
@@ -0,0 +1,35 @@
+-- name: CreateRecordPause :one
+INSERT INTO records_pauses (id, record_id, paused_at)
+VALUES (
+    gen_random_uuid(),
+    $1,
+    $2
+)
+RETURNING *;
+
+-- name: GetRecordPauses :many
+-- Times a record was put on hold, oldest first
+SELECT * FROM records_pauses
+WHERE record_id = $1
+ORDER BY paused_at, id;
+
+-- name: ResumeRecordPause :exec
+-- Close record's open pause, if any
+UPDATE records_pauses
+SET resumed_at = sqlc.arg(resumed_at)
+WHERE record_id = sqlc.arg(record_id)
+AND resumed_at IS NULL;
+
+-- name: RepointPausesToRecord :exec
+-- Pauses of a record merged into another one follow it, except an open pause if the kept record has one already
+UPDATE records_pauses
+SET record_id = sqlc.arg(new_record_id)
+WHERE record_id = sqlc.arg(old_record_id)
+AND (
+    resumed_at IS NOT NULL
+    OR NOT EXISTS (
+        SELECT 1 FROM records_pauses AS existing
+        WHERE existing.record_id = sqlc.arg(new_record_id)
+        AND existing.resumed_at IS NULL
+    )
+);
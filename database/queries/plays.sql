@@ -0,0 +1,84 @@
+-- name: CreatePlay :one
+INSERT INTO plays (id, created_at, updated_at, user_id, media_id, played_at, duration_minutes, location, expansions)
+VALUES (
+    gen_random_uuid(),
+    NOW(),
+    NOW(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6
+)
+RETURNING *;
+
+-- name: GetUserPlayByID :one
+SELECT * FROM plays
+WHERE id = $1
+AND user_id = $2;
+
+-- name: GetPlaysByUserID :many
+-- Every play logged by user, last one first
+SELECT
+    plays.id,
+    plays.created_at,
+    plays.updated_at,
+    plays.user_id,
+    plays.media_id,
+    media.title,
+    plays.played_at,
+    plays.duration_minutes,
+    plays.location,
+    plays.expansions
+FROM plays
+INNER JOIN media
+ON plays.media_id = media.id
+WHERE plays.user_id = $1
+ORDER BY plays.played_at DESC, plays.id;
+
+-- name: UpdatePlay :one
+UPDATE plays
+SET played_at = $3, duration_minutes = $4, location = $5, expansions = $6, updated_at = NOW()
+WHERE id = $1
+AND user_id = $2
+RETURNING *;
+
+-- name: DeletePlay :one
+WITH deleted AS (
+    DELETE FROM plays
+    WHERE id = $1
+    AND user_id = $2
+    RETURNING *
+)
+SELECT count(*) FROM deleted;
+
+-- name: CreatePlayPlayer :one
+INSERT INTO plays_players (play_id, seat, player_id, player_name, score, is_winner)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6
+)
+RETURNING *;
+
+-- name: DeletePlayPlayers :exec
+DELETE FROM plays_players
+WHERE play_id = $1;
+
+-- name: GetPlayersByUserID :many
+-- Players of every play logged by user, in seat order
+SELECT plays_players.* FROM plays_players
+INNER JOIN plays
+ON plays_players.play_id = plays.id
+WHERE plays.user_id = $1
+ORDER BY plays_players.play_id, plays_players.seat;
+
+-- name: RepointPlaysToMedium :exec
+-- Plays of a merged medium go to the kept one
+UPDATE plays
+SET media_id = sqlc.arg(new_media_id), updated_at = NOW()
+WHERE media_id = sqlc.arg(old_media_id);
@@ -0,0 +1,23 @@
+-- name: CreateRecordProgress :one
+INSERT INTO records_progress (id, logged_at, record_id, pages, percent, total)
+VALUES (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5
+)
+RETURNING *;
+
+-- name: GetRecordProgress :many
+-- Progress log of a record, oldest update first
+SELECT * FROM records_progress
+WHERE record_id = $1
+ORDER BY logged_at, id;
+
+-- name: RepointProgressToRecord :exec
+-- Progress of a record merged into another one follows it
+UPDATE records_progress
+SET record_id = sqlc.arg(new_record_id)
+WHERE record_id = sqlc.arg(old_record_id);
@@ -1,5 +1,5 @@
 -- name: CreateUserMediumRecord :one
-INSERT INTO users_media_records (id, created_at, updated_at, user_id, media_id, is_finished, start_date, end_date, duration, comments)
+INSERT INTO users_media_records (id, created_at, updated_at, user_id, media_id, is_finished, start_date, end_date, duration, comments, rating, status, abandon_reason)
 VALUES (
     gen_random_uuid(),
     NOW(),
@@ -10,7 +10,10 @@ VALUES (
     $4,
     $5,
     $6,
-    $7
+    $7,
+    $8,
+    $9,
+    $10
 )
 RETURNING *;
 
@@ -21,6 +24,8 @@ WHERE user_id = $1;
 -- name: GetRecordsAndMediaByUserID :many
 SELECT
     records.id, 
+    records.created_at,
+    records.updated_at,
     records.user_id, 
     records.media_id, 
     records.is_finished, 
@@ -28,28 +33,61 @@ SELECT
     records.end_date, 
     records.duration, 
     records.comments,
+    records.rating,
+    records.status,
+    records.abandon_reason,
     media.media_type,
     media.title,
     media.creator,
     media.pub_date,
     media.image_url,
-    media.metadata
+    media.metadata,
+    COALESCE(ratings.average, 0)::float8 AS rating_average,
+    COALESCE(ratings.count, 0)::bigint AS rating_count
 FROM users_media_records AS records
 INNER JOIN media
 ON records.media_id = media.id
-WHERE records.user_id = $1;
+LEFT JOIN (
+    SELECT users_ratings.media_id, avg(users_ratings.rating) AS average, count(*) AS count
+    FROM (
+        SELECT media_id, user_id, avg(rating) AS rating
+        FROM users_media_records
+        WHERE rating IS NOT NULL
+        GROUP BY media_id, user_id
+    ) AS users_ratings
+    GROUP BY users_ratings.media_id
+) AS ratings
+ON ratings.media_id = media.id
+WHERE records.user_id = $1
+ORDER BY media.title, records.media_id, records.start_date NULLS LAST, records.created_at, records.id;
 
 -- name: GetRecordByID :one
 SELECT * FROM users_media_records
 WHERE id = $1;
 
+-- name: GetUserRecordByID :one
+SELECT * FROM users_media_records
+WHERE id = $1
+AND user_id = $2;
+
 -- name: UpdateRecord :one
 UPDATE users_media_records
-SET is_finished = $2, start_date = $3, end_date = $4, duration = $5, comments = $6, updated_at = NOW()
+SET is_finished = $2, start_date = $3, end_date = $4, duration = $5, comments = $6, rating = $8, status = $9, abandon_reason = $10, updated_at = NOW()
 WHERE id = $1
+AND user_id = $7
 RETURNING *;
 
 -- name: DeleteRecord :one
+WITH deleted AS (
+    DELETE FROM users_media_records
+    WHERE id = $1
+    AND user_id = $2
+    RETURNING *
+)
+SELECT count(*) FROM deleted;
+
+-- name: DeleteUserMediumRecords :one
+-- Remove a medium from user's shelf, with every consumption of it
 WITH deleted AS (
     DELETE FROM users_media_records
     WHERE media_id = $1
@@ -58,10 +96,41 @@ WITH deleted AS (
 )
 SELECT count(*) FROM deleted;
 
--- name: GetDatesFromRecord :one
-SELECT start_date, end_date
-FROM users_media_records
-WHERE id = $1;
+-- name: GetRecordsByMediumID :many
+SELECT * FROM users_media_records
+WHERE media_id = $1;
+
+-- name: RepointRecordsToMedium :one
+WITH moved AS (
+    UPDATE users_media_records
+    SET media_id = sqlc.arg(new_media_id), updated_at = NOW()
+    WHERE media_id = sqlc.arg(old_media_id)
+    RETURNING *
+)
+SELECT count(*) FROM moved;
+
+-- name: CountUserRecordsByMediumID :one
+SELECT count(*) FROM users_media_records
+WHERE media_id = sqlc.arg(media_id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: CountOtherUsersRecordsByMediumID :one
+SELECT count(*) FROM users_media_records
+WHERE media_id = sqlc.arg(media_id)
+AND user_id <> sqlc.arg(user_id);
+
+-- name: GetMediumRating :one
+-- Average rating of a medium, each user who rated it counting once (with the average of their ratings)
+SELECT
+    COALESCE(avg(users_ratings.rating), 0)::float8 AS average,
+    count(*) AS count
+FROM (
+    SELECT avg(rating) AS rating
+    FROM users_media_records
+    WHERE media_id = $1
+    AND rating IS NOT NULL
+    GROUP BY user_id
+) AS users_ratings;
 
 -- name: ResetRecords :exec
 DELETE FROM users_media_records;
\ No newline at end of file
@@ -0,0 +1,83 @@
+-- name: CreateReview :one
+INSERT INTO reviews (id, created_at, updated_at, record_id, title, body, is_published, published_at)
+VALUES (
+    gen_random_uuid(),
+    NOW(),
+    NOW(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5
+)
+RETURNING *;
+
+-- name: GetReviewByRecordID :one
+SELECT * FROM reviews
+WHERE record_id = $1;
+
+-- name: UpdateReview :one
+UPDATE reviews
+SET title = $2, body = $3, is_published = $4, published_at = $5, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteReview :one
+WITH deleted AS (
+    DELETE FROM reviews
+    WHERE record_id = $1
+    RETURNING *
+)
+SELECT count(*) FROM deleted;
+
+-- name: CreateReviewRevision :one
+-- Save a version of a review, numbered after the latest one
+INSERT INTO reviews_revisions (id, review_id, revision, created_at, title, body)
+SELECT
+    gen_random_uuid(),
+    sqlc.arg(review_id),
+    COALESCE(max(revision), 0) + 1,
+    NOW(),
+    sqlc.arg(title),
+    sqlc.arg(body)
+FROM reviews_revisions
+WHERE review_id = sqlc.arg(review_id)
+RETURNING *;
+
+-- name: GetReviewRevisions :many
+-- Versions of a review, oldest first
+SELECT * FROM reviews_revisions
+WHERE review_id = $1
+ORDER BY revision;
+
+-- name: GetPublishedMediumReviews :many
+-- Every user's published review of a medium, latest published first
+SELECT
+    reviews.id,
+    reviews.created_at,
+    reviews.updated_at,
+    reviews.record_id,
+    reviews.title,
+    reviews.body,
+    reviews.is_published,
+    reviews.published_at,
+    users.id AS user_id,
+    users.username
+FROM reviews
+INNER JOIN users_media_records AS records
+ON reviews.record_id = records.id
+INNER JOIN users
+ON records.user_id = users.id
+WHERE records.media_id = $1
+AND reviews.is_published
+ORDER BY reviews.published_at DESC, reviews.id;
+
+-- name: RepointReviewToRecord :exec
+-- Review of a record merged into another one follows it, unless the kept record already has one
+UPDATE reviews
+SET record_id = sqlc.arg(new_record_id)
+WHERE record_id = sqlc.arg(old_record_id)
+AND NOT EXISTS (
+    SELECT 1 FROM reviews AS existing
+    WHERE existing.record_id = sqlc.arg(new_record_id)
+);
@@ -0,0 +1,89 @@
+-- name: CreateShare :one
+INSERT INTO shares (id, created_at, owner_id, recipient_id, record_id, media_type)
+VALUES (
+    gen_random_uuid(),
+    NOW(),
+    $1,
+    $2,
+    $3,
+    $4
+)
+RETURNING *;
+
+-- name: GetShareByID :one
+SELECT * FROM shares
+WHERE id = $1;
+
+-- name: GetSharesByUserID :many
+SELECT
+    shares.id,
+    shares.created_at,
+    shares.owner_id,
+    owners.username AS owner_username,
+    shares.recipient_id,
+    recipients.username AS recipient_username,
+    shares.record_id,
+    COALESCE(shares.media_type, media.media_type)::text AS media_type,
+    COALESCE(media.title, '')::text AS title
+FROM shares
+INNER JOIN users AS owners
+ON shares.owner_id = owners.id
+INNER JOIN users AS recipients
+ON shares.recipient_id = recipients.id
+LEFT JOIN users_media_records AS records
+ON shares.record_id = records.id
+LEFT JOIN media
+ON records.media_id = media.id
+WHERE shares.owner_id = $1
+OR shares.recipient_id = $1
+ORDER BY shares.created_at DESC, shares.id;
+
+-- name: GetSharedMediaRecords :many
+SELECT
+    records.id,
+    records.user_id,
+    records.media_id,
+    records.is_finished,
+    records.start_date,
+    records.end_date,
+    records.duration,
+    records.comments,
+    records.rating,
+    records.status,
+    media.media_type,
+    media.title,
+    media.creator,
+    media.pub_date,
+    media.image_url,
+    media.metadata
+FROM shares
+INNER JOIN users_media_records AS records
+ON records.id = shares.record_id
+OR (shares.record_id IS NULL AND records.user_id = shares.owner_id)
+INNER JOIN media
+ON records.media_id = media.id
+WHERE shares.id = sqlc.arg(share_id)
+AND shares.recipient_id = sqlc.arg(recipient_id)
+AND (shares.media_type IS NULL OR media.media_type = shares.media_type)
+ORDER BY media.title, records.id;
+
+-- name: DeleteShare :one
+WITH deleted AS (
+    DELETE FROM shares
+    WHERE id = sqlc.arg(id)
+    AND (owner_id = sqlc.arg(user_id) OR recipient_id = sqlc.arg(user_id))
+    RETURNING *
+)
+SELECT count(*) FROM deleted;
+
+-- name: RepointSharesToRecord :exec
+-- Shares of a record merged into another one follow it, unless the same share already exists for the kept record
+UPDATE shares
+SET record_id = sqlc.arg(new_record_id)
+WHERE record_id = sqlc.arg(old_record_id)
+AND NOT EXISTS (
+    SELECT 1 FROM shares AS existing
+    WHERE existing.owner_id = shares.owner_id
+    AND existing.recipient_id = shares.recipient_id
+    AND existing.record_id = sqlc.arg(new_record_id)
+);
\ No newline at end of file
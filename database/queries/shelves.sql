@@ -0,0 +1,88 @@
+-- name: CreateShelf :one
+INSERT INTO shelves (id, created_at, updated_at, user_id, name, description)
+VALUES (
+    gen_random_uuid(),
+    NOW(),
+    NOW(),
+    $1,
+    $2,
+    $3
+)
+RETURNING *;
+
+-- name: GetShelvesByUserID :many
+-- User's custom shelves by name, with how many media each one holds
+SELECT
+    shelves.id,
+    shelves.created_at,
+    shelves.updated_at,
+    shelves.user_id,
+    shelves.name,
+    shelves.description,
+    count(shelves_media.media_id) AS media_count
+FROM shelves
+LEFT JOIN shelves_media
+ON shelves_media.shelf_id = shelves.id
+WHERE shelves.user_id = $1
+GROUP BY shelves.id
+ORDER BY lower(shelves.name), shelves.id;
+
+-- name: GetUserShelfByID :one
+SELECT * FROM shelves
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: UpdateShelf :one
+UPDATE shelves
+SET name = sqlc.arg(name), description = sqlc.arg(description), updated_at = NOW()
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id)
+RETURNING *;
+
+-- name: DeleteShelf :one
+WITH deleted AS (
+    DELETE FROM shelves
+    WHERE id = sqlc.arg(id)
+    AND user_id = sqlc.arg(user_id)
+    RETURNING *
+)
+SELECT count(*) FROM deleted;
+
+-- name: AddMediaToShelf :one
+-- Put several media on a shelf at once, media already on it are skipped
+WITH inserted AS (
+    INSERT INTO shelves_media (shelf_id, media_id, added_at)
+    SELECT sqlc.arg(shelf_id), unnest(sqlc.arg(media_ids)::uuid[]), NOW()
+    ON CONFLICT DO NOTHING
+    RETURNING *
+)
+SELECT count(*) FROM inserted;
+
+-- name: RemoveMediaFromShelf :one
+WITH deleted AS (
+    DELETE FROM shelves_media
+    WHERE shelf_id = sqlc.arg(shelf_id)
+    AND media_id = ANY(sqlc.arg(media_ids)::uuid[])
+    RETURNING *
+)
+SELECT count(*) FROM deleted;
+
+-- name: GetUserShelvesMedia :many
+-- Every medium user put on their custom shelves, in the order they were added
+SELECT shelves_media.shelf_id, shelves_media.media_id
+FROM shelves_media
+INNER JOIN shelves
+ON shelves_media.shelf_id = shelves.id
+WHERE shelves.user_id = $1
+ORDER BY shelves_media.added_at, shelves_media.media_id;
+
+-- name: RepointShelvesMediaToMedium :exec
+-- A medium merged into another one leaves its place on shelves to it, unless the kept medium is already there
+UPDATE shelves_media
+SET media_id = sqlc.arg(new_media_id)
+WHERE media_id = sqlc.arg(old_media_id)
+AND NOT EXISTS (
+    SELECT 1 FROM shelves_media AS existing
+    WHERE existing.shelf_id = shelves_media.shelf_id
+    AND existing.media_id = sqlc.arg(new_media_id)
+);
@@ -0,0 +1,162 @@
+-- name: CreateShelvingUnit :one
+INSERT INTO shelving_units (id, created_at, updated_at, user_id, name, rows_count, columns_count, cell_width_cm, cell_height_cm, cell_depth_cm)
+VALUES (
+    gen_random_uuid(),
+    NOW(),
+    NOW(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7
+)
+RETURNING *;
+
+-- name: GetShelvingUnitsByUserID :many
+-- User's shelving units by name, with how many media each one holds
+SELECT
+    shelving_units.id,
+    shelving_units.created_at,
+    shelving_units.updated_at,
+    shelving_units.user_id,
+    shelving_units.name,
+    shelving_units.rows_count,
+    shelving_units.columns_count,
+    shelving_units.cell_width_cm,
+    shelving_units.cell_height_cm,
+    shelving_units.cell_depth_cm,
+    count(shelving_items.media_id) AS items_count
+FROM shelving_units
+LEFT JOIN shelving_items
+ON shelving_items.unit_id = shelving_units.id
+WHERE shelving_units.user_id = $1
+GROUP BY shelving_units.id
+ORDER BY lower(shelving_units.name), shelving_units.id;
+
+-- name: GetUserShelvingUnitByID :one
+SELECT * FROM shelving_units
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: UpdateShelvingUnit :one
+UPDATE shelving_units
+SET
+    name = sqlc.arg(name),
+    rows_count = sqlc.arg(rows_count),
+    columns_count = sqlc.arg(columns_count),
+    cell_width_cm = sqlc.arg(cell_width_cm),
+    cell_height_cm = sqlc.arg(cell_height_cm),
+    cell_depth_cm = sqlc.arg(cell_depth_cm),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id)
+RETURNING *;
+
+-- name: DeleteShelvingUnit :one
+WITH deleted AS (
+    DELETE FROM shelving_units
+    WHERE id = sqlc.arg(id)
+    AND user_id = sqlc.arg(user_id)
+    RETURNING *
+)
+SELECT count(*) FROM deleted;
+
+-- name: CountShelvingItemsOutsideGrid :one
+-- Items a unit resized to the given grid would leave out
+SELECT count(*) FROM shelving_items
+WHERE unit_id = sqlc.arg(unit_id)
+AND (cell_row > sqlc.arg(rows_count)::integer OR cell_column > sqlc.arg(columns_count)::integer);
+
+-- name: PlaceShelvingItem :one
+-- Put a medium in a cell, moving it from where it was
+INSERT INTO shelving_items (user_id, media_id, unit_id, cell_row, cell_column, placed_at)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    NOW()
+)
+ON CONFLICT (user_id, media_id) DO UPDATE
+SET unit_id = excluded.unit_id, cell_row = excluded.cell_row, cell_column = excluded.cell_column, placed_at = excluded.placed_at
+RETURNING *;
+
+-- name: GetShelvingUnitItems :many
+-- Media in a unit, cell by cell, in the order they were put in
+SELECT
+    shelving_items.media_id,
+    media.media_type,
+    media.title,
+    media.image_url,
+    shelving_items.cell_row,
+    shelving_items.cell_column,
+    shelving_items.placed_at
+FROM shelving_items
+INNER JOIN media
+ON shelving_items.media_id = media.id
+WHERE shelving_items.unit_id = $1
+ORDER BY shelving_items.cell_row, shelving_items.cell_column, shelving_items.placed_at, shelving_items.media_id;
+
+-- name: GetUserMediumShelvingItem :one
+-- Where user keeps a medium
+SELECT
+    shelving_items.media_id,
+    media.media_type,
+    media.title,
+    media.image_url,
+    shelving_items.unit_id,
+    shelving_units.name AS unit_name,
+    shelving_items.cell_row,
+    shelving_items.cell_column,
+    shelving_items.placed_at
+FROM shelving_items
+INNER JOIN media
+ON shelving_items.media_id = media.id
+INNER JOIN shelving_units
+ON shelving_items.unit_id = shelving_units.id
+WHERE shelving_items.user_id = sqlc.arg(user_id)
+AND shelving_items.media_id = sqlc.arg(media_id);
+
+-- name: FindShelvingItemsByTitle :many
+-- Where user keeps the media whose title contains the given text, case insensitive
+SELECT
+    shelving_items.media_id,
+    media.media_type,
+    media.title,
+    media.image_url,
+    shelving_items.unit_id,
+    shelving_units.name AS unit_name,
+    shelving_items.cell_row,
+    shelving_items.cell_column,
+    shelving_items.placed_at
+FROM shelving_items
+INNER JOIN media
+ON shelving_items.media_id = media.id
+INNER JOIN shelving_units
+ON shelving_items.unit_id = shelving_units.id
+WHERE shelving_items.user_id = sqlc.arg(user_id)
+AND strpos(lower(media.title), lower(sqlc.arg(title)::text)) > 0
+ORDER BY lower(media.title), shelving_items.media_id;
+
+-- name: RemoveShelvingItem :one
+WITH deleted AS (
+    DELETE FROM shelving_items
+    WHERE user_id = sqlc.arg(user_id)
+    AND media_id = sqlc.arg(media_id)
+    RETURNING *
+)
+SELECT count(*) FROM deleted;
+
+-- name: RepointShelvingItemsToMedium :exec
+-- A medium merged into another one leaves its cell to it, unless its user keeps the kept medium somewhere already
+UPDATE shelving_items
+SET media_id = sqlc.arg(new_media_id)
+WHERE media_id = sqlc.arg(old_media_id)
+AND NOT EXISTS (
+    SELECT 1 FROM shelving_items AS existing
+    WHERE existing.user_id = shelving_items.user_id
+    AND existing.media_id = sqlc.arg(new_media_id)
+);
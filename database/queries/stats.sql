@@ -0,0 +1,156 @@
+-- name: GetStatsByMediaType :many
+SELECT
+    media.media_type,
+    count(*) FILTER (
+        WHERE records.start_date >= sqlc.arg(window_start)::timestamp AND records.start_date < sqlc.arg(window_end)::timestamp
+    ) AS started,
+    count(*) FILTER (
+        WHERE records.is_finished AND records.end_date >= sqlc.arg(window_start)::timestamp AND records.end_date < sqlc.arg(window_end)::timestamp
+    ) AS finished,
+    COALESCE(avg(EXTRACT(DAY FROM records.duration)) FILTER (
+        WHERE records.is_finished AND records.end_date >= sqlc.arg(window_start)::timestamp AND records.end_date < sqlc.arg(window_end)::timestamp
+    ), 0)::float8 AS avg_duration,
+    COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(DAY FROM records.duration)) FILTER (
+        WHERE records.is_finished AND records.end_date >= sqlc.arg(window_start)::timestamp AND records.end_date < sqlc.arg(window_end)::timestamp
+    ), 0)::float8 AS median_duration
+FROM users_media_records AS records
+INNER JOIN media
+ON records.media_id = media.id
+WHERE records.user_id = sqlc.arg(user_id)
+AND (
+    (records.start_date >= sqlc.arg(window_start)::timestamp AND records.start_date < sqlc.arg(window_end)::timestamp)
+    OR (records.end_date >= sqlc.arg(window_start)::timestamp AND records.end_date < sqlc.arg(window_end)::timestamp)
+)
+GROUP BY media.media_type
+ORDER BY media.media_type;
+
+-- name: GetStatsTotals :one
+SELECT
+    count(*) FILTER (
+        WHERE records.start_date >= sqlc.arg(window_start)::timestamp AND records.start_date < sqlc.arg(window_end)::timestamp
+    ) AS started,
+    count(*) FILTER (
+        WHERE records.is_finished AND records.end_date >= sqlc.arg(window_start)::timestamp AND records.end_date < sqlc.arg(window_end)::timestamp
+    ) AS finished,
+    COALESCE(avg(EXTRACT(DAY FROM records.duration)) FILTER (
+        WHERE records.is_finished AND records.end_date >= sqlc.arg(window_start)::timestamp AND records.end_date < sqlc.arg(window_end)::timestamp
+    ), 0)::float8 AS avg_duration,
+    COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(DAY FROM records.duration)) FILTER (
+        WHERE records.is_finished AND records.end_date >= sqlc.arg(window_start)::timestamp AND records.end_date < sqlc.arg(window_end)::timestamp
+    ), 0)::float8 AS median_duration
+FROM users_media_records AS records
+WHERE records.user_id = sqlc.arg(user_id);
+
+-- name: GetLongestCompletions :many
+SELECT
+    records.id,
+    records.media_id,
+    media.media_type,
+    media.title,
+    media.creator,
+    records.start_date,
+    records.end_date,
+    records.duration
+FROM users_media_records AS records
+INNER JOIN media
+ON records.media_id = media.id
+WHERE records.user_id = sqlc.arg(user_id)
+AND records.is_finished
+AND records.duration IS NOT NULL
+AND records.end_date >= sqlc.arg(window_start)::timestamp
+AND records.end_date < sqlc.arg(window_end)::timestamp
+ORDER BY records.duration DESC, records.end_date DESC, records.id
+LIMIT sqlc.arg(top);
+
+-- name: GetShortestCompletions :many
+SELECT
+    records.id,
+    records.media_id,
+    media.media_type,
+    media.title,
+    media.creator,
+    records.start_date,
+    records.end_date,
+    records.duration
+FROM users_media_records AS records
+INNER JOIN media
+ON records.media_id = media.id
+WHERE records.user_id = sqlc.arg(user_id)
+AND records.is_finished
+AND records.duration IS NOT NULL
+AND records.end_date >= sqlc.arg(window_start)::timestamp
+AND records.end_date < sqlc.arg(window_end)::timestamp
+ORDER BY records.duration ASC, records.end_date DESC, records.id
+LIMIT sqlc.arg(top);
+
+-- name: GetTopCreators :many
+SELECT
+    media.creator,
+    count(*) AS count
+FROM users_media_records AS records
+INNER JOIN media
+ON records.media_id = media.id
+WHERE records.user_id = sqlc.arg(user_id)
+AND records.is_finished
+AND records.end_date >= sqlc.arg(window_start)::timestamp
+AND records.end_date < sqlc.arg(window_end)::timestamp
+AND media.creator <> ''
+GROUP BY media.creator
+ORDER BY count DESC, media.creator
+LIMIT sqlc.arg(top);
+
+-- name: GetTopGenres :many
+-- Genres are stored under "genres" (movies, series), "subjects" (books) or "categories" (boardgames)
+SELECT
+    genres.genre::text AS genre,
+    count(DISTINCT records.id) AS count
+FROM users_media_records AS records
+INNER JOIN media
+ON records.media_id = media.id
+CROSS JOIN LATERAL (
+    SELECT jsonb_array_elements_text(media.metadata -> genre_key) AS genre
+    FROM unnest(ARRAY['genres', 'subjects', 'categories']) AS genre_key
+    WHERE jsonb_typeof(media.metadata -> genre_key) = 'array'
+) AS genres
+WHERE records.user_id = sqlc.arg(user_id)
+AND records.is_finished
+AND records.end_date >= sqlc.arg(window_start)::timestamp
+AND records.end_date < sqlc.arg(window_end)::timestamp
+GROUP BY genres.genre
+ORDER BY count DESC, genres.genre
+LIMIT sqlc.arg(top);
+
+-- name: GetStatsSeries :many
+-- Bucket is one of 'day', 'week' (starting on monday) or 'month'
+WITH buckets AS (
+    SELECT generate_series(
+        date_trunc(sqlc.arg(bucket)::text, sqlc.arg(window_start)::timestamp),
+        sqlc.arg(window_end)::timestamp - interval '1 microsecond',
+        ('1 ' || sqlc.arg(bucket)::text)::interval
+    )::timestamp AS bucket_start
+),
+started AS (
+    SELECT date_trunc(sqlc.arg(bucket)::text, records.start_date) AS bucket_start, count(*) AS count
+    FROM users_media_records AS records
+    WHERE records.user_id = sqlc.arg(user_id)
+    AND records.start_date >= sqlc.arg(window_start)::timestamp
+    AND records.start_date < sqlc.arg(window_end)::timestamp
+    GROUP BY 1
+),
+finished AS (
+    SELECT date_trunc(sqlc.arg(bucket)::text, records.end_date) AS bucket_start, count(*) AS count
+    FROM users_media_records AS records
+    WHERE records.user_id = sqlc.arg(user_id)
+    AND records.is_finished
+    AND records.end_date >= sqlc.arg(window_start)::timestamp
+    AND records.end_date < sqlc.arg(window_end)::timestamp
+    GROUP BY 1
+)
+SELECT
+    buckets.bucket_start,
+    COALESCE(started.count, 0)::bigint AS started,
+    COALESCE(finished.count, 0)::bigint AS finished
+FROM buckets
+LEFT JOIN started ON started.bucket_start = buckets.bucket_start
+LEFT JOIN finished ON finished.bucket_start = buckets.bucket_start
+ORDER BY buckets.bucket_start;
@@ -0,0 +1,89 @@
+-- name: CreateTag :one
+INSERT INTO tags (id, created_at, updated_at, user_id, name)
+VALUES (
+    gen_random_uuid(),
+    NOW(),
+    NOW(),
+    $1,
+    $2
+)
+RETURNING *;
+
+-- name: GetTagsByUserID :many
+-- User's tags by name, with how many media each one holds
+SELECT
+    tags.id,
+    tags.created_at,
+    tags.updated_at,
+    tags.user_id,
+    tags.name,
+    count(media_tags.media_id) AS media_count
+FROM tags
+LEFT JOIN media_tags
+ON media_tags.tag_id = tags.id
+WHERE tags.user_id = $1
+GROUP BY tags.id
+ORDER BY lower(tags.name), tags.id;
+
+-- name: GetUserTagByID :one
+SELECT * FROM tags
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: UpdateTag :one
+UPDATE tags
+SET name = sqlc.arg(name), updated_at = NOW()
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id)
+RETURNING *;
+
+-- name: DeleteTag :one
+WITH deleted AS (
+    DELETE FROM tags
+    WHERE id = sqlc.arg(id)
+    AND user_id = sqlc.arg(user_id)
+    RETURNING *
+)
+SELECT count(*) FROM deleted;
+
+-- name: TagMedia :one
+-- Tag several media at once, media already holding the tag are skipped
+WITH inserted AS (
+    INSERT INTO media_tags (tag_id, media_id, created_at)
+    SELECT sqlc.arg(tag_id), unnest(sqlc.arg(media_ids)::uuid[]), NOW()
+    ON CONFLICT DO NOTHING
+    RETURNING *
+)
+SELECT count(*) FROM inserted;
+
+-- name: UntagMedia :one
+WITH deleted AS (
+    DELETE FROM media_tags
+    WHERE tag_id = sqlc.arg(tag_id)
+    AND media_id = ANY(sqlc.arg(media_ids)::uuid[])
+    RETURNING *
+)
+SELECT count(*) FROM deleted;
+
+-- name: GetUserMediaTags :many
+-- Every tag user put on their media, by name
+SELECT
+    media_tags.media_id,
+    tags.id AS tag_id,
+    tags.name
+FROM media_tags
+INNER JOIN tags
+ON media_tags.tag_id = tags.id
+WHERE tags.user_id = $1
+ORDER BY lower(tags.name), media_tags.media_id;
+
+-- name: RepointMediaTagsToMedium :exec
+-- Tags of a medium merged into another one follow it, unless the kept medium already holds them
+UPDATE media_tags
+SET media_id = sqlc.arg(new_media_id)
+WHERE media_id = sqlc.arg(old_media_id)
+AND NOT EXISTS (
+    SELECT 1 FROM media_tags AS existing
+    WHERE existing.tag_id = media_tags.tag_id
+    AND existing.media_id = sqlc.arg(new_media_id)
+);
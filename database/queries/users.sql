@@ -34,6 +34,27 @@ SET hashed_password = $2, updated_at = NOW()
 WHERE id = $1
 RETURNING *;
 
+-- name: SearchUsers :many
+-- An empty search matches every user
+SELECT * FROM users
+WHERE sqlc.arg(search)::text = ''
+OR username ILIKE '%' || sqlc.arg(search)::text || '%'
+OR email ILIKE '%' || sqlc.arg(search)::text || '%'
+ORDER BY username
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: UpdateUserRoleByUsername :one
+UPDATE users
+SET role = $2, updated_at = NOW()
+WHERE username = $1
+RETURNING *;
+
+-- name: UpdateUserDeactivatedAt :one
+UPDATE users
+SET deactivated_at = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
 -- name: DeleteUser :one
 WITH deleted AS (
     DELETE FROM users
@@ -0,0 +1,57 @@
+-- name: UpsertRecordVideogame :one
+-- Every detail is replaced
+INSERT INTO records_videogames (record_id, updated_at, platform, hours_played, completion, achievements_earned, achievements_total)
+VALUES (
+    $1,
+    NOW(),
+    $2,
+    $3,
+    $4,
+    $5,
+    $6
+)
+ON CONFLICT (record_id) DO UPDATE
+SET updated_at = NOW(),
+    platform = EXCLUDED.platform,
+    hours_played = EXCLUDED.hours_played,
+    completion = EXCLUDED.completion,
+    achievements_earned = EXCLUDED.achievements_earned,
+    achievements_total = EXCLUDED.achievements_total
+RETURNING *;
+
+-- name: GetRecordVideogame :one
+SELECT * FROM records_videogames
+WHERE record_id = $1;
+
+-- name: GetVideogameRecordsByUserID :many
+-- Every videogame record of user, with how it was played if known
+SELECT
+    records.id,
+    records.media_id,
+    records.status,
+    media.title,
+    media.image_url,
+    media.metadata,
+    records_videogames.platform,
+    records_videogames.hours_played,
+    records_videogames.completion,
+    records_videogames.achievements_earned,
+    records_videogames.achievements_total
+FROM users_media_records AS records
+INNER JOIN media
+ON records.media_id = media.id
+LEFT JOIN records_videogames
+ON records_videogames.record_id = records.id
+WHERE records.user_id = $1
+AND media.media_type = 'videogame'
+ORDER BY media.title, records.id;
+
+-- name: RepointVideogameToRecord :exec
+-- Videogame details of a record merged into another one follow it, unless the kept record already has some
+UPDATE records_videogames
+SET record_id = sqlc.arg(new_record_id)
+WHERE record_id = sqlc.arg(old_record_id)
+AND NOT EXISTS (
+    SELECT 1 FROM records_videogames AS existing
+    WHERE existing.record_id = sqlc.arg(new_record_id)
+);
@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE shares (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL,
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    recipient_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    record_id UUID REFERENCES users_media_records(id) ON DELETE CASCADE,
+    media_type TEXT,
+    CHECK (owner_id <> recipient_id),
+    CHECK ((record_id IS NULL) <> (media_type IS NULL))
+);
+
+-- A record or a compartment is shared only once with the same user
+CREATE UNIQUE INDEX shares_record_key ON shares (owner_id, recipient_id, record_id) WHERE record_id IS NOT NULL;
+CREATE UNIQUE INDEX shares_media_type_key ON shares (owner_id, recipient_id, media_type) WHERE media_type IS NOT NULL;
+
+-- +goose Down
+DROP TABLE shares;
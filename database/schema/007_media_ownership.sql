@@ -0,0 +1,14 @@
+-- +goose Up
+ALTER TABLE media ADD COLUMN created_by UUID REFERENCES users(id) ON DELETE SET NULL;
+
+-- Existing media are given to the first user who recorded them
+UPDATE media
+SET created_by = (
+    SELECT user_id FROM users_media_records
+    WHERE media_id = media.id
+    ORDER BY created_at, id
+    LIMIT 1
+);
+
+-- +goose Down
+ALTER TABLE media DROP COLUMN created_by;
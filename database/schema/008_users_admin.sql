@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE users ADD COLUMN role TEXT NOT NULL DEFAULT 'user' CHECK (role IN ('user', 'admin'));
+
+ALTER TABLE users ADD COLUMN deactivated_at TIMESTAMP;
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN deactivated_at;
+
+ALTER TABLE users DROP COLUMN role;
@@ -0,0 +1,13 @@
+-- +goose Up
+-- IDs of media merged into another one, so old IDs still resolve to the medium they were merged into
+CREATE TABLE media_redirects (
+    old_id UUID PRIMARY KEY,
+    media_id UUID NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL,
+    merged_by UUID REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX media_redirects_media_id_idx ON media_redirects (media_id);
+
+-- +goose Down
+DROP TABLE media_redirects;
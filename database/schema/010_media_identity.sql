@@ -0,0 +1,99 @@
+-- +goose Up
+-- Media sharing a title (remakes, homonymous books...) are told apart by creator and publication year, or by external IDs
+ALTER TABLE media DROP CONSTRAINT media_media_type_title_key;
+
+-- IDs of the medium on the providers its info can come from, by provider (e.g. {"tmdb_movie": "438631"})
+ALTER TABLE media ADD COLUMN external_ids JSONB NOT NULL DEFAULT '{}'
+CHECK (
+    jsonb_typeof(external_ids) = 'object'
+    AND external_ids - ARRAY['openlibrary_work', 'tmdb_movie', 'tmdb_tv', 'rawg', 'bgg', 'isbn13'] = '{}'::jsonb
+);
+
+-- One medium per provider's ID
+CREATE UNIQUE INDEX media_external_ids_openlibrary_work_key ON media ((external_ids->>'openlibrary_work')) WHERE external_ids ? 'openlibrary_work';
+CREATE UNIQUE INDEX media_external_ids_tmdb_movie_key ON media ((external_ids->>'tmdb_movie')) WHERE external_ids ? 'tmdb_movie';
+CREATE UNIQUE INDEX media_external_ids_tmdb_tv_key ON media ((external_ids->>'tmdb_tv')) WHERE external_ids ? 'tmdb_tv';
+CREATE UNIQUE INDEX media_external_ids_rawg_key ON media ((external_ids->>'rawg')) WHERE external_ids ? 'rawg';
+CREATE UNIQUE INDEX media_external_ids_bgg_key ON media ((external_ids->>'bgg')) WHERE external_ids ? 'bgg';
+CREATE UNIQUE INDEX media_external_ids_isbn13_key ON media ((external_ids->>'isbn13')) WHERE external_ids ? 'isbn13';
+
+-- Media only differing by case were told apart so far, the oldest one of them is kept and the others merged into it
+CREATE TEMPORARY TABLE media_duplicates ON COMMIT DROP AS
+SELECT id, kept_id FROM (
+    SELECT id, first_value(id) OVER (
+        PARTITION BY LOWER(media_type), LOWER(title), LOWER(creator), COALESCE(SUBSTRING(pub_date FROM '[0-9]{4}'), '')
+        ORDER BY created_at, id
+    ) AS kept_id
+    FROM media
+) AS identities
+WHERE id <> kept_id;
+
+-- A user keeps a single record of the merged media: the kept medium's one, or else the oldest one
+DELETE FROM users_media_records AS records
+USING media_duplicates AS duplicate, users_media_records AS other
+WHERE records.media_id = duplicate.id
+AND other.user_id = records.user_id
+AND other.id <> records.id
+AND (
+    other.media_id = duplicate.kept_id
+    OR (
+        other.media_id IN (SELECT id FROM media_duplicates WHERE kept_id = duplicate.kept_id)
+        AND (other.created_at, other.id) < (records.created_at, records.id)
+    )
+);
+
+UPDATE users_media_records
+SET media_id = duplicate.kept_id, updated_at = NOW()
+FROM media_duplicates AS duplicate
+WHERE users_media_records.media_id = duplicate.id;
+
+-- Merged media's IDs keep resolving, like after an admin's merge
+UPDATE media_redirects
+SET media_id = duplicate.kept_id
+FROM media_duplicates AS duplicate
+WHERE media_redirects.media_id = duplicate.id;
+
+INSERT INTO media_redirects (old_id, media_id, created_at)
+SELECT id, kept_id, NOW() FROM media_duplicates;
+
+DELETE FROM media
+WHERE id IN (SELECT id FROM media_duplicates);
+
+-- Media without any external ID are told apart by title, creator and publication year
+-- media_type is compared case-insensitively, like lookups do
+CREATE UNIQUE INDEX media_identity_key ON media (
+    LOWER(media_type),
+    LOWER(title),
+    LOWER(creator),
+    COALESCE(SUBSTRING(pub_date FROM '[0-9]{4}'), '')
+)
+WHERE external_ids = '{}'::jsonb;
+
+CREATE INDEX media_title_idx ON media (LOWER(media_type), LOWER(title));
+
+-- +goose Down
+DROP INDEX media_title_idx;
+
+DROP INDEX media_identity_key;
+
+DROP INDEX media_external_ids_isbn13_key;
+DROP INDEX media_external_ids_bgg_key;
+DROP INDEX media_external_ids_rawg_key;
+DROP INDEX media_external_ids_tmdb_tv_key;
+DROP INDEX media_external_ids_tmdb_movie_key;
+DROP INDEX media_external_ids_openlibrary_work_key;
+
+ALTER TABLE media DROP COLUMN external_ids;
+
+-- Media sharing a type and a title can't be told apart anymore, they must be merged first
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM media GROUP BY media_type, title HAVING count(*) > 1) THEN
+        RAISE EXCEPTION 'some media share a type and a title, merge them (POST /admin/media/merge) before going down to a schema where titles are unique';
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+ALTER TABLE media ADD CONSTRAINT media_media_type_title_key UNIQUE (media_type, title);
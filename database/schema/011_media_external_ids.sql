@@ -0,0 +1,18 @@
+-- +goose Up
+-- Books' ISBN 13 only lived in metadata, unless two books claim the same one
+UPDATE media
+SET external_ids = external_ids || jsonb_build_object('isbn13', metadata->>'isbn13')
+WHERE media_type = 'book'
+AND COALESCE(metadata->>'isbn13', '') <> ''
+AND NOT external_ids ? 'isbn13'
+AND NOT EXISTS (
+    SELECT 1 FROM media other
+    WHERE other.id <> media.id
+    AND (other.metadata->>'isbn13' = media.metadata->>'isbn13' OR other.external_ids->>'isbn13' = media.metadata->>'isbn13')
+);
+
+-- +goose Down
+-- ISBN 13 still lives in metadata, only the copy matching it is dropped
+UPDATE media
+SET external_ids = external_ids - 'isbn13'
+WHERE external_ids->>'isbn13' = metadata->>'isbn13';
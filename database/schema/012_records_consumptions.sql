@@ -0,0 +1,17 @@
+-- +goose Up
+-- A medium can be read, watched or played several times by the same user, each consumption being its own record
+ALTER TABLE users_media_records DROP CONSTRAINT users_media_records_user_id_media_id_key;
+
+CREATE INDEX users_media_records_user_id_media_id_idx ON users_media_records (user_id, media_id);
+
+-- +goose Down
+DROP INDEX users_media_records_user_id_media_id_idx;
+
+-- Only the latest consumption of each medium is kept
+DELETE FROM users_media_records AS records
+USING users_media_records AS newer
+WHERE newer.user_id = records.user_id
+AND newer.media_id = records.media_id
+AND (newer.created_at, newer.id) > (records.created_at, records.id);
+
+ALTER TABLE users_media_records ADD CONSTRAINT users_media_records_user_id_media_id_key UNIQUE (user_id, media_id);
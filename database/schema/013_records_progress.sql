@@ -0,0 +1,19 @@
+-- +goose Up
+-- Progress log of a record, one row per update: pages or percent for books, episodes for series, hours and completion percent for videogames
+CREATE TABLE records_progress (
+    id UUID PRIMARY KEY,
+    logged_at TIMESTAMP NOT NULL,
+    record_id UUID NOT NULL REFERENCES users_media_records(id) ON DELETE CASCADE,
+    pages INTEGER CHECK (pages >= 0),
+    percent DOUBLE PRECISION CHECK (percent BETWEEN 0 AND 100),
+    episodes INTEGER CHECK (episodes >= 0),
+    hours DOUBLE PRECISION CHECK (hours >= 0),
+    -- Pages or episodes of the whole medium, when known
+    total INTEGER CHECK (total > 0),
+    CHECK (COALESCE(pages, episodes) IS NOT NULL OR COALESCE(percent, hours) IS NOT NULL)
+);
+
+CREATE INDEX records_progress_record_id_idx ON records_progress (record_id, logged_at);
+
+-- +goose Down
+DROP TABLE records_progress;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Rating stored normalized from 0 to 100, whatever the scale it was given on (5 stars with halves, 10 or 100)
+ALTER TABLE users_media_records ADD COLUMN rating SMALLINT CHECK (rating BETWEEN 0 AND 100);
+
+-- +goose Down
+ALTER TABLE users_media_records DROP COLUMN rating;
@@ -0,0 +1,43 @@
+-- +goose Up
+-- User's own tags and custom shelves, both grouping media of user's shelf whatever their type
+CREATE TABLE tags (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL CHECK (btrim(name) <> '')
+);
+
+-- A user can't have two tags with the same name, whatever their case
+CREATE UNIQUE INDEX tags_user_id_name_key ON tags (user_id, lower(name));
+
+CREATE TABLE media_tags (
+    tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    media_id UUID NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (tag_id, media_id)
+);
+
+CREATE TABLE shelves (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL CHECK (btrim(name) <> ''),
+    description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE UNIQUE INDEX shelves_user_id_name_key ON shelves (user_id, lower(name));
+
+CREATE TABLE shelves_media (
+    shelf_id UUID NOT NULL REFERENCES shelves(id) ON DELETE CASCADE,
+    media_id UUID NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    added_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (shelf_id, media_id)
+);
+
+-- +goose Down
+DROP TABLE shelves_media;
+DROP TABLE shelves;
+DROP TABLE media_tags;
+DROP TABLE tags;
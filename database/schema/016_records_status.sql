@@ -0,0 +1,32 @@
+-- +goose Up
+-- Explicit status of a record, is_finished being kept in sync (true for finished records only)
+ALTER TABLE users_media_records ADD COLUMN status TEXT NOT NULL DEFAULT 'planned'
+    CHECK (status IN ('wishlist', 'planned', 'in_progress', 'paused', 'finished', 'abandoned'));
+ALTER TABLE users_media_records ADD COLUMN abandon_reason TEXT;
+
+-- Existing records get the status they were shown with: finished with both dates, in progress once started
+UPDATE users_media_records
+SET status = CASE
+    WHEN is_finished THEN 'finished'
+    WHEN start_date IS NOT NULL THEN 'in_progress'
+    ELSE 'planned'
+END;
+
+ALTER TABLE users_media_records ALTER COLUMN status DROP DEFAULT;
+
+-- Times a record was put on hold, left out of its duration
+CREATE TABLE records_pauses (
+    id UUID PRIMARY KEY,
+    record_id UUID NOT NULL REFERENCES users_media_records(id) ON DELETE CASCADE,
+    paused_at TIMESTAMP NOT NULL,
+    resumed_at TIMESTAMP CHECK (resumed_at >= paused_at)
+);
+
+-- A record has one open pause at most
+CREATE UNIQUE INDEX records_pauses_record_id_open_key ON records_pauses (record_id) WHERE resumed_at IS NULL;
+
+-- +goose Down
+DROP TABLE records_pauses;
+
+ALTER TABLE users_media_records DROP COLUMN abandon_reason;
+ALTER TABLE users_media_records DROP COLUMN status;
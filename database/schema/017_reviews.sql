@@ -0,0 +1,27 @@
+-- +goose Up
+-- User's long-form review of one of their records, written in Markdown
+CREATE TABLE reviews (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    record_id UUID NOT NULL UNIQUE REFERENCES users_media_records(id) ON DELETE CASCADE,
+    title TEXT NOT NULL CHECK (btrim(title) <> ''),
+    body TEXT NOT NULL,
+    is_published BOOLEAN NOT NULL,
+    published_at TIMESTAMP
+);
+
+-- Every saved version of a review, the latest one matching the review itself
+CREATE TABLE reviews_revisions (
+    id UUID PRIMARY KEY,
+    review_id UUID NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL CHECK (revision > 0),
+    created_at TIMESTAMP NOT NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    UNIQUE (review_id, revision)
+);
+
+-- +goose Down
+DROP TABLE reviews_revisions;
+DROP TABLE reviews;
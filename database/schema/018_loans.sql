@@ -0,0 +1,22 @@
+-- +goose Up
+-- Physical items a user lent, to another user or to someone named freely
+CREATE TABLE loans (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    media_id UUID NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    -- Borrower's name stays when the borrowing user is deleted
+    borrower_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    borrower_name TEXT NOT NULL CHECK (btrim(borrower_name) <> ''),
+    lent_at TIMESTAMP NOT NULL,
+    due_at TIMESTAMP CHECK (due_at >= lent_at),
+    returned_at TIMESTAMP CHECK (returned_at >= lent_at),
+    CHECK (borrower_id <> owner_id)
+);
+
+-- An item is lent to one borrower at a time
+CREATE UNIQUE INDEX loans_owner_id_media_id_open_key ON loans (owner_id, media_id) WHERE returned_at IS NULL;
+
+-- +goose Down
+DROP TABLE loans;
@@ -0,0 +1,25 @@
+-- +goose Up
+-- Copies of media a user owns, with how and where they were bought
+CREATE TABLE owned_copies (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    media_id UUID NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    -- Hardcover, ebook, Blu-ray, digital on Steam, big-box edition...
+    format TEXT NOT NULL CHECK (btrim(format) <> ''),
+    condition TEXT NOT NULL DEFAULT '' CHECK (condition IN ('', 'new', 'like_new', 'very_good', 'good', 'acceptable', 'poor')),
+    language TEXT NOT NULL DEFAULT '',
+    purchase_date TIMESTAMP,
+    -- In hundredths of currency's unit
+    price_cents BIGINT CHECK (price_cents >= 0),
+    -- ISO 4217 code, required with a price
+    currency TEXT NOT NULL DEFAULT '' CHECK (currency = '' OR currency ~ '^[A-Z]{3}$'),
+    store TEXT NOT NULL DEFAULT '',
+    CHECK (price_cents IS NULL OR currency <> '')
+);
+
+CREATE INDEX owned_copies_user_id_media_id_idx ON owned_copies (user_id, media_id);
+
+-- +goose Down
+DROP TABLE owned_copies;
@@ -0,0 +1,34 @@
+-- +goose Up
+-- Physical shelving units of a user, as grids of cells (a 4x4 Kallax has 4 rows of 4 cubes of 33x33x39 cm)
+CREATE TABLE shelving_units (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL CHECK (btrim(name) <> ''),
+    rows_count INTEGER NOT NULL CHECK (rows_count BETWEEN 1 AND 20),
+    columns_count INTEGER NOT NULL CHECK (columns_count BETWEEN 1 AND 20),
+    cell_width_cm INTEGER NOT NULL CHECK (cell_width_cm > 0),
+    cell_height_cm INTEGER NOT NULL CHECK (cell_height_cm > 0),
+    cell_depth_cm INTEGER NOT NULL CHECK (cell_depth_cm > 0)
+);
+
+-- Unit names are unique per user, case insensitive
+CREATE UNIQUE INDEX shelving_units_user_id_name_key ON shelving_units (user_id, lower(name));
+
+-- Where a user keeps a medium: one cell of one of their units, rows and columns counted from 1 at the top left
+CREATE TABLE shelving_items (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    media_id UUID NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    unit_id UUID NOT NULL REFERENCES shelving_units(id) ON DELETE CASCADE,
+    cell_row INTEGER NOT NULL CHECK (cell_row >= 1),
+    cell_column INTEGER NOT NULL CHECK (cell_column >= 1),
+    placed_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, media_id)
+);
+
+CREATE INDEX shelving_items_unit_id_idx ON shelving_items (unit_id);
+
+-- +goose Down
+DROP TABLE shelving_items;
+DROP TABLE shelving_units;
@@ -0,0 +1,31 @@
+-- +goose Up
+-- Each time a user played a boardgame, with the expansions used
+CREATE TABLE plays (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    media_id UUID NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    played_at TIMESTAMP NOT NULL,
+    duration_minutes INTEGER CHECK (duration_minutes > 0),
+    location TEXT NOT NULL DEFAULT '',
+    expansions TEXT[] NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX plays_user_id_media_id_idx ON plays (user_id, media_id);
+
+-- Who took part in a play, a Kallaxy user or a guest named freely, in seat order
+CREATE TABLE plays_players (
+    play_id UUID NOT NULL REFERENCES plays(id) ON DELETE CASCADE,
+    seat INTEGER NOT NULL CHECK (seat >= 1),
+    -- Player's name stays when the playing user is deleted
+    player_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    player_name TEXT NOT NULL CHECK (btrim(player_name) <> ''),
+    score INTEGER,
+    is_winner BOOLEAN NOT NULL DEFAULT false,
+    PRIMARY KEY (play_id, seat)
+);
+
+-- +goose Down
+DROP TABLE plays_players;
+DROP TABLE plays;
@@ -0,0 +1,16 @@
+-- +goose Up
+-- How a videogame record was played, at most one row per record
+CREATE TABLE records_videogames (
+    record_id UUID PRIMARY KEY REFERENCES users_media_records(id) ON DELETE CASCADE,
+    updated_at TIMESTAMP NOT NULL,
+    -- Platform played on, spelled as in medium's metadata when it is one of its platforms
+    platform TEXT NOT NULL DEFAULT '',
+    hours_played DOUBLE PRECISION CHECK (hours_played >= 0),
+    completion TEXT NOT NULL DEFAULT '' CHECK (completion IN ('', 'main_story', 'main_extras', 'completionist')),
+    achievements_earned INTEGER CHECK (achievements_earned >= 0),
+    achievements_total INTEGER CHECK (achievements_total >= 0),
+    CHECK (achievements_earned <= achievements_total)
+);
+
+-- +goose Down
+DROP TABLE records_videogames;
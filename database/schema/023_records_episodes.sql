@@ -0,0 +1,14 @@
+-- +goose Up
+-- Episodes of a series watched by a record's user, one row per episode
+CREATE TABLE records_episodes (
+    record_id UUID NOT NULL REFERENCES users_media_records(id) ON DELETE CASCADE,
+    season_number INTEGER NOT NULL CHECK (season_number > 0),
+    episode_number INTEGER NOT NULL CHECK (episode_number > 0),
+    watched_at TIMESTAMP NOT NULL,
+    -- Normalized from 0 to 100, as records' rating
+    rating SMALLINT CHECK (rating BETWEEN 0 AND 100),
+    PRIMARY KEY (record_id, season_number, episode_number)
+);
+
+-- +goose Down
+DROP TABLE records_episodes;
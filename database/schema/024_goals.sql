@@ -0,0 +1,31 @@
+-- +goose Up
+-- User's goals over a period, their progress is computed from the records finished in it
+CREATE TABLE goals (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL DEFAULT '',
+    media_type TEXT NOT NULL CHECK (btrim(media_type) <> ''),
+    -- Finished records counted, or the sum of their pages or hours
+    metric TEXT NOT NULL CHECK (metric IN ('count', 'pages', 'hours')),
+    target INTEGER NOT NULL CHECK (target > 0),
+    -- Calendar period the goal was set for, its bounds are kept as given for a custom one
+    period TEXT NOT NULL CHECK (period IN ('week', 'month', 'year', 'custom')),
+    period_start TIMESTAMP NOT NULL,
+    period_end TIMESTAMP NOT NULL,
+    CHECK (period_start < period_end)
+);
+
+CREATE INDEX goals_user_id_idx ON goals (user_id, period_start);
+
+-- A goal with tags only counts media holding one of them
+CREATE TABLE goals_tags (
+    goal_id UUID NOT NULL REFERENCES goals(id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (goal_id, tag_id)
+);
+
+-- +goose Down
+DROP TABLE goals_tags;
+DROP TABLE goals;
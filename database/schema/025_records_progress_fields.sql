@@ -0,0 +1,27 @@
+-- +goose Up
+-- Series' progress is told by their watched episodes, and videogames' hours played by records_videogames
+-- Latest hours logged as progress fill videogame records' missing hours played
+INSERT INTO records_videogames (record_id, updated_at, hours_played)
+SELECT DISTINCT ON (record_id) record_id, NOW(), hours
+FROM records_progress
+WHERE hours IS NOT NULL
+ORDER BY record_id, logged_at DESC, id DESC
+ON CONFLICT (record_id) DO UPDATE
+SET hours_played = EXCLUDED.hours_played, updated_at = NOW()
+WHERE records_videogames.hours_played IS NULL;
+
+-- Updates left without pages nor percent go
+DELETE FROM records_progress
+WHERE pages IS NULL AND percent IS NULL;
+
+ALTER TABLE records_progress
+DROP COLUMN episodes,
+DROP COLUMN hours,
+ADD CONSTRAINT records_progress_pages_percent_check CHECK (pages IS NOT NULL OR percent IS NOT NULL);
+
+-- +goose Down
+ALTER TABLE records_progress
+DROP CONSTRAINT records_progress_pages_percent_check,
+ADD COLUMN episodes INTEGER CHECK (episodes >= 0),
+ADD COLUMN hours DOUBLE PRECISION CHECK (hours >= 0),
+ADD CHECK (COALESCE(pages, episodes) IS NOT NULL OR COALESCE(percent, hours) IS NOT NULL);
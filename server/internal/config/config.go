@@ -0,0 +1,322 @@
+// Package config loads the server configuration.
+// Values come from, by increasing priority: defaults, an optional YAML or TOML file,
+// environment variables and command line flags.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	Port          int    `yaml:"port" toml:"port"`
+	ServerVersion string `yaml:"server_version" toml:"server_version"`
+	DBURL         string `yaml:"db_url" toml:"db_url"`
+	JWTSecret     string `yaml:"secret" toml:"secret"`
+
+	// Upstream APIs used by proxy endpoints, optional
+	OpenLibraryUA string `yaml:"open_library_user_agent" toml:"open_library_user_agent"`
+	MovieDBKey    string `yaml:"moviedb_key" toml:"moviedb_key"`
+	RAWGKey       string `yaml:"rawg_key" toml:"rawg_key"`
+
+	// Usernames given the admin role at startup, they must already be registered
+	AdminUsers []string `yaml:"admin_users" toml:"admin_users"`
+
+	DB     DBConfig     `yaml:"db" toml:"db"`
+	HTTP   HTTPConfig   `yaml:"http" toml:"http"`
+	Tokens TokensConfig `yaml:"tokens" toml:"tokens"`
+}
+
+// Database connection pool sizing
+type DBConfig struct {
+	MaxConns        int32         `yaml:"max_conns" toml:"max_conns"`
+	MinConns        int32         `yaml:"min_conns" toml:"min_conns"`
+	MaxConnLifetime time.Duration `yaml:"max_conn_lifetime" toml:"max_conn_lifetime"`
+	MaxConnIdleTime time.Duration `yaml:"max_conn_idle_time" toml:"max_conn_idle_time"`
+}
+
+type HTTPConfig struct {
+	ReadTimeout  time.Duration `yaml:"read_timeout" toml:"read_timeout"`
+	WriteTimeout time.Duration `yaml:"write_timeout" toml:"write_timeout"`
+	IdleTimeout  time.Duration `yaml:"idle_timeout" toml:"idle_timeout"`
+	// How long in-flight requests get to finish once a shutdown signal is received
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout"`
+}
+
+// Lifetimes of the tokens handed to users
+type TokensConfig struct {
+	AccessTokenTTL  time.Duration `yaml:"access_token_ttl" toml:"access_token_ttl"`
+	RefreshTokenTTL time.Duration `yaml:"refresh_token_ttl" toml:"refresh_token_ttl"`
+	ResetTokenTTL   time.Duration `yaml:"reset_token_ttl" toml:"reset_token_ttl"`
+}
+
+// Default returns the configuration used when nothing overrides it
+func Default() Config {
+	return Config{
+		Port:          8080,
+		ServerVersion: "v1.0.0",
+		OpenLibraryUA: "Kallaxy (https://github.com/VincNT21/project_kallaxy)",
+		DB: DBConfig{
+			MaxConns:        10,
+			MinConns:        0,
+			MaxConnLifetime: time.Hour,
+			MaxConnIdleTime: 30 * time.Minute,
+		},
+		HTTP: HTTPConfig{
+			ReadTimeout:     15 * time.Second,
+			WriteTimeout:    30 * time.Second,
+			IdleTimeout:     2 * time.Minute,
+			ShutdownTimeout: 15 * time.Second,
+		},
+		Tokens: TokensConfig{
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: 60 * 24 * time.Hour,
+			ResetTokenTTL:   6 * time.Hour,
+		},
+	}
+}
+
+// A setting that can be given by environment variable and command line flag
+type setting struct {
+	env   string
+	flag  string
+	usage string
+	set   func(value string) error
+}
+
+func (c *Config) settings() []setting {
+	return []setting{
+		{"PORT", "port", "port to listen on", intSetter(&c.Port)},
+		{"SERVER_VERSION", "server-version", "server version communicated to clients", stringSetter(&c.ServerVersion)},
+		{"DB_URL", "db-url", "PostgreSQL connection string", stringSetter(&c.DBURL)},
+		{"SECRET", "secret", "secret used to sign access tokens", stringSetter(&c.JWTSecret)},
+		{"OPEN_LIBRARY_USER_AGENT", "open-library-user-agent", "User-Agent sent to Open Library API", stringSetter(&c.OpenLibraryUA)},
+		{"MOVIEDB_KEY", "moviedb-key", "The Movie DB API key", stringSetter(&c.MovieDBKey)},
+		{"RAWG_KEY", "rawg-key", "RAWG API key", stringSetter(&c.RAWGKey)},
+		{"ADMIN_USERS", "admin-users", "comma-separated usernames given the admin role at startup", stringListSetter(&c.AdminUsers)},
+		{"DB_MAX_CONNS", "db-max-conns", "maximum number of database connections", int32Setter(&c.DB.MaxConns)},
+		{"DB_MIN_CONNS", "db-min-conns", "minimum number of idle database connections", int32Setter(&c.DB.MinConns)},
+		{"DB_MAX_CONN_LIFETIME", "db-max-conn-lifetime", "maximum lifetime of a database connection", durationSetter(&c.DB.MaxConnLifetime)},
+		{"DB_MAX_CONN_IDLE_TIME", "db-max-conn-idle-time", "maximum idle time of a database connection", durationSetter(&c.DB.MaxConnIdleTime)},
+		{"HTTP_READ_TIMEOUT", "http-read-timeout", "maximum duration for reading a request", durationSetter(&c.HTTP.ReadTimeout)},
+		{"HTTP_WRITE_TIMEOUT", "http-write-timeout", "maximum duration for writing a response", durationSetter(&c.HTTP.WriteTimeout)},
+		{"HTTP_IDLE_TIMEOUT", "http-idle-timeout", "maximum time to keep an idle connection open", durationSetter(&c.HTTP.IdleTimeout)},
+		{"SHUTDOWN_TIMEOUT", "shutdown-timeout", "time given to in-flight requests on shutdown", durationSetter(&c.HTTP.ShutdownTimeout)},
+		{"ACCESS_TOKEN_TTL", "access-token-ttl", "access token lifetime", durationSetter(&c.Tokens.AccessTokenTTL)},
+		{"REFRESH_TOKEN_TTL", "refresh-token-ttl", "refresh token lifetime", durationSetter(&c.Tokens.RefreshTokenTTL)},
+		{"RESET_TOKEN_TTL", "reset-token-ttl", "password reset token lifetime", durationSetter(&c.Tokens.ResetTokenTTL)},
+	}
+}
+
+// Load builds the configuration from command line args (without program name) and environment.
+// The config file is given by -config flag or KALLAXY_CONFIG env. variable.
+func Load(args []string, lookupEnv func(string) (string, bool)) (Config, error) {
+	cfg := Default()
+	settings := cfg.settings()
+
+	// Parse flags first, but only apply them last as they have the highest priority
+	type flagValue struct {
+		setting setting
+		value   string
+	}
+	var flagValues []flagValue
+	configPath, _ := lookupEnv("KALLAXY_CONFIG")
+
+	flags := flag.NewFlagSet("server", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.StringVar(&configPath, "config", configPath, "path to a YAML or TOML config file")
+	for _, s := range settings {
+		s := s
+		flags.Func(s.flag, fmt.Sprintf("%s (env %s)", s.usage, s.env), func(value string) error {
+			flagValues = append(flagValues, flagValue{setting: s, value: value})
+			return nil
+		})
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			flags.SetOutput(os.Stderr)
+			flags.PrintDefaults()
+		}
+		return Config{}, fmt.Errorf("invalid command line: %w", err)
+	}
+	if flags.NArg() > 0 {
+		return Config{}, fmt.Errorf("invalid command line: unexpected argument %q", flags.Arg(0))
+	}
+
+	// Config file
+	if configPath != "" {
+		if err := cfg.loadFile(configPath); err != nil {
+			return Config{}, err
+		}
+	}
+
+	// Environment variables
+	var errs []error
+	for _, s := range settings {
+		value, ok := lookupEnv(s.env)
+		if !ok || value == "" {
+			continue
+		}
+		if err := s.set(value); err != nil {
+			errs = append(errs, fmt.Errorf("env. variable %s: %w", s.env, err))
+		}
+	}
+
+	// Flags
+	for _, f := range flagValues {
+		if err := f.setting.set(f.value); err != nil {
+			errs = append(errs, fmt.Errorf("flag -%s: %w", f.setting.flag, err))
+		}
+	}
+	if len(errs) > 0 {
+		return Config{}, errors.Join(errs...)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// Decode a YAML or TOML file on top of current values, format is chosen by file extension
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("couldn't read config file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(c)
+		if errors.Is(err, io.EOF) {
+			// Empty file
+			err = nil
+		}
+	case ".toml":
+		var meta toml.MetaData
+		meta, err = toml.Decode(string(data), c)
+		if err == nil && len(meta.Undecoded()) > 0 {
+			err = fmt.Errorf("unknown key %q", meta.Undecoded()[0].String())
+		}
+	default:
+		return fmt.Errorf("config file %s: unsupported format, use .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Validate checks every value and reports all problems at once
+func (c Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.Port > 0 && c.Port <= 65535, "port (PORT) must be between 1 and 65535, got %d", c.Port)
+	check(c.ServerVersion != "", "server version (SERVER_VERSION) must be set")
+	check(c.DBURL != "", "database URL (DB_URL) must be set")
+	check(c.JWTSecret != "", "JWT secret (SECRET) must be set")
+
+	check(c.DB.MaxConns > 0, "db max_conns (DB_MAX_CONNS) must be positive, got %d", c.DB.MaxConns)
+	check(c.DB.MinConns >= 0, "db min_conns (DB_MIN_CONNS) can't be negative, got %d", c.DB.MinConns)
+	check(c.DB.MinConns <= c.DB.MaxConns, "db min_conns (%d) can't be greater than max_conns (%d)", c.DB.MinConns, c.DB.MaxConns)
+	check(c.DB.MaxConnLifetime > 0, "db max_conn_lifetime (DB_MAX_CONN_LIFETIME) must be positive")
+	check(c.DB.MaxConnIdleTime > 0, "db max_conn_idle_time (DB_MAX_CONN_IDLE_TIME) must be positive")
+
+	check(c.HTTP.ReadTimeout > 0, "http read_timeout (HTTP_READ_TIMEOUT) must be positive")
+	check(c.HTTP.WriteTimeout > 0, "http write_timeout (HTTP_WRITE_TIMEOUT) must be positive")
+	check(c.HTTP.IdleTimeout > 0, "http idle_timeout (HTTP_IDLE_TIMEOUT) must be positive")
+	check(c.HTTP.ShutdownTimeout > 0, "http shutdown_timeout (SHUTDOWN_TIMEOUT) must be positive")
+
+	check(c.Tokens.AccessTokenTTL > 0, "tokens access_token_ttl (ACCESS_TOKEN_TTL) must be positive")
+	check(c.Tokens.RefreshTokenTTL > c.Tokens.AccessTokenTTL, "tokens refresh_token_ttl (REFRESH_TOKEN_TTL) must be longer than access_token_ttl")
+	check(c.Tokens.ResetTokenTTL > 0, "tokens reset_token_ttl (RESET_TOKEN_TTL) must be positive")
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration:\n%w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// MissingAPIKeys lists the upstream APIs whose proxy endpoints won't be available
+func (c Config) MissingAPIKeys() []string {
+	var missing []string
+	if c.MovieDBKey == "" {
+		missing = append(missing, "The Movie DB (MOVIEDB_KEY)")
+	}
+	if c.RAWGKey == "" {
+		missing = append(missing, "RAWG (RAWG_KEY)")
+	}
+	return missing
+}
+
+func stringSetter(target *string) func(string) error {
+	return func(value string) error {
+		*target = value
+		return nil
+	}
+}
+
+func stringListSetter(target *[]string) func(string) error {
+	return func(value string) error {
+		var list []string
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			if item != "" {
+				list = append(list, item)
+			}
+		}
+		*target = list
+		return nil
+	}
+}
+
+func intSetter(target *int) func(string) error {
+	return func(value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+		*target = n
+		return nil
+	}
+}
+
+func int32Setter(target *int32) func(string) error {
+	return func(value string) error {
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+		*target = int32(n)
+		return nil
+	}
+}
+
+func durationSetter(target *time.Duration) func(string) error {
+	return func(value string) error {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a duration (e.g. 30s, 15m, 1h)", value)
+		}
+		*target = d
+		return nil
+	}
+}
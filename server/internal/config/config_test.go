@@ -0,0 +1,166 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Build a lookupEnv function from a map
+func envFrom(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("couldn't write config file: %v", err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	requiredEnv := map[string]string{"DB_URL": "postgres://localhost/kallaxy", "SECRET": "secret"}
+
+	yamlFile := writeFile(t, "kallaxy.yaml", `
+port: 9090
+db:
+  max_conns: 20
+http:
+  read_timeout: 5s
+tokens:
+  access_token_ttl: 30m
+`)
+	tomlFile := writeFile(t, "kallaxy.toml", `
+port = 9091
+rawg_key = "rawg"
+admin_users = ["alice"]
+
+[tokens]
+reset_token_ttl = "2h"
+`)
+	unknownKeyFile := writeFile(t, "unknown.yaml", "prot: 9090\n")
+
+	// Create tests table
+	tests := []struct {
+		name    string
+		args    []string
+		env     map[string]string
+		check   func(t *testing.T, cfg Config)
+		wantErr string
+	}{
+		{
+			name: "Defaults",
+			env:  requiredEnv,
+			check: func(t *testing.T, cfg Config) {
+				if cfg.Port != 8080 || cfg.Tokens.AccessTokenTTL != time.Hour || cfg.Tokens.RefreshTokenTTL != 60*24*time.Hour || cfg.Tokens.ResetTokenTTL != 6*time.Hour {
+					t.Errorf("unexpected defaults: %+v", cfg)
+				}
+			},
+		},
+		{
+			name: "YAML file",
+			args: []string{"-config", yamlFile},
+			env:  requiredEnv,
+			check: func(t *testing.T, cfg Config) {
+				if cfg.Port != 9090 || cfg.DB.MaxConns != 20 || cfg.HTTP.ReadTimeout != 5*time.Second || cfg.Tokens.AccessTokenTTL != 30*time.Minute {
+					t.Errorf("file values not applied: %+v", cfg)
+				}
+				if cfg.HTTP.WriteTimeout != Default().HTTP.WriteTimeout {
+					t.Errorf("values missing from file should keep their default, got %v", cfg.HTTP.WriteTimeout)
+				}
+			},
+		},
+		{
+			name: "TOML file from env",
+			env:  map[string]string{"DB_URL": "db", "SECRET": "secret", "KALLAXY_CONFIG": tomlFile},
+			check: func(t *testing.T, cfg Config) {
+				if cfg.Port != 9091 || cfg.RAWGKey != "rawg" || cfg.Tokens.ResetTokenTTL != 2*time.Hour || !slices.Equal(cfg.AdminUsers, []string{"alice"}) {
+					t.Errorf("file values not applied: %+v", cfg)
+				}
+			},
+		},
+		{
+			name: "Env overrides file, flag overrides env",
+			args: []string{"-config", yamlFile, "-port", "7000"},
+			env:  map[string]string{"DB_URL": "db", "SECRET": "secret", "PORT": "6000", "DB_MAX_CONNS": "3"},
+			check: func(t *testing.T, cfg Config) {
+				if cfg.Port != 7000 {
+					t.Errorf("Port = %d, want 7000", cfg.Port)
+				}
+				if cfg.DB.MaxConns != 3 {
+					t.Errorf("DB.MaxConns = %d, want 3", cfg.DB.MaxConns)
+				}
+			},
+		},
+		{
+			name: "Admin users list",
+			env:  map[string]string{"DB_URL": "db", "SECRET": "secret", "ADMIN_USERS": " alice, bob,,"},
+			check: func(t *testing.T, cfg Config) {
+				if !slices.Equal(cfg.AdminUsers, []string{"alice", "bob"}) {
+					t.Errorf("AdminUsers = %q, want [alice bob]", cfg.AdminUsers)
+				}
+			},
+		},
+		{
+			name: "Missing API keys are not an error",
+			env:  requiredEnv,
+			check: func(t *testing.T, cfg Config) {
+				if len(cfg.MissingAPIKeys()) != 2 {
+					t.Errorf("MissingAPIKeys() = %v, want 2 items", cfg.MissingAPIKeys())
+				}
+			},
+		},
+		{
+			name:    "Missing required values",
+			env:     map[string]string{},
+			wantErr: "database URL (DB_URL) must be set",
+		},
+		{
+			name:    "Invalid duration",
+			env:     map[string]string{"DB_URL": "db", "SECRET": "secret", "HTTP_READ_TIMEOUT": "10"},
+			wantErr: "env. variable HTTP_READ_TIMEOUT",
+		},
+		{
+			name:    "Pool sizing",
+			args:    []string{"-db-min-conns", "5", "-db-max-conns", "2"},
+			env:     requiredEnv,
+			wantErr: "db min_conns (5) can't be greater than max_conns (2)",
+		},
+		{
+			name:    "Unknown file key",
+			args:    []string{"-config", unknownKeyFile},
+			env:     requiredEnv,
+			wantErr: "prot",
+		},
+		{
+			name:    "Unknown flag",
+			args:    []string{"-prot", "9090"},
+			env:     requiredEnv,
+			wantErr: "invalid command line",
+		},
+	}
+
+	// Test loop
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := Load(tt.args, envFrom(tt.env))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() unexpected error: %v", err)
+			}
+			tt.check(t, cfg)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type SaveGoalParams struct {
+	// Goal to replace, a new goal is set if not valid
+	ID          pgtype.UUID
+	UserID      pgtype.UUID
+	Name        string
+	MediaType   string
+	Metric      string
+	Target      int32
+	Period      string
+	PeriodStart pgtype.Timestamp
+	PeriodEnd   pgtype.Timestamp
+	// Tags replace the goal's previous ones
+	TagIDs []pgtype.UUID
+}
+
+type SaveGoalResult struct {
+	Goal Goal
+	Tags []GoalsTag
+}
+
+// SaveGoal sets a goal, or replaces one of user's goals, with its tags, in a single transaction.
+func (q *Queries) SaveGoal(ctx context.Context, arg SaveGoalParams) (SaveGoalResult, error) {
+	var result SaveGoalResult
+	err := q.execTx(ctx, func(qtx *Queries) error {
+		var err error
+		result, err = qtx.saveGoal(ctx, arg)
+		return err
+	})
+	return result, err
+}
+
+func (q *Queries) saveGoal(ctx context.Context, arg SaveGoalParams) (SaveGoalResult, error) {
+	var result SaveGoalResult
+	var err error
+	if arg.ID.Valid {
+		result.Goal, err = q.UpdateGoal(ctx, UpdateGoalParams{
+			ID:          arg.ID,
+			UserID:      arg.UserID,
+			Name:        arg.Name,
+			MediaType:   arg.MediaType,
+			Metric:      arg.Metric,
+			Target:      arg.Target,
+			Period:      arg.Period,
+			PeriodStart: arg.PeriodStart,
+			PeriodEnd:   arg.PeriodEnd,
+		})
+		if err != nil {
+			return SaveGoalResult{}, err
+		}
+		err = q.DeleteGoalTags(ctx, arg.ID)
+		if err != nil {
+			return SaveGoalResult{}, err
+		}
+	} else {
+		result.Goal, err = q.CreateGoal(ctx, CreateGoalParams{
+			UserID:      arg.UserID,
+			Name:        arg.Name,
+			MediaType:   arg.MediaType,
+			Metric:      arg.Metric,
+			Target:      arg.Target,
+			Period:      arg.Period,
+			PeriodStart: arg.PeriodStart,
+			PeriodEnd:   arg.PeriodEnd,
+		})
+		if err != nil {
+			return SaveGoalResult{}, err
+		}
+	}
+
+	result.Tags = make([]GoalsTag, 0, len(arg.TagIDs))
+	for _, tagID := range arg.TagIDs {
+		created, err := q.CreateGoalTag(ctx, CreateGoalTagParams{
+			GoalID: result.Goal.ID,
+			TagID:  tagID,
+		})
+		if err != nil {
+			return SaveGoalResult{}, err
+		}
+		result.Tags = append(result.Tags, created)
+	}
+	return result, nil
+}
@@ -12,7 +12,7 @@ import (
 )
 
 const createMedium = `-- name: CreateMedium :one
-INSERT INTO media (id, media_type, created_at, updated_at, title, creator, pub_date, image_url, metadata)
+INSERT INTO media (id, media_type, created_at, updated_at, title, creator, pub_date, image_url, metadata, created_by, external_ids)
 VALUES (
     gen_random_uuid(),
     $1,
@@ -22,18 +22,22 @@ VALUES (
     $3,
     $4,
     $5,
-    $6
+    $6,
+    $7,
+    $8
 )
-RETURNING id, media_type, created_at, updated_at, title, creator, pub_date, image_url, metadata
+RETURNING id, media_type, created_at, updated_at, title, creator, pub_date, image_url, metadata, created_by, external_ids
 `
 
 type CreateMediumParams struct {
-	MediaType string
-	Title     string
-	Creator   string
-	PubDate   string
-	ImageUrl  string
-	Metadata  []byte
+	MediaType   string
+	Title       string
+	Creator     string
+	PubDate     string
+	ImageUrl    string
+	Metadata    []byte
+	CreatedBy   pgtype.UUID
+	ExternalIds []byte
 }
 
 func (q *Queries) CreateMedium(ctx context.Context, arg CreateMediumParams) (Medium, error) {
@@ -44,6 +48,8 @@ func (q *Queries) CreateMedium(ctx context.Context, arg CreateMediumParams) (Med
 		arg.PubDate,
 		arg.ImageUrl,
 		arg.Metadata,
+		arg.CreatedBy,
+		arg.ExternalIds,
 	)
 	var i Medium
 	err := row.Scan(
@@ -56,15 +62,38 @@ func (q *Queries) CreateMedium(ctx context.Context, arg CreateMediumParams) (Med
 		&i.PubDate,
 		&i.ImageUrl,
 		&i.Metadata,
+		&i.CreatedBy,
+		&i.ExternalIds,
 	)
 	return i, err
 }
 
+const createMediumRedirect = `-- name: CreateMediumRedirect :exec
+INSERT INTO media_redirects (old_id, media_id, created_at, merged_by)
+VALUES (
+    $1,
+    $2,
+    NOW(),
+    $3
+)
+`
+
+type CreateMediumRedirectParams struct {
+	OldID    pgtype.UUID
+	MediaID  pgtype.UUID
+	MergedBy pgtype.UUID
+}
+
+func (q *Queries) CreateMediumRedirect(ctx context.Context, arg CreateMediumRedirectParams) error {
+	_, err := q.db.Exec(ctx, createMediumRedirect, arg.OldID, arg.MediaID, arg.MergedBy)
+	return err
+}
+
 const deleteMedium = `-- name: DeleteMedium :one
 WITH deleted AS (
     DELETE FROM media
     WHERE id = $1
-    RETURNING id, media_type, created_at, updated_at, title, creator, pub_date, image_url, metadata
+    RETURNING id, media_type, created_at, updated_at, title, creator, pub_date, image_url, metadata, created_by, external_ids
 )
 SELECT count(*) FROM deleted
 `
@@ -76,8 +105,104 @@ func (q *Queries) DeleteMedium(ctx context.Context, id pgtype.UUID) (int64, erro
 	return count, err
 }
 
+const getMediaByExternalIDs = `-- name: GetMediaByExternalIDs :many
+SELECT id, media_type, created_at, updated_at, title, creator, pub_date, image_url, metadata, created_by, external_ids FROM media
+WHERE EXISTS (
+    SELECT 1 FROM jsonb_each_text($1::jsonb) AS given
+    WHERE media.external_ids->>given.key = given.value
+)
+ORDER BY pub_date, creator, id
+`
+
+// Media linked to any of the given provider IDs, given as {"provider": "id"}
+func (q *Queries) GetMediaByExternalIDs(ctx context.Context, externalIds []byte) ([]Medium, error) {
+	rows, err := q.db.Query(ctx, getMediaByExternalIDs, externalIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Medium
+	for rows.Next() {
+		var i Medium
+		if err := rows.Scan(
+			&i.ID,
+			&i.MediaType,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Title,
+			&i.Creator,
+			&i.PubDate,
+			&i.ImageUrl,
+			&i.Metadata,
+			&i.CreatedBy,
+			&i.ExternalIds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMediaByTitleAndType = `-- name: GetMediaByTitleAndType :many
+SELECT id, media_type, created_at, updated_at, title, creator, pub_date, image_url, metadata, created_by, external_ids FROM media
+WHERE LOWER(title) = LOWER($1)
+AND LOWER(media_type) = LOWER($2)
+AND ($3::text = '' OR LOWER(creator) = LOWER($3))
+AND ($4::text = '' OR SUBSTRING(pub_date FROM '[0-9]{4}') = $4)
+ORDER BY pub_date, creator, id
+`
+
+type GetMediaByTitleAndTypeParams struct {
+	Title     string
+	MediaType string
+	Creator   string
+	PubYear   string
+}
+
+// Every medium sharing the title is a candidate, creator and publication year narrow the list when given
+func (q *Queries) GetMediaByTitleAndType(ctx context.Context, arg GetMediaByTitleAndTypeParams) ([]Medium, error) {
+	rows, err := q.db.Query(ctx, getMediaByTitleAndType,
+		arg.Title,
+		arg.MediaType,
+		arg.Creator,
+		arg.PubYear,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Medium
+	for rows.Next() {
+		var i Medium
+		if err := rows.Scan(
+			&i.ID,
+			&i.MediaType,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Title,
+			&i.Creator,
+			&i.PubDate,
+			&i.ImageUrl,
+			&i.Metadata,
+			&i.CreatedBy,
+			&i.ExternalIds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getMediaByType = `-- name: GetMediaByType :many
-SELECT id, media_type, created_at, updated_at, title, creator, pub_date, image_url, metadata FROM media
+SELECT id, media_type, created_at, updated_at, title, creator, pub_date, image_url, metadata, created_by, external_ids FROM media
 WHERE LOWER(media_type) = LOWER($1)
 `
 
@@ -100,6 +225,8 @@ func (q *Queries) GetMediaByType(ctx context.Context, lower string) ([]Medium, e
 			&i.PubDate,
 			&i.ImageUrl,
 			&i.Metadata,
+			&i.CreatedBy,
+			&i.ExternalIds,
 		); err != nil {
 			return nil, err
 		}
@@ -112,7 +239,7 @@ func (q *Queries) GetMediaByType(ctx context.Context, lower string) ([]Medium, e
 }
 
 const getMediumByID = `-- name: GetMediumByID :one
-SELECT id, media_type, created_at, updated_at, title, creator, pub_date, image_url, metadata FROM media
+SELECT id, media_type, created_at, updated_at, title, creator, pub_date, image_url, metadata, created_by, external_ids FROM media
 WHERE id = $1
 `
 
@@ -129,23 +256,20 @@ func (q *Queries) GetMediumByID(ctx context.Context, id pgtype.UUID) (Medium, er
 		&i.PubDate,
 		&i.ImageUrl,
 		&i.Metadata,
+		&i.CreatedBy,
+		&i.ExternalIds,
 	)
 	return i, err
 }
 
-const getMediumByTitleAndType = `-- name: GetMediumByTitleAndType :one
-SELECT id, media_type, created_at, updated_at, title, creator, pub_date, image_url, metadata FROM media
-WHERE LOWER(title) = LOWER($1)
-AND LOWER(media_type) = LOWER($2)
+const getMediumByIDForUpdate = `-- name: GetMediumByIDForUpdate :one
+SELECT id, media_type, created_at, updated_at, title, creator, pub_date, image_url, metadata, created_by, external_ids FROM media
+WHERE id = $1
+FOR UPDATE
 `
 
-type GetMediumByTitleAndTypeParams struct {
-	Lower   string
-	Lower_2 string
-}
-
-func (q *Queries) GetMediumByTitleAndType(ctx context.Context, arg GetMediumByTitleAndTypeParams) (Medium, error) {
-	row := q.db.QueryRow(ctx, getMediumByTitleAndType, arg.Lower, arg.Lower_2)
+func (q *Queries) GetMediumByIDForUpdate(ctx context.Context, id pgtype.UUID) (Medium, error) {
+	row := q.db.QueryRow(ctx, getMediumByIDForUpdate, id)
 	var i Medium
 	err := row.Scan(
 		&i.ID,
@@ -157,10 +281,41 @@ func (q *Queries) GetMediumByTitleAndType(ctx context.Context, arg GetMediumByTi
 		&i.PubDate,
 		&i.ImageUrl,
 		&i.Metadata,
+		&i.CreatedBy,
+		&i.ExternalIds,
 	)
 	return i, err
 }
 
+const getMediumRedirect = `-- name: GetMediumRedirect :one
+SELECT media_id FROM media_redirects
+WHERE old_id = $1
+`
+
+func (q *Queries) GetMediumRedirect(ctx context.Context, oldID pgtype.UUID) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, getMediumRedirect, oldID)
+	var media_id pgtype.UUID
+	err := row.Scan(&media_id)
+	return media_id, err
+}
+
+const repointMediumRedirects = `-- name: RepointMediumRedirects :exec
+UPDATE media_redirects
+SET media_id = $1
+WHERE media_id = $2
+`
+
+type RepointMediumRedirectsParams struct {
+	NewMediaID pgtype.UUID
+	OldMediaID pgtype.UUID
+}
+
+// Redirects to a merged medium follow it to the medium it is merged into
+func (q *Queries) RepointMediumRedirects(ctx context.Context, arg RepointMediumRedirectsParams) error {
+	_, err := q.db.Exec(ctx, repointMediumRedirects, arg.NewMediaID, arg.OldMediaID)
+	return err
+}
+
 const resetMedia = `-- name: ResetMedia :exec
 DELETE FROM media
 `
@@ -172,18 +327,19 @@ func (q *Queries) ResetMedia(ctx context.Context) error {
 
 const updateMedium = `-- name: UpdateMedium :one
 UPDATE media
-SET title = $2, creator = $3, pub_date = $4, image_url = $5, metadata = $6, updated_at = NOW()
+SET title = $2, creator = $3, pub_date = $4, image_url = $5, metadata = $6, external_ids = $7, updated_at = NOW()
 WHERE id = $1
-RETURNING id, media_type, created_at, updated_at, title, creator, pub_date, image_url, metadata
+RETURNING id, media_type, created_at, updated_at, title, creator, pub_date, image_url, metadata, created_by, external_ids
 `
 
 type UpdateMediumParams struct {
-	ID       pgtype.UUID
-	Title    string
-	Creator  string
-	PubDate  string
-	ImageUrl string
-	Metadata []byte
+	ID          pgtype.UUID
+	Title       string
+	Creator     string
+	PubDate     string
+	ImageUrl    string
+	Metadata    []byte
+	ExternalIds []byte
 }
 
 func (q *Queries) UpdateMedium(ctx context.Context, arg UpdateMediumParams) (Medium, error) {
@@ -194,6 +350,7 @@ func (q *Queries) UpdateMedium(ctx context.Context, arg UpdateMediumParams) (Med
 		arg.PubDate,
 		arg.ImageUrl,
 		arg.Metadata,
+		arg.ExternalIds,
 	)
 	var i Medium
 	err := row.Scan(
@@ -206,6 +363,8 @@ func (q *Queries) UpdateMedium(ctx context.Context, arg UpdateMediumParams) (Med
 		&i.PubDate,
 		&i.ImageUrl,
 		&i.Metadata,
+		&i.CreatedBy,
+		&i.ExternalIds,
 	)
 	return i, err
 }
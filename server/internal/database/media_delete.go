@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type DeleteMediumForUserParams struct {
+	MediumID pgtype.UUID
+	UserID   pgtype.UUID
+	// Whether the user may delete the medium itself, user's records are removed anyway
+	CanDeleteMedium bool
+}
+
+type DeleteMediumForUserResult struct {
+	// User's records of the medium, removed from its shelf
+	DeletedRecords int64
+	// Records of other users, which keep the medium from being deleted
+	OtherUsersRecords int64
+	MediumDeleted     bool
+}
+
+// DeleteMediumForUser removes a medium from user's shelf, in a single transaction:
+// user's records are deleted, then the medium too if user may delete it and no other user holds a record for it.
+// The medium is locked, so no record can be added to it in between.
+func (q *Queries) DeleteMediumForUser(ctx context.Context, arg DeleteMediumForUserParams) (DeleteMediumForUserResult, error) {
+	var result DeleteMediumForUserResult
+	err := q.execTx(ctx, func(qtx *Queries) error {
+		var err error
+		result, err = qtx.deleteMediumForUser(ctx, arg)
+		return err
+	})
+	return result, err
+}
+
+func (q *Queries) deleteMediumForUser(ctx context.Context, arg DeleteMediumForUserParams) (DeleteMediumForUserResult, error) {
+	// Lock the medium until the end of the transaction
+	_, err := q.GetMediumByIDForUpdate(ctx, arg.MediumID)
+	if err != nil {
+		return DeleteMediumForUserResult{}, err
+	}
+
+	var result DeleteMediumForUserResult
+	result.OtherUsersRecords, err = q.CountOtherUsersRecordsByMediumID(ctx, CountOtherUsersRecordsByMediumIDParams{
+		MediaID: arg.MediumID,
+		UserID:  arg.UserID,
+	})
+	if err != nil {
+		return DeleteMediumForUserResult{}, err
+	}
+	result.DeletedRecords, err = q.DeleteUserMediumRecords(ctx, DeleteUserMediumRecordsParams{
+		MediaID: arg.MediumID,
+		UserID:  arg.UserID,
+	})
+	if err != nil {
+		return DeleteMediumForUserResult{}, err
+	}
+
+	if !arg.CanDeleteMedium || result.OtherUsersRecords > 0 {
+		return result, nil
+	}
+	count, err := q.DeleteMedium(ctx, arg.MediumID)
+	if err != nil {
+		return DeleteMediumForUserResult{}, err
+	}
+	result.MediumDeleted = count > 0
+	return result, nil
+}
@@ -0,0 +1,59 @@
+package database
+
+// Media identity rules aren't generated by sqlc: they mirror the media unique indexes and the external_ids check,
+// so every Store implementation tells media apart the same way.
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+)
+
+var pubYearRegexp = regexp.MustCompile(`[0-9]{4}`)
+
+// PubYear extracts the publication year from a publication date, whatever its format ("2021", "2021-10-22", "22/10/2021"...).
+// It returns an empty string when there is none.
+func PubYear(pubDate string) string {
+	return pubYearRegexp.FindString(pubDate)
+}
+
+// Providers a medium can be linked to, in the order their IDs are preferred when refreshing a medium
+var ExternalIDProviders = []string{"openlibrary_work", "isbn13", "tmdb_movie", "tmdb_tv", "rawg", "bgg"}
+
+// IsExternalIDProvider reports whether media can be linked to the given provider
+func IsExternalIDProvider(provider string) bool {
+	return slices.Contains(ExternalIDProviders, provider)
+}
+
+// DecodeExternalIDs converts external_ids JSON to a provider -> ID map
+func DecodeExternalIDs(externalIDs []byte) (map[string]string, error) {
+	ids := map[string]string{}
+	if len(externalIDs) == 0 {
+		return ids, nil
+	}
+	if err := json.Unmarshal(externalIDs, &ids); err != nil {
+		return nil, err
+	}
+	if ids == nil {
+		ids = map[string]string{}
+	}
+	return ids, nil
+}
+
+// MergeExternalIDs adds source's provider IDs to target's ones, target's IDs win
+func MergeExternalIDs(target, source []byte) ([]byte, error) {
+	targetIDs, err := DecodeExternalIDs(target)
+	if err != nil {
+		return nil, err
+	}
+	sourceIDs, err := DecodeExternalIDs(source)
+	if err != nil {
+		return nil, err
+	}
+	for provider, id := range sourceIDs {
+		if targetIDs[provider] == "" {
+			targetIDs[provider] = id
+		}
+	}
+	return json.Marshal(targetIDs)
+}
@@ -0,0 +1,397 @@
+package database
+
+// The rules deciding what is kept are plain functions, so every Store implementation merges the same way.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrMergeSameMedium = errors.New("can't merge a medium into itself")
+	ErrMergeMediaType  = errors.New("can't merge media of different types")
+)
+
+type MergeMediaParams struct {
+	TargetID pgtype.UUID
+	SourceID pgtype.UUID
+	MergedBy pgtype.UUID
+}
+
+type MergeMediaResult struct {
+	Medium Medium
+	// Source's records now pointing to target
+	MovedRecords int64
+	// Records of users who had both media describing the same consumption, merged into one
+	MergedRecords int64
+	// Source's open loans closed, as their owners had target lent out already
+	ClosedLoans int64
+}
+
+// MergeMedia merges source medium into target medium, in a single transaction:
+// source's records are moved to target, source's metadata and external IDs fill target's gaps,
+// source is deleted and its ID is redirected to target.
+func (q *Queries) MergeMedia(ctx context.Context, arg MergeMediaParams) (MergeMediaResult, error) {
+	var result MergeMediaResult
+	err := q.execTx(ctx, func(qtx *Queries) error {
+		var err error
+		result, err = qtx.mergeMedia(ctx, arg)
+		return err
+	})
+	return result, err
+}
+
+func (q *Queries) mergeMedia(ctx context.Context, arg MergeMediaParams) (MergeMediaResult, error) {
+	if arg.TargetID == arg.SourceID {
+		return MergeMediaResult{}, ErrMergeSameMedium
+	}
+
+	// Lock both media until the end of the transaction
+	target, err := q.GetMediumByIDForUpdate(ctx, arg.TargetID)
+	if err != nil {
+		return MergeMediaResult{}, err
+	}
+	source, err := q.GetMediumByIDForUpdate(ctx, arg.SourceID)
+	if err != nil {
+		return MergeMediaResult{}, err
+	}
+	if target.MediaType != source.MediaType {
+		return MergeMediaResult{}, ErrMergeMediaType
+	}
+
+	// A user holding both media may have recorded the same consumption twice: keep only one record of it
+	targetRecords, err := q.GetRecordsByMediumID(ctx, target.ID)
+	if err != nil {
+		return MergeMediaResult{}, err
+	}
+	sourceRecords, err := q.GetRecordsByMediumID(ctx, source.ID)
+	if err != nil {
+		return MergeMediaResult{}, err
+	}
+	targetRecordsByUser := make(map[pgtype.UUID][]UsersMediaRecord, len(targetRecords))
+	for _, record := range targetRecords {
+		targetRecordsByUser[record.UserID] = append(targetRecordsByUser[record.UserID], record)
+	}
+
+	var result MergeMediaResult
+	for _, sourceRecord := range sourceRecords {
+		// Each target record is merged with one source record at most
+		candidates := targetRecordsByUser[sourceRecord.UserID]
+		match := slices.IndexFunc(candidates, func(record UsersMediaRecord) bool {
+			return SameConsumption(record, sourceRecord)
+		})
+		if match == -1 {
+			continue
+		}
+		targetRecord := candidates[match]
+		targetRecordsByUser[sourceRecord.UserID] = slices.Delete(candidates, match, match+1)
+
+		kept, dropped := targetRecord, sourceRecord
+		if RicherRecord(sourceRecord, targetRecord) {
+			kept, dropped = sourceRecord, targetRecord
+		}
+
+		err = q.RepointSharesToRecord(ctx, RepointSharesToRecordParams{
+			NewRecordID: kept.ID,
+			OldRecordID: dropped.ID,
+		})
+		if err != nil {
+			return MergeMediaResult{}, err
+		}
+		err = q.RepointProgressToRecord(ctx, RepointProgressToRecordParams{
+			NewRecordID: kept.ID,
+			OldRecordID: dropped.ID,
+		})
+		if err != nil {
+			return MergeMediaResult{}, err
+		}
+		err = q.RepointPausesToRecord(ctx, RepointPausesToRecordParams{
+			NewRecordID: kept.ID,
+			OldRecordID: dropped.ID,
+		})
+		if err != nil {
+			return MergeMediaResult{}, err
+		}
+		err = q.RepointReviewToRecord(ctx, RepointReviewToRecordParams{
+			NewRecordID: kept.ID,
+			OldRecordID: dropped.ID,
+		})
+		if err != nil {
+			return MergeMediaResult{}, err
+		}
+		err = q.RepointVideogameToRecord(ctx, RepointVideogameToRecordParams{
+			NewRecordID: kept.ID,
+			OldRecordID: dropped.ID,
+		})
+		if err != nil {
+			return MergeMediaResult{}, err
+		}
+		err = q.RepointEpisodesToRecord(ctx, RepointEpisodesToRecordParams{
+			NewRecordID: kept.ID,
+			OldRecordID: dropped.ID,
+		})
+		if err != nil {
+			return MergeMediaResult{}, err
+		}
+		_, err = q.DeleteRecord(ctx, DeleteRecordParams{
+			ID:     dropped.ID,
+			UserID: dropped.UserID,
+		})
+		if err != nil {
+			return MergeMediaResult{}, err
+		}
+		// Kept record takes dropped one's rating if it had none
+		comments := MergeComments(kept.Comments, dropped.Comments)
+		if comments != kept.Comments || (!kept.Rating.Valid && dropped.Rating.Valid) {
+			rating := kept.Rating
+			if !rating.Valid {
+				rating = dropped.Rating
+			}
+			_, err = q.UpdateRecord(ctx, UpdateRecordParams{
+				ID:            kept.ID,
+				IsFinished:    kept.IsFinished,
+				StartDate:     kept.StartDate,
+				EndDate:       kept.EndDate,
+				Duration:      kept.Duration,
+				Comments:      comments,
+				UserID:        kept.UserID,
+				Rating:        rating,
+				Status:        kept.Status,
+				AbandonReason: kept.AbandonReason,
+			})
+			if err != nil {
+				return MergeMediaResult{}, err
+			}
+		}
+		result.MergedRecords++
+	}
+
+	result.MovedRecords, err = q.RepointRecordsToMedium(ctx, RepointRecordsToMediumParams{
+		NewMediaID: target.ID,
+		OldMediaID: source.ID,
+	})
+	if err != nil {
+		return MergeMediaResult{}, err
+	}
+
+	// Source's ID, and the IDs already redirected to it, now resolve to target
+	err = q.RepointMediumRedirects(ctx, RepointMediumRedirectsParams{
+		NewMediaID: target.ID,
+		OldMediaID: source.ID,
+	})
+	if err != nil {
+		return MergeMediaResult{}, err
+	}
+	// Source's tags and places on custom shelves go to target, the ones target already has go with source
+	err = q.RepointMediaTagsToMedium(ctx, RepointMediaTagsToMediumParams{
+		NewMediaID: target.ID,
+		OldMediaID: source.ID,
+	})
+	if err != nil {
+		return MergeMediaResult{}, err
+	}
+	err = q.RepointShelvesMediaToMedium(ctx, RepointShelvesMediaToMediumParams{
+		NewMediaID: target.ID,
+		OldMediaID: source.ID,
+	})
+	if err != nil {
+		return MergeMediaResult{}, err
+	}
+	// Source's loans go to target, the open ones being closed first when their owner has target lent out already
+	result.ClosedLoans, err = q.CloseLoansOfMergedMedium(ctx, CloseLoansOfMergedMediumParams{
+		OldMediaID: source.ID,
+		NewMediaID: target.ID,
+	})
+	if err != nil {
+		return MergeMediaResult{}, err
+	}
+	err = q.RepointLoansToMedium(ctx, RepointLoansToMediumParams{
+		NewMediaID: target.ID,
+		OldMediaID: source.ID,
+	})
+	if err != nil {
+		return MergeMediaResult{}, err
+	}
+	// And so do all of source's owned copies
+	err = q.RepointOwnedCopiesToMedium(ctx, RepointOwnedCopiesToMediumParams{
+		NewMediaID: target.ID,
+		OldMediaID: source.ID,
+	})
+	if err != nil {
+		return MergeMediaResult{}, err
+	}
+	// Source takes target's place in shelving units' cells, unless its user keeps target somewhere already
+	err = q.RepointShelvingItemsToMedium(ctx, RepointShelvingItemsToMediumParams{
+		NewMediaID: target.ID,
+		OldMediaID: source.ID,
+	})
+	if err != nil {
+		return MergeMediaResult{}, err
+	}
+	// Every play logged for source was a play of target
+	err = q.RepointPlaysToMedium(ctx, RepointPlaysToMediumParams{
+		NewMediaID: target.ID,
+		OldMediaID: source.ID,
+	})
+	if err != nil {
+		return MergeMediaResult{}, err
+	}
+
+	// Source goes before target takes its info, as they could then share the same identity
+	_, err = q.DeleteMedium(ctx, source.ID)
+	if err != nil {
+		return MergeMediaResult{}, err
+	}
+
+	// Fill target's gaps with source's info
+	metadata, err := MergeMetadata(target.Metadata, source.Metadata)
+	if err != nil {
+		return MergeMediaResult{}, err
+	}
+	externalIDs, err := MergeExternalIDs(target.ExternalIds, source.ExternalIds)
+	if err != nil {
+		return MergeMediaResult{}, err
+	}
+	result.Medium, err = q.UpdateMedium(ctx, UpdateMediumParams{
+		ID:          target.ID,
+		Title:       target.Title,
+		Creator:     firstNonEmpty(target.Creator, source.Creator),
+		PubDate:     firstNonEmpty(target.PubDate, source.PubDate),
+		ImageUrl:    firstNonEmpty(target.ImageUrl, source.ImageUrl),
+		Metadata:    metadata,
+		ExternalIds: externalIDs,
+	})
+	if err != nil {
+		return MergeMediaResult{}, err
+	}
+
+	err = q.CreateMediumRedirect(ctx, CreateMediumRedirectParams{
+		OldID:    source.ID,
+		MediaID:  target.ID,
+		MergedBy: arg.MergedBy,
+	})
+	if err != nil {
+		return MergeMediaResult{}, err
+	}
+
+	return result, nil
+}
+
+// SameConsumption reports whether records a and b can describe the same consumption of a medium:
+// their start dates, and their end dates, are either equal or missing on one side.
+func SameConsumption(a, b UsersMediaRecord) bool {
+	compatible := func(x, y pgtype.Timestamp) bool {
+		return !x.Valid || !y.Valid || x.Time.Equal(y.Time)
+	}
+	return compatible(a.StartDate, b.StartDate) && compatible(a.EndDate, b.EndDate)
+}
+
+// RicherRecord reports whether record a holds more information than record b.
+// Dates count first, then comments length. On a tie, b is considered richer.
+func RicherRecord(a, b UsersMediaRecord) bool {
+	richness := func(record UsersMediaRecord) int {
+		score := 0
+		if record.StartDate.Valid {
+			score++
+		}
+		if record.EndDate.Valid {
+			score++
+		}
+		return score
+	}
+	if richness(a) != richness(b) {
+		return richness(a) > richness(b)
+	}
+	return len(strings.TrimSpace(a.Comments)) > len(strings.TrimSpace(b.Comments))
+}
+
+// MergeComments appends dropped record's comments to kept record's ones, unless they are already there
+func MergeComments(kept, dropped string) string {
+	dropped = strings.TrimSpace(dropped)
+	switch {
+	case dropped == "" || strings.Contains(kept, dropped):
+		return kept
+	case strings.TrimSpace(kept) == "":
+		return dropped
+	default:
+		return kept + "\n\n" + dropped
+	}
+}
+
+// MergeMetadata adds source's keys to target's metadata.
+// Target's values win, except empty ones, and lists holding both media's items.
+func MergeMetadata(target, source []byte) ([]byte, error) {
+	targetMap := map[string]interface{}{}
+	if len(target) > 0 {
+		if err := json.Unmarshal(target, &targetMap); err != nil {
+			return nil, err
+		}
+	}
+	sourceMap := map[string]interface{}{}
+	if len(source) > 0 {
+		if err := json.Unmarshal(source, &sourceMap); err != nil {
+			return nil, err
+		}
+	}
+	if targetMap == nil {
+		// Metadata stored as JSON null
+		targetMap = map[string]interface{}{}
+	}
+
+	for key, sourceValue := range sourceMap {
+		targetValue, ok := targetMap[key]
+		if !ok || isEmptyMetadataValue(targetValue) {
+			targetMap[key] = sourceValue
+			continue
+		}
+		targetList, targetIsList := targetValue.([]interface{})
+		sourceList, sourceIsList := sourceValue.([]interface{})
+		if targetIsList && sourceIsList {
+			for _, item := range sourceList {
+				if !containsMetadataValue(targetList, item) {
+					targetList = append(targetList, item)
+				}
+			}
+			targetMap[key] = targetList
+		}
+	}
+	return json.Marshal(targetMap)
+}
+
+func isEmptyMetadataValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+func containsMetadataValue(list []interface{}, value interface{}) bool {
+	for _, item := range list {
+		if reflect.DeepEqual(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -8,16 +8,78 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type Goal struct {
+	ID          pgtype.UUID
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+	UserID      pgtype.UUID
+	Name        string
+	MediaType   string
+	Metric      string
+	Target      int32
+	Period      string
+	PeriodStart pgtype.Timestamp
+	PeriodEnd   pgtype.Timestamp
+}
+
+type GoalsTag struct {
+	GoalID pgtype.UUID
+	TagID  pgtype.UUID
+}
+
+type Loan struct {
+	ID           pgtype.UUID
+	CreatedAt    pgtype.Timestamp
+	UpdatedAt    pgtype.Timestamp
+	OwnerID      pgtype.UUID
+	MediaID      pgtype.UUID
+	BorrowerID   pgtype.UUID
+	BorrowerName string
+	LentAt       pgtype.Timestamp
+	DueAt        pgtype.Timestamp
+	ReturnedAt   pgtype.Timestamp
+}
+
 type Medium struct {
-	ID        pgtype.UUID
-	MediaType string
+	ID          pgtype.UUID
+	MediaType   string
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+	Title       string
+	Creator     string
+	PubDate     string
+	ImageUrl    string
+	Metadata    []byte
+	CreatedBy   pgtype.UUID
+	ExternalIds []byte
+}
+
+type MediaRedirect struct {
+	OldID     pgtype.UUID
+	MediaID   pgtype.UUID
+	CreatedAt pgtype.Timestamp
+	MergedBy  pgtype.UUID
+}
+
+type MediaTag struct {
+	TagID     pgtype.UUID
+	MediaID   pgtype.UUID
 	CreatedAt pgtype.Timestamp
-	UpdatedAt pgtype.Timestamp
-	Title     string
-	Creator   string
-	PubDate   string
-	ImageUrl  string
-	Metadata  []byte
+}
+
+type OwnedCopy struct {
+	ID           pgtype.UUID
+	CreatedAt    pgtype.Timestamp
+	UpdatedAt    pgtype.Timestamp
+	UserID       pgtype.UUID
+	MediaID      pgtype.UUID
+	Format       string
+	Condition    string
+	Language     string
+	PurchaseDate pgtype.Timestamp
+	PriceCents   pgtype.Int8
+	Currency     string
+	Store        string
 }
 
 type PasswordResetToken struct {
@@ -29,6 +91,61 @@ type PasswordResetToken struct {
 	UsedAt    pgtype.Timestamp
 }
 
+type Play struct {
+	ID              pgtype.UUID
+	CreatedAt       pgtype.Timestamp
+	UpdatedAt       pgtype.Timestamp
+	UserID          pgtype.UUID
+	MediaID         pgtype.UUID
+	PlayedAt        pgtype.Timestamp
+	DurationMinutes pgtype.Int4
+	Location        string
+	Expansions      []string
+}
+
+type PlaysPlayer struct {
+	PlayID     pgtype.UUID
+	Seat       int32
+	PlayerID   pgtype.UUID
+	PlayerName string
+	Score      pgtype.Int4
+	IsWinner   bool
+}
+
+type RecordsEpisode struct {
+	RecordID      pgtype.UUID
+	SeasonNumber  int32
+	EpisodeNumber int32
+	WatchedAt     pgtype.Timestamp
+	Rating        pgtype.Int2
+}
+
+type RecordsPause struct {
+	ID        pgtype.UUID
+	RecordID  pgtype.UUID
+	PausedAt  pgtype.Timestamp
+	ResumedAt pgtype.Timestamp
+}
+
+type RecordsProgress struct {
+	ID       pgtype.UUID
+	LoggedAt pgtype.Timestamp
+	RecordID pgtype.UUID
+	Pages    pgtype.Int4
+	Percent  pgtype.Float8
+	Total    pgtype.Int4
+}
+
+type RecordsVideogame struct {
+	RecordID           pgtype.UUID
+	UpdatedAt          pgtype.Timestamp
+	Platform           string
+	HoursPlayed        pgtype.Float8
+	Completion         string
+	AchievementsEarned pgtype.Int4
+	AchievementsTotal  pgtype.Int4
+}
+
 type RefreshToken struct {
 	Token     string
 	CreatedAt pgtype.Timestamp
@@ -38,6 +155,80 @@ type RefreshToken struct {
 	RevokedAt pgtype.Timestamp
 }
 
+type Review struct {
+	ID          pgtype.UUID
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+	RecordID    pgtype.UUID
+	Title       string
+	Body        string
+	IsPublished bool
+	PublishedAt pgtype.Timestamp
+}
+
+type ReviewsRevision struct {
+	ID        pgtype.UUID
+	ReviewID  pgtype.UUID
+	Revision  int32
+	CreatedAt pgtype.Timestamp
+	Title     string
+	Body      string
+}
+
+type Share struct {
+	ID          pgtype.UUID
+	CreatedAt   pgtype.Timestamp
+	OwnerID     pgtype.UUID
+	RecipientID pgtype.UUID
+	RecordID    pgtype.UUID
+	MediaType   pgtype.Text
+}
+
+type Shelf struct {
+	ID          pgtype.UUID
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+	UserID      pgtype.UUID
+	Name        string
+	Description string
+}
+
+type ShelvesMedium struct {
+	ShelfID pgtype.UUID
+	MediaID pgtype.UUID
+	AddedAt pgtype.Timestamp
+}
+
+type ShelvingItem struct {
+	UserID     pgtype.UUID
+	MediaID    pgtype.UUID
+	UnitID     pgtype.UUID
+	CellRow    int32
+	CellColumn int32
+	PlacedAt   pgtype.Timestamp
+}
+
+type ShelvingUnit struct {
+	ID           pgtype.UUID
+	CreatedAt    pgtype.Timestamp
+	UpdatedAt    pgtype.Timestamp
+	UserID       pgtype.UUID
+	Name         string
+	RowsCount    int32
+	ColumnsCount int32
+	CellWidthCm  int32
+	CellHeightCm int32
+	CellDepthCm  int32
+}
+
+type Tag struct {
+	ID        pgtype.UUID
+	CreatedAt pgtype.Timestamp
+	UpdatedAt pgtype.Timestamp
+	UserID    pgtype.UUID
+	Name      string
+}
+
 type User struct {
 	ID             pgtype.UUID
 	CreatedAt      pgtype.Timestamp
@@ -45,17 +236,22 @@ type User struct {
 	Username       string
 	HashedPassword string
 	Email          string
+	Role           string
+	DeactivatedAt  pgtype.Timestamp
 }
 
 type UsersMediaRecord struct {
-	ID         pgtype.UUID
-	CreatedAt  pgtype.Timestamp
-	UpdatedAt  pgtype.Timestamp
-	UserID     pgtype.UUID
-	MediaID    pgtype.UUID
-	IsFinished pgtype.Bool
-	StartDate  pgtype.Timestamp
-	EndDate    pgtype.Timestamp
-	Duration   pgtype.Interval
-	Comments   string
+	ID            pgtype.UUID
+	CreatedAt     pgtype.Timestamp
+	UpdatedAt     pgtype.Timestamp
+	UserID        pgtype.UUID
+	MediaID       pgtype.UUID
+	IsFinished    pgtype.Bool
+	StartDate     pgtype.Timestamp
+	EndDate       pgtype.Timestamp
+	Duration      pgtype.Interval
+	Comments      string
+	Rating        pgtype.Int2
+	Status        string
+	AbandonReason pgtype.Text
 }
@@ -0,0 +1,95 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type SavePlayParams struct {
+	// Play to replace, a new play is logged if not valid
+	ID              pgtype.UUID
+	UserID          pgtype.UUID
+	MediaID         pgtype.UUID
+	PlayedAt        pgtype.Timestamp
+	DurationMinutes pgtype.Int4
+	Location        string
+	Expansions      []string
+	// Players in seat order, they replace the play's previous ones
+	Players []SavePlayPlayerParams
+}
+
+type SavePlayPlayerParams struct {
+	PlayerID   pgtype.UUID
+	PlayerName string
+	Score      pgtype.Int4
+	IsWinner   bool
+}
+
+type SavePlayResult struct {
+	Play    Play
+	Players []PlaysPlayer
+}
+
+// SavePlay logs a play of a boardgame, or replaces one of user's plays (its medium stays), with its players, in a single transaction.
+func (q *Queries) SavePlay(ctx context.Context, arg SavePlayParams) (SavePlayResult, error) {
+	var result SavePlayResult
+	err := q.execTx(ctx, func(qtx *Queries) error {
+		var err error
+		result, err = qtx.savePlay(ctx, arg)
+		return err
+	})
+	return result, err
+}
+
+func (q *Queries) savePlay(ctx context.Context, arg SavePlayParams) (SavePlayResult, error) {
+	var result SavePlayResult
+	var err error
+	if arg.ID.Valid {
+		result.Play, err = q.UpdatePlay(ctx, UpdatePlayParams{
+			ID:              arg.ID,
+			UserID:          arg.UserID,
+			PlayedAt:        arg.PlayedAt,
+			DurationMinutes: arg.DurationMinutes,
+			Location:        arg.Location,
+			Expansions:      arg.Expansions,
+		})
+		if err != nil {
+			return SavePlayResult{}, err
+		}
+		err = q.DeletePlayPlayers(ctx, arg.ID)
+		if err != nil {
+			return SavePlayResult{}, err
+		}
+	} else {
+		result.Play, err = q.CreatePlay(ctx, CreatePlayParams{
+			UserID:          arg.UserID,
+			MediaID:         arg.MediaID,
+			PlayedAt:        arg.PlayedAt,
+			DurationMinutes: arg.DurationMinutes,
+			Location:        arg.Location,
+			Expansions:      arg.Expansions,
+		})
+		if err != nil {
+			return SavePlayResult{}, err
+		}
+	}
+
+	// Seats are numbered from 1, in the given order
+	result.Players = make([]PlaysPlayer, 0, len(arg.Players))
+	for i, player := range arg.Players {
+		created, err := q.CreatePlayPlayer(ctx, CreatePlayPlayerParams{
+			PlayID:     result.Play.ID,
+			Seat:       int32(i + 1),
+			PlayerID:   player.PlayerID,
+			PlayerName: player.PlayerName,
+			Score:      player.Score,
+			IsWinner:   player.IsWinner,
+		})
+		if err != nil {
+			return SavePlayResult{}, err
+		}
+		result.Players = append(result.Players, created)
+	}
+	return result, nil
+}
@@ -11,8 +11,44 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countOtherUsersRecordsByMediumID = `-- name: CountOtherUsersRecordsByMediumID :one
+SELECT count(*) FROM users_media_records
+WHERE media_id = $1
+AND user_id <> $2
+`
+
+type CountOtherUsersRecordsByMediumIDParams struct {
+	MediaID pgtype.UUID
+	UserID  pgtype.UUID
+}
+
+func (q *Queries) CountOtherUsersRecordsByMediumID(ctx context.Context, arg CountOtherUsersRecordsByMediumIDParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countOtherUsersRecordsByMediumID, arg.MediaID, arg.UserID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countUserRecordsByMediumID = `-- name: CountUserRecordsByMediumID :one
+SELECT count(*) FROM users_media_records
+WHERE media_id = $1
+AND user_id = $2
+`
+
+type CountUserRecordsByMediumIDParams struct {
+	MediaID pgtype.UUID
+	UserID  pgtype.UUID
+}
+
+func (q *Queries) CountUserRecordsByMediumID(ctx context.Context, arg CountUserRecordsByMediumIDParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countUserRecordsByMediumID, arg.MediaID, arg.UserID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createUserMediumRecord = `-- name: CreateUserMediumRecord :one
-INSERT INTO users_media_records (id, created_at, updated_at, user_id, media_id, is_finished, start_date, end_date, duration, comments)
+INSERT INTO users_media_records (id, created_at, updated_at, user_id, media_id, is_finished, start_date, end_date, duration, comments, rating, status, abandon_reason)
 VALUES (
     gen_random_uuid(),
     NOW(),
@@ -23,19 +59,25 @@ VALUES (
     $4,
     $5,
     $6,
-    $7
+    $7,
+    $8,
+    $9,
+    $10
 )
-RETURNING id, created_at, updated_at, user_id, media_id, is_finished, start_date, end_date, duration, comments
+RETURNING id, created_at, updated_at, user_id, media_id, is_finished, start_date, end_date, duration, comments, rating, status, abandon_reason
 `
 
 type CreateUserMediumRecordParams struct {
-	UserID     pgtype.UUID
-	MediaID    pgtype.UUID
-	IsFinished pgtype.Bool
-	StartDate  pgtype.Timestamp
-	EndDate    pgtype.Timestamp
-	Duration   pgtype.Interval
-	Comments   string
+	UserID        pgtype.UUID
+	MediaID       pgtype.UUID
+	IsFinished    pgtype.Bool
+	StartDate     pgtype.Timestamp
+	EndDate       pgtype.Timestamp
+	Duration      pgtype.Interval
+	Comments      string
+	Rating        pgtype.Int2
+	Status        string
+	AbandonReason pgtype.Text
 }
 
 func (q *Queries) CreateUserMediumRecord(ctx context.Context, arg CreateUserMediumRecordParams) (UsersMediaRecord, error) {
@@ -47,6 +89,9 @@ func (q *Queries) CreateUserMediumRecord(ctx context.Context, arg CreateUserMedi
 		arg.EndDate,
 		arg.Duration,
 		arg.Comments,
+		arg.Rating,
+		arg.Status,
+		arg.AbandonReason,
 	)
 	var i UsersMediaRecord
 	err := row.Scan(
@@ -60,6 +105,9 @@ func (q *Queries) CreateUserMediumRecord(ctx context.Context, arg CreateUserMedi
 		&i.EndDate,
 		&i.Duration,
 		&i.Comments,
+		&i.Rating,
+		&i.Status,
+		&i.AbandonReason,
 	)
 	return i, err
 }
@@ -67,45 +115,76 @@ func (q *Queries) CreateUserMediumRecord(ctx context.Context, arg CreateUserMedi
 const deleteRecord = `-- name: DeleteRecord :one
 WITH deleted AS (
     DELETE FROM users_media_records
-    WHERE media_id = $1
+    WHERE id = $1
     AND user_id = $2
-    RETURNING id, created_at, updated_at, user_id, media_id, is_finished, start_date, end_date, duration, comments
+    RETURNING id, created_at, updated_at, user_id, media_id, is_finished, start_date, end_date, duration, comments, rating, status, abandon_reason
 )
 SELECT count(*) FROM deleted
 `
 
 type DeleteRecordParams struct {
+	ID     pgtype.UUID
+	UserID pgtype.UUID
+}
+
+func (q *Queries) DeleteRecord(ctx context.Context, arg DeleteRecordParams) (int64, error) {
+	row := q.db.QueryRow(ctx, deleteRecord, arg.ID, arg.UserID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const deleteUserMediumRecords = `-- name: DeleteUserMediumRecords :one
+WITH deleted AS (
+    DELETE FROM users_media_records
+    WHERE media_id = $1
+    AND user_id = $2
+    RETURNING id, created_at, updated_at, user_id, media_id, is_finished, start_date, end_date, duration, comments, rating, status, abandon_reason
+)
+SELECT count(*) FROM deleted
+`
+
+type DeleteUserMediumRecordsParams struct {
 	MediaID pgtype.UUID
 	UserID  pgtype.UUID
 }
 
-func (q *Queries) DeleteRecord(ctx context.Context, arg DeleteRecordParams) (int64, error) {
-	row := q.db.QueryRow(ctx, deleteRecord, arg.MediaID, arg.UserID)
+// Remove a medium from user's shelf, with every consumption of it
+func (q *Queries) DeleteUserMediumRecords(ctx context.Context, arg DeleteUserMediumRecordsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, deleteUserMediumRecords, arg.MediaID, arg.UserID)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
 }
 
-const getDatesFromRecord = `-- name: GetDatesFromRecord :one
-SELECT start_date, end_date
-FROM users_media_records
-WHERE id = $1
+const getMediumRating = `-- name: GetMediumRating :one
+SELECT
+    COALESCE(avg(users_ratings.rating), 0)::float8 AS average,
+    count(*) AS count
+FROM (
+    SELECT avg(rating) AS rating
+    FROM users_media_records
+    WHERE media_id = $1
+    AND rating IS NOT NULL
+    GROUP BY user_id
+) AS users_ratings
 `
 
-type GetDatesFromRecordRow struct {
-	StartDate pgtype.Timestamp
-	EndDate   pgtype.Timestamp
+type GetMediumRatingRow struct {
+	Average float64
+	Count   int64
 }
 
-func (q *Queries) GetDatesFromRecord(ctx context.Context, id pgtype.UUID) (GetDatesFromRecordRow, error) {
-	row := q.db.QueryRow(ctx, getDatesFromRecord, id)
-	var i GetDatesFromRecordRow
-	err := row.Scan(&i.StartDate, &i.EndDate)
+// Average rating of a medium, each user who rated it counting once (with the average of their ratings)
+func (q *Queries) GetMediumRating(ctx context.Context, mediaID pgtype.UUID) (GetMediumRatingRow, error) {
+	row := q.db.QueryRow(ctx, getMediumRating, mediaID)
+	var i GetMediumRatingRow
+	err := row.Scan(&i.Average, &i.Count)
 	return i, err
 }
 
 const getRecordByID = `-- name: GetRecordByID :one
-SELECT id, created_at, updated_at, user_id, media_id, is_finished, start_date, end_date, duration, comments FROM users_media_records
+SELECT id, created_at, updated_at, user_id, media_id, is_finished, start_date, end_date, duration, comments, rating, status, abandon_reason FROM users_media_records
 WHERE id = $1
 `
 
@@ -123,6 +202,9 @@ func (q *Queries) GetRecordByID(ctx context.Context, id pgtype.UUID) (UsersMedia
 		&i.EndDate,
 		&i.Duration,
 		&i.Comments,
+		&i.Rating,
+		&i.Status,
+		&i.AbandonReason,
 	)
 	return i, err
 }
@@ -130,6 +212,8 @@ func (q *Queries) GetRecordByID(ctx context.Context, id pgtype.UUID) (UsersMedia
 const getRecordsAndMediaByUserID = `-- name: GetRecordsAndMediaByUserID :many
 SELECT
     records.id, 
+    records.created_at,
+    records.updated_at,
     records.user_id, 
     records.media_id, 
     records.is_finished, 
@@ -137,33 +221,57 @@ SELECT
     records.end_date, 
     records.duration, 
     records.comments,
+    records.rating,
+    records.status,
+    records.abandon_reason,
     media.media_type,
     media.title,
     media.creator,
     media.pub_date,
     media.image_url,
-    media.metadata
+    media.metadata,
+    COALESCE(ratings.average, 0)::float8 AS rating_average,
+    COALESCE(ratings.count, 0)::bigint AS rating_count
 FROM users_media_records AS records
 INNER JOIN media
 ON records.media_id = media.id
+LEFT JOIN (
+    SELECT users_ratings.media_id, avg(users_ratings.rating) AS average, count(*) AS count
+    FROM (
+        SELECT media_id, user_id, avg(rating) AS rating
+        FROM users_media_records
+        WHERE rating IS NOT NULL
+        GROUP BY media_id, user_id
+    ) AS users_ratings
+    GROUP BY users_ratings.media_id
+) AS ratings
+ON ratings.media_id = media.id
 WHERE records.user_id = $1
+ORDER BY media.title, records.media_id, records.start_date NULLS LAST, records.created_at, records.id
 `
 
 type GetRecordsAndMediaByUserIDRow struct {
-	ID         pgtype.UUID
-	UserID     pgtype.UUID
-	MediaID    pgtype.UUID
-	IsFinished pgtype.Bool
-	StartDate  pgtype.Timestamp
-	EndDate    pgtype.Timestamp
-	Duration   pgtype.Interval
-	Comments   string
-	MediaType  string
-	Title      string
-	Creator    string
-	PubDate    string
-	ImageUrl   string
-	Metadata   []byte
+	ID            pgtype.UUID
+	CreatedAt     pgtype.Timestamp
+	UpdatedAt     pgtype.Timestamp
+	UserID        pgtype.UUID
+	MediaID       pgtype.UUID
+	IsFinished    pgtype.Bool
+	StartDate     pgtype.Timestamp
+	EndDate       pgtype.Timestamp
+	Duration      pgtype.Interval
+	Comments      string
+	Rating        pgtype.Int2
+	Status        string
+	AbandonReason pgtype.Text
+	MediaType     string
+	Title         string
+	Creator       string
+	PubDate       string
+	ImageUrl      string
+	Metadata      []byte
+	RatingAverage float64
+	RatingCount   int64
 }
 
 func (q *Queries) GetRecordsAndMediaByUserID(ctx context.Context, userID pgtype.UUID) ([]GetRecordsAndMediaByUserIDRow, error) {
@@ -177,6 +285,8 @@ func (q *Queries) GetRecordsAndMediaByUserID(ctx context.Context, userID pgtype.
 		var i GetRecordsAndMediaByUserIDRow
 		if err := rows.Scan(
 			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
 			&i.UserID,
 			&i.MediaID,
 			&i.IsFinished,
@@ -184,12 +294,56 @@ func (q *Queries) GetRecordsAndMediaByUserID(ctx context.Context, userID pgtype.
 			&i.EndDate,
 			&i.Duration,
 			&i.Comments,
+			&i.Rating,
+			&i.Status,
+			&i.AbandonReason,
 			&i.MediaType,
 			&i.Title,
 			&i.Creator,
 			&i.PubDate,
 			&i.ImageUrl,
 			&i.Metadata,
+			&i.RatingAverage,
+			&i.RatingCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRecordsByMediumID = `-- name: GetRecordsByMediumID :many
+SELECT id, created_at, updated_at, user_id, media_id, is_finished, start_date, end_date, duration, comments, rating, status, abandon_reason FROM users_media_records
+WHERE media_id = $1
+`
+
+func (q *Queries) GetRecordsByMediumID(ctx context.Context, mediaID pgtype.UUID) ([]UsersMediaRecord, error) {
+	rows, err := q.db.Query(ctx, getRecordsByMediumID, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UsersMediaRecord
+	for rows.Next() {
+		var i UsersMediaRecord
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.UserID,
+			&i.MediaID,
+			&i.IsFinished,
+			&i.StartDate,
+			&i.EndDate,
+			&i.Duration,
+			&i.Comments,
+			&i.Rating,
+			&i.Status,
+			&i.AbandonReason,
 		); err != nil {
 			return nil, err
 		}
@@ -202,7 +356,7 @@ func (q *Queries) GetRecordsAndMediaByUserID(ctx context.Context, userID pgtype.
 }
 
 const getRecordsByUserID = `-- name: GetRecordsByUserID :many
-SELECT id, created_at, updated_at, user_id, media_id, is_finished, start_date, end_date, duration, comments FROM users_media_records
+SELECT id, created_at, updated_at, user_id, media_id, is_finished, start_date, end_date, duration, comments, rating, status, abandon_reason FROM users_media_records
 WHERE user_id = $1
 `
 
@@ -226,6 +380,9 @@ func (q *Queries) GetRecordsByUserID(ctx context.Context, userID pgtype.UUID) ([
 			&i.EndDate,
 			&i.Duration,
 			&i.Comments,
+			&i.Rating,
+			&i.Status,
+			&i.AbandonReason,
 		); err != nil {
 			return nil, err
 		}
@@ -237,6 +394,60 @@ func (q *Queries) GetRecordsByUserID(ctx context.Context, userID pgtype.UUID) ([
 	return items, nil
 }
 
+const getUserRecordByID = `-- name: GetUserRecordByID :one
+SELECT id, created_at, updated_at, user_id, media_id, is_finished, start_date, end_date, duration, comments, rating, status, abandon_reason FROM users_media_records
+WHERE id = $1
+AND user_id = $2
+`
+
+type GetUserRecordByIDParams struct {
+	ID     pgtype.UUID
+	UserID pgtype.UUID
+}
+
+func (q *Queries) GetUserRecordByID(ctx context.Context, arg GetUserRecordByIDParams) (UsersMediaRecord, error) {
+	row := q.db.QueryRow(ctx, getUserRecordByID, arg.ID, arg.UserID)
+	var i UsersMediaRecord
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UserID,
+		&i.MediaID,
+		&i.IsFinished,
+		&i.StartDate,
+		&i.EndDate,
+		&i.Duration,
+		&i.Comments,
+		&i.Rating,
+		&i.Status,
+		&i.AbandonReason,
+	)
+	return i, err
+}
+
+const repointRecordsToMedium = `-- name: RepointRecordsToMedium :one
+WITH moved AS (
+    UPDATE users_media_records
+    SET media_id = $1, updated_at = NOW()
+    WHERE media_id = $2
+    RETURNING id, created_at, updated_at, user_id, media_id, is_finished, start_date, end_date, duration, comments, rating, status, abandon_reason
+)
+SELECT count(*) FROM moved
+`
+
+type RepointRecordsToMediumParams struct {
+	NewMediaID pgtype.UUID
+	OldMediaID pgtype.UUID
+}
+
+func (q *Queries) RepointRecordsToMedium(ctx context.Context, arg RepointRecordsToMediumParams) (int64, error) {
+	row := q.db.QueryRow(ctx, repointRecordsToMedium, arg.NewMediaID, arg.OldMediaID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const resetRecords = `-- name: ResetRecords :exec
 DELETE FROM users_media_records
 `
@@ -248,18 +459,23 @@ func (q *Queries) ResetRecords(ctx context.Context) error {
 
 const updateRecord = `-- name: UpdateRecord :one
 UPDATE users_media_records
-SET is_finished = $2, start_date = $3, end_date = $4, duration = $5, comments = $6, updated_at = NOW()
+SET is_finished = $2, start_date = $3, end_date = $4, duration = $5, comments = $6, rating = $8, status = $9, abandon_reason = $10, updated_at = NOW()
 WHERE id = $1
-RETURNING id, created_at, updated_at, user_id, media_id, is_finished, start_date, end_date, duration, comments
+AND user_id = $7
+RETURNING id, created_at, updated_at, user_id, media_id, is_finished, start_date, end_date, duration, comments, rating, status, abandon_reason
 `
 
 type UpdateRecordParams struct {
-	ID         pgtype.UUID
-	IsFinished pgtype.Bool
-	StartDate  pgtype.Timestamp
-	EndDate    pgtype.Timestamp
-	Duration   pgtype.Interval
-	Comments   string
+	ID            pgtype.UUID
+	IsFinished    pgtype.Bool
+	StartDate     pgtype.Timestamp
+	EndDate       pgtype.Timestamp
+	Duration      pgtype.Interval
+	Comments      string
+	UserID        pgtype.UUID
+	Rating        pgtype.Int2
+	Status        string
+	AbandonReason pgtype.Text
 }
 
 func (q *Queries) UpdateRecord(ctx context.Context, arg UpdateRecordParams) (UsersMediaRecord, error) {
@@ -270,6 +486,10 @@ func (q *Queries) UpdateRecord(ctx context.Context, arg UpdateRecordParams) (Use
 		arg.EndDate,
 		arg.Duration,
 		arg.Comments,
+		arg.UserID,
+		arg.Rating,
+		arg.Status,
+		arg.AbandonReason,
 	)
 	var i UsersMediaRecord
 	err := row.Scan(
@@ -283,6 +503,9 @@ func (q *Queries) UpdateRecord(ctx context.Context, arg UpdateRecordParams) (Use
 		&i.EndDate,
 		&i.Duration,
 		&i.Comments,
+		&i.Rating,
+		&i.Status,
+		&i.AbandonReason,
 	)
 	return i, err
 }
@@ -0,0 +1,682 @@
+package database
+
+// QueryRecords isn't generated by sqlc: its filters and sort order are only known at runtime.
+// The SQL is built from a fixed list of expressions below, every value coming from a request is sent as a parameter.
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Record statuses, stored in users_media_records.status
+const (
+	RecordStatusWishlist   = "wishlist"
+	RecordStatusPlanned    = "planned"
+	RecordStatusInProgress = "in_progress"
+	RecordStatusPaused     = "paused"
+	RecordStatusFinished   = "finished"
+	RecordStatusAbandoned  = "abandoned"
+)
+
+// Every record status, in the order a record usually goes through them
+var RecordStatuses = []string{
+	RecordStatusWishlist,
+	RecordStatusPlanned,
+	RecordStatusInProgress,
+	RecordStatusPaused,
+	RecordStatusFinished,
+	RecordStatusAbandoned,
+}
+
+// Status filter matching records not started yet, wished for or planned
+const RecordStatusUnstarted = "unstarted"
+
+// Conditions of an owned copy, stored in owned_copies.condition (empty when not given)
+const (
+	CopyConditionNew        = "new"
+	CopyConditionLikeNew    = "like_new"
+	CopyConditionVeryGood   = "very_good"
+	CopyConditionGood       = "good"
+	CopyConditionAcceptable = "acceptable"
+	CopyConditionPoor       = "poor"
+)
+
+// Every copy condition, from the best one
+var CopyConditions = []string{
+	CopyConditionNew,
+	CopyConditionLikeNew,
+	CopyConditionVeryGood,
+	CopyConditionGood,
+	CopyConditionAcceptable,
+	CopyConditionPoor,
+}
+
+// Completion levels of a videogame record, stored in records_videogames.completion (empty when not given)
+const (
+	CompletionMainStory     = "main_story"
+	CompletionMainExtras    = "main_extras"
+	CompletionCompletionist = "completionist"
+)
+
+// Every completion level, from the least complete one
+var VideogameCompletions = []string{
+	CompletionMainStory,
+	CompletionMainExtras,
+	CompletionCompletionist,
+}
+
+// Operators available to filter on a metadata key
+const (
+	MetadataOpEq       = "eq"       // value equals, case insensitive
+	MetadataOpContains = "contains" // array holds value (case insensitive) or string contains value
+	MetadataOpLt       = "lt"
+	MetadataOpLte      = "lte"
+	MetadataOpGt       = "gt"
+	MetadataOpGte      = "gte"
+)
+
+// Keys available to sort records on
+const (
+	RecordsSortTitle     = "title"
+	RecordsSortCreator   = "creator"
+	RecordsSortMediaType = "media_type"
+	RecordsSortPubDate   = "pub_date"
+	RecordsSortStartDate = "start_date"
+	RecordsSortEndDate   = "end_date"
+	RecordsSortDuration  = "duration"
+	RecordsSortRating    = "rating"
+	RecordsSortCreatedAt = "created_at"
+	RecordsSortUpdatedAt = "updated_at"
+)
+
+const (
+	QueryRecordsDefaultLimit = 50
+	QueryRecordsMaxLimit     = 200
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+type MetadataFilter struct {
+	Key   string
+	Op    string
+	Value string
+}
+
+type RecordsSort struct {
+	Key  string
+	Desc bool
+}
+
+type QueryRecordsParams struct {
+	UserID     pgtype.UUID
+	MediaTypes []string
+	Status     string
+	// Case insensitive substring
+	Creator     string
+	StartFrom   pgtype.Timestamp
+	StartTo     pgtype.Timestamp
+	EndFrom     pgtype.Timestamp
+	EndTo       pgtype.Timestamp
+	MinDuration pgtype.Int4
+	MaxDuration pgtype.Int4
+	// Normalized ratings (0 to 100), unrated records are left out by these filters
+	MinRating pgtype.Int2
+	MaxRating pgtype.Int2
+	// Case insensitive substring
+	Comments string
+	Metadata []MetadataFilter
+	// Names of user's tags medium must all hold, case insensitive
+	Tags []string
+	// One of user's custom shelves medium must be on
+	ShelfID pgtype.UUID
+	// Media user owns a copy of (true) or doesn't (false)
+	Owned pgtype.Bool
+	// One of user's copies of medium must match all of these, format and language are case insensitive
+	CopyFormat    string
+	CopyCondition string
+	CopyLanguage  string
+	// Videogame records played on this platform (case insensitive) and to this completion level
+	Platform   string
+	Completion string
+	// Records ID is always used as last sort key, so the order is total
+	Sort []RecordsSort
+	// Cursor returned with a previous page, empty for the first page
+	Cursor string
+	// Rows per page, 0 giving QueryRecordsDefaultLimit
+	Limit int32
+}
+
+type QueryRecordsRow struct {
+	ID            pgtype.UUID
+	CreatedAt     pgtype.Timestamp
+	UpdatedAt     pgtype.Timestamp
+	UserID        pgtype.UUID
+	MediaID       pgtype.UUID
+	IsFinished    pgtype.Bool
+	StartDate     pgtype.Timestamp
+	EndDate       pgtype.Timestamp
+	Duration      pgtype.Interval
+	Comments      string
+	Rating        pgtype.Int2
+	Status        string
+	AbandonReason pgtype.Text
+	MediaType     string
+	Title         string
+	Creator       string
+	PubDate       string
+	ImageUrl      string
+	Metadata      []byte
+}
+
+// A sortable expression, never NULL so rows can be compared to a cursor
+type recordsSortKey struct {
+	expr string
+	cast string
+	// Same value computed in Go, as stored in cursors
+	value func(row QueryRecordsRow) string
+	// Compare two values returned by value()
+	compare func(a, b string) int
+}
+
+// Fixed width, so that formatted timestamps sort like times do ("infinity" sorts after digits)
+const cursorTimeFormat = "2006-01-02T15:04:05.000000"
+
+// Sentinel sorting NULL durations last, like 'infinity' for timestamps
+const nullDurationSortValue = 2147483647
+
+// Sentinel sorting unrated records last, ratings being at most 100
+const nullRatingSortValue = 101
+
+// Compare two values of a "bigint" sort key
+func compareIntSortValues(a, b string) int {
+	x, _ := strconv.ParseInt(a, 10, 64)
+	y, _ := strconv.ParseInt(b, 10, 64)
+	return int(x - y)
+}
+
+func textSortKey(column string, value func(row QueryRecordsRow) string) recordsSortKey {
+	return recordsSortKey{
+		expr:    fmt.Sprintf(`lower(%s) COLLATE "C"`, column),
+		cast:    "text",
+		value:   func(row QueryRecordsRow) string { return strings.ToLower(value(row)) },
+		compare: strings.Compare,
+	}
+}
+
+func timestampSortKey(column string, value func(row QueryRecordsRow) pgtype.Timestamp) recordsSortKey {
+	return recordsSortKey{
+		expr: fmt.Sprintf("COALESCE(%s, 'infinity')", column),
+		cast: "timestamp",
+		value: func(row QueryRecordsRow) string {
+			timestamp := value(row)
+			if !timestamp.Valid {
+				return "infinity"
+			}
+			return timestamp.Time.UTC().Format(cursorTimeFormat)
+		},
+		compare: strings.Compare,
+	}
+}
+
+var recordsSortKeys = map[string]recordsSortKey{
+	RecordsSortTitle:     textSortKey("media.title", func(row QueryRecordsRow) string { return row.Title }),
+	RecordsSortCreator:   textSortKey("media.creator", func(row QueryRecordsRow) string { return row.Creator }),
+	RecordsSortMediaType: textSortKey("media.media_type", func(row QueryRecordsRow) string { return row.MediaType }),
+	RecordsSortPubDate:   textSortKey("media.pub_date", func(row QueryRecordsRow) string { return row.PubDate }),
+	RecordsSortStartDate: timestampSortKey("records.start_date", func(row QueryRecordsRow) pgtype.Timestamp { return row.StartDate }),
+	RecordsSortEndDate:   timestampSortKey("records.end_date", func(row QueryRecordsRow) pgtype.Timestamp { return row.EndDate }),
+	RecordsSortCreatedAt: timestampSortKey("records.created_at", func(row QueryRecordsRow) pgtype.Timestamp { return row.CreatedAt }),
+	RecordsSortUpdatedAt: timestampSortKey("records.updated_at", func(row QueryRecordsRow) pgtype.Timestamp { return row.UpdatedAt }),
+	RecordsSortDuration: {
+		expr: fmt.Sprintf("COALESCE(EXTRACT(DAY FROM records.duration)::bigint, %d)", nullDurationSortValue),
+		cast: "bigint",
+		value: func(row QueryRecordsRow) string {
+			if !row.Duration.Valid {
+				return strconv.Itoa(nullDurationSortValue)
+			}
+			return strconv.Itoa(int(row.Duration.Days))
+		},
+		compare: compareIntSortValues,
+	},
+	RecordsSortRating: {
+		expr: fmt.Sprintf("COALESCE(records.rating::bigint, %d)", nullRatingSortValue),
+		cast: "bigint",
+		value: func(row QueryRecordsRow) string {
+			if !row.Rating.Valid {
+				return strconv.Itoa(nullRatingSortValue)
+			}
+			return strconv.Itoa(int(row.Rating.Int16))
+		},
+		compare: compareIntSortValues,
+	},
+}
+
+// Last sort key, always ascending
+var recordsIDSortKey = recordsSortKey{
+	expr: "records.id",
+	cast: "uuid",
+	value: func(row QueryRecordsRow) string {
+		return row.ID.String()
+	},
+	// Canonical lowercase hex form sorts like UUID bytes do
+	compare: strings.Compare,
+}
+
+var metadataKeyRegex = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// Validate checks filters and sort keys, it should be called before QueryRecords
+func (arg *QueryRecordsParams) Validate() error {
+	if arg.Status != "" && arg.Status != RecordStatusUnstarted && !slices.Contains(RecordStatuses, arg.Status) {
+		return fmt.Errorf("unknown status %q", arg.Status)
+	}
+	for _, filter := range arg.Metadata {
+		if !metadataKeyRegex.MatchString(filter.Key) {
+			return fmt.Errorf("invalid metadata key %q", filter.Key)
+		}
+		switch filter.Op {
+		case MetadataOpEq, MetadataOpContains:
+		case MetadataOpLt, MetadataOpLte, MetadataOpGt, MetadataOpGte:
+			if _, err := strconv.ParseFloat(filter.Value, 64); err != nil {
+				return fmt.Errorf("metadata filter on %q: %q is not a number", filter.Key, filter.Value)
+			}
+		default:
+			return fmt.Errorf("unknown metadata operator %q", filter.Op)
+		}
+	}
+	for _, tag := range arg.Tags {
+		if strings.TrimSpace(tag) == "" {
+			return errors.New("tag names can't be empty")
+		}
+	}
+	if arg.CopyCondition != "" && !slices.Contains(CopyConditions, arg.CopyCondition) {
+		return fmt.Errorf("unknown copy condition %q", arg.CopyCondition)
+	}
+	if arg.Owned.Valid && !arg.Owned.Bool && arg.hasCopyFilters() {
+		return errors.New("copy filters can't be used to find media not owned")
+	}
+	if arg.Completion != "" && !slices.Contains(VideogameCompletions, arg.Completion) {
+		return fmt.Errorf("unknown completion %q", arg.Completion)
+	}
+	seen := make(map[string]bool)
+	for _, sort := range arg.Sort {
+		if _, ok := recordsSortKeys[sort.Key]; !ok {
+			return fmt.Errorf("unknown sort key %q", sort.Key)
+		}
+		if seen[sort.Key] {
+			return fmt.Errorf("sort key %q is given twice", sort.Key)
+		}
+		seen[sort.Key] = true
+	}
+	if arg.Limit < 0 || arg.Limit > QueryRecordsMaxLimit {
+		return fmt.Errorf("limit must be between 1 and %d, or 0 for the default %d", QueryRecordsMaxLimit, QueryRecordsDefaultLimit)
+	}
+	if arg.Cursor != "" {
+		if _, err := arg.decodeCursor(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Tells if records are filtered on their medium's copies details
+func (arg *QueryRecordsParams) hasCopyFilters() bool {
+	return arg.CopyFormat != "" || arg.CopyCondition != "" || arg.CopyLanguage != ""
+}
+
+// PageLimit returns the number of rows per page
+func (arg *QueryRecordsParams) PageLimit() int {
+	if arg.Limit == 0 {
+		return QueryRecordsDefaultLimit
+	}
+	return int(arg.Limit)
+}
+
+// Sort keys in use, ending with the records ID
+func (arg *QueryRecordsParams) sortKeys() ([]recordsSortKey, []bool) {
+	sorts := arg.Sort
+	if len(sorts) == 0 {
+		sorts = []RecordsSort{{Key: RecordsSortTitle}}
+	}
+	keys := make([]recordsSortKey, 0, len(sorts)+1)
+	desc := make([]bool, 0, len(sorts)+1)
+	for _, sort := range sorts {
+		keys = append(keys, recordsSortKeys[sort.Key])
+		desc = append(desc, sort.Desc)
+	}
+	keys = append(keys, recordsIDSortKey)
+	desc = append(desc, false)
+	return keys, desc
+}
+
+// Identifies the sort order a cursor was built for
+func (arg *QueryRecordsParams) sortSignature() string {
+	var parts []string
+	for _, sort := range arg.Sort {
+		parts = append(parts, fmt.Sprintf("%s:%t", sort.Key, sort.Desc))
+	}
+	return strings.Join(parts, ",")
+}
+
+type recordsCursor struct {
+	Sort   string   `json:"s"`
+	Values []string `json:"v"`
+}
+
+// CursorAfter returns the cursor pointing after given row, for the same params
+func (arg *QueryRecordsParams) CursorAfter(row QueryRecordsRow) string {
+	keys, _ := arg.sortKeys()
+	cursor := recordsCursor{Sort: arg.sortSignature()}
+	for _, key := range keys {
+		cursor.Values = append(cursor.Values, key.value(row))
+	}
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func (arg *QueryRecordsParams) decodeCursor() ([]string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(arg.Cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor recordsCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	keys, _ := arg.sortKeys()
+	if cursor.Sort != arg.sortSignature() || len(cursor.Values) != len(keys) {
+		return nil, fmt.Errorf("%w: it was made for another sort order", ErrInvalidCursor)
+	}
+	for i, key := range keys {
+		switch key.cast {
+		case "timestamp":
+			if _, err := time.Parse(cursorTimeFormat, cursor.Values[i]); err != nil && cursor.Values[i] != "infinity" {
+				return nil, ErrInvalidCursor
+			}
+		case "bigint":
+			if _, err := strconv.ParseInt(cursor.Values[i], 10, 64); err != nil {
+				return nil, ErrInvalidCursor
+			}
+		case "uuid":
+			var id pgtype.UUID
+			if err := id.Scan(cursor.Values[i]); err != nil {
+				return nil, ErrInvalidCursor
+			}
+		}
+	}
+	return cursor.Values, nil
+}
+
+// CompareRows orders two rows like QueryRecords does
+func (arg *QueryRecordsParams) CompareRows(a, b QueryRecordsRow) int {
+	keys, desc := arg.sortKeys()
+	for i, key := range keys {
+		c := key.compare(key.value(a), key.value(b))
+		if desc[i] {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// IsAfterCursor tells if a row belongs to the page following params' cursor
+func (arg *QueryRecordsParams) IsAfterCursor(row QueryRecordsRow) bool {
+	if arg.Cursor == "" {
+		return true
+	}
+	values, err := arg.decodeCursor()
+	if err != nil {
+		return false
+	}
+	keys, desc := arg.sortKeys()
+	for i, key := range keys {
+		c := key.compare(key.value(row), values[i])
+		if desc[i] {
+			c = -c
+		}
+		if c != 0 {
+			return c > 0
+		}
+	}
+	return false
+}
+
+// Escape LIKE wildcards in a user given string
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// Build the SQL query and its arguments
+func (arg *QueryRecordsParams) build() (string, []interface{}, error) {
+	var conditions []string
+	var args []interface{}
+	param := func(value interface{}, cast string) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d::%s", len(args), cast)
+	}
+
+	conditions = append(conditions, "records.user_id = "+param(arg.UserID, "uuid"))
+	if len(arg.MediaTypes) > 0 {
+		lowered := make([]string, len(arg.MediaTypes))
+		for i, mediaType := range arg.MediaTypes {
+			lowered[i] = strings.ToLower(mediaType)
+		}
+		conditions = append(conditions, "lower(media.media_type) = ANY("+param(lowered, "text[]")+")")
+	}
+	switch arg.Status {
+	case "":
+	case RecordStatusUnstarted:
+		conditions = append(conditions, "records.status = ANY("+param([]string{RecordStatusWishlist, RecordStatusPlanned}, "text[]")+")")
+	default:
+		conditions = append(conditions, "records.status = "+param(arg.Status, "text"))
+	}
+	if arg.Creator != "" {
+		conditions = append(conditions, "media.creator ILIKE '%' || "+param(escapeLike(arg.Creator), "text")+" || '%'")
+	}
+	if arg.StartFrom.Valid {
+		conditions = append(conditions, "records.start_date >= "+param(arg.StartFrom, "timestamp"))
+	}
+	if arg.StartTo.Valid {
+		conditions = append(conditions, "records.start_date <= "+param(arg.StartTo, "timestamp"))
+	}
+	if arg.EndFrom.Valid {
+		conditions = append(conditions, "records.end_date >= "+param(arg.EndFrom, "timestamp"))
+	}
+	if arg.EndTo.Valid {
+		conditions = append(conditions, "records.end_date <= "+param(arg.EndTo, "timestamp"))
+	}
+	if arg.MinDuration.Valid {
+		conditions = append(conditions, "EXTRACT(DAY FROM records.duration) >= "+param(arg.MinDuration.Int32, "int"))
+	}
+	if arg.MaxDuration.Valid {
+		conditions = append(conditions, "EXTRACT(DAY FROM records.duration) <= "+param(arg.MaxDuration.Int32, "int"))
+	}
+	if arg.MinRating.Valid {
+		conditions = append(conditions, "records.rating >= "+param(arg.MinRating.Int16, "int"))
+	}
+	if arg.MaxRating.Valid {
+		conditions = append(conditions, "records.rating <= "+param(arg.MaxRating.Int16, "int"))
+	}
+	if arg.Comments != "" {
+		conditions = append(conditions, "records.comments ILIKE '%' || "+param(escapeLike(arg.Comments), "text")+" || '%'")
+	}
+	for _, tag := range arg.Tags {
+		conditions = append(conditions, `EXISTS (SELECT 1 FROM media_tags INNER JOIN tags ON media_tags.tag_id = tags.id
+			WHERE media_tags.media_id = records.media_id AND tags.user_id = records.user_id AND lower(tags.name) = lower(`+param(strings.TrimSpace(tag), "text")+`))`)
+	}
+	if arg.ShelfID.Valid {
+		conditions = append(conditions, `EXISTS (SELECT 1 FROM shelves_media INNER JOIN shelves ON shelves_media.shelf_id = shelves.id
+			WHERE shelves_media.media_id = records.media_id AND shelves.user_id = records.user_id AND shelves.id = `+param(arg.ShelfID, "uuid")+`)`)
+	}
+	if arg.Owned.Valid || arg.hasCopyFilters() {
+		copyConditions := []string{"owned_copies.media_id = records.media_id", "owned_copies.user_id = records.user_id"}
+		if arg.CopyFormat != "" {
+			copyConditions = append(copyConditions, "lower(owned_copies.format) = lower("+param(strings.TrimSpace(arg.CopyFormat), "text")+")")
+		}
+		if arg.CopyCondition != "" {
+			copyConditions = append(copyConditions, "owned_copies.condition = "+param(arg.CopyCondition, "text"))
+		}
+		if arg.CopyLanguage != "" {
+			copyConditions = append(copyConditions, "lower(owned_copies.language) = lower("+param(strings.TrimSpace(arg.CopyLanguage), "text")+")")
+		}
+		exists := "EXISTS (SELECT 1 FROM owned_copies WHERE " + strings.Join(copyConditions, " AND ") + ")"
+		if arg.Owned.Valid && !arg.Owned.Bool {
+			exists = "NOT " + exists
+		}
+		conditions = append(conditions, exists)
+	}
+	if arg.Platform != "" || arg.Completion != "" {
+		videogameConditions := []string{"records_videogames.record_id = records.id"}
+		if arg.Platform != "" {
+			videogameConditions = append(videogameConditions, "lower(records_videogames.platform) = lower("+param(strings.TrimSpace(arg.Platform), "text")+")")
+		}
+		if arg.Completion != "" {
+			videogameConditions = append(videogameConditions, "records_videogames.completion = "+param(arg.Completion, "text"))
+		}
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM records_videogames WHERE "+strings.Join(videogameConditions, " AND ")+")")
+	}
+
+	for _, filter := range arg.Metadata {
+		key := param(filter.Key, "text")
+		switch filter.Op {
+		case MetadataOpEq:
+			conditions = append(conditions, fmt.Sprintf("lower(media.metadata ->> %s) = lower(%s)", key, param(filter.Value, "text")))
+		case MetadataOpContains:
+			value := param(filter.Value, "text")
+			conditions = append(conditions, fmt.Sprintf(`CASE jsonb_typeof(media.metadata -> %[1]s)
+			WHEN 'array' THEN EXISTS (SELECT 1 FROM jsonb_array_elements_text(media.metadata -> %[1]s) AS elem WHERE lower(elem) = lower(%[2]s))
+			WHEN 'string' THEN (media.metadata ->> %[1]s) ILIKE '%%' || %[3]s || '%%'
+			ELSE false END`, key, value, param(escapeLike(filter.Value), "text")))
+		default:
+			operators := map[string]string{MetadataOpLt: "<", MetadataOpLte: "<=", MetadataOpGt: ">", MetadataOpGte: ">="}
+			// Numbers can be stored as JSON numbers or numeric strings
+			conditions = append(conditions, fmt.Sprintf(`CASE WHEN trim(media.metadata ->> %[1]s) ~ '^-?[0-9]+(\.[0-9]+)?$'
+			THEN trim(media.metadata ->> %[1]s)::numeric %[2]s %[3]s
+			ELSE false END`, key, operators[filter.Op], param(filter.Value, "numeric")))
+		}
+	}
+
+	// Keyset pagination: (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ...
+	keys, desc := arg.sortKeys()
+	if arg.Cursor != "" {
+		values, err := arg.decodeCursor()
+		if err != nil {
+			return "", nil, err
+		}
+		var alternatives []string
+		for i := range keys {
+			var terms []string
+			for j := 0; j < i; j++ {
+				terms = append(terms, fmt.Sprintf("%s = %s", keys[j].expr, param(values[j], keys[j].cast)))
+			}
+			operator := ">"
+			if desc[i] {
+				operator = "<"
+			}
+			terms = append(terms, fmt.Sprintf("%s %s %s", keys[i].expr, operator, param(values[i], keys[i].cast)))
+			alternatives = append(alternatives, "("+strings.Join(terms, " AND ")+")")
+		}
+		conditions = append(conditions, "("+strings.Join(alternatives, "\n    OR ")+")")
+	}
+
+	var orderBy []string
+	for i, key := range keys {
+		direction := "ASC"
+		if desc[i] {
+			direction = "DESC"
+		}
+		orderBy = append(orderBy, key.expr+" "+direction)
+	}
+
+	query := queryRecordsSelect +
+		"WHERE " + strings.Join(conditions, "\nAND ") +
+		"\nORDER BY " + strings.Join(orderBy, ", ") +
+		"\nLIMIT " + param(arg.PageLimit(), "int")
+	return query, args, nil
+}
+
+const queryRecordsSelect = `SELECT
+    records.id,
+    records.created_at,
+    records.updated_at,
+    records.user_id,
+    records.media_id,
+    records.is_finished,
+    records.start_date,
+    records.end_date,
+    records.duration,
+    records.comments,
+    records.rating,
+    records.status,
+    records.abandon_reason,
+    media.media_type,
+    media.title,
+    media.creator,
+    media.pub_date,
+    media.image_url,
+    media.metadata
+FROM users_media_records AS records
+INNER JOIN media
+ON records.media_id = media.id
+`
+
+// QueryRecords returns one page of a user's records joined with their medium,
+// filtered and sorted as asked in params
+func (q *Queries) QueryRecords(ctx context.Context, arg QueryRecordsParams) ([]QueryRecordsRow, error) {
+	if err := arg.Validate(); err != nil {
+		return nil, err
+	}
+	query, args, err := arg.build()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []QueryRecordsRow
+	for rows.Next() {
+		var i QueryRecordsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.UserID,
+			&i.MediaID,
+			&i.IsFinished,
+			&i.StartDate,
+			&i.EndDate,
+			&i.Duration,
+			&i.Comments,
+			&i.Rating,
+			&i.Status,
+			&i.AbandonReason,
+			&i.MediaType,
+			&i.Title,
+			&i.Creator,
+			&i.PubDate,
+			&i.ImageUrl,
+			&i.Metadata,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,70 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type SaveRecordUpdateParams struct {
+	Record UpdateRecordParams
+	// Opens a pause at this time if valid, when record is put on hold
+	PausedAt pgtype.Timestamp
+	// Closes record's open pause at this time if valid, when record is picked up again
+	ResumedAt pgtype.Timestamp
+	// Entry appended to record's progress log, if any
+	Progress *CreateRecordProgressParams
+}
+
+type SaveRecordUpdateResult struct {
+	Record   UsersMediaRecord
+	Progress *RecordsProgress
+}
+
+// SaveRecordUpdate updates one of user's records, opens or closes its pause and logs its progress, in a single transaction.
+func (q *Queries) SaveRecordUpdate(ctx context.Context, arg SaveRecordUpdateParams) (SaveRecordUpdateResult, error) {
+	var result SaveRecordUpdateResult
+	err := q.execTx(ctx, func(qtx *Queries) error {
+		var err error
+		result, err = qtx.saveRecordUpdate(ctx, arg)
+		return err
+	})
+	return result, err
+}
+
+func (q *Queries) saveRecordUpdate(ctx context.Context, arg SaveRecordUpdateParams) (SaveRecordUpdateResult, error) {
+	var result SaveRecordUpdateResult
+	var err error
+	result.Record, err = q.UpdateRecord(ctx, arg.Record)
+	if err != nil {
+		return SaveRecordUpdateResult{}, err
+	}
+
+	if arg.PausedAt.Valid {
+		_, err = q.CreateRecordPause(ctx, CreateRecordPauseParams{
+			RecordID: result.Record.ID,
+			PausedAt: arg.PausedAt,
+		})
+		if err != nil {
+			return SaveRecordUpdateResult{}, err
+		}
+	}
+	if arg.ResumedAt.Valid {
+		err = q.ResumeRecordPause(ctx, ResumeRecordPauseParams{
+			ResumedAt: arg.ResumedAt,
+			RecordID:  result.Record.ID,
+		})
+		if err != nil {
+			return SaveRecordUpdateResult{}, err
+		}
+	}
+
+	if arg.Progress != nil {
+		entry, err := q.CreateRecordProgress(ctx, *arg.Progress)
+		if err != nil {
+			return SaveRecordUpdateResult{}, err
+		}
+		result.Progress = &entry
+	}
+	return result, nil
+}
@@ -0,0 +1,185 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Store lists every query the server can run against its storage.
+// *Queries implements it on top of PostgreSQL, the memstore package implements it in memory.
+type Store interface {
+	// Users
+	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
+	GetUserByUsername(ctx context.Context, username string) (User, error)
+	GetUserByEmail(ctx context.Context, email string) (User, error)
+	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdatePassword(ctx context.Context, arg UpdatePasswordParams) (User, error)
+	DeleteUser(ctx context.Context, id pgtype.UUID) (int64, error)
+	ResetUsers(ctx context.Context) error
+	SearchUsers(ctx context.Context, arg SearchUsersParams) ([]User, error)
+	UpdateUserRoleByUsername(ctx context.Context, arg UpdateUserRoleByUsernameParams) (User, error)
+	UpdateUserDeactivatedAt(ctx context.Context, arg UpdateUserDeactivatedAtParams) (User, error)
+
+	// Refresh tokens
+	CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) (RefreshToken, error)
+	GetRefreshToken(ctx context.Context, token string) (RefreshToken, error)
+	RevokeRefreshToken(ctx context.Context, token string) (int64, error)
+	RevokeAllRefreshTokensByUserID(ctx context.Context, userID pgtype.UUID) (int64, error)
+	DeleteRevokedTokens(ctx context.Context) error
+
+	// Password reset tokens
+	StorePasswordToken(ctx context.Context, arg StorePasswordTokenParams) (PasswordResetToken, error)
+	GetPasswordResetToken(ctx context.Context, token string) (PasswordResetToken, error)
+	InvalidateResetTokensByUserId(ctx context.Context, userID pgtype.UUID) error
+	ResetPasswordResetTable(ctx context.Context) error
+
+	// Media
+	CreateMedium(ctx context.Context, arg CreateMediumParams) (Medium, error)
+	UpdateMedium(ctx context.Context, arg UpdateMediumParams) (Medium, error)
+	GetMediaByTitleAndType(ctx context.Context, arg GetMediaByTitleAndTypeParams) ([]Medium, error)
+	GetMediaByExternalIDs(ctx context.Context, externalIds []byte) ([]Medium, error)
+	GetMediaByType(ctx context.Context, lower string) ([]Medium, error)
+	GetMediumByID(ctx context.Context, id pgtype.UUID) (Medium, error)
+	DeleteMedium(ctx context.Context, id pgtype.UUID) (int64, error)
+	GetMediumRedirect(ctx context.Context, oldID pgtype.UUID) (pgtype.UUID, error)
+	MergeMedia(ctx context.Context, arg MergeMediaParams) (MergeMediaResult, error)
+	DeleteMediumForUser(ctx context.Context, arg DeleteMediumForUserParams) (DeleteMediumForUserResult, error)
+	ResetMedia(ctx context.Context) error
+
+	// Records
+	CreateUserMediumRecord(ctx context.Context, arg CreateUserMediumRecordParams) (UsersMediaRecord, error)
+	GetRecordsByUserID(ctx context.Context, userID pgtype.UUID) ([]UsersMediaRecord, error)
+	GetRecordsAndMediaByUserID(ctx context.Context, userID pgtype.UUID) ([]GetRecordsAndMediaByUserIDRow, error)
+	QueryRecords(ctx context.Context, arg QueryRecordsParams) ([]QueryRecordsRow, error)
+	GetRecordByID(ctx context.Context, id pgtype.UUID) (UsersMediaRecord, error)
+	GetUserRecordByID(ctx context.Context, arg GetUserRecordByIDParams) (UsersMediaRecord, error)
+	UpdateRecord(ctx context.Context, arg UpdateRecordParams) (UsersMediaRecord, error)
+	SaveRecordUpdate(ctx context.Context, arg SaveRecordUpdateParams) (SaveRecordUpdateResult, error)
+	DeleteRecord(ctx context.Context, arg DeleteRecordParams) (int64, error)
+	CountUserRecordsByMediumID(ctx context.Context, arg CountUserRecordsByMediumIDParams) (int64, error)
+	GetMediumRating(ctx context.Context, mediaID pgtype.UUID) (GetMediumRatingRow, error)
+	ResetRecords(ctx context.Context) error
+
+	// Progress
+	CreateRecordProgress(ctx context.Context, arg CreateRecordProgressParams) (RecordsProgress, error)
+	GetRecordProgress(ctx context.Context, recordID pgtype.UUID) ([]RecordsProgress, error)
+
+	// Videogames
+	UpsertRecordVideogame(ctx context.Context, arg UpsertRecordVideogameParams) (RecordsVideogame, error)
+	GetRecordVideogame(ctx context.Context, recordID pgtype.UUID) (RecordsVideogame, error)
+	GetVideogameRecordsByUserID(ctx context.Context, userID pgtype.UUID) ([]GetVideogameRecordsByUserIDRow, error)
+
+	// Episodes
+	MarkRecordEpisode(ctx context.Context, arg MarkRecordEpisodeParams) (RecordsEpisode, error)
+	UnmarkRecordEpisode(ctx context.Context, arg UnmarkRecordEpisodeParams) (int64, error)
+	GetRecordEpisodes(ctx context.Context, recordID pgtype.UUID) ([]RecordsEpisode, error)
+	GetEpisodesByUserID(ctx context.Context, userID pgtype.UUID) ([]RecordsEpisode, error)
+	GetSeriesRecordsByUserID(ctx context.Context, userID pgtype.UUID) ([]GetSeriesRecordsByUserIDRow, error)
+
+	// Pauses
+	CreateRecordPause(ctx context.Context, arg CreateRecordPauseParams) (RecordsPause, error)
+	GetRecordPauses(ctx context.Context, recordID pgtype.UUID) ([]RecordsPause, error)
+	ResumeRecordPause(ctx context.Context, arg ResumeRecordPauseParams) error
+
+	// Reviews
+	CreateReview(ctx context.Context, arg CreateReviewParams) (Review, error)
+	GetReviewByRecordID(ctx context.Context, recordID pgtype.UUID) (Review, error)
+	UpdateReview(ctx context.Context, arg UpdateReviewParams) (Review, error)
+	DeleteReview(ctx context.Context, recordID pgtype.UUID) (int64, error)
+	CreateReviewRevision(ctx context.Context, arg CreateReviewRevisionParams) (ReviewsRevision, error)
+	GetReviewRevisions(ctx context.Context, reviewID pgtype.UUID) ([]ReviewsRevision, error)
+	GetPublishedMediumReviews(ctx context.Context, mediaID pgtype.UUID) ([]GetPublishedMediumReviewsRow, error)
+
+	// Loans
+	CreateLoan(ctx context.Context, arg CreateLoanParams) (Loan, error)
+	GetUserLoanByID(ctx context.Context, arg GetUserLoanByIDParams) (Loan, error)
+	GetOpenLoansByUserID(ctx context.Context, ownerID pgtype.UUID) ([]GetOpenLoansByUserIDRow, error)
+	GetOverdueLoansByUserID(ctx context.Context, ownerID pgtype.UUID) ([]GetOverdueLoansByUserIDRow, error)
+	GetMediumLoans(ctx context.Context, arg GetMediumLoansParams) ([]Loan, error)
+	UpdateLoan(ctx context.Context, arg UpdateLoanParams) (Loan, error)
+	DeleteLoan(ctx context.Context, arg DeleteLoanParams) (int64, error)
+
+	// Owned copies
+	CreateOwnedCopy(ctx context.Context, arg CreateOwnedCopyParams) (OwnedCopy, error)
+	GetUserOwnedCopyByID(ctx context.Context, arg GetUserOwnedCopyByIDParams) (OwnedCopy, error)
+	GetOwnedCopiesByUserID(ctx context.Context, userID pgtype.UUID) ([]GetOwnedCopiesByUserIDRow, error)
+	GetMediumOwnedCopies(ctx context.Context, arg GetMediumOwnedCopiesParams) ([]OwnedCopy, error)
+	UpdateOwnedCopy(ctx context.Context, arg UpdateOwnedCopyParams) (OwnedCopy, error)
+	DeleteOwnedCopy(ctx context.Context, arg DeleteOwnedCopyParams) (int64, error)
+	GetCollectionValueByUserID(ctx context.Context, userID pgtype.UUID) ([]GetCollectionValueByUserIDRow, error)
+
+	// Shares
+	CreateShare(ctx context.Context, arg CreateShareParams) (Share, error)
+	GetShareByID(ctx context.Context, id pgtype.UUID) (Share, error)
+	GetSharesByUserID(ctx context.Context, ownerID pgtype.UUID) ([]GetSharesByUserIDRow, error)
+	GetSharedMediaRecords(ctx context.Context, arg GetSharedMediaRecordsParams) ([]GetSharedMediaRecordsRow, error)
+	DeleteShare(ctx context.Context, arg DeleteShareParams) (int64, error)
+
+	// Tags
+	CreateTag(ctx context.Context, arg CreateTagParams) (Tag, error)
+	GetTagsByUserID(ctx context.Context, userID pgtype.UUID) ([]GetTagsByUserIDRow, error)
+	GetUserTagByID(ctx context.Context, arg GetUserTagByIDParams) (Tag, error)
+	UpdateTag(ctx context.Context, arg UpdateTagParams) (Tag, error)
+	DeleteTag(ctx context.Context, arg DeleteTagParams) (int64, error)
+	TagMedia(ctx context.Context, arg TagMediaParams) (int64, error)
+	UntagMedia(ctx context.Context, arg UntagMediaParams) (int64, error)
+	GetUserMediaTags(ctx context.Context, userID pgtype.UUID) ([]GetUserMediaTagsRow, error)
+
+	// Shelves
+	CreateShelf(ctx context.Context, arg CreateShelfParams) (Shelf, error)
+	GetShelvesByUserID(ctx context.Context, userID pgtype.UUID) ([]GetShelvesByUserIDRow, error)
+	GetUserShelfByID(ctx context.Context, arg GetUserShelfByIDParams) (Shelf, error)
+	UpdateShelf(ctx context.Context, arg UpdateShelfParams) (Shelf, error)
+	DeleteShelf(ctx context.Context, arg DeleteShelfParams) (int64, error)
+	AddMediaToShelf(ctx context.Context, arg AddMediaToShelfParams) (int64, error)
+	RemoveMediaFromShelf(ctx context.Context, arg RemoveMediaFromShelfParams) (int64, error)
+	GetUserShelvesMedia(ctx context.Context, userID pgtype.UUID) ([]GetUserShelvesMediaRow, error)
+
+	// Shelving units
+	CreateShelvingUnit(ctx context.Context, arg CreateShelvingUnitParams) (ShelvingUnit, error)
+	GetShelvingUnitsByUserID(ctx context.Context, userID pgtype.UUID) ([]GetShelvingUnitsByUserIDRow, error)
+	GetUserShelvingUnitByID(ctx context.Context, arg GetUserShelvingUnitByIDParams) (ShelvingUnit, error)
+	UpdateShelvingUnit(ctx context.Context, arg UpdateShelvingUnitParams) (ShelvingUnit, error)
+	DeleteShelvingUnit(ctx context.Context, arg DeleteShelvingUnitParams) (int64, error)
+	CountShelvingItemsOutsideGrid(ctx context.Context, arg CountShelvingItemsOutsideGridParams) (int64, error)
+	PlaceShelvingItem(ctx context.Context, arg PlaceShelvingItemParams) (ShelvingItem, error)
+	GetShelvingUnitItems(ctx context.Context, unitID pgtype.UUID) ([]GetShelvingUnitItemsRow, error)
+	GetUserMediumShelvingItem(ctx context.Context, arg GetUserMediumShelvingItemParams) (GetUserMediumShelvingItemRow, error)
+	FindShelvingItemsByTitle(ctx context.Context, arg FindShelvingItemsByTitleParams) ([]FindShelvingItemsByTitleRow, error)
+	RemoveShelvingItem(ctx context.Context, arg RemoveShelvingItemParams) (int64, error)
+
+	// Boardgame plays
+	SavePlay(ctx context.Context, arg SavePlayParams) (SavePlayResult, error)
+	GetUserPlayByID(ctx context.Context, arg GetUserPlayByIDParams) (Play, error)
+	GetPlaysByUserID(ctx context.Context, userID pgtype.UUID) ([]GetPlaysByUserIDRow, error)
+	GetPlayersByUserID(ctx context.Context, userID pgtype.UUID) ([]PlaysPlayer, error)
+	DeletePlay(ctx context.Context, arg DeletePlayParams) (int64, error)
+
+	// Goals
+	SaveGoal(ctx context.Context, arg SaveGoalParams) (SaveGoalResult, error)
+	GetUserGoalByID(ctx context.Context, arg GetUserGoalByIDParams) (Goal, error)
+	GetGoalsByUserID(ctx context.Context, userID pgtype.UUID) ([]Goal, error)
+	GetGoalTagsByUserID(ctx context.Context, userID pgtype.UUID) ([]GetGoalTagsByUserIDRow, error)
+	DeleteGoal(ctx context.Context, arg DeleteGoalParams) (int64, error)
+	GetGoalRecords(ctx context.Context, arg GetGoalRecordsParams) ([]GetGoalRecordsRow, error)
+	GetGoalRecordsByUserID(ctx context.Context, userID pgtype.UUID) ([]GetGoalRecordsByUserIDRow, error)
+	GetActivityDays(ctx context.Context, arg GetActivityDaysParams) ([]pgtype.Timestamp, error)
+
+	// Admin
+	GetInstanceCounts(ctx context.Context) (GetInstanceCountsRow, error)
+	CountMediaByType(ctx context.Context) ([]CountMediaByTypeRow, error)
+
+	// Stats
+	GetStatsTotals(ctx context.Context, arg GetStatsTotalsParams) (GetStatsTotalsRow, error)
+	GetStatsByMediaType(ctx context.Context, arg GetStatsByMediaTypeParams) ([]GetStatsByMediaTypeRow, error)
+	GetLongestCompletions(ctx context.Context, arg GetLongestCompletionsParams) ([]GetLongestCompletionsRow, error)
+	GetShortestCompletions(ctx context.Context, arg GetShortestCompletionsParams) ([]GetShortestCompletionsRow, error)
+	GetTopCreators(ctx context.Context, arg GetTopCreatorsParams) ([]GetTopCreatorsRow, error)
+	GetTopGenres(ctx context.Context, arg GetTopGenresParams) ([]GetTopGenresRow, error)
+	GetStatsSeries(ctx context.Context, arg GetStatsSeriesParams) ([]GetStatsSeriesRow, error)
+}
+
+// Make sure the sqlc generated queries always satisfy Store
+var _ Store = (*Queries)(nil)
@@ -0,0 +1,29 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Store methods written by hand, rather than generated by sqlc, chain several queries that must succeed or fail together
+func (q *Queries) execTx(ctx context.Context, fn func(*Queries) error) error {
+	beginner, ok := q.db.(interface {
+		Begin(context.Context) (pgx.Tx, error)
+	})
+	if !ok {
+		return errors.New("a transaction needs a connection able to begin one")
+	}
+	tx, err := beginner.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = fn(q.WithTx(tx))
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -21,7 +21,7 @@ VALUES (
     $2,
     $3
 )
-RETURNING id, created_at, updated_at, username, hashed_password, email
+RETURNING id, created_at, updated_at, username, hashed_password, email, role, deactivated_at
 `
 
 type CreateUserParams struct {
@@ -40,6 +40,8 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Username,
 		&i.HashedPassword,
 		&i.Email,
+		&i.Role,
+		&i.DeactivatedAt,
 	)
 	return i, err
 }
@@ -48,7 +50,7 @@ const deleteUser = `-- name: DeleteUser :one
 WITH deleted AS (
     DELETE FROM users
     WHERE id = $1
-    RETURNING id, created_at, updated_at, username, hashed_password, email
+    RETURNING id, created_at, updated_at, username, hashed_password, email, role, deactivated_at
 )
 SELECT count(*) FROM deleted
 `
@@ -61,7 +63,7 @@ func (q *Queries) DeleteUser(ctx context.Context, id pgtype.UUID) (int64, error)
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, created_at, updated_at, username, hashed_password, email FROM users
+SELECT id, created_at, updated_at, username, hashed_password, email, role, deactivated_at FROM users
 WHERE email = $1
 `
 
@@ -75,12 +77,14 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.Username,
 		&i.HashedPassword,
 		&i.Email,
+		&i.Role,
+		&i.DeactivatedAt,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, created_at, updated_at, username, hashed_password, email FROM users
+SELECT id, created_at, updated_at, username, hashed_password, email, role, deactivated_at FROM users
 WHERE id = $1
 `
 
@@ -94,12 +98,14 @@ func (q *Queries) GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
 		&i.Username,
 		&i.HashedPassword,
 		&i.Email,
+		&i.Role,
+		&i.DeactivatedAt,
 	)
 	return i, err
 }
 
 const getUserByUsername = `-- name: GetUserByUsername :one
-SELECT id, created_at, updated_at, username, hashed_password, email FROM users
+SELECT id, created_at, updated_at, username, hashed_password, email, role, deactivated_at FROM users
 WHERE username = $1
 `
 
@@ -113,6 +119,8 @@ func (q *Queries) GetUserByUsername(ctx context.Context, username string) (User,
 		&i.Username,
 		&i.HashedPassword,
 		&i.Email,
+		&i.Role,
+		&i.DeactivatedAt,
 	)
 	return i, err
 }
@@ -126,11 +134,56 @@ func (q *Queries) ResetUsers(ctx context.Context) error {
 	return err
 }
 
+const searchUsers = `-- name: SearchUsers :many
+SELECT id, created_at, updated_at, username, hashed_password, email, role, deactivated_at FROM users
+WHERE $1::text = ''
+OR username ILIKE '%' || $1::text || '%'
+OR email ILIKE '%' || $1::text || '%'
+ORDER BY username
+LIMIT $2 OFFSET $3
+`
+
+type SearchUsersParams struct {
+	Search     string
+	PageLimit  int32
+	PageOffset int32
+}
+
+// An empty search matches every user
+func (q *Queries) SearchUsers(ctx context.Context, arg SearchUsersParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, searchUsers, arg.Search, arg.PageLimit, arg.PageOffset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Username,
+			&i.HashedPassword,
+			&i.Email,
+			&i.Role,
+			&i.DeactivatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updatePassword = `-- name: UpdatePassword :one
 UPDATE users
 SET hashed_password = $2, updated_at = NOW()
 WHERE id = $1
-RETURNING id, created_at, updated_at, username, hashed_password, email
+RETURNING id, created_at, updated_at, username, hashed_password, email, role, deactivated_at
 `
 
 type UpdatePasswordParams struct {
@@ -148,6 +201,8 @@ func (q *Queries) UpdatePassword(ctx context.Context, arg UpdatePasswordParams)
 		&i.Username,
 		&i.HashedPassword,
 		&i.Email,
+		&i.Role,
+		&i.DeactivatedAt,
 	)
 	return i, err
 }
@@ -156,7 +211,7 @@ const updateUser = `-- name: UpdateUser :one
 UPDATE users
 SET username = $2, hashed_password = $3, email = $4, updated_at = NOW()
 WHERE id = $1
-RETURNING id, created_at, updated_at, username, hashed_password, email
+RETURNING id, created_at, updated_at, username, hashed_password, email, role, deactivated_at
 `
 
 type UpdateUserParams struct {
@@ -181,6 +236,64 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.Username,
 		&i.HashedPassword,
 		&i.Email,
+		&i.Role,
+		&i.DeactivatedAt,
+	)
+	return i, err
+}
+
+const updateUserDeactivatedAt = `-- name: UpdateUserDeactivatedAt :one
+UPDATE users
+SET deactivated_at = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING id, created_at, updated_at, username, hashed_password, email, role, deactivated_at
+`
+
+type UpdateUserDeactivatedAtParams struct {
+	ID            pgtype.UUID
+	DeactivatedAt pgtype.Timestamp
+}
+
+func (q *Queries) UpdateUserDeactivatedAt(ctx context.Context, arg UpdateUserDeactivatedAtParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserDeactivatedAt, arg.ID, arg.DeactivatedAt)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Username,
+		&i.HashedPassword,
+		&i.Email,
+		&i.Role,
+		&i.DeactivatedAt,
+	)
+	return i, err
+}
+
+const updateUserRoleByUsername = `-- name: UpdateUserRoleByUsername :one
+UPDATE users
+SET role = $2, updated_at = NOW()
+WHERE username = $1
+RETURNING id, created_at, updated_at, username, hashed_password, email, role, deactivated_at
+`
+
+type UpdateUserRoleByUsernameParams struct {
+	Username string
+	Role     string
+}
+
+func (q *Queries) UpdateUserRoleByUsername(ctx context.Context, arg UpdateUserRoleByUsernameParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserRoleByUsername, arg.Username, arg.Role)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Username,
+		&i.HashedPassword,
+		&i.Email,
+		&i.Role,
+		&i.DeactivatedAt,
 	)
 	return i, err
 }
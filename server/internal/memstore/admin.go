@@ -0,0 +1,49 @@
+package memstore
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+)
+
+func (s *MemStore) GetInstanceCounts(ctx context.Context) (database.GetInstanceCountsRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := database.GetInstanceCountsRow{
+		Users:   int64(len(s.users)),
+		Media:   int64(len(s.media)),
+		Records: int64(len(s.records)),
+		Shares:  int64(len(s.shares)),
+	}
+	for _, user := range s.users {
+		if user.DeactivatedAt.Valid {
+			counts.DeactivatedUsers++
+		}
+		if user.Role == "admin" {
+			counts.Admins++
+		}
+	}
+	return counts, nil
+}
+
+func (s *MemStore) CountMediaByType(ctx context.Context) ([]database.CountMediaByTypeRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := map[string]int64{}
+	for _, medium := range s.media {
+		counts[medium.MediaType]++
+	}
+
+	var items []database.CountMediaByTypeRow
+	for mediaType, count := range counts {
+		items = append(items, database.CountMediaByTypeRow{MediaType: mediaType, Count: count})
+	}
+	slices.SortFunc(items, func(a, b database.CountMediaByTypeRow) int {
+		return strings.Compare(a.MediaType, b.MediaType)
+	})
+	return items, nil
+}
@@ -0,0 +1,158 @@
+package memstore
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Find a watched episode's index by its key, -1 if not found (caller must hold the lock)
+func (s *MemStore) episodeIndex(recordID pgtype.UUID, seasonNumber, episodeNumber int32) int {
+	for i, episode := range s.episodes {
+		if sameUUID(episode.RecordID, recordID) && episode.SeasonNumber == seasonNumber && episode.EpisodeNumber == episodeNumber {
+			return i
+		}
+	}
+	return -1
+}
+
+// ORDER BY season_number, episode_number
+func compareEpisodes(a, b database.RecordsEpisode) int {
+	if c := cmp.Compare(a.SeasonNumber, b.SeasonNumber); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.EpisodeNumber, b.EpisodeNumber)
+}
+
+func (s *MemStore) MarkRecordEpisode(ctx context.Context, arg database.MarkRecordEpisodeParams) (database.RecordsEpisode, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !arg.RecordID.Valid {
+		return database.RecordsEpisode{}, notNullViolation("records_episodes", "record_id")
+	}
+	if !arg.WatchedAt.Valid {
+		return database.RecordsEpisode{}, notNullViolation("records_episodes", "watched_at")
+	}
+	if arg.SeasonNumber <= 0 {
+		return database.RecordsEpisode{}, checkViolation("records_episodes", "records_episodes_season_number_check")
+	}
+	if arg.EpisodeNumber <= 0 {
+		return database.RecordsEpisode{}, checkViolation("records_episodes", "records_episodes_episode_number_check")
+	}
+	if arg.Rating.Valid && (arg.Rating.Int16 < 0 || arg.Rating.Int16 > 100) {
+		return database.RecordsEpisode{}, checkViolation("records_episodes", "records_episodes_rating_check")
+	}
+	if s.recordIndex(arg.RecordID) == -1 {
+		return database.RecordsEpisode{}, foreignKeyViolation("records_episodes", "records_episodes_record_id_fkey", fmt.Sprintf("Key (record_id)=(%s) is not present in table \"users_media_records\".", arg.RecordID))
+	}
+
+	episode := database.RecordsEpisode{
+		RecordID:      arg.RecordID,
+		SeasonNumber:  arg.SeasonNumber,
+		EpisodeNumber: arg.EpisodeNumber,
+		WatchedAt:     arg.WatchedAt,
+		Rating:        arg.Rating,
+	}
+	// ON CONFLICT (record_id, season_number, episode_number) DO UPDATE
+	if i := s.episodeIndex(arg.RecordID, arg.SeasonNumber, arg.EpisodeNumber); i != -1 {
+		s.episodes[i] = episode
+	} else {
+		s.episodes = append(s.episodes, episode)
+	}
+	return episode, nil
+}
+
+func (s *MemStore) UnmarkRecordEpisode(ctx context.Context, arg database.UnmarkRecordEpisodeParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.episodeIndex(arg.RecordID, arg.SeasonNumber, arg.EpisodeNumber)
+	if i == -1 {
+		return 0, nil
+	}
+	s.episodes = append(s.episodes[:i], s.episodes[i+1:]...)
+	return 1, nil
+}
+
+func (s *MemStore) GetRecordEpisodes(ctx context.Context, recordID pgtype.UUID) ([]database.RecordsEpisode, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []database.RecordsEpisode
+	for _, episode := range s.episodes {
+		if sameUUID(episode.RecordID, recordID) {
+			items = append(items, episode)
+		}
+	}
+	slices.SortFunc(items, compareEpisodes)
+	return items, nil
+}
+
+func (s *MemStore) GetEpisodesByUserID(ctx context.Context, userID pgtype.UUID) ([]database.RecordsEpisode, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []database.RecordsEpisode
+	for _, episode := range s.episodes {
+		i := s.recordIndex(episode.RecordID)
+		if i != -1 && sameUUID(s.records[i].UserID, userID) {
+			items = append(items, episode)
+		}
+	}
+	// ORDER BY record_id, season_number, episode_number
+	slices.SortFunc(items, func(a, b database.RecordsEpisode) int {
+		if c := bytes.Compare(a.RecordID.Bytes[:], b.RecordID.Bytes[:]); c != 0 {
+			return c
+		}
+		return compareEpisodes(a, b)
+	})
+	return items, nil
+}
+
+func (s *MemStore) GetSeriesRecordsByUserID(ctx context.Context, userID pgtype.UUID) ([]database.GetSeriesRecordsByUserIDRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []database.GetSeriesRecordsByUserIDRow
+	for _, record := range s.records {
+		if !sameUUID(record.UserID, userID) || record.Status != database.RecordStatusInProgress {
+			continue
+		}
+		m := s.mediumIndex(record.MediaID)
+		if m == -1 || s.media[m].MediaType != "series" {
+			continue
+		}
+		items = append(items, database.GetSeriesRecordsByUserIDRow{
+			ID:       record.ID,
+			MediaID:  record.MediaID,
+			Status:   record.Status,
+			Title:    s.media[m].Title,
+			ImageUrl: s.media[m].ImageUrl,
+			Metadata: copyBytes(s.media[m].Metadata),
+		})
+	}
+	// ORDER BY media.title, records.id
+	slices.SortFunc(items, func(a, b database.GetSeriesRecordsByUserIDRow) int {
+		if c := strings.Compare(a.Title, b.Title); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ID.Bytes[:], b.ID.Bytes[:])
+	})
+	return items, nil
+}
+
+// Episodes of a record merged into another one follow it, unless the kept record already has them (caller must hold the lock)
+func (s *MemStore) repointEpisodes(oldRecordID, newRecordID pgtype.UUID) {
+	for i, episode := range s.episodes {
+		if sameUUID(episode.RecordID, oldRecordID) && s.episodeIndex(newRecordID, episode.SeasonNumber, episode.EpisodeNumber) == -1 {
+			s.episodes[i].RecordID = newRecordID
+		}
+	}
+}
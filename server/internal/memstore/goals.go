@@ -0,0 +1,345 @@
+package memstore
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Find a goal's index by ID, -1 if not found (caller must hold the lock)
+func (s *MemStore) goalIndex(id pgtype.UUID) int {
+	for i, goal := range s.goals {
+		if sameUUID(goal.ID, id) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Same steps as database.Queries.SaveGoal, every row is checked before anything is written, as the transaction would roll back
+func (s *MemStore) SaveGoal(ctx context.Context, arg database.SaveGoalParams) (database.SaveGoalResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// NOT NULL and CHECK constraints of goals
+	if !arg.PeriodStart.Valid {
+		return database.SaveGoalResult{}, notNullViolation("goals", "period_start")
+	}
+	if !arg.PeriodEnd.Valid {
+		return database.SaveGoalResult{}, notNullViolation("goals", "period_end")
+	}
+	if strings.TrimSpace(arg.MediaType) == "" {
+		return database.SaveGoalResult{}, checkViolation("goals", "goals_media_type_check")
+	}
+	if !slices.Contains([]string{"count", "pages", "hours"}, arg.Metric) {
+		return database.SaveGoalResult{}, checkViolation("goals", "goals_metric_check")
+	}
+	if arg.Target <= 0 {
+		return database.SaveGoalResult{}, checkViolation("goals", "goals_target_check")
+	}
+	if !slices.Contains([]string{"week", "month", "year", "custom"}, arg.Period) {
+		return database.SaveGoalResult{}, checkViolation("goals", "goals_period_check")
+	}
+	if !arg.PeriodStart.Time.Before(arg.PeriodEnd.Time) {
+		return database.SaveGoalResult{}, checkViolation("goals", "goals_check")
+	}
+
+	var goal database.Goal
+	i := -1
+	if arg.ID.Valid {
+		// UPDATE ... WHERE id = $1 AND user_id = $2
+		i = s.goalIndex(arg.ID)
+		if i == -1 || !sameUUID(s.goals[i].UserID, arg.UserID) {
+			return database.SaveGoalResult{}, pgx.ErrNoRows
+		}
+		goal = s.goals[i]
+	} else {
+		if !arg.UserID.Valid {
+			return database.SaveGoalResult{}, notNullViolation("goals", "user_id")
+		}
+		if s.userIndex(arg.UserID) == -1 {
+			return database.SaveGoalResult{}, foreignKeyViolation("goals", "goals_user_id_fkey", fmt.Sprintf("Key (user_id)=(%s) is not present in table \"users\".", arg.UserID))
+		}
+		goal = database.Goal{
+			ID:        newUUID(),
+			CreatedAt: now(),
+			UserID:    arg.UserID,
+		}
+	}
+	goal.UpdatedAt = now()
+	goal.Name = arg.Name
+	goal.MediaType = arg.MediaType
+	goal.Metric = arg.Metric
+	goal.Target = arg.Target
+	goal.Period = arg.Period
+	goal.PeriodStart = arg.PeriodStart
+	goal.PeriodEnd = arg.PeriodEnd
+
+	// Constraints of goals_tags
+	tags := make([]database.GoalsTag, 0, len(arg.TagIDs))
+	for _, tagID := range arg.TagIDs {
+		if !tagID.Valid {
+			return database.SaveGoalResult{}, notNullViolation("goals_tags", "tag_id")
+		}
+		if s.tagIndex(tagID) == -1 {
+			return database.SaveGoalResult{}, foreignKeyViolation("goals_tags", "goals_tags_tag_id_fkey", fmt.Sprintf("Key (tag_id)=(%s) is not present in table \"tags\".", tagID))
+		}
+		if slices.ContainsFunc(tags, func(tag database.GoalsTag) bool { return sameUUID(tag.TagID, tagID) }) {
+			return database.SaveGoalResult{}, uniqueViolation("goals_tags", "goals_tags_pkey", fmt.Sprintf("Key (goal_id, tag_id)=(%s, %s) already exists.", goal.ID, tagID))
+		}
+		tags = append(tags, database.GoalsTag{GoalID: goal.ID, TagID: tagID})
+	}
+
+	if i == -1 {
+		s.goals = append(s.goals, goal)
+	} else {
+		s.goals[i] = goal
+	}
+	goalsTags := s.goalsTags[:0]
+	for _, tag := range s.goalsTags {
+		if !sameUUID(tag.GoalID, goal.ID) {
+			goalsTags = append(goalsTags, tag)
+		}
+	}
+	s.goalsTags = append(goalsTags, tags...)
+
+	return database.SaveGoalResult{Goal: goal, Tags: slices.Clone(tags)}, nil
+}
+
+func (s *MemStore) GetUserGoalByID(ctx context.Context, arg database.GetUserGoalByIDParams) (database.Goal, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := s.goalIndex(arg.ID)
+	if i == -1 || !sameUUID(s.goals[i].UserID, arg.UserID) {
+		return database.Goal{}, pgx.ErrNoRows
+	}
+	return s.goals[i], nil
+}
+
+func (s *MemStore) GetGoalsByUserID(ctx context.Context, userID pgtype.UUID) ([]database.Goal, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []database.Goal
+	for _, goal := range s.goals {
+		if sameUUID(goal.UserID, userID) {
+			items = append(items, goal)
+		}
+	}
+	// ORDER BY period_start DESC, created_at, id
+	slices.SortFunc(items, func(a, b database.Goal) int {
+		if c := b.PeriodStart.Time.Compare(a.PeriodStart.Time); c != 0 {
+			return c
+		}
+		if c := a.CreatedAt.Time.Compare(b.CreatedAt.Time); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ID.Bytes[:], b.ID.Bytes[:])
+	})
+	return items, nil
+}
+
+func (s *MemStore) GetGoalTagsByUserID(ctx context.Context, userID pgtype.UUID) ([]database.GetGoalTagsByUserIDRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []database.GetGoalTagsByUserIDRow
+	for _, link := range s.goalsTags {
+		i := s.goalIndex(link.GoalID)
+		j := s.tagIndex(link.TagID)
+		if i == -1 || j == -1 || !sameUUID(s.goals[i].UserID, userID) {
+			continue
+		}
+		items = append(items, database.GetGoalTagsByUserIDRow{
+			GoalID: link.GoalID,
+			TagID:  link.TagID,
+			Name:   s.tags[j].Name,
+		})
+	}
+	// ORDER BY goal_id, lower(tags.name), tags.id
+	slices.SortFunc(items, func(a, b database.GetGoalTagsByUserIDRow) int {
+		if c := bytes.Compare(a.GoalID.Bytes[:], b.GoalID.Bytes[:]); c != 0 {
+			return c
+		}
+		if c := strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.TagID.Bytes[:], b.TagID.Bytes[:])
+	})
+	return items, nil
+}
+
+func (s *MemStore) DeleteGoal(ctx context.Context, arg database.DeleteGoalParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.goalIndex(arg.ID)
+	if i == -1 || !sameUUID(s.goals[i].UserID, arg.UserID) {
+		return 0, nil
+	}
+	s.goals = append(s.goals[:i], s.goals[i+1:]...)
+	s.cascadeGoalDelete()
+	return 1, nil
+}
+
+func (s *MemStore) GetGoalRecords(ctx context.Context, arg database.GetGoalRecordsParams) ([]database.GetGoalRecordsRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.goalRecords(arg), nil
+}
+
+func (s *MemStore) GetGoalRecordsByUserID(ctx context.Context, userID pgtype.UUID) ([]database.GetGoalRecordsByUserIDRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var goals []database.Goal
+	for _, goal := range s.goals {
+		if sameUUID(goal.UserID, userID) {
+			goals = append(goals, goal)
+		}
+	}
+	// ORDER BY goals.id, records.end_date, records.id
+	slices.SortFunc(goals, func(a, b database.Goal) int {
+		return bytes.Compare(a.ID.Bytes[:], b.ID.Bytes[:])
+	})
+
+	var items []database.GetGoalRecordsByUserIDRow
+	for _, goal := range goals {
+		var tagIDs []pgtype.UUID
+		for _, link := range s.goalsTags {
+			if sameUUID(link.GoalID, goal.ID) {
+				tagIDs = append(tagIDs, link.TagID)
+			}
+		}
+		records := s.goalRecords(database.GetGoalRecordsParams{
+			UserID:      goal.UserID,
+			PeriodStart: goal.PeriodStart,
+			PeriodEnd:   goal.PeriodEnd,
+			MediaType:   goal.MediaType,
+			TagIds:      tagIDs,
+		})
+		for _, record := range records {
+			items = append(items, database.GetGoalRecordsByUserIDRow{
+				GoalID:      goal.ID,
+				ID:          record.ID,
+				MediaID:     record.MediaID,
+				EndDate:     record.EndDate,
+				MediaType:   record.MediaType,
+				Metadata:    record.Metadata,
+				HoursPlayed: record.HoursPlayed,
+			})
+		}
+	}
+	return items, nil
+}
+
+// Records a goal counts (caller must hold the lock)
+func (s *MemStore) goalRecords(arg database.GetGoalRecordsParams) []database.GetGoalRecordsRow {
+	var items []database.GetGoalRecordsRow
+	records, media := s.userRecordsWithMedia(arg.UserID)
+	for i, record := range records {
+		if !finishedIn(record, arg.PeriodStart, arg.PeriodEnd) || !strings.EqualFold(media[i].MediaType, arg.MediaType) {
+			continue
+		}
+		// cardinality(tag_ids) = 0 OR EXISTS (... media_tags.tag_id = ANY(tag_ids))
+		if len(arg.TagIds) > 0 && !slices.ContainsFunc(s.mediaTags, func(link database.MediaTag) bool {
+			return sameUUID(link.MediaID, record.MediaID) && slices.ContainsFunc(arg.TagIds, func(tagID pgtype.UUID) bool { return sameUUID(link.TagID, tagID) })
+		}) {
+			continue
+		}
+		row := database.GetGoalRecordsRow{
+			ID:        record.ID,
+			MediaID:   record.MediaID,
+			EndDate:   record.EndDate,
+			MediaType: media[i].MediaType,
+			Metadata:  copyBytes(media[i].Metadata),
+		}
+		// LEFT JOIN records_videogames
+		if j := s.videogameIndexByRecordID(record.ID); j != -1 {
+			row.HoursPlayed = s.videogames[j].HoursPlayed
+		}
+		items = append(items, row)
+	}
+	// ORDER BY records.end_date, records.id
+	slices.SortFunc(items, func(a, b database.GetGoalRecordsRow) int {
+		if c := a.EndDate.Time.Compare(b.EndDate.Time); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ID.Bytes[:], b.ID.Bytes[:])
+	})
+	return items
+}
+
+func (s *MemStore) GetActivityDays(ctx context.Context, arg database.GetActivityDaysParams) ([]pgtype.Timestamp, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Activity of user's records, of the given media type only if there is one
+	days := map[time.Time]bool{}
+	add := func(at pgtype.Timestamp, mediaID pgtype.UUID) {
+		m := s.mediumIndex(mediaID)
+		if !at.Valid || m == -1 || (arg.MediaType != "" && !strings.EqualFold(s.media[m].MediaType, arg.MediaType)) {
+			return
+		}
+		days[truncateTime("day", at.Time)] = true
+	}
+	recordMedium := func(recordID pgtype.UUID) pgtype.UUID {
+		i := s.recordIndex(recordID)
+		if i == -1 || !sameUUID(s.records[i].UserID, arg.UserID) {
+			return pgtype.UUID{}
+		}
+		return s.records[i].MediaID
+	}
+
+	for _, record := range s.records {
+		if !sameUUID(record.UserID, arg.UserID) {
+			continue
+		}
+		add(record.StartDate, record.MediaID)
+		if record.IsFinished.Valid && record.IsFinished.Bool {
+			add(record.EndDate, record.MediaID)
+		}
+	}
+	for _, entry := range s.progress {
+		add(entry.LoggedAt, recordMedium(entry.RecordID))
+	}
+	for _, episode := range s.episodes {
+		add(episode.WatchedAt, recordMedium(episode.RecordID))
+	}
+	for _, play := range s.plays {
+		if sameUUID(play.UserID, arg.UserID) {
+			add(play.PlayedAt, play.MediaID)
+		}
+	}
+
+	items := make([]pgtype.Timestamp, 0, len(days))
+	for day := range days {
+		items = append(items, pgtype.Timestamp{Time: day, Valid: true})
+	}
+	// ORDER BY day DESC
+	slices.SortFunc(items, func(a, b pgtype.Timestamp) int {
+		return cmp.Compare(b.Time.UnixMicro(), a.Time.UnixMicro())
+	})
+	return items, nil
+}
+
+// Apply ON DELETE CASCADE to goals_tags once goals or tags were removed (caller must hold the lock)
+func (s *MemStore) cascadeGoalDelete() {
+	goalsTags := s.goalsTags[:0]
+	for _, link := range s.goalsTags {
+		if s.goalIndex(link.GoalID) != -1 && s.tagIndex(link.TagID) != -1 {
+			goalsTags = append(goalsTags, link)
+		}
+	}
+	s.goalsTags = goalsTags
+}
@@ -0,0 +1,249 @@
+package memstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Find a loan's index by ID, -1 if not found (caller must hold the lock)
+func (s *MemStore) loanIndex(id pgtype.UUID) int {
+	for i, loan := range s.loans {
+		if sameUUID(loan.ID, id) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Dates CHECK constraints and partial unique index on (owner_id, media_id) of open loans,
+// skipping the loan being updated (caller must hold the lock)
+func (s *MemStore) checkLoan(candidate database.Loan) error {
+	if candidate.DueAt.Valid && candidate.DueAt.Time.Before(candidate.LentAt.Time) {
+		return checkViolation("loans", "loans_due_at_check")
+	}
+	if candidate.ReturnedAt.Valid && candidate.ReturnedAt.Time.Before(candidate.LentAt.Time) {
+		return checkViolation("loans", "loans_returned_at_check")
+	}
+	if candidate.ReturnedAt.Valid {
+		return nil
+	}
+	for _, loan := range s.loans {
+		if !sameUUID(loan.ID, candidate.ID) && !loan.ReturnedAt.Valid && sameUUID(loan.OwnerID, candidate.OwnerID) && sameUUID(loan.MediaID, candidate.MediaID) {
+			return uniqueViolation("loans", "loans_owner_id_media_id_open_key", fmt.Sprintf("Key (owner_id, media_id)=(%s, %s) already exists.", candidate.OwnerID, candidate.MediaID))
+		}
+	}
+	return nil
+}
+
+func (s *MemStore) CreateLoan(ctx context.Context, arg database.CreateLoanParams) (database.Loan, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// NOT NULL and CHECK constraints
+	if !arg.OwnerID.Valid {
+		return database.Loan{}, notNullViolation("loans", "owner_id")
+	}
+	if !arg.MediaID.Valid {
+		return database.Loan{}, notNullViolation("loans", "media_id")
+	}
+	if !arg.LentAt.Valid {
+		return database.Loan{}, notNullViolation("loans", "lent_at")
+	}
+	if strings.TrimSpace(arg.BorrowerName) == "" {
+		return database.Loan{}, checkViolation("loans", "loans_borrower_name_check")
+	}
+	if sameUUID(arg.BorrowerID, arg.OwnerID) {
+		return database.Loan{}, checkViolation("loans", "loans_check")
+	}
+
+	// Foreign keys
+	if s.userIndex(arg.OwnerID) == -1 {
+		return database.Loan{}, foreignKeyViolation("loans", "loans_owner_id_fkey", fmt.Sprintf("Key (owner_id)=(%s) is not present in table \"users\".", arg.OwnerID))
+	}
+	if s.mediumIndex(arg.MediaID) == -1 {
+		return database.Loan{}, foreignKeyViolation("loans", "loans_media_id_fkey", fmt.Sprintf("Key (media_id)=(%s) is not present in table \"media\".", arg.MediaID))
+	}
+	if arg.BorrowerID.Valid && s.userIndex(arg.BorrowerID) == -1 {
+		return database.Loan{}, foreignKeyViolation("loans", "loans_borrower_id_fkey", fmt.Sprintf("Key (borrower_id)=(%s) is not present in table \"users\".", arg.BorrowerID))
+	}
+
+	timestamp := now()
+	loan := database.Loan{
+		ID:           newUUID(),
+		CreatedAt:    timestamp,
+		UpdatedAt:    timestamp,
+		OwnerID:      arg.OwnerID,
+		MediaID:      arg.MediaID,
+		BorrowerID:   arg.BorrowerID,
+		BorrowerName: arg.BorrowerName,
+		LentAt:       arg.LentAt,
+		DueAt:        arg.DueAt,
+	}
+	if err := s.checkLoan(loan); err != nil {
+		return database.Loan{}, err
+	}
+	s.loans = append(s.loans, loan)
+	return loan, nil
+}
+
+func (s *MemStore) GetUserLoanByID(ctx context.Context, arg database.GetUserLoanByIDParams) (database.Loan, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := s.loanIndex(arg.ID)
+	if i == -1 || !sameUUID(s.loans[i].OwnerID, arg.OwnerID) {
+		return database.Loan{}, pgx.ErrNoRows
+	}
+	return s.loans[i], nil
+}
+
+// Open loans made by or to a user, joined with owner and medium (caller must hold the lock)
+func (s *MemStore) userOpenLoans(userID pgtype.UUID, keep func(loan database.Loan) bool) []database.GetOpenLoansByUserIDRow {
+	var items []database.GetOpenLoansByUserIDRow
+	for _, loan := range s.loans {
+		if loan.ReturnedAt.Valid || (!sameUUID(loan.OwnerID, userID) && !sameUUID(loan.BorrowerID, userID)) || !keep(loan) {
+			continue
+		}
+		u := s.userIndex(loan.OwnerID)
+		m := s.mediumIndex(loan.MediaID)
+		if u == -1 || m == -1 {
+			continue
+		}
+		items = append(items, database.GetOpenLoansByUserIDRow{
+			ID:            loan.ID,
+			CreatedAt:     loan.CreatedAt,
+			UpdatedAt:     loan.UpdatedAt,
+			OwnerID:       loan.OwnerID,
+			OwnerUsername: s.users[u].Username,
+			MediaID:       loan.MediaID,
+			MediaType:     s.media[m].MediaType,
+			Title:         s.media[m].Title,
+			BorrowerID:    loan.BorrowerID,
+			BorrowerName:  loan.BorrowerName,
+			LentAt:        loan.LentAt,
+			DueAt:         loan.DueAt,
+			ReturnedAt:    loan.ReturnedAt,
+		})
+	}
+	// ORDER BY due_at NULLS LAST, lent_at, id
+	slices.SortFunc(items, func(a, b database.GetOpenLoansByUserIDRow) int {
+		if a.DueAt.Valid != b.DueAt.Valid {
+			if a.DueAt.Valid {
+				return -1
+			}
+			return 1
+		}
+		if c := a.DueAt.Time.Compare(b.DueAt.Time); c != 0 {
+			return c
+		}
+		if c := a.LentAt.Time.Compare(b.LentAt.Time); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ID.Bytes[:], b.ID.Bytes[:])
+	})
+	return items
+}
+
+func (s *MemStore) GetOpenLoansByUserID(ctx context.Context, ownerID pgtype.UUID) ([]database.GetOpenLoansByUserIDRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.userOpenLoans(ownerID, func(database.Loan) bool { return true }), nil
+}
+
+func (s *MemStore) GetOverdueLoansByUserID(ctx context.Context, ownerID pgtype.UUID) ([]database.GetOverdueLoansByUserIDRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Timestamps are stored in UTC, as NOW() in a TIMESTAMP column
+	timestamp := time.Now().UTC()
+	rows := s.userOpenLoans(ownerID, func(loan database.Loan) bool {
+		return loan.DueAt.Valid && loan.DueAt.Time.Before(timestamp)
+	})
+	var items []database.GetOverdueLoansByUserIDRow
+	for _, row := range rows {
+		items = append(items, database.GetOverdueLoansByUserIDRow(row))
+	}
+	return items, nil
+}
+
+func (s *MemStore) GetMediumLoans(ctx context.Context, arg database.GetMediumLoansParams) ([]database.Loan, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []database.Loan
+	for _, loan := range s.loans {
+		if sameUUID(loan.OwnerID, arg.OwnerID) && sameUUID(loan.MediaID, arg.MediaID) {
+			items = append(items, loan)
+		}
+	}
+	// ORDER BY lent_at DESC, id
+	slices.SortFunc(items, func(a, b database.Loan) int {
+		if c := b.LentAt.Time.Compare(a.LentAt.Time); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ID.Bytes[:], b.ID.Bytes[:])
+	})
+	return items, nil
+}
+
+func (s *MemStore) UpdateLoan(ctx context.Context, arg database.UpdateLoanParams) (database.Loan, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.loanIndex(arg.ID)
+	if i == -1 || !sameUUID(s.loans[i].OwnerID, arg.OwnerID) {
+		return database.Loan{}, pgx.ErrNoRows
+	}
+	candidate := s.loans[i]
+	candidate.DueAt = arg.DueAt
+	candidate.ReturnedAt = arg.ReturnedAt
+	if err := s.checkLoan(candidate); err != nil {
+		return database.Loan{}, err
+	}
+	candidate.UpdatedAt = now()
+	s.loans[i] = candidate
+	return candidate, nil
+}
+
+func (s *MemStore) DeleteLoan(ctx context.Context, arg database.DeleteLoanParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.loanIndex(arg.ID)
+	if i == -1 || !sameUUID(s.loans[i].OwnerID, arg.OwnerID) {
+		return 0, nil
+	}
+	s.loans = append(s.loans[:i], s.loans[i+1:]...)
+	return 1, nil
+}
+
+// Move loans of a merged medium to the kept one, closing the open ones first when their owner has the kept medium lent out already (caller must hold the lock)
+func (s *MemStore) repointLoans(oldMediaID, newMediaID pgtype.UUID) int64 {
+	var closed int64
+	for i, loan := range s.loans {
+		if !sameUUID(loan.MediaID, oldMediaID) {
+			continue
+		}
+		if !loan.ReturnedAt.Valid && slices.ContainsFunc(s.loans, func(existing database.Loan) bool {
+			return !existing.ReturnedAt.Valid && sameUUID(existing.OwnerID, loan.OwnerID) && sameUUID(existing.MediaID, newMediaID)
+		}) {
+			s.loans[i].ReturnedAt = now()
+			if s.loans[i].ReturnedAt.Time.Before(loan.LentAt.Time) {
+				s.loans[i].ReturnedAt = loan.LentAt
+			}
+			closed++
+		}
+		s.loans[i].MediaID = newMediaID
+		s.loans[i].UpdatedAt = now()
+	}
+	return closed
+}
@@ -0,0 +1,500 @@
+package memstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Find a medium's index by ID, -1 if not found (caller must hold the lock)
+func (s *MemStore) mediumIndex(id pgtype.UUID) int {
+	for i, medium := range s.media {
+		if sameUUID(medium.ID, id) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Check media external_ids check constraint
+func checkExternalIDs(externalIDs []byte) error {
+	if externalIDs == nil {
+		return notNullViolation("media", "external_ids")
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(externalIDs, &object); err != nil || object == nil {
+		return checkViolation("media", "media_external_ids_check")
+	}
+	for provider := range object {
+		if !database.IsExternalIDProvider(provider) {
+			return checkViolation("media", "media_external_ids_check")
+		}
+	}
+	return nil
+}
+
+// Check media unique indexes, ignoring the row at index skip (caller must hold the lock):
+// one medium per provider's ID, and (media_type, title, creator, publication year) for media without any external ID
+func (s *MemStore) checkMediumUnique(candidate database.Medium, skip int) error {
+	candidateIDs, err := database.DecodeExternalIDs(candidate.ExternalIds)
+	if err != nil {
+		return err
+	}
+	for i, medium := range s.media {
+		if i == skip {
+			continue
+		}
+		mediumIDs, err := database.DecodeExternalIDs(medium.ExternalIds)
+		if err != nil {
+			return err
+		}
+		for provider, id := range candidateIDs {
+			if other, ok := mediumIDs[provider]; ok && other == id {
+				return uniqueViolation("media", fmt.Sprintf("media_external_ids_%s_key", provider), fmt.Sprintf("Key ((external_ids ->> '%s'::text))=(%s) already exists.", provider, id))
+			}
+		}
+		if len(candidateIDs) == 0 && len(mediumIDs) == 0 &&
+			strings.ToLower(medium.MediaType) == strings.ToLower(candidate.MediaType) &&
+			strings.ToLower(medium.Title) == strings.ToLower(candidate.Title) &&
+			strings.ToLower(medium.Creator) == strings.ToLower(candidate.Creator) &&
+			database.PubYear(medium.PubDate) == database.PubYear(candidate.PubDate) {
+			return uniqueViolation("media", "media_identity_key", fmt.Sprintf("Key (lower(media_type), lower(title), lower(creator), pub_year)=(%s, %s, %s, %s) already exists.", strings.ToLower(candidate.MediaType), strings.ToLower(candidate.Title), strings.ToLower(candidate.Creator), database.PubYear(candidate.PubDate)))
+		}
+	}
+	return nil
+}
+
+// Return a copy of a stored medium
+func copyMedium(medium database.Medium) database.Medium {
+	medium.Metadata = copyBytes(medium.Metadata)
+	medium.ExternalIds = copyBytes(medium.ExternalIds)
+	return medium
+}
+
+func (s *MemStore) CreateMedium(ctx context.Context, arg database.CreateMediumParams) (database.Medium, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if arg.Metadata == nil {
+		return database.Medium{}, notNullViolation("media", "metadata")
+	}
+	if err := checkExternalIDs(arg.ExternalIds); err != nil {
+		return database.Medium{}, err
+	}
+	if arg.CreatedBy.Valid && s.userIndex(arg.CreatedBy) == -1 {
+		return database.Medium{}, foreignKeyViolation("media", "media_created_by_fkey", fmt.Sprintf("Key (created_by)=(%s) is not present in table \"users\".", arg.CreatedBy))
+	}
+
+	timestamp := now()
+	medium := database.Medium{
+		ID:          newUUID(),
+		MediaType:   arg.MediaType,
+		CreatedAt:   timestamp,
+		UpdatedAt:   timestamp,
+		Title:       arg.Title,
+		Creator:     arg.Creator,
+		PubDate:     arg.PubDate,
+		ImageUrl:    arg.ImageUrl,
+		Metadata:    copyBytes(arg.Metadata),
+		CreatedBy:   arg.CreatedBy,
+		ExternalIds: copyBytes(arg.ExternalIds),
+	}
+	if err := s.checkMediumUnique(medium, -1); err != nil {
+		return database.Medium{}, err
+	}
+	s.media = append(s.media, medium)
+	return copyMedium(medium), nil
+}
+
+func (s *MemStore) UpdateMedium(ctx context.Context, arg database.UpdateMediumParams) (database.Medium, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.mediumIndex(arg.ID)
+	if i == -1 {
+		return database.Medium{}, pgx.ErrNoRows
+	}
+	if arg.Metadata == nil {
+		return database.Medium{}, notNullViolation("media", "metadata")
+	}
+	if err := checkExternalIDs(arg.ExternalIds); err != nil {
+		return database.Medium{}, err
+	}
+	updated := s.media[i]
+	updated.Title = arg.Title
+	updated.Creator = arg.Creator
+	updated.PubDate = arg.PubDate
+	updated.ExternalIds = arg.ExternalIds
+	if err := s.checkMediumUnique(updated, i); err != nil {
+		return database.Medium{}, err
+	}
+
+	s.media[i].Title = arg.Title
+	s.media[i].Creator = arg.Creator
+	s.media[i].PubDate = arg.PubDate
+	s.media[i].ImageUrl = arg.ImageUrl
+	s.media[i].Metadata = copyBytes(arg.Metadata)
+	s.media[i].ExternalIds = copyBytes(arg.ExternalIds)
+	s.media[i].UpdatedAt = now()
+	return copyMedium(s.media[i]), nil
+}
+
+func (s *MemStore) GetMediaByTitleAndType(ctx context.Context, arg database.GetMediaByTitleAndTypeParams) ([]database.Medium, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []database.Medium
+	for _, medium := range s.media {
+		if strings.ToLower(medium.Title) != strings.ToLower(arg.Title) || strings.ToLower(medium.MediaType) != strings.ToLower(arg.MediaType) {
+			continue
+		}
+		if arg.Creator != "" && strings.ToLower(medium.Creator) != strings.ToLower(arg.Creator) {
+			continue
+		}
+		if arg.PubYear != "" && database.PubYear(medium.PubDate) != arg.PubYear {
+			continue
+		}
+		items = append(items, copyMedium(medium))
+	}
+	sortMedia(items)
+	return items, nil
+}
+
+// ORDER BY pub_date, creator, id
+func sortMedia(items []database.Medium) {
+	slices.SortFunc(items, func(a, b database.Medium) int {
+		if c := strings.Compare(a.PubDate, b.PubDate); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.Creator, b.Creator); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ID.Bytes[:], b.ID.Bytes[:])
+	})
+}
+
+func (s *MemStore) GetMediaByExternalIDs(ctx context.Context, externalIds []byte) ([]database.Medium, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	given, err := database.DecodeExternalIDs(externalIds)
+	if err != nil {
+		return nil, err
+	}
+	var items []database.Medium
+	for _, medium := range s.media {
+		mediumIDs, err := database.DecodeExternalIDs(medium.ExternalIds)
+		if err != nil {
+			return nil, err
+		}
+		for provider, id := range given {
+			if other, ok := mediumIDs[provider]; ok && other == id {
+				items = append(items, copyMedium(medium))
+				break
+			}
+		}
+	}
+	sortMedia(items)
+	return items, nil
+}
+
+func (s *MemStore) GetMediaByType(ctx context.Context, lower string) ([]database.Medium, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []database.Medium
+	for _, medium := range s.media {
+		if strings.ToLower(medium.MediaType) == strings.ToLower(lower) {
+			items = append(items, copyMedium(medium))
+		}
+	}
+	return items, nil
+}
+
+func (s *MemStore) GetMediumByID(ctx context.Context, id pgtype.UUID) (database.Medium, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := s.mediumIndex(id)
+	if i == -1 {
+		return database.Medium{}, pgx.ErrNoRows
+	}
+	return copyMedium(s.media[i]), nil
+}
+
+func (s *MemStore) DeleteMedium(ctx context.Context, id pgtype.UUID) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.mediumIndex(id)
+	if i == -1 {
+		return 0, nil
+	}
+	s.media = append(s.media[:i], s.media[i+1:]...)
+	s.cascadeMediumDelete(func(mediaID pgtype.UUID) bool { return sameUUID(mediaID, id) })
+	return 1, nil
+}
+
+func (s *MemStore) GetMediumRedirect(ctx context.Context, oldID pgtype.UUID) (pgtype.UUID, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, redirect := range s.redirects {
+		if sameUUID(redirect.OldID, oldID) {
+			return redirect.MediaID, nil
+		}
+	}
+	return pgtype.UUID{}, pgx.ErrNoRows
+}
+
+// Same steps as database.Queries.DeleteMediumForUser, the lock held all along stands for the transaction
+func (s *MemStore) DeleteMediumForUser(ctx context.Context, arg database.DeleteMediumForUserParams) (database.DeleteMediumForUserResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.mediumIndex(arg.MediumID)
+	if i == -1 {
+		return database.DeleteMediumForUserResult{}, pgx.ErrNoRows
+	}
+
+	var result database.DeleteMediumForUserResult
+	records := s.records[:0]
+	for _, record := range s.records {
+		if !sameUUID(record.MediaID, arg.MediumID) {
+			records = append(records, record)
+			continue
+		}
+		if !sameUUID(record.UserID, arg.UserID) {
+			result.OtherUsersRecords++
+			records = append(records, record)
+			continue
+		}
+		result.DeletedRecords++
+	}
+	s.records = records
+	s.cascadeRecordDelete()
+
+	if !arg.CanDeleteMedium || result.OtherUsersRecords > 0 {
+		return result, nil
+	}
+	s.media = append(s.media[:i], s.media[i+1:]...)
+	s.cascadeMediumDelete(func(mediaID pgtype.UUID) bool { return sameUUID(mediaID, arg.MediumID) })
+	result.MediumDeleted = true
+	return result, nil
+}
+
+// Same steps as database.Queries.MergeMedia, the lock held all along stands for the transaction
+func (s *MemStore) MergeMedia(ctx context.Context, arg database.MergeMediaParams) (database.MergeMediaResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if sameUUID(arg.TargetID, arg.SourceID) {
+		return database.MergeMediaResult{}, database.ErrMergeSameMedium
+	}
+	t := s.mediumIndex(arg.TargetID)
+	src := s.mediumIndex(arg.SourceID)
+	if t == -1 || src == -1 {
+		return database.MergeMediaResult{}, pgx.ErrNoRows
+	}
+	target, source := s.media[t], s.media[src]
+	if target.MediaType != source.MediaType {
+		return database.MergeMediaResult{}, database.ErrMergeMediaType
+	}
+	if arg.MergedBy.Valid && s.userIndex(arg.MergedBy) == -1 {
+		return database.MergeMediaResult{}, foreignKeyViolation("media_redirects", "media_redirects_merged_by_fkey", fmt.Sprintf("Key (merged_by)=(%s) is not present in table \"users\".", arg.MergedBy))
+	}
+	metadata, err := database.MergeMetadata(target.Metadata, source.Metadata)
+	if err != nil {
+		return database.MergeMediaResult{}, err
+	}
+	externalIDs, err := database.MergeExternalIDs(target.ExternalIds, source.ExternalIds)
+	if err != nil {
+		return database.MergeMediaResult{}, err
+	}
+
+	// A user holding both media keeps only one record of the same consumption
+	var result database.MergeMediaResult
+	dropped := map[int]bool{}
+	matched := map[int]bool{}
+	for i, sourceRecord := range s.records {
+		if !sameUUID(sourceRecord.MediaID, source.ID) {
+			continue
+		}
+		for j, targetRecord := range s.records {
+			if !sameUUID(targetRecord.MediaID, target.ID) || !sameUUID(targetRecord.UserID, sourceRecord.UserID) {
+				continue
+			}
+			// Each target record is merged with one source record at most
+			if matched[j] || !database.SameConsumption(targetRecord, sourceRecord) {
+				continue
+			}
+			matched[j] = true
+			kept, drop := j, i
+			if database.RicherRecord(sourceRecord, targetRecord) {
+				kept, drop = i, j
+			}
+			comments := database.MergeComments(s.records[kept].Comments, s.records[drop].Comments)
+			if comments != s.records[kept].Comments {
+				s.records[kept].Comments = comments
+				s.records[kept].UpdatedAt = now()
+			}
+			// Kept record takes dropped one's rating if it had none
+			if !s.records[kept].Rating.Valid && s.records[drop].Rating.Valid {
+				s.records[kept].Rating = s.records[drop].Rating
+				s.records[kept].UpdatedAt = now()
+			}
+			s.repointShares(s.records[drop].ID, s.records[kept].ID)
+			s.repointProgress(s.records[drop].ID, s.records[kept].ID)
+			s.repointPauses(s.records[drop].ID, s.records[kept].ID)
+			s.repointReview(s.records[drop].ID, s.records[kept].ID)
+			s.repointVideogame(s.records[drop].ID, s.records[kept].ID)
+			s.repointEpisodes(s.records[drop].ID, s.records[kept].ID)
+			dropped[drop] = true
+			result.MergedRecords++
+			break
+		}
+	}
+	records := s.records[:0]
+	for i, record := range s.records {
+		if !dropped[i] {
+			records = append(records, record)
+		}
+	}
+	s.records = records
+	s.cascadeRecordDelete()
+
+	for i, record := range s.records {
+		if sameUUID(record.MediaID, source.ID) {
+			s.records[i].MediaID = target.ID
+			s.records[i].UpdatedAt = now()
+			result.MovedRecords++
+		}
+	}
+
+	// Tags, custom shelves, loans, owned copies, shelving units' cells and plays follow the merged medium
+	s.repointMediaTags(source.ID, target.ID)
+	s.repointShelvesMedia(source.ID, target.ID)
+	result.ClosedLoans = s.repointLoans(source.ID, target.ID)
+	s.repointOwnedCopies(source.ID, target.ID)
+	s.repointShelvingItems(source.ID, target.ID)
+	s.repointPlays(source.ID, target.ID)
+
+	// Fill target's gaps with source's info
+	t = s.mediumIndex(target.ID)
+	if s.media[t].Creator == "" {
+		s.media[t].Creator = source.Creator
+	}
+	if s.media[t].PubDate == "" {
+		s.media[t].PubDate = source.PubDate
+	}
+	if s.media[t].ImageUrl == "" {
+		s.media[t].ImageUrl = source.ImageUrl
+	}
+	s.media[t].Metadata = metadata
+	s.media[t].ExternalIds = externalIDs
+	s.media[t].UpdatedAt = now()
+	result.Medium = copyMedium(s.media[t])
+
+	// Redirect source's ID, and the IDs already redirected to it
+	for i, redirect := range s.redirects {
+		if sameUUID(redirect.MediaID, source.ID) {
+			s.redirects[i].MediaID = target.ID
+		}
+	}
+	src = s.mediumIndex(source.ID)
+	s.media = append(s.media[:src], s.media[src+1:]...)
+	s.cascadeMediumDelete(func(mediaID pgtype.UUID) bool { return sameUUID(mediaID, source.ID) })
+	s.redirects = append(s.redirects, database.MediaRedirect{
+		OldID:     source.ID,
+		MediaID:   target.ID,
+		CreatedAt: now(),
+		MergedBy:  arg.MergedBy,
+	})
+
+	return result, nil
+}
+
+func (s *MemStore) ResetMedia(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.media = nil
+	s.cascadeMediumDelete(func(pgtype.UUID) bool { return true })
+	return nil
+}
+
+// Apply ON DELETE CASCADE to every table referencing media (caller must hold the lock)
+func (s *MemStore) cascadeMediumDelete(deleted func(mediaID pgtype.UUID) bool) {
+	records := s.records[:0]
+	for _, record := range s.records {
+		if !record.MediaID.Valid || !deleted(record.MediaID) {
+			records = append(records, record)
+		}
+	}
+	s.records = records
+	s.cascadeRecordDelete()
+
+	redirects := s.redirects[:0]
+	for _, redirect := range s.redirects {
+		if !deleted(redirect.MediaID) {
+			redirects = append(redirects, redirect)
+		}
+	}
+	s.redirects = redirects
+
+	mediaTags := s.mediaTags[:0]
+	for _, link := range s.mediaTags {
+		if !deleted(link.MediaID) {
+			mediaTags = append(mediaTags, link)
+		}
+	}
+	s.mediaTags = mediaTags
+
+	shelvesMedia := s.shelvesMedia[:0]
+	for _, link := range s.shelvesMedia {
+		if !deleted(link.MediaID) {
+			shelvesMedia = append(shelvesMedia, link)
+		}
+	}
+	s.shelvesMedia = shelvesMedia
+
+	loans := s.loans[:0]
+	for _, loan := range s.loans {
+		if !deleted(loan.MediaID) {
+			loans = append(loans, loan)
+		}
+	}
+	s.loans = loans
+
+	copies := s.copies[:0]
+	for _, ownedCopy := range s.copies {
+		if !deleted(ownedCopy.MediaID) {
+			copies = append(copies, ownedCopy)
+		}
+	}
+	s.copies = copies
+
+	shelvingItems := s.shelvingItems[:0]
+	for _, item := range s.shelvingItems {
+		if !deleted(item.MediaID) {
+			shelvingItems = append(shelvingItems, item)
+		}
+	}
+	s.shelvingItems = shelvingItems
+
+	plays := s.plays[:0]
+	for _, play := range s.plays {
+		if !deleted(play.MediaID) {
+			plays = append(plays, play)
+		}
+	}
+	s.plays = plays
+	s.cascadePlayDelete()
+}
@@ -0,0 +1,134 @@
+// Package memstore is an in-memory implementation of database.Store.
+// It mirrors the PostgreSQL schema rules (unique keys, foreign keys, cascading deletes)
+// and returns the same pgx/pgconn errors, so handlers behave the same with or without a database.
+package memstore
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// PostgreSQL error codes returned by the store
+const (
+	codeNotNullViolation    = "23502"
+	codeForeignKeyViolation = "23503"
+	codeUniqueViolation     = "23505"
+	codeCheckViolation      = "23514"
+)
+
+// MemStore holds every table in memory, rows are kept in insertion order
+type MemStore struct {
+	mu            sync.RWMutex
+	users         []database.User
+	refreshTokens []database.RefreshToken
+	resetTokens   []database.PasswordResetToken
+	media         []database.Medium
+	redirects     []database.MediaRedirect
+	records       []database.UsersMediaRecord
+	progress      []database.RecordsProgress
+	pauses        []database.RecordsPause
+	videogames    []database.RecordsVideogame
+	episodes      []database.RecordsEpisode
+	reviews       []database.Review
+	revisions     []database.ReviewsRevision
+	loans         []database.Loan
+	copies        []database.OwnedCopy
+	shares        []database.Share
+	tags          []database.Tag
+	mediaTags     []database.MediaTag
+	shelves       []database.Shelf
+	shelvesMedia  []database.ShelvesMedium
+	units         []database.ShelvingUnit
+	shelvingItems []database.ShelvingItem
+	plays         []database.Play
+	playsPlayers  []database.PlaysPlayer
+	goals         []database.Goal
+	goalsTags     []database.GoalsTag
+}
+
+// Make sure MemStore always satisfies database.Store
+var _ database.Store = (*MemStore)(nil)
+
+// New returns an empty store
+func New() *MemStore {
+	return &MemStore{}
+}
+
+// Equivalent of NOW() for a TIMESTAMP column
+func now() pgtype.Timestamp {
+	return pgtype.Timestamp{
+		Time:  time.Now().UTC().Truncate(time.Microsecond),
+		Valid: true,
+	}
+}
+
+// Equivalent of gen_random_uuid()
+func newUUID() pgtype.UUID {
+	var id pgtype.UUID
+	rand.Read(id.Bytes[:])
+	id.Bytes[6] = (id.Bytes[6] & 0x0f) | 0x40 // version 4
+	id.Bytes[8] = (id.Bytes[8] & 0x3f) | 0x80 // variant 10
+	id.Valid = true
+	return id
+}
+
+// Two UUIDs are equal only if both are non NULL, like in SQL
+func sameUUID(a, b pgtype.UUID) bool {
+	return a.Valid && b.Valid && a.Bytes == b.Bytes
+}
+
+// Copy a byte slice so callers can't modify stored data
+func copyBytes(data []byte) []byte {
+	if data == nil {
+		return nil
+	}
+	return append([]byte{}, data...)
+}
+
+func uniqueViolation(table, constraint, detail string) error {
+	return &pgconn.PgError{
+		Severity:       "ERROR",
+		Code:           codeUniqueViolation,
+		Message:        fmt.Sprintf("duplicate key value violates unique constraint \"%s\"", constraint),
+		Detail:         detail,
+		TableName:      table,
+		ConstraintName: constraint,
+	}
+}
+
+func foreignKeyViolation(table, constraint, detail string) error {
+	return &pgconn.PgError{
+		Severity:       "ERROR",
+		Code:           codeForeignKeyViolation,
+		Message:        fmt.Sprintf("insert or update on table \"%s\" violates foreign key constraint \"%s\"", table, constraint),
+		Detail:         detail,
+		TableName:      table,
+		ConstraintName: constraint,
+	}
+}
+
+func notNullViolation(table, column string) error {
+	return &pgconn.PgError{
+		Severity:   "ERROR",
+		Code:       codeNotNullViolation,
+		Message:    fmt.Sprintf("null value in column \"%s\" of relation \"%s\" violates not-null constraint", column, table),
+		TableName:  table,
+		ColumnName: column,
+	}
+}
+
+func checkViolation(table, constraint string) error {
+	return &pgconn.PgError{
+		Severity:       "ERROR",
+		Code:           codeCheckViolation,
+		Message:        fmt.Sprintf("new row for relation \"%s\" violates check constraint \"%s\"", table, constraint),
+		TableName:      table,
+		ConstraintName: constraint,
+	}
+}
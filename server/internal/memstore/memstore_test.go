@@ -0,0 +1,744 @@
+package memstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func pgErrorCode(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code
+	}
+	return ""
+}
+
+func TestConstraints(t *testing.T) {
+	ctx := context.Background()
+	store := New()
+
+	// Create some rows for testing
+	user, err := store.CreateUser(ctx, database.CreateUserParams{Username: "user", HashedPassword: "hash", Email: "user@example.com"})
+	if err != nil {
+		t.Fatalf("couldn't create test user: %v", err)
+	}
+	medium, err := store.CreateMedium(ctx, database.CreateMediumParams{MediaType: "book", Title: "Emma", Creator: "Jane Austen", PubDate: "1815", Metadata: []byte("{}"), ExternalIds: []byte("{}")})
+	if err != nil {
+		t.Fatalf("couldn't create test medium: %v", err)
+	}
+	_, err = store.CreateMedium(ctx, database.CreateMediumParams{MediaType: "movie", Title: "Dune", Creator: "Denis Villeneuve", PubDate: "2021", Metadata: []byte("{}"), ExternalIds: []byte(`{"tmdb_movie": "438631"}`)})
+	if err != nil {
+		t.Fatalf("couldn't create test medium: %v", err)
+	}
+	record, err := store.CreateUserMediumRecord(ctx, database.CreateUserMediumRecordParams{UserID: user.ID, MediaID: medium.ID, Status: database.RecordStatusInProgress})
+	if err != nil {
+		t.Fatalf("couldn't create test record: %v", err)
+	}
+	_, err = store.CreateRecordPause(ctx, database.CreateRecordPauseParams{RecordID: record.ID, PausedAt: now()})
+	if err != nil {
+		t.Fatalf("couldn't create test pause: %v", err)
+	}
+	_, err = store.CreateReview(ctx, database.CreateReviewParams{RecordID: record.ID, Title: "A classic"})
+	if err != nil {
+		t.Fatalf("couldn't create test review: %v", err)
+	}
+	friend, err := store.CreateUser(ctx, database.CreateUserParams{Username: "friend", HashedPassword: "hash", Email: "friend@example.com"})
+	if err != nil {
+		t.Fatalf("couldn't create test user: %v", err)
+	}
+	books := pgtype.Text{String: "book", Valid: true}
+	_, err = store.CreateShare(ctx, database.CreateShareParams{OwnerID: user.ID, RecipientID: friend.ID, MediaType: books})
+	if err != nil {
+		t.Fatalf("couldn't create test share: %v", err)
+	}
+	tag, err := store.CreateTag(ctx, database.CreateTagParams{UserID: user.ID, Name: "Owned in French"})
+	if err != nil {
+		t.Fatalf("couldn't create test tag: %v", err)
+	}
+	_, err = store.CreateLoan(ctx, database.CreateLoanParams{OwnerID: user.ID, MediaID: medium.ID, BorrowerID: friend.ID, BorrowerName: "friend", LentAt: now()})
+	if err != nil {
+		t.Fatalf("couldn't create test loan: %v", err)
+	}
+	unknownID := newUUID()
+
+	// Create tests table
+	tests := []struct {
+		name     string
+		call     func() error
+		wantCode string
+	}{
+		{
+			name: "Duplicate username",
+			call: func() error {
+				_, err := store.CreateUser(ctx, database.CreateUserParams{Username: "user", Email: "other@example.com"})
+				return err
+			},
+			wantCode: codeUniqueViolation,
+		},
+		{
+			name: "Duplicate email on update",
+			call: func() error {
+				other, _ := store.CreateUser(ctx, database.CreateUserParams{Username: "other", Email: "other2@example.com"})
+				_, err := store.UpdateUser(ctx, database.UpdateUserParams{ID: other.ID, Username: "other", Email: "user@example.com"})
+				return err
+			},
+			wantCode: codeUniqueViolation,
+		},
+		{
+			name: "Duplicate media type, title, creator and publication year",
+			call: func() error {
+				_, err := store.CreateMedium(ctx, database.CreateMediumParams{MediaType: "book", Title: "emma", Creator: "jane austen", PubDate: "1815-12-23", Metadata: []byte("{}"), ExternalIds: []byte("{}")})
+				return err
+			},
+			wantCode: codeUniqueViolation,
+		},
+		{
+			name: "Duplicate provider ID",
+			call: func() error {
+				_, err := store.CreateMedium(ctx, database.CreateMediumParams{MediaType: "movie", Title: "Dune: Part One", Metadata: []byte("{}"), ExternalIds: []byte(`{"tmdb_movie": "438631"}`)})
+				return err
+			},
+			wantCode: codeUniqueViolation,
+		},
+		{
+			name: "Unknown provider",
+			call: func() error {
+				_, err := store.CreateMedium(ctx, database.CreateMediumParams{MediaType: "movie", Title: "Dune", Metadata: []byte("{}"), ExternalIds: []byte(`{"imdb": "tt1160419"}`)})
+				return err
+			},
+			wantCode: codeCheckViolation,
+		},
+		{
+			name: "Another record of the same user-medium couple",
+			call: func() error {
+				_, err := store.CreateUserMediumRecord(ctx, database.CreateUserMediumRecordParams{UserID: user.ID, MediaID: medium.ID, Status: database.RecordStatusPlanned})
+				return err
+			},
+			wantCode: "",
+		},
+		{
+			name: "Record with unknown medium",
+			call: func() error {
+				_, err := store.CreateUserMediumRecord(ctx, database.CreateUserMediumRecordParams{UserID: user.ID, MediaID: unknownID, Status: database.RecordStatusPlanned})
+				return err
+			},
+			wantCode: codeForeignKeyViolation,
+		},
+		{
+			name: "Rating above 100",
+			call: func() error {
+				_, err := store.CreateUserMediumRecord(ctx, database.CreateUserMediumRecordParams{UserID: user.ID, MediaID: medium.ID, Status: database.RecordStatusPlanned, Rating: pgtype.Int2{Int16: 101, Valid: true}})
+				return err
+			},
+			wantCode: codeCheckViolation,
+		},
+		{
+			name: "Record with unknown status",
+			call: func() error {
+				_, err := store.CreateUserMediumRecord(ctx, database.CreateUserMediumRecordParams{UserID: user.ID, MediaID: medium.ID, Status: "on_hold"})
+				return err
+			},
+			wantCode: codeCheckViolation,
+		},
+		{
+			name: "Second open pause of a record",
+			call: func() error {
+				_, err := store.CreateRecordPause(ctx, database.CreateRecordPauseParams{RecordID: record.ID, PausedAt: now()})
+				return err
+			},
+			wantCode: codeUniqueViolation,
+		},
+		{
+			name: "Second review of a record",
+			call: func() error {
+				_, err := store.CreateReview(ctx, database.CreateReviewParams{RecordID: record.ID, Title: "Again"})
+				return err
+			},
+			wantCode: codeUniqueViolation,
+		},
+		{
+			name: "Review without title",
+			call: func() error {
+				_, err := store.CreateReview(ctx, database.CreateReviewParams{RecordID: record.ID, Title: " "})
+				return err
+			},
+			wantCode: codeCheckViolation,
+		},
+		{
+			name: "Review of unknown record",
+			call: func() error {
+				_, err := store.CreateReview(ctx, database.CreateReviewParams{RecordID: unknownID, Title: "Emma"})
+				return err
+			},
+			wantCode: codeForeignKeyViolation,
+		},
+		{
+			name: "Revision of unknown review",
+			call: func() error {
+				_, err := store.CreateReviewRevision(ctx, database.CreateReviewRevisionParams{ReviewID: unknownID, Title: "Draft"})
+				return err
+			},
+			wantCode: codeForeignKeyViolation,
+		},
+		{
+			name: "Refresh token for unknown user",
+			call: func() error {
+				_, err := store.CreateRefreshToken(ctx, database.CreateRefreshTokenParams{Token: "token", UserID: unknownID, ExpiresAt: now()})
+				return err
+			},
+			wantCode: codeForeignKeyViolation,
+		},
+		{
+			name: "Duplicate compartment share",
+			call: func() error {
+				_, err := store.CreateShare(ctx, database.CreateShareParams{OwnerID: user.ID, RecipientID: friend.ID, MediaType: books})
+				return err
+			},
+			wantCode: codeUniqueViolation,
+		},
+		{
+			name: "Share with yourself",
+			call: func() error {
+				_, err := store.CreateShare(ctx, database.CreateShareParams{OwnerID: user.ID, RecipientID: user.ID, MediaType: books})
+				return err
+			},
+			wantCode: codeCheckViolation,
+		},
+		{
+			name: "Share with both a record and a media type",
+			call: func() error {
+				_, err := store.CreateShare(ctx, database.CreateShareParams{OwnerID: friend.ID, RecipientID: user.ID, RecordID: unknownID, MediaType: books})
+				return err
+			},
+			wantCode: codeCheckViolation,
+		},
+		{
+			name: "Share of unknown record",
+			call: func() error {
+				_, err := store.CreateShare(ctx, database.CreateShareParams{OwnerID: friend.ID, RecipientID: user.ID, RecordID: unknownID})
+				return err
+			},
+			wantCode: codeForeignKeyViolation,
+		},
+		{
+			name: "Second open loan of a medium",
+			call: func() error {
+				_, err := store.CreateLoan(ctx, database.CreateLoanParams{OwnerID: user.ID, MediaID: medium.ID, BorrowerName: "Jane", LentAt: now()})
+				return err
+			},
+			wantCode: codeUniqueViolation,
+		},
+		{
+			name: "Loan due before it was lent",
+			call: func() error {
+				lentAt := now()
+				_, err := store.CreateLoan(ctx, database.CreateLoanParams{OwnerID: friend.ID, MediaID: medium.ID, BorrowerName: "Jane", LentAt: lentAt, DueAt: pgtype.Timestamp{Time: lentAt.Time.AddDate(0, 0, -1), Valid: true}})
+				return err
+			},
+			wantCode: codeCheckViolation,
+		},
+		{
+			name: "Loan to yourself",
+			call: func() error {
+				_, err := store.CreateLoan(ctx, database.CreateLoanParams{OwnerID: friend.ID, MediaID: medium.ID, BorrowerID: friend.ID, BorrowerName: "friend", LentAt: now()})
+				return err
+			},
+			wantCode: codeCheckViolation,
+		},
+		{
+			name: "Loan of unknown medium",
+			call: func() error {
+				_, err := store.CreateLoan(ctx, database.CreateLoanParams{OwnerID: user.ID, MediaID: unknownID, BorrowerName: "Jane", LentAt: now()})
+				return err
+			},
+			wantCode: codeForeignKeyViolation,
+		},
+		{
+			name: "Owned copy without format",
+			call: func() error {
+				_, err := store.CreateOwnedCopy(ctx, database.CreateOwnedCopyParams{UserID: user.ID, MediaID: medium.ID, Format: " "})
+				return err
+			},
+			wantCode: codeCheckViolation,
+		},
+		{
+			name: "Owned copy with a price and no currency",
+			call: func() error {
+				_, err := store.CreateOwnedCopy(ctx, database.CreateOwnedCopyParams{UserID: user.ID, MediaID: medium.ID, Format: "ebook", PriceCents: pgtype.Int8{Int64: 350, Valid: true}})
+				return err
+			},
+			wantCode: codeCheckViolation,
+		},
+		{
+			name: "Owned copy in unknown condition",
+			call: func() error {
+				_, err := store.CreateOwnedCopy(ctx, database.CreateOwnedCopyParams{UserID: user.ID, MediaID: medium.ID, Format: "hardcover", Condition: "mint"})
+				return err
+			},
+			wantCode: codeCheckViolation,
+		},
+		{
+			name: "Owned copy of unknown medium",
+			call: func() error {
+				_, err := store.CreateOwnedCopy(ctx, database.CreateOwnedCopyParams{UserID: user.ID, MediaID: unknownID, Format: "ebook"})
+				return err
+			},
+			wantCode: codeForeignKeyViolation,
+		},
+		{
+			name: "Shelving unit with too many rows",
+			call: func() error {
+				_, err := store.CreateShelvingUnit(ctx, database.CreateShelvingUnitParams{UserID: user.ID, Name: "Kallax", RowsCount: 21, ColumnsCount: 4, CellWidthCm: 33, CellHeightCm: 33, CellDepthCm: 39})
+				return err
+			},
+			wantCode: codeCheckViolation,
+		},
+		{
+			name: "Shelving item in unknown unit",
+			call: func() error {
+				_, err := store.PlaceShelvingItem(ctx, database.PlaceShelvingItemParams{UserID: user.ID, MediaID: medium.ID, UnitID: unknownID, CellRow: 1, CellColumn: 1})
+				return err
+			},
+			wantCode: codeForeignKeyViolation,
+		},
+		{
+			name: "Play lasting no time",
+			call: func() error {
+				_, err := store.SavePlay(ctx, database.SavePlayParams{UserID: user.ID, MediaID: medium.ID, PlayedAt: now(), DurationMinutes: pgtype.Int4{Valid: true}, Expansions: []string{}})
+				return err
+			},
+			wantCode: codeCheckViolation,
+		},
+		{
+			name: "Play with unknown player",
+			call: func() error {
+				_, err := store.SavePlay(ctx, database.SavePlayParams{UserID: user.ID, MediaID: medium.ID, PlayedAt: now(), Expansions: []string{}, Players: []database.SavePlayPlayerParams{{PlayerID: unknownID, PlayerName: "ghost"}}})
+				return err
+			},
+			wantCode: codeForeignKeyViolation,
+		},
+		{
+			name: "Videogame details with more achievements than total",
+			call: func() error {
+				_, err := store.UpsertRecordVideogame(ctx, database.UpsertRecordVideogameParams{RecordID: record.ID, AchievementsEarned: pgtype.Int4{Int32: 12, Valid: true}, AchievementsTotal: pgtype.Int4{Int32: 10, Valid: true}})
+				return err
+			},
+			wantCode: codeCheckViolation,
+		},
+		{
+			name: "Videogame details of unknown record",
+			call: func() error {
+				_, err := store.UpsertRecordVideogame(ctx, database.UpsertRecordVideogameParams{RecordID: unknownID, Platform: "PC"})
+				return err
+			},
+			wantCode: codeForeignKeyViolation,
+		},
+		{
+			name: "Episode of season 0",
+			call: func() error {
+				_, err := store.MarkRecordEpisode(ctx, database.MarkRecordEpisodeParams{RecordID: record.ID, SeasonNumber: 0, EpisodeNumber: 1, WatchedAt: now()})
+				return err
+			},
+			wantCode: codeCheckViolation,
+		},
+		{
+			name: "Episode of unknown record",
+			call: func() error {
+				_, err := store.MarkRecordEpisode(ctx, database.MarkRecordEpisodeParams{RecordID: unknownID, SeasonNumber: 1, EpisodeNumber: 1, WatchedAt: now()})
+				return err
+			},
+			wantCode: codeForeignKeyViolation,
+		},
+		{
+			name: "Medium without metadata",
+			call: func() error {
+				_, err := store.CreateMedium(ctx, database.CreateMediumParams{MediaType: "book", Title: "Persuasion"})
+				return err
+			},
+			wantCode: codeNotNullViolation,
+		},
+		{
+			name: "Duplicate tag name, other case",
+			call: func() error {
+				_, err := store.CreateTag(ctx, database.CreateTagParams{UserID: user.ID, Name: "owned in french"})
+				return err
+			},
+			wantCode: codeUniqueViolation,
+		},
+		{
+			name: "Blank shelf name",
+			call: func() error {
+				_, err := store.CreateShelf(ctx, database.CreateShelfParams{UserID: user.ID, Name: " "})
+				return err
+			},
+			wantCode: codeCheckViolation,
+		},
+		{
+			name: "Tag of unknown medium",
+			call: func() error {
+				_, err := store.TagMedia(ctx, database.TagMediaParams{TagID: tag.ID, MediaIds: []pgtype.UUID{medium.ID, unknownID}})
+				return err
+			},
+			wantCode: codeForeignKeyViolation,
+		},
+		{
+			name: "Goal without target",
+			call: func() error {
+				_, err := store.SaveGoal(ctx, database.SaveGoalParams{UserID: user.ID, MediaType: "book", Metric: "count", Period: "year", PeriodStart: now(), PeriodEnd: pgtype.Timestamp{Time: now().Time.AddDate(1, 0, 0), Valid: true}})
+				return err
+			},
+			wantCode: codeCheckViolation,
+		},
+		{
+			name: "Goal of unknown tag",
+			call: func() error {
+				_, err := store.SaveGoal(ctx, database.SaveGoalParams{UserID: user.ID, MediaType: "book", Metric: "count", Target: 5, Period: "year", PeriodStart: now(), PeriodEnd: pgtype.Timestamp{Time: now().Time.AddDate(1, 0, 0), Valid: true}, TagIDs: []pgtype.UUID{tag.ID, unknownID}})
+				return err
+			},
+			wantCode: codeForeignKeyViolation,
+		},
+	}
+
+	// Test loop
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if code := pgErrorCode(err); code != tt.wantCode {
+				t.Errorf("error = %v, want code %s", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestCascadeDelete(t *testing.T) {
+	ctx := context.Background()
+	store := New()
+
+	user, _ := store.CreateUser(ctx, database.CreateUserParams{Username: "user", Email: "user@example.com"})
+	medium, _ := store.CreateMedium(ctx, database.CreateMediumParams{MediaType: "book", Title: "Emma", Metadata: []byte("{}"), ExternalIds: []byte("{}")})
+	record, _ := store.CreateUserMediumRecord(ctx, database.CreateUserMediumRecordParams{UserID: user.ID, MediaID: medium.ID, Status: database.RecordStatusPlanned})
+	store.CreateRefreshToken(ctx, database.CreateRefreshTokenParams{Token: "token", UserID: user.ID, ExpiresAt: now()})
+	friend, _ := store.CreateUser(ctx, database.CreateUserParams{Username: "friend", Email: "friend@example.com"})
+	recordShare, _ := store.CreateShare(ctx, database.CreateShareParams{OwnerID: user.ID, RecipientID: friend.ID, RecordID: record.ID})
+	compartmentShare, _ := store.CreateShare(ctx, database.CreateShareParams{OwnerID: friend.ID, RecipientID: user.ID, MediaType: pgtype.Text{String: "book", Valid: true}})
+	ownedMedium, _ := store.CreateMedium(ctx, database.CreateMediumParams{MediaType: "book", Title: "Persuasion", Metadata: []byte("{}"), ExternalIds: []byte("{}"), CreatedBy: user.ID})
+	tag, _ := store.CreateTag(ctx, database.CreateTagParams{UserID: user.ID, Name: "Favourites"})
+	shelf, _ := store.CreateShelf(ctx, database.CreateShelfParams{UserID: user.ID, Name: "Summer"})
+	store.TagMedia(ctx, database.TagMediaParams{TagID: tag.ID, MediaIds: []pgtype.UUID{medium.ID, ownedMedium.ID}})
+	store.AddMediaToShelf(ctx, database.AddMediaToShelfParams{ShelfID: shelf.ID, MediaIds: []pgtype.UUID{medium.ID}})
+	store.CreateLoan(ctx, database.CreateLoanParams{OwnerID: user.ID, MediaID: medium.ID, BorrowerName: "Jane", LentAt: now()})
+	friendLoan, _ := store.CreateLoan(ctx, database.CreateLoanParams{OwnerID: friend.ID, MediaID: ownedMedium.ID, BorrowerID: user.ID, BorrowerName: "user", LentAt: now()})
+	store.CreateOwnedCopy(ctx, database.CreateOwnedCopyParams{UserID: user.ID, MediaID: medium.ID, Format: "hardcover"})
+	store.CreateOwnedCopy(ctx, database.CreateOwnedCopyParams{UserID: user.ID, MediaID: ownedMedium.ID, Format: "ebook"})
+	unit, _ := store.CreateShelvingUnit(ctx, database.CreateShelvingUnitParams{UserID: user.ID, Name: "Kallax", RowsCount: 4, ColumnsCount: 4, CellWidthCm: 33, CellHeightCm: 33, CellDepthCm: 39})
+	store.PlaceShelvingItem(ctx, database.PlaceShelvingItemParams{UserID: user.ID, MediaID: medium.ID, UnitID: unit.ID, CellRow: 1, CellColumn: 1})
+	store.PlaceShelvingItem(ctx, database.PlaceShelvingItemParams{UserID: user.ID, MediaID: ownedMedium.ID, UnitID: unit.ID, CellRow: 2, CellColumn: 1})
+	store.SavePlay(ctx, database.SavePlayParams{UserID: user.ID, MediaID: medium.ID, PlayedAt: now(), Expansions: []string{}, Players: []database.SavePlayPlayerParams{{PlayerID: user.ID, PlayerName: "user"}}})
+	store.UpsertRecordVideogame(ctx, database.UpsertRecordVideogameParams{RecordID: record.ID, Platform: "PC"})
+	store.MarkRecordEpisode(ctx, database.MarkRecordEpisodeParams{RecordID: record.ID, SeasonNumber: 1, EpisodeNumber: 1, WatchedAt: now()})
+	friendPlay, _ := store.SavePlay(ctx, database.SavePlayParams{UserID: friend.ID, MediaID: ownedMedium.ID, PlayedAt: now(), Expansions: []string{}, Players: []database.SavePlayPlayerParams{{PlayerID: friend.ID, PlayerName: "friend"}, {PlayerID: user.ID, PlayerName: "user", IsWinner: true}}})
+	goal, _ := store.SaveGoal(ctx, database.SaveGoalParams{UserID: user.ID, MediaType: "book", Metric: "count", Target: 5, Period: "year", PeriodStart: now(), PeriodEnd: pgtype.Timestamp{Time: now().Time.AddDate(1, 0, 0), Valid: true}, TagIDs: []pgtype.UUID{tag.ID}})
+
+	// Deleting the medium deletes its records, the shares of those records, its tags and shelves links, its loans, owned copies, place in shelving units and plays
+	count, err := store.DeleteMedium(ctx, medium.ID)
+	if err != nil || count != 1 {
+		t.Fatalf("DeleteMedium() count = %v, err = %v", count, err)
+	}
+	if _, err := store.GetRecordByID(ctx, record.ID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("record should have been deleted, got err = %v", err)
+	}
+	if _, err := store.GetRecordVideogame(ctx, record.ID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("record's videogame details should have been deleted, got err = %v", err)
+	}
+	if episodes, _ := store.GetRecordEpisodes(ctx, record.ID); len(episodes) != 0 {
+		t.Errorf("record's episodes should have been deleted, got %v", episodes)
+	}
+	if _, err := store.GetShareByID(ctx, recordShare.ID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("record share should have been deleted, got err = %v", err)
+	}
+	if _, err := store.GetShareByID(ctx, compartmentShare.ID); err != nil {
+		t.Errorf("compartment share shouldn't have been deleted, got err = %v", err)
+	}
+	if mediaTags, _ := store.GetUserMediaTags(ctx, user.ID); len(mediaTags) != 1 || mediaTags[0].MediaID != ownedMedium.ID {
+		t.Errorf("only the deleted medium's tag should have been deleted, got %v", mediaTags)
+	}
+	if shelvesMedia, _ := store.GetUserShelvesMedia(ctx, user.ID); len(shelvesMedia) != 0 {
+		t.Errorf("deleted medium should have left the shelf, got %v", shelvesMedia)
+	}
+	if loans, _ := store.GetMediumLoans(ctx, database.GetMediumLoansParams{OwnerID: user.ID, MediaID: medium.ID}); len(loans) != 0 {
+		t.Errorf("deleted medium's loans should have been deleted, got %v", loans)
+	}
+	if copies, _ := store.GetOwnedCopiesByUserID(ctx, user.ID); len(copies) != 1 || copies[0].MediaID != ownedMedium.ID {
+		t.Errorf("only the deleted medium's copy should have been deleted, got %v", copies)
+	}
+	if items, _ := store.GetShelvingUnitItems(ctx, unit.ID); len(items) != 1 || items[0].MediaID != ownedMedium.ID {
+		t.Errorf("only the deleted medium should have left the shelving unit, got %v", items)
+	}
+	if plays, _ := store.GetPlaysByUserID(ctx, user.ID); len(plays) != 0 {
+		t.Errorf("deleted medium's plays should have been deleted, got %v", plays)
+	}
+	if players, _ := store.GetPlayersByUserID(ctx, user.ID); len(players) != 0 {
+		t.Errorf("deleted medium's plays' players should have been deleted, got %v", players)
+	}
+
+	// Deleting the user deletes its tokens, tags, shelves, owned copies, shelving units, goals and the shares it received, and keeps the media it created, the loans made to it and its seats in others' plays
+	store.DeleteUser(ctx, user.ID)
+	if _, err := store.GetRefreshToken(ctx, "token"); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("refresh token should have been deleted, got err = %v", err)
+	}
+	if got, err := store.GetMediumByID(ctx, ownedMedium.ID); err != nil || got.CreatedBy.Valid {
+		t.Errorf("medium's created_by should have been set to NULL, got %v, err = %v", got.CreatedBy, err)
+	}
+	if _, err := store.GetShareByID(ctx, compartmentShare.ID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("compartment share should have been deleted, got err = %v", err)
+	}
+	if _, err := store.GetUserTagByID(ctx, database.GetUserTagByIDParams{ID: tag.ID, UserID: user.ID}); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("tag should have been deleted, got err = %v", err)
+	}
+	if _, err := store.GetUserShelfByID(ctx, database.GetUserShelfByIDParams{ID: shelf.ID, UserID: user.ID}); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("shelf should have been deleted, got err = %v", err)
+	}
+	if mediaTags, _ := store.GetUserMediaTags(ctx, user.ID); len(mediaTags) != 0 {
+		t.Errorf("tag's media links should have been deleted, got %v", mediaTags)
+	}
+	if got, err := store.GetUserLoanByID(ctx, database.GetUserLoanByIDParams{ID: friendLoan.ID, OwnerID: friend.ID}); err != nil || got.BorrowerID.Valid || got.BorrowerName != "user" {
+		t.Errorf("loan's borrower_id should have been set to NULL and its name kept, got %v, err = %v", got, err)
+	}
+	if copies, _ := store.GetOwnedCopiesByUserID(ctx, user.ID); len(copies) != 0 {
+		t.Errorf("user's owned copies should have been deleted, got %v", copies)
+	}
+	if _, err := store.GetUserShelvingUnitByID(ctx, database.GetUserShelvingUnitByIDParams{ID: unit.ID, UserID: user.ID}); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("shelving unit should have been deleted, got err = %v", err)
+	}
+	if items, _ := store.GetShelvingUnitItems(ctx, unit.ID); len(items) != 0 {
+		t.Errorf("shelving unit's items should have been deleted, got %v", items)
+	}
+	if _, err := store.GetUserGoalByID(ctx, database.GetUserGoalByIDParams{ID: goal.Goal.ID, UserID: user.ID}); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("goal should have been deleted, got err = %v", err)
+	}
+	if goalTags, _ := store.GetGoalTagsByUserID(ctx, user.ID); len(goalTags) != 0 {
+		t.Errorf("goal's tags should have been deleted, got %v", goalTags)
+	}
+	if players, _ := store.GetPlayersByUserID(ctx, friend.ID); len(players) != 2 || players[1].PlayID != friendPlay.Play.ID || players[1].PlayerID.Valid || players[1].PlayerName != "user" || !players[1].IsWinner {
+		t.Errorf("player's player_id should have been set to NULL and its name kept, got %v", players)
+	}
+
+	// Deleting an unknown row counts nothing
+	count, err = store.DeleteUser(ctx, pgtype.UUID{})
+	if err != nil || count != 0 {
+		t.Errorf("DeleteUser() count = %v, err = %v", count, err)
+	}
+}
+
+func TestDeleteRecord(t *testing.T) {
+	ctx := context.Background()
+	store := New()
+
+	user, _ := store.CreateUser(ctx, database.CreateUserParams{Username: "user", Email: "user@example.com"})
+	friend, _ := store.CreateUser(ctx, database.CreateUserParams{Username: "friend", Email: "friend@example.com"})
+	medium, _ := store.CreateMedium(ctx, database.CreateMediumParams{MediaType: "book", Title: "Emma", Metadata: []byte("{}"), ExternalIds: []byte("{}")})
+	firstRead, _ := store.CreateUserMediumRecord(ctx, database.CreateUserMediumRecordParams{UserID: user.ID, MediaID: medium.ID, Status: database.RecordStatusPlanned})
+	secondRead, _ := store.CreateUserMediumRecord(ctx, database.CreateUserMediumRecordParams{UserID: user.ID, MediaID: medium.ID, Status: database.RecordStatusPlanned})
+	thirdRead, _ := store.CreateUserMediumRecord(ctx, database.CreateUserMediumRecordParams{UserID: user.ID, MediaID: medium.ID, Status: database.RecordStatusPlanned})
+	friendRead, _ := store.CreateUserMediumRecord(ctx, database.CreateUserMediumRecordParams{UserID: friend.ID, MediaID: medium.ID, Status: database.RecordStatusPlanned})
+	for _, record := range []database.UsersMediaRecord{firstRead, secondRead} {
+		_, err := store.CreateRecordProgress(ctx, database.CreateRecordProgressParams{RecordID: record.ID, LoggedAt: now(), Pages: pgtype.Int4{Int32: 10, Valid: true}})
+		if err != nil {
+			t.Fatalf("CreateRecordProgress() err = %v", err)
+		}
+		_, err = store.CreateRecordPause(ctx, database.CreateRecordPauseParams{RecordID: record.ID, PausedAt: now()})
+		if err != nil {
+			t.Fatalf("CreateRecordPause() err = %v", err)
+		}
+		review, err := store.CreateReview(ctx, database.CreateReviewParams{RecordID: record.ID, Title: "Review"})
+		if err != nil {
+			t.Fatalf("CreateReview() err = %v", err)
+		}
+		_, err = store.CreateReviewRevision(ctx, database.CreateReviewRevisionParams{ReviewID: review.ID, Title: review.Title})
+		if err != nil {
+			t.Fatalf("CreateReviewRevision() err = %v", err)
+		}
+	}
+
+	// Another user's record can't be deleted
+	count, err := store.DeleteRecord(ctx, database.DeleteRecordParams{ID: friendRead.ID, UserID: user.ID})
+	if err != nil || count != 0 {
+		t.Errorf("DeleteRecord() on friend's record count = %v, err = %v", count, err)
+	}
+
+	// Only the given record is deleted
+	count, err = store.DeleteRecord(ctx, database.DeleteRecordParams{ID: firstRead.ID, UserID: user.ID})
+	if err != nil || count != 1 {
+		t.Fatalf("DeleteRecord() count = %v, err = %v", count, err)
+	}
+	if _, err := store.GetRecordByID(ctx, secondRead.ID); err != nil {
+		t.Errorf("other records of the medium shouldn't have been deleted, got err = %v", err)
+	}
+	// Progress log goes with its record
+	if progress, _ := store.GetRecordProgress(ctx, firstRead.ID); len(progress) != 0 {
+		t.Errorf("deleted record's progress should have been deleted, got %v", progress)
+	}
+	if progress, _ := store.GetRecordProgress(ctx, secondRead.ID); len(progress) != 1 {
+		t.Errorf("other records' progress shouldn't have been deleted, got %v", progress)
+	}
+	// And so do its pauses
+	if pauses, _ := store.GetRecordPauses(ctx, firstRead.ID); len(pauses) != 0 {
+		t.Errorf("deleted record's pauses should have been deleted, got %v", pauses)
+	}
+	if pauses, _ := store.GetRecordPauses(ctx, secondRead.ID); len(pauses) != 1 {
+		t.Errorf("other records' pauses shouldn't have been deleted, got %v", pauses)
+	}
+	// And its review, with the review's revisions
+	if _, err := store.GetReviewByRecordID(ctx, firstRead.ID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("deleted record's review should have been deleted, got err = %v", err)
+	}
+	if len(store.revisions) != 1 {
+		t.Errorf("only other records' review revisions should be left, got %v", store.revisions)
+	}
+
+	// Removing the medium from user's shelf deletes all its remaining records, the medium is kept while friend holds it
+	result, err := store.DeleteMediumForUser(ctx, database.DeleteMediumForUserParams{MediumID: medium.ID, UserID: user.ID, CanDeleteMedium: true})
+	if err != nil || result.DeletedRecords != 2 || result.OtherUsersRecords != 1 || result.MediumDeleted {
+		t.Errorf("DeleteMediumForUser() result = %+v, err = %v", result, err)
+	}
+	if _, err := store.GetRecordByID(ctx, thirdRead.ID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("record should have been deleted, got err = %v", err)
+	}
+	if _, err := store.GetRecordByID(ctx, friendRead.ID); err != nil {
+		t.Errorf("friend's record shouldn't have been deleted, got err = %v", err)
+	}
+	if _, err := store.GetMediumByID(ctx, medium.ID); err != nil {
+		t.Errorf("medium held by friend shouldn't have been deleted, got err = %v", err)
+	}
+
+	// Once friend removed it too, the medium goes with the last records
+	result, err = store.DeleteMediumForUser(ctx, database.DeleteMediumForUserParams{MediumID: medium.ID, UserID: friend.ID, CanDeleteMedium: true})
+	if err != nil || result.DeletedRecords != 1 || !result.MediumDeleted {
+		t.Errorf("DeleteMediumForUser() result = %+v, err = %v", result, err)
+	}
+	if _, err := store.GetMediumByID(ctx, medium.ID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("medium should have been deleted, got err = %v", err)
+	}
+}
+
+func TestMergeMedia(t *testing.T) {
+	ctx := context.Background()
+	store := New()
+
+	user, _ := store.CreateUser(ctx, database.CreateUserParams{Username: "user", Email: "user@example.com"})
+	admin, _ := store.CreateUser(ctx, database.CreateUserParams{Username: "admin", Email: "admin@example.com"})
+	target, _ := store.CreateMedium(ctx, database.CreateMediumParams{MediaType: "book", Title: "Emma", Metadata: []byte("{}"), ExternalIds: []byte("{}")})
+	source, _ := store.CreateMedium(ctx, database.CreateMediumParams{MediaType: "book", Title: "Emma.", Metadata: []byte("{}"), ExternalIds: []byte("{}")})
+	targetRead, _ := store.CreateUserMediumRecord(ctx, database.CreateUserMediumRecordParams{UserID: user.ID, MediaID: target.ID, Status: database.RecordStatusPaused})
+	sourceRead, _ := store.CreateUserMediumRecord(ctx, database.CreateUserMediumRecordParams{UserID: user.ID, MediaID: source.ID, Status: database.RecordStatusPaused})
+	// Both records were paused once, and are on hold again
+	for _, record := range []database.UsersMediaRecord{targetRead, sourceRead} {
+		_, err := store.CreateRecordPause(ctx, database.CreateRecordPauseParams{RecordID: record.ID, PausedAt: now()})
+		if err != nil {
+			t.Fatalf("CreateRecordPause() err = %v", err)
+		}
+		err = store.ResumeRecordPause(ctx, database.ResumeRecordPauseParams{RecordID: record.ID, ResumedAt: now()})
+		if err != nil {
+			t.Fatalf("ResumeRecordPause() err = %v", err)
+		}
+		_, err = store.CreateRecordPause(ctx, database.CreateRecordPauseParams{RecordID: record.ID, PausedAt: now()})
+		if err != nil {
+			t.Fatalf("CreateRecordPause() err = %v", err)
+		}
+	}
+
+	// User lent both media out, friend only source
+	friend, _ := store.CreateUser(ctx, database.CreateUserParams{Username: "friend", Email: "friend@example.com"})
+	store.CreateLoan(ctx, database.CreateLoanParams{OwnerID: user.ID, MediaID: target.ID, BorrowerName: "Jane", LentAt: now()})
+	userLoan, _ := store.CreateLoan(ctx, database.CreateLoanParams{OwnerID: user.ID, MediaID: source.ID, BorrowerName: "John", LentAt: now()})
+	friendLoan, _ := store.CreateLoan(ctx, database.CreateLoanParams{OwnerID: friend.ID, MediaID: source.ID, BorrowerName: "Jane", LentAt: now()})
+
+	result, err := store.MergeMedia(ctx, database.MergeMediaParams{TargetID: target.ID, SourceID: source.ID, MergedBy: admin.ID})
+	if err != nil || result.MergedRecords != 1 || result.ClosedLoans != 1 {
+		t.Fatalf("MergeMedia() result = %+v, err = %v", result, err)
+	}
+
+	// Records are as rich as each other, target's one is kept
+	if _, err := store.GetRecordByID(ctx, sourceRead.ID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("source's record should have been merged, got err = %v", err)
+	}
+	// Kept record gets dropped one's past pauses, but keeps a single open pause
+	pauses, _ := store.GetRecordPauses(ctx, targetRead.ID)
+	open := 0
+	for _, pause := range pauses {
+		if !pause.ResumedAt.Valid {
+			open++
+		}
+	}
+	if len(pauses) != 3 || open != 1 {
+		t.Errorf("expected 3 pauses with a single open one, got %v", pauses)
+	}
+	if len(store.pauses) != 3 {
+		t.Errorf("dropped record's open pause should have been deleted, got %v", store.pauses)
+	}
+	// Source's loans are kept on target, user's one being closed as target is lent out already
+	for _, loan := range []database.Loan{userLoan, friendLoan} {
+		i := store.loanIndex(loan.ID)
+		if i == -1 || !sameUUID(store.loans[i].MediaID, target.ID) {
+			t.Fatalf("source's loan %v should have moved to target", loan.BorrowerName)
+		}
+		if closed := store.loans[i].ReturnedAt.Valid; closed != (loan.ID == userLoan.ID) {
+			t.Errorf("loan of %v closed = %v", loan.BorrowerName, closed)
+		}
+	}
+}
+
+func TestSaveRecordUpdate(t *testing.T) {
+	ctx := context.Background()
+	store := New()
+
+	user, _ := store.CreateUser(ctx, database.CreateUserParams{Username: "user", Email: "user@example.com"})
+	medium, _ := store.CreateMedium(ctx, database.CreateMediumParams{MediaType: "book", Title: "Emma", Metadata: []byte("{}"), ExternalIds: []byte("{}")})
+	record, _ := store.CreateUserMediumRecord(ctx, database.CreateUserMediumRecordParams{UserID: user.ID, MediaID: medium.ID, Status: database.RecordStatusInProgress, StartDate: now()})
+	update := database.UpdateRecordParams{ID: record.ID, UserID: user.ID, StartDate: record.StartDate, Status: database.RecordStatusPaused, Comments: "On hold"}
+
+	// A failing progress entry leaves the record and its pauses untouched
+	_, err := store.SaveRecordUpdate(ctx, database.SaveRecordUpdateParams{
+		Record:   update,
+		PausedAt: now(),
+		Progress: &database.CreateRecordProgressParams{RecordID: record.ID, LoggedAt: now()},
+	})
+	if pgErrorCode(err) != codeCheckViolation {
+		t.Fatalf("SaveRecordUpdate() err = %v, expected check violation", err)
+	}
+	if got, _ := store.GetRecordByID(ctx, record.ID); got.Status != database.RecordStatusInProgress || got.Comments != "" {
+		t.Errorf("record shouldn't have been updated, got %+v", got)
+	}
+	if pauses, _ := store.GetRecordPauses(ctx, record.ID); len(pauses) != 0 {
+		t.Errorf("no pause should have been opened, got %v", pauses)
+	}
+
+	// Otherwise all is written
+	result, err := store.SaveRecordUpdate(ctx, database.SaveRecordUpdateParams{
+		Record:   update,
+		PausedAt: now(),
+		Progress: &database.CreateRecordProgressParams{RecordID: record.ID, LoggedAt: now(), Pages: pgtype.Int4{Int32: 10, Valid: true}},
+	})
+	if err != nil || result.Record.Status != database.RecordStatusPaused || result.Progress == nil {
+		t.Fatalf("SaveRecordUpdate() result = %+v, err = %v", result, err)
+	}
+	if pauses, _ := store.GetRecordPauses(ctx, record.ID); len(pauses) != 1 || pauses[0].ResumedAt.Valid {
+		t.Errorf("expected an open pause, got %v", pauses)
+	}
+
+	// Resuming closes the pause
+	update.Status = database.RecordStatusInProgress
+	_, err = store.SaveRecordUpdate(ctx, database.SaveRecordUpdateParams{Record: update, ResumedAt: now()})
+	if err != nil {
+		t.Fatalf("SaveRecordUpdate() err = %v", err)
+	}
+	if pauses, _ := store.GetRecordPauses(ctx, record.ID); len(pauses) != 1 || !pauses[0].ResumedAt.Valid {
+		t.Errorf("expected pause to be closed, got %v", pauses)
+	}
+}
@@ -0,0 +1,264 @@
+package memstore
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Find an owned copy's index by ID, -1 if not found (caller must hold the lock)
+func (s *MemStore) ownedCopyIndex(id pgtype.UUID) int {
+	for i, ownedCopy := range s.copies {
+		if sameUUID(ownedCopy.ID, id) {
+			return i
+		}
+	}
+	return -1
+}
+
+var currencyRegex = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// CHECK constraints of owned_copies
+func checkOwnedCopy(candidate database.OwnedCopy) error {
+	if strings.TrimSpace(candidate.Format) == "" {
+		return checkViolation("owned_copies", "owned_copies_format_check")
+	}
+	if candidate.Condition != "" && !slices.Contains(database.CopyConditions, candidate.Condition) {
+		return checkViolation("owned_copies", "owned_copies_condition_check")
+	}
+	if candidate.PriceCents.Valid && candidate.PriceCents.Int64 < 0 {
+		return checkViolation("owned_copies", "owned_copies_price_cents_check")
+	}
+	if candidate.Currency != "" && !currencyRegex.MatchString(candidate.Currency) {
+		return checkViolation("owned_copies", "owned_copies_currency_check")
+	}
+	if candidate.PriceCents.Valid && candidate.Currency == "" {
+		return checkViolation("owned_copies", "owned_copies_check")
+	}
+	return nil
+}
+
+func (s *MemStore) CreateOwnedCopy(ctx context.Context, arg database.CreateOwnedCopyParams) (database.OwnedCopy, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// NOT NULL constraints
+	if !arg.UserID.Valid {
+		return database.OwnedCopy{}, notNullViolation("owned_copies", "user_id")
+	}
+	if !arg.MediaID.Valid {
+		return database.OwnedCopy{}, notNullViolation("owned_copies", "media_id")
+	}
+
+	timestamp := now()
+	ownedCopy := database.OwnedCopy{
+		ID:           newUUID(),
+		CreatedAt:    timestamp,
+		UpdatedAt:    timestamp,
+		UserID:       arg.UserID,
+		MediaID:      arg.MediaID,
+		Format:       arg.Format,
+		Condition:    arg.Condition,
+		Language:     arg.Language,
+		PurchaseDate: arg.PurchaseDate,
+		PriceCents:   arg.PriceCents,
+		Currency:     arg.Currency,
+		Store:        arg.Store,
+	}
+	if err := checkOwnedCopy(ownedCopy); err != nil {
+		return database.OwnedCopy{}, err
+	}
+
+	// Foreign keys
+	if s.userIndex(arg.UserID) == -1 {
+		return database.OwnedCopy{}, foreignKeyViolation("owned_copies", "owned_copies_user_id_fkey", fmt.Sprintf("Key (user_id)=(%s) is not present in table \"users\".", arg.UserID))
+	}
+	if s.mediumIndex(arg.MediaID) == -1 {
+		return database.OwnedCopy{}, foreignKeyViolation("owned_copies", "owned_copies_media_id_fkey", fmt.Sprintf("Key (media_id)=(%s) is not present in table \"media\".", arg.MediaID))
+	}
+
+	s.copies = append(s.copies, ownedCopy)
+	return ownedCopy, nil
+}
+
+func (s *MemStore) GetUserOwnedCopyByID(ctx context.Context, arg database.GetUserOwnedCopyByIDParams) (database.OwnedCopy, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := s.ownedCopyIndex(arg.ID)
+	if i == -1 || !sameUUID(s.copies[i].UserID, arg.UserID) {
+		return database.OwnedCopy{}, pgx.ErrNoRows
+	}
+	return s.copies[i], nil
+}
+
+func (s *MemStore) GetOwnedCopiesByUserID(ctx context.Context, userID pgtype.UUID) ([]database.GetOwnedCopiesByUserIDRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []database.GetOwnedCopiesByUserIDRow
+	for _, ownedCopy := range s.copies {
+		if !sameUUID(ownedCopy.UserID, userID) {
+			continue
+		}
+		m := s.mediumIndex(ownedCopy.MediaID)
+		if m == -1 {
+			continue
+		}
+		items = append(items, database.GetOwnedCopiesByUserIDRow{
+			ID:           ownedCopy.ID,
+			CreatedAt:    ownedCopy.CreatedAt,
+			UpdatedAt:    ownedCopy.UpdatedAt,
+			UserID:       ownedCopy.UserID,
+			MediaID:      ownedCopy.MediaID,
+			MediaType:    s.media[m].MediaType,
+			Title:        s.media[m].Title,
+			Format:       ownedCopy.Format,
+			Condition:    ownedCopy.Condition,
+			Language:     ownedCopy.Language,
+			PurchaseDate: ownedCopy.PurchaseDate,
+			PriceCents:   ownedCopy.PriceCents,
+			Currency:     ownedCopy.Currency,
+			Store:        ownedCopy.Store,
+		})
+	}
+	// ORDER BY media_type, lower(title), created_at, id
+	slices.SortFunc(items, func(a, b database.GetOwnedCopiesByUserIDRow) int {
+		if c := strings.Compare(a.MediaType, b.MediaType); c != 0 {
+			return c
+		}
+		if c := strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title)); c != 0 {
+			return c
+		}
+		if c := a.CreatedAt.Time.Compare(b.CreatedAt.Time); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ID.Bytes[:], b.ID.Bytes[:])
+	})
+	return items, nil
+}
+
+func (s *MemStore) GetMediumOwnedCopies(ctx context.Context, arg database.GetMediumOwnedCopiesParams) ([]database.OwnedCopy, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []database.OwnedCopy
+	for _, ownedCopy := range s.copies {
+		if sameUUID(ownedCopy.UserID, arg.UserID) && sameUUID(ownedCopy.MediaID, arg.MediaID) {
+			items = append(items, ownedCopy)
+		}
+	}
+	// ORDER BY created_at, id
+	slices.SortFunc(items, func(a, b database.OwnedCopy) int {
+		if c := a.CreatedAt.Time.Compare(b.CreatedAt.Time); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ID.Bytes[:], b.ID.Bytes[:])
+	})
+	return items, nil
+}
+
+func (s *MemStore) UpdateOwnedCopy(ctx context.Context, arg database.UpdateOwnedCopyParams) (database.OwnedCopy, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.ownedCopyIndex(arg.ID)
+	if i == -1 || !sameUUID(s.copies[i].UserID, arg.UserID) {
+		return database.OwnedCopy{}, pgx.ErrNoRows
+	}
+	candidate := s.copies[i]
+	candidate.Format = arg.Format
+	candidate.Condition = arg.Condition
+	candidate.Language = arg.Language
+	candidate.PurchaseDate = arg.PurchaseDate
+	candidate.PriceCents = arg.PriceCents
+	candidate.Currency = arg.Currency
+	candidate.Store = arg.Store
+	if err := checkOwnedCopy(candidate); err != nil {
+		return database.OwnedCopy{}, err
+	}
+	candidate.UpdatedAt = now()
+	s.copies[i] = candidate
+	return candidate, nil
+}
+
+func (s *MemStore) DeleteOwnedCopy(ctx context.Context, arg database.DeleteOwnedCopyParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.ownedCopyIndex(arg.ID)
+	if i == -1 || !sameUUID(s.copies[i].UserID, arg.UserID) {
+		return 0, nil
+	}
+	s.copies = append(s.copies[:i], s.copies[i+1:]...)
+	return 1, nil
+}
+
+func (s *MemStore) GetCollectionValueByUserID(ctx context.Context, userID pgtype.UUID) ([]database.GetCollectionValueByUserIDRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// GROUP BY media_type, currency
+	var items []database.GetCollectionValueByUserIDRow
+	for _, ownedCopy := range s.copies {
+		if !sameUUID(ownedCopy.UserID, userID) {
+			continue
+		}
+		m := s.mediumIndex(ownedCopy.MediaID)
+		if m == -1 {
+			continue
+		}
+		i := slices.IndexFunc(items, func(row database.GetCollectionValueByUserIDRow) bool {
+			return row.MediaType == s.media[m].MediaType && row.Currency == ownedCopy.Currency
+		})
+		if i == -1 {
+			items = append(items, database.GetCollectionValueByUserIDRow{MediaType: s.media[m].MediaType, Currency: ownedCopy.Currency})
+			i = len(items) - 1
+		}
+		items[i].CopiesCount++
+		if ownedCopy.PriceCents.Valid {
+			items[i].PricedCount++
+			items[i].TotalCents += ownedCopy.PriceCents.Int64
+		}
+	}
+	// ORDER BY media_type, currency
+	slices.SortFunc(items, func(a, b database.GetCollectionValueByUserIDRow) int {
+		return cmp.Or(strings.Compare(a.MediaType, b.MediaType), strings.Compare(a.Currency, b.Currency))
+	})
+	return items, nil
+}
+
+// Medium must have a copy owned by the record's user matching copy filters, or none if asked (caller must hold the lock)
+func (s *MemStore) matchRecordCopies(arg database.QueryRecordsParams, record database.UsersMediaRecord) bool {
+	if !arg.Owned.Valid && arg.CopyFormat == "" && arg.CopyCondition == "" && arg.CopyLanguage == "" {
+		return true
+	}
+	owned := slices.ContainsFunc(s.copies, func(ownedCopy database.OwnedCopy) bool {
+		return sameUUID(ownedCopy.UserID, record.UserID) && sameUUID(ownedCopy.MediaID, record.MediaID) &&
+			(arg.CopyFormat == "" || strings.EqualFold(ownedCopy.Format, strings.TrimSpace(arg.CopyFormat))) &&
+			(arg.CopyCondition == "" || ownedCopy.Condition == arg.CopyCondition) &&
+			(arg.CopyLanguage == "" || strings.EqualFold(ownedCopy.Language, strings.TrimSpace(arg.CopyLanguage)))
+	})
+	if arg.Owned.Valid && !arg.Owned.Bool {
+		return !owned
+	}
+	return owned
+}
+
+// Move owned copies of a merged medium to the kept one (caller must hold the lock)
+func (s *MemStore) repointOwnedCopies(oldMediaID, newMediaID pgtype.UUID) {
+	for i, ownedCopy := range s.copies {
+		if sameUUID(ownedCopy.MediaID, oldMediaID) {
+			s.copies[i].MediaID = newMediaID
+			s.copies[i].UpdatedAt = now()
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package memstore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (s *MemStore) StorePasswordToken(ctx context.Context, arg database.StorePasswordTokenParams) (database.PasswordResetToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, token := range s.resetTokens {
+		if token.Token == arg.Token {
+			return database.PasswordResetToken{}, uniqueViolation("password_reset_tokens", "password_reset_tokens_pkey", fmt.Sprintf("Key (token)=(%s) already exists.", arg.Token))
+		}
+	}
+	if !arg.UserID.Valid {
+		return database.PasswordResetToken{}, notNullViolation("password_reset_tokens", "user_id")
+	}
+	if s.userIndex(arg.UserID) == -1 {
+		return database.PasswordResetToken{}, foreignKeyViolation("password_reset_tokens", "password_reset_tokens_user_id_fkey", fmt.Sprintf("Key (user_id)=(%s) is not present in table \"users\".", arg.UserID))
+	}
+	if !arg.ExpiresAt.Valid {
+		return database.PasswordResetToken{}, notNullViolation("password_reset_tokens", "expires_at")
+	}
+
+	token := database.PasswordResetToken{
+		Token:     arg.Token,
+		UserID:    arg.UserID,
+		UserEmail: arg.UserEmail,
+		CreatedAt: now(),
+		ExpiresAt: arg.ExpiresAt,
+	}
+	s.resetTokens = append(s.resetTokens, token)
+	return token, nil
+}
+
+func (s *MemStore) GetPasswordResetToken(ctx context.Context, token string) (database.PasswordResetToken, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, resetToken := range s.resetTokens {
+		if resetToken.Token == token {
+			return resetToken, nil
+		}
+	}
+	return database.PasswordResetToken{}, pgx.ErrNoRows
+}
+
+func (s *MemStore) InvalidateResetTokensByUserId(ctx context.Context, userID pgtype.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	timestamp := now()
+	for i := range s.resetTokens {
+		if sameUUID(s.resetTokens[i].UserID, userID) {
+			s.resetTokens[i].UsedAt = timestamp
+		}
+	}
+	return nil
+}
+
+func (s *MemStore) ResetPasswordResetTable(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.resetTokens = nil
+	return nil
+}
@@ -0,0 +1,84 @@
+package memstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (s *MemStore) CreateRecordPause(ctx context.Context, arg database.CreateRecordPauseParams) (database.RecordsPause, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.recordIndex(arg.RecordID) == -1 {
+		return database.RecordsPause{}, foreignKeyViolation("records_pauses", "records_pauses_record_id_fkey", fmt.Sprintf("Key (record_id)=(%s) is not present in table \"users_media_records\".", arg.RecordID))
+	}
+	if !arg.PausedAt.Valid {
+		return database.RecordsPause{}, notNullViolation("records_pauses", "paused_at")
+	}
+	for _, pause := range s.pauses {
+		if sameUUID(pause.RecordID, arg.RecordID) && !pause.ResumedAt.Valid {
+			return database.RecordsPause{}, uniqueViolation("records_pauses", "records_pauses_record_id_open_key", fmt.Sprintf("Key (record_id)=(%s) already exists.", arg.RecordID))
+		}
+	}
+
+	pause := database.RecordsPause{
+		ID:       newUUID(),
+		RecordID: arg.RecordID,
+		PausedAt: arg.PausedAt,
+	}
+	s.pauses = append(s.pauses, pause)
+	return pause, nil
+}
+
+func (s *MemStore) GetRecordPauses(ctx context.Context, recordID pgtype.UUID) ([]database.RecordsPause, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []database.RecordsPause
+	for _, pause := range s.pauses {
+		if sameUUID(pause.RecordID, recordID) {
+			items = append(items, pause)
+		}
+	}
+	slices.SortFunc(items, func(a, b database.RecordsPause) int {
+		if c := a.PausedAt.Time.Compare(b.PausedAt.Time); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ID.Bytes[:], b.ID.Bytes[:])
+	})
+	return items, nil
+}
+
+func (s *MemStore) ResumeRecordPause(ctx context.Context, arg database.ResumeRecordPauseParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, pause := range s.pauses {
+		if !sameUUID(pause.RecordID, arg.RecordID) || pause.ResumedAt.Valid {
+			continue
+		}
+		// CHECK (resumed_at >= paused_at)
+		if arg.ResumedAt.Valid && arg.ResumedAt.Time.Before(pause.PausedAt.Time) {
+			return checkViolation("records_pauses", "records_pauses_check")
+		}
+		s.pauses[i].ResumedAt = arg.ResumedAt
+	}
+	return nil
+}
+
+// Pauses of a record merged into another one follow it, except an open pause if the kept record has one already (caller must hold the lock)
+func (s *MemStore) repointPauses(oldRecordID, newRecordID pgtype.UUID) {
+	keptIsPaused := slices.ContainsFunc(s.pauses, func(pause database.RecordsPause) bool {
+		return sameUUID(pause.RecordID, newRecordID) && !pause.ResumedAt.Valid
+	})
+	for i, pause := range s.pauses {
+		if sameUUID(pause.RecordID, oldRecordID) && (pause.ResumedAt.Valid || !keptIsPaused) {
+			s.pauses[i].RecordID = newRecordID
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package memstore
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Find a play's index by ID, -1 if not found (caller must hold the lock)
+func (s *MemStore) playIndex(id pgtype.UUID) int {
+	for i, play := range s.plays {
+		if sameUUID(play.ID, id) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Same steps as database.Queries.SavePlay, every row is checked before anything is written, as the transaction would roll back
+func (s *MemStore) SavePlay(ctx context.Context, arg database.SavePlayParams) (database.SavePlayResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// NOT NULL and CHECK constraints of plays
+	if !arg.PlayedAt.Valid {
+		return database.SavePlayResult{}, notNullViolation("plays", "played_at")
+	}
+	if arg.Expansions == nil {
+		return database.SavePlayResult{}, notNullViolation("plays", "expansions")
+	}
+	if arg.DurationMinutes.Valid && arg.DurationMinutes.Int32 <= 0 {
+		return database.SavePlayResult{}, checkViolation("plays", "plays_duration_minutes_check")
+	}
+
+	var play database.Play
+	i := -1
+	if arg.ID.Valid {
+		// UPDATE ... WHERE id = $1 AND user_id = $2
+		i = s.playIndex(arg.ID)
+		if i == -1 || !sameUUID(s.plays[i].UserID, arg.UserID) {
+			return database.SavePlayResult{}, pgx.ErrNoRows
+		}
+		play = s.plays[i]
+	} else {
+		if !arg.UserID.Valid {
+			return database.SavePlayResult{}, notNullViolation("plays", "user_id")
+		}
+		if !arg.MediaID.Valid {
+			return database.SavePlayResult{}, notNullViolation("plays", "media_id")
+		}
+		if s.userIndex(arg.UserID) == -1 {
+			return database.SavePlayResult{}, foreignKeyViolation("plays", "plays_user_id_fkey", fmt.Sprintf("Key (user_id)=(%s) is not present in table \"users\".", arg.UserID))
+		}
+		if s.mediumIndex(arg.MediaID) == -1 {
+			return database.SavePlayResult{}, foreignKeyViolation("plays", "plays_media_id_fkey", fmt.Sprintf("Key (media_id)=(%s) is not present in table \"media\".", arg.MediaID))
+		}
+		timestamp := now()
+		play = database.Play{
+			ID:        newUUID(),
+			CreatedAt: timestamp,
+			UserID:    arg.UserID,
+			MediaID:   arg.MediaID,
+		}
+	}
+	play.UpdatedAt = now()
+	play.PlayedAt = arg.PlayedAt
+	play.DurationMinutes = arg.DurationMinutes
+	play.Location = arg.Location
+	play.Expansions = slices.Clone(arg.Expansions)
+
+	// Constraints of plays_players
+	players := make([]database.PlaysPlayer, 0, len(arg.Players))
+	for seat, player := range arg.Players {
+		if strings.TrimSpace(player.PlayerName) == "" {
+			return database.SavePlayResult{}, checkViolation("plays_players", "plays_players_player_name_check")
+		}
+		if player.PlayerID.Valid && s.userIndex(player.PlayerID) == -1 {
+			return database.SavePlayResult{}, foreignKeyViolation("plays_players", "plays_players_player_id_fkey", fmt.Sprintf("Key (player_id)=(%s) is not present in table \"users\".", player.PlayerID))
+		}
+		players = append(players, database.PlaysPlayer{
+			PlayID:     play.ID,
+			Seat:       int32(seat + 1),
+			PlayerID:   player.PlayerID,
+			PlayerName: player.PlayerName,
+			Score:      player.Score,
+			IsWinner:   player.IsWinner,
+		})
+	}
+
+	if i == -1 {
+		s.plays = append(s.plays, play)
+	} else {
+		s.plays[i] = play
+	}
+	playsPlayers := s.playsPlayers[:0]
+	for _, player := range s.playsPlayers {
+		if !sameUUID(player.PlayID, play.ID) {
+			playsPlayers = append(playsPlayers, player)
+		}
+	}
+	s.playsPlayers = append(playsPlayers, players...)
+
+	play.Expansions = slices.Clone(play.Expansions)
+	return database.SavePlayResult{Play: play, Players: slices.Clone(players)}, nil
+}
+
+func (s *MemStore) GetUserPlayByID(ctx context.Context, arg database.GetUserPlayByIDParams) (database.Play, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := s.playIndex(arg.ID)
+	if i == -1 || !sameUUID(s.plays[i].UserID, arg.UserID) {
+		return database.Play{}, pgx.ErrNoRows
+	}
+	play := s.plays[i]
+	play.Expansions = slices.Clone(play.Expansions)
+	return play, nil
+}
+
+func (s *MemStore) GetPlaysByUserID(ctx context.Context, userID pgtype.UUID) ([]database.GetPlaysByUserIDRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []database.GetPlaysByUserIDRow
+	for _, play := range s.plays {
+		if !sameUUID(play.UserID, userID) {
+			continue
+		}
+		m := s.mediumIndex(play.MediaID)
+		if m == -1 {
+			continue
+		}
+		items = append(items, database.GetPlaysByUserIDRow{
+			ID:              play.ID,
+			CreatedAt:       play.CreatedAt,
+			UpdatedAt:       play.UpdatedAt,
+			UserID:          play.UserID,
+			MediaID:         play.MediaID,
+			Title:           s.media[m].Title,
+			PlayedAt:        play.PlayedAt,
+			DurationMinutes: play.DurationMinutes,
+			Location:        play.Location,
+			Expansions:      slices.Clone(play.Expansions),
+		})
+	}
+	// ORDER BY played_at DESC, id
+	slices.SortFunc(items, func(a, b database.GetPlaysByUserIDRow) int {
+		if c := b.PlayedAt.Time.Compare(a.PlayedAt.Time); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ID.Bytes[:], b.ID.Bytes[:])
+	})
+	return items, nil
+}
+
+func (s *MemStore) GetPlayersByUserID(ctx context.Context, userID pgtype.UUID) ([]database.PlaysPlayer, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []database.PlaysPlayer
+	for _, player := range s.playsPlayers {
+		i := s.playIndex(player.PlayID)
+		if i != -1 && sameUUID(s.plays[i].UserID, userID) {
+			items = append(items, player)
+		}
+	}
+	// ORDER BY play_id, seat
+	slices.SortFunc(items, func(a, b database.PlaysPlayer) int {
+		if c := bytes.Compare(a.PlayID.Bytes[:], b.PlayID.Bytes[:]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Seat, b.Seat)
+	})
+	return items, nil
+}
+
+func (s *MemStore) DeletePlay(ctx context.Context, arg database.DeletePlayParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.playIndex(arg.ID)
+	if i == -1 || !sameUUID(s.plays[i].UserID, arg.UserID) {
+		return 0, nil
+	}
+	s.plays = append(s.plays[:i], s.plays[i+1:]...)
+	s.cascadePlayDelete()
+	return 1, nil
+}
+
+// Move plays of a merged medium to the kept one (caller must hold the lock)
+func (s *MemStore) repointPlays(oldMediaID, newMediaID pgtype.UUID) {
+	for i, play := range s.plays {
+		if sameUUID(play.MediaID, oldMediaID) {
+			s.plays[i].MediaID = newMediaID
+			s.plays[i].UpdatedAt = now()
+		}
+	}
+}
+
+// Apply ON DELETE CASCADE to plays_players once plays were removed (caller must hold the lock)
+func (s *MemStore) cascadePlayDelete() {
+	playsPlayers := s.playsPlayers[:0]
+	for _, player := range s.playsPlayers {
+		if s.playIndex(player.PlayID) != -1 {
+			playsPlayers = append(playsPlayers, player)
+		}
+	}
+	s.playsPlayers = playsPlayers
+}
@@ -0,0 +1,62 @@
+package memstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (s *MemStore) CreateRecordProgress(ctx context.Context, arg database.CreateRecordProgressParams) (database.RecordsProgress, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.recordIndex(arg.RecordID) == -1 {
+		return database.RecordsProgress{}, foreignKeyViolation("records_progress", "records_progress_record_id_fkey", fmt.Sprintf("Key (record_id)=(%s) is not present in table \"users_media_records\".", arg.RecordID))
+	}
+	if !arg.Pages.Valid && !arg.Percent.Valid {
+		return database.RecordsProgress{}, checkViolation("records_progress", "records_progress_pages_percent_check")
+	}
+
+	entry := database.RecordsProgress{
+		ID:       newUUID(),
+		LoggedAt: arg.LoggedAt,
+		RecordID: arg.RecordID,
+		Pages:    arg.Pages,
+		Percent:  arg.Percent,
+		Total:    arg.Total,
+	}
+	s.progress = append(s.progress, entry)
+	return entry, nil
+}
+
+func (s *MemStore) GetRecordProgress(ctx context.Context, recordID pgtype.UUID) ([]database.RecordsProgress, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []database.RecordsProgress
+	for _, entry := range s.progress {
+		if sameUUID(entry.RecordID, recordID) {
+			items = append(items, entry)
+		}
+	}
+	slices.SortFunc(items, func(a, b database.RecordsProgress) int {
+		if c := a.LoggedAt.Time.Compare(b.LoggedAt.Time); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ID.Bytes[:], b.ID.Bytes[:])
+	})
+	return items, nil
+}
+
+// Progress of a record merged into another one follows it (caller must hold the lock)
+func (s *MemStore) repointProgress(oldRecordID, newRecordID pgtype.UUID) {
+	for i, entry := range s.progress {
+		if sameUUID(entry.RecordID, oldRecordID) {
+			s.progress[i].RecordID = newRecordID
+		}
+	}
+}
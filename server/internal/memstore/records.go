@@ -0,0 +1,410 @@
+package memstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Find a record's index by ID, -1 if not found (caller must hold the lock)
+func (s *MemStore) recordIndex(id pgtype.UUID) int {
+	for i, record := range s.records {
+		if sameUUID(record.ID, id) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *MemStore) CreateUserMediumRecord(ctx context.Context, arg database.CreateUserMediumRecordParams) (database.UsersMediaRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Foreign keys (NULL references are allowed by the schema)
+	if arg.UserID.Valid && s.userIndex(arg.UserID) == -1 {
+		return database.UsersMediaRecord{}, foreignKeyViolation("users_media_records", "users_media_records_user_id_fkey", fmt.Sprintf("Key (user_id)=(%s) is not present in table \"users\".", arg.UserID))
+	}
+	if arg.MediaID.Valid && s.mediumIndex(arg.MediaID) == -1 {
+		return database.UsersMediaRecord{}, foreignKeyViolation("users_media_records", "users_media_records_media_id_fkey", fmt.Sprintf("Key (media_id)=(%s) is not present in table \"media\".", arg.MediaID))
+	}
+
+	if !validRating(arg.Rating) {
+		return database.UsersMediaRecord{}, checkViolation("users_media_records", "users_media_records_rating_check")
+	}
+	if !slices.Contains(database.RecordStatuses, arg.Status) {
+		return database.UsersMediaRecord{}, checkViolation("users_media_records", "users_media_records_status_check")
+	}
+
+	timestamp := now()
+	record := database.UsersMediaRecord{
+		ID:            newUUID(),
+		CreatedAt:     timestamp,
+		UpdatedAt:     timestamp,
+		UserID:        arg.UserID,
+		MediaID:       arg.MediaID,
+		IsFinished:    arg.IsFinished,
+		StartDate:     arg.StartDate,
+		EndDate:       arg.EndDate,
+		Duration:      arg.Duration,
+		Comments:      arg.Comments,
+		Rating:        arg.Rating,
+		Status:        arg.Status,
+		AbandonReason: arg.AbandonReason,
+	}
+	s.records = append(s.records, record)
+	return record, nil
+}
+
+func (s *MemStore) GetRecordsByUserID(ctx context.Context, userID pgtype.UUID) ([]database.UsersMediaRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []database.UsersMediaRecord
+	for _, record := range s.records {
+		if sameUUID(record.UserID, userID) {
+			items = append(items, record)
+		}
+	}
+	return items, nil
+}
+
+func (s *MemStore) GetRecordsAndMediaByUserID(ctx context.Context, userID pgtype.UUID) ([]database.GetRecordsAndMediaByUserIDRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	type recordWithMedium struct {
+		record database.UsersMediaRecord
+		medium database.Medium
+	}
+	var rows []recordWithMedium
+	for _, record := range s.records {
+		if !sameUUID(record.UserID, userID) {
+			continue
+		}
+		j := s.mediumIndex(record.MediaID)
+		if j == -1 {
+			continue
+		}
+		rows = append(rows, recordWithMedium{record: record, medium: s.media[j]})
+	}
+
+	// ORDER BY media.title, records.media_id, records.start_date NULLS LAST, records.created_at, records.id
+	slices.SortFunc(rows, func(a, b recordWithMedium) int {
+		if c := strings.Compare(a.medium.Title, b.medium.Title); c != 0 {
+			return c
+		}
+		if c := bytes.Compare(a.record.MediaID.Bytes[:], b.record.MediaID.Bytes[:]); c != 0 {
+			return c
+		}
+		if a.record.StartDate.Valid != b.record.StartDate.Valid {
+			if a.record.StartDate.Valid {
+				return -1
+			}
+			return 1
+		}
+		if c := a.record.StartDate.Time.Compare(b.record.StartDate.Time); c != 0 {
+			return c
+		}
+		if c := a.record.CreatedAt.Time.Compare(b.record.CreatedAt.Time); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.record.ID.Bytes[:], b.record.ID.Bytes[:])
+	})
+
+	var items []database.GetRecordsAndMediaByUserIDRow
+	for _, row := range rows {
+		rating := s.mediumRating(row.medium.ID)
+		items = append(items, database.GetRecordsAndMediaByUserIDRow{
+			ID:            row.record.ID,
+			CreatedAt:     row.record.CreatedAt,
+			UpdatedAt:     row.record.UpdatedAt,
+			UserID:        row.record.UserID,
+			MediaID:       row.record.MediaID,
+			IsFinished:    row.record.IsFinished,
+			StartDate:     row.record.StartDate,
+			EndDate:       row.record.EndDate,
+			Duration:      row.record.Duration,
+			Comments:      row.record.Comments,
+			Rating:        row.record.Rating,
+			Status:        row.record.Status,
+			AbandonReason: row.record.AbandonReason,
+			MediaType:     row.medium.MediaType,
+			Title:         row.medium.Title,
+			Creator:       row.medium.Creator,
+			PubDate:       row.medium.PubDate,
+			ImageUrl:      row.medium.ImageUrl,
+			Metadata:      copyBytes(row.medium.Metadata),
+			RatingAverage: rating.Average,
+			RatingCount:   rating.Count,
+		})
+	}
+	return items, nil
+}
+
+func (s *MemStore) GetRecordByID(ctx context.Context, id pgtype.UUID) (database.UsersMediaRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := s.recordIndex(id)
+	if i == -1 {
+		return database.UsersMediaRecord{}, pgx.ErrNoRows
+	}
+	return s.records[i], nil
+}
+
+func (s *MemStore) GetUserRecordByID(ctx context.Context, arg database.GetUserRecordByIDParams) (database.UsersMediaRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := s.recordIndex(arg.ID)
+	if i == -1 || !sameUUID(s.records[i].UserID, arg.UserID) {
+		return database.UsersMediaRecord{}, pgx.ErrNoRows
+	}
+	return s.records[i], nil
+}
+
+func (s *MemStore) UpdateRecord(ctx context.Context, arg database.UpdateRecordParams) (database.UsersMediaRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.recordIndex(arg.ID)
+	if i == -1 || !sameUUID(s.records[i].UserID, arg.UserID) {
+		return database.UsersMediaRecord{}, pgx.ErrNoRows
+	}
+	if !validRating(arg.Rating) {
+		return database.UsersMediaRecord{}, checkViolation("users_media_records", "users_media_records_rating_check")
+	}
+	if !slices.Contains(database.RecordStatuses, arg.Status) {
+		return database.UsersMediaRecord{}, checkViolation("users_media_records", "users_media_records_status_check")
+	}
+
+	s.records[i].IsFinished = arg.IsFinished
+	s.records[i].StartDate = arg.StartDate
+	s.records[i].EndDate = arg.EndDate
+	s.records[i].Duration = arg.Duration
+	s.records[i].Comments = arg.Comments
+	s.records[i].Rating = arg.Rating
+	s.records[i].Status = arg.Status
+	s.records[i].AbandonReason = arg.AbandonReason
+	s.records[i].UpdatedAt = now()
+	return s.records[i], nil
+}
+
+// Same steps as database.Queries.SaveRecordUpdate, every row is checked before anything is written, as the transaction would roll back
+func (s *MemStore) SaveRecordUpdate(ctx context.Context, arg database.SaveRecordUpdateParams) (database.SaveRecordUpdateResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// UPDATE ... WHERE id = $1 AND user_id = $2
+	i := s.recordIndex(arg.Record.ID)
+	if i == -1 || !sameUUID(s.records[i].UserID, arg.Record.UserID) {
+		return database.SaveRecordUpdateResult{}, pgx.ErrNoRows
+	}
+	if !validRating(arg.Record.Rating) {
+		return database.SaveRecordUpdateResult{}, checkViolation("users_media_records", "users_media_records_rating_check")
+	}
+	if !slices.Contains(database.RecordStatuses, arg.Record.Status) {
+		return database.SaveRecordUpdateResult{}, checkViolation("users_media_records", "users_media_records_status_check")
+	}
+	for _, pause := range s.pauses {
+		if !sameUUID(pause.RecordID, arg.Record.ID) || pause.ResumedAt.Valid {
+			continue
+		}
+		if arg.PausedAt.Valid {
+			return database.SaveRecordUpdateResult{}, uniqueViolation("records_pauses", "records_pauses_record_id_open_key", fmt.Sprintf("Key (record_id)=(%s) already exists.", arg.Record.ID))
+		}
+		// CHECK (resumed_at >= paused_at)
+		if arg.ResumedAt.Valid && arg.ResumedAt.Time.Before(pause.PausedAt.Time) {
+			return database.SaveRecordUpdateResult{}, checkViolation("records_pauses", "records_pauses_check")
+		}
+	}
+	if arg.PausedAt.Valid && arg.ResumedAt.Valid && arg.ResumedAt.Time.Before(arg.PausedAt.Time) {
+		return database.SaveRecordUpdateResult{}, checkViolation("records_pauses", "records_pauses_check")
+	}
+	if arg.Progress != nil {
+		if s.recordIndex(arg.Progress.RecordID) == -1 {
+			return database.SaveRecordUpdateResult{}, foreignKeyViolation("records_progress", "records_progress_record_id_fkey", fmt.Sprintf("Key (record_id)=(%s) is not present in table \"users_media_records\".", arg.Progress.RecordID))
+		}
+		if !arg.Progress.Pages.Valid && !arg.Progress.Percent.Valid {
+			return database.SaveRecordUpdateResult{}, checkViolation("records_progress", "records_progress_pages_percent_check")
+		}
+	}
+
+	s.records[i].IsFinished = arg.Record.IsFinished
+	s.records[i].StartDate = arg.Record.StartDate
+	s.records[i].EndDate = arg.Record.EndDate
+	s.records[i].Duration = arg.Record.Duration
+	s.records[i].Comments = arg.Record.Comments
+	s.records[i].Rating = arg.Record.Rating
+	s.records[i].Status = arg.Record.Status
+	s.records[i].AbandonReason = arg.Record.AbandonReason
+	s.records[i].UpdatedAt = now()
+	result := database.SaveRecordUpdateResult{Record: s.records[i]}
+
+	if arg.PausedAt.Valid {
+		s.pauses = append(s.pauses, database.RecordsPause{
+			ID:       newUUID(),
+			RecordID: arg.Record.ID,
+			PausedAt: arg.PausedAt,
+		})
+	}
+	if arg.ResumedAt.Valid {
+		for j, pause := range s.pauses {
+			if sameUUID(pause.RecordID, arg.Record.ID) && !pause.ResumedAt.Valid {
+				s.pauses[j].ResumedAt = arg.ResumedAt
+			}
+		}
+	}
+
+	if arg.Progress != nil {
+		entry := database.RecordsProgress{
+			ID:       newUUID(),
+			LoggedAt: arg.Progress.LoggedAt,
+			RecordID: arg.Progress.RecordID,
+			Pages:    arg.Progress.Pages,
+			Percent:  arg.Progress.Percent,
+			Total:    arg.Progress.Total,
+		}
+		s.progress = append(s.progress, entry)
+		result.Progress = &entry
+	}
+	return result, nil
+}
+
+func (s *MemStore) DeleteRecord(ctx context.Context, arg database.DeleteRecordParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.recordIndex(arg.ID)
+	if i == -1 || !sameUUID(s.records[i].UserID, arg.UserID) {
+		return 0, nil
+	}
+	s.records = slices.Delete(s.records, i, i+1)
+	s.cascadeRecordDelete()
+	return 1, nil
+}
+
+func (s *MemStore) CountUserRecordsByMediumID(ctx context.Context, arg database.CountUserRecordsByMediumIDParams) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var count int64
+	for _, record := range s.records {
+		if sameUUID(record.MediaID, arg.MediaID) && sameUUID(record.UserID, arg.UserID) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *MemStore) GetMediumRating(ctx context.Context, mediaID pgtype.UUID) (database.GetMediumRatingRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.mediumRating(mediaID), nil
+}
+
+// Average of users' ratings of a medium, each user counting once with the average of their ratings (caller must hold the lock)
+func (s *MemStore) mediumRating(mediaID pgtype.UUID) database.GetMediumRatingRow {
+	type userRatings struct {
+		sum   float64
+		count int
+	}
+	byUser := map[pgtype.UUID]*userRatings{}
+	var users []pgtype.UUID
+	for _, record := range s.records {
+		if !sameUUID(record.MediaID, mediaID) || !record.Rating.Valid {
+			continue
+		}
+		ratings, ok := byUser[record.UserID]
+		if !ok {
+			ratings = &userRatings{}
+			byUser[record.UserID] = ratings
+			users = append(users, record.UserID)
+		}
+		ratings.sum += float64(record.Rating.Int16)
+		ratings.count++
+	}
+
+	var result database.GetMediumRatingRow
+	for _, user := range users {
+		result.Average += byUser[user].sum / float64(byUser[user].count)
+	}
+	result.Count = int64(len(users))
+	if result.Count > 0 {
+		result.Average /= float64(result.Count)
+	}
+	return result
+}
+
+// CHECK (rating BETWEEN 0 AND 100)
+func validRating(rating pgtype.Int2) bool {
+	return !rating.Valid || (rating.Int16 >= 0 && rating.Int16 <= 100)
+}
+
+func (s *MemStore) ResetRecords(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records = nil
+	s.cascadeRecordDelete()
+	return nil
+}
+
+// Apply ON DELETE CASCADE to every table referencing deleted records (caller must hold the lock)
+func (s *MemStore) cascadeRecordDelete() {
+	shares := s.shares[:0]
+	for _, share := range s.shares {
+		if !share.RecordID.Valid || s.recordIndex(share.RecordID) != -1 {
+			shares = append(shares, share)
+		}
+	}
+	s.shares = shares
+
+	progress := s.progress[:0]
+	for _, entry := range s.progress {
+		if s.recordIndex(entry.RecordID) != -1 {
+			progress = append(progress, entry)
+		}
+	}
+	s.progress = progress
+
+	pauses := s.pauses[:0]
+	for _, pause := range s.pauses {
+		if s.recordIndex(pause.RecordID) != -1 {
+			pauses = append(pauses, pause)
+		}
+	}
+	s.pauses = pauses
+
+	videogames := s.videogames[:0]
+	for _, details := range s.videogames {
+		if s.recordIndex(details.RecordID) != -1 {
+			videogames = append(videogames, details)
+		}
+	}
+	s.videogames = videogames
+
+	episodes := s.episodes[:0]
+	for _, episode := range s.episodes {
+		if s.recordIndex(episode.RecordID) != -1 {
+			episodes = append(episodes, episode)
+		}
+	}
+	s.episodes = episodes
+
+	reviews := s.reviews[:0]
+	for _, review := range s.reviews {
+		if s.recordIndex(review.RecordID) != -1 {
+			reviews = append(reviews, review)
+		}
+	}
+	s.reviews = reviews
+	s.cascadeReviewDelete()
+}
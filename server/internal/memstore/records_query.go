@@ -0,0 +1,214 @@
+package memstore
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (s *MemStore) QueryRecords(ctx context.Context, arg database.QueryRecordsParams) ([]database.QueryRecordsRow, error) {
+	if err := arg.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []database.QueryRecordsRow
+	for _, record := range s.records {
+		if !sameUUID(record.UserID, arg.UserID) {
+			continue
+		}
+		j := s.mediumIndex(record.MediaID)
+		if j == -1 {
+			continue
+		}
+		medium := s.media[j]
+		row := database.QueryRecordsRow{
+			ID:            record.ID,
+			CreatedAt:     record.CreatedAt,
+			UpdatedAt:     record.UpdatedAt,
+			UserID:        record.UserID,
+			MediaID:       record.MediaID,
+			IsFinished:    record.IsFinished,
+			StartDate:     record.StartDate,
+			EndDate:       record.EndDate,
+			Duration:      record.Duration,
+			Comments:      record.Comments,
+			Rating:        record.Rating,
+			Status:        record.Status,
+			AbandonReason: record.AbandonReason,
+			MediaType:     medium.MediaType,
+			Title:         medium.Title,
+			Creator:       medium.Creator,
+			PubDate:       medium.PubDate,
+			ImageUrl:      medium.ImageUrl,
+			Metadata:      copyBytes(medium.Metadata),
+		}
+		if matchRecordsQuery(arg, row) && s.matchRecordGroups(arg, record) && s.matchRecordCopies(arg, record) && s.matchRecordVideogame(arg, record) && arg.IsAfterCursor(row) {
+			items = append(items, row)
+		}
+	}
+
+	slices.SortFunc(items, arg.CompareRows)
+	if len(items) > arg.PageLimit() {
+		items = items[:arg.PageLimit()]
+	}
+	return items, nil
+}
+
+// Apply the same filters as the SQL query
+func matchRecordsQuery(arg database.QueryRecordsParams, row database.QueryRecordsRow) bool {
+	if len(arg.MediaTypes) > 0 && !slices.ContainsFunc(arg.MediaTypes, func(mediaType string) bool {
+		return strings.EqualFold(mediaType, row.MediaType)
+	}) {
+		return false
+	}
+
+	switch arg.Status {
+	case "":
+	case database.RecordStatusUnstarted:
+		if row.Status != database.RecordStatusWishlist && row.Status != database.RecordStatusPlanned {
+			return false
+		}
+	default:
+		if row.Status != arg.Status {
+			return false
+		}
+	}
+
+	if arg.Creator != "" && !containsFold(row.Creator, arg.Creator) {
+		return false
+	}
+	if !inTimestampRange(row.StartDate, arg.StartFrom, arg.StartTo) || !inTimestampRange(row.EndDate, arg.EndFrom, arg.EndTo) {
+		return false
+	}
+	if arg.MinDuration.Valid && (!row.Duration.Valid || row.Duration.Days < arg.MinDuration.Int32) {
+		return false
+	}
+	if arg.MaxDuration.Valid && (!row.Duration.Valid || row.Duration.Days > arg.MaxDuration.Int32) {
+		return false
+	}
+	if arg.MinRating.Valid && (!row.Rating.Valid || row.Rating.Int16 < arg.MinRating.Int16) {
+		return false
+	}
+	if arg.MaxRating.Valid && (!row.Rating.Valid || row.Rating.Int16 > arg.MaxRating.Int16) {
+		return false
+	}
+	if arg.Comments != "" && !containsFold(row.Comments, arg.Comments) {
+		return false
+	}
+
+	if len(arg.Metadata) > 0 {
+		var metadata map[string]interface{}
+		if err := json.Unmarshal(row.Metadata, &metadata); err != nil {
+			return false
+		}
+		for _, filter := range arg.Metadata {
+			if !matchMetadataFilter(metadata[filter.Key], filter) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Medium must hold every asked tag of the record's owner and be on the asked custom shelf (caller must hold the lock)
+func (s *MemStore) matchRecordGroups(arg database.QueryRecordsParams, record database.UsersMediaRecord) bool {
+	for _, name := range arg.Tags {
+		if !s.hasUserMediaTagName(record.UserID, record.MediaID, strings.TrimSpace(name)) {
+			return false
+		}
+	}
+	if arg.ShelfID.Valid {
+		i := s.shelfIndex(arg.ShelfID)
+		if i == -1 || !sameUUID(s.shelves[i].UserID, record.UserID) || !s.isOnShelf(arg.ShelfID, record.MediaID) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// NULL values are outside any range, like in SQL
+func inTimestampRange(value, from, to pgtype.Timestamp) bool {
+	if !from.Valid && !to.Valid {
+		return true
+	}
+	if !value.Valid {
+		return false
+	}
+	if from.Valid && value.Time.Before(from.Time) {
+		return false
+	}
+	if to.Valid && value.Time.After(to.Time) {
+		return false
+	}
+	return true
+}
+
+var numericRegex = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// Text form of a JSON value, like the ->> operator
+func jsonText(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	default:
+		data, _ := json.Marshal(v)
+		return string(data), true
+	}
+}
+
+func matchMetadataFilter(value interface{}, filter database.MetadataFilter) bool {
+	switch filter.Op {
+	case database.MetadataOpEq:
+		text, ok := jsonText(value)
+		return ok && strings.EqualFold(text, filter.Value)
+	case database.MetadataOpContains:
+		switch v := value.(type) {
+		case []interface{}:
+			for _, elem := range v {
+				if text, ok := jsonText(elem); ok && strings.EqualFold(text, filter.Value) {
+					return true
+				}
+			}
+			return false
+		case string:
+			return containsFold(v, filter.Value)
+		default:
+			return false
+		}
+	default:
+		text, ok := jsonText(value)
+		text = strings.TrimSpace(text)
+		if !ok || !numericRegex.MatchString(text) {
+			return false
+		}
+		x, _ := strconv.ParseFloat(text, 64)
+		y, _ := strconv.ParseFloat(filter.Value, 64)
+		switch filter.Op {
+		case database.MetadataOpLt:
+			return x < y
+		case database.MetadataOpLte:
+			return x <= y
+		case database.MetadataOpGt:
+			return x > y
+		default:
+			return x >= y
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package memstore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (s *MemStore) CreateRefreshToken(ctx context.Context, arg database.CreateRefreshTokenParams) (database.RefreshToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, token := range s.refreshTokens {
+		if token.Token == arg.Token {
+			return database.RefreshToken{}, uniqueViolation("refresh_tokens", "refresh_tokens_pkey", fmt.Sprintf("Key (token)=(%s) already exists.", arg.Token))
+		}
+	}
+	if !arg.UserID.Valid {
+		return database.RefreshToken{}, notNullViolation("refresh_tokens", "user_id")
+	}
+	if s.userIndex(arg.UserID) == -1 {
+		return database.RefreshToken{}, foreignKeyViolation("refresh_tokens", "refresh_tokens_user_id_fkey", fmt.Sprintf("Key (user_id)=(%s) is not present in table \"users\".", arg.UserID))
+	}
+	if !arg.ExpiresAt.Valid {
+		return database.RefreshToken{}, notNullViolation("refresh_tokens", "expires_at")
+	}
+
+	timestamp := now()
+	token := database.RefreshToken{
+		Token:     arg.Token,
+		CreatedAt: timestamp,
+		UpdatedAt: timestamp,
+		UserID:    arg.UserID,
+		ExpiresAt: arg.ExpiresAt,
+	}
+	s.refreshTokens = append(s.refreshTokens, token)
+	return token, nil
+}
+
+func (s *MemStore) GetRefreshToken(ctx context.Context, token string) (database.RefreshToken, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, refreshToken := range s.refreshTokens {
+		if refreshToken.Token == token {
+			return refreshToken, nil
+		}
+	}
+	return database.RefreshToken{}, pgx.ErrNoRows
+}
+
+func (s *MemStore) RevokeRefreshToken(ctx context.Context, token string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var count int64
+	timestamp := now()
+	for i := range s.refreshTokens {
+		if s.refreshTokens[i].Token == token {
+			s.refreshTokens[i].RevokedAt = timestamp
+			s.refreshTokens[i].UpdatedAt = timestamp
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *MemStore) RevokeAllRefreshTokensByUserID(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var count int64
+	timestamp := now()
+	for i := range s.refreshTokens {
+		if sameUUID(s.refreshTokens[i].UserID, userID) {
+			s.refreshTokens[i].RevokedAt = timestamp
+			s.refreshTokens[i].UpdatedAt = timestamp
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *MemStore) DeleteRevokedTokens(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens := s.refreshTokens[:0]
+	for _, token := range s.refreshTokens {
+		if !token.RevokedAt.Valid {
+			tokens = append(tokens, token)
+		}
+	}
+	s.refreshTokens = tokens
+	return nil
+}
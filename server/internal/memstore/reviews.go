@@ -0,0 +1,206 @@
+package memstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Find a review's index by record ID, -1 if not found (caller must hold the lock)
+func (s *MemStore) reviewIndexByRecordID(recordID pgtype.UUID) int {
+	for i, review := range s.reviews {
+		if sameUUID(review.RecordID, recordID) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *MemStore) CreateReview(ctx context.Context, arg database.CreateReviewParams) (database.Review, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.recordIndex(arg.RecordID) == -1 {
+		return database.Review{}, foreignKeyViolation("reviews", "reviews_record_id_fkey", fmt.Sprintf("Key (record_id)=(%s) is not present in table \"users_media_records\".", arg.RecordID))
+	}
+	if strings.TrimSpace(arg.Title) == "" {
+		return database.Review{}, checkViolation("reviews", "reviews_title_check")
+	}
+	if s.reviewIndexByRecordID(arg.RecordID) != -1 {
+		return database.Review{}, uniqueViolation("reviews", "reviews_record_id_key", fmt.Sprintf("Key (record_id)=(%s) already exists.", arg.RecordID))
+	}
+
+	timestamp := now()
+	review := database.Review{
+		ID:          newUUID(),
+		CreatedAt:   timestamp,
+		UpdatedAt:   timestamp,
+		RecordID:    arg.RecordID,
+		Title:       arg.Title,
+		Body:        arg.Body,
+		IsPublished: arg.IsPublished,
+		PublishedAt: arg.PublishedAt,
+	}
+	s.reviews = append(s.reviews, review)
+	return review, nil
+}
+
+func (s *MemStore) GetReviewByRecordID(ctx context.Context, recordID pgtype.UUID) (database.Review, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := s.reviewIndexByRecordID(recordID)
+	if i == -1 {
+		return database.Review{}, pgx.ErrNoRows
+	}
+	return s.reviews[i], nil
+}
+
+func (s *MemStore) UpdateReview(ctx context.Context, arg database.UpdateReviewParams) (database.Review, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.IndexFunc(s.reviews, func(review database.Review) bool { return sameUUID(review.ID, arg.ID) })
+	if i == -1 {
+		return database.Review{}, pgx.ErrNoRows
+	}
+	if strings.TrimSpace(arg.Title) == "" {
+		return database.Review{}, checkViolation("reviews", "reviews_title_check")
+	}
+	s.reviews[i].Title = arg.Title
+	s.reviews[i].Body = arg.Body
+	s.reviews[i].IsPublished = arg.IsPublished
+	s.reviews[i].PublishedAt = arg.PublishedAt
+	s.reviews[i].UpdatedAt = now()
+	return s.reviews[i], nil
+}
+
+func (s *MemStore) DeleteReview(ctx context.Context, recordID pgtype.UUID) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.reviewIndexByRecordID(recordID)
+	if i == -1 {
+		return 0, nil
+	}
+	s.reviews = append(s.reviews[:i], s.reviews[i+1:]...)
+	s.cascadeReviewDelete()
+	return 1, nil
+}
+
+func (s *MemStore) CreateReviewRevision(ctx context.Context, arg database.CreateReviewRevisionParams) (database.ReviewsRevision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !slices.ContainsFunc(s.reviews, func(review database.Review) bool { return sameUUID(review.ID, arg.ReviewID) }) {
+		return database.ReviewsRevision{}, foreignKeyViolation("reviews_revisions", "reviews_revisions_review_id_fkey", fmt.Sprintf("Key (review_id)=(%s) is not present in table \"reviews\".", arg.ReviewID))
+	}
+
+	// Numbered after the latest revision
+	var latest int32
+	for _, revision := range s.revisions {
+		if sameUUID(revision.ReviewID, arg.ReviewID) && revision.Revision > latest {
+			latest = revision.Revision
+		}
+	}
+
+	revision := database.ReviewsRevision{
+		ID:        newUUID(),
+		ReviewID:  arg.ReviewID,
+		Revision:  latest + 1,
+		CreatedAt: now(),
+		Title:     arg.Title,
+		Body:      arg.Body,
+	}
+	s.revisions = append(s.revisions, revision)
+	return revision, nil
+}
+
+func (s *MemStore) GetReviewRevisions(ctx context.Context, reviewID pgtype.UUID) ([]database.ReviewsRevision, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []database.ReviewsRevision
+	for _, revision := range s.revisions {
+		if sameUUID(revision.ReviewID, reviewID) {
+			items = append(items, revision)
+		}
+	}
+	slices.SortFunc(items, func(a, b database.ReviewsRevision) int {
+		return int(a.Revision - b.Revision)
+	})
+	return items, nil
+}
+
+func (s *MemStore) GetPublishedMediumReviews(ctx context.Context, mediaID pgtype.UUID) ([]database.GetPublishedMediumReviewsRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []database.GetPublishedMediumReviewsRow
+	for _, review := range s.reviews {
+		if !review.IsPublished {
+			continue
+		}
+		r := s.recordIndex(review.RecordID)
+		if r == -1 || !sameUUID(s.records[r].MediaID, mediaID) {
+			continue
+		}
+		u := s.userIndex(s.records[r].UserID)
+		if u == -1 {
+			continue
+		}
+		items = append(items, database.GetPublishedMediumReviewsRow{
+			ID:          review.ID,
+			CreatedAt:   review.CreatedAt,
+			UpdatedAt:   review.UpdatedAt,
+			RecordID:    review.RecordID,
+			Title:       review.Title,
+			Body:        review.Body,
+			IsPublished: review.IsPublished,
+			PublishedAt: review.PublishedAt,
+			UserID:      s.users[u].ID,
+			Username:    s.users[u].Username,
+		})
+	}
+	// ORDER BY published_at DESC (NULLS FIRST), id
+	slices.SortFunc(items, func(a, b database.GetPublishedMediumReviewsRow) int {
+		if a.PublishedAt.Valid != b.PublishedAt.Valid {
+			if !a.PublishedAt.Valid {
+				return -1
+			}
+			return 1
+		}
+		if c := b.PublishedAt.Time.Compare(a.PublishedAt.Time); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ID.Bytes[:], b.ID.Bytes[:])
+	})
+	return items, nil
+}
+
+// Review of a record merged into another one follows it, unless the kept record already has one (caller must hold the lock)
+func (s *MemStore) repointReview(oldRecordID, newRecordID pgtype.UUID) {
+	if s.reviewIndexByRecordID(newRecordID) != -1 {
+		return
+	}
+	if i := s.reviewIndexByRecordID(oldRecordID); i != -1 {
+		s.reviews[i].RecordID = newRecordID
+	}
+}
+
+// Apply ON DELETE CASCADE to every table referencing deleted reviews (caller must hold the lock)
+func (s *MemStore) cascadeReviewDelete() {
+	revisions := s.revisions[:0]
+	for _, revision := range s.revisions {
+		if slices.ContainsFunc(s.reviews, func(review database.Review) bool { return sameUUID(review.ID, revision.ReviewID) }) {
+			revisions = append(revisions, revision)
+		}
+	}
+	s.revisions = revisions
+}
@@ -0,0 +1,221 @@
+package memstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Find a share's index by ID, -1 if not found (caller must hold the lock)
+func (s *MemStore) shareIndex(id pgtype.UUID) int {
+	for i, share := range s.shares {
+		if sameUUID(share.ID, id) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *MemStore) CreateShare(ctx context.Context, arg database.CreateShareParams) (database.Share, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// NOT NULL and CHECK constraints
+	if !arg.OwnerID.Valid {
+		return database.Share{}, notNullViolation("shares", "owner_id")
+	}
+	if !arg.RecipientID.Valid {
+		return database.Share{}, notNullViolation("shares", "recipient_id")
+	}
+	if sameUUID(arg.OwnerID, arg.RecipientID) {
+		return database.Share{}, checkViolation("shares", "shares_check")
+	}
+	if arg.RecordID.Valid == arg.MediaType.Valid {
+		return database.Share{}, checkViolation("shares", "shares_check1")
+	}
+
+	// Foreign keys
+	if s.userIndex(arg.OwnerID) == -1 {
+		return database.Share{}, foreignKeyViolation("shares", "shares_owner_id_fkey", fmt.Sprintf("Key (owner_id)=(%s) is not present in table \"users\".", arg.OwnerID))
+	}
+	if s.userIndex(arg.RecipientID) == -1 {
+		return database.Share{}, foreignKeyViolation("shares", "shares_recipient_id_fkey", fmt.Sprintf("Key (recipient_id)=(%s) is not present in table \"users\".", arg.RecipientID))
+	}
+	if arg.RecordID.Valid && s.recordIndex(arg.RecordID) == -1 {
+		return database.Share{}, foreignKeyViolation("shares", "shares_record_id_fkey", fmt.Sprintf("Key (record_id)=(%s) is not present in table \"users_media_records\".", arg.RecordID))
+	}
+
+	// Partial unique indexes on (owner_id, recipient_id, record_id) and (owner_id, recipient_id, media_type)
+	for _, share := range s.shares {
+		if !sameUUID(share.OwnerID, arg.OwnerID) || !sameUUID(share.RecipientID, arg.RecipientID) {
+			continue
+		}
+		if arg.RecordID.Valid && sameUUID(share.RecordID, arg.RecordID) {
+			return database.Share{}, uniqueViolation("shares", "shares_record_key", fmt.Sprintf("Key (owner_id, recipient_id, record_id)=(%s, %s, %s) already exists.", arg.OwnerID, arg.RecipientID, arg.RecordID))
+		}
+		if arg.MediaType.Valid && share.MediaType.Valid && share.MediaType.String == arg.MediaType.String {
+			return database.Share{}, uniqueViolation("shares", "shares_media_type_key", fmt.Sprintf("Key (owner_id, recipient_id, media_type)=(%s, %s, %s) already exists.", arg.OwnerID, arg.RecipientID, arg.MediaType.String))
+		}
+	}
+
+	share := database.Share{
+		ID:          newUUID(),
+		CreatedAt:   now(),
+		OwnerID:     arg.OwnerID,
+		RecipientID: arg.RecipientID,
+		RecordID:    arg.RecordID,
+		MediaType:   arg.MediaType,
+	}
+	s.shares = append(s.shares, share)
+	return share, nil
+}
+
+func (s *MemStore) GetShareByID(ctx context.Context, id pgtype.UUID) (database.Share, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := s.shareIndex(id)
+	if i == -1 {
+		return database.Share{}, pgx.ErrNoRows
+	}
+	return s.shares[i], nil
+}
+
+func (s *MemStore) GetSharesByUserID(ctx context.Context, ownerID pgtype.UUID) ([]database.GetSharesByUserIDRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []database.GetSharesByUserIDRow
+	for _, share := range s.shares {
+		if !sameUUID(share.OwnerID, ownerID) && !sameUUID(share.RecipientID, ownerID) {
+			continue
+		}
+		owner := s.userIndex(share.OwnerID)
+		recipient := s.userIndex(share.RecipientID)
+		if owner == -1 || recipient == -1 {
+			continue
+		}
+		row := database.GetSharesByUserIDRow{
+			ID:                share.ID,
+			CreatedAt:         share.CreatedAt,
+			OwnerID:           share.OwnerID,
+			OwnerUsername:     s.users[owner].Username,
+			RecipientID:       share.RecipientID,
+			RecipientUsername: s.users[recipient].Username,
+			RecordID:          share.RecordID,
+			MediaType:         share.MediaType.String,
+		}
+
+		// LEFT JOIN on the shared record's medium
+		if i := s.recordIndex(share.RecordID); i != -1 {
+			if j := s.mediumIndex(s.records[i].MediaID); j != -1 {
+				if !share.MediaType.Valid {
+					row.MediaType = s.media[j].MediaType
+				}
+				row.Title = s.media[j].Title
+			}
+		}
+		items = append(items, row)
+	}
+
+	slices.SortFunc(items, func(a, b database.GetSharesByUserIDRow) int {
+		if c := b.CreatedAt.Time.Compare(a.CreatedAt.Time); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ID.Bytes[:], b.ID.Bytes[:])
+	})
+	return items, nil
+}
+
+func (s *MemStore) GetSharedMediaRecords(ctx context.Context, arg database.GetSharedMediaRecordsParams) ([]database.GetSharedMediaRecordsRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := s.shareIndex(arg.ShareID)
+	if i == -1 || !sameUUID(s.shares[i].RecipientID, arg.RecipientID) {
+		return nil, nil
+	}
+	share := s.shares[i]
+
+	var items []database.GetSharedMediaRecordsRow
+	for _, record := range s.records {
+		// A record share gives access to one record, a compartment share to all owner's records of a media type
+		if share.RecordID.Valid {
+			if !sameUUID(record.ID, share.RecordID) {
+				continue
+			}
+		} else if !sameUUID(record.UserID, share.OwnerID) {
+			continue
+		}
+		j := s.mediumIndex(record.MediaID)
+		if j == -1 {
+			continue
+		}
+		medium := s.media[j]
+		if share.MediaType.Valid && medium.MediaType != share.MediaType.String {
+			continue
+		}
+		items = append(items, database.GetSharedMediaRecordsRow{
+			ID:         record.ID,
+			UserID:     record.UserID,
+			MediaID:    record.MediaID,
+			IsFinished: record.IsFinished,
+			StartDate:  record.StartDate,
+			EndDate:    record.EndDate,
+			Duration:   record.Duration,
+			Comments:   record.Comments,
+			Rating:     record.Rating,
+			Status:     record.Status,
+			MediaType:  medium.MediaType,
+			Title:      medium.Title,
+			Creator:    medium.Creator,
+			PubDate:    medium.PubDate,
+			ImageUrl:   medium.ImageUrl,
+			Metadata:   copyBytes(medium.Metadata),
+		})
+	}
+
+	slices.SortFunc(items, func(a, b database.GetSharedMediaRecordsRow) int {
+		if c := strings.Compare(a.Title, b.Title); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ID.Bytes[:], b.ID.Bytes[:])
+	})
+	return items, nil
+}
+
+func (s *MemStore) DeleteShare(ctx context.Context, arg database.DeleteShareParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.shareIndex(arg.ID)
+	if i == -1 || (!sameUUID(s.shares[i].OwnerID, arg.UserID) && !sameUUID(s.shares[i].RecipientID, arg.UserID)) {
+		return 0, nil
+	}
+	s.shares = append(s.shares[:i], s.shares[i+1:]...)
+	return 1, nil
+}
+
+// Equivalent of RepointSharesToRecord: move shares to another record, unless the same share already exists for it (caller must hold the lock)
+func (s *MemStore) repointShares(oldRecordID, newRecordID pgtype.UUID) {
+	for i, share := range s.shares {
+		if !sameUUID(share.RecordID, oldRecordID) {
+			continue
+		}
+		exists := false
+		for _, other := range s.shares {
+			if sameUUID(other.OwnerID, share.OwnerID) && sameUUID(other.RecipientID, share.RecipientID) && sameUUID(other.RecordID, newRecordID) {
+				exists = true
+			}
+		}
+		if !exists {
+			s.shares[i].RecordID = newRecordID
+		}
+	}
+}
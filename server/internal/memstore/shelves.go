@@ -0,0 +1,259 @@
+package memstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Find a shelf's index by ID, -1 if not found (caller must hold the lock)
+func (s *MemStore) shelfIndex(id pgtype.UUID) int {
+	for i, shelf := range s.shelves {
+		if sameUUID(shelf.ID, id) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Find a user's shelf by ID, -1 if not found or owned by someone else (caller must hold the lock)
+func (s *MemStore) userShelfIndex(id, userID pgtype.UUID) int {
+	i := s.shelfIndex(id)
+	if i == -1 || !sameUUID(s.shelves[i].UserID, userID) {
+		return -1
+	}
+	return i
+}
+
+// Unique index on (user_id, lower(name)), skipping the shelf being updated (caller must hold the lock)
+func (s *MemStore) checkShelfName(userID pgtype.UUID, name string, skip pgtype.UUID) error {
+	if strings.TrimSpace(name) == "" {
+		return checkViolation("shelves", "shelves_name_check")
+	}
+	for _, shelf := range s.shelves {
+		if sameUUID(shelf.UserID, userID) && !sameUUID(shelf.ID, skip) && strings.EqualFold(shelf.Name, name) {
+			return uniqueViolation("shelves", "shelves_user_id_name_key", fmt.Sprintf("Key (user_id, lower(name))=(%s, %s) already exists.", userID, strings.ToLower(name)))
+		}
+	}
+	return nil
+}
+
+func (s *MemStore) CreateShelf(ctx context.Context, arg database.CreateShelfParams) (database.Shelf, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !arg.UserID.Valid {
+		return database.Shelf{}, notNullViolation("shelves", "user_id")
+	}
+	if err := s.checkShelfName(arg.UserID, arg.Name, pgtype.UUID{}); err != nil {
+		return database.Shelf{}, err
+	}
+	if s.userIndex(arg.UserID) == -1 {
+		return database.Shelf{}, foreignKeyViolation("shelves", "shelves_user_id_fkey", fmt.Sprintf("Key (user_id)=(%s) is not present in table \"users\".", arg.UserID))
+	}
+
+	shelf := database.Shelf{
+		ID:          newUUID(),
+		CreatedAt:   now(),
+		UpdatedAt:   now(),
+		UserID:      arg.UserID,
+		Name:        arg.Name,
+		Description: arg.Description,
+	}
+	s.shelves = append(s.shelves, shelf)
+	return shelf, nil
+}
+
+func (s *MemStore) GetShelvesByUserID(ctx context.Context, userID pgtype.UUID) ([]database.GetShelvesByUserIDRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []database.GetShelvesByUserIDRow
+	for _, shelf := range s.shelves {
+		if !sameUUID(shelf.UserID, userID) {
+			continue
+		}
+		row := database.GetShelvesByUserIDRow{
+			ID:          shelf.ID,
+			CreatedAt:   shelf.CreatedAt,
+			UpdatedAt:   shelf.UpdatedAt,
+			UserID:      shelf.UserID,
+			Name:        shelf.Name,
+			Description: shelf.Description,
+		}
+		for _, link := range s.shelvesMedia {
+			if sameUUID(link.ShelfID, shelf.ID) {
+				row.MediaCount++
+			}
+		}
+		items = append(items, row)
+	}
+
+	slices.SortFunc(items, func(a, b database.GetShelvesByUserIDRow) int {
+		if c := strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ID.Bytes[:], b.ID.Bytes[:])
+	})
+	return items, nil
+}
+
+func (s *MemStore) GetUserShelfByID(ctx context.Context, arg database.GetUserShelfByIDParams) (database.Shelf, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := s.userShelfIndex(arg.ID, arg.UserID)
+	if i == -1 {
+		return database.Shelf{}, pgx.ErrNoRows
+	}
+	return s.shelves[i], nil
+}
+
+func (s *MemStore) UpdateShelf(ctx context.Context, arg database.UpdateShelfParams) (database.Shelf, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.userShelfIndex(arg.ID, arg.UserID)
+	if i == -1 {
+		return database.Shelf{}, pgx.ErrNoRows
+	}
+	if err := s.checkShelfName(arg.UserID, arg.Name, arg.ID); err != nil {
+		return database.Shelf{}, err
+	}
+	s.shelves[i].Name = arg.Name
+	s.shelves[i].Description = arg.Description
+	s.shelves[i].UpdatedAt = now()
+	return s.shelves[i], nil
+}
+
+func (s *MemStore) DeleteShelf(ctx context.Context, arg database.DeleteShelfParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.userShelfIndex(arg.ID, arg.UserID)
+	if i == -1 {
+		return 0, nil
+	}
+	s.shelves = append(s.shelves[:i], s.shelves[i+1:]...)
+	s.cascadeShelfDelete()
+	return 1, nil
+}
+
+func (s *MemStore) AddMediaToShelf(ctx context.Context, arg database.AddMediaToShelfParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The whole insert fails if one row is invalid
+	if !arg.ShelfID.Valid {
+		return 0, notNullViolation("shelves_media", "shelf_id")
+	}
+	if s.shelfIndex(arg.ShelfID) == -1 {
+		return 0, foreignKeyViolation("shelves_media", "shelves_media_shelf_id_fkey", fmt.Sprintf("Key (shelf_id)=(%s) is not present in table \"shelves\".", arg.ShelfID))
+	}
+	for _, mediaID := range arg.MediaIds {
+		if !mediaID.Valid {
+			return 0, notNullViolation("shelves_media", "media_id")
+		}
+		if s.mediumIndex(mediaID) == -1 {
+			return 0, foreignKeyViolation("shelves_media", "shelves_media_media_id_fkey", fmt.Sprintf("Key (media_id)=(%s) is not present in table \"media\".", mediaID))
+		}
+	}
+
+	// ON CONFLICT DO NOTHING
+	var count int64
+	for _, mediaID := range arg.MediaIds {
+		if s.isOnShelf(arg.ShelfID, mediaID) {
+			continue
+		}
+		s.shelvesMedia = append(s.shelvesMedia, database.ShelvesMedium{
+			ShelfID: arg.ShelfID,
+			MediaID: mediaID,
+			AddedAt: now(),
+		})
+		count++
+	}
+	return count, nil
+}
+
+func (s *MemStore) RemoveMediaFromShelf(ctx context.Context, arg database.RemoveMediaFromShelfParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var count int64
+	shelvesMedia := s.shelvesMedia[:0]
+	for _, link := range s.shelvesMedia {
+		if sameUUID(link.ShelfID, arg.ShelfID) && slices.ContainsFunc(arg.MediaIds, func(mediaID pgtype.UUID) bool { return sameUUID(mediaID, link.MediaID) }) {
+			count++
+			continue
+		}
+		shelvesMedia = append(shelvesMedia, link)
+	}
+	s.shelvesMedia = shelvesMedia
+	return count, nil
+}
+
+func (s *MemStore) GetUserShelvesMedia(ctx context.Context, userID pgtype.UUID) ([]database.GetUserShelvesMediaRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	type entry struct {
+		row     database.GetUserShelvesMediaRow
+		addedAt pgtype.Timestamp
+	}
+	var entries []entry
+	for _, link := range s.shelvesMedia {
+		i := s.shelfIndex(link.ShelfID)
+		if i == -1 || !sameUUID(s.shelves[i].UserID, userID) {
+			continue
+		}
+		entries = append(entries, entry{
+			row:     database.GetUserShelvesMediaRow{ShelfID: link.ShelfID, MediaID: link.MediaID},
+			addedAt: link.AddedAt,
+		})
+	}
+
+	slices.SortStableFunc(entries, func(a, b entry) int {
+		return a.addedAt.Time.Compare(b.addedAt.Time)
+	})
+	items := make([]database.GetUserShelvesMediaRow, 0, len(entries))
+	for _, e := range entries {
+		items = append(items, e.row)
+	}
+	return items, nil
+}
+
+// Check if a medium is on a shelf (caller must hold the lock)
+func (s *MemStore) isOnShelf(shelfID, mediaID pgtype.UUID) bool {
+	for _, link := range s.shelvesMedia {
+		if sameUUID(link.ShelfID, shelfID) && sameUUID(link.MediaID, mediaID) {
+			return true
+		}
+	}
+	return false
+}
+
+// Move a merged medium to the kept one on every shelf it was on, unless it's already there (caller must hold the lock)
+func (s *MemStore) repointShelvesMedia(oldMediaID, newMediaID pgtype.UUID) {
+	for i, link := range s.shelvesMedia {
+		if sameUUID(link.MediaID, oldMediaID) && !s.isOnShelf(link.ShelfID, newMediaID) {
+			s.shelvesMedia[i].MediaID = newMediaID
+		}
+	}
+}
+
+// Apply ON DELETE CASCADE to shelves_media once shelves were removed (caller must hold the lock)
+func (s *MemStore) cascadeShelfDelete() {
+	shelvesMedia := s.shelvesMedia[:0]
+	for _, link := range s.shelvesMedia {
+		if s.shelfIndex(link.ShelfID) != -1 {
+			shelvesMedia = append(shelvesMedia, link)
+		}
+	}
+	s.shelvesMedia = shelvesMedia
+}
@@ -0,0 +1,369 @@
+package memstore
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Find a shelving unit's index by ID, -1 if not found (caller must hold the lock)
+func (s *MemStore) shelvingUnitIndex(id pgtype.UUID) int {
+	for i, unit := range s.units {
+		if sameUUID(unit.ID, id) {
+			return i
+		}
+	}
+	return -1
+}
+
+// CHECK constraints of shelving_units and unique index on (user_id, lower(name)),
+// skipping the unit being updated (caller must hold the lock)
+func (s *MemStore) checkShelvingUnit(candidate database.ShelvingUnit) error {
+	if strings.TrimSpace(candidate.Name) == "" {
+		return checkViolation("shelving_units", "shelving_units_name_check")
+	}
+	if candidate.RowsCount < 1 || candidate.RowsCount > 20 {
+		return checkViolation("shelving_units", "shelving_units_rows_count_check")
+	}
+	if candidate.ColumnsCount < 1 || candidate.ColumnsCount > 20 {
+		return checkViolation("shelving_units", "shelving_units_columns_count_check")
+	}
+	if candidate.CellWidthCm <= 0 {
+		return checkViolation("shelving_units", "shelving_units_cell_width_cm_check")
+	}
+	if candidate.CellHeightCm <= 0 {
+		return checkViolation("shelving_units", "shelving_units_cell_height_cm_check")
+	}
+	if candidate.CellDepthCm <= 0 {
+		return checkViolation("shelving_units", "shelving_units_cell_depth_cm_check")
+	}
+	for _, unit := range s.units {
+		if sameUUID(unit.UserID, candidate.UserID) && !sameUUID(unit.ID, candidate.ID) && strings.EqualFold(unit.Name, candidate.Name) {
+			return uniqueViolation("shelving_units", "shelving_units_user_id_name_key", fmt.Sprintf("Key (user_id, lower(name))=(%s, %s) already exists.", candidate.UserID, strings.ToLower(candidate.Name)))
+		}
+	}
+	return nil
+}
+
+func (s *MemStore) CreateShelvingUnit(ctx context.Context, arg database.CreateShelvingUnitParams) (database.ShelvingUnit, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !arg.UserID.Valid {
+		return database.ShelvingUnit{}, notNullViolation("shelving_units", "user_id")
+	}
+
+	timestamp := now()
+	unit := database.ShelvingUnit{
+		ID:           newUUID(),
+		CreatedAt:    timestamp,
+		UpdatedAt:    timestamp,
+		UserID:       arg.UserID,
+		Name:         arg.Name,
+		RowsCount:    arg.RowsCount,
+		ColumnsCount: arg.ColumnsCount,
+		CellWidthCm:  arg.CellWidthCm,
+		CellHeightCm: arg.CellHeightCm,
+		CellDepthCm:  arg.CellDepthCm,
+	}
+	if err := s.checkShelvingUnit(unit); err != nil {
+		return database.ShelvingUnit{}, err
+	}
+	if s.userIndex(arg.UserID) == -1 {
+		return database.ShelvingUnit{}, foreignKeyViolation("shelving_units", "shelving_units_user_id_fkey", fmt.Sprintf("Key (user_id)=(%s) is not present in table \"users\".", arg.UserID))
+	}
+
+	s.units = append(s.units, unit)
+	return unit, nil
+}
+
+func (s *MemStore) GetShelvingUnitsByUserID(ctx context.Context, userID pgtype.UUID) ([]database.GetShelvingUnitsByUserIDRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []database.GetShelvingUnitsByUserIDRow
+	for _, unit := range s.units {
+		if !sameUUID(unit.UserID, userID) {
+			continue
+		}
+		var itemsCount int64
+		for _, item := range s.shelvingItems {
+			if sameUUID(item.UnitID, unit.ID) {
+				itemsCount++
+			}
+		}
+		items = append(items, database.GetShelvingUnitsByUserIDRow{
+			ID:           unit.ID,
+			CreatedAt:    unit.CreatedAt,
+			UpdatedAt:    unit.UpdatedAt,
+			UserID:       unit.UserID,
+			Name:         unit.Name,
+			RowsCount:    unit.RowsCount,
+			ColumnsCount: unit.ColumnsCount,
+			CellWidthCm:  unit.CellWidthCm,
+			CellHeightCm: unit.CellHeightCm,
+			CellDepthCm:  unit.CellDepthCm,
+			ItemsCount:   itemsCount,
+		})
+	}
+	// ORDER BY lower(name), id
+	slices.SortFunc(items, func(a, b database.GetShelvingUnitsByUserIDRow) int {
+		if c := strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ID.Bytes[:], b.ID.Bytes[:])
+	})
+	return items, nil
+}
+
+func (s *MemStore) GetUserShelvingUnitByID(ctx context.Context, arg database.GetUserShelvingUnitByIDParams) (database.ShelvingUnit, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := s.shelvingUnitIndex(arg.ID)
+	if i == -1 || !sameUUID(s.units[i].UserID, arg.UserID) {
+		return database.ShelvingUnit{}, pgx.ErrNoRows
+	}
+	return s.units[i], nil
+}
+
+func (s *MemStore) UpdateShelvingUnit(ctx context.Context, arg database.UpdateShelvingUnitParams) (database.ShelvingUnit, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.shelvingUnitIndex(arg.ID)
+	if i == -1 || !sameUUID(s.units[i].UserID, arg.UserID) {
+		return database.ShelvingUnit{}, pgx.ErrNoRows
+	}
+	candidate := s.units[i]
+	candidate.Name = arg.Name
+	candidate.RowsCount = arg.RowsCount
+	candidate.ColumnsCount = arg.ColumnsCount
+	candidate.CellWidthCm = arg.CellWidthCm
+	candidate.CellHeightCm = arg.CellHeightCm
+	candidate.CellDepthCm = arg.CellDepthCm
+	if err := s.checkShelvingUnit(candidate); err != nil {
+		return database.ShelvingUnit{}, err
+	}
+	candidate.UpdatedAt = now()
+	s.units[i] = candidate
+	return candidate, nil
+}
+
+func (s *MemStore) DeleteShelvingUnit(ctx context.Context, arg database.DeleteShelvingUnitParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.shelvingUnitIndex(arg.ID)
+	if i == -1 || !sameUUID(s.units[i].UserID, arg.UserID) {
+		return 0, nil
+	}
+	s.units = append(s.units[:i], s.units[i+1:]...)
+	s.cascadeShelvingUnitDelete()
+	return 1, nil
+}
+
+func (s *MemStore) CountShelvingItemsOutsideGrid(ctx context.Context, arg database.CountShelvingItemsOutsideGridParams) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var count int64
+	for _, item := range s.shelvingItems {
+		if sameUUID(item.UnitID, arg.UnitID) && (item.CellRow > arg.RowsCount || item.CellColumn > arg.ColumnsCount) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *MemStore) PlaceShelvingItem(ctx context.Context, arg database.PlaceShelvingItemParams) (database.ShelvingItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// NOT NULL and CHECK constraints
+	if !arg.UserID.Valid {
+		return database.ShelvingItem{}, notNullViolation("shelving_items", "user_id")
+	}
+	if !arg.MediaID.Valid {
+		return database.ShelvingItem{}, notNullViolation("shelving_items", "media_id")
+	}
+	if !arg.UnitID.Valid {
+		return database.ShelvingItem{}, notNullViolation("shelving_items", "unit_id")
+	}
+	if arg.CellRow < 1 {
+		return database.ShelvingItem{}, checkViolation("shelving_items", "shelving_items_cell_row_check")
+	}
+	if arg.CellColumn < 1 {
+		return database.ShelvingItem{}, checkViolation("shelving_items", "shelving_items_cell_column_check")
+	}
+
+	// Foreign keys
+	if s.userIndex(arg.UserID) == -1 {
+		return database.ShelvingItem{}, foreignKeyViolation("shelving_items", "shelving_items_user_id_fkey", fmt.Sprintf("Key (user_id)=(%s) is not present in table \"users\".", arg.UserID))
+	}
+	if s.mediumIndex(arg.MediaID) == -1 {
+		return database.ShelvingItem{}, foreignKeyViolation("shelving_items", "shelving_items_media_id_fkey", fmt.Sprintf("Key (media_id)=(%s) is not present in table \"media\".", arg.MediaID))
+	}
+	if s.shelvingUnitIndex(arg.UnitID) == -1 {
+		return database.ShelvingItem{}, foreignKeyViolation("shelving_items", "shelving_items_unit_id_fkey", fmt.Sprintf("Key (unit_id)=(%s) is not present in table \"shelving_units\".", arg.UnitID))
+	}
+
+	item := database.ShelvingItem{
+		UserID:     arg.UserID,
+		MediaID:    arg.MediaID,
+		UnitID:     arg.UnitID,
+		CellRow:    arg.CellRow,
+		CellColumn: arg.CellColumn,
+		PlacedAt:   now(),
+	}
+
+	// ON CONFLICT (user_id, media_id) DO UPDATE
+	i := slices.IndexFunc(s.shelvingItems, func(existing database.ShelvingItem) bool {
+		return sameUUID(existing.UserID, arg.UserID) && sameUUID(existing.MediaID, arg.MediaID)
+	})
+	if i != -1 {
+		s.shelvingItems[i] = item
+		return item, nil
+	}
+	s.shelvingItems = append(s.shelvingItems, item)
+	return item, nil
+}
+
+func (s *MemStore) GetShelvingUnitItems(ctx context.Context, unitID pgtype.UUID) ([]database.GetShelvingUnitItemsRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []database.GetShelvingUnitItemsRow
+	for _, item := range s.shelvingItems {
+		if !sameUUID(item.UnitID, unitID) {
+			continue
+		}
+		m := s.mediumIndex(item.MediaID)
+		if m == -1 {
+			continue
+		}
+		items = append(items, database.GetShelvingUnitItemsRow{
+			MediaID:    item.MediaID,
+			MediaType:  s.media[m].MediaType,
+			Title:      s.media[m].Title,
+			ImageUrl:   s.media[m].ImageUrl,
+			CellRow:    item.CellRow,
+			CellColumn: item.CellColumn,
+			PlacedAt:   item.PlacedAt,
+		})
+	}
+	// ORDER BY cell_row, cell_column, placed_at, media_id
+	slices.SortFunc(items, func(a, b database.GetShelvingUnitItemsRow) int {
+		return cmp.Or(
+			cmp.Compare(a.CellRow, b.CellRow),
+			cmp.Compare(a.CellColumn, b.CellColumn),
+			a.PlacedAt.Time.Compare(b.PlacedAt.Time),
+			bytes.Compare(a.MediaID.Bytes[:], b.MediaID.Bytes[:]),
+		)
+	})
+	return items, nil
+}
+
+// User's items joined with their medium and unit, keeping the ones asked for (caller must hold the lock)
+func (s *MemStore) userShelvingItems(userID pgtype.UUID, keep func(medium database.Medium, item database.ShelvingItem) bool) []database.FindShelvingItemsByTitleRow {
+	var items []database.FindShelvingItemsByTitleRow
+	for _, item := range s.shelvingItems {
+		if !sameUUID(item.UserID, userID) {
+			continue
+		}
+		m := s.mediumIndex(item.MediaID)
+		u := s.shelvingUnitIndex(item.UnitID)
+		if m == -1 || u == -1 || !keep(s.media[m], item) {
+			continue
+		}
+		items = append(items, database.FindShelvingItemsByTitleRow{
+			MediaID:    item.MediaID,
+			MediaType:  s.media[m].MediaType,
+			Title:      s.media[m].Title,
+			ImageUrl:   s.media[m].ImageUrl,
+			UnitID:     item.UnitID,
+			UnitName:   s.units[u].Name,
+			CellRow:    item.CellRow,
+			CellColumn: item.CellColumn,
+			PlacedAt:   item.PlacedAt,
+		})
+	}
+	return items
+}
+
+func (s *MemStore) GetUserMediumShelvingItem(ctx context.Context, arg database.GetUserMediumShelvingItemParams) (database.GetUserMediumShelvingItemRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	items := s.userShelvingItems(arg.UserID, func(_ database.Medium, item database.ShelvingItem) bool {
+		return sameUUID(item.MediaID, arg.MediaID)
+	})
+	if len(items) == 0 {
+		return database.GetUserMediumShelvingItemRow{}, pgx.ErrNoRows
+	}
+	return database.GetUserMediumShelvingItemRow(items[0]), nil
+}
+
+func (s *MemStore) FindShelvingItemsByTitle(ctx context.Context, arg database.FindShelvingItemsByTitleParams) ([]database.FindShelvingItemsByTitleRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	items := s.userShelvingItems(arg.UserID, func(medium database.Medium, _ database.ShelvingItem) bool {
+		return strings.Contains(strings.ToLower(medium.Title), strings.ToLower(arg.Title))
+	})
+	// ORDER BY lower(title), media_id
+	slices.SortFunc(items, func(a, b database.FindShelvingItemsByTitleRow) int {
+		if c := strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title)); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.MediaID.Bytes[:], b.MediaID.Bytes[:])
+	})
+	return items, nil
+}
+
+func (s *MemStore) RemoveShelvingItem(ctx context.Context, arg database.RemoveShelvingItemParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.IndexFunc(s.shelvingItems, func(item database.ShelvingItem) bool {
+		return sameUUID(item.UserID, arg.UserID) && sameUUID(item.MediaID, arg.MediaID)
+	})
+	if i == -1 {
+		return 0, nil
+	}
+	s.shelvingItems = append(s.shelvingItems[:i], s.shelvingItems[i+1:]...)
+	return 1, nil
+}
+
+// Move items of a merged medium to the kept one, unless their user keeps it somewhere already (caller must hold the lock)
+func (s *MemStore) repointShelvingItems(oldMediaID, newMediaID pgtype.UUID) {
+	for i, item := range s.shelvingItems {
+		if !sameUUID(item.MediaID, oldMediaID) {
+			continue
+		}
+		if slices.ContainsFunc(s.shelvingItems, func(existing database.ShelvingItem) bool {
+			return sameUUID(existing.UserID, item.UserID) && sameUUID(existing.MediaID, newMediaID)
+		}) {
+			continue
+		}
+		s.shelvingItems[i].MediaID = newMediaID
+	}
+}
+
+// Apply ON DELETE CASCADE to shelving_items once shelving units were removed (caller must hold the lock)
+func (s *MemStore) cascadeShelvingUnitDelete() {
+	shelvingItems := s.shelvingItems[:0]
+	for _, item := range s.shelvingItems {
+		if s.shelvingUnitIndex(item.UnitID) != -1 {
+			shelvingItems = append(shelvingItems, item)
+		}
+	}
+	s.shelvingItems = shelvingItems
+}
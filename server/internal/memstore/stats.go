@@ -0,0 +1,333 @@
+package memstore
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Keys under which a medium's metadata stores its genres
+var genreKeys = []string{"genres", "subjects", "categories"}
+
+// Equivalent of "value >= start AND value < end" (half-open window)
+func inWindow(value, start, end pgtype.Timestamp) bool {
+	return value.Valid && !value.Time.Before(start.Time) && value.Time.Before(end.Time)
+}
+
+func startedIn(record database.UsersMediaRecord, start, end pgtype.Timestamp) bool {
+	return inWindow(record.StartDate, start, end)
+}
+
+func finishedIn(record database.UsersMediaRecord, start, end pgtype.Timestamp) bool {
+	return record.IsFinished.Valid && record.IsFinished.Bool && inWindow(record.EndDate, start, end)
+}
+
+// Equivalent of avg() and percentile_cont(0.5) over EXTRACT(DAY FROM duration), 0 when empty
+func durationStats(records []database.UsersMediaRecord) (float64, float64) {
+	var days []float64
+	for _, record := range records {
+		if record.Duration.Valid {
+			days = append(days, float64(record.Duration.Days))
+		}
+	}
+	if len(days) == 0 {
+		return 0, 0
+	}
+
+	sum := 0.0
+	for _, d := range days {
+		sum += d
+	}
+	slices.Sort(days)
+	median := days[len(days)/2]
+	if len(days)%2 == 0 {
+		median = (days[len(days)/2-1] + median) / 2
+	}
+	return sum / float64(len(days)), median
+}
+
+// Count started/finished records and compute finished records' duration stats
+func windowStats(records []database.UsersMediaRecord, start, end pgtype.Timestamp) (started, finished int64, avg, median float64) {
+	var completed []database.UsersMediaRecord
+	for _, record := range records {
+		if startedIn(record, start, end) {
+			started++
+		}
+		if finishedIn(record, start, end) {
+			finished++
+			completed = append(completed, record)
+		}
+	}
+	avg, median = durationStats(completed)
+	return started, finished, avg, median
+}
+
+// Get a user's records, joined with their medium (caller must hold the lock)
+func (s *MemStore) userRecordsWithMedia(userID pgtype.UUID) ([]database.UsersMediaRecord, []database.Medium) {
+	var records []database.UsersMediaRecord
+	var media []database.Medium
+	for _, record := range s.records {
+		if !sameUUID(record.UserID, userID) {
+			continue
+		}
+		j := s.mediumIndex(record.MediaID)
+		if j == -1 {
+			continue
+		}
+		records = append(records, record)
+		media = append(media, s.media[j])
+	}
+	return records, media
+}
+
+func (s *MemStore) GetStatsByMediaType(ctx context.Context, arg database.GetStatsByMediaTypeParams) ([]database.GetStatsByMediaTypeRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	records, media := s.userRecordsWithMedia(arg.UserID)
+	byType := map[string][]database.UsersMediaRecord{}
+	for i, record := range records {
+		if startedIn(record, arg.WindowStart, arg.WindowEnd) || inWindow(record.EndDate, arg.WindowStart, arg.WindowEnd) {
+			byType[media[i].MediaType] = append(byType[media[i].MediaType], record)
+		}
+	}
+
+	var items []database.GetStatsByMediaTypeRow
+	for mediaType, typeRecords := range byType {
+		started, finished, avg, median := windowStats(typeRecords, arg.WindowStart, arg.WindowEnd)
+		items = append(items, database.GetStatsByMediaTypeRow{
+			MediaType:      mediaType,
+			Started:        started,
+			Finished:       finished,
+			AvgDuration:    avg,
+			MedianDuration: median,
+		})
+	}
+	slices.SortFunc(items, func(a, b database.GetStatsByMediaTypeRow) int {
+		return strings.Compare(a.MediaType, b.MediaType)
+	})
+	return items, nil
+}
+
+func (s *MemStore) GetStatsTotals(ctx context.Context, arg database.GetStatsTotalsParams) (database.GetStatsTotalsRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var records []database.UsersMediaRecord
+	for _, record := range s.records {
+		if sameUUID(record.UserID, arg.UserID) {
+			records = append(records, record)
+		}
+	}
+	started, finished, avg, median := windowStats(records, arg.WindowStart, arg.WindowEnd)
+	return database.GetStatsTotalsRow{
+		Started:        started,
+		Finished:       finished,
+		AvgDuration:    avg,
+		MedianDuration: median,
+	}, nil
+}
+
+// Equivalent of comparing two INTERVALs (a month counts as 30 days)
+func compareIntervals(a, b pgtype.Interval) int {
+	toMicroseconds := func(i pgtype.Interval) int64 {
+		return (int64(i.Months)*30+int64(i.Days))*int64(24*time.Hour/time.Microsecond) + i.Microseconds
+	}
+	return cmp.Compare(toMicroseconds(a), toMicroseconds(b))
+}
+
+// Finished records in the window with a duration, ordered by duration (longest first if desc)
+func (s *MemStore) completions(userID pgtype.UUID, start, end pgtype.Timestamp, top int32, desc bool) []database.GetLongestCompletionsRow {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	records, media := s.userRecordsWithMedia(userID)
+	var items []database.GetLongestCompletionsRow
+	for i, record := range records {
+		if !finishedIn(record, start, end) || !record.Duration.Valid {
+			continue
+		}
+		items = append(items, database.GetLongestCompletionsRow{
+			ID:        record.ID,
+			MediaID:   record.MediaID,
+			MediaType: media[i].MediaType,
+			Title:     media[i].Title,
+			Creator:   media[i].Creator,
+			StartDate: record.StartDate,
+			EndDate:   record.EndDate,
+			Duration:  record.Duration,
+		})
+	}
+
+	slices.SortFunc(items, func(a, b database.GetLongestCompletionsRow) int {
+		c := compareIntervals(a.Duration, b.Duration)
+		if desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+		if c := b.EndDate.Time.Compare(a.EndDate.Time); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ID.Bytes[:], b.ID.Bytes[:])
+	})
+	if len(items) > int(top) {
+		items = items[:max(top, 0)]
+	}
+	return items
+}
+
+func (s *MemStore) GetLongestCompletions(ctx context.Context, arg database.GetLongestCompletionsParams) ([]database.GetLongestCompletionsRow, error) {
+	return s.completions(arg.UserID, arg.WindowStart, arg.WindowEnd, arg.Top, true), nil
+}
+
+func (s *MemStore) GetShortestCompletions(ctx context.Context, arg database.GetShortestCompletionsParams) ([]database.GetShortestCompletionsRow, error) {
+	var items []database.GetShortestCompletionsRow
+	for _, row := range s.completions(arg.UserID, arg.WindowStart, arg.WindowEnd, arg.Top, false) {
+		items = append(items, database.GetShortestCompletionsRow(row))
+	}
+	return items, nil
+}
+
+// Sort counted values by count DESC then value, and keep the top ones
+func topCounts(counts map[string]int64, top int32) []string {
+	var values []string
+	for value := range counts {
+		values = append(values, value)
+	}
+	slices.SortFunc(values, func(a, b string) int {
+		if c := cmp.Compare(counts[b], counts[a]); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+	if len(values) > int(top) {
+		values = values[:max(top, 0)]
+	}
+	return values
+}
+
+func (s *MemStore) GetTopCreators(ctx context.Context, arg database.GetTopCreatorsParams) ([]database.GetTopCreatorsRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	records, media := s.userRecordsWithMedia(arg.UserID)
+	counts := map[string]int64{}
+	for i, record := range records {
+		if finishedIn(record, arg.WindowStart, arg.WindowEnd) && media[i].Creator != "" {
+			counts[media[i].Creator]++
+		}
+	}
+
+	var items []database.GetTopCreatorsRow
+	for _, creator := range topCounts(counts, arg.Top) {
+		items = append(items, database.GetTopCreatorsRow{Creator: creator, Count: counts[creator]})
+	}
+	return items, nil
+}
+
+// Equivalent of jsonb_array_elements_text() over every genre key of a medium's metadata
+func mediumGenres(metadata []byte) []string {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(metadata, &fields); err != nil {
+		return nil
+	}
+	var genres []string
+	for _, key := range genreKeys {
+		values, ok := fields[key].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, value := range values {
+			if text, ok := jsonText(value); ok {
+				genres = append(genres, text)
+			}
+		}
+	}
+	return genres
+}
+
+func (s *MemStore) GetTopGenres(ctx context.Context, arg database.GetTopGenresParams) ([]database.GetTopGenresRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	records, media := s.userRecordsWithMedia(arg.UserID)
+	counts := map[string]int64{}
+	for i, record := range records {
+		if !finishedIn(record, arg.WindowStart, arg.WindowEnd) {
+			continue
+		}
+		// count(DISTINCT records.id): a genre listed twice counts once per record
+		seen := map[string]bool{}
+		for _, genre := range mediumGenres(media[i].Metadata) {
+			if !seen[genre] {
+				seen[genre] = true
+				counts[genre]++
+			}
+		}
+	}
+
+	var items []database.GetTopGenresRow
+	for _, genre := range topCounts(counts, arg.Top) {
+		items = append(items, database.GetTopGenresRow{Genre: genre, Count: counts[genre]})
+	}
+	return items, nil
+}
+
+// Equivalent of date_trunc() for 'day', 'week' (starting on monday) and 'month'
+func truncateTime(bucket string, t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch bucket {
+	case "week":
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case "month":
+		return day.AddDate(0, 0, 1-day.Day())
+	default:
+		return day
+	}
+}
+
+// Equivalent of adding "1 <bucket>" interval
+func nextBucket(bucket string, t time.Time) time.Time {
+	switch bucket {
+	case "week":
+		return t.AddDate(0, 0, 7)
+	case "month":
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+func (s *MemStore) GetStatsSeries(ctx context.Context, arg database.GetStatsSeriesParams) ([]database.GetStatsSeriesRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []database.GetStatsSeriesRow
+	index := map[time.Time]int{}
+	for t := truncateTime(arg.Bucket, arg.WindowStart.Time); t.Before(arg.WindowEnd.Time); t = nextBucket(arg.Bucket, t) {
+		index[t] = len(items)
+		items = append(items, database.GetStatsSeriesRow{BucketStart: pgtype.Timestamp{Time: t, Valid: true}})
+	}
+
+	for _, record := range s.records {
+		if !sameUUID(record.UserID, arg.UserID) {
+			continue
+		}
+		if startedIn(record, arg.WindowStart, arg.WindowEnd) {
+			items[index[truncateTime(arg.Bucket, record.StartDate.Time)]].Started++
+		}
+		if finishedIn(record, arg.WindowStart, arg.WindowEnd) {
+			items[index[truncateTime(arg.Bucket, record.EndDate.Time)]].Finished++
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,267 @@
+package memstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Find a tag's index by ID, -1 if not found (caller must hold the lock)
+func (s *MemStore) tagIndex(id pgtype.UUID) int {
+	for i, tag := range s.tags {
+		if sameUUID(tag.ID, id) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Find a user's tag by ID, -1 if not found or owned by someone else (caller must hold the lock)
+func (s *MemStore) userTagIndex(id, userID pgtype.UUID) int {
+	i := s.tagIndex(id)
+	if i == -1 || !sameUUID(s.tags[i].UserID, userID) {
+		return -1
+	}
+	return i
+}
+
+// Unique index on (user_id, lower(name)), skipping the tag being updated (caller must hold the lock)
+func (s *MemStore) checkTagName(userID pgtype.UUID, name string, skip pgtype.UUID) error {
+	if strings.TrimSpace(name) == "" {
+		return checkViolation("tags", "tags_name_check")
+	}
+	for _, tag := range s.tags {
+		if sameUUID(tag.UserID, userID) && !sameUUID(tag.ID, skip) && strings.EqualFold(tag.Name, name) {
+			return uniqueViolation("tags", "tags_user_id_name_key", fmt.Sprintf("Key (user_id, lower(name))=(%s, %s) already exists.", userID, strings.ToLower(name)))
+		}
+	}
+	return nil
+}
+
+func (s *MemStore) CreateTag(ctx context.Context, arg database.CreateTagParams) (database.Tag, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !arg.UserID.Valid {
+		return database.Tag{}, notNullViolation("tags", "user_id")
+	}
+	if err := s.checkTagName(arg.UserID, arg.Name, pgtype.UUID{}); err != nil {
+		return database.Tag{}, err
+	}
+	if s.userIndex(arg.UserID) == -1 {
+		return database.Tag{}, foreignKeyViolation("tags", "tags_user_id_fkey", fmt.Sprintf("Key (user_id)=(%s) is not present in table \"users\".", arg.UserID))
+	}
+
+	tag := database.Tag{
+		ID:        newUUID(),
+		CreatedAt: now(),
+		UpdatedAt: now(),
+		UserID:    arg.UserID,
+		Name:      arg.Name,
+	}
+	s.tags = append(s.tags, tag)
+	return tag, nil
+}
+
+func (s *MemStore) GetTagsByUserID(ctx context.Context, userID pgtype.UUID) ([]database.GetTagsByUserIDRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []database.GetTagsByUserIDRow
+	for _, tag := range s.tags {
+		if !sameUUID(tag.UserID, userID) {
+			continue
+		}
+		row := database.GetTagsByUserIDRow{
+			ID:        tag.ID,
+			CreatedAt: tag.CreatedAt,
+			UpdatedAt: tag.UpdatedAt,
+			UserID:    tag.UserID,
+			Name:      tag.Name,
+		}
+		for _, link := range s.mediaTags {
+			if sameUUID(link.TagID, tag.ID) {
+				row.MediaCount++
+			}
+		}
+		items = append(items, row)
+	}
+
+	slices.SortFunc(items, func(a, b database.GetTagsByUserIDRow) int {
+		if c := strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ID.Bytes[:], b.ID.Bytes[:])
+	})
+	return items, nil
+}
+
+func (s *MemStore) GetUserTagByID(ctx context.Context, arg database.GetUserTagByIDParams) (database.Tag, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := s.userTagIndex(arg.ID, arg.UserID)
+	if i == -1 {
+		return database.Tag{}, pgx.ErrNoRows
+	}
+	return s.tags[i], nil
+}
+
+func (s *MemStore) UpdateTag(ctx context.Context, arg database.UpdateTagParams) (database.Tag, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.userTagIndex(arg.ID, arg.UserID)
+	if i == -1 {
+		return database.Tag{}, pgx.ErrNoRows
+	}
+	if err := s.checkTagName(arg.UserID, arg.Name, arg.ID); err != nil {
+		return database.Tag{}, err
+	}
+	s.tags[i].Name = arg.Name
+	s.tags[i].UpdatedAt = now()
+	return s.tags[i], nil
+}
+
+func (s *MemStore) DeleteTag(ctx context.Context, arg database.DeleteTagParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.userTagIndex(arg.ID, arg.UserID)
+	if i == -1 {
+		return 0, nil
+	}
+	s.tags = append(s.tags[:i], s.tags[i+1:]...)
+	s.cascadeTagDelete()
+	return 1, nil
+}
+
+func (s *MemStore) TagMedia(ctx context.Context, arg database.TagMediaParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The whole insert fails if one row is invalid
+	if !arg.TagID.Valid {
+		return 0, notNullViolation("media_tags", "tag_id")
+	}
+	if s.tagIndex(arg.TagID) == -1 {
+		return 0, foreignKeyViolation("media_tags", "media_tags_tag_id_fkey", fmt.Sprintf("Key (tag_id)=(%s) is not present in table \"tags\".", arg.TagID))
+	}
+	for _, mediaID := range arg.MediaIds {
+		if !mediaID.Valid {
+			return 0, notNullViolation("media_tags", "media_id")
+		}
+		if s.mediumIndex(mediaID) == -1 {
+			return 0, foreignKeyViolation("media_tags", "media_tags_media_id_fkey", fmt.Sprintf("Key (media_id)=(%s) is not present in table \"media\".", mediaID))
+		}
+	}
+
+	// ON CONFLICT DO NOTHING
+	var count int64
+	for _, mediaID := range arg.MediaIds {
+		if s.hasMediaTag(arg.TagID, mediaID) {
+			continue
+		}
+		s.mediaTags = append(s.mediaTags, database.MediaTag{
+			TagID:     arg.TagID,
+			MediaID:   mediaID,
+			CreatedAt: now(),
+		})
+		count++
+	}
+	return count, nil
+}
+
+func (s *MemStore) UntagMedia(ctx context.Context, arg database.UntagMediaParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var count int64
+	mediaTags := s.mediaTags[:0]
+	for _, link := range s.mediaTags {
+		if sameUUID(link.TagID, arg.TagID) && slices.ContainsFunc(arg.MediaIds, func(mediaID pgtype.UUID) bool { return sameUUID(mediaID, link.MediaID) }) {
+			count++
+			continue
+		}
+		mediaTags = append(mediaTags, link)
+	}
+	s.mediaTags = mediaTags
+	return count, nil
+}
+
+func (s *MemStore) GetUserMediaTags(ctx context.Context, userID pgtype.UUID) ([]database.GetUserMediaTagsRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []database.GetUserMediaTagsRow
+	for _, link := range s.mediaTags {
+		i := s.tagIndex(link.TagID)
+		if i == -1 || !sameUUID(s.tags[i].UserID, userID) {
+			continue
+		}
+		items = append(items, database.GetUserMediaTagsRow{
+			MediaID: link.MediaID,
+			TagID:   link.TagID,
+			Name:    s.tags[i].Name,
+		})
+	}
+
+	slices.SortFunc(items, func(a, b database.GetUserMediaTagsRow) int {
+		if c := strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.MediaID.Bytes[:], b.MediaID.Bytes[:])
+	})
+	return items, nil
+}
+
+// Check if a medium holds a tag (caller must hold the lock)
+func (s *MemStore) hasMediaTag(tagID, mediaID pgtype.UUID) bool {
+	for _, link := range s.mediaTags {
+		if sameUUID(link.TagID, tagID) && sameUUID(link.MediaID, mediaID) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check if a user put a tag with this name on a medium (caller must hold the lock)
+func (s *MemStore) hasUserMediaTagName(userID, mediaID pgtype.UUID, name string) bool {
+	for _, link := range s.mediaTags {
+		if !sameUUID(link.MediaID, mediaID) {
+			continue
+		}
+		i := s.tagIndex(link.TagID)
+		if i != -1 && sameUUID(s.tags[i].UserID, userID) && strings.EqualFold(s.tags[i].Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Move a merged medium's tags to the kept one, unless it already holds them (caller must hold the lock)
+func (s *MemStore) repointMediaTags(oldMediaID, newMediaID pgtype.UUID) {
+	for i, link := range s.mediaTags {
+		if sameUUID(link.MediaID, oldMediaID) && !s.hasMediaTag(link.TagID, newMediaID) {
+			s.mediaTags[i].MediaID = newMediaID
+		}
+	}
+}
+
+// Apply ON DELETE CASCADE to media_tags and goals_tags once tags were removed (caller must hold the lock)
+func (s *MemStore) cascadeTagDelete() {
+	mediaTags := s.mediaTags[:0]
+	for _, link := range s.mediaTags {
+		if s.tagIndex(link.TagID) != -1 {
+			mediaTags = append(mediaTags, link)
+		}
+	}
+	s.mediaTags = mediaTags
+	s.cascadeGoalDelete()
+}
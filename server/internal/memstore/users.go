@@ -0,0 +1,339 @@
+package memstore
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Find a user's index by ID, -1 if not found (caller must hold the lock)
+func (s *MemStore) userIndex(id pgtype.UUID) int {
+	for i, user := range s.users {
+		if sameUUID(user.ID, id) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Check users UNIQUE constraints, ignoring the row at index skip (caller must hold the lock)
+func (s *MemStore) checkUserUnique(username, email string, skip int) error {
+	for i, user := range s.users {
+		if i == skip {
+			continue
+		}
+		if user.Username == username {
+			return uniqueViolation("users", "users_username_key", fmt.Sprintf("Key (username)=(%s) already exists.", username))
+		}
+		if user.Email == email {
+			return uniqueViolation("users", "users_email_key", fmt.Sprintf("Key (email)=(%s) already exists.", email))
+		}
+	}
+	return nil
+}
+
+func (s *MemStore) CreateUser(ctx context.Context, arg database.CreateUserParams) (database.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkUserUnique(arg.Username, arg.Email, -1); err != nil {
+		return database.User{}, err
+	}
+
+	timestamp := now()
+	user := database.User{
+		ID:             newUUID(),
+		CreatedAt:      timestamp,
+		UpdatedAt:      timestamp,
+		Username:       arg.Username,
+		HashedPassword: arg.HashedPassword,
+		Email:          arg.Email,
+		Role:           "user",
+	}
+	s.users = append(s.users, user)
+	return user, nil
+}
+
+func (s *MemStore) GetUserByID(ctx context.Context, id pgtype.UUID) (database.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := s.userIndex(id)
+	if i == -1 {
+		return database.User{}, pgx.ErrNoRows
+	}
+	return s.users[i], nil
+}
+
+func (s *MemStore) GetUserByUsername(ctx context.Context, username string) (database.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, user := range s.users {
+		if user.Username == username {
+			return user, nil
+		}
+	}
+	return database.User{}, pgx.ErrNoRows
+}
+
+func (s *MemStore) GetUserByEmail(ctx context.Context, email string) (database.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, user := range s.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return database.User{}, pgx.ErrNoRows
+}
+
+func (s *MemStore) UpdateUser(ctx context.Context, arg database.UpdateUserParams) (database.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.userIndex(arg.ID)
+	if i == -1 {
+		return database.User{}, pgx.ErrNoRows
+	}
+	if err := s.checkUserUnique(arg.Username, arg.Email, i); err != nil {
+		return database.User{}, err
+	}
+
+	s.users[i].Username = arg.Username
+	s.users[i].HashedPassword = arg.HashedPassword
+	s.users[i].Email = arg.Email
+	s.users[i].UpdatedAt = now()
+	return s.users[i], nil
+}
+
+func (s *MemStore) UpdatePassword(ctx context.Context, arg database.UpdatePasswordParams) (database.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.userIndex(arg.ID)
+	if i == -1 {
+		return database.User{}, pgx.ErrNoRows
+	}
+
+	s.users[i].HashedPassword = arg.HashedPassword
+	s.users[i].UpdatedAt = now()
+	return s.users[i], nil
+}
+
+func (s *MemStore) SearchUsers(ctx context.Context, arg database.SearchUsersParams) ([]database.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Equivalent of ILIKE '%search%' on username or email
+	search := strings.ToLower(arg.Search)
+	var items []database.User
+	for _, user := range s.users {
+		if search == "" || strings.Contains(strings.ToLower(user.Username), search) || strings.Contains(strings.ToLower(user.Email), search) {
+			items = append(items, user)
+		}
+	}
+	slices.SortFunc(items, func(a, b database.User) int {
+		return strings.Compare(a.Username, b.Username)
+	})
+
+	// LIMIT and OFFSET
+	offset := min(max(int(arg.PageOffset), 0), len(items))
+	items = items[offset:]
+	if len(items) > int(arg.PageLimit) {
+		items = items[:max(arg.PageLimit, 0)]
+	}
+	return items, nil
+}
+
+func (s *MemStore) UpdateUserRoleByUsername(ctx context.Context, arg database.UpdateUserRoleByUsernameParams) (database.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// CHECK (role IN ('user', 'admin'))
+	if arg.Role != "user" && arg.Role != "admin" {
+		return database.User{}, checkViolation("users", "users_role_check")
+	}
+	for i, user := range s.users {
+		if user.Username == arg.Username {
+			s.users[i].Role = arg.Role
+			s.users[i].UpdatedAt = now()
+			return s.users[i], nil
+		}
+	}
+	return database.User{}, pgx.ErrNoRows
+}
+
+func (s *MemStore) UpdateUserDeactivatedAt(ctx context.Context, arg database.UpdateUserDeactivatedAtParams) (database.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.userIndex(arg.ID)
+	if i == -1 {
+		return database.User{}, pgx.ErrNoRows
+	}
+
+	s.users[i].DeactivatedAt = arg.DeactivatedAt
+	s.users[i].UpdatedAt = now()
+	return s.users[i], nil
+}
+
+func (s *MemStore) DeleteUser(ctx context.Context, id pgtype.UUID) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.userIndex(id)
+	if i == -1 {
+		return 0, nil
+	}
+	s.users = append(s.users[:i], s.users[i+1:]...)
+	s.cascadeUserDelete(func(userID pgtype.UUID) bool { return sameUUID(userID, id) })
+	return 1, nil
+}
+
+func (s *MemStore) ResetUsers(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.users = nil
+	s.cascadeUserDelete(func(pgtype.UUID) bool { return true })
+	return nil
+}
+
+// Apply ON DELETE CASCADE to every table referencing users (caller must hold the lock)
+func (s *MemStore) cascadeUserDelete(deleted func(userID pgtype.UUID) bool) {
+	refreshTokens := s.refreshTokens[:0]
+	for _, token := range s.refreshTokens {
+		if !deleted(token.UserID) {
+			refreshTokens = append(refreshTokens, token)
+		}
+	}
+	s.refreshTokens = refreshTokens
+
+	resetTokens := s.resetTokens[:0]
+	for _, token := range s.resetTokens {
+		if !deleted(token.UserID) {
+			resetTokens = append(resetTokens, token)
+		}
+	}
+	s.resetTokens = resetTokens
+
+	records := s.records[:0]
+	for _, record := range s.records {
+		if !record.UserID.Valid || !deleted(record.UserID) {
+			records = append(records, record)
+		}
+	}
+	s.records = records
+
+	shares := s.shares[:0]
+	for _, share := range s.shares {
+		if !deleted(share.OwnerID) && !deleted(share.RecipientID) {
+			shares = append(shares, share)
+		}
+	}
+	s.shares = shares
+	s.cascadeRecordDelete()
+
+	tags := s.tags[:0]
+	for _, tag := range s.tags {
+		if !deleted(tag.UserID) {
+			tags = append(tags, tag)
+		}
+	}
+	s.tags = tags
+	s.cascadeTagDelete()
+
+	shelves := s.shelves[:0]
+	for _, shelf := range s.shelves {
+		if !deleted(shelf.UserID) {
+			shelves = append(shelves, shelf)
+		}
+	}
+	s.shelves = shelves
+	s.cascadeShelfDelete()
+
+	loans := s.loans[:0]
+	for _, loan := range s.loans {
+		if !deleted(loan.OwnerID) {
+			loans = append(loans, loan)
+		}
+	}
+	s.loans = loans
+
+	copies := s.copies[:0]
+	for _, ownedCopy := range s.copies {
+		if !deleted(ownedCopy.UserID) {
+			copies = append(copies, ownedCopy)
+		}
+	}
+	s.copies = copies
+
+	units := s.units[:0]
+	for _, unit := range s.units {
+		if !deleted(unit.UserID) {
+			units = append(units, unit)
+		}
+	}
+	s.units = units
+
+	shelvingItems := s.shelvingItems[:0]
+	for _, item := range s.shelvingItems {
+		if !deleted(item.UserID) && s.shelvingUnitIndex(item.UnitID) != -1 {
+			shelvingItems = append(shelvingItems, item)
+		}
+	}
+	s.shelvingItems = shelvingItems
+
+	plays := s.plays[:0]
+	for _, play := range s.plays {
+		if !deleted(play.UserID) {
+			plays = append(plays, play)
+		}
+	}
+	s.plays = plays
+	s.cascadePlayDelete()
+
+	goals := s.goals[:0]
+	for _, goal := range s.goals {
+		if !deleted(goal.UserID) {
+			goals = append(goals, goal)
+		}
+	}
+	s.goals = goals
+	s.cascadeGoalDelete()
+
+	// ON DELETE SET NULL on loans.borrower_id, borrower's name is kept
+	for i, loan := range s.loans {
+		if loan.BorrowerID.Valid && deleted(loan.BorrowerID) {
+			s.loans[i].BorrowerID = pgtype.UUID{}
+		}
+	}
+
+	// ON DELETE SET NULL on plays_players.player_id, player's name is kept
+	for i, player := range s.playsPlayers {
+		if player.PlayerID.Valid && deleted(player.PlayerID) {
+			s.playsPlayers[i].PlayerID = pgtype.UUID{}
+		}
+	}
+
+	// ON DELETE SET NULL on media.created_by
+	for i, medium := range s.media {
+		if medium.CreatedBy.Valid && deleted(medium.CreatedBy) {
+			s.media[i].CreatedBy = pgtype.UUID{}
+		}
+	}
+
+	// ON DELETE SET NULL on media_redirects.merged_by
+	for i, redirect := range s.redirects {
+		if redirect.MergedBy.Valid && deleted(redirect.MergedBy) {
+			s.redirects[i].MergedBy = pgtype.UUID{}
+		}
+	}
+}
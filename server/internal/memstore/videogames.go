@@ -0,0 +1,144 @@
+package memstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Find videogame details' index by record ID, -1 if not found (caller must hold the lock)
+func (s *MemStore) videogameIndexByRecordID(recordID pgtype.UUID) int {
+	for i, details := range s.videogames {
+		if sameUUID(details.RecordID, recordID) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *MemStore) UpsertRecordVideogame(ctx context.Context, arg database.UpsertRecordVideogameParams) (database.RecordsVideogame, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !arg.RecordID.Valid {
+		return database.RecordsVideogame{}, notNullViolation("records_videogames", "record_id")
+	}
+	if s.recordIndex(arg.RecordID) == -1 {
+		return database.RecordsVideogame{}, foreignKeyViolation("records_videogames", "records_videogames_record_id_fkey", fmt.Sprintf("Key (record_id)=(%s) is not present in table \"users_media_records\".", arg.RecordID))
+	}
+	if arg.HoursPlayed.Valid && arg.HoursPlayed.Float64 < 0 {
+		return database.RecordsVideogame{}, checkViolation("records_videogames", "records_videogames_hours_played_check")
+	}
+	if arg.Completion != "" && !slices.Contains(database.VideogameCompletions, arg.Completion) {
+		return database.RecordsVideogame{}, checkViolation("records_videogames", "records_videogames_completion_check")
+	}
+	if arg.AchievementsEarned.Valid && arg.AchievementsEarned.Int32 < 0 {
+		return database.RecordsVideogame{}, checkViolation("records_videogames", "records_videogames_achievements_earned_check")
+	}
+	if arg.AchievementsTotal.Valid && arg.AchievementsTotal.Int32 < 0 {
+		return database.RecordsVideogame{}, checkViolation("records_videogames", "records_videogames_achievements_total_check")
+	}
+	if arg.AchievementsEarned.Valid && arg.AchievementsTotal.Valid && arg.AchievementsEarned.Int32 > arg.AchievementsTotal.Int32 {
+		return database.RecordsVideogame{}, checkViolation("records_videogames", "records_videogames_check")
+	}
+
+	details := database.RecordsVideogame{
+		RecordID:           arg.RecordID,
+		UpdatedAt:          now(),
+		Platform:           arg.Platform,
+		HoursPlayed:        arg.HoursPlayed,
+		Completion:         arg.Completion,
+		AchievementsEarned: arg.AchievementsEarned,
+		AchievementsTotal:  arg.AchievementsTotal,
+	}
+	// ON CONFLICT (record_id) DO UPDATE
+	if i := s.videogameIndexByRecordID(arg.RecordID); i != -1 {
+		s.videogames[i] = details
+	} else {
+		s.videogames = append(s.videogames, details)
+	}
+	return details, nil
+}
+
+func (s *MemStore) GetRecordVideogame(ctx context.Context, recordID pgtype.UUID) (database.RecordsVideogame, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := s.videogameIndexByRecordID(recordID)
+	if i == -1 {
+		return database.RecordsVideogame{}, pgx.ErrNoRows
+	}
+	return s.videogames[i], nil
+}
+
+func (s *MemStore) GetVideogameRecordsByUserID(ctx context.Context, userID pgtype.UUID) ([]database.GetVideogameRecordsByUserIDRow, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []database.GetVideogameRecordsByUserIDRow
+	for _, record := range s.records {
+		if !sameUUID(record.UserID, userID) {
+			continue
+		}
+		m := s.mediumIndex(record.MediaID)
+		if m == -1 || s.media[m].MediaType != "videogame" {
+			continue
+		}
+		row := database.GetVideogameRecordsByUserIDRow{
+			ID:       record.ID,
+			MediaID:  record.MediaID,
+			Status:   record.Status,
+			Title:    s.media[m].Title,
+			ImageUrl: s.media[m].ImageUrl,
+			Metadata: copyBytes(s.media[m].Metadata),
+		}
+		// LEFT JOIN records_videogames
+		if i := s.videogameIndexByRecordID(record.ID); i != -1 {
+			details := s.videogames[i]
+			row.Platform = pgtype.Text{String: details.Platform, Valid: true}
+			row.HoursPlayed = details.HoursPlayed
+			row.Completion = pgtype.Text{String: details.Completion, Valid: true}
+			row.AchievementsEarned = details.AchievementsEarned
+			row.AchievementsTotal = details.AchievementsTotal
+		}
+		items = append(items, row)
+	}
+	// ORDER BY media.title, records.id
+	slices.SortFunc(items, func(a, b database.GetVideogameRecordsByUserIDRow) int {
+		if c := strings.Compare(a.Title, b.Title); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ID.Bytes[:], b.ID.Bytes[:])
+	})
+	return items, nil
+}
+
+// Record must have videogame details matching platform and completion filters (caller must hold the lock)
+func (s *MemStore) matchRecordVideogame(arg database.QueryRecordsParams, record database.UsersMediaRecord) bool {
+	if arg.Platform == "" && arg.Completion == "" {
+		return true
+	}
+	i := s.videogameIndexByRecordID(record.ID)
+	if i == -1 {
+		return false
+	}
+	details := s.videogames[i]
+	return (arg.Platform == "" || strings.EqualFold(details.Platform, strings.TrimSpace(arg.Platform))) &&
+		(arg.Completion == "" || details.Completion == arg.Completion)
+}
+
+// Videogame details of a record merged into another one follow it, unless the kept record already has some (caller must hold the lock)
+func (s *MemStore) repointVideogame(oldRecordID, newRecordID pgtype.UUID) {
+	if s.videogameIndexByRecordID(newRecordID) != -1 {
+		return
+	}
+	if i := s.videogameIndexByRecordID(oldRecordID); i != -1 {
+		s.videogames[i].RecordID = newRecordID
+	}
+}
@@ -0,0 +1,324 @@
+// Package migrate applies the goose formatted schema migrations embedded in the server binary.
+// It keeps track of applied versions in goose's own goose_db_version table,
+// so databases migrated by hand with goose are picked up where they were left.
+package migrate
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Key of the PostgreSQL advisory lock held while migrating,
+// so two servers starting at the same time can't migrate concurrently
+const advisoryLockKey int64 = 4_271_936_512
+
+const versionTable = "goose_db_version"
+
+// ErrSchemaTooNew is returned when the database has been migrated by a newer server version
+var ErrSchemaTooNew = errors.New("database schema is newer than this server version")
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt pgtype.Timestamp
+}
+
+type Migrator struct {
+	pool       *pgxpool.Pool
+	migrations []Migration
+}
+
+// New loads migrations from fsys and returns a Migrator using the given pool
+func New(pool *pgxpool.Pool, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		pool:       pool,
+		migrations: migrations,
+	}, nil
+}
+
+// Load reads every <version>_<name>.sql file at the root of fsys, sorted by version
+func Load(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := []Migration{}
+	seen := make(map[int64]string)
+	for _, file := range files {
+		prefix, name, found := strings.Cut(strings.TrimSuffix(path.Base(file), ".sql"), "_")
+		if !found {
+			return nil, fmt.Errorf("migration file %s: name must be <version>_<name>.sql", file)
+		}
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("migration file %s: invalid version %q", file, prefix)
+		}
+		if other, exists := seen[version]; exists {
+			return nil, fmt.Errorf("migration files %s and %s have the same version", other, file)
+		}
+		seen[version] = file
+
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		up, down, err := parse(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("migration file %s: %w", file, err)
+		}
+
+		migrations = append(migrations, Migration{
+			Version: version,
+			Name:    name,
+			Up:      up,
+			Down:    down,
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Split a goose file into its Up and Down sections
+func parse(content string) (string, string, error) {
+	var up, down strings.Builder
+	var current *strings.Builder
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		annotation, isAnnotation := strings.CutPrefix(strings.TrimSpace(line), "-- +goose ")
+		if isAnnotation {
+			switch strings.TrimSpace(annotation) {
+			case "Up":
+				current = &up
+			case "Down":
+				current = &down
+			case "StatementBegin", "StatementEnd":
+				// Whole sections are sent at once, statements don't need to be split
+			default:
+				return "", "", fmt.Errorf("unsupported goose annotation %q", annotation)
+			}
+			continue
+		}
+		if current == nil {
+			if strings.TrimSpace(line) != "" && !strings.HasPrefix(strings.TrimSpace(line), "--") {
+				return "", "", errors.New("statement found before '-- +goose Up' annotation")
+			}
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return "", "", err
+	}
+	if strings.TrimSpace(up.String()) == "" {
+		return "", "", errors.New("missing '-- +goose Up' section")
+	}
+
+	return up.String(), down.String(), nil
+}
+
+// Latest returns the highest version known by this binary
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up applies every pending migration, in order, under an advisory lock.
+// It refuses to do anything if the database schema is newer than the latest known migration.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.checkVersion(applied); err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, migration.Up); err != nil {
+					return err
+				}
+				_, err := tx.Exec(ctx, "INSERT INTO "+versionTable+" (version_id, is_applied) VALUES ($1, true)", migration.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("applying migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// Down rolls back the most recently applied migration
+func (m *Migrator) Down(ctx context.Context) error {
+	return m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.checkVersion(applied); err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, migration.Down); err != nil {
+					return err
+				}
+				_, err := tx.Exec(ctx, "DELETE FROM "+versionTable+" WHERE version_id = $1", migration.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("rolling back migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			return nil
+		}
+		return errors.New("no migration to roll back")
+	})
+}
+
+// Status returns every known migration with its state in database
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			appliedAt, ok := applied[migration.Version]
+			statuses = append(statuses, MigrationStatus{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				Applied:   ok,
+				AppliedAt: appliedAt,
+			})
+		}
+		return m.checkVersion(applied)
+	})
+	return statuses, err
+}
+
+// Check the database doesn't hold versions this binary doesn't know about
+func (m *Migrator) checkVersion(applied map[int64]pgtype.Timestamp) error {
+	for version := range applied {
+		if version > m.Latest() {
+			return fmt.Errorf("%w: database is at version %d, server only knows up to version %d", ErrSchemaTooNew, version, m.Latest())
+		}
+	}
+	return nil
+}
+
+// Run fn on a single connection holding the migration advisory lock
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("couldn't acquire a database connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", advisoryLockKey); err != nil {
+		return fmt.Errorf("couldn't take migration lock: %w", err)
+	}
+	defer conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", advisoryLockKey)
+
+	if err := ensureVersionTable(ctx, conn); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+// Create goose's version table if it doesn't exist yet
+func ensureVersionTable(ctx context.Context, conn *pgxpool.Conn) error {
+	var exists bool
+	err := conn.QueryRow(ctx, "SELECT to_regclass($1) IS NOT NULL", versionTable).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("couldn't check for version table: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `CREATE TABLE `+versionTable+` (
+			id SERIAL PRIMARY KEY,
+			version_id BIGINT NOT NULL,
+			is_applied BOOLEAN NOT NULL,
+			tstamp TIMESTAMP NULL DEFAULT NOW()
+		)`)
+		if err != nil {
+			return fmt.Errorf("couldn't create version table: %w", err)
+		}
+		_, err = tx.Exec(ctx, "INSERT INTO "+versionTable+" (version_id, is_applied) VALUES (0, true)")
+		return err
+	})
+}
+
+// Get applied versions and when they were applied
+// The latest row for a version tells its state, like goose does
+func appliedVersions(ctx context.Context, conn *pgxpool.Conn) (map[int64]pgtype.Timestamp, error) {
+	rows, err := conn.Query(ctx, "SELECT version_id, is_applied, tstamp FROM "+versionTable+" ORDER BY id DESC")
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read version table: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]pgtype.Timestamp)
+	seen := make(map[int64]bool)
+	for rows.Next() {
+		var version int64
+		var isApplied bool
+		var tstamp pgtype.Timestamp
+		if err := rows.Scan(&version, &isApplied, &tstamp); err != nil {
+			return nil, err
+		}
+		if seen[version] || version == 0 {
+			continue
+		}
+		seen[version] = true
+		if isApplied {
+			applied[version] = tstamp
+		}
+	}
+	return applied, rows.Err()
+}
@@ -0,0 +1,111 @@
+package migrate
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/VincNT21/kallaxy/database/schema"
+)
+
+func TestLoadEmbeddedSchema(t *testing.T) {
+	migrations, err := Load(schema.FS)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("no embedded migration found")
+	}
+
+	// Versions must be strictly increasing and every migration must be reversible
+	for i, migration := range migrations {
+		if i > 0 && migration.Version <= migrations[i-1].Version {
+			t.Errorf("migration %d_%s is out of order", migration.Version, migration.Name)
+		}
+		if strings.TrimSpace(migration.Down) == "" {
+			t.Errorf("migration %d_%s has no Down section", migration.Version, migration.Name)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	// Create tests table
+	tests := []struct {
+		name         string
+		files        fstest.MapFS
+		wantVersions []int64
+		wantUp       string
+		wantDown     string
+		wantErr      bool
+	}{
+		{
+			name: "Sorted by version",
+			files: fstest.MapFS{
+				"010_later.sql":  {Data: []byte("-- +goose Up\nSELECT 10;\n-- +goose Down\nSELECT -10;")},
+				"002_second.sql": {Data: []byte("-- +goose Up\nSELECT 2;\n-- +goose Down\nSELECT -2;")},
+				"001_first.sql":  {Data: []byte("-- +goose Up\nSELECT 1;\n-- +goose Down\nSELECT -1;")},
+			},
+			wantVersions: []int64{1, 2, 10},
+			wantUp:       "SELECT 1;\n",
+			wantDown:     "SELECT -1;\n",
+		},
+		{
+			name: "Statement blocks",
+			files: fstest.MapFS{
+				"001_function.sql": {Data: []byte("-- +goose Up\n-- +goose StatementBegin\nCREATE FUNCTION f() RETURNS int AS $$ SELECT 1; $$ LANGUAGE sql;\n-- +goose StatementEnd\n\n-- +goose Down\nDROP FUNCTION f;\n")},
+			},
+			wantVersions: []int64{1},
+			wantUp:       "CREATE FUNCTION f() RETURNS int AS $$ SELECT 1; $$ LANGUAGE sql;\n\n",
+			wantDown:     "DROP FUNCTION f;\n",
+		},
+		{
+			name: "Duplicate version",
+			files: fstest.MapFS{
+				"001_a.sql":  {Data: []byte("-- +goose Up\nSELECT 1;")},
+				"0001_b.sql": {Data: []byte("-- +goose Up\nSELECT 1;")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid file name",
+			files: fstest.MapFS{
+				"users.sql": {Data: []byte("-- +goose Up\nSELECT 1;")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Missing Up section",
+			files: fstest.MapFS{
+				"001_a.sql": {Data: []byte("SELECT 1;")},
+			},
+			wantErr: true,
+		},
+	}
+
+	// Test loop
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := Load(tt.files)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(migrations) != len(tt.wantVersions) {
+				t.Fatalf("Load() got %d migrations, want %d", len(migrations), len(tt.wantVersions))
+			}
+			for i, version := range tt.wantVersions {
+				if migrations[i].Version != version {
+					t.Errorf("migration %d version = %d, want %d", i, migrations[i].Version, version)
+				}
+			}
+			if migrations[0].Up != tt.wantUp {
+				t.Errorf("Up = %q, want %q", migrations[0].Up, tt.wantUp)
+			}
+			if migrations[0].Down != tt.wantDown {
+				t.Errorf("Down = %q, want %q", migrations[0].Down, tt.wantDown)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/VincNT21/kallaxy/server/internal/config"
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/joho/godotenv"
+)
+
+// Admin runs the "admin promote|demote <username> [flags]" command against the database configured as for the server
+func Admin(args []string) error {
+	if len(args) < 2 {
+		return errors.New("usage: admin promote|demote <username> [flags]")
+	}
+
+	var role string
+	switch args[0] {
+	case "promote":
+		role = roleAdmin
+	case "demote":
+		role = roleUser
+	default:
+		return fmt.Errorf("unknown admin command %q, usage: admin promote|demote <username> [flags]", args[0])
+	}
+
+	// Load the .env file into the environement
+	err := godotenv.Load(".env")
+	if err != nil {
+		log.Printf("Warning: .env file not found, using environment variables")
+	}
+
+	// Load config from flags, env. variables and optional config file
+	serverCfg, err := config.Load(args[2:], os.LookupEnv)
+	if err != nil {
+		return err
+	}
+
+	// Open a connection to database
+	dbConnection, err := pgxpool.New(context.Background(), serverCfg.DBURL)
+	if err != nil {
+		return fmt.Errorf("couldn't open a connection to db: %w", err)
+	}
+	defer dbConnection.Close()
+
+	user, err := database.New(dbConnection).UpdateUserRoleByUsername(context.Background(), database.UpdateUserRoleByUsernameParams{
+		Username: args[1],
+		Role:     role,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("no user with username %q", args[1])
+		}
+		return fmt.Errorf("couldn't update user's role: %w", err)
+	}
+	fmt.Printf("%s is now %s\n", user.Username, user.Role)
+	return nil
+}
+
+// Give admin role to usernames listed in config
+// Only existing accounts are promoted, so nobody can register a listed username afterwards to become admin
+func promoteAdminsOnStartup(db database.Store, usernames []string) {
+	for _, username := range usernames {
+		_, err := db.UpdateUserRoleByUsername(context.Background(), database.UpdateUserRoleByUsernameParams{
+			Username: username,
+			Role:     roleAdmin,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				log.Printf("--WARNING-- Admin user %q doesn't exist, register it then restart the server", username)
+				continue
+			}
+			log.Printf("--ERROR-- Couldn't give admin role to %q: %v", username, err)
+			continue
+		}
+		log.Printf("--INFO-- %q has admin role", username)
+	}
+}
@@ -1,23 +1,48 @@
 package server
 
-import "github.com/VincNT21/kallaxy/server/internal/database"
+import (
+	"net/http"
+	"time"
+
+	"github.com/VincNT21/kallaxy/server/internal/config"
+	"github.com/VincNT21/kallaxy/server/internal/database"
+)
 
 type apiConfig struct {
-	db            *database.Queries
-	jwtsecret     string
-	openlibraryUA string
-	moviedbKey    string
-	rawgKey       string
-	serverVersion string
+	db              database.Store
+	jwtsecret       string
+	openlibraryUA   string
+	moviedbKey      string
+	rawgKey         string
+	serverVersion   string
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+	resetTokenTTL   time.Duration
+	mediumProviders map[string]mediumProvider
+}
+
+func newAPIConfig(db database.Store, cfg config.Config) *apiConfig {
+	apiCfg := &apiConfig{
+		db:              db,
+		jwtsecret:       cfg.JWTSecret,
+		openlibraryUA:   cfg.OpenLibraryUA,
+		moviedbKey:      cfg.MovieDBKey,
+		rawgKey:         cfg.RAWGKey,
+		serverVersion:   cfg.ServerVersion,
+		accessTokenTTL:  cfg.Tokens.AccessTokenTTL,
+		refreshTokenTTL: cfg.Tokens.RefreshTokenTTL,
+		resetTokenTTL:   cfg.Tokens.ResetTokenTTL,
+	}
+	apiCfg.mediumProviders = apiCfg.defaultMediumProviders()
+	return apiCfg
 }
 
-func newAPIConfig(db *database.Queries, jwtsecret, openLibraryUA, moviedbAPIKey, rawgKey, serverVersion string) *apiConfig {
-	return &apiConfig{
-		db:            db,
-		jwtsecret:     jwtsecret,
-		openlibraryUA: openLibraryUA,
-		moviedbKey:    moviedbAPIKey,
-		rawgKey:       rawgKey,
-		serverVersion: serverVersion,
+// Proxy endpoints can't reach an upstream API whose key isn't configured
+// Respond with an error and return false in that case
+func checkAPIKey(w http.ResponseWriter, key, apiName string) bool {
+	if key == "" {
+		respondWithError(w, 503, apiName+" API key is not configured on this server", nil)
+		return false
 	}
+	return true
 }
@@ -301,6 +301,38 @@ func (ctx *TestContext) CreateTestRecord(t *testing.T, mediumID string) string {
 
 }
 
+// Create a record (with custom fields) for testing use, return record ID if needed
+func (ctx *TestContext) CreateTestRecordCustom(t *testing.T, request parametersCreateUserMediumRecord) string {
+	// Create Record via API request
+	reqBody, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("Failed to marshal body request for test create record: %v", err)
+	}
+	req, err := http.NewRequest("POST", ctx.BaseURL+"/api/records", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create test record request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.UserAcessToken))
+	resp, err := ctx.Client.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to create test record: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 201 {
+		t.Fatalf("Failed to create test record. Status: %d", resp.StatusCode)
+	}
+
+	// Parse response
+	var responseBody ClientRecord
+	err = json.NewDecoder(resp.Body).Decode(&responseBody)
+	if err != nil {
+		t.Fatalf("Failed to decode response body for test create records: %v", err)
+	}
+
+	return responseBody.ID
+}
+
 // Check if a record exist by reaching admin/record endpoint
 func (ctx *TestContext) TestIfRecordExist(recordID string) bool {
 	// To check if a user still exists in DB, make a request to GET /api/medium
@@ -323,3 +355,615 @@ func (ctx *TestContext) TestIfRecordExist(recordID string) bool {
 	defer resp.Body.Close()
 	return resp.StatusCode == 200
 }
+
+// Create and log in another user on the same test server, return its own context
+func (ctx *TestContext) CreateOtherTestUser(t *testing.T, username string) *TestContext {
+	other := &TestContext{
+		Server:       ctx.Server,
+		BaseURL:      ctx.BaseURL,
+		UserUsername: username,
+		UserPassword: "qwerty5678",
+		UserEmail:    fmt.Sprintf("%s@example.com", strings.ToLower(username)),
+		Client:       ctx.Client,
+	}
+	other.CreateTestUser(t)
+	other.LoginTestUser(t)
+	return other
+}
+
+// Create a share for testing use, return share ID if needed
+func (ctx *TestContext) CreateTestShare(t *testing.T, request parametersCreateShare) string {
+	// Create Share via API request
+	reqBody, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("Failed to marshal body request for test share: %v", err)
+	}
+	req, err := http.NewRequest("POST", ctx.BaseURL+"/api/shares", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create test share request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.UserAcessToken))
+	resp, err := ctx.Client.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to create test share: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 201 {
+		t.Fatalf("Failed to create test share. Status: %d", resp.StatusCode)
+	}
+
+	// Parse response
+	var responseBody ClientShare
+	err = json.NewDecoder(resp.Body).Decode(&responseBody)
+	if err != nil {
+		t.Fatalf("Failed to decode response body for test share: %v", err)
+	}
+
+	return responseBody.ID
+}
+
+// Give a role to context's user, through test-only endpoint
+func (ctx *TestContext) SetTestUserRole(t *testing.T, role string) {
+	reqBody, err := json.Marshal(parametersSetUserRole{
+		Username: ctx.UserUsername,
+		Role:     role,
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal body request for user role: %v", err)
+	}
+	req, err := http.NewRequest("PUT", ctx.BaseURL+"/admin/user/role", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create user role request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := ctx.Client.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to set user role: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Fatalf("Failed to set user role. Status: %d", resp.StatusCode)
+	}
+}
+
+// Deactivate context's user through test-only endpoint, its tokens are left as they are
+func (ctx *TestContext) DeactivateTestUser(t *testing.T) {
+	reqBody, err := json.Marshal(parametersSetUserDeactivated{
+		Username: ctx.UserUsername,
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal body request for user deactivation: %v", err)
+	}
+	req, err := http.NewRequest("PUT", ctx.BaseURL+"/admin/user/deactivated", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create user deactivation request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := ctx.Client.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to deactivate user: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Fatalf("Failed to deactivate user. Status: %d", resp.StatusCode)
+	}
+}
+
+// Create a tag for testing use, return tag ID if needed
+func (ctx *TestContext) CreateTestTag(t *testing.T, name string) string {
+	// Create Tag via API request
+	reqBody, err := json.Marshal(parametersCreateTag{Name: name})
+	if err != nil {
+		t.Fatalf("Failed to marshal body request for test tag: %v", err)
+	}
+	req, err := http.NewRequest("POST", ctx.BaseURL+"/api/tags", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create test tag request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.UserAcessToken))
+	resp, err := ctx.Client.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to create test tag: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 201 {
+		t.Fatalf("Failed to create test tag. Status: %d", resp.StatusCode)
+	}
+
+	// Parse response
+	var responseBody ClientTag
+	err = json.NewDecoder(resp.Body).Decode(&responseBody)
+	if err != nil {
+		t.Fatalf("Failed to decode response body for test tag: %v", err)
+	}
+
+	return responseBody.ID
+}
+
+// Put tags on media for testing use
+func (ctx *TestContext) TagTestMedia(t *testing.T, tagIDs, mediumIDs []string) {
+	reqBody, err := json.Marshal(parametersTagMedia{TagIDs: tagIDs, MediumIDs: mediumIDs})
+	if err != nil {
+		t.Fatalf("Failed to marshal body request for test media tags: %v", err)
+	}
+	req, err := http.NewRequest("POST", ctx.BaseURL+"/api/tags/media", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create test media tags request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.UserAcessToken))
+	resp, err := ctx.Client.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to tag test media: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Fatalf("Failed to tag test media. Status: %d", resp.StatusCode)
+	}
+}
+
+// Create a custom shelf for testing use, return shelf ID if needed
+func (ctx *TestContext) CreateTestShelf(t *testing.T, request parametersCreateShelf) string {
+	// Create Shelf via API request
+	reqBody, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("Failed to marshal body request for test shelf: %v", err)
+	}
+	req, err := http.NewRequest("POST", ctx.BaseURL+"/api/shelves", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create test shelf request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.UserAcessToken))
+	resp, err := ctx.Client.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to create test shelf: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 201 {
+		t.Fatalf("Failed to create test shelf. Status: %d", resp.StatusCode)
+	}
+
+	// Parse response
+	var responseBody ClientShelf
+	err = json.NewDecoder(resp.Body).Decode(&responseBody)
+	if err != nil {
+		t.Fatalf("Failed to decode response body for test shelf: %v", err)
+	}
+
+	return responseBody.ID
+}
+
+// Put media on a custom shelf for testing use
+func (ctx *TestContext) AddTestMediaToShelf(t *testing.T, shelfID string, mediumIDs []string) {
+	reqBody, err := json.Marshal(parametersShelfMedia{ShelfID: shelfID, MediumIDs: mediumIDs})
+	if err != nil {
+		t.Fatalf("Failed to marshal body request for test shelf media: %v", err)
+	}
+	req, err := http.NewRequest("POST", ctx.BaseURL+"/api/shelves/media", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create test shelf media request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.UserAcessToken))
+	resp, err := ctx.Client.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to add test media to shelf: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Fatalf("Failed to add test media to shelf. Status: %d", resp.StatusCode)
+	}
+}
+
+// Get context's user's media with their records, by media type
+func (ctx *TestContext) GetTestMediaRecords(t *testing.T) ClientMediaRecords {
+	req, err := http.NewRequest("GET", ctx.BaseURL+"/api/media_records", nil)
+	if err != nil {
+		t.Fatalf("Failed to create test media records request: %v", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.UserAcessToken))
+	resp, err := ctx.Client.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to get test media records: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Fatalf("Failed to get test media records. Status: %d", resp.StatusCode)
+	}
+
+	var responseBody ClientMediaRecords
+	err = json.NewDecoder(resp.Body).Decode(&responseBody)
+	if err != nil {
+		t.Fatalf("Failed to decode response body for test media records: %v", err)
+	}
+	return responseBody
+}
+
+// Update a record for testing use, return the updated record
+func (ctx *TestContext) UpdateTestRecord(t *testing.T, request parametersUpdateRecord) ClientRecord {
+	reqBody, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("Failed to marshal body request for test update record: %v", err)
+	}
+	req, err := http.NewRequest("PUT", ctx.BaseURL+"/api/records", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create test update record request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.UserAcessToken))
+	resp, err := ctx.Client.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to update test record: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Fatalf("Failed to update test record. Status: %d", resp.StatusCode)
+	}
+
+	// Parse response
+	var responseBody ClientRecord
+	err = json.NewDecoder(resp.Body).Decode(&responseBody)
+	if err != nil {
+		t.Fatalf("Failed to decode response body for test update record: %v", err)
+	}
+
+	return responseBody
+}
+
+// Create a review for testing use, return review ID if needed
+func (ctx *TestContext) CreateTestReview(t *testing.T, request parametersCreateReview) string {
+	// Create Review via API request
+	reqBody, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("Failed to marshal body request for test review: %v", err)
+	}
+	req, err := http.NewRequest("POST", ctx.BaseURL+"/api/reviews", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create test review request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.UserAcessToken))
+	resp, err := ctx.Client.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to create test review: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 201 {
+		t.Fatalf("Failed to create test review. Status: %d", resp.StatusCode)
+	}
+
+	// Parse response
+	var responseBody ClientReview
+	err = json.NewDecoder(resp.Body).Decode(&responseBody)
+	if err != nil {
+		t.Fatalf("Failed to decode response body for test review: %v", err)
+	}
+
+	return responseBody.ID
+}
+
+// Update a review for testing use
+func (ctx *TestContext) UpdateTestReview(t *testing.T, request parametersUpdateReview) {
+	reqBody, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("Failed to marshal body request for test review update: %v", err)
+	}
+	req, err := http.NewRequest("PUT", ctx.BaseURL+"/api/reviews", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create test review update request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.UserAcessToken))
+	resp, err := ctx.Client.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to update test review: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Fatalf("Failed to update test review. Status: %d", resp.StatusCode)
+	}
+}
+
+// Create a loan for testing use, return loan ID if needed
+func (ctx *TestContext) CreateTestLoan(t *testing.T, request parametersCreateLoan) string {
+	// Create Loan via API request
+	reqBody, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("Failed to marshal body request for test loan: %v", err)
+	}
+	req, err := http.NewRequest("POST", ctx.BaseURL+"/api/loans", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create test loan request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.UserAcessToken))
+	resp, err := ctx.Client.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to create test loan: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 201 {
+		t.Fatalf("Failed to create test loan. Status: %d", resp.StatusCode)
+	}
+
+	// Parse response
+	var responseBody ClientLoan
+	err = json.NewDecoder(resp.Body).Decode(&responseBody)
+	if err != nil {
+		t.Fatalf("Failed to decode response body for test loan: %v", err)
+	}
+
+	return responseBody.ID
+}
+
+// Mark a loan as returned for testing use
+func (ctx *TestContext) ReturnTestLoan(t *testing.T, loanID string) {
+	reqBody, err := json.Marshal(parametersReturnLoan{LoanID: loanID})
+	if err != nil {
+		t.Fatalf("Failed to marshal body request for test loan return: %v", err)
+	}
+	req, err := http.NewRequest("PUT", ctx.BaseURL+"/api/loans/return", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create test loan return request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.UserAcessToken))
+	resp, err := ctx.Client.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to return test loan: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Fatalf("Failed to return test loan. Status: %d", resp.StatusCode)
+	}
+}
+
+// Create an owned copy for testing use, return copy ID if needed
+func (ctx *TestContext) CreateTestCopy(t *testing.T, request parametersCreateCopy) string {
+	// Create Copy via API request
+	reqBody, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("Failed to marshal body request for test copy: %v", err)
+	}
+	req, err := http.NewRequest("POST", ctx.BaseURL+"/api/copies", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create test copy request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.UserAcessToken))
+	resp, err := ctx.Client.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to create test copy: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 201 {
+		t.Fatalf("Failed to create test copy. Status: %d", resp.StatusCode)
+	}
+
+	// Parse response
+	var responseBody ClientOwnedCopy
+	err = json.NewDecoder(resp.Body).Decode(&responseBody)
+	if err != nil {
+		t.Fatalf("Failed to decode response body for test copy: %v", err)
+	}
+
+	return responseBody.ID
+}
+
+// Create a shelving unit for testing use, return unit ID if needed
+func (ctx *TestContext) CreateTestShelvingUnit(t *testing.T, request parametersCreateShelvingUnit) string {
+	// Create Shelving unit via API request
+	reqBody, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("Failed to marshal body request for test shelving unit: %v", err)
+	}
+	req, err := http.NewRequest("POST", ctx.BaseURL+"/api/shelving_units", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create test shelving unit request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.UserAcessToken))
+	resp, err := ctx.Client.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to create test shelving unit: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 201 {
+		t.Fatalf("Failed to create test shelving unit. Status: %d", resp.StatusCode)
+	}
+
+	// Parse response
+	var responseBody ClientShelvingUnit
+	err = json.NewDecoder(resp.Body).Decode(&responseBody)
+	if err != nil {
+		t.Fatalf("Failed to decode response body for test shelving unit: %v", err)
+	}
+
+	return responseBody.ID
+}
+
+// Put a medium in a shelving unit's cell for testing use
+func (ctx *TestContext) PlaceTestShelvingItem(t *testing.T, request parametersPlaceShelvingItem) {
+	reqBody, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("Failed to marshal body request for test shelving item: %v", err)
+	}
+	req, err := http.NewRequest("PUT", ctx.BaseURL+"/api/shelving_units/items", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create test shelving item request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.UserAcessToken))
+	resp, err := ctx.Client.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to place test shelving item: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Fatalf("Failed to place test shelving item. Status: %d", resp.StatusCode)
+	}
+}
+
+// Get where a medium is shelved for testing use
+func (ctx *TestContext) WhereIsTestMedium(t *testing.T, mediumID string) ClientShelvingLocations {
+	reqBody, err := json.Marshal(parametersWhereIsMedium{MediumID: mediumID})
+	if err != nil {
+		t.Fatalf("Failed to marshal body request for test medium location: %v", err)
+	}
+	req, err := http.NewRequest("GET", ctx.BaseURL+"/api/shelving_units/where", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create test medium location request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.UserAcessToken))
+	resp, err := ctx.Client.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to get test medium location: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Fatalf("Failed to get test medium location. Status: %d", resp.StatusCode)
+	}
+
+	// Parse response
+	var responseBody ClientShelvingLocations
+	err = json.NewDecoder(resp.Body).Decode(&responseBody)
+	if err != nil {
+		t.Fatalf("Failed to decode response body for test medium location: %v", err)
+	}
+
+	return responseBody
+}
+
+// Log a boardgame play for testing use, return play ID if needed
+func (ctx *TestContext) CreateTestPlay(t *testing.T, request parametersCreatePlay) string {
+	// Create Play via API request
+	reqBody, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("Failed to marshal body request for test play: %v", err)
+	}
+	req, err := http.NewRequest("POST", ctx.BaseURL+"/api/plays", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create test play request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.UserAcessToken))
+	resp, err := ctx.Client.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to create test play: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 201 {
+		t.Fatalf("Failed to create test play. Status: %d", resp.StatusCode)
+	}
+
+	// Parse response
+	var responseBody ClientPlay
+	err = json.NewDecoder(resp.Body).Decode(&responseBody)
+	if err != nil {
+		t.Fatalf("Failed to decode response body for test play: %v", err)
+	}
+
+	return responseBody.ID
+}
+
+// Set a videogame record's details for testing use
+func (ctx *TestContext) UpdateTestRecordVideogame(t *testing.T, request parametersRecordVideogame) {
+	reqBody, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("Failed to marshal body request for test videogame details: %v", err)
+	}
+	req, err := http.NewRequest("PUT", ctx.BaseURL+"/api/records/videogame", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create test videogame details request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.UserAcessToken))
+	resp, err := ctx.Client.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to update test videogame details: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Fatalf("Failed to update test videogame details. Status: %d", resp.StatusCode)
+	}
+}
+
+// Delete a record for testing use
+func (ctx *TestContext) DeleteTestRecord(t *testing.T, recordID string) {
+	reqBody, err := json.Marshal(parametersDeleteRecord{RecordID: recordID})
+	if err != nil {
+		t.Fatalf("Failed to marshal body request for test record deletion: %v", err)
+	}
+	req, err := http.NewRequest("DELETE", ctx.BaseURL+"/api/records", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create test record deletion request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.UserAcessToken))
+	resp, err := ctx.Client.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to delete test record: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Fatalf("Failed to delete test record. Status: %d", resp.StatusCode)
+	}
+}
+
+// Mark a series record's episodes as watched for testing use
+func (ctx *TestContext) MarkTestEpisodes(t *testing.T, request parametersMarkEpisodes) {
+	reqBody, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("Failed to marshal body request for test episodes: %v", err)
+	}
+	req, err := http.NewRequest("PUT", ctx.BaseURL+"/api/records/episodes", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create test episodes request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.UserAcessToken))
+	resp, err := ctx.Client.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to mark test episodes: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Fatalf("Failed to mark test episodes. Status: %d", resp.StatusCode)
+	}
+}
+
+// Create a goal for testing use, return goal ID if needed
+func (ctx *TestContext) CreateTestGoal(t *testing.T, request parametersGoalDetails) string {
+	// Create Goal via API request
+	reqBody, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("Failed to marshal body request for test goal: %v", err)
+	}
+	req, err := http.NewRequest("POST", ctx.BaseURL+"/api/goals", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create test goal request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.UserAcessToken))
+	resp, err := ctx.Client.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to create test goal: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 201 {
+		t.Fatalf("Failed to create test goal. Status: %d", resp.StatusCode)
+	}
+
+	// Parse response
+	var responseBody ClientGoal
+	err = json.NewDecoder(resp.Body).Decode(&responseBody)
+	if err != nil {
+		t.Fatalf("Failed to decode response body for test goal: %v", err)
+	}
+
+	return responseBody.ID
+}
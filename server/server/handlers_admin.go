@@ -4,8 +4,11 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/VincNT21/kallaxy/server/internal/database"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -24,6 +27,310 @@ func (cfg *apiConfig) handlerGetServerVersion(w http.ResponseWriter, r *http.Req
 
 }
 
+// Page size of the admin users listing
+const (
+	adminUsersDefaultLimit = 50
+	adminUsersMaxLimit     = 200
+)
+
+func toAdminUser(user database.User) AdminUser {
+	return AdminUser{
+		User: User{
+			ID:        user.ID,
+			CreatedAt: user.CreatedAt,
+			UpdatedAt: user.UpdatedAt,
+			Username:  user.Username,
+			Email:     user.Email,
+			Role:      user.Role,
+		},
+		DeactivatedAt: user.DeactivatedAt,
+	}
+}
+
+type responseAdminGetUsers struct {
+	Users []AdminUser `json:"users"`
+}
+
+// GET /admin/users
+func (cfg *apiConfig) handlerAdminGetUsers(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersAdminGetUsers
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Check paging values
+	if params.Limit < 0 || params.Limit > adminUsersMaxLimit {
+		respondWithError(w, 400, fmt.Sprintf("limit must be between 1 and %d, or 0 for the default %d", adminUsersMaxLimit, adminUsersDefaultLimit), nil)
+		return
+	}
+	if params.Limit == 0 {
+		params.Limit = adminUsersDefaultLimit
+	}
+	if params.Offset < 0 {
+		respondWithError(w, 400, "offset can't be negative", nil)
+		return
+	}
+
+	// Call query function
+	users, err := cfg.db.SearchUsers(r.Context(), database.SearchUsersParams{
+		Search:     params.Search,
+		PageLimit:  params.Limit,
+		PageOffset: params.Offset,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't search users in database", err)
+		return
+	}
+
+	response := responseAdminGetUsers{
+		Users: make([]AdminUser, 0, len(users)),
+	}
+	for _, user := range users {
+		response.Users = append(response.Users, toAdminUser(user))
+	}
+
+	// Respond
+	respondWithJson(w, 200, response)
+}
+
+// Get the user targeted by an admin request, respond with an error if there is none
+func (cfg *apiConfig) getAdminTargetUser(w http.ResponseWriter, r *http.Request) (database.User, bool) {
+	// Parse data from request body
+	var params parametersAdminUser
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return database.User{}, false
+	}
+
+	userID, err := convertIdToPgtype(params.UserID)
+	if err != nil {
+		respondWithError(w, 400, "user_id not in good format", err)
+		return database.User{}, false
+	}
+
+	user, err := cfg.db.GetUserByID(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondWithError(w, 404, "No user found with given ID", err)
+			return database.User{}, false
+		}
+		respondWithError(w, 500, "couldn't get user info from DB", err)
+		return database.User{}, false
+	}
+	return user, true
+}
+
+// PUT /admin/users/deactivate
+func (cfg *apiConfig) handlerAdminDeactivateUser(w http.ResponseWriter, r *http.Request) {
+
+	user, ok := cfg.getAdminTargetUser(w, r)
+	if !ok {
+		return
+	}
+
+	// An admin locking themself out would need the CLI to get back in
+	adminID := r.Context().Value(userIDKey).(pgtype.UUID)
+	if user.ID == adminID {
+		respondWithError(w, 400, "admins can't deactivate their own account", errors.New("admin tried to deactivate themself"))
+		return
+	}
+
+	// Keep the first deactivation date if account is already deactivated
+	if !user.DeactivatedAt.Valid {
+		var err error
+		user, err = cfg.db.UpdateUserDeactivatedAt(r.Context(), database.UpdateUserDeactivatedAtParams{
+			ID: user.ID,
+			DeactivatedAt: pgtype.Timestamp{
+				Time:  time.Now().UTC(),
+				Valid: true,
+			},
+		})
+		if err != nil {
+			respondWithError(w, 500, "couldn't deactivate user in database", err)
+			return
+		}
+	}
+
+	// Revoke all refresh tokens, user's access token is already refused as the account is deactivated
+	_, err := cfg.db.RevokeAllRefreshTokensByUserID(r.Context(), user.ID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't revoke all refresh token by user ID", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 200, toAdminUser(user))
+}
+
+// PUT /admin/users/reactivate
+func (cfg *apiConfig) handlerAdminReactivateUser(w http.ResponseWriter, r *http.Request) {
+
+	user, ok := cfg.getAdminTargetUser(w, r)
+	if !ok {
+		return
+	}
+
+	// Call query function
+	user, err := cfg.db.UpdateUserDeactivatedAt(r.Context(), database.UpdateUserDeactivatedAtParams{
+		ID:            user.ID,
+		DeactivatedAt: pgtype.Timestamp{},
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't reactivate user in database", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 200, toAdminUser(user))
+}
+
+// POST /admin/users/logout
+func (cfg *apiConfig) handlerAdminLogoutUser(w http.ResponseWriter, r *http.Request) {
+	type response struct {
+		RevokedTokens int64 `json:"revoked_tokens"`
+	}
+
+	user, ok := cfg.getAdminTargetUser(w, r)
+	if !ok {
+		return
+	}
+
+	// Call query function
+	count, err := cfg.db.RevokeAllRefreshTokensByUserID(r.Context(), user.ID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't revoke all refresh token by user ID", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 200, response{
+		RevokedTokens: count,
+	})
+}
+
+// GET /admin/counts
+func (cfg *apiConfig) handlerAdminGetCounts(w http.ResponseWriter, r *http.Request) {
+	type response struct {
+		Users            int64            `json:"users"`
+		DeactivatedUsers int64            `json:"deactivated_users"`
+		Admins           int64            `json:"admins"`
+		Media            int64            `json:"media"`
+		MediaByType      map[string]int64 `json:"media_by_type"`
+		Records          int64            `json:"records"`
+		Shares           int64            `json:"shares"`
+	}
+
+	// Call query functions
+	counts, err := cfg.db.GetInstanceCounts(r.Context())
+	if err != nil {
+		respondWithError(w, 500, "couldn't count instance content in database", err)
+		return
+	}
+	mediaByType, err := cfg.db.CountMediaByType(r.Context())
+	if err != nil {
+		respondWithError(w, 500, "couldn't count media by type in database", err)
+		return
+	}
+
+	resp := response{
+		Users:            counts.Users,
+		DeactivatedUsers: counts.DeactivatedUsers,
+		Admins:           counts.Admins,
+		Media:            counts.Media,
+		MediaByType:      make(map[string]int64, len(mediaByType)),
+		Records:          counts.Records,
+		Shares:           counts.Shares,
+	}
+	for _, row := range mediaByType {
+		resp.MediaByType[row.MediaType] = row.Count
+	}
+
+	// Respond
+	respondWithJson(w, 200, resp)
+}
+
+type responseAdminMergeMedia struct {
+	Medium        Medium `json:"medium"`
+	MovedRecords  int64  `json:"moved_records"`
+	MergedRecords int64  `json:"merged_records"`
+	ClosedLoans   int64  `json:"closed_loans"`
+}
+
+// POST /admin/media/merge
+func (cfg *apiConfig) handlerAdminMergeMedia(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersAdminMergeMedia
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Convert IDs to pgtype.UUID
+	targetID, err := convertIdToPgtype(params.TargetID)
+	if err != nil {
+		respondWithError(w, 400, "target_id not in good format", err)
+		return
+	}
+	sourceID, err := convertIdToPgtype(params.SourceID)
+	if err != nil {
+		respondWithError(w, 400, "source_id not in good format", err)
+		return
+	}
+
+	// Follow previous merges
+	targetID, err = cfg.resolveMediumID(r.Context(), targetID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get medium redirect in database", err)
+		return
+	}
+	sourceID, err = cfg.resolveMediumID(r.Context(), sourceID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get medium redirect in database", err)
+		return
+	}
+
+	// Call query function
+	result, err := cfg.db.MergeMedia(r.Context(), database.MergeMediaParams{
+		TargetID: targetID,
+		SourceID: sourceID,
+		MergedBy: r.Context().Value(userIDKey).(pgtype.UUID),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondWithError(w, 404, "No medium with given ID in database", err)
+			return
+		}
+		if errors.Is(err, database.ErrMergeSameMedium) || errors.Is(err, database.ErrMergeMediaType) {
+			respondWithError(w, 400, err.Error(), err)
+			return
+		}
+		respondWithError(w, 500, "couldn't merge media in database", err)
+		return
+	}
+
+	// Convert medium for response
+	responseMedium, err := toResponseMedium(result.Medium)
+	if err != nil {
+		respondWithError(w, 500, "couldn't convert medium from database", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 200, responseAdminMergeMedia{
+		Medium:        responseMedium,
+		MovedRecords:  result.MovedRecords,
+		MergedRecords: result.MergedRecords,
+		ClosedLoans:   result.ClosedLoans,
+	})
+}
+
 // This handler is only used for integration tests
 // No endpoint for it exists in production server
 // POST /admin/reset
@@ -150,3 +457,76 @@ func (cfg *apiConfig) handlerCheckRecordExists(w http.ResponseWriter, r *http.Re
 	w.WriteHeader(200)
 
 }
+
+// This handler is only used for integration tests
+// No endpoint for it exists in production server, roles are given by config or CLI there
+type parametersSetUserRole struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+// PUT /admin/user/role
+func (cfg *apiConfig) handlerSetUserRole(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersSetUserRole
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	_, err = cfg.db.UpdateUserRoleByUsername(r.Context(), database.UpdateUserRoleByUsernameParams{
+		Username: params.Username,
+		Role:     params.Role,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(404)
+			return
+		}
+		w.WriteHeader(500)
+		return
+	}
+	w.WriteHeader(200)
+}
+
+// This handler is only used for integration tests
+// Unlike PUT /admin/users/deactivate, user's refresh tokens are kept, so tests can check they are refused
+type parametersSetUserDeactivated struct {
+	Username string `json:"username"`
+}
+
+// PUT /admin/user/deactivated
+func (cfg *apiConfig) handlerSetUserDeactivated(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersSetUserDeactivated
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	user, err := cfg.db.GetUserByUsername(r.Context(), params.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(404)
+			return
+		}
+		w.WriteHeader(500)
+		return
+	}
+	_, err = cfg.db.UpdateUserDeactivatedAt(r.Context(), database.UpdateUserDeactivatedAtParams{
+		ID: user.ID,
+		DeactivatedAt: pgtype.Timestamp{
+			Time:  time.Now().UTC(),
+			Valid: true,
+		},
+	})
+	if err != nil {
+		w.WriteHeader(500)
+		return
+	}
+	w.WriteHeader(200)
+}
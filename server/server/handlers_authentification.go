@@ -46,8 +46,14 @@ func (cfg *apiConfig) handlerLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Deactivated accounts can't log in until an admin reactivates them
+	if user.DeactivatedAt.Valid {
+		respondWithError(w, 403, "this account has been deactivated", errors.New("user account is deactivated"))
+		return
+	}
+
 	// Create a JWT
-	accessToken, err := auth.MakeJWT(user.ID, cfg.jwtsecret, time.Hour)
+	accessToken, err := auth.MakeJWT(user.ID, cfg.jwtsecret, cfg.accessTokenTTL)
 	if err != nil {
 		respondWithError(w, 500, "couldn't create a JWT", err)
 		return
@@ -65,7 +71,7 @@ func (cfg *apiConfig) handlerLogin(w http.ResponseWriter, r *http.Request) {
 		Token:  refreshTokenString,
 		UserID: user.ID,
 		ExpiresAt: pgtype.Timestamp{
-			Time:  time.Now().UTC().AddDate(0, 0, 60),
+			Time:  time.Now().UTC().Add(cfg.refreshTokenTTL),
 			Valid: true,
 		},
 	})
@@ -82,6 +88,7 @@ func (cfg *apiConfig) handlerLogin(w http.ResponseWriter, r *http.Request) {
 			UpdatedAt: user.UpdatedAt,
 			Username:  user.Username,
 			Email:     user.Email,
+			Role:      user.Role,
 		},
 		Tokens: Tokens{
 			AccessToken:  accessToken,
@@ -142,8 +149,19 @@ func (cfg *apiConfig) handlerRefresh(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Deactivated accounts can't get new tokens, even with a refresh token issued before
+	user, err := cfg.db.GetUserByID(r.Context(), refreshTokenOld.UserID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get user info from DB", err)
+		return
+	}
+	if user.DeactivatedAt.Valid {
+		respondWithError(w, 403, "this account has been deactivated", errors.New("user account is deactivated"))
+		return
+	}
+
 	// Create a new JWT access token (validity: 1 hour)
-	accessToken, err := auth.MakeJWT(refreshTokenOld.UserID, cfg.jwtsecret, time.Hour)
+	accessToken, err := auth.MakeJWT(refreshTokenOld.UserID, cfg.jwtsecret, cfg.accessTokenTTL)
 	if err != nil {
 		respondWithError(w, 500, "couldn't create a new access token", err)
 		return
@@ -159,7 +177,7 @@ func (cfg *apiConfig) handlerRefresh(w http.ResponseWriter, r *http.Request) {
 		Token:  refreshTokenNewStr,
 		UserID: refreshTokenOld.UserID,
 		ExpiresAt: pgtype.Timestamp{
-			Time:  time.Now().UTC().AddDate(0, 0, 60),
+			Time:  time.Now().UTC().Add(cfg.refreshTokenTTL),
 			Valid: true,
 		},
 	})
@@ -0,0 +1,431 @@
+package server
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Highest price accepted, in currency's unit
+const maxCopyPrice = 1e12
+
+var currencyRegex = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Prices are stored in hundredths of their currency's unit
+func priceToCents(price float64) (int64, error) {
+	if math.IsNaN(price) || price < 0 {
+		return 0, errors.New("price can't be negative")
+	}
+	if price > maxCopyPrice {
+		return 0, errors.New("price is too high")
+	}
+	return int64(math.Round(price * 100)), nil
+}
+
+func centsToPrice(cents pgtype.Int8) *float64 {
+	if !cents.Valid {
+		return nil
+	}
+	price := float64(cents.Int64) / 100
+	return &price
+}
+
+// Owned copy's details, checked and ready for database
+type copyDetails struct {
+	Format       string
+	Condition    string
+	Language     string
+	PurchaseDate pgtype.Timestamp
+	PriceCents   pgtype.Int8
+	Currency     string
+	Store        string
+}
+
+// Check and convert owned copy's details, returned errors are meant for the client
+func (params parametersCopyDetails) toCopyDetails() (copyDetails, error) {
+	details := copyDetails{
+		Format:    strings.TrimSpace(params.Format),
+		Condition: strings.ToLower(strings.TrimSpace(params.Condition)),
+		Language:  strings.TrimSpace(params.Language),
+		Currency:  strings.ToUpper(strings.TrimSpace(params.Currency)),
+		Store:     strings.TrimSpace(params.Store),
+	}
+	if details.Format == "" {
+		return details, errors.New("format must be provided")
+	}
+	if details.Condition != "" && !slices.Contains(database.CopyConditions, details.Condition) {
+		return details, fmt.Errorf("condition must be one of %s", strings.Join(database.CopyConditions, ", "))
+	}
+
+	purchaseDate, err := convertDateToPgtype(params.PurchaseDate)
+	if err != nil {
+		return details, errors.New("purchase_date not in good format")
+	}
+	details.PurchaseDate = purchaseDate
+
+	// A price is only meaningful with its currency
+	if params.Price != nil {
+		cents, err := priceToCents(*params.Price)
+		if err != nil {
+			return details, err
+		}
+		details.PriceCents = pgtype.Int8{Int64: cents, Valid: true}
+		if details.Currency == "" {
+			return details, errors.New("currency must be provided with a price")
+		}
+	}
+	if details.Currency != "" && !currencyRegex.MatchString(details.Currency) {
+		return details, errors.New("currency must be a 3 letters ISO 4217 code")
+	}
+	return details, nil
+}
+
+// Build an owned copy response, medium is given by caller
+func ownedCopyResponse(ownedCopy database.OwnedCopy, medium database.Medium) OwnedCopy {
+	return OwnedCopy{
+		ID:           ownedCopy.ID,
+		CreatedAt:    ownedCopy.CreatedAt,
+		UpdatedAt:    ownedCopy.UpdatedAt,
+		UserID:       ownedCopy.UserID,
+		MediumID:     ownedCopy.MediaID,
+		MediaType:    medium.MediaType,
+		Title:        medium.Title,
+		Format:       ownedCopy.Format,
+		Condition:    ownedCopy.Condition,
+		Language:     ownedCopy.Language,
+		PurchaseDate: ownedCopy.PurchaseDate,
+		Price:        centsToPrice(ownedCopy.PriceCents),
+		Currency:     ownedCopy.Currency,
+		Store:        ownedCopy.Store,
+	}
+}
+
+// POST /api/copies
+func (cfg *apiConfig) handlerCreateCopy(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersCreateCopy
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Check if all required fields are provided
+	if params.MediumID == "" {
+		respondWithError(w, 400, "medium_id must be provided", errors.New("medium_id missing from copy request body"))
+		return
+	}
+	details, err := params.toCopyDetails()
+	if err != nil {
+		respondWithError(w, 400, err.Error(), err)
+		return
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// A user can only own copies of media on their shelf
+	mediumID, err := convertIdToPgtype(params.MediumID)
+	if err != nil {
+		respondWithError(w, 400, "medium_id not in good format", err)
+		return
+	}
+	medium, ok := cfg.getMedium(w, r, mediumID)
+	if !ok {
+		return
+	}
+	count, err := cfg.db.CountUserRecordsByMediumID(r.Context(), database.CountUserRecordsByMediumIDParams{
+		MediaID: medium.ID,
+		UserID:  userID,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't get user's records in database", err)
+		return
+	}
+	if count == 0 {
+		respondWithError(w, 404, "no medium with given ID in user's shelf", errors.New("medium not in user's shelf"))
+		return
+	}
+
+	// Call query function
+	ownedCopy, err := cfg.db.CreateOwnedCopy(r.Context(), database.CreateOwnedCopyParams{
+		UserID:       userID,
+		MediaID:      medium.ID,
+		Format:       details.Format,
+		Condition:    details.Condition,
+		Language:     details.Language,
+		PurchaseDate: details.PurchaseDate,
+		PriceCents:   details.PriceCents,
+		Currency:     details.Currency,
+		Store:        details.Store,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't create owned copy in database", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 201, ownedCopyResponse(ownedCopy, medium))
+}
+
+type responseGetCopies struct {
+	Copies []OwnedCopy `json:"copies"`
+}
+
+// GET /api/copies
+func (cfg *apiConfig) handlerGetCopies(w http.ResponseWriter, r *http.Request) {
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Call query function
+	rows, err := cfg.db.GetOwnedCopiesByUserID(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get owned copies in database", err)
+		return
+	}
+
+	response := responseGetCopies{
+		Copies: make([]OwnedCopy, 0, len(rows)),
+	}
+	for _, row := range rows {
+		response.Copies = append(response.Copies, ownedCopyResponse(database.OwnedCopy{
+			ID:           row.ID,
+			CreatedAt:    row.CreatedAt,
+			UpdatedAt:    row.UpdatedAt,
+			UserID:       row.UserID,
+			MediaID:      row.MediaID,
+			Format:       row.Format,
+			Condition:    row.Condition,
+			Language:     row.Language,
+			PurchaseDate: row.PurchaseDate,
+			PriceCents:   row.PriceCents,
+			Currency:     row.Currency,
+			Store:        row.Store,
+		}, database.Medium{MediaType: row.MediaType, Title: row.Title}))
+	}
+
+	// Respond
+	respondWithJson(w, 200, response)
+}
+
+// PUT /api/copies
+func (cfg *apiConfig) handlerUpdateCopy(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersUpdateCopy
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Convert CopyID to pgtype.UUID
+	copyID, err := convertIdToPgtype(params.CopyID)
+	if err != nil {
+		respondWithError(w, 400, "copy_id not in good format", err)
+		return
+	}
+	details, err := params.toCopyDetails()
+	if err != nil {
+		respondWithError(w, 400, err.Error(), err)
+		return
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Call query function, every detail is replaced
+	ownedCopy, err := cfg.db.UpdateOwnedCopy(r.Context(), database.UpdateOwnedCopyParams{
+		ID:           copyID,
+		UserID:       userID,
+		Format:       details.Format,
+		Condition:    details.Condition,
+		Language:     details.Language,
+		PurchaseDate: details.PurchaseDate,
+		PriceCents:   details.PriceCents,
+		Currency:     details.Currency,
+		Store:        details.Store,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondWithError(w, 404, "no owned copy found with given ID for user", err)
+			return
+		}
+		respondWithError(w, 500, "couldn't update owned copy in database", err)
+		return
+	}
+
+	medium, err := cfg.db.GetMediumByID(r.Context(), ownedCopy.MediaID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get owned copy's medium in database", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 200, ownedCopyResponse(ownedCopy, medium))
+}
+
+// DELETE /api/copies
+func (cfg *apiConfig) handlerDeleteCopy(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersCopy
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Convert CopyID to pgtype.UUID
+	copyID, err := convertIdToPgtype(params.CopyID)
+	if err != nil {
+		respondWithError(w, 400, "copy_id not in good format", err)
+		return
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Call query function
+	count, err := cfg.db.DeleteOwnedCopy(r.Context(), database.DeleteOwnedCopyParams{
+		ID:     copyID,
+		UserID: userID,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't delete owned copy in database", err)
+		return
+	}
+	if count == 0 {
+		respondWithError(w, 404, "no owned copy found with given ID for user", nil)
+		return
+	}
+
+	// Respond
+	w.WriteHeader(200)
+}
+
+type responseGetCollectionValue struct {
+	CopiesCount int64 `json:"copies_count"`
+	// Copies bought without a price, left out of values
+	UnpricedCount int64             `json:"unpriced_count"`
+	ByMediaType   []CollectionValue `json:"by_media_type"`
+	Totals        []CollectionValue `json:"totals"`
+}
+
+// Sum copies prices by media type and currency, and by currency only
+// Rows are ordered by media type then currency
+func collectionValue(rows []database.GetCollectionValueByUserIDRow) responseGetCollectionValue {
+	response := responseGetCollectionValue{
+		ByMediaType: []CollectionValue{},
+		Totals:      []CollectionValue{},
+	}
+	totalsCents := make(map[string]int64)
+	for _, row := range rows {
+		response.CopiesCount += row.CopiesCount
+		response.UnpricedCount += row.CopiesCount - row.PricedCount
+		if row.PricedCount == 0 {
+			continue
+		}
+		response.ByMediaType = append(response.ByMediaType, CollectionValue{
+			MediaType:   row.MediaType,
+			Currency:    row.Currency,
+			CopiesCount: row.PricedCount,
+			Value:       float64(row.TotalCents) / 100,
+		})
+
+		i := slices.IndexFunc(response.Totals, func(total CollectionValue) bool { return total.Currency == row.Currency })
+		if i == -1 {
+			response.Totals = append(response.Totals, CollectionValue{Currency: row.Currency})
+			i = len(response.Totals) - 1
+		}
+		response.Totals[i].CopiesCount += row.PricedCount
+		totalsCents[row.Currency] += row.TotalCents
+	}
+	for i := range response.Totals {
+		response.Totals[i].Value = float64(totalsCents[response.Totals[i].Currency]) / 100
+	}
+	slices.SortFunc(response.Totals, func(a, b CollectionValue) int {
+		return strings.Compare(a.Currency, b.Currency)
+	})
+	return response
+}
+
+// GET /api/copies/value
+func (cfg *apiConfig) handlerGetCollectionValue(w http.ResponseWriter, r *http.Request) {
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Call query function
+	rows, err := cfg.db.GetCollectionValueByUserID(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get collection value in database", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 200, collectionValue(rows))
+}
+
+type responseGetMediumCopies struct {
+	MediumID pgtype.UUID `json:"medium_id"`
+	Copies   []OwnedCopy `json:"copies"`
+}
+
+// GET /api/media/copies
+func (cfg *apiConfig) handlerGetMediumCopies(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersGetMediumCopies
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Convert MediumID to pgtype.UUID
+	mediumID, err := convertIdToPgtype(params.MediumID)
+	if err != nil {
+		respondWithError(w, 400, "medium_id not in good format", err)
+		return
+	}
+	medium, ok := cfg.getMedium(w, r, mediumID)
+	if !ok {
+		return
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Call query function
+	copies, err := cfg.db.GetMediumOwnedCopies(r.Context(), database.GetMediumOwnedCopiesParams{
+		UserID:  userID,
+		MediaID: medium.ID,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't get medium's owned copies in database", err)
+		return
+	}
+
+	response := responseGetMediumCopies{
+		MediumID: medium.ID,
+		Copies:   make([]OwnedCopy, 0, len(copies)),
+	}
+	for _, ownedCopy := range copies {
+		response.Copies = append(response.Copies, ownedCopyResponse(ownedCopy, medium))
+	}
+
+	// Respond
+	respondWithJson(w, 200, response)
+}
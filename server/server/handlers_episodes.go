@@ -0,0 +1,493 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Seasons of a series from its metadata, ordered by season number and without specials.
+// Metadata lists them as "Season 1 counts 10 episodes", or as plain counts starting at season 1
+func metadataSeasons(metadata []byte) []SeriesSeason {
+	metadataMap, err := bytesToMap(metadata)
+	if err != nil {
+		return nil
+	}
+	values, _ := metadataMap["number_of_episodes_per_season"].([]interface{})
+	var seasons []SeriesSeason
+	for i, value := range values {
+		var seasonNumber, episodeCount int32
+		switch value := value.(type) {
+		case string:
+			if _, err := fmt.Sscanf(value, "Season %d counts %d episodes", &seasonNumber, &episodeCount); err != nil {
+				count, err := strconv.ParseInt(value, 10, 32)
+				if err != nil {
+					continue
+				}
+				seasonNumber, episodeCount = int32(i+1), int32(count)
+			}
+		case float64:
+			seasonNumber, episodeCount = int32(i+1), int32(value)
+		}
+		if seasonNumber <= 0 || episodeCount <= 0 {
+			continue
+		}
+		seasons = append(seasons, SeriesSeason{SeasonNumber: seasonNumber, EpisodeCount: episodeCount})
+	}
+	slices.SortFunc(seasons, func(a, b SeriesSeason) int {
+		return int(a.SeasonNumber - b.SeasonNumber)
+	})
+	return seasons
+}
+
+// Episodes of the whole series, from its seasons or else from its metadata, 0 when unknown
+func seriesTotalEpisodes(medium database.Medium, seasons []SeriesSeason) int32 {
+	var total int32
+	for _, season := range seasons {
+		total += season.EpisodeCount
+	}
+	if total == 0 {
+		total = metadataProgressTotal(medium).Int32
+	}
+	return total
+}
+
+// Check an episode against series' seasons, any positive one being accepted when they aren't known
+func checkEpisode(seasons []SeriesSeason, seasonNumber, episodeNumber int32) error {
+	if seasonNumber <= 0 || episodeNumber <= 0 {
+		return errors.New("season_number and episode_number must be positive")
+	}
+	if len(seasons) == 0 {
+		return nil
+	}
+	i := slices.IndexFunc(seasons, func(season SeriesSeason) bool {
+		return season.SeasonNumber == seasonNumber
+	})
+	if i == -1 {
+		return fmt.Errorf("series has no season %d", seasonNumber)
+	}
+	if episodeNumber > seasons[i].EpisodeCount {
+		return fmt.Errorf("season %d of series has %d episodes", seasonNumber, seasons[i].EpisodeCount)
+	}
+	return nil
+}
+
+// Episode following the furthest one watched, skipping the ones already watched.
+// When seasons aren't known, it is the next one of the same season. ok is false once every episode is watched
+func nextEpisode(seasons []SeriesSeason, episodes []database.RecordsEpisode) (seasonNumber, episodeNumber int32, ok bool) {
+	watched := make(map[[2]int32]bool, len(episodes))
+	for _, episode := range episodes {
+		watched[[2]int32{episode.SeasonNumber, episode.EpisodeNumber}] = true
+	}
+
+	seasonNumber, episodeNumber = 1, 1
+	if len(seasons) > 0 {
+		seasonNumber = seasons[0].SeasonNumber
+	}
+	// Episodes are ordered, the last one is the furthest
+	if len(episodes) > 0 {
+		furthest := episodes[len(episodes)-1]
+		seasonNumber, episodeNumber = furthest.SeasonNumber, furthest.EpisodeNumber+1
+	}
+
+	for {
+		i := slices.IndexFunc(seasons, func(season SeriesSeason) bool {
+			return season.SeasonNumber == seasonNumber
+		})
+		if i != -1 && episodeNumber > seasons[i].EpisodeCount {
+			if i == len(seasons)-1 {
+				return 0, 0, false
+			}
+			seasonNumber, episodeNumber = seasons[i+1].SeasonNumber, 1
+			continue
+		}
+		if !watched[[2]int32{seasonNumber, episodeNumber}] {
+			return seasonNumber, episodeNumber, true
+		}
+		episodeNumber++
+	}
+}
+
+// Status a series record gets from its watched episodes, total being 0 when unknown.
+// Watching an episode starts or resumes the record and watching the last one finishes it,
+// unwatching one reopens a finished record. Abandoned records are left as they are
+func statusFromEpisodes(previous string, watched, total int32, unwatching bool) string {
+	complete := total > 0 && watched >= total
+	switch {
+	case previous == database.RecordStatusAbandoned:
+		return previous
+	case unwatching:
+		if previous == database.RecordStatusFinished && !complete {
+			return database.RecordStatusInProgress
+		}
+	case complete:
+		return database.RecordStatusFinished
+	case watched > 0 && previous != database.RecordStatusFinished:
+		return database.RecordStatusInProgress
+	}
+	return previous
+}
+
+// Set a series record's status from its episodes, its dates following them as a record update would.
+// A resumed record's pause is closed, the record is returned unchanged if its status stays the same
+func (cfg *apiConfig) updateStatusFromEpisodes(ctx context.Context, record database.UsersMediaRecord, episodes []database.RecordsEpisode, total int32, unwatching bool) (database.UsersMediaRecord, error) {
+	status := statusFromEpisodes(record.Status, int32(len(episodes)), total, unwatching)
+	if status == record.Status {
+		return record, nil
+	}
+
+	// Record started with its first episode watched and finished with its last one
+	startDate, endDate := record.StartDate, pgtype.Timestamp{}
+	var firstWatched, lastWatched pgtype.Timestamp
+	for _, episode := range episodes {
+		if !firstWatched.Valid || episode.WatchedAt.Time.Before(firstWatched.Time) {
+			firstWatched = episode.WatchedAt
+		}
+		if !lastWatched.Valid || episode.WatchedAt.Time.After(lastWatched.Time) {
+			lastWatched = episode.WatchedAt
+		}
+	}
+	if !startDate.Valid {
+		startDate = firstWatched
+	}
+	if status == database.RecordStatusFinished {
+		endDate = lastWatched
+	}
+	startDate, endDate, err := checkStatusDates(status, startDate, endDate)
+	if err != nil {
+		return database.UsersMediaRecord{}, err
+	}
+
+	pauses, err := cfg.db.GetRecordPauses(ctx, record.ID)
+	if err != nil {
+		return database.UsersMediaRecord{}, err
+	}
+	resuming := record.Status == database.RecordStatusPaused
+	statusChangedAt := timestampNow()
+	if resuming {
+		for i := range pauses {
+			if !pauses[i].ResumedAt.Valid {
+				pauses[i].ResumedAt = statusChangedAt
+			}
+		}
+	}
+	interval, err := calculateActiveDuration(startDate, endDate, pauses)
+	if err != nil {
+		return database.UsersMediaRecord{}, err
+	}
+
+	update := database.SaveRecordUpdateParams{
+		Record: database.UpdateRecordParams{
+			ID:            record.ID,
+			IsFinished:    pgtype.Bool{Bool: status == database.RecordStatusFinished, Valid: true},
+			StartDate:     startDate,
+			EndDate:       endDate,
+			Duration:      interval,
+			Comments:      record.Comments,
+			UserID:        record.UserID,
+			Rating:        record.Rating,
+			Status:        status,
+			AbandonReason: record.AbandonReason,
+		},
+	}
+	if resuming {
+		update.ResumedAt = statusChangedAt
+	}
+	result, err := cfg.db.SaveRecordUpdate(ctx, update)
+	if err != nil {
+		return database.UsersMediaRecord{}, err
+	}
+	return result.Record, nil
+}
+
+// Watched episodes of a record with its series' seasons, and the episode to watch next
+func recordEpisodesResponse(record database.UsersMediaRecord, medium database.Medium, seasons []SeriesSeason, episodes []database.RecordsEpisode) RecordEpisodes {
+	response := RecordEpisodes{
+		RecordID:     record.ID,
+		Status:       record.Status,
+		WatchedCount: int32(len(episodes)),
+		Seasons:      []SeriesSeason{},
+		Episodes:     []RecordEpisode{},
+	}
+	if total := seriesTotalEpisodes(medium, seasons); total > 0 {
+		response.TotalEpisodes = pgtype.Int4{Int32: total, Valid: true}
+	}
+
+	// Seasons of watched episodes missing from metadata are listed too, without their episode count
+	response.Seasons = append(response.Seasons, seasons...)
+	for _, episode := range episodes {
+		i := slices.IndexFunc(response.Seasons, func(season SeriesSeason) bool {
+			return season.SeasonNumber == episode.SeasonNumber
+		})
+		if i == -1 {
+			i = len(response.Seasons)
+			response.Seasons = append(response.Seasons, SeriesSeason{SeasonNumber: episode.SeasonNumber})
+		}
+		response.Seasons[i].WatchedCount++
+		response.Episodes = append(response.Episodes, RecordEpisode{
+			SeasonNumber:  episode.SeasonNumber,
+			EpisodeNumber: episode.EpisodeNumber,
+			WatchedAt:     episode.WatchedAt,
+			Rating:        episode.Rating,
+		})
+	}
+	slices.SortFunc(response.Seasons, func(a, b SeriesSeason) int {
+		return int(a.SeasonNumber - b.SeasonNumber)
+	})
+
+	if seasonNumber, episodeNumber, ok := nextEpisode(seasons, episodes); ok && record.Status != database.RecordStatusFinished {
+		response.NextEpisode = &NextEpisode{
+			RecordID:      record.ID,
+			MediumID:      medium.ID,
+			Title:         medium.Title,
+			ImageUrl:      medium.ImageUrl,
+			SeasonNumber:  seasonNumber,
+			EpisodeNumber: episodeNumber,
+		}
+	}
+	return response
+}
+
+// Logged user's record of a series with its medium, responding with an error if it isn't one
+func (cfg *apiConfig) getSeriesRecord(w http.ResponseWriter, r *http.Request, stringID string) (database.UsersMediaRecord, database.Medium, bool) {
+	record, ok := cfg.getUserRecord(w, r, stringID)
+	if !ok {
+		return database.UsersMediaRecord{}, database.Medium{}, false
+	}
+	medium, ok := cfg.getMedium(w, r, record.MediaID)
+	if !ok {
+		return database.UsersMediaRecord{}, database.Medium{}, false
+	}
+	if medium.MediaType != "series" {
+		respondWithError(w, 400, "only records of series have episodes", errors.New("record of a medium not a series"))
+		return database.UsersMediaRecord{}, database.Medium{}, false
+	}
+	return record, medium, true
+}
+
+// PUT /api/records/episodes
+func (cfg *apiConfig) handlerMarkEpisodes(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersMarkEpisodes
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	record, medium, ok := cfg.getSeriesRecord(w, r, params.RecordID)
+	if !ok {
+		return
+	}
+	if len(params.Episodes) == 0 {
+		respondWithError(w, 400, "no episode in request body", errors.New("field 'episodes' is empty"))
+		return
+	}
+	seasons := metadataSeasons(medium.Metadata)
+	for _, episode := range params.Episodes {
+		err = checkEpisode(seasons, episode.SeasonNumber, episode.EpisodeNumber)
+		if err != nil {
+			respondWithError(w, 400, err.Error(), err)
+			return
+		}
+	}
+
+	// Watched now, unless an earlier date is given
+	watchedAt, err := convertDateToPgtype(params.WatchedAt)
+	if err != nil {
+		respondWithError(w, 400, "watched_at not in good format", err)
+		return
+	}
+	if !watchedAt.Valid {
+		watchedAt = timestampNow()
+	}
+	if watchedAt.Time.After(time.Now()) {
+		respondWithError(w, 400, "episodes can't be watched in the future", errors.New("watched_at is in the future"))
+		return
+	}
+	if record.StartDate.Valid && watchedAt.Time.Before(record.StartDate.Time) {
+		respondWithError(w, 400, "episodes can't be watched before record's start date", errors.New("watched_at is before start_date"))
+		return
+	}
+	var rating pgtype.Int2
+	if params.Rating != nil {
+		rating, err = normalizeRating(*params.Rating, params.RatingScale)
+		if err != nil {
+			respondWithError(w, 400, err.Error(), err)
+			return
+		}
+	}
+
+	// Call query function for each episode, one watched again gets the new date and rating
+	for _, episode := range params.Episodes {
+		_, err = cfg.db.MarkRecordEpisode(r.Context(), database.MarkRecordEpisodeParams{
+			RecordID:      record.ID,
+			SeasonNumber:  episode.SeasonNumber,
+			EpisodeNumber: episode.EpisodeNumber,
+			WatchedAt:     watchedAt,
+			Rating:        rating,
+		})
+		if err != nil {
+			respondWithError(w, 500, "couldn't mark episode as watched in database", err)
+			return
+		}
+	}
+
+	cfg.respondWithRecordEpisodes(w, r, record, medium, seasons, false)
+}
+
+// DELETE /api/records/episodes
+func (cfg *apiConfig) handlerUnmarkEpisodes(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersUnmarkEpisodes
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	record, medium, ok := cfg.getSeriesRecord(w, r, params.RecordID)
+	if !ok {
+		return
+	}
+	if len(params.Episodes) == 0 {
+		respondWithError(w, 400, "no episode in request body", errors.New("field 'episodes' is empty"))
+		return
+	}
+
+	// Call query function for each episode, the ones not watched are skipped
+	for _, episode := range params.Episodes {
+		_, err = cfg.db.UnmarkRecordEpisode(r.Context(), database.UnmarkRecordEpisodeParams{
+			RecordID:      record.ID,
+			SeasonNumber:  episode.SeasonNumber,
+			EpisodeNumber: episode.EpisodeNumber,
+		})
+		if err != nil {
+			respondWithError(w, 500, "couldn't unmark episode in database", err)
+			return
+		}
+	}
+
+	cfg.respondWithRecordEpisodes(w, r, record, medium, metadataSeasons(medium.Metadata), true)
+}
+
+// Update record's status from its episodes once they changed, and respond with them
+func (cfg *apiConfig) respondWithRecordEpisodes(w http.ResponseWriter, r *http.Request, record database.UsersMediaRecord, medium database.Medium, seasons []SeriesSeason, unwatching bool) {
+	episodes, err := cfg.db.GetRecordEpisodes(r.Context(), record.ID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get record's episodes in database", err)
+		return
+	}
+	record, err = cfg.updateStatusFromEpisodes(r.Context(), record, episodes, seriesTotalEpisodes(medium, seasons), unwatching)
+	if err != nil {
+		respondWithError(w, 500, "couldn't update record's status in database", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 200, recordEpisodesResponse(record, medium, seasons, episodes))
+}
+
+// GET /api/records/episodes
+func (cfg *apiConfig) handlerGetRecordEpisodes(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersGetRecordEpisodes
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	record, medium, ok := cfg.getSeriesRecord(w, r, params.RecordID)
+	if !ok {
+		return
+	}
+
+	// Call query function
+	episodes, err := cfg.db.GetRecordEpisodes(r.Context(), record.ID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get record's episodes in database", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 200, recordEpisodesResponse(record, medium, metadataSeasons(medium.Metadata), episodes))
+}
+
+type responseGetNextEpisodes struct {
+	NextEpisodes []NextEpisode `json:"next_episodes"`
+}
+
+// GET /api/records/episodes/next
+func (cfg *apiConfig) handlerGetNextEpisodes(w http.ResponseWriter, r *http.Request) {
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Call query functions
+	records, err := cfg.db.GetSeriesRecordsByUserID(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get user's series in database", err)
+		return
+	}
+	episodes, err := cfg.db.GetEpisodesByUserID(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get user's episodes in database", err)
+		return
+	}
+	episodesByRecord := make(map[pgtype.UUID][]database.RecordsEpisode)
+	for _, episode := range episodes {
+		episodesByRecord[episode.RecordID] = append(episodesByRecord[episode.RecordID], episode)
+	}
+
+	// Series in progress with an episode left to watch
+	response := responseGetNextEpisodes{
+		NextEpisodes: []NextEpisode{},
+	}
+	for _, record := range records {
+		recordEpisodes := episodesByRecord[record.ID]
+		seasonNumber, episodeNumber, ok := nextEpisode(metadataSeasons(record.Metadata), recordEpisodes)
+		if !ok {
+			continue
+		}
+		next := NextEpisode{
+			RecordID:      record.ID,
+			MediumID:      record.MediaID,
+			Title:         record.Title,
+			ImageUrl:      record.ImageUrl,
+			SeasonNumber:  seasonNumber,
+			EpisodeNumber: episodeNumber,
+		}
+		for _, episode := range recordEpisodes {
+			if !next.LastWatchedAt.Valid || episode.WatchedAt.Time.After(next.LastWatchedAt.Time) {
+				next.LastWatchedAt = episode.WatchedAt
+			}
+		}
+		response.NextEpisodes = append(response.NextEpisodes, next)
+	}
+
+	// Series watched last first, the ones not started yet keep their title order
+	slices.SortStableFunc(response.NextEpisodes, func(a, b NextEpisode) int {
+		if a.LastWatchedAt.Valid != b.LastWatchedAt.Valid {
+			if a.LastWatchedAt.Valid {
+				return -1
+			}
+			return 1
+		}
+		return b.LastWatchedAt.Time.Compare(a.LastWatchedAt.Time)
+	})
+
+	// Respond
+	respondWithJson(w, 200, response)
+}
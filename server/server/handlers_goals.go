@@ -0,0 +1,520 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Metrics a goal can track besides counting finished records, by media type
+var goalMetricsByMediaType = map[string][]string{
+	"book":      {"pages"},
+	"movie":     {"hours"},
+	"videogame": {"hours"},
+}
+
+// Check a goal's details and turn them into the store's parameters, tags still have to be resolved.
+// An error is returned when the details aren't valid, to be sent back as it is
+func (params parametersGoalDetails) toSaveGoalParams(now time.Time) (database.SaveGoalParams, error) {
+	mediaType := strings.ToLower(strings.TrimSpace(params.MediaType))
+	if mediaType == "" {
+		return database.SaveGoalParams{}, errors.New("media_type must be provided")
+	}
+
+	// Goals count finished records by default
+	metric := params.Metric
+	if metric == "" {
+		metric = "count"
+	}
+	if metric != "count" && !slices.Contains(goalMetricsByMediaType[mediaType], metric) {
+		if metric != "pages" && metric != "hours" {
+			return database.SaveGoalParams{}, fmt.Errorf("unknown metric %q (count, pages or hours)", metric)
+		}
+		return database.SaveGoalParams{}, fmt.Errorf("%s can't be tracked for media of type %s", metric, mediaType)
+	}
+	if params.Target <= 0 {
+		return database.SaveGoalParams{}, errors.New("target must be positive")
+	}
+
+	// Goals are set for the current year by default
+	period := params.Period
+	if period == "" {
+		period = "year"
+	}
+	from, to, err := periodWindow(period, params.Date, params.From, params.To, now)
+	if err != nil {
+		return database.SaveGoalParams{}, err
+	}
+
+	return database.SaveGoalParams{
+		Name:        strings.TrimSpace(params.Name),
+		MediaType:   mediaType,
+		Metric:      metric,
+		Target:      params.Target,
+		Period:      period,
+		PeriodStart: pgtype.Timestamp{Time: from, Valid: true},
+		PeriodEnd:   pgtype.Timestamp{Time: to, Valid: true},
+	}, nil
+}
+
+// Resolve a goal's tags among the logged user's ones, responding with an error if one isn't theirs
+// A tag given twice is kept once
+func (cfg *apiConfig) resolveGoalTags(w http.ResponseWriter, r *http.Request, stringIDs []string) ([]pgtype.UUID, bool) {
+	tagIDs := make([]pgtype.UUID, 0, len(stringIDs))
+	for _, stringID := range stringIDs {
+		tag, ok := cfg.getUserTag(w, r, stringID)
+		if !ok {
+			return nil, false
+		}
+		if !slices.Contains(tagIDs, tag.ID) {
+			tagIDs = append(tagIDs, tag.ID)
+		}
+	}
+	return tagIDs, true
+}
+
+// What a finished record adds to a goal's progress, nothing when its pages or hours aren't known
+func goalRecordAmount(metric string, record database.GetGoalRecordsRow) float64 {
+	switch metric {
+	case "pages":
+		total := metadataProgressTotal(database.Medium{MediaType: strings.ToLower(record.MediaType), Metadata: record.Metadata})
+		if total.Valid {
+			return float64(total.Int32)
+		}
+	case "hours":
+		if record.HoursPlayed.Valid {
+			return record.HoursPlayed.Float64
+		}
+		// Movies' runtime is in minutes
+		metadata, err := bytesToMap(record.Metadata)
+		if err != nil {
+			return 0
+		}
+		var minutes float64
+		switch value := metadata["runtime"].(type) {
+		case float64:
+			minutes = value
+		case string:
+			minutes, _ = strconv.ParseFloat(value, 64)
+		}
+		if minutes > 0 {
+			return minutes / 60
+		}
+	default:
+		return 1
+	}
+	return 0
+}
+
+// Progress a goal should have reached at now at a steady pace over its period, and where the goal stands
+func goalPace(target int32, progress float64, start, end, now time.Time) (float64, string) {
+	elapsed := min(1, max(0, now.Sub(start).Seconds()/end.Sub(start).Seconds()))
+	expected := math.Round(float64(target)*elapsed*100) / 100
+
+	switch {
+	case progress >= float64(target):
+		return expected, "completed"
+	case now.Before(start):
+		return expected, "upcoming"
+	case !now.Before(end):
+		return expected, "missed"
+	case progress >= expected:
+		return expected, "ahead"
+	default:
+		return expected, "behind"
+	}
+}
+
+// Build a goal response with its progress, computed from the records finished in its period
+func goalResponse(goal database.Goal, tags []GoalTag, records []database.GetGoalRecordsRow, now time.Time) Goal {
+	progress := 0.0
+	for _, record := range records {
+		progress += goalRecordAmount(goal.Metric, record)
+	}
+	progress = math.Round(progress*100) / 100
+	expected, status := goalPace(goal.Target, progress, goal.PeriodStart.Time, goal.PeriodEnd.Time, now)
+
+	if tags == nil {
+		tags = []GoalTag{}
+	}
+	return Goal{
+		ID:           goal.ID,
+		CreatedAt:    goal.CreatedAt,
+		UpdatedAt:    goal.UpdatedAt,
+		Name:         goal.Name,
+		MediaType:    goal.MediaType,
+		Metric:       goal.Metric,
+		Target:       goal.Target,
+		Period:       goal.Period,
+		PeriodStart:  goal.PeriodStart,
+		PeriodEnd:    goal.PeriodEnd,
+		Tags:         tags,
+		RecordsCount: len(records),
+		Progress:     progress,
+		Percent:      math.Round(min(100, 100*progress/float64(goal.Target))*100) / 100,
+		Expected:     expected,
+		Pace:         math.Round((progress-expected)*100) / 100,
+		Status:       status,
+	}
+}
+
+// Tags of the logged user's goals, by goal's ID
+func (cfg *apiConfig) getGoalTags(ctx context.Context, userID pgtype.UUID) (map[pgtype.UUID][]GoalTag, error) {
+	rows, err := cfg.db.GetGoalTagsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	tagsByGoal := make(map[pgtype.UUID][]GoalTag)
+	for _, row := range rows {
+		tagsByGoal[row.GoalID] = append(tagsByGoal[row.GoalID], GoalTag{ID: row.TagID, Name: row.Name})
+	}
+	return tagsByGoal, nil
+}
+
+// Records each of the logged user's goals counts, by goal's ID
+func (cfg *apiConfig) getGoalsRecords(ctx context.Context, userID pgtype.UUID) (map[pgtype.UUID][]database.GetGoalRecordsRow, error) {
+	rows, err := cfg.db.GetGoalRecordsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	recordsByGoal := make(map[pgtype.UUID][]database.GetGoalRecordsRow)
+	for _, row := range rows {
+		recordsByGoal[row.GoalID] = append(recordsByGoal[row.GoalID], database.GetGoalRecordsRow{
+			ID:          row.ID,
+			MediaID:     row.MediaID,
+			EndDate:     row.EndDate,
+			MediaType:   row.MediaType,
+			Metadata:    row.Metadata,
+			HoursPlayed: row.HoursPlayed,
+		})
+	}
+	return recordsByGoal, nil
+}
+
+// Save a goal and respond with it and its progress
+func (cfg *apiConfig) saveGoal(w http.ResponseWriter, r *http.Request, saveParams database.SaveGoalParams, status int) {
+	result, err := cfg.db.SaveGoal(r.Context(), saveParams)
+	if err != nil {
+		respondWithError(w, 500, "couldn't save goal in database", err)
+		return
+	}
+
+	tagsByGoal, err := cfg.getGoalTags(r.Context(), saveParams.UserID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get goal's tags in database", err)
+		return
+	}
+	tags := tagsByGoal[result.Goal.ID]
+	tagIDs := make([]pgtype.UUID, 0, len(tags))
+	for _, tag := range tags {
+		tagIDs = append(tagIDs, tag.ID)
+	}
+	records, err := cfg.db.GetGoalRecords(r.Context(), database.GetGoalRecordsParams{
+		UserID:      result.Goal.UserID,
+		PeriodStart: result.Goal.PeriodStart,
+		PeriodEnd:   result.Goal.PeriodEnd,
+		MediaType:   result.Goal.MediaType,
+		TagIds:      tagIDs,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't get goal's records in database", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, status, goalResponse(result.Goal, tags, records, time.Now().UTC()))
+}
+
+// POST /api/goals
+func (cfg *apiConfig) handlerCreateGoal(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersGoalDetails
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	saveParams, err := params.toSaveGoalParams(time.Now().UTC())
+	if err != nil {
+		respondWithError(w, 400, err.Error(), err)
+		return
+	}
+
+	var ok bool
+	saveParams.TagIDs, ok = cfg.resolveGoalTags(w, r, params.TagIDs)
+	if !ok {
+		return
+	}
+
+	// Call query function
+	saveParams.UserID = r.Context().Value(userIDKey).(pgtype.UUID)
+	cfg.saveGoal(w, r, saveParams, 201)
+}
+
+type responseGetGoals struct {
+	Goals []Goal `json:"goals"`
+}
+
+// GET /api/goals
+func (cfg *apiConfig) handlerGetGoals(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersGetGoals
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Call query functions
+	goals, err := cfg.db.GetGoalsByUserID(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get goals in database", err)
+		return
+	}
+	tagsByGoal, err := cfg.getGoalTags(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get goals' tags in database", err)
+		return
+	}
+	recordsByGoal, err := cfg.getGoalsRecords(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get goals' records in database", err)
+		return
+	}
+
+	now := time.Now().UTC()
+	response := responseGetGoals{
+		Goals: make([]Goal, 0, len(goals)),
+	}
+	for _, goal := range goals {
+		// Current goals are the ones whose period holds now
+		if params.CurrentOnly && (now.Before(goal.PeriodStart.Time) || !now.Before(goal.PeriodEnd.Time)) {
+			continue
+		}
+		response.Goals = append(response.Goals, goalResponse(goal, tagsByGoal[goal.ID], recordsByGoal[goal.ID], now))
+	}
+
+	// Respond
+	respondWithJson(w, 200, response)
+}
+
+// Get one of the logged user's goals, respond with an error if there is none
+func (cfg *apiConfig) getUserGoal(w http.ResponseWriter, r *http.Request, stringID string) (database.Goal, bool) {
+	goalID, err := convertIdToPgtype(stringID)
+	if err != nil {
+		respondWithError(w, 400, "goal_id not in good format", err)
+		return database.Goal{}, false
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	goal, err := cfg.db.GetUserGoalByID(r.Context(), database.GetUserGoalByIDParams{
+		ID:     goalID,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondWithError(w, 404, "no goal found with given ID for user", err)
+			return database.Goal{}, false
+		}
+		respondWithError(w, 500, "couldn't get goal in database", err)
+		return database.Goal{}, false
+	}
+	return goal, true
+}
+
+// PUT /api/goals
+func (cfg *apiConfig) handlerUpdateGoal(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersUpdateGoal
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	goal, ok := cfg.getUserGoal(w, r, params.GoalID)
+	if !ok {
+		return
+	}
+
+	// A calendar period is found again from the given date, or from now
+	saveParams, err := params.toSaveGoalParams(time.Now().UTC())
+	if err != nil {
+		respondWithError(w, 400, err.Error(), err)
+		return
+	}
+	saveParams.TagIDs, ok = cfg.resolveGoalTags(w, r, params.TagIDs)
+	if !ok {
+		return
+	}
+
+	// Call query function, the given tags replace the goal's previous ones
+	saveParams.ID = goal.ID
+	saveParams.UserID = goal.UserID
+	cfg.saveGoal(w, r, saveParams, 200)
+}
+
+// DELETE /api/goals
+func (cfg *apiConfig) handlerDeleteGoal(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersGoal
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Convert GoalID to pgtype.UUID
+	goalID, err := convertIdToPgtype(params.GoalID)
+	if err != nil {
+		respondWithError(w, 400, "goal_id not in good format", err)
+		return
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Call query function
+	count, err := cfg.db.DeleteGoal(r.Context(), database.DeleteGoalParams{
+		ID:     goalID,
+		UserID: userID,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't delete goal in database", err)
+		return
+	}
+	if count == 0 {
+		respondWithError(w, 404, "no goal found with given ID for user", nil)
+		return
+	}
+
+	// Respond
+	w.WriteHeader(200)
+}
+
+// Start of the day or week (starting on monday) holding t
+func streakUnitStart(unit string, t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if unit == "week" {
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	}
+	return day
+}
+
+// Count consecutive days or weeks with activity, from the days user was active, latest first
+func activityStreak(days []time.Time, unit string, now time.Time) Streak {
+	streak := Streak{Unit: unit}
+	if len(days) == 0 {
+		return streak
+	}
+	step := 1
+	if unit == "week" {
+		step = 7
+	}
+
+	// Days of a same week are a single unit
+	var units []time.Time
+	for _, day := range days {
+		start := streakUnitStart(unit, day)
+		if len(units) == 0 || !units[len(units)-1].Equal(start) {
+			units = append(units, start)
+		}
+	}
+
+	current := streakUnitStart(unit, now)
+	streak.LastActiveAt = pgtype.Timestamp{Time: days[0], Valid: true}
+	streak.ActiveNow = units[0].Equal(current)
+	running := units[0].Equal(current) || units[0].Equal(current.AddDate(0, 0, -step))
+
+	// Units are latest first, so a run's start is its last unit
+	length, firstRun := 0, 0
+	for i, start := range units {
+		if i > 0 && !units[i-1].AddDate(0, 0, -step).Equal(start) {
+			if firstRun == 0 {
+				firstRun = length
+			}
+			length = 0
+		}
+		length++
+		if length > streak.Longest {
+			streak.Longest = length
+			streak.LongestStart = pgtype.Timestamp{Time: start, Valid: true}
+		}
+	}
+	if firstRun == 0 {
+		firstRun = length
+	}
+
+	// The latest run is still going if it reaches the current or the previous unit
+	if running {
+		streak.Current = firstRun
+	}
+	return streak
+}
+
+// GET /api/goals/streak
+func (cfg *apiConfig) handlerGetStreak(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersGetStreak
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Streaks are counted in days by default
+	unit := params.Unit
+	if unit == "" {
+		unit = "day"
+	}
+	if unit != "day" && unit != "week" {
+		respondWithError(w, 400, fmt.Sprintf("unknown unit %q (day or week)", unit), errors.New("invalid streak unit"))
+		return
+	}
+	mediaType := strings.ToLower(strings.TrimSpace(params.MediaType))
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Call query function
+	rows, err := cfg.db.GetActivityDays(r.Context(), database.GetActivityDaysParams{
+		UserID:    userID,
+		MediaType: mediaType,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't get activity in database", err)
+		return
+	}
+	days := make([]time.Time, 0, len(rows))
+	for _, row := range rows {
+		days = append(days, row.Time)
+	}
+
+	streak := activityStreak(days, unit, time.Now().UTC())
+	streak.MediaType = mediaType
+
+	// Respond
+	respondWithJson(w, 200, streak)
+}
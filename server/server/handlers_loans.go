@@ -0,0 +1,458 @@
+package server
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Build a loan response, owner and medium are given by caller
+func loanResponse(loan database.Loan, ownerUsername string, medium database.Medium) Loan {
+	response := Loan{
+		ID:            loan.ID,
+		CreatedAt:     loan.CreatedAt,
+		UpdatedAt:     loan.UpdatedAt,
+		OwnerID:       loan.OwnerID,
+		OwnerUsername: ownerUsername,
+		MediumID:      loan.MediaID,
+		MediaType:     medium.MediaType,
+		Title:         medium.Title,
+		BorrowerID:    loan.BorrowerID,
+		BorrowerName:  loan.BorrowerName,
+		LentAt:        loan.LentAt,
+		DueAt:         loan.DueAt,
+		ReturnedAt:    loan.ReturnedAt,
+	}
+	timestamp := time.Now().UTC()
+	response.IsOverdue = loan.DueAt.Valid && !loan.ReturnedAt.Valid && timestamp.After(loan.DueAt.Time)
+	response.DaysOverdue = daysOverdue(loan.DueAt, loan.ReturnedAt, timestamp)
+	return response
+}
+
+// Whole days an open loan is past its expected return date, 0 if it's not overdue (or was returned)
+func daysOverdue(dueAt, returnedAt pgtype.Timestamp, now time.Time) int32 {
+	if !dueAt.Valid || returnedAt.Valid || !now.After(dueAt.Time) {
+		return 0
+	}
+	return int32(now.Sub(dueAt.Time).Hours() / 24)
+}
+
+// Split loans of a user between the ones they made and the ones made to them
+func splitUserLoans(userID pgtype.UUID, rows []database.GetOpenLoansByUserIDRow) responseGetLoans {
+	response := responseGetLoans{
+		LentOut:  []Loan{},
+		Borrowed: []Loan{},
+	}
+	for _, row := range rows {
+		loan := loanResponse(database.Loan{
+			ID:           row.ID,
+			CreatedAt:    row.CreatedAt,
+			UpdatedAt:    row.UpdatedAt,
+			OwnerID:      row.OwnerID,
+			MediaID:      row.MediaID,
+			BorrowerID:   row.BorrowerID,
+			BorrowerName: row.BorrowerName,
+			LentAt:       row.LentAt,
+			DueAt:        row.DueAt,
+			ReturnedAt:   row.ReturnedAt,
+		}, row.OwnerUsername, database.Medium{MediaType: row.MediaType, Title: row.Title})
+		if row.OwnerID == userID {
+			response.LentOut = append(response.LentOut, loan)
+		} else {
+			response.Borrowed = append(response.Borrowed, loan)
+		}
+	}
+	return response
+}
+
+// POST /api/loans
+func (cfg *apiConfig) handlerCreateLoan(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersCreateLoan
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Check if all required fields are provided, a medium is lent to a user OR to someone named freely
+	params.BorrowerName = strings.TrimSpace(params.BorrowerName)
+	if params.MediumID == "" || (params.BorrowerUsername == "") == (params.BorrowerName == "") {
+		respondWithError(w, 400, "medium_id and either borrower_username or borrower_name must be provided", errors.New("invalid loan request body"))
+		return
+	}
+
+	// Convert dates to pgtype.Timestamp, a loan starts now by default
+	lentAt, err := convertDateToPgtype(params.LentAt)
+	if err != nil {
+		respondWithError(w, 400, "lent_at not in good format", err)
+		return
+	}
+	if !lentAt.Valid {
+		lentAt = timestampNow()
+	}
+	dueAt, err := convertDateToPgtype(params.DueAt)
+	if err != nil {
+		respondWithError(w, 400, "due_at not in good format", err)
+		return
+	}
+	if dueAt.Valid && dueAt.Time.Before(lentAt.Time) {
+		respondWithError(w, 400, "due_at can't be before lent_at", errors.New("loan due before it started"))
+		return
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// A user can only lend a medium on their shelf
+	mediumID, err := convertIdToPgtype(params.MediumID)
+	if err != nil {
+		respondWithError(w, 400, "medium_id not in good format", err)
+		return
+	}
+	medium, ok := cfg.getMedium(w, r, mediumID)
+	if !ok {
+		return
+	}
+	count, err := cfg.db.CountUserRecordsByMediumID(r.Context(), database.CountUserRecordsByMediumIDParams{
+		MediaID: medium.ID,
+		UserID:  userID,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't get user's records in database", err)
+		return
+	}
+	if count == 0 {
+		respondWithError(w, 404, "no medium with given ID in user's shelf", errors.New("medium not in user's shelf"))
+		return
+	}
+
+	owner, err := cfg.db.GetUserByID(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get user in database", err)
+		return
+	}
+
+	// Borrower is another user, whose username is kept as borrower's name
+	var borrowerID pgtype.UUID
+	borrowerName := params.BorrowerName
+	if params.BorrowerUsername != "" {
+		borrower, err := cfg.db.GetUserByUsername(r.Context(), params.BorrowerUsername)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				respondWithError(w, 404, "no user found with given username", err)
+				return
+			}
+			respondWithError(w, 500, "couldn't get user in database", err)
+			return
+		}
+		if borrower.ID == owner.ID {
+			respondWithError(w, 400, "you can't lend to yourself", errors.New("loan borrower is the owner"))
+			return
+		}
+		borrowerID = borrower.ID
+		borrowerName = borrower.Username
+	}
+
+	// Call query function
+	loan, err := cfg.db.CreateLoan(r.Context(), database.CreateLoanParams{
+		OwnerID:      owner.ID,
+		MediaID:      medium.ID,
+		BorrowerID:   borrowerID,
+		BorrowerName: borrowerName,
+		LentAt:       lentAt,
+		DueAt:        dueAt,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			// This is a unique constraint violation
+			respondWithError(w, 409, "this medium is already lent out", err)
+			return
+		}
+		respondWithError(w, 500, "couldn't create loan in database", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 201, loanResponse(loan, owner.Username, medium))
+}
+
+type responseGetLoans struct {
+	LentOut  []Loan `json:"lent_out"`
+	Borrowed []Loan `json:"borrowed"`
+}
+
+// GET /api/loans
+func (cfg *apiConfig) handlerGetLoans(w http.ResponseWriter, r *http.Request) {
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Call query function
+	loans, err := cfg.db.GetOpenLoansByUserID(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get loans in database", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 200, splitUserLoans(userID, loans))
+}
+
+// GET /api/loans/overdue
+func (cfg *apiConfig) handlerGetOverdueLoans(w http.ResponseWriter, r *http.Request) {
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Call query function
+	loans, err := cfg.db.GetOverdueLoansByUserID(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get overdue loans in database", err)
+		return
+	}
+
+	// Both queries return the same columns
+	rows := make([]database.GetOpenLoansByUserIDRow, 0, len(loans))
+	for _, loan := range loans {
+		rows = append(rows, database.GetOpenLoansByUserIDRow(loan))
+	}
+
+	// Respond
+	respondWithJson(w, 200, splitUserLoans(userID, rows))
+}
+
+// Get one of logged user's loans, responding with an error if it can't be found
+func (cfg *apiConfig) getUserLoan(w http.ResponseWriter, r *http.Request, stringID string) (database.Loan, bool) {
+	loanID, err := convertIdToPgtype(stringID)
+	if err != nil {
+		respondWithError(w, 400, "loan_id not in good format", err)
+		return database.Loan{}, false
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	loan, err := cfg.db.GetUserLoanByID(r.Context(), database.GetUserLoanByIDParams{
+		ID:      loanID,
+		OwnerID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondWithError(w, 404, "no loan found with given ID for user", err)
+			return database.Loan{}, false
+		}
+		respondWithError(w, 500, "couldn't get loan in database", err)
+		return database.Loan{}, false
+	}
+	return loan, true
+}
+
+// Update a loan and respond with it
+func (cfg *apiConfig) updateLoan(w http.ResponseWriter, r *http.Request, loan database.Loan) {
+	updatedLoan, err := cfg.db.UpdateLoan(r.Context(), database.UpdateLoanParams{
+		ID:         loan.ID,
+		OwnerID:    loan.OwnerID,
+		DueAt:      loan.DueAt,
+		ReturnedAt: loan.ReturnedAt,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't update loan in database", err)
+		return
+	}
+
+	owner, err := cfg.db.GetUserByID(r.Context(), loan.OwnerID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get user in database", err)
+		return
+	}
+	medium, err := cfg.db.GetMediumByID(r.Context(), loan.MediaID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get loan's medium in database", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 200, loanResponse(updatedLoan, owner.Username, medium))
+}
+
+// PUT /api/loans
+func (cfg *apiConfig) handlerUpdateLoan(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersUpdateLoan
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	loan, ok := cfg.getUserLoan(w, r, params.LoanID)
+	if !ok {
+		return
+	}
+	if loan.ReturnedAt.Valid {
+		respondWithError(w, 400, "loan was already returned", errors.New("loan already returned"))
+		return
+	}
+
+	// An empty due_at removes the expected return date
+	loan.DueAt, err = convertDateToPgtype(params.DueAt)
+	if err != nil {
+		respondWithError(w, 400, "due_at not in good format", err)
+		return
+	}
+	if loan.DueAt.Valid && loan.DueAt.Time.Before(loan.LentAt.Time) {
+		respondWithError(w, 400, "due_at can't be before lent_at", errors.New("loan due before it started"))
+		return
+	}
+
+	cfg.updateLoan(w, r, loan)
+}
+
+// PUT /api/loans/return
+func (cfg *apiConfig) handlerReturnLoan(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersReturnLoan
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	loan, ok := cfg.getUserLoan(w, r, params.LoanID)
+	if !ok {
+		return
+	}
+	if loan.ReturnedAt.Valid {
+		respondWithError(w, 400, "loan was already returned", errors.New("loan already returned"))
+		return
+	}
+
+	// A loan is returned now by default
+	loan.ReturnedAt, err = convertDateToPgtype(params.ReturnedAt)
+	if err != nil {
+		respondWithError(w, 400, "returned_at not in good format", err)
+		return
+	}
+	if !loan.ReturnedAt.Valid {
+		loan.ReturnedAt = timestampNow()
+	}
+	if loan.ReturnedAt.Time.Before(loan.LentAt.Time) {
+		respondWithError(w, 400, "returned_at can't be before lent_at", errors.New("loan returned before it started"))
+		return
+	}
+
+	cfg.updateLoan(w, r, loan)
+}
+
+// DELETE /api/loans
+func (cfg *apiConfig) handlerDeleteLoan(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersLoan
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Convert LoanID to pgtype.UUID
+	loanID, err := convertIdToPgtype(params.LoanID)
+	if err != nil {
+		respondWithError(w, 400, "loan_id not in good format", err)
+		return
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Call query function
+	count, err := cfg.db.DeleteLoan(r.Context(), database.DeleteLoanParams{
+		ID:      loanID,
+		OwnerID: userID,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't delete loan in database", err)
+		return
+	}
+	if count == 0 {
+		respondWithError(w, 404, "no loan found with given ID for user", nil)
+		return
+	}
+
+	// Respond
+	w.WriteHeader(200)
+}
+
+type responseGetMediumLoans struct {
+	MediumID  pgtype.UUID `json:"medium_id"`
+	IsLentOut bool        `json:"is_lent_out"`
+	Loans     []Loan      `json:"loans"`
+}
+
+// GET /api/media/loans
+func (cfg *apiConfig) handlerGetMediumLoans(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersGetMediumLoans
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Convert MediumID to pgtype.UUID
+	mediumID, err := convertIdToPgtype(params.MediumID)
+	if err != nil {
+		respondWithError(w, 400, "medium_id not in good format", err)
+		return
+	}
+	medium, ok := cfg.getMedium(w, r, mediumID)
+	if !ok {
+		return
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	owner, err := cfg.db.GetUserByID(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get user in database", err)
+		return
+	}
+
+	// Call query function
+	loans, err := cfg.db.GetMediumLoans(r.Context(), database.GetMediumLoansParams{
+		OwnerID: userID,
+		MediaID: medium.ID,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't get medium's loans in database", err)
+		return
+	}
+
+	response := responseGetMediumLoans{
+		MediumID: medium.ID,
+		Loans:    make([]Loan, 0, len(loans)),
+	}
+	for _, loan := range loans {
+		response.Loans = append(response.Loans, loanResponse(loan, owner.Username, medium))
+		if !loan.ReturnedAt.Valid {
+			response.IsLentOut = true
+		}
+	}
+
+	// Respond
+	respondWithJson(w, 200, response)
+}
@@ -1,11 +1,16 @@
 package server
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/VincNT21/kallaxy/server/internal/database"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -39,99 +44,115 @@ func (cfg *apiConfig) handlerCreateMedium(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	// Check and convert external IDs to []byte
+	externalIDsBytes, err := externalIDsToBytes(params.ExternalIDs)
+	if err != nil {
+		respondWithError(w, 400, err.Error(), err)
+		return
+	}
+
+	// Get userID from access token, the user creating a medium owns it
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
 	// Call query function
 	medium, err := cfg.db.CreateMedium(r.Context(), database.CreateMediumParams{
-		MediaType: params.MediaType,
-		Title:     params.Title,
-		Creator:   params.Creator,
-		PubDate:   params.PubDate,
-		ImageUrl:  params.ImageUrl,
-		Metadata:  metadataBytes,
+		MediaType:   params.MediaType,
+		Title:       params.Title,
+		Creator:     params.Creator,
+		PubDate:     params.PubDate,
+		ImageUrl:    params.ImageUrl,
+		Metadata:    metadataBytes,
+		CreatedBy:   userID,
+		ExternalIds: externalIDsBytes,
 	})
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
 			// This is a unique constraint violation
-			respondWithError(w, 409, "A medium with same title already exists in database", err)
+			respondWithError(w, 409, mediumConflictMessage(pgErr), err)
 			return
 		}
 		respondWithError(w, 500, "couldn't create new medium in database", err)
 		return
 	}
 
-	// Convert metadata back to map
-	metadataMap, err := bytesToMap(medium.Metadata)
+	// Convert medium for response
+	responseMedium, err := toResponseMedium(medium)
 	if err != nil {
-		respondWithError(w, 500, "couldn't convert metadata map from database", err)
+		respondWithError(w, 500, "couldn't convert medium from database", err)
 		return
 	}
 
 	// Respond
 	respondWithJson(w, 201, response{
-		Medium: Medium{
-			ID:        medium.ID,
-			MediaType: medium.MediaType,
-			CreatedAt: medium.CreatedAt,
-			UpdatedAt: medium.UpdatedAt,
-			Title:     medium.Title,
-			Creator:   medium.Creator,
-			PubDate:   medium.PubDate,
-			ImageUrl:  medium.ImageUrl,
-			Metadata:  metadataMap,
-		},
+		Medium: responseMedium,
 	})
 }
 
+type responseGetMediaByTitleAndType struct {
+	Media []Medium `json:"media"`
+}
+
 // GET /api/media
-func (cfg *apiConfig) handlerGetMediumByTitleAndType(w http.ResponseWriter, r *http.Request) {
-	type response struct {
-		Medium
-	}
+func (cfg *apiConfig) handlerGetMediaByTitleAndType(w http.ResponseWriter, r *http.Request) {
 
 	// Parse data from request body
-	var params parametersGetMediumByTitleAndType
+	var params parametersGetMediaByTitleAndType
 	err := json.NewDecoder(r.Body).Decode(&params)
 	if err != nil {
 		respondWithError(w, 500, "couldn't decode body from request", err)
 		return
 	}
 
-	// Call query function
-	medium, err := cfg.db.GetMediumByTitleAndType(r.Context(), database.GetMediumByTitleAndTypeParams{
-		Lower:   params.Title,
-		Lower_2: params.MediaType,
-	})
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			respondWithError(w, 404, fmt.Sprintf("No %s medium with title %s in database", params.MediaType, params.Title), err)
+	var media []database.Medium
+	if len(params.ExternalIDs) > 0 {
+		// Every medium linked to one of the given provider IDs is a candidate
+		externalIDsBytes, err := externalIDsToBytes(params.ExternalIDs)
+		if err != nil {
+			respondWithError(w, 400, err.Error(), err)
 			return
 		}
-		respondWithError(w, 500, "couldn't get medium by title", err)
+		media, err = cfg.db.GetMediaByExternalIDs(r.Context(), externalIDsBytes)
+		if err != nil {
+			respondWithError(w, 500, "couldn't get media by external ids", err)
+			return
+		}
+	} else {
+		// Call query function, every medium sharing the title is a candidate
+		media, err = cfg.db.GetMediaByTitleAndType(r.Context(), database.GetMediaByTitleAndTypeParams{
+			Title:     params.Title,
+			MediaType: params.MediaType,
+			Creator:   params.Creator,
+			PubYear:   database.PubYear(params.PubDate),
+		})
+		if err != nil {
+			respondWithError(w, 500, "couldn't get media by title", err)
+			return
+		}
+	}
+	if len(media) == 0 {
+		if len(params.ExternalIDs) > 0 {
+			respondWithError(w, 404, "No medium linked to given external ids in database", sql.ErrNoRows)
+			return
+		}
+		respondWithError(w, 404, fmt.Sprintf("No %s medium with title %s in database", params.MediaType, params.Title), sql.ErrNoRows)
 		return
 	}
 
-	// Convert metadata back to map
-	metadataMap, err := bytesToMap(medium.Metadata)
-	if err != nil {
-		respondWithError(w, 500, "couldn't convert metadata map from database", err)
-		return
+	response := responseGetMediaByTitleAndType{}
+	for _, medium := range media {
+		// Convert medium for response
+		responseMedium, err := toResponseMedium(medium)
+		if err != nil {
+			respondWithError(w, 500, "couldn't convert medium from database", err)
+			return
+		}
+
+		response.Media = append(response.Media, responseMedium)
 	}
 
 	// Respond
-	respondWithJson(w, 200, response{
-		Medium: Medium{
-			ID:        medium.ID,
-			MediaType: medium.MediaType,
-			CreatedAt: medium.CreatedAt,
-			UpdatedAt: medium.UpdatedAt,
-			Title:     medium.Title,
-			Creator:   medium.Creator,
-			PubDate:   medium.PubDate,
-			ImageUrl:  medium.ImageUrl,
-			Metadata:  metadataMap,
-		},
-	})
-
+	respondWithJson(w, 200, response)
 }
 
 type responseGetMediaByType struct {
@@ -162,24 +183,14 @@ func (cfg *apiConfig) handlerGetMediaByType(w http.ResponseWriter, r *http.Reque
 
 	response := responseGetMediaByType{}
 	for _, medium := range media {
-		// Convert metadata back to map
-		metadataMap, err := bytesToMap(medium.Metadata)
+		// Convert medium for response
+		responseMedium, err := toResponseMedium(medium)
 		if err != nil {
-			respondWithError(w, 500, "couldn't convert metadata map from database", err)
+			respondWithError(w, 500, "couldn't convert medium from database", err)
 			return
 		}
 
-		response.Media = append(response.Media, Medium{
-			ID:        medium.ID,
-			MediaType: medium.MediaType,
-			CreatedAt: medium.CreatedAt,
-			UpdatedAt: medium.UpdatedAt,
-			Title:     medium.Title,
-			Creator:   medium.Creator,
-			PubDate:   medium.PubDate,
-			ImageUrl:  medium.ImageUrl,
-			Metadata:  metadataMap,
-		})
+		response.Media = append(response.Media, responseMedium)
 	}
 
 	// Respond
@@ -208,45 +219,277 @@ func (cfg *apiConfig) handlerGetRecordsAndMediaByUserID(w http.ResponseWriter, r
 		return
 	}
 
+	// Rows are ordered by medium, then by consumption
+	// Each medium gets one entry holding its latest consumption, and the history of all of them
+	mediaRecords := []MediumWithRecord{}
+	mediumIndex := make(map[pgtype.UUID]int)
+	for _, row := range recordsAndMedia {
+		i, ok := mediumIndex[row.MediaID]
+		if !ok {
+			// Convert metadata back to map
+			metadataMap, err := bytesToMap(row.Metadata)
+			if err != nil {
+				respondWithError(w, 500, "couldn't convert metadata map from database", err)
+				return
+			}
+			mediaRecords = append(mediaRecords, MediumWithRecord{
+				UserID:    row.UserID,
+				MediaID:   row.MediaID,
+				MediaType: row.MediaType,
+				Title:     row.Title,
+				Creator:   row.Creator,
+				PubDate:   row.PubDate,
+				ImageUrl:  row.ImageUrl,
+				Metadata:  metadataMap,
+			})
+			i = len(mediaRecords) - 1
+			mediumIndex[row.MediaID] = i
+		}
+
+		// The last consumption read is the latest one
+		mediumRecord := &mediaRecords[i]
+		mediumRecord.ID = row.ID
+		mediumRecord.IsFinished = row.IsFinished
+		mediumRecord.StartDate = row.StartDate
+		mediumRecord.EndDate = row.EndDate
+		mediumRecord.Duration = row.Duration.Days
+		mediumRecord.Comments = row.Comments
+		mediumRecord.Rating = row.Rating
+		mediumRecord.Status = row.Status
+		mediumRecord.AbandonReason = row.AbandonReason
+		mediumRecord.RatingAverage = &row.RatingAverage
+		mediumRecord.RatingCount = &row.RatingCount
+		mediumRecord.ConsumptionCount++
+		mediumRecord.History = append(mediumRecord.History, Record{
+			ID:            row.ID,
+			CreatedAt:     row.CreatedAt,
+			UpdatedAt:     row.UpdatedAt,
+			UserID:        row.UserID,
+			MediaID:       row.MediaID,
+			IsFinished:    row.IsFinished,
+			StartDate:     row.StartDate,
+			EndDate:       row.EndDate,
+			Duration:      row.Duration.Days,
+			Comments:      row.Comments,
+			Rating:        row.Rating,
+			Status:        row.Status,
+			AbandonReason: row.AbandonReason,
+		})
+	}
+
+	// Add user's tags and custom shelves to each medium
+	groups, err := cfg.getUserMediaGroups(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get user's tags and shelves in database", err)
+		return
+	}
+	for i := range mediaRecords {
+		groups.fill(&mediaRecords[i])
+	}
+
 	response := responseGetRecordsAndMediaByUserID{
 		MediaRecords: make(map[string][]MediumWithRecord),
 	}
+	for _, mediumRecord := range mediaRecords {
+		// Append to the correct slice in the map
+		response.MediaRecords[mediumRecord.MediaType] = append(response.MediaRecords[mediumRecord.MediaType], mediumRecord)
+	}
+
+	// Respond
+	respondWithJson(w, 200, response)
+
+}
+
+type responseSearchMediaRecords struct {
+	MediaRecords []MediumWithRecord `json:"records"`
+	NextCursor   string             `json:"next_cursor"`
+}
+
+// GET /api/media_records/search
+func (cfg *apiConfig) handlerSearchMediaRecords(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersSearchMediaRecords
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
 
-	for _, medium := range recordsAndMedia {
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Convert request parameters to query parameters
+	queryParams, err := params.toQueryRecordsParams(userID)
+	if err != nil {
+		respondWithError(w, 400, err.Error(), err)
+		return
+	}
+	err = queryParams.Validate()
+	if err != nil {
+		respondWithError(w, 400, err.Error(), err)
+		return
+	}
+
+	// Call query function
+	rows, err := cfg.db.QueryRecords(r.Context(), queryParams)
+	if err != nil {
+		respondWithError(w, 500, "couldn't search records in database", err)
+		return
+	}
+
+	groups, err := cfg.getUserMediaGroups(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get user's tags and shelves in database", err)
+		return
+	}
+
+	response := responseSearchMediaRecords{
+		MediaRecords: make([]MediumWithRecord, 0, len(rows)),
+	}
+	for _, row := range rows {
 		// Convert metadata back to map
-		metadataMap, err := bytesToMap(medium.Metadata)
+		metadataMap, err := bytesToMap(row.Metadata)
 		if err != nil {
 			respondWithError(w, 500, "couldn't convert metadata map from database", err)
 			return
 		}
-		// Create the MediumWithRecord object
-		mediumRecord := MediumWithRecord{
-			ID:         medium.ID,
-			UserID:     medium.UserID,
-			MediaID:    medium.MediaID,
-			IsFinished: medium.IsFinished,
-			StartDate:  medium.StartDate,
-			EndDate:    medium.EndDate,
-			Duration:   medium.Duration.Days,
-			Comments:   medium.Comments,
-			MediaType:  medium.MediaType,
-			Title:      medium.Title,
-			Creator:    medium.Creator,
-			PubDate:    medium.PubDate,
-			ImageUrl:   medium.ImageUrl,
-			Metadata:   metadataMap,
-		}
-
-		// Get the appropriate media type key
-		mediaType := medium.MediaType
+		response.MediaRecords = append(response.MediaRecords, MediumWithRecord{
+			ID:            row.ID,
+			UserID:        row.UserID,
+			MediaID:       row.MediaID,
+			IsFinished:    row.IsFinished,
+			StartDate:     row.StartDate,
+			EndDate:       row.EndDate,
+			Duration:      row.Duration.Days,
+			Comments:      row.Comments,
+			Rating:        row.Rating,
+			Status:        row.Status,
+			AbandonReason: row.AbandonReason,
+			MediaType:     row.MediaType,
+			Title:         row.Title,
+			Creator:       row.Creator,
+			PubDate:       row.PubDate,
+			ImageUrl:      row.ImageUrl,
+			Metadata:      metadataMap,
+		})
+		groups.fill(&response.MediaRecords[len(response.MediaRecords)-1])
+	}
 
-		// Append to the correct slice in the map
-		response.MediaRecords[mediaType] = append(response.MediaRecords[mediaType], mediumRecord)
+	// A full page means there may be more records to get
+	if len(rows) > 0 && len(rows) == queryParams.PageLimit() {
+		response.NextCursor = queryParams.CursorAfter(rows[len(rows)-1])
 	}
 
 	// Respond
 	respondWithJson(w, 200, response)
+}
+
+// Convert and check search request parameters
+func (params parametersSearchMediaRecords) toQueryRecordsParams(userID pgtype.UUID) (database.QueryRecordsParams, error) {
+	queryParams := database.QueryRecordsParams{
+		UserID:     userID,
+		MediaTypes: params.MediaTypes,
+		Status:     params.Status,
+		Creator:    params.Creator,
+		Comments:   params.Comments,
+		Tags:       params.Tags,
+		Limit:      params.Limit,
+		Cursor:     params.Cursor,
+	}
+
+	// Convert dates to pgtype.Timestamp
+	dates := []struct {
+		name   string
+		value  string
+		target *pgtype.Timestamp
+	}{
+		{"start_date_from", params.StartDateFrom, &queryParams.StartFrom},
+		{"start_date_to", params.StartDateTo, &queryParams.StartTo},
+		{"end_date_from", params.EndDateFrom, &queryParams.EndFrom},
+		{"end_date_to", params.EndDateTo, &queryParams.EndTo},
+	}
+	for _, date := range dates {
+		timestamp, err := convertDateToPgtype(date.value)
+		if err != nil {
+			return queryParams, fmt.Errorf("%s not in good format", date.name)
+		}
+		*date.target = timestamp
+	}
+
+	if params.ShelfID != "" {
+		shelfID, err := convertIdToPgtype(params.ShelfID)
+		if err != nil {
+			return queryParams, errors.New("shelf_id not in good format")
+		}
+		queryParams.ShelfID = shelfID
+	}
 
+	if params.Owned != nil {
+		queryParams.Owned = pgtype.Bool{Bool: *params.Owned, Valid: true}
+	}
+	queryParams.CopyFormat = params.CopyFormat
+	queryParams.CopyCondition = params.CopyCondition
+	queryParams.CopyLanguage = params.CopyLanguage
+	queryParams.Platform = params.Platform
+	queryParams.Completion = params.Completion
+
+	if params.MinDuration != nil {
+		queryParams.MinDuration = pgtype.Int4{Int32: *params.MinDuration, Valid: true}
+	}
+	if params.MaxDuration != nil {
+		queryParams.MaxDuration = pgtype.Int4{Int32: *params.MaxDuration, Valid: true}
+	}
+
+	// Convert ratings to the stored 0-100 scale
+	if params.MinRating != nil {
+		rating, err := normalizeRating(*params.MinRating, params.RatingScale)
+		if err != nil {
+			return queryParams, fmt.Errorf("min_rating: %w", err)
+		}
+		queryParams.MinRating = rating
+	}
+	if params.MaxRating != nil {
+		rating, err := normalizeRating(*params.MaxRating, params.RatingScale)
+		if err != nil {
+			return queryParams, fmt.Errorf("max_rating: %w", err)
+		}
+		queryParams.MaxRating = rating
+	}
+
+	for _, filter := range params.Metadata {
+		// Values can be given as JSON strings, numbers or booleans
+		var value string
+		switch v := filter.Value.(type) {
+		case string:
+			value = v
+		case float64:
+			value = strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			value = strconv.FormatBool(v)
+		default:
+			return queryParams, fmt.Errorf("metadata filter on %q: value must be a string, a number or a boolean", filter.Key)
+		}
+		queryParams.Metadata = append(queryParams.Metadata, database.MetadataFilter{
+			Key:   filter.Key,
+			Op:    filter.Op,
+			Value: value,
+		})
+	}
+
+	for _, sort := range params.Sort {
+		switch sort.Order {
+		case "", "asc", "desc":
+		default:
+			return queryParams, fmt.Errorf("sort order on %q must be asc or desc", sort.Key)
+		}
+		queryParams.Sort = append(queryParams.Sort, database.RecordsSort{
+			Key:  sort.Key,
+			Desc: sort.Order == "desc",
+		})
+	}
+
+	return queryParams, nil
 }
 
 // PUT /api/media
@@ -267,6 +510,21 @@ func (cfg *apiConfig) handlerUpdateMedium(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	// Only the medium's creator or an admin can edit it
+	current, ok := cfg.getMedium(w, r, mediumID)
+	if !ok {
+		return
+	}
+	allowed, err := cfg.canEditMedium(r, current)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get user in database", err)
+		return
+	}
+	if !allowed {
+		respondWithError(w, 403, "only the medium's creator or an admin can edit it", errors.New("user is not allowed to edit medium"))
+		return
+	}
+
 	// Convert metadata map to []byte
 	metadataBytes, err := mapToBytes(params.Metadata)
 	if err != nil {
@@ -274,14 +532,25 @@ func (cfg *apiConfig) handlerUpdateMedium(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	// External IDs are kept when not given
+	externalIDsBytes := current.ExternalIds
+	if params.ExternalIDs != nil {
+		externalIDsBytes, err = externalIDsToBytes(params.ExternalIDs)
+		if err != nil {
+			respondWithError(w, 400, err.Error(), err)
+			return
+		}
+	}
+
 	// Call query function
 	medium, err := cfg.db.UpdateMedium(r.Context(), database.UpdateMediumParams{
-		ID:       mediumID,
-		Title:    params.Title,
-		Creator:  params.Creator,
-		PubDate:  params.PubDate,
-		ImageUrl: params.ImageUrl,
-		Metadata: metadataBytes,
+		ID:          current.ID,
+		Title:       params.Title,
+		Creator:     params.Creator,
+		PubDate:     params.PubDate,
+		ImageUrl:    params.ImageUrl,
+		Metadata:    metadataBytes,
+		ExternalIds: externalIDsBytes,
 	})
 
 	if err != nil {
@@ -292,34 +561,332 @@ func (cfg *apiConfig) handlerUpdateMedium(w http.ResponseWriter, r *http.Request
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
 			// This is a unique constraint violation
-			respondWithError(w, 409, "A medium with same title already exists in database", err)
+			respondWithError(w, 409, mediumConflictMessage(pgErr), err)
 			return
 		}
 		respondWithError(w, 500, "couldn't udpate medium by given ID", err)
 		return
 	}
 
-	// Convert metadata back to map
-	metadataMap, err := bytesToMap(medium.Metadata)
+	// Convert medium for response
+	responseMedium, err := toResponseMedium(medium)
+	if err != nil {
+		respondWithError(w, 500, "couldn't convert medium from database", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 200, responseMedium)
+}
+
+type refreshChange struct {
+	Field   string      `json:"field"`
+	Current interface{} `json:"current"`
+	Fetched interface{} `json:"fetched"`
+}
+
+type responseRefreshMedium struct {
+	Medium   Medium          `json:"medium"`
+	Provider string          `json:"provider"`
+	Changes  []refreshChange `json:"changes"`
+	Applied  bool            `json:"applied"`
+}
+
+// POST /api/media/{id}/refresh
+func (cfg *apiConfig) handlerRefreshMedium(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersRefreshMedium
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Convert path's medium ID to pgtype.UUID
+	mediumID, err := convertIdToPgtype(r.PathValue("id"))
+	if err != nil {
+		respondWithError(w, 400, "medium id not in good format", err)
+		return
+	}
+
+	current, ok := cfg.getMedium(w, r, mediumID)
+	if !ok {
+		return
+	}
+
+	// Only the medium's creator or an admin can apply changes
+	if params.Apply {
+		allowed, err := cfg.canEditMedium(r, current)
+		if err != nil {
+			respondWithError(w, 500, "couldn't get user in database", err)
+			return
+		}
+		if !allowed {
+			respondWithError(w, 403, "only the medium's creator or an admin can edit it", errors.New("user is not allowed to edit medium"))
+			return
+		}
+	}
+
+	// Refresh from the first linked provider, in providers' order
+	externalIDs, err := database.DecodeExternalIDs(current.ExternalIds)
+	if err != nil {
+		respondWithError(w, 500, "couldn't convert medium from database", err)
+		return
+	}
+	provider := ""
+	for _, p := range database.ExternalIDProviders {
+		if externalIDs[p] != "" && cfg.mediumProviders[p] != nil {
+			provider = p
+			break
+		}
+	}
+	if provider == "" {
+		respondWithError(w, 400, "medium is not linked to any provider", errors.New("medium has no external id"))
+		return
+	}
+
+	fetched, err := cfg.mediumProviders[provider](r.Context(), externalIDs[provider])
+	if err != nil {
+		if errors.Is(err, errProviderNotFound) {
+			respondWithError(w, 404, fmt.Sprintf("No item with medium's %s ID on provider", provider), err)
+			return
+		}
+		if errors.Is(err, errProviderNotConfigured) {
+			respondWithError(w, 503, fmt.Sprintf("%s API key is not configured on this server", provider), err)
+			return
+		}
+		respondWithError(w, 500, "failed to fetch data", err)
+		return
+	}
+
+	// Compare fetched details with current ones
+	updated, changes, err := diffProviderMedium(current, fetched)
 	if err != nil {
-		respondWithError(w, 500, "couldn't convert metadata map from database", err)
+		respondWithError(w, 500, "couldn't compare medium with fetched details", err)
+		return
+	}
+
+	medium := current
+	if params.Apply && len(changes) > 0 {
+		medium, err = cfg.db.UpdateMedium(r.Context(), updated)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				// This is a unique constraint violation
+				respondWithError(w, 409, mediumConflictMessage(pgErr), err)
+				return
+			}
+			respondWithError(w, 500, "couldn't udpate medium by given ID", err)
+			return
+		}
+	}
+
+	// Convert medium for response
+	responseMedium, err := toResponseMedium(medium)
+	if err != nil {
+		respondWithError(w, 500, "couldn't convert medium from database", err)
 		return
 	}
 
 	// Respond
-	respondWithJson(w, 200, Medium{
-		ID:        medium.ID,
-		MediaType: medium.MediaType,
-		CreatedAt: medium.CreatedAt,
-		UpdatedAt: medium.UpdatedAt,
-		Title:     medium.Title,
-		Creator:   medium.Creator,
-		PubDate:   medium.PubDate,
-		ImageUrl:  medium.ImageUrl,
-		Metadata:  metadataMap,
+	respondWithJson(w, 200, responseRefreshMedium{
+		Medium:   responseMedium,
+		Provider: provider,
+		Changes:  changes,
+		Applied:  params.Apply && len(changes) > 0,
 	})
 }
 
+// List fields where fetched details differ from the medium's, and build the update applying them
+// Empty fetched values are skipped, and metadata keys the provider doesn't give are kept
+func diffProviderMedium(current database.Medium, fetched providerMedium) (database.UpdateMediumParams, []refreshChange, error) {
+	updated := database.UpdateMediumParams{
+		ID:          current.ID,
+		Title:       current.Title,
+		Creator:     current.Creator,
+		PubDate:     current.PubDate,
+		ImageUrl:    current.ImageUrl,
+		Metadata:    current.Metadata,
+		ExternalIds: current.ExternalIds,
+	}
+	changes := []refreshChange{}
+
+	for _, field := range []struct {
+		name    string
+		current *string
+		fetched string
+	}{
+		{"title", &updated.Title, fetched.Title},
+		{"creator", &updated.Creator, fetched.Creator},
+		{"pub_date", &updated.PubDate, fetched.PubDate},
+		{"image_url", &updated.ImageUrl, fetched.ImageUrl},
+	} {
+		if field.fetched == "" || field.fetched == *field.current {
+			continue
+		}
+		changes = append(changes, refreshChange{Field: field.name, Current: *field.current, Fetched: field.fetched})
+		*field.current = field.fetched
+	}
+
+	// Round trip fetched metadata through JSON, so values compare with stored ones
+	currentMetadata, err := bytesToMap(current.Metadata)
+	if err != nil {
+		return database.UpdateMediumParams{}, nil, err
+	}
+	if currentMetadata == nil {
+		currentMetadata = map[string]interface{}{}
+	}
+	fetchedBytes, err := mapToBytes(fetched.Metadata)
+	if err != nil {
+		return database.UpdateMediumParams{}, nil, err
+	}
+	fetchedMetadata, err := bytesToMap(fetchedBytes)
+	if err != nil {
+		return database.UpdateMediumParams{}, nil, err
+	}
+
+	keys := []string{}
+	for key := range fetchedMetadata {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	metadataChanged := false
+	for _, key := range keys {
+		value := fetchedMetadata[key]
+		if isEmptyValue(value) || reflect.DeepEqual(value, currentMetadata[key]) {
+			continue
+		}
+		changes = append(changes, refreshChange{Field: "metadata." + key, Current: currentMetadata[key], Fetched: value})
+		currentMetadata[key] = value
+		metadataChanged = true
+	}
+	if metadataChanged {
+		updated.Metadata, err = mapToBytes(currentMetadata)
+		if err != nil {
+			return database.UpdateMediumParams{}, nil, err
+		}
+	}
+
+	return updated, changes, nil
+}
+
+func isEmptyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case float64:
+		return v == 0
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// Get a medium by ID, respond with an error if there is none
+// IDs of merged media resolve to the medium they were merged into
+func (cfg *apiConfig) getMedium(w http.ResponseWriter, r *http.Request, mediumID pgtype.UUID) (database.Medium, bool) {
+	mediumID, err := cfg.resolveMediumID(r.Context(), mediumID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get medium redirect in database", err)
+		return database.Medium{}, false
+	}
+	medium, err := cfg.db.GetMediumByID(r.Context(), mediumID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondWithError(w, 404, "No medium with given ID in database", err)
+			return database.Medium{}, false
+		}
+		respondWithError(w, 500, "couldn't get medium by given ID", err)
+		return database.Medium{}, false
+	}
+	return medium, true
+}
+
+// Follow the redirect left by a merge, if any
+func (cfg *apiConfig) resolveMediumID(ctx context.Context, mediumID pgtype.UUID) (pgtype.UUID, error) {
+	redirect, err := cfg.db.GetMediumRedirect(ctx, mediumID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return mediumID, nil
+		}
+		return pgtype.UUID{}, err
+	}
+	return redirect, nil
+}
+
+// Check if the logged user is the medium's creator or an admin
+func (cfg *apiConfig) canEditMedium(r *http.Request, medium database.Medium) (bool, error) {
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+	if medium.CreatedBy.Valid && medium.CreatedBy == userID {
+		return true, nil
+	}
+	user, err := cfg.db.GetUserByID(r.Context(), userID)
+	if err != nil {
+		return false, err
+	}
+	return user.Role == roleAdmin, nil
+}
+
+// Explain which medium identity a unique violation is about
+func mediumConflictMessage(pgErr *pgconn.PgError) string {
+	for _, provider := range database.ExternalIDProviders {
+		if pgErr.ConstraintName == fmt.Sprintf("media_external_ids_%s_key", provider) {
+			return fmt.Sprintf("A medium with same %s ID already exists in database", provider)
+		}
+	}
+	return "A medium with same title, creator and publication year already exists in database"
+}
+
+// Check external IDs' providers and convert them to []byte, IDs left empty are dropped
+func externalIDsToBytes(externalIDs map[string]string) ([]byte, error) {
+	ids := make(map[string]string, len(externalIDs))
+	for provider, id := range externalIDs {
+		if !database.IsExternalIDProvider(provider) {
+			return nil, fmt.Errorf("unknown external id provider %q, must be one of %s", provider, strings.Join(database.ExternalIDProviders, ", "))
+		}
+		if id != "" {
+			ids[provider] = id
+		}
+	}
+	return json.Marshal(ids)
+}
+
+// Convert a medium from database for responses
+func toResponseMedium(medium database.Medium) (Medium, error) {
+	metadataMap, err := bytesToMap(medium.Metadata)
+	if err != nil {
+		return Medium{}, err
+	}
+	externalIDs, err := database.DecodeExternalIDs(medium.ExternalIds)
+	if err != nil {
+		return Medium{}, err
+	}
+	return Medium{
+		ID:          medium.ID,
+		MediaType:   medium.MediaType,
+		CreatedAt:   medium.CreatedAt,
+		UpdatedAt:   medium.UpdatedAt,
+		Title:       medium.Title,
+		Creator:     medium.Creator,
+		PubDate:     medium.PubDate,
+		ImageUrl:    medium.ImageUrl,
+		Metadata:    metadataMap,
+		CreatedBy:   medium.CreatedBy,
+		ExternalIDs: externalIDs,
+	}, nil
+}
+
+type responseDeleteMedium struct {
+	MediumDeleted bool `json:"medium_deleted"`
+	RecordDeleted bool `json:"record_deleted"`
+}
+
 // DELETE /api/media
 func (cfg *apiConfig) handlerDeleteMedium(w http.ResponseWriter, r *http.Request) {
 
@@ -338,18 +905,48 @@ func (cfg *apiConfig) handlerDeleteMedium(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	// Call query function
-	count, err := cfg.db.DeleteMedium(r.Context(), mediumID)
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	medium, ok := cfg.getMedium(w, r, mediumID)
+	if !ok {
+		return
+	}
+	mediumID = medium.ID
+	allowed, err := cfg.canEditMedium(r, medium)
 	if err != nil {
+		respondWithError(w, 500, "couldn't get user in database", err)
+		return
+	}
+
+	// User's own records are always removed from its shelf
+	// The medium itself is only deleted by its creator or an admin, once no other user holds a record for it
+	result, err := cfg.db.DeleteMediumForUser(r.Context(), database.DeleteMediumForUserParams{
+		MediumID:        mediumID,
+		UserID:          userID,
+		CanDeleteMedium: allowed,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondWithError(w, 404, "No medium with given ID in database", err)
+			return
+		}
 		respondWithError(w, 500, "couldn't delete medium with id on database", err)
 		return
 	}
-	if count == 0 {
-		respondWithError(w, 404, "No medium with given ID in database", nil)
+	response := responseDeleteMedium{
+		MediumDeleted: result.MediumDeleted,
+		RecordDeleted: result.DeletedRecords > 0,
+	}
+	if !response.MediumDeleted && !response.RecordDeleted {
+		if result.OtherUsersRecords > 0 {
+			respondWithError(w, 403, "this medium is still in other users' shelves, it can't be deleted", errors.New("medium is still referenced by other users' records"))
+			return
+		}
+		respondWithError(w, 403, "only the medium's creator or an admin can delete it", errors.New("user is not allowed to delete medium"))
 		return
 	}
 
 	// Respond
-	w.WriteHeader(200)
-
+	respondWithJson(w, 200, response)
 }
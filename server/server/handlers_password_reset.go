@@ -44,14 +44,14 @@ func (cfg *apiConfig) handlerPasswordResetRequest(w http.ResponseWriter, r *http
 		return
 	}
 
-	// Generate reset token (valid for 6 hour)
+	// Generate reset token (valid for resetTokenTTL, 6 hours by default)
 	token := auth.GenerateResetToken()
 	if token == "" {
 		respondWithError(w, 500, "couldn't generate a reset token", errors.New("error with GenerateResetToken(): returning an empty string"))
 		return
 	}
 	expiry := pgtype.Timestamp{
-		Time:  time.Now().Add(6 * time.Hour),
+		Time:  time.Now().Add(cfg.resetTokenTTL),
 		Valid: true,
 	}
 
@@ -180,6 +180,7 @@ func (cfg *apiConfig) handlerResetPassword(w http.ResponseWriter, r *http.Reques
 			UpdatedAt: user.UpdatedAt,
 			Username:  user.Username,
 			Email:     user.Email,
+			Role:      user.Role,
 		},
 	})
 
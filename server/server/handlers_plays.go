@@ -0,0 +1,576 @@
+package server
+
+import (
+	"cmp"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Build a play response, title and players are given by caller
+func playResponse(play database.Play, title string, players []database.PlaysPlayer) Play {
+	response := Play{
+		ID:              play.ID,
+		CreatedAt:       play.CreatedAt,
+		UpdatedAt:       play.UpdatedAt,
+		MediumID:        play.MediaID,
+		Title:           title,
+		PlayedAt:        play.PlayedAt,
+		DurationMinutes: play.DurationMinutes,
+		Location:        play.Location,
+		Expansions:      play.Expansions,
+		Players:         make([]PlayPlayer, 0, len(players)),
+	}
+	if response.Expansions == nil {
+		response.Expansions = []string{}
+	}
+	for _, player := range players {
+		response.Players = append(response.Players, PlayPlayer{
+			PlayerID:   player.PlayerID,
+			PlayerName: player.PlayerName,
+			Score:      player.Score,
+			IsWinner:   player.IsWinner,
+		})
+	}
+	return response
+}
+
+// Check a play's details and turn them into the store's parameters, players still have to be resolved.
+// An error is returned when the details aren't valid, to be sent back as it is
+func (params parametersPlayDetails) toSavePlayParams() (database.SavePlayParams, error) {
+	// A play happened now by default
+	playedAt, err := convertDateToPgtype(params.PlayedAt)
+	if err != nil {
+		return database.SavePlayParams{}, errors.New("played_at not in good format")
+	}
+	if !playedAt.Valid {
+		playedAt = timestampNow()
+	}
+
+	var duration pgtype.Int4
+	if params.DurationMinutes != nil {
+		if *params.DurationMinutes <= 0 {
+			return database.SavePlayParams{}, errors.New("duration_minutes must be positive")
+		}
+		duration = pgtype.Int4{Int32: *params.DurationMinutes, Valid: true}
+	}
+
+	// Blank expansions are dropped, the same one used twice is kept once
+	expansions := []string{}
+	for _, expansion := range params.Expansions {
+		expansion = strings.TrimSpace(expansion)
+		if expansion != "" && !slices.ContainsFunc(expansions, func(used string) bool { return strings.EqualFold(used, expansion) }) {
+			expansions = append(expansions, expansion)
+		}
+	}
+
+	for _, player := range params.Players {
+		if (player.Username == "") == (strings.TrimSpace(player.Name) == "") {
+			return database.SavePlayParams{}, errors.New("each player needs either a username or a name")
+		}
+	}
+
+	return database.SavePlayParams{
+		PlayedAt:        playedAt,
+		DurationMinutes: duration,
+		Location:        strings.TrimSpace(params.Location),
+		Expansions:      expansions,
+	}, nil
+}
+
+// When no winner is given, the players with the best score won, if scores were kept
+func defaultWinners(players []database.SavePlayPlayerParams) {
+	best := pgtype.Int4{}
+	for _, player := range players {
+		if player.IsWinner {
+			return
+		}
+		if player.Score.Valid && (!best.Valid || player.Score.Int32 > best.Int32) {
+			best = player.Score
+		}
+	}
+	if !best.Valid {
+		return
+	}
+	for i, player := range players {
+		players[i].IsWinner = player.Score == best
+	}
+}
+
+// Resolve the players of a play, Kallaxy users by their username which is kept as their name, responding with an error if one can't be found
+func (cfg *apiConfig) resolvePlayers(w http.ResponseWriter, r *http.Request, params []parametersPlayer) ([]database.SavePlayPlayerParams, bool) {
+	players := make([]database.SavePlayPlayerParams, 0, len(params))
+	for _, param := range params {
+		player := database.SavePlayPlayerParams{
+			PlayerName: strings.TrimSpace(param.Name),
+			IsWinner:   param.IsWinner,
+		}
+		if param.Score != nil {
+			player.Score = pgtype.Int4{Int32: *param.Score, Valid: true}
+		}
+		if param.Username != "" {
+			user, err := cfg.db.GetUserByUsername(r.Context(), param.Username)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					respondWithError(w, 404, "no user found with given username", err)
+					return nil, false
+				}
+				respondWithError(w, 500, "couldn't get user in database", err)
+				return nil, false
+			}
+			player.PlayerID = user.ID
+			player.PlayerName = user.Username
+		}
+
+		// Someone takes one seat only
+		if slices.ContainsFunc(players, func(seated database.SavePlayPlayerParams) bool {
+			if player.PlayerID.Valid || seated.PlayerID.Valid {
+				return player.PlayerID == seated.PlayerID
+			}
+			return strings.EqualFold(player.PlayerName, seated.PlayerName)
+		}) {
+			respondWithError(w, 400, "a player can't take two seats in the same play", errors.New("duplicate player in play"))
+			return nil, false
+		}
+		players = append(players, player)
+	}
+	defaultWinners(players)
+	return players, true
+}
+
+// POST /api/plays
+func (cfg *apiConfig) handlerCreatePlay(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersCreatePlay
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Check if all required fields are provided
+	if params.MediumID == "" {
+		respondWithError(w, 400, "medium_id must be provided", errors.New("invalid play request body"))
+		return
+	}
+	saveParams, err := params.toSavePlayParams()
+	if err != nil {
+		respondWithError(w, 400, err.Error(), err)
+		return
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// A user can only log plays of a boardgame on their shelf
+	mediumID, err := convertIdToPgtype(params.MediumID)
+	if err != nil {
+		respondWithError(w, 400, "medium_id not in good format", err)
+		return
+	}
+	medium, ok := cfg.getMedium(w, r, mediumID)
+	if !ok {
+		return
+	}
+	if medium.MediaType != "boardgame" {
+		respondWithError(w, 400, "plays can only be logged for boardgames", errors.New("play of a medium not a boardgame"))
+		return
+	}
+	count, err := cfg.db.CountUserRecordsByMediumID(r.Context(), database.CountUserRecordsByMediumIDParams{
+		MediaID: medium.ID,
+		UserID:  userID,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't get user's records in database", err)
+		return
+	}
+	if count == 0 {
+		respondWithError(w, 404, "no medium with given ID in user's shelf", errors.New("medium not in user's shelf"))
+		return
+	}
+
+	saveParams.Players, ok = cfg.resolvePlayers(w, r, params.Players)
+	if !ok {
+		return
+	}
+
+	// Call query function
+	saveParams.UserID = userID
+	saveParams.MediaID = medium.ID
+	result, err := cfg.db.SavePlay(r.Context(), saveParams)
+	if err != nil {
+		respondWithError(w, 500, "couldn't create play in database", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 201, playResponse(result.Play, medium.Title, result.Players))
+}
+
+type responseGetPlays struct {
+	Plays []Play `json:"plays"`
+}
+
+// Logged user's plays with their players, of a single boardgame if given, responding with an error if they can't be fetched
+func (cfg *apiConfig) getUserPlays(w http.ResponseWriter, r *http.Request, stringMediumID string) ([]database.GetPlaysByUserIDRow, map[pgtype.UUID][]database.PlaysPlayer, bool) {
+	var mediumID pgtype.UUID
+	if stringMediumID != "" {
+		id, err := convertIdToPgtype(stringMediumID)
+		if err != nil {
+			respondWithError(w, 400, "medium_id not in good format", err)
+			return nil, nil, false
+		}
+		mediumID, err = cfg.resolveMediumID(r.Context(), id)
+		if err != nil {
+			respondWithError(w, 500, "couldn't get medium redirect in database", err)
+			return nil, nil, false
+		}
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	plays, err := cfg.db.GetPlaysByUserID(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get plays in database", err)
+		return nil, nil, false
+	}
+	if mediumID.Valid {
+		plays = slices.DeleteFunc(plays, func(play database.GetPlaysByUserIDRow) bool {
+			return play.MediaID != mediumID
+		})
+	}
+
+	players, err := cfg.db.GetPlayersByUserID(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get plays' players in database", err)
+		return nil, nil, false
+	}
+	playersByPlay := make(map[pgtype.UUID][]database.PlaysPlayer, len(plays))
+	for _, player := range players {
+		playersByPlay[player.PlayID] = append(playersByPlay[player.PlayID], player)
+	}
+	return plays, playersByPlay, true
+}
+
+// GET /api/plays
+func (cfg *apiConfig) handlerGetPlays(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersGetPlays
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	plays, playersByPlay, ok := cfg.getUserPlays(w, r, params.MediumID)
+	if !ok {
+		return
+	}
+
+	response := responseGetPlays{
+		Plays: make([]Play, 0, len(plays)),
+	}
+	for _, row := range plays {
+		play := database.Play{
+			ID:              row.ID,
+			CreatedAt:       row.CreatedAt,
+			UpdatedAt:       row.UpdatedAt,
+			UserID:          row.UserID,
+			MediaID:         row.MediaID,
+			PlayedAt:        row.PlayedAt,
+			DurationMinutes: row.DurationMinutes,
+			Location:        row.Location,
+			Expansions:      row.Expansions,
+		}
+		response.Plays = append(response.Plays, playResponse(play, row.Title, playersByPlay[row.ID]))
+	}
+
+	// Respond
+	respondWithJson(w, 200, response)
+}
+
+// PUT /api/plays
+func (cfg *apiConfig) handlerUpdatePlay(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersUpdatePlay
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Convert PlayID to pgtype.UUID
+	playID, err := convertIdToPgtype(params.PlayID)
+	if err != nil {
+		respondWithError(w, 400, "play_id not in good format", err)
+		return
+	}
+	saveParams, err := params.toSavePlayParams()
+	if err != nil {
+		respondWithError(w, 400, err.Error(), err)
+		return
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	play, err := cfg.db.GetUserPlayByID(r.Context(), database.GetUserPlayByIDParams{
+		ID:     playID,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondWithError(w, 404, "no play found with given ID for user", err)
+			return
+		}
+		respondWithError(w, 500, "couldn't get play in database", err)
+		return
+	}
+
+	var ok bool
+	saveParams.Players, ok = cfg.resolvePlayers(w, r, params.Players)
+	if !ok {
+		return
+	}
+
+	// Call query function, the given players replace the play's previous ones
+	saveParams.ID = play.ID
+	saveParams.UserID = userID
+	saveParams.MediaID = play.MediaID
+	result, err := cfg.db.SavePlay(r.Context(), saveParams)
+	if err != nil {
+		respondWithError(w, 500, "couldn't update play in database", err)
+		return
+	}
+
+	medium, err := cfg.db.GetMediumByID(r.Context(), play.MediaID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get play's medium in database", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 200, playResponse(result.Play, medium.Title, result.Players))
+}
+
+// DELETE /api/plays
+func (cfg *apiConfig) handlerDeletePlay(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersPlay
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Convert PlayID to pgtype.UUID
+	playID, err := convertIdToPgtype(params.PlayID)
+	if err != nil {
+		respondWithError(w, 400, "play_id not in good format", err)
+		return
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Call query function
+	count, err := cfg.db.DeletePlay(r.Context(), database.DeletePlayParams{
+		ID:     playID,
+		UserID: userID,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't delete play in database", err)
+		return
+	}
+	if count == 0 {
+		respondWithError(w, 404, "no play found with given ID for user", nil)
+		return
+	}
+
+	// Respond
+	w.WriteHeader(200)
+}
+
+// Count plays per boardgame, most played first
+func gamePlayCounts(plays []database.GetPlaysByUserIDRow) []GamePlays {
+	games := []GamePlays{}
+	for _, play := range plays {
+		i := slices.IndexFunc(games, func(game GamePlays) bool { return game.MediumID == play.MediaID })
+		if i == -1 {
+			games = append(games, GamePlays{MediumID: play.MediaID, Title: play.Title})
+			i = len(games) - 1
+		}
+		games[i].PlaysCount++
+		if play.DurationMinutes.Valid {
+			games[i].TotalMinutes += int64(play.DurationMinutes.Int32)
+		}
+		if !games[i].LastPlayedAt.Valid || play.PlayedAt.Time.After(games[i].LastPlayedAt.Time) {
+			games[i].LastPlayedAt = play.PlayedAt
+		}
+	}
+	slices.SortStableFunc(games, func(a, b GamePlays) int {
+		if c := cmp.Compare(b.PlaysCount, a.PlaysCount); c != 0 {
+			return c
+		}
+		return cmp.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	})
+	return games
+}
+
+// Largest h such that h games were played at least h times each, counts being sorted from most played
+func hIndex(sortedCounts []int64) int64 {
+	var h int64
+	for _, count := range sortedCounts {
+		if count <= h {
+			break
+		}
+		h++
+	}
+	return h
+}
+
+// Plays still needed to reach an H-index of h+1: the h+1 most played games must reach h+1 plays each
+func playsToNextHIndex(sortedCounts []int64, h int64) int64 {
+	var missing int64
+	for i := int64(0); i <= h; i++ {
+		var count int64
+		if i < int64(len(sortedCounts)) {
+			count = sortedCounts[i]
+		}
+		missing += max(0, h+1-count)
+	}
+	return missing
+}
+
+type responseGetPlayCounts struct {
+	PlaysCount   int64       `json:"plays_count"`
+	TotalMinutes int64       `json:"total_minutes"`
+	Games        []GamePlays `json:"games"`
+}
+
+// GET /api/plays/counts
+func (cfg *apiConfig) handlerGetPlayCounts(w http.ResponseWriter, r *http.Request) {
+
+	plays, _, ok := cfg.getUserPlays(w, r, "")
+	if !ok {
+		return
+	}
+
+	response := responseGetPlayCounts{
+		PlaysCount: int64(len(plays)),
+		Games:      gamePlayCounts(plays),
+	}
+	for _, game := range response.Games {
+		response.TotalMinutes += game.TotalMinutes
+	}
+
+	// Respond
+	respondWithJson(w, 200, response)
+}
+
+// Wins of every player in the given plays, best win rate first.
+// Kallaxy users are told apart by their ID, guests by their name
+func playerWinRates(plays []database.GetPlaysByUserIDRow, playersByPlay map[pgtype.UUID][]database.PlaysPlayer) []PlayerWinRate {
+	rates := []PlayerWinRate{}
+	for _, play := range plays {
+		for _, player := range playersByPlay[play.ID] {
+			i := slices.IndexFunc(rates, func(rate PlayerWinRate) bool {
+				if player.PlayerID.Valid || rate.PlayerID.Valid {
+					return player.PlayerID == rate.PlayerID
+				}
+				return strings.EqualFold(player.PlayerName, rate.PlayerName)
+			})
+			if i == -1 {
+				rates = append(rates, PlayerWinRate{PlayerID: player.PlayerID, PlayerName: player.PlayerName})
+				i = len(rates) - 1
+			}
+			rates[i].PlaysCount++
+			if player.IsWinner {
+				rates[i].WinsCount++
+			}
+		}
+	}
+	for i, rate := range rates {
+		rates[i].WinRate = float64(rate.WinsCount) / float64(rate.PlaysCount)
+	}
+	slices.SortStableFunc(rates, func(a, b PlayerWinRate) int {
+		if c := cmp.Compare(b.WinRate, a.WinRate); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.PlaysCount, a.PlaysCount); c != 0 {
+			return c
+		}
+		return cmp.Compare(strings.ToLower(a.PlayerName), strings.ToLower(b.PlayerName))
+	})
+	return rates
+}
+
+type responseGetPlayerWinRates struct {
+	PlaysCount int64           `json:"plays_count"`
+	Players    []PlayerWinRate `json:"players"`
+}
+
+// GET /api/plays/win_rates
+func (cfg *apiConfig) handlerGetPlayerWinRates(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersGetPlays
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	plays, playersByPlay, ok := cfg.getUserPlays(w, r, params.MediumID)
+	if !ok {
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 200, responseGetPlayerWinRates{
+		PlaysCount: int64(len(plays)),
+		Players:    playerWinRates(plays, playersByPlay),
+	})
+}
+
+type responseGetPlaysHIndex struct {
+	HIndex      int64 `json:"h_index"`
+	PlaysToNext int64 `json:"plays_to_next"`
+	GamesCount  int64 `json:"games_count"`
+	PlaysCount  int64 `json:"plays_count"`
+}
+
+// GET /api/plays/h_index
+func (cfg *apiConfig) handlerGetPlaysHIndex(w http.ResponseWriter, r *http.Request) {
+
+	plays, _, ok := cfg.getUserPlays(w, r, "")
+	if !ok {
+		return
+	}
+
+	games := gamePlayCounts(plays)
+	counts := make([]int64, 0, len(games))
+	for _, game := range games {
+		counts = append(counts, game.PlaysCount)
+	}
+	h := hIndex(counts)
+
+	// Respond
+	respondWithJson(w, 200, responseGetPlaysHIndex{
+		HIndex:      h,
+		PlaysToNext: playsToNextHIndex(counts, h),
+		GamesCount:  int64(len(games)),
+		PlaysCount:  int64(len(plays)),
+	})
+}
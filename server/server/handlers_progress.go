@@ -0,0 +1,227 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Progress fields a record can log, by medium's type
+// Series' progress is told by their watched episodes, and videogames' hours played are kept with their other play details
+var progressFieldsByMediaType = map[string][]string{
+	"book":      {"pages", "percent"},
+	"videogame": {"percent"},
+}
+
+// Metadata keys holding the whole medium's pages or episodes, by medium's type
+var progressTotalKeys = map[string][]string{
+	"book":   {"page_count", "number_of_pages"},
+	"series": {"number_of_episodes"},
+}
+
+// Check a progress update against medium's type and record's dates, and prepare its row
+// Total falls back on medium's metadata, so the logged completion doesn't change if metadata does
+func checkRecordProgress(params parametersRecordProgress, record database.UsersMediaRecord, medium database.Medium) (database.CreateRecordProgressParams, error) {
+	if medium.MediaType == "series" {
+		return database.CreateRecordProgressParams{}, errors.New("series' progress is told by their watched episodes, see PUT /api/records/episodes")
+	}
+	allowed, ok := progressFieldsByMediaType[medium.MediaType]
+	if !ok {
+		return database.CreateRecordProgressParams{}, fmt.Errorf("progress isn't tracked for media of type %s", medium.MediaType)
+	}
+	given := map[string]bool{
+		"pages":   params.Pages != nil,
+		"percent": params.Percent != nil,
+	}
+	count := 0
+	for field, isGiven := range given {
+		if !isGiven {
+			continue
+		}
+		if !slices.Contains(allowed, field) {
+			return database.CreateRecordProgressParams{}, fmt.Errorf("%s progress can't be logged for media of type %s", field, medium.MediaType)
+		}
+		count++
+	}
+	if count == 0 {
+		return database.CreateRecordProgressParams{}, fmt.Errorf("progress needs one of %v for media of type %s", allowed, medium.MediaType)
+	}
+
+	entry := database.CreateRecordProgressParams{RecordID: record.ID}
+	if params.Total != nil {
+		if *params.Total <= 0 {
+			return database.CreateRecordProgressParams{}, errors.New("total must be positive")
+		}
+		entry.Total = pgtype.Int4{Int32: *params.Total, Valid: true}
+	} else {
+		entry.Total = metadataProgressTotal(medium)
+	}
+	if params.Pages != nil {
+		if *params.Pages < 0 || (entry.Total.Valid && *params.Pages > entry.Total.Int32) {
+			return database.CreateRecordProgressParams{}, errors.New("pages must be between 0 and medium's total")
+		}
+		entry.Pages = pgtype.Int4{Int32: *params.Pages, Valid: true}
+	}
+	if params.Percent != nil {
+		if *params.Percent < 0 || *params.Percent > 100 {
+			return database.CreateRecordProgressParams{}, errors.New("percent must be between 0 and 100")
+		}
+		entry.Percent = pgtype.Float8{Float64: *params.Percent, Valid: true}
+	}
+
+	// Logged now, unless an earlier date is given
+	loggedAt, err := convertDateToPgtype(params.LoggedAt)
+	if err != nil {
+		return database.CreateRecordProgressParams{}, errors.New("logged_at not in good format")
+	}
+	if !loggedAt.Valid {
+		loggedAt = pgtype.Timestamp{Time: time.Now().UTC().Truncate(time.Microsecond), Valid: true}
+	}
+	if loggedAt.Time.After(time.Now()) {
+		return database.CreateRecordProgressParams{}, errors.New("progress can't be logged in the future")
+	}
+	entry.LoggedAt = loggedAt
+	return entry, nil
+}
+
+// Medium's pages or episodes from its metadata, null if unknown
+func metadataProgressTotal(medium database.Medium) pgtype.Int4 {
+	metadata, err := bytesToMap(medium.Metadata)
+	if err != nil {
+		return pgtype.Int4{}
+	}
+	for _, key := range progressTotalKeys[medium.MediaType] {
+		var total int64
+		switch value := metadata[key].(type) {
+		case float64:
+			total = int64(value)
+		case string:
+			total, _ = strconv.ParseInt(value, 10, 32)
+		}
+		if total > 0 && total <= math.MaxInt32 {
+			return pgtype.Int4{Int32: int32(total), Valid: true}
+		}
+	}
+	return pgtype.Int4{}
+}
+
+// Percent of the medium consumed at a progress update, null when it can't be told
+func progressCompletion(entry database.RecordsProgress) pgtype.Float8 {
+	switch {
+	case entry.Percent.Valid:
+		return entry.Percent
+	case entry.Pages.Valid && entry.Total.Valid:
+		return pgtype.Float8{Float64: min(100, 100*float64(entry.Pages.Int32)/float64(entry.Total.Int32)), Valid: true}
+	}
+	return pgtype.Float8{}
+}
+
+// Finish dates further away are no estimate, and would overflow time.Duration at a tiny pace
+const maxFinishEstimateDays = 10 * 365
+
+// User's pace, in percent of the medium per day, from record's start (or its first update) to its latest update
+// The finish date is extrapolated from this pace, it stays null for finished or abandoned records, without progress to rely on
+// or when the pace is too slow to finish within maxFinishEstimateDays
+func estimateFinishDate(record database.UsersMediaRecord, progress []RecordProgress) (float64, pgtype.Timestamp) {
+	type point struct {
+		at         time.Time
+		completion float64
+	}
+	var points []point
+	for _, entry := range progress {
+		if entry.Completion.Valid {
+			points = append(points, point{at: entry.LoggedAt.Time, completion: entry.Completion.Float64})
+		}
+	}
+	if len(points) == 0 {
+		return 0, pgtype.Timestamp{}
+	}
+	if record.StartDate.Valid && record.StartDate.Time.Before(points[0].at) {
+		points = append([]point{{at: record.StartDate.Time}}, points...)
+	}
+
+	first, last := points[0], points[len(points)-1]
+	days := last.at.Sub(first.at).Hours() / 24
+	if days <= 0 {
+		return 0, pgtype.Timestamp{}
+	}
+	pace := (last.completion - first.completion) / days
+	if record.Status == database.RecordStatusFinished || record.Status == database.RecordStatusAbandoned || pace <= 0 {
+		return pace, pgtype.Timestamp{}
+	}
+	remainingDays := (100 - last.completion) / pace
+	if remainingDays > maxFinishEstimateDays {
+		return pace, pgtype.Timestamp{}
+	}
+	estimate := last.at.Add(time.Duration(remainingDays * 24 * float64(time.Hour))).Truncate(time.Second)
+	return pace, pgtype.Timestamp{Time: estimate, Valid: true}
+}
+
+func toRecordProgress(entry database.RecordsProgress) RecordProgress {
+	return RecordProgress{
+		ID:         entry.ID,
+		LoggedAt:   entry.LoggedAt,
+		Pages:      entry.Pages,
+		Percent:    entry.Percent,
+		Total:      entry.Total,
+		Completion: progressCompletion(entry),
+	}
+}
+
+type responseRecordProgress struct {
+	RecordID  pgtype.UUID      `json:"record_id"`
+	MediaType string           `json:"media_type"`
+	Progress  []RecordProgress `json:"progress"`
+	// Percent of the medium per day
+	Pace                float64          `json:"pace"`
+	EstimatedFinishDate pgtype.Timestamp `json:"estimated_finish_date"`
+}
+
+// GET /api/records/progress
+func (cfg *apiConfig) handlerGetRecordProgress(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersGetRecordProgress
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	record, ok := cfg.getUserRecord(w, r, params.RecordID)
+	if !ok {
+		return
+	}
+	medium, ok := cfg.getMedium(w, r, record.MediaID)
+	if !ok {
+		return
+	}
+
+	// Call query function
+	entries, err := cfg.db.GetRecordProgress(r.Context(), record.ID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get record's progress in database", err)
+		return
+	}
+
+	response := responseRecordProgress{
+		RecordID:  record.ID,
+		MediaType: medium.MediaType,
+		Progress:  []RecordProgress{},
+	}
+	for _, entry := range entries {
+		response.Progress = append(response.Progress, toRecordProgress(entry))
+	}
+	response.Pace, response.EstimatedFinishDate = estimateFinishDate(record, response.Progress)
+
+	// Respond
+	respondWithJson(w, 200, response)
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Ratings are stored on a 0-100 scale, whatever scale users rate on
+type ratingScale struct {
+	max  float64
+	step float64
+}
+
+// Rating scales users can rate on: 5 stars with halves, out of 10 or out of 100
+var ratingScales = map[string]ratingScale{
+	"5":   {max: 5, step: 0.5},
+	"10":  {max: 10, step: 1},
+	"100": {max: 100, step: 1},
+}
+
+// Scale used when none is given
+const defaultRatingScale = "100"
+
+// Convert a rating given on a scale to its stored 0-100 value
+func normalizeRating(value float64, scale string) (pgtype.Int2, error) {
+	if scale == "" {
+		scale = defaultRatingScale
+	}
+	ratingScale, ok := ratingScales[scale]
+	if !ok {
+		return pgtype.Int2{}, fmt.Errorf("unknown rating scale %q", scale)
+	}
+	if value < 0 || value > ratingScale.max {
+		return pgtype.Int2{}, fmt.Errorf("rating must be between 0 and %v", ratingScale.max)
+	}
+	steps := value / ratingScale.step
+	if math.Abs(steps-math.Round(steps)) > 1e-9 {
+		return pgtype.Int2{}, fmt.Errorf("rating must be a multiple of %v on a %s scale", ratingScale.step, scale)
+	}
+	normalized := math.Round(value * 100 / ratingScale.max)
+	return pgtype.Int2{Int16: int16(normalized), Valid: true}, nil
+}
+
+// Average of users' ratings of a medium, each user counting once
+type MediumRating struct {
+	MediaID pgtype.UUID `json:"medium_id"`
+	// On the 0-100 scale, 0 when nobody rated the medium
+	Average float64 `json:"average"`
+	Count   int64   `json:"count"`
+}
+
+// GET /api/media/rating
+func (cfg *apiConfig) handlerGetMediumRating(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersGetMediumRating
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Convert MediumID to pgtype.UUID
+	mediumID, err := convertIdToPgtype(params.MediumID)
+	if err != nil {
+		respondWithError(w, 400, "medium_id not in good format", err)
+		return
+	}
+	medium, ok := cfg.getMedium(w, r, mediumID)
+	if !ok {
+		return
+	}
+
+	// Call query function
+	rating, err := cfg.db.GetMediumRating(r.Context(), medium.ID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get medium's rating in database", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 200, MediumRating{
+		MediaID: medium.ID,
+		Average: rating.Average,
+		Count:   rating.Count,
+	})
+}
@@ -37,6 +37,11 @@ func (cfg *apiConfig) handlerCreateUserMediumRecord(w http.ResponseWriter, r *ht
 		respondWithError(w, 400, "medium_id not in good format", err)
 		return
 	}
+	mediumID, err = cfg.resolveMediumID(r.Context(), mediumID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get medium redirect in database", err)
+		return
+	}
 
 	// Convert dates to pgtype.Timestamp
 	startDate, err := convertDateToPgtype(params.StartDate)
@@ -50,17 +55,48 @@ func (cfg *apiConfig) handlerCreateUserMediumRecord(w http.ResponseWriter, r *ht
 		return
 	}
 
-	// Get user ID
-	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+	// Convert rating to the stored 0-100 scale
+	var rating pgtype.Int2
+	if params.Rating != nil {
+		rating, err = normalizeRating(*params.Rating, params.RatingScale)
+		if err != nil {
+			respondWithError(w, 400, err.Error(), err)
+			return
+		}
+	}
 
-	// Set is_finished
-	isFinished := pgtype.Bool{Valid: true}
-	if startDate.Valid && endDate.Valid {
-		isFinished.Bool = true
+	// Check status, set from dates when none is given
+	err = checkRecordStatus(params.Status)
+	if err != nil {
+		respondWithError(w, 400, err.Error(), err)
+		return
+	}
+	status := params.Status
+	if status == "" {
+		status = statusFromDates("", startDate, endDate)
 	} else {
-		isFinished.Bool = false
+		if status == database.RecordStatusPaused {
+			respondWithError(w, 400, "a record must be in progress before being paused", errors.New("record created paused"))
+			return
+		}
+		startDate, endDate, err = checkStatusDates(status, startDate, endDate)
+		if err != nil {
+			respondWithError(w, 400, err.Error(), err)
+			return
+		}
+	}
+	abandonReason, err := checkAbandonReason(status, params.AbandonReason, pgtype.Text{})
+	if err != nil {
+		respondWithError(w, 400, err.Error(), err)
+		return
 	}
 
+	// Get user ID
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Set is_finished, kept in sync with status
+	isFinished := pgtype.Bool{Bool: status == database.RecordStatusFinished, Valid: true}
+
 	// Calculate interval duration
 	interval, err := calculateDuration(startDate, endDate)
 	if err != nil {
@@ -70,13 +106,16 @@ func (cfg *apiConfig) handlerCreateUserMediumRecord(w http.ResponseWriter, r *ht
 
 	// Call query function
 	record, err := cfg.db.CreateUserMediumRecord(r.Context(), database.CreateUserMediumRecordParams{
-		UserID:     userID,
-		MediaID:    mediumID,
-		IsFinished: isFinished,
-		StartDate:  startDate,
-		EndDate:    endDate,
-		Duration:   interval,
-		Comments:   params.Comments,
+		UserID:        userID,
+		MediaID:       mediumID,
+		IsFinished:    isFinished,
+		StartDate:     startDate,
+		EndDate:       endDate,
+		Duration:      interval,
+		Comments:      params.Comments,
+		Rating:        rating,
+		Status:        status,
+		AbandonReason: abandonReason,
 	})
 	if err != nil {
 		var pgErr *pgconn.PgError
@@ -84,10 +123,6 @@ func (cfg *apiConfig) handlerCreateUserMediumRecord(w http.ResponseWriter, r *ht
 			// This is a foreign key constraint violation
 			respondWithError(w, 404, "given user id or media id didn't exist in database", err)
 			return
-		} else if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-			// This is a unique constraint violation (about couple user/media id)
-			respondWithError(w, 409, "there is already a record in database with same user-medium couple", err)
-			return
 		}
 		respondWithError(w, 500, "couldn't create new record in database", err)
 		return
@@ -96,16 +131,19 @@ func (cfg *apiConfig) handlerCreateUserMediumRecord(w http.ResponseWriter, r *ht
 	// Respond
 	respondWithJson(w, 201, response{
 		Record: Record{
-			ID:         record.ID,
-			CreatedAt:  record.CreatedAt,
-			UpdatedAt:  record.UpdatedAt,
-			UserID:     record.UserID,
-			MediaID:    record.MediaID,
-			IsFinished: record.IsFinished,
-			StartDate:  record.StartDate,
-			EndDate:    record.EndDate,
-			Duration:   record.Duration.Days,
-			Comments:   record.Comments,
+			ID:            record.ID,
+			CreatedAt:     record.CreatedAt,
+			UpdatedAt:     record.UpdatedAt,
+			UserID:        record.UserID,
+			MediaID:       record.MediaID,
+			IsFinished:    record.IsFinished,
+			StartDate:     record.StartDate,
+			EndDate:       record.EndDate,
+			Duration:      record.Duration.Days,
+			Comments:      record.Comments,
+			Rating:        record.Rating,
+			Status:        record.Status,
+			AbandonReason: record.AbandonReason,
 		},
 	})
 }
@@ -134,16 +172,19 @@ func (cfg *apiConfig) handlerGetRecordsByUserID(w http.ResponseWriter, r *http.R
 	response := responseGetRecordsByUserID{}
 	for _, record := range records {
 		response.Records = append(response.Records, Record{
-			ID:         record.ID,
-			CreatedAt:  record.CreatedAt,
-			UpdatedAt:  record.UpdatedAt,
-			UserID:     record.UserID,
-			MediaID:    record.MediaID,
-			IsFinished: record.IsFinished,
-			StartDate:  record.StartDate,
-			EndDate:    record.EndDate,
-			Duration:   record.Duration.Days,
-			Comments:   record.Comments,
+			ID:            record.ID,
+			CreatedAt:     record.CreatedAt,
+			UpdatedAt:     record.UpdatedAt,
+			UserID:        record.UserID,
+			MediaID:       record.MediaID,
+			IsFinished:    record.IsFinished,
+			StartDate:     record.StartDate,
+			EndDate:       record.EndDate,
+			Duration:      record.Duration.Days,
+			Comments:      record.Comments,
+			Rating:        record.Rating,
+			Status:        record.Status,
+			AbandonReason: record.AbandonReason,
 		})
 	}
 
@@ -151,10 +192,38 @@ func (cfg *apiConfig) handlerGetRecordsByUserID(w http.ResponseWriter, r *http.R
 	respondWithJson(w, 200, response)
 }
 
+// Get a record of the logged user, respond with an error if there is none
+// Other users' records are reported as not found, so a record ID can't be used to reach them
+func (cfg *apiConfig) getUserRecord(w http.ResponseWriter, r *http.Request, stringID string) (database.UsersMediaRecord, bool) {
+	recordID, err := convertIdToPgtype(stringID)
+	if err != nil {
+		respondWithError(w, 400, "record_id not in good format", err)
+		return database.UsersMediaRecord{}, false
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	record, err := cfg.db.GetUserRecordByID(r.Context(), database.GetUserRecordByIDParams{
+		ID:     recordID,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondWithError(w, 404, "No record found with given ID in user's shelf", err)
+			return database.UsersMediaRecord{}, false
+		}
+		respondWithError(w, 500, "couldn't get record in database", err)
+		return database.UsersMediaRecord{}, false
+	}
+	return record, true
+}
+
 // PUT /api/records
 func (cfg *apiConfig) handlerUpdateRecord(w http.ResponseWriter, r *http.Request) {
 	type response struct {
 		Record
+		Progress *RecordProgress `json:"progress,omitempty"`
 	}
 
 	// Parse data from request body
@@ -165,13 +234,6 @@ func (cfg *apiConfig) handlerUpdateRecord(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	// Convert RecordID to pgtype.UUID
-	recordID, err := convertIdToPgtype(params.RecordID)
-	if err != nil {
-		respondWithError(w, 400, "record_id not in good format", err)
-		return
-	}
-
 	// Convert dates to pgtype.Timestamp
 	paramStartDate, err := convertDateToPgtype(params.StartDate)
 	if err != nil {
@@ -184,55 +246,148 @@ func (cfg *apiConfig) handlerUpdateRecord(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	// Get already previous info from record in database
-	previousDates, err := cfg.db.GetDatesFromRecord(r.Context(), recordID)
+	// Check status, if one is given
+	err = checkRecordStatus(params.Status)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			respondWithError(w, 404, "No record found with given ID", err)
+		respondWithError(w, 400, err.Error(), err)
+		return
+	}
+
+	// Get already previous info from user's record in database
+	previousRecord, ok := cfg.getUserRecord(w, r, params.RecordID)
+	if !ok {
+		return
+	}
+
+	// Check progress update, if any, before changing anything
+	var progressEntry database.CreateRecordProgressParams
+	if params.Progress != nil {
+		medium, ok := cfg.getMedium(w, r, previousRecord.MediaID)
+		if !ok {
+			return
+		}
+		progressEntry, err = checkRecordProgress(*params.Progress, previousRecord, medium)
+		if err != nil {
+			respondWithError(w, 400, err.Error(), err)
+			return
+		}
+	}
+
+	// Check if rating has been modified
+	rating := previousRecord.Rating
+	if params.RemoveRating {
+		rating = pgtype.Int2{}
+	} else if params.Rating != nil {
+		rating, err = normalizeRating(*params.Rating, params.RatingScale)
+		if err != nil {
+			respondWithError(w, 400, err.Error(), err)
 			return
 		}
-		respondWithError(w, 500, "couldn't get record's dates from db", err)
-		return
 	}
 
 	// Check if dates has been modified
 	startDate := pgtype.Timestamp{}
-	if !paramStartDate.Valid || paramStartDate == previousDates.StartDate {
-		startDate = previousDates.StartDate
+	if !paramStartDate.Valid || paramStartDate == previousRecord.StartDate {
+		startDate = previousRecord.StartDate
 	} else {
 		startDate = paramStartDate
 	}
 	endDate := pgtype.Timestamp{}
-	if !paramEndDate.Valid || paramEndDate == previousDates.EndDate {
-		endDate = previousDates.EndDate
+	if !paramEndDate.Valid || paramEndDate == previousRecord.EndDate {
+		endDate = previousRecord.EndDate
 	} else {
 		endDate = paramEndDate
 	}
 
-	// Set is_finished
-	isFinished := pgtype.Bool{Valid: true}
-	if startDate.Valid && endDate.Valid {
-		isFinished.Bool = true
-	} else {
-		isFinished.Bool = false
+	// Logging progress on an unstarted record starts it
+	if params.Progress != nil {
+		if !startDate.Valid {
+			startDate = progressEntry.LoggedAt
+		}
+		if progressEntry.LoggedAt.Time.Before(startDate.Time) {
+			respondWithError(w, 400, "progress can't be logged before record's start date", errors.New("logged_at is before start_date"))
+			return
+		}
+	}
+
+	// Check status change, status being set from dates when none is given
+	status := params.Status
+	if status == "" {
+		status = statusFromDates(previousRecord.Status, startDate, endDate)
+	}
+	err = checkStatusTransition(previousRecord.Status, status)
+	if err != nil {
+		respondWithError(w, 400, err.Error(), err)
+		return
+	}
+	if params.Status != "" {
+		startDate, endDate, err = checkStatusDates(status, startDate, endDate)
+		if err != nil {
+			respondWithError(w, 400, err.Error(), err)
+			return
+		}
+	}
+	abandonReason, err := checkAbandonReason(status, params.AbandonReason, previousRecord.AbandonReason)
+	if err != nil {
+		respondWithError(w, 400, err.Error(), err)
+		return
+	}
+
+	// Set is_finished, kept in sync with status
+	isFinished := pgtype.Bool{Bool: status == database.RecordStatusFinished, Valid: true}
+
+	// Days the record was paused are left out of its duration, the current pause ending now if record is resumed
+	pauses, err := cfg.db.GetRecordPauses(r.Context(), previousRecord.ID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get record's pauses in database", err)
+		return
+	}
+	pausing := status == database.RecordStatusPaused && previousRecord.Status != database.RecordStatusPaused
+	resuming := previousRecord.Status == database.RecordStatusPaused && status != database.RecordStatusPaused
+	statusChangedAt := timestampNow()
+	if resuming {
+		for i := range pauses {
+			if !pauses[i].ResumedAt.Valid {
+				pauses[i].ResumedAt = statusChangedAt
+			}
+		}
 	}
 
 	// Calculate interval duration
-	interval, err := calculateDuration(startDate, endDate)
+	interval, err := calculateActiveDuration(startDate, endDate, pauses)
 	if err != nil {
 		respondWithError(w, 400, "start date is after end date", err)
 		return
 	}
 
+	// Open or close record's pause along with the update
+	update := database.SaveRecordUpdateParams{
+		Record: database.UpdateRecordParams{
+			ID:            previousRecord.ID,
+			IsFinished:    isFinished,
+			StartDate:     startDate,
+			EndDate:       endDate,
+			Duration:      interval,
+			Comments:      params.Comments,
+			UserID:        previousRecord.UserID,
+			Rating:        rating,
+			Status:        status,
+			AbandonReason: abandonReason,
+		},
+	}
+	if pausing {
+		update.PausedAt = statusChangedAt
+	}
+	if resuming {
+		update.ResumedAt = statusChangedAt
+	}
+	// Append the update to record's progress log
+	if params.Progress != nil {
+		update.Progress = &progressEntry
+	}
+
 	// Call query function
-	record, err := cfg.db.UpdateRecord(r.Context(), database.UpdateRecordParams{
-		ID:         recordID,
-		IsFinished: isFinished,
-		StartDate:  startDate,
-		EndDate:    endDate,
-		Duration:   interval,
-		Comments:   params.Comments,
-	})
+	result, err := cfg.db.SaveRecordUpdate(r.Context(), update)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			respondWithError(w, 404, "No record found with given ID", err)
@@ -241,26 +396,36 @@ func (cfg *apiConfig) handlerUpdateRecord(w http.ResponseWriter, r *http.Request
 		respondWithError(w, 500, "couldn't update record in database", err)
 		return
 	}
+	record := result.Record
+	var progress *RecordProgress
+	if result.Progress != nil {
+		logged := toRecordProgress(*result.Progress)
+		progress = &logged
+	}
 
 	// Respond
 	respondWithJson(w, 200, response{
+		Progress: progress,
 		Record: Record{
-			ID:         record.ID,
-			CreatedAt:  record.CreatedAt,
-			UpdatedAt:  record.UpdatedAt,
-			UserID:     record.UserID,
-			MediaID:    record.MediaID,
-			IsFinished: record.IsFinished,
-			StartDate:  record.StartDate,
-			EndDate:    record.EndDate,
-			Duration:   record.Duration.Days,
-			Comments:   record.Comments,
+			ID:            record.ID,
+			CreatedAt:     record.CreatedAt,
+			UpdatedAt:     record.UpdatedAt,
+			UserID:        record.UserID,
+			MediaID:       record.MediaID,
+			IsFinished:    record.IsFinished,
+			StartDate:     record.StartDate,
+			EndDate:       record.EndDate,
+			Duration:      record.Duration.Days,
+			Comments:      record.Comments,
+			Rating:        record.Rating,
+			Status:        record.Status,
+			AbandonReason: record.AbandonReason,
 		},
 	})
 }
 
 type parametersDeleteRecord struct {
-	MediumID string `json:"medium_id"`
+	RecordID string `json:"record_id"`
 }
 
 // DELETE /api/records
@@ -277,24 +442,24 @@ func (cfg *apiConfig) handlerDeleteRecord(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	// Convert MediumID to pgtype.UUID
-	mediumID, err := convertIdToPgtype(params.MediumID)
+	// Convert RecordID to pgtype.UUID
+	recordID, err := convertIdToPgtype(params.RecordID)
 	if err != nil {
 		respondWithError(w, 400, "record_id not in good format", err)
 		return
 	}
 
-	// Call query function
+	// Call query function, other consumptions of the same medium are kept
 	count, err := cfg.db.DeleteRecord(r.Context(), database.DeleteRecordParams{
-		MediaID: mediumID,
-		UserID:  userID,
+		ID:     recordID,
+		UserID: userID,
 	})
 	if err != nil {
 		respondWithError(w, 500, "couldn't delete record in database", err)
 		return
 	}
 	if count == 0 {
-		respondWithError(w, 404, "No record with given IDs in database", nil)
+		respondWithError(w, 404, "No record found with given ID in user's shelf", nil)
 		return
 	}
 
@@ -0,0 +1,552 @@
+package server
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// Longest review body accepted, in bytes
+const maxReviewLength = 50000
+
+// Markdown renderer of reviews
+// Raw HTML is left out and dangerous links (javascript:...) are dropped, as goldmark isn't set to unsafe mode
+var reviewMarkdown = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+// Spoiler blocks are written between a ":::spoiler" line, optionally followed by a label, and a ":::" line
+const (
+	spoilerOpening = ":::spoiler"
+	spoilerClosing = ":::"
+)
+
+type reviewBlock struct {
+	spoiler bool
+	label   string
+	text    string
+}
+
+// Split a review body into plain and spoiler blocks, an unclosed spoiler block lasting until the end
+func splitReviewBody(body string) []reviewBlock {
+	var blocks []reviewBlock
+	current := reviewBlock{}
+	var lines []string
+
+	flush := func() {
+		current.text = strings.Join(lines, "\n")
+		if current.spoiler || strings.TrimSpace(current.text) != "" {
+			blocks = append(blocks, current)
+		}
+		lines = nil
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case !current.spoiler && strings.HasPrefix(strings.ToLower(trimmed), spoilerOpening):
+			flush()
+			current = reviewBlock{spoiler: true, label: strings.TrimSpace(trimmed[len(spoilerOpening):])}
+		case current.spoiler && trimmed == spoilerClosing:
+			flush()
+			current = reviewBlock{}
+		default:
+			lines = append(lines, line)
+		}
+	}
+	flush()
+	return blocks
+}
+
+// Render a review body to sanitized HTML
+// Spoiler blocks are folded in a <details> element, or replaced by a notice when hidden
+func renderReview(body string, showSpoilers bool) (string, bool, error) {
+	var buf bytes.Buffer
+	hasSpoilers := false
+
+	for _, block := range splitReviewBody(body) {
+		if !block.spoiler {
+			if err := reviewMarkdown.Convert([]byte(block.text), &buf); err != nil {
+				return "", false, err
+			}
+			continue
+		}
+
+		hasSpoilers = true
+		label := "Spoiler"
+		if block.label != "" {
+			label = fmt.Sprintf("Spoiler: %s", block.label)
+		}
+		if !showSpoilers {
+			fmt.Fprintf(&buf, "<p class=\"spoiler-hidden\">%s (hidden)</p>\n", html.EscapeString(label))
+			continue
+		}
+		fmt.Fprintf(&buf, "<details class=\"spoiler\">\n<summary>%s</summary>\n", html.EscapeString(label))
+		if err := reviewMarkdown.Convert([]byte(block.text), &buf); err != nil {
+			return "", false, err
+		}
+		buf.WriteString("</details>\n")
+	}
+	return buf.String(), hasSpoilers, nil
+}
+
+// Review as a single Markdown document, title first, for diffs between revisions
+func reviewDocument(title, body string) string {
+	return fmt.Sprintf("# %s\n\n%s", title, strings.ReplaceAll(body, "\r\n", "\n"))
+}
+
+// Above this many line comparisons, a diff simply replaces every changed line
+const maxDiffComparisons = 4_000_000
+
+// Line by line diff from old to new text, each line starting with "+ " (added), "- " (removed) or "  " (kept)
+func diffLines(oldText, newText string) string {
+	oldLines := strings.Split(oldText, "\n")
+	newLines := strings.Split(newText, "\n")
+	if oldText == "" {
+		oldLines = nil
+	}
+
+	// Common lines at both ends are kept as is
+	prefix := 0
+	for prefix < len(oldLines) && prefix < len(newLines) && oldLines[prefix] == newLines[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(oldLines)-prefix && suffix < len(newLines)-prefix && oldLines[len(oldLines)-1-suffix] == newLines[len(newLines)-1-suffix] {
+		suffix++
+	}
+	oldMiddle := oldLines[prefix : len(oldLines)-suffix]
+	newMiddle := newLines[prefix : len(newLines)-suffix]
+
+	var diff []string
+	for _, line := range oldLines[:prefix] {
+		diff = append(diff, "  "+line)
+	}
+
+	if len(oldMiddle)*len(newMiddle) > maxDiffComparisons {
+		for _, line := range oldMiddle {
+			diff = append(diff, "- "+line)
+		}
+		for _, line := range newMiddle {
+			diff = append(diff, "+ "+line)
+		}
+	} else {
+		// Longest common subsequence of the changed lines, lcs[i][j] being the one of oldMiddle[i:] and newMiddle[j:]
+		lcs := make([][]int, len(oldMiddle)+1)
+		for i := range lcs {
+			lcs[i] = make([]int, len(newMiddle)+1)
+		}
+		for i := len(oldMiddle) - 1; i >= 0; i-- {
+			for j := len(newMiddle) - 1; j >= 0; j-- {
+				if oldMiddle[i] == newMiddle[j] {
+					lcs[i][j] = lcs[i+1][j+1] + 1
+				} else {
+					lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+				}
+			}
+		}
+		i, j := 0, 0
+		for i < len(oldMiddle) || j < len(newMiddle) {
+			switch {
+			case i < len(oldMiddle) && j < len(newMiddle) && oldMiddle[i] == newMiddle[j]:
+				diff = append(diff, "  "+oldMiddle[i])
+				i++
+				j++
+			case i < len(oldMiddle) && (j == len(newMiddle) || lcs[i+1][j] >= lcs[i][j+1]):
+				diff = append(diff, "- "+oldMiddle[i])
+				i++
+			default:
+				diff = append(diff, "+ "+newMiddle[j])
+				j++
+			}
+		}
+	}
+
+	for _, line := range oldLines[len(oldLines)-suffix:] {
+		diff = append(diff, "  "+line)
+	}
+	return strings.Join(diff, "\n")
+}
+
+// Check review's fields, a published review can't be empty
+func checkReview(title, body string, isPublished bool) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "", errors.New("a title must be provided")
+	}
+	if len(body) > maxReviewLength {
+		return "", fmt.Errorf("review body can't be longer than %d characters", maxReviewLength)
+	}
+	if isPublished && strings.TrimSpace(body) == "" {
+		return "", errors.New("a published review must have a body")
+	}
+	return title, nil
+}
+
+// Review as its owner sees it, spoilers included
+func reviewResponse(review database.Review, revision int32) (Review, error) {
+	reviewHTML, hasSpoilers, err := renderReview(review.Body, true)
+	if err != nil {
+		return Review{}, err
+	}
+	return Review{
+		ID:          review.ID,
+		CreatedAt:   review.CreatedAt,
+		UpdatedAt:   review.UpdatedAt,
+		RecordID:    review.RecordID,
+		Title:       review.Title,
+		Body:        review.Body,
+		HTML:        reviewHTML,
+		HasSpoilers: hasSpoilers,
+		IsPublished: review.IsPublished,
+		PublishedAt: review.PublishedAt,
+		Revision:    revision,
+	}, nil
+}
+
+// POST /api/reviews
+func (cfg *apiConfig) handlerCreateReview(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersCreateReview
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	title, err := checkReview(params.Title, params.Body, params.IsPublished)
+	if err != nil {
+		respondWithError(w, 400, err.Error(), err)
+		return
+	}
+
+	record, ok := cfg.getUserRecord(w, r, params.RecordID)
+	if !ok {
+		return
+	}
+
+	var publishedAt pgtype.Timestamp
+	if params.IsPublished {
+		publishedAt = timestampNow()
+	}
+
+	// Call query functions, the first revision being the review itself
+	review, err := cfg.db.CreateReview(r.Context(), database.CreateReviewParams{
+		RecordID:    record.ID,
+		Title:       title,
+		Body:        params.Body,
+		IsPublished: params.IsPublished,
+		PublishedAt: publishedAt,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			respondWithError(w, 409, "record already has a review", err)
+			return
+		}
+		respondWithError(w, 500, "couldn't create review in database", err)
+		return
+	}
+	revision, err := cfg.db.CreateReviewRevision(r.Context(), database.CreateReviewRevisionParams{
+		ReviewID: review.ID,
+		Title:    review.Title,
+		Body:     review.Body,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't create review's revision in database", err)
+		return
+	}
+
+	response, err := reviewResponse(review, revision.Revision)
+	if err != nil {
+		respondWithError(w, 500, "couldn't render review", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 201, response)
+}
+
+// Get the review of one of the logged user's records, respond with an error if there is none
+func (cfg *apiConfig) getUserReview(w http.ResponseWriter, r *http.Request, recordID string) (database.Review, bool) {
+	record, ok := cfg.getUserRecord(w, r, recordID)
+	if !ok {
+		return database.Review{}, false
+	}
+
+	review, err := cfg.db.GetReviewByRecordID(r.Context(), record.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondWithError(w, 404, "no review found for given record", err)
+			return database.Review{}, false
+		}
+		respondWithError(w, 500, "couldn't get review in database", err)
+		return database.Review{}, false
+	}
+	return review, true
+}
+
+// GET /api/reviews
+func (cfg *apiConfig) handlerGetReview(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersReview
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	review, ok := cfg.getUserReview(w, r, params.RecordID)
+	if !ok {
+		return
+	}
+
+	revisions, err := cfg.db.GetReviewRevisions(r.Context(), review.ID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get review's revisions in database", err)
+		return
+	}
+
+	response, err := reviewResponse(review, int32(len(revisions)))
+	if err != nil {
+		respondWithError(w, 500, "couldn't render review", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 200, response)
+}
+
+// PUT /api/reviews
+func (cfg *apiConfig) handlerUpdateReview(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersUpdateReview
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	previousReview, ok := cfg.getUserReview(w, r, params.RecordID)
+	if !ok {
+		return
+	}
+
+	// Omitted fields are kept
+	title, body, isPublished := previousReview.Title, previousReview.Body, previousReview.IsPublished
+	if params.Title != nil {
+		title = *params.Title
+	}
+	if params.Body != nil {
+		body = *params.Body
+	}
+	if params.IsPublished != nil {
+		isPublished = *params.IsPublished
+	}
+	title, err = checkReview(title, body, isPublished)
+	if err != nil {
+		respondWithError(w, 400, err.Error(), err)
+		return
+	}
+
+	// Publication date is the one of the latest publication
+	publishedAt := previousReview.PublishedAt
+	switch {
+	case isPublished && !previousReview.IsPublished:
+		publishedAt = timestampNow()
+	case !isPublished:
+		publishedAt = pgtype.Timestamp{}
+	}
+
+	// Call query functions, a new revision being saved only if the text changed
+	review, err := cfg.db.UpdateReview(r.Context(), database.UpdateReviewParams{
+		ID:          previousReview.ID,
+		Title:       title,
+		Body:        body,
+		IsPublished: isPublished,
+		PublishedAt: publishedAt,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't update review in database", err)
+		return
+	}
+	if review.Title != previousReview.Title || review.Body != previousReview.Body {
+		_, err = cfg.db.CreateReviewRevision(r.Context(), database.CreateReviewRevisionParams{
+			ReviewID: review.ID,
+			Title:    review.Title,
+			Body:     review.Body,
+		})
+		if err != nil {
+			respondWithError(w, 500, "couldn't create review's revision in database", err)
+			return
+		}
+	}
+	revisions, err := cfg.db.GetReviewRevisions(r.Context(), review.ID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get review's revisions in database", err)
+		return
+	}
+
+	response, err := reviewResponse(review, int32(len(revisions)))
+	if err != nil {
+		respondWithError(w, 500, "couldn't render review", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 200, response)
+}
+
+// DELETE /api/reviews
+func (cfg *apiConfig) handlerDeleteReview(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersReview
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	record, ok := cfg.getUserRecord(w, r, params.RecordID)
+	if !ok {
+		return
+	}
+
+	// Call query function, revisions go with the review
+	count, err := cfg.db.DeleteReview(r.Context(), record.ID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't delete review in database", err)
+		return
+	}
+	if count == 0 {
+		respondWithError(w, 404, "no review found for given record", nil)
+		return
+	}
+
+	// Respond
+	w.WriteHeader(200)
+}
+
+type responseReviewRevisions struct {
+	ReviewID  pgtype.UUID      `json:"review_id"`
+	Revisions []ReviewRevision `json:"revisions"`
+}
+
+// GET /api/reviews/revisions
+func (cfg *apiConfig) handlerGetReviewRevisions(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersReview
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	review, ok := cfg.getUserReview(w, r, params.RecordID)
+	if !ok {
+		return
+	}
+
+	// Call query function
+	revisions, err := cfg.db.GetReviewRevisions(r.Context(), review.ID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get review's revisions in database", err)
+		return
+	}
+
+	// Each revision is compared to the previous one, the first one to an empty review
+	response := responseReviewRevisions{
+		ReviewID:  review.ID,
+		Revisions: make([]ReviewRevision, 0, len(revisions)),
+	}
+	previous := ""
+	for _, revision := range revisions {
+		document := reviewDocument(revision.Title, revision.Body)
+		response.Revisions = append(response.Revisions, ReviewRevision{
+			Revision:  revision.Revision,
+			CreatedAt: revision.CreatedAt,
+			Title:     revision.Title,
+			Body:      revision.Body,
+			Diff:      diffLines(previous, document),
+		})
+		previous = document
+	}
+
+	// Respond
+	respondWithJson(w, 200, response)
+}
+
+type responseGetMediumReviews struct {
+	MediaID pgtype.UUID       `json:"medium_id"`
+	Reviews []PublishedReview `json:"reviews"`
+}
+
+// GET /api/media/reviews
+func (cfg *apiConfig) handlerGetMediumReviews(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersGetMediumReviews
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Convert MediumID to pgtype.UUID
+	mediumID, err := convertIdToPgtype(params.MediumID)
+	if err != nil {
+		respondWithError(w, 400, "medium_id not in good format", err)
+		return
+	}
+	medium, ok := cfg.getMedium(w, r, mediumID)
+	if !ok {
+		return
+	}
+
+	// Call query function
+	reviews, err := cfg.db.GetPublishedMediumReviews(r.Context(), medium.ID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get medium's reviews in database", err)
+		return
+	}
+
+	response := responseGetMediumReviews{
+		MediaID: medium.ID,
+		Reviews: make([]PublishedReview, 0, len(reviews)),
+	}
+	for _, review := range reviews {
+		reviewHTML, hasSpoilers, err := renderReview(review.Body, params.ShowSpoilers)
+		if err != nil {
+			respondWithError(w, 500, "couldn't render review", err)
+			return
+		}
+		response.Reviews = append(response.Reviews, PublishedReview{
+			ID:          review.ID,
+			UpdatedAt:   review.UpdatedAt,
+			RecordID:    review.RecordID,
+			UserID:      review.UserID,
+			Username:    review.Username,
+			Title:       review.Title,
+			HTML:        reviewHTML,
+			HasSpoilers: hasSpoilers,
+			PublishedAt: review.PublishedAt,
+		})
+	}
+
+	// Respond
+	respondWithJson(w, 200, response)
+}
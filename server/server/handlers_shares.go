@@ -0,0 +1,360 @@
+package server
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// POST /api/shares
+func (cfg *apiConfig) handlerCreateShare(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersCreateShare
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Check if all required fields are provided, a share is about a record OR a media type compartment
+	if params.RecipientUsername == "" || (params.RecordID == "") == (params.MediaType == "") {
+		respondWithError(w, 400, "recipient_username and either record_id or media_type must be provided", errors.New("invalid share request body"))
+		return
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Get users
+	owner, err := cfg.db.GetUserByID(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get user in database", err)
+		return
+	}
+	recipient, err := cfg.db.GetUserByUsername(r.Context(), params.RecipientUsername)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondWithError(w, 404, "no user found with given username", err)
+			return
+		}
+		respondWithError(w, 500, "couldn't get user in database", err)
+		return
+	}
+	if recipient.ID == owner.ID {
+		respondWithError(w, 400, "you can't share with yourself", errors.New("share recipient is the owner"))
+		return
+	}
+
+	share := Share{
+		OwnerID:           owner.ID,
+		OwnerUsername:     owner.Username,
+		RecipientID:       recipient.ID,
+		RecipientUsername: recipient.Username,
+		MediaType:         params.MediaType,
+	}
+	var mediaType pgtype.Text
+	var recordID pgtype.UUID
+	if params.RecordID != "" {
+		// A user can only share its own records
+		record, ok := cfg.getUserRecord(w, r, params.RecordID)
+		if !ok {
+			return
+		}
+		recordID = record.ID
+		medium, err := cfg.db.GetMediumByID(r.Context(), record.MediaID)
+		if err != nil {
+			respondWithError(w, 500, "couldn't get record's medium in database", err)
+			return
+		}
+		share.MediaType = medium.MediaType
+		share.Title = medium.Title
+	} else {
+		mediaType = pgtype.Text{String: params.MediaType, Valid: true}
+	}
+
+	// Call query function
+	newShare, err := cfg.db.CreateShare(r.Context(), database.CreateShareParams{
+		OwnerID:     owner.ID,
+		RecipientID: recipient.ID,
+		RecordID:    recordID,
+		MediaType:   mediaType,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			// This is a unique constraint violation
+			respondWithError(w, 409, "this is already shared with given user", err)
+			return
+		}
+		respondWithError(w, 500, "couldn't create share in database", err)
+		return
+	}
+	share.ID = newShare.ID
+	share.CreatedAt = newShare.CreatedAt
+	share.RecordID = newShare.RecordID
+
+	// Respond
+	respondWithJson(w, 201, share)
+}
+
+type responseGetShares struct {
+	SharedByMe   []Share `json:"shared_by_me"`
+	SharedWithMe []Share `json:"shared_with_me"`
+}
+
+// GET /api/shares
+func (cfg *apiConfig) handlerGetShares(w http.ResponseWriter, r *http.Request) {
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Call query function
+	shares, err := cfg.db.GetSharesByUserID(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get shares in database", err)
+		return
+	}
+
+	response := responseGetShares{
+		SharedByMe:   []Share{},
+		SharedWithMe: []Share{},
+	}
+	for _, row := range shares {
+		share := Share{
+			ID:                row.ID,
+			CreatedAt:         row.CreatedAt,
+			OwnerID:           row.OwnerID,
+			OwnerUsername:     row.OwnerUsername,
+			RecipientID:       row.RecipientID,
+			RecipientUsername: row.RecipientUsername,
+			RecordID:          row.RecordID,
+			MediaType:         row.MediaType,
+			Title:             row.Title,
+		}
+		if row.OwnerID == userID {
+			response.SharedByMe = append(response.SharedByMe, share)
+		} else {
+			response.SharedWithMe = append(response.SharedWithMe, share)
+		}
+	}
+
+	// Respond
+	respondWithJson(w, 200, response)
+}
+
+// Get a share received by the logged user, respond with an error if there is none
+func (cfg *apiConfig) getReceivedShare(w http.ResponseWriter, r *http.Request, stringID string) (database.Share, bool) {
+	shareID, err := convertIdToPgtype(stringID)
+	if err != nil {
+		respondWithError(w, 400, "share_id not in good format", err)
+		return database.Share{}, false
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	share, err := cfg.db.GetShareByID(r.Context(), shareID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		respondWithError(w, 500, "couldn't get share in database", err)
+		return database.Share{}, false
+	}
+	if err != nil || share.RecipientID != userID {
+		respondWithError(w, 404, "no share found with given id for user", err)
+		return database.Share{}, false
+	}
+	return share, true
+}
+
+type responseGetSharedMediaRecords struct {
+	MediaRecords []MediumWithRecord `json:"records"`
+}
+
+// GET /api/shares/records
+func (cfg *apiConfig) handlerGetSharedMediaRecords(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersShare
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	share, ok := cfg.getReceivedShare(w, r, params.ShareID)
+	if !ok {
+		return
+	}
+
+	// Call query function
+	rows, err := cfg.db.GetSharedMediaRecords(r.Context(), database.GetSharedMediaRecordsParams{
+		ShareID:     share.ID,
+		RecipientID: share.RecipientID,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't get shared records in database", err)
+		return
+	}
+
+	response := responseGetSharedMediaRecords{
+		MediaRecords: make([]MediumWithRecord, 0, len(rows)),
+	}
+	for _, row := range rows {
+		// Convert metadata back to map
+		metadataMap, err := bytesToMap(row.Metadata)
+		if err != nil {
+			respondWithError(w, 500, "couldn't convert metadata map from database", err)
+			return
+		}
+		response.MediaRecords = append(response.MediaRecords, MediumWithRecord{
+			ID:         row.ID,
+			UserID:     row.UserID,
+			MediaID:    row.MediaID,
+			IsFinished: row.IsFinished,
+			StartDate:  row.StartDate,
+			EndDate:    row.EndDate,
+			Duration:   row.Duration.Days,
+			Comments:   row.Comments,
+			Rating:     row.Rating,
+			Status:     row.Status,
+			MediaType:  row.MediaType,
+			Title:      row.Title,
+			Creator:    row.Creator,
+			PubDate:    row.PubDate,
+			ImageUrl:   row.ImageUrl,
+			Metadata:   metadataMap,
+		})
+	}
+
+	// Respond
+	respondWithJson(w, 200, response)
+}
+
+// POST /api/shares/records
+func (cfg *apiConfig) handlerAddSharedRecord(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersAddSharedRecord
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	share, ok := cfg.getReceivedShare(w, r, params.ShareID)
+	if !ok {
+		return
+	}
+	recordID, err := convertIdToPgtype(params.RecordID)
+	if err != nil {
+		respondWithError(w, 400, "record_id not in good format", err)
+		return
+	}
+
+	// Find the record among the shared ones
+	rows, err := cfg.db.GetSharedMediaRecords(r.Context(), database.GetSharedMediaRecordsParams{
+		ShareID:     share.ID,
+		RecipientID: share.RecipientID,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't get shared records in database", err)
+		return
+	}
+	var mediumID pgtype.UUID
+	for _, row := range rows {
+		if row.ID == recordID {
+			mediumID = row.MediaID
+		}
+	}
+	if !mediumID.Valid {
+		respondWithError(w, 404, "no record found with given id in share", errors.New("record is not part of the share"))
+		return
+	}
+
+	// A medium already in user's shelf isn't added again
+	count, err := cfg.db.CountUserRecordsByMediumID(r.Context(), database.CountUserRecordsByMediumIDParams{
+		MediaID: mediumID,
+		UserID:  share.RecipientID,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't count user's records in database", err)
+		return
+	}
+	if count > 0 {
+		respondWithError(w, 409, "this medium is already in user's shelf", errors.New("user already has a record for this medium"))
+		return
+	}
+
+	// Create user's own record, sharer's dates and comments are not copied
+	record, err := cfg.db.CreateUserMediumRecord(r.Context(), database.CreateUserMediumRecordParams{
+		UserID:     share.RecipientID,
+		MediaID:    mediumID,
+		IsFinished: pgtype.Bool{Bool: false, Valid: true},
+		Status:     database.RecordStatusWishlist,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't create new record in database", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 201, Record{
+		ID:            record.ID,
+		CreatedAt:     record.CreatedAt,
+		UpdatedAt:     record.UpdatedAt,
+		UserID:        record.UserID,
+		MediaID:       record.MediaID,
+		IsFinished:    record.IsFinished,
+		StartDate:     record.StartDate,
+		EndDate:       record.EndDate,
+		Duration:      record.Duration.Days,
+		Comments:      record.Comments,
+		Rating:        record.Rating,
+		Status:        record.Status,
+		AbandonReason: record.AbandonReason,
+	})
+}
+
+// DELETE /api/shares
+func (cfg *apiConfig) handlerDeleteShare(w http.ResponseWriter, r *http.Request) {
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Parse data from request body
+	var params parametersShare
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Convert ShareID to pgtype.UUID
+	shareID, err := convertIdToPgtype(params.ShareID)
+	if err != nil {
+		respondWithError(w, 400, "share_id not in good format", err)
+		return
+	}
+
+	// Call query function, both the owner and the recipient can remove a share
+	count, err := cfg.db.DeleteShare(r.Context(), database.DeleteShareParams{
+		ID:     shareID,
+		UserID: userID,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't delete share in database", err)
+		return
+	}
+	if count == 0 {
+		respondWithError(w, 404, "No share with given ID for user in database", nil)
+		return
+	}
+
+	// Respond
+	w.WriteHeader(200)
+}
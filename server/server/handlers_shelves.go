@@ -0,0 +1,270 @@
+package server
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// POST /api/shelves
+func (cfg *apiConfig) handlerCreateShelf(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersCreateShelf
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Check if name is provided
+	name := strings.TrimSpace(params.Name)
+	if name == "" {
+		respondWithError(w, 400, "a name must be provided", errors.New("empty shelf name"))
+		return
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Call query function
+	shelf, err := cfg.db.CreateShelf(r.Context(), database.CreateShelfParams{
+		UserID:      userID,
+		Name:        name,
+		Description: params.Description,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			// This is a unique constraint violation
+			respondWithError(w, 409, "user already has a shelf with this name", err)
+			return
+		}
+		respondWithError(w, 500, "couldn't create shelf in database", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 201, Shelf{
+		ID:          shelf.ID,
+		CreatedAt:   shelf.CreatedAt,
+		UpdatedAt:   shelf.UpdatedAt,
+		Name:        shelf.Name,
+		Description: shelf.Description,
+	})
+}
+
+type responseGetShelves struct {
+	Shelves []Shelf `json:"shelves"`
+}
+
+// GET /api/shelves
+func (cfg *apiConfig) handlerGetShelves(w http.ResponseWriter, r *http.Request) {
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Call query function
+	shelves, err := cfg.db.GetShelvesByUserID(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get shelves in database", err)
+		return
+	}
+
+	response := responseGetShelves{
+		Shelves: make([]Shelf, 0, len(shelves)),
+	}
+	for _, shelf := range shelves {
+		response.Shelves = append(response.Shelves, Shelf{
+			ID:          shelf.ID,
+			CreatedAt:   shelf.CreatedAt,
+			UpdatedAt:   shelf.UpdatedAt,
+			Name:        shelf.Name,
+			Description: shelf.Description,
+			MediaCount:  shelf.MediaCount,
+		})
+	}
+
+	// Respond
+	respondWithJson(w, 200, response)
+}
+
+// PUT /api/shelves
+func (cfg *apiConfig) handlerUpdateShelf(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersUpdateShelf
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	shelf, ok := cfg.getUserShelf(w, r, params.ShelfID)
+	if !ok {
+		return
+	}
+
+	// Omitted fields are kept
+	name := strings.TrimSpace(params.Name)
+	if name == "" {
+		name = shelf.Name
+	}
+	description := shelf.Description
+	if params.Description != nil {
+		description = *params.Description
+	}
+
+	// Call query function
+	updatedShelf, err := cfg.db.UpdateShelf(r.Context(), database.UpdateShelfParams{
+		Name:        name,
+		Description: description,
+		ID:          shelf.ID,
+		UserID:      shelf.UserID,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			respondWithError(w, 409, "user already has a shelf with this name", err)
+			return
+		}
+		respondWithError(w, 500, "couldn't update shelf in database", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 200, Shelf{
+		ID:          updatedShelf.ID,
+		CreatedAt:   updatedShelf.CreatedAt,
+		UpdatedAt:   updatedShelf.UpdatedAt,
+		Name:        updatedShelf.Name,
+		Description: updatedShelf.Description,
+	})
+}
+
+// DELETE /api/shelves
+func (cfg *apiConfig) handlerDeleteShelf(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersShelf
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	shelfID, err := convertIdToPgtype(params.ShelfID)
+	if err != nil {
+		respondWithError(w, 400, "shelf_id not in good format", err)
+		return
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Call query function, media and their records are left untouched
+	count, err := cfg.db.DeleteShelf(r.Context(), database.DeleteShelfParams{
+		ID:     shelfID,
+		UserID: userID,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't delete shelf in database", err)
+		return
+	}
+	if count == 0 {
+		respondWithError(w, 404, "no shelf found with given id for user", nil)
+		return
+	}
+
+	// Respond
+	w.WriteHeader(200)
+}
+
+// POST /api/shelves/media
+func (cfg *apiConfig) handlerAddMediaToShelf(w http.ResponseWriter, r *http.Request) {
+	cfg.changeShelfMedia(w, r, true)
+}
+
+// DELETE /api/shelves/media
+func (cfg *apiConfig) handlerRemoveMediaFromShelf(w http.ResponseWriter, r *http.Request) {
+	cfg.changeShelfMedia(w, r, false)
+}
+
+// Put several media on a custom shelf at once, or take them off
+func (cfg *apiConfig) changeShelfMedia(w http.ResponseWriter, r *http.Request, add bool) {
+
+	// Parse data from request body
+	var params parametersShelfMedia
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Check if all required fields are provided
+	if len(params.MediumIDs) == 0 {
+		respondWithError(w, 400, "shelf_id and medium_ids must be provided", errors.New("empty medium_ids"))
+		return
+	}
+
+	shelf, ok := cfg.getUserShelf(w, r, params.ShelfID)
+	if !ok {
+		return
+	}
+	mediumIDs, ok := cfg.getUserMediumIDs(w, r, params.MediumIDs, add)
+	if !ok {
+		return
+	}
+
+	var response responseGroupMedia
+	if add {
+		response.Count, err = cfg.db.AddMediaToShelf(r.Context(), database.AddMediaToShelfParams{
+			ShelfID:  shelf.ID,
+			MediaIds: mediumIDs,
+		})
+	} else {
+		response.Count, err = cfg.db.RemoveMediaFromShelf(r.Context(), database.RemoveMediaFromShelfParams{
+			ShelfID:  shelf.ID,
+			MediaIds: mediumIDs,
+		})
+	}
+	if err != nil {
+		respondWithError(w, 500, "couldn't change shelf's media in database", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 200, response)
+}
+
+// Get one of the logged user's custom shelves, respond with an error if there is none
+func (cfg *apiConfig) getUserShelf(w http.ResponseWriter, r *http.Request, stringID string) (database.Shelf, bool) {
+	shelfID, err := convertIdToPgtype(stringID)
+	if err != nil {
+		respondWithError(w, 400, "shelf_id not in good format", err)
+		return database.Shelf{}, false
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	shelf, err := cfg.db.GetUserShelfByID(r.Context(), database.GetUserShelfByIDParams{
+		ID:     shelfID,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondWithError(w, 404, "no shelf found with given id for user", err)
+			return database.Shelf{}, false
+		}
+		respondWithError(w, 500, "couldn't get shelf in database", err)
+		return database.Shelf{}, false
+	}
+	return shelf, true
+}
@@ -0,0 +1,550 @@
+package server
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Largest grid a shelving unit can have, in rows and in columns
+const maxShelvingUnitSide = 20
+
+// Kallax cube's inner dimensions, used for the ones not given
+const (
+	defaultCellWidthCm  = 33
+	defaultCellHeightCm = 33
+	defaultCellDepthCm  = 39
+)
+
+// Check shelving unit's details and fill missing cell dimensions, returned errors are meant for the client
+func (params parametersShelvingUnitDetails) toShelvingUnitDetails() (parametersShelvingUnitDetails, error) {
+	details := params
+	details.Name = strings.TrimSpace(params.Name)
+	if details.Name == "" {
+		return details, errors.New("a name must be provided")
+	}
+	if details.Rows < 1 || details.Rows > maxShelvingUnitSide || details.Columns < 1 || details.Columns > maxShelvingUnitSide {
+		return details, fmt.Errorf("rows and columns must be between 1 and %d", maxShelvingUnitSide)
+	}
+	if details.CellWidthCm < 0 || details.CellHeightCm < 0 || details.CellDepthCm < 0 {
+		return details, errors.New("cell dimensions can't be negative")
+	}
+	if details.CellWidthCm == 0 {
+		details.CellWidthCm = defaultCellWidthCm
+	}
+	if details.CellHeightCm == 0 {
+		details.CellHeightCm = defaultCellHeightCm
+	}
+	if details.CellDepthCm == 0 {
+		details.CellDepthCm = defaultCellDepthCm
+	}
+	return details, nil
+}
+
+func shelvingUnitResponse(unit database.ShelvingUnit, itemsCount int64) ShelvingUnit {
+	return ShelvingUnit{
+		ID:           unit.ID,
+		CreatedAt:    unit.CreatedAt,
+		UpdatedAt:    unit.UpdatedAt,
+		Name:         unit.Name,
+		Rows:         unit.RowsCount,
+		Columns:      unit.ColumnsCount,
+		CellWidthCm:  unit.CellWidthCm,
+		CellHeightCm: unit.CellHeightCm,
+		CellDepthCm:  unit.CellDepthCm,
+		ItemsCount:   itemsCount,
+	}
+}
+
+// POST /api/shelving_units
+func (cfg *apiConfig) handlerCreateShelvingUnit(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersCreateShelvingUnit
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	details, err := params.toShelvingUnitDetails()
+	if err != nil {
+		respondWithError(w, 400, err.Error(), err)
+		return
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Call query function
+	unit, err := cfg.db.CreateShelvingUnit(r.Context(), database.CreateShelvingUnitParams{
+		UserID:       userID,
+		Name:         details.Name,
+		RowsCount:    details.Rows,
+		ColumnsCount: details.Columns,
+		CellWidthCm:  details.CellWidthCm,
+		CellHeightCm: details.CellHeightCm,
+		CellDepthCm:  details.CellDepthCm,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			// This is a unique constraint violation
+			respondWithError(w, 409, "user already has a shelving unit with this name", err)
+			return
+		}
+		respondWithError(w, 500, "couldn't create shelving unit in database", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 201, shelvingUnitResponse(unit, 0))
+}
+
+type responseGetShelvingUnits struct {
+	Units []ShelvingUnit `json:"units"`
+}
+
+// GET /api/shelving_units
+func (cfg *apiConfig) handlerGetShelvingUnits(w http.ResponseWriter, r *http.Request) {
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Call query function
+	units, err := cfg.db.GetShelvingUnitsByUserID(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get shelving units in database", err)
+		return
+	}
+
+	response := responseGetShelvingUnits{
+		Units: make([]ShelvingUnit, 0, len(units)),
+	}
+	for _, unit := range units {
+		response.Units = append(response.Units, shelvingUnitResponse(database.ShelvingUnit{
+			ID:           unit.ID,
+			CreatedAt:    unit.CreatedAt,
+			UpdatedAt:    unit.UpdatedAt,
+			UserID:       unit.UserID,
+			Name:         unit.Name,
+			RowsCount:    unit.RowsCount,
+			ColumnsCount: unit.ColumnsCount,
+			CellWidthCm:  unit.CellWidthCm,
+			CellHeightCm: unit.CellHeightCm,
+			CellDepthCm:  unit.CellDepthCm,
+		}, unit.ItemsCount))
+	}
+
+	// Respond
+	respondWithJson(w, 200, response)
+}
+
+// PUT /api/shelving_units
+func (cfg *apiConfig) handlerUpdateShelvingUnit(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersUpdateShelvingUnit
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	unit, ok := cfg.getUserShelvingUnit(w, r, params.UnitID)
+	if !ok {
+		return
+	}
+
+	details, err := params.toShelvingUnitDetails()
+	if err != nil {
+		respondWithError(w, 400, err.Error(), err)
+		return
+	}
+
+	// A unit can't shrink over the cells holding media
+	outside, err := cfg.db.CountShelvingItemsOutsideGrid(r.Context(), database.CountShelvingItemsOutsideGridParams{
+		UnitID:       unit.ID,
+		RowsCount:    details.Rows,
+		ColumnsCount: details.Columns,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't get shelving unit's items in database", err)
+		return
+	}
+	if outside > 0 {
+		respondWithError(w, 409, fmt.Sprintf("%d media are in cells outside the new grid, move them first", outside), errors.New("shelving unit resized over its items"))
+		return
+	}
+
+	// Call query function, every detail is replaced
+	updatedUnit, err := cfg.db.UpdateShelvingUnit(r.Context(), database.UpdateShelvingUnitParams{
+		Name:         details.Name,
+		RowsCount:    details.Rows,
+		ColumnsCount: details.Columns,
+		CellWidthCm:  details.CellWidthCm,
+		CellHeightCm: details.CellHeightCm,
+		CellDepthCm:  details.CellDepthCm,
+		ID:           unit.ID,
+		UserID:       unit.UserID,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			respondWithError(w, 409, "user already has a shelving unit with this name", err)
+			return
+		}
+		respondWithError(w, 500, "couldn't update shelving unit in database", err)
+		return
+	}
+
+	items, err := cfg.db.GetShelvingUnitItems(r.Context(), unit.ID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get shelving unit's items in database", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 200, shelvingUnitResponse(updatedUnit, int64(len(items))))
+}
+
+// DELETE /api/shelving_units
+func (cfg *apiConfig) handlerDeleteShelvingUnit(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersShelvingUnit
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Convert UnitID to pgtype.UUID
+	unitID, err := convertIdToPgtype(params.UnitID)
+	if err != nil {
+		respondWithError(w, 400, "unit_id not in good format", err)
+		return
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Call query function, media in the unit are only taken out of it
+	count, err := cfg.db.DeleteShelvingUnit(r.Context(), database.DeleteShelvingUnitParams{
+		ID:     unitID,
+		UserID: userID,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't delete shelving unit in database", err)
+		return
+	}
+	if count == 0 {
+		respondWithError(w, 404, "no shelving unit found with given id for user", nil)
+		return
+	}
+
+	// Respond
+	w.WriteHeader(200)
+}
+
+type responseGetShelvingUnitGrid struct {
+	Unit  ShelvingUnit   `json:"unit"`
+	Cells []ShelvingCell `json:"cells"`
+}
+
+// Lay a unit's items out in its cells, row by row from the top left, empty cells included
+func shelvingUnitGrid(unit database.ShelvingUnit, items []database.GetShelvingUnitItemsRow) []ShelvingCell {
+	cells := make([]ShelvingCell, 0, unit.RowsCount*unit.ColumnsCount)
+	for row := int32(1); row <= unit.RowsCount; row++ {
+		for column := int32(1); column <= unit.ColumnsCount; column++ {
+			cells = append(cells, ShelvingCell{
+				Row:    row,
+				Column: column,
+				Items:  []ShelvingItem{},
+			})
+		}
+	}
+	for _, item := range items {
+		if item.CellRow > unit.RowsCount || item.CellColumn > unit.ColumnsCount {
+			continue
+		}
+		i := (item.CellRow-1)*unit.ColumnsCount + item.CellColumn - 1
+		cells[i].Items = append(cells[i].Items, ShelvingItem{
+			MediumID:  item.MediaID,
+			MediaType: item.MediaType,
+			Title:     item.Title,
+			ImageUrl:  item.ImageUrl,
+			UnitID:    unit.ID,
+			UnitName:  unit.Name,
+			Row:       item.CellRow,
+			Column:    item.CellColumn,
+			PlacedAt:  item.PlacedAt,
+		})
+	}
+	return cells
+}
+
+// GET /api/shelving_units/grid
+func (cfg *apiConfig) handlerGetShelvingUnitGrid(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersShelvingUnit
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	unit, ok := cfg.getUserShelvingUnit(w, r, params.UnitID)
+	if !ok {
+		return
+	}
+
+	// Call query function
+	items, err := cfg.db.GetShelvingUnitItems(r.Context(), unit.ID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get shelving unit's items in database", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 200, responseGetShelvingUnitGrid{
+		Unit:  shelvingUnitResponse(unit, int64(len(items))),
+		Cells: shelvingUnitGrid(unit, items),
+	})
+}
+
+// PUT /api/shelving_units/items
+func (cfg *apiConfig) handlerPlaceShelvingItem(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersPlaceShelvingItem
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Check if all required fields are provided
+	if params.MediumID == "" {
+		respondWithError(w, 400, "medium_id must be provided", errors.New("medium_id missing from shelving item request body"))
+		return
+	}
+	mediumID, err := convertIdToPgtype(params.MediumID)
+	if err != nil {
+		respondWithError(w, 400, "medium_id not in good format", err)
+		return
+	}
+
+	unit, ok := cfg.getUserShelvingUnit(w, r, params.UnitID)
+	if !ok {
+		return
+	}
+	if params.Row < 1 || params.Row > unit.RowsCount || params.Column < 1 || params.Column > unit.ColumnsCount {
+		respondWithError(w, 400, fmt.Sprintf("cell must be within the unit's %d rows and %d columns", unit.RowsCount, unit.ColumnsCount), errors.New("cell outside shelving unit's grid"))
+		return
+	}
+
+	// A user can only place media on their shelf
+	medium, ok := cfg.getMedium(w, r, mediumID)
+	if !ok {
+		return
+	}
+	count, err := cfg.db.CountUserRecordsByMediumID(r.Context(), database.CountUserRecordsByMediumIDParams{
+		MediaID: medium.ID,
+		UserID:  unit.UserID,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't get user's records in database", err)
+		return
+	}
+	if count == 0 {
+		respondWithError(w, 404, "no medium with given ID in user's shelf", errors.New("medium not in user's shelf"))
+		return
+	}
+
+	// Call query function, the medium leaves the cell it was in
+	item, err := cfg.db.PlaceShelvingItem(r.Context(), database.PlaceShelvingItemParams{
+		UserID:     unit.UserID,
+		MediaID:    medium.ID,
+		UnitID:     unit.ID,
+		CellRow:    params.Row,
+		CellColumn: params.Column,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't place medium in shelving unit in database", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 200, ShelvingItem{
+		MediumID:  item.MediaID,
+		MediaType: medium.MediaType,
+		Title:     medium.Title,
+		ImageUrl:  medium.ImageUrl,
+		UnitID:    unit.ID,
+		UnitName:  unit.Name,
+		Row:       item.CellRow,
+		Column:    item.CellColumn,
+		PlacedAt:  item.PlacedAt,
+	})
+}
+
+// DELETE /api/shelving_units/items
+func (cfg *apiConfig) handlerRemoveShelvingItem(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersShelvingItem
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Convert MediumID to pgtype.UUID
+	mediumID, err := convertIdToPgtype(params.MediumID)
+	if err != nil {
+		respondWithError(w, 400, "medium_id not in good format", err)
+		return
+	}
+	mediumID, err = cfg.resolveMediumID(r.Context(), mediumID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get medium redirect in database", err)
+		return
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Call query function
+	count, err := cfg.db.RemoveShelvingItem(r.Context(), database.RemoveShelvingItemParams{
+		UserID:  userID,
+		MediaID: mediumID,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't take medium out of shelving unit in database", err)
+		return
+	}
+	if count == 0 {
+		respondWithError(w, 404, "medium isn't in any of user's shelving units", nil)
+		return
+	}
+
+	// Respond
+	w.WriteHeader(200)
+}
+
+type responseWhereIsMedium struct {
+	Locations []ShelvingItem `json:"locations"`
+}
+
+// GET /api/shelving_units/where
+func (cfg *apiConfig) handlerWhereIsMedium(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersWhereIsMedium
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	response := responseWhereIsMedium{
+		Locations: []ShelvingItem{},
+	}
+
+	// Look a medium up by ID, or every medium whose title holds the given text
+	title := strings.TrimSpace(params.Title)
+	switch {
+	case params.MediumID != "":
+		mediumID, err := convertIdToPgtype(params.MediumID)
+		if err != nil {
+			respondWithError(w, 400, "medium_id not in good format", err)
+			return
+		}
+		mediumID, err = cfg.resolveMediumID(r.Context(), mediumID)
+		if err != nil {
+			respondWithError(w, 500, "couldn't get medium redirect in database", err)
+			return
+		}
+		item, err := cfg.db.GetUserMediumShelvingItem(r.Context(), database.GetUserMediumShelvingItemParams{
+			UserID:  userID,
+			MediaID: mediumID,
+		})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			respondWithError(w, 500, "couldn't get medium's location in database", err)
+			return
+		}
+		if err == nil {
+			response.Locations = append(response.Locations, shelvingLocation(database.FindShelvingItemsByTitleRow(item)))
+		}
+	case title != "":
+		items, err := cfg.db.FindShelvingItemsByTitle(r.Context(), database.FindShelvingItemsByTitleParams{
+			UserID: userID,
+			Title:  title,
+		})
+		if err != nil {
+			respondWithError(w, 500, "couldn't get media's locations in database", err)
+			return
+		}
+		for _, item := range items {
+			response.Locations = append(response.Locations, shelvingLocation(item))
+		}
+	default:
+		respondWithError(w, 400, "medium_id or title must be provided", errors.New("nothing to look for in where request body"))
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 200, response)
+}
+
+func shelvingLocation(item database.FindShelvingItemsByTitleRow) ShelvingItem {
+	return ShelvingItem{
+		MediumID:  item.MediaID,
+		MediaType: item.MediaType,
+		Title:     item.Title,
+		ImageUrl:  item.ImageUrl,
+		UnitID:    item.UnitID,
+		UnitName:  item.UnitName,
+		Row:       item.CellRow,
+		Column:    item.CellColumn,
+		PlacedAt:  item.PlacedAt,
+	}
+}
+
+// Get one of the logged user's shelving units, respond with an error if there is none
+func (cfg *apiConfig) getUserShelvingUnit(w http.ResponseWriter, r *http.Request, stringID string) (database.ShelvingUnit, bool) {
+	unitID, err := convertIdToPgtype(stringID)
+	if err != nil {
+		respondWithError(w, 400, "unit_id not in good format", err)
+		return database.ShelvingUnit{}, false
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	unit, err := cfg.db.GetUserShelvingUnitByID(r.Context(), database.GetUserShelvingUnitByIDParams{
+		ID:     unitID,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondWithError(w, 404, "no shelving unit found with given id for user", err)
+			return database.ShelvingUnit{}, false
+		}
+		respondWithError(w, 500, "couldn't get shelving unit in database", err)
+		return database.ShelvingUnit{}, false
+	}
+	return unit, true
+}
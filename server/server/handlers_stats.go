@@ -0,0 +1,296 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	defaultStatsTop = 5
+	maxStatsTop     = 50
+	maxStatsBuckets = 1000
+)
+
+type responseGetStats struct {
+	From        pgtype.Timestamp   `json:"from"`
+	To          pgtype.Timestamp   `json:"to"`
+	Bucket      string             `json:"bucket"`
+	Totals      StatsSummary       `json:"totals"`
+	ByMediaType []StatsByMediaType `json:"by_media_type"`
+	Longest     []StatsCompletion  `json:"longest"`
+	Shortest    []StatsCompletion  `json:"shortest"`
+	TopCreators []StatsCount       `json:"top_creators"`
+	TopGenres   []StatsCount       `json:"top_genres"`
+	Series      []StatsBucket      `json:"series"`
+}
+
+// GET /api/stats
+func (cfg *apiConfig) handlerGetStats(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersGetStats
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Get the time window and its series bucket
+	from, to, err := params.window(time.Now().UTC())
+	if err != nil {
+		respondWithError(w, 400, err.Error(), err)
+		return
+	}
+	bucket, err := params.seriesBucket(from, to)
+	if err != nil {
+		respondWithError(w, 400, err.Error(), err)
+		return
+	}
+	top := params.Top
+	if top == 0 {
+		top = defaultStatsTop
+	}
+	if top < 0 || top > maxStatsTop {
+		respondWithError(w, 400, fmt.Sprintf("top must be between 1 and %d", maxStatsTop), errors.New("invalid top value"))
+		return
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+	windowStart := pgtype.Timestamp{Time: from, Valid: true}
+	windowEnd := pgtype.Timestamp{Time: to, Valid: true}
+
+	response := responseGetStats{
+		From:        windowStart,
+		To:          windowEnd,
+		Bucket:      bucket,
+		ByMediaType: []StatsByMediaType{},
+		Longest:     []StatsCompletion{},
+		Shortest:    []StatsCompletion{},
+		TopCreators: []StatsCount{},
+		TopGenres:   []StatsCount{},
+		Series:      []StatsBucket{},
+	}
+
+	// Call query functions
+	totals, err := cfg.db.GetStatsTotals(r.Context(), database.GetStatsTotalsParams{
+		WindowStart: windowStart,
+		WindowEnd:   windowEnd,
+		UserID:      userID,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't get stats totals in database", err)
+		return
+	}
+	response.Totals = StatsSummary{
+		Started:        totals.Started,
+		Finished:       totals.Finished,
+		AvgDuration:    totals.AvgDuration,
+		MedianDuration: totals.MedianDuration,
+	}
+
+	byMediaType, err := cfg.db.GetStatsByMediaType(r.Context(), database.GetStatsByMediaTypeParams{
+		WindowStart: windowStart,
+		WindowEnd:   windowEnd,
+		UserID:      userID,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't get stats by media type in database", err)
+		return
+	}
+	for _, row := range byMediaType {
+		response.ByMediaType = append(response.ByMediaType, StatsByMediaType{
+			MediaType: row.MediaType,
+			StatsSummary: StatsSummary{
+				Started:        row.Started,
+				Finished:       row.Finished,
+				AvgDuration:    row.AvgDuration,
+				MedianDuration: row.MedianDuration,
+			},
+		})
+	}
+
+	longest, err := cfg.db.GetLongestCompletions(r.Context(), database.GetLongestCompletionsParams{
+		UserID:      userID,
+		WindowStart: windowStart,
+		WindowEnd:   windowEnd,
+		Top:         top,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't get longest completions in database", err)
+		return
+	}
+	for _, row := range longest {
+		response.Longest = append(response.Longest, StatsCompletion{
+			RecordID:  row.ID,
+			MediaID:   row.MediaID,
+			MediaType: row.MediaType,
+			Title:     row.Title,
+			Creator:   row.Creator,
+			StartDate: row.StartDate,
+			EndDate:   row.EndDate,
+			Duration:  row.Duration.Days,
+		})
+	}
+
+	shortest, err := cfg.db.GetShortestCompletions(r.Context(), database.GetShortestCompletionsParams{
+		UserID:      userID,
+		WindowStart: windowStart,
+		WindowEnd:   windowEnd,
+		Top:         top,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't get shortest completions in database", err)
+		return
+	}
+	for _, row := range shortest {
+		response.Shortest = append(response.Shortest, StatsCompletion{
+			RecordID:  row.ID,
+			MediaID:   row.MediaID,
+			MediaType: row.MediaType,
+			Title:     row.Title,
+			Creator:   row.Creator,
+			StartDate: row.StartDate,
+			EndDate:   row.EndDate,
+			Duration:  row.Duration.Days,
+		})
+	}
+
+	creators, err := cfg.db.GetTopCreators(r.Context(), database.GetTopCreatorsParams{
+		UserID:      userID,
+		WindowStart: windowStart,
+		WindowEnd:   windowEnd,
+		Top:         top,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't get top creators in database", err)
+		return
+	}
+	for _, row := range creators {
+		response.TopCreators = append(response.TopCreators, StatsCount{Name: row.Creator, Count: row.Count})
+	}
+
+	genres, err := cfg.db.GetTopGenres(r.Context(), database.GetTopGenresParams{
+		UserID:      userID,
+		WindowStart: windowStart,
+		WindowEnd:   windowEnd,
+		Top:         top,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't get top genres in database", err)
+		return
+	}
+	for _, row := range genres {
+		response.TopGenres = append(response.TopGenres, StatsCount{Name: row.Genre, Count: row.Count})
+	}
+
+	series, err := cfg.db.GetStatsSeries(r.Context(), database.GetStatsSeriesParams{
+		Bucket:      bucket,
+		WindowStart: windowStart,
+		WindowEnd:   windowEnd,
+		UserID:      userID,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't get stats series in database", err)
+		return
+	}
+	for _, row := range series {
+		response.Series = append(response.Series, StatsBucket{
+			BucketStart: row.BucketStart,
+			Started:     row.Started,
+			Finished:    row.Finished,
+		})
+	}
+
+	// Respond
+	respondWithJson(w, 200, response)
+}
+
+// Get the [from, to) time window matching the requested period
+func (params parametersGetStats) window(now time.Time) (time.Time, time.Time, error) {
+	return periodWindow(params.Period, params.Date, params.From, params.To, now)
+}
+
+// Get the [from, to) time window of a calendar period holding date, or between from and to for a custom one
+func periodWindow(period, date, from, to string, now time.Time) (time.Time, time.Time, error) {
+	if period == "custom" {
+		if from == "" || to == "" {
+			return time.Time{}, time.Time{}, errors.New("from and to are required for a custom period")
+		}
+		fromTime, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("from not in good format")
+		}
+		toTime, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("to not in good format")
+		}
+		if !fromTime.Before(toTime) {
+			return time.Time{}, time.Time{}, errors.New("from must be before to")
+		}
+		return fromTime.UTC(), toTime.UTC(), nil
+	}
+
+	// Other periods are the calendar week, month or year holding the given date (today by default)
+	dateTime := now
+	if date != "" {
+		parsed, err := time.Parse(time.RFC3339, date)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("date not in good format")
+		}
+		dateTime = parsed.UTC()
+	}
+	day := time.Date(dateTime.Year(), dateTime.Month(), dateTime.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch period {
+	case "week":
+		// Weeks start on monday
+		start := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		return start, start.AddDate(0, 0, 7), nil
+	case "month", "":
+		start := day.AddDate(0, 0, 1-day.Day())
+		return start, start.AddDate(0, 1, 0), nil
+	case "year":
+		start := time.Date(day.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(1, 0, 0), nil
+	default:
+		return time.Time{}, time.Time{}, fmt.Errorf("unknown period %q (week, month, year or custom)", period)
+	}
+}
+
+// Get the series bucket, by default the finest one giving a readable series for the window
+func (params parametersGetStats) seriesBucket(from, to time.Time) (string, error) {
+	bucket := params.Bucket
+	if bucket == "" {
+		switch days := to.Sub(from).Hours() / 24; {
+		case days <= 31:
+			bucket = "day"
+		case days <= 184:
+			bucket = "week"
+		default:
+			bucket = "month"
+		}
+	}
+
+	var bucketDays float64
+	switch bucket {
+	case "day":
+		bucketDays = 1
+	case "week":
+		bucketDays = 7
+	case "month":
+		bucketDays = 28
+	default:
+		return "", fmt.Errorf("unknown bucket %q (day, week or month)", bucket)
+	}
+	if to.Sub(from).Hours()/24/bucketDays > maxStatsBuckets {
+		return "", fmt.Errorf("window is too long for a series by %s (max %d buckets)", bucket, maxStatsBuckets)
+	}
+	return bucket, nil
+}
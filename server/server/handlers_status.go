@@ -0,0 +1,180 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Statuses a record can go to from each status
+// Finished and abandoned records are done with, picking the medium up again is a new consumption and so a new record
+var recordStatusTransitions = map[string][]string{
+	database.RecordStatusWishlist:   {database.RecordStatusPlanned, database.RecordStatusInProgress, database.RecordStatusFinished},
+	database.RecordStatusPlanned:    {database.RecordStatusWishlist, database.RecordStatusInProgress, database.RecordStatusFinished},
+	database.RecordStatusInProgress: {database.RecordStatusPaused, database.RecordStatusFinished, database.RecordStatusAbandoned},
+	database.RecordStatusPaused:     {database.RecordStatusInProgress, database.RecordStatusFinished, database.RecordStatusAbandoned},
+	database.RecordStatusFinished:   {},
+	database.RecordStatusAbandoned:  {},
+}
+
+// Check a status given in a request, an empty one being left to the caller
+func checkRecordStatus(status string) error {
+	if status != "" && !slices.Contains(database.RecordStatuses, status) {
+		return fmt.Errorf("status must be one of %s", strings.Join(database.RecordStatuses, ", "))
+	}
+	return nil
+}
+
+func checkStatusTransition(from, to string) error {
+	if from == to || slices.Contains(recordStatusTransitions[from], to) {
+		return nil
+	}
+	if len(recordStatusTransitions[from]) == 0 {
+		return fmt.Errorf("a %s record can't go to %s, create a new record for a new consumption", from, to)
+	}
+	return fmt.Errorf("a record can't go from %s to %s", from, to)
+}
+
+// Status a record gets when none is given, from its dates as is_finished used to be set:
+// a started record is in progress, it is finished once it has both dates
+func statusFromDates(previous string, startDate, endDate pgtype.Timestamp) string {
+	unstarted := previous == "" || previous == database.RecordStatusWishlist || previous == database.RecordStatusPlanned
+	switch {
+	case startDate.Valid && endDate.Valid && (unstarted || previous == database.RecordStatusInProgress || previous == database.RecordStatusPaused):
+		return database.RecordStatusFinished
+	case startDate.Valid && unstarted:
+		return database.RecordStatusInProgress
+	case previous == "":
+		return database.RecordStatusPlanned
+	default:
+		return previous
+	}
+}
+
+// Check record's dates against a status given by user, missing ones being set to now
+// Only started records have a start date, and only finished or abandoned ones an end date
+func checkStatusDates(status string, startDate, endDate pgtype.Timestamp) (pgtype.Timestamp, pgtype.Timestamp, error) {
+	switch status {
+	case database.RecordStatusWishlist, database.RecordStatusPlanned:
+		if startDate.Valid || endDate.Valid {
+			return startDate, endDate, fmt.Errorf("a %s record can't have a start or end date", status)
+		}
+	case database.RecordStatusInProgress, database.RecordStatusPaused:
+		if endDate.Valid {
+			return startDate, endDate, fmt.Errorf("a %s record can't have an end date", status)
+		}
+		if !startDate.Valid {
+			startDate = timestampNow()
+		}
+	case database.RecordStatusFinished, database.RecordStatusAbandoned:
+		if !endDate.Valid {
+			endDate = timestampNow()
+		}
+	}
+	return startDate, endDate, nil
+}
+
+// Abandon reason kept with the record, only abandoned records have one
+func checkAbandonReason(status string, reason *string, previous pgtype.Text) (pgtype.Text, error) {
+	if status != database.RecordStatusAbandoned {
+		if reason != nil && strings.TrimSpace(*reason) != "" {
+			return pgtype.Text{}, errors.New("only abandoned records have an abandon_reason")
+		}
+		return pgtype.Text{}, nil
+	}
+	if reason == nil {
+		return previous, nil
+	}
+	if strings.TrimSpace(*reason) == "" {
+		return pgtype.Text{}, nil
+	}
+	return pgtype.Text{String: strings.TrimSpace(*reason), Valid: true}, nil
+}
+
+// Record's duration in days, without the days it was paused between its start and end dates
+func calculateActiveDuration(startDate, endDate pgtype.Timestamp, pauses []database.RecordsPause) (pgtype.Interval, error) {
+	interval, err := calculateDuration(startDate, endDate)
+	if err != nil || !interval.Valid {
+		return interval, err
+	}
+
+	active := endDate.Time.Sub(startDate.Time)
+	for _, pause := range pauses {
+		from, to := pause.PausedAt.Time, endDate.Time
+		if pause.ResumedAt.Valid && pause.ResumedAt.Time.Before(to) {
+			to = pause.ResumedAt.Time
+		}
+		if from.Before(startDate.Time) {
+			from = startDate.Time
+		}
+		if to.After(from) {
+			active -= to.Sub(from)
+		}
+	}
+	interval.Days = int32(active.Hours() / 24)
+	return interval, nil
+}
+
+// Equivalent of NOW(), for dates set by the server
+func timestampNow() pgtype.Timestamp {
+	return pgtype.Timestamp{Time: time.Now().UTC().Truncate(time.Microsecond), Valid: true}
+}
+
+type RecordPause struct {
+	ID        pgtype.UUID      `json:"id"`
+	PausedAt  pgtype.Timestamp `json:"paused_at"`
+	ResumedAt pgtype.Timestamp `json:"resumed_at"`
+}
+
+type responseRecordPauses struct {
+	RecordID pgtype.UUID   `json:"record_id"`
+	Status   string        `json:"status"`
+	Pauses   []RecordPause `json:"pauses"`
+}
+
+// GET /api/records/pauses
+func (cfg *apiConfig) handlerGetRecordPauses(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersGetRecordPauses
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	record, ok := cfg.getUserRecord(w, r, params.RecordID)
+	if !ok {
+		return
+	}
+
+	// Call query function
+	pauses, err := cfg.db.GetRecordPauses(r.Context(), record.ID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get record's pauses in database", err)
+		return
+	}
+
+	response := responseRecordPauses{
+		RecordID: record.ID,
+		Status:   record.Status,
+		Pauses:   make([]RecordPause, 0, len(pauses)),
+	}
+	for _, pause := range pauses {
+		response.Pauses = append(response.Pauses, RecordPause{
+			ID:        pause.ID,
+			PausedAt:  pause.PausedAt,
+			ResumedAt: pause.ResumedAt,
+		})
+	}
+
+	// Respond
+	respondWithJson(w, 200, response)
+}
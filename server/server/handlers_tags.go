@@ -0,0 +1,349 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// POST /api/tags
+func (cfg *apiConfig) handlerCreateTag(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersCreateTag
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Check if name is provided
+	name := strings.TrimSpace(params.Name)
+	if name == "" {
+		respondWithError(w, 400, "a name must be provided", errors.New("empty tag name"))
+		return
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Call query function
+	tag, err := cfg.db.CreateTag(r.Context(), database.CreateTagParams{
+		UserID: userID,
+		Name:   name,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			// This is a unique constraint violation
+			respondWithError(w, 409, "user already has a tag with this name", err)
+			return
+		}
+		respondWithError(w, 500, "couldn't create tag in database", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 201, Tag{
+		ID:        tag.ID,
+		CreatedAt: tag.CreatedAt,
+		UpdatedAt: tag.UpdatedAt,
+		Name:      tag.Name,
+	})
+}
+
+type responseGetTags struct {
+	Tags []Tag `json:"tags"`
+}
+
+// GET /api/tags
+func (cfg *apiConfig) handlerGetTags(w http.ResponseWriter, r *http.Request) {
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Call query function
+	tags, err := cfg.db.GetTagsByUserID(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get tags in database", err)
+		return
+	}
+
+	response := responseGetTags{
+		Tags: make([]Tag, 0, len(tags)),
+	}
+	for _, tag := range tags {
+		response.Tags = append(response.Tags, Tag{
+			ID:         tag.ID,
+			CreatedAt:  tag.CreatedAt,
+			UpdatedAt:  tag.UpdatedAt,
+			Name:       tag.Name,
+			MediaCount: tag.MediaCount,
+		})
+	}
+
+	// Respond
+	respondWithJson(w, 200, response)
+}
+
+// PUT /api/tags
+func (cfg *apiConfig) handlerUpdateTag(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersUpdateTag
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Check if name is provided
+	name := strings.TrimSpace(params.Name)
+	if name == "" {
+		respondWithError(w, 400, "a name must be provided", errors.New("empty tag name"))
+		return
+	}
+
+	tag, ok := cfg.getUserTag(w, r, params.TagID)
+	if !ok {
+		return
+	}
+
+	// Call query function
+	updatedTag, err := cfg.db.UpdateTag(r.Context(), database.UpdateTagParams{
+		Name:   name,
+		ID:     tag.ID,
+		UserID: tag.UserID,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			respondWithError(w, 409, "user already has a tag with this name", err)
+			return
+		}
+		respondWithError(w, 500, "couldn't update tag in database", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 200, Tag{
+		ID:        updatedTag.ID,
+		CreatedAt: updatedTag.CreatedAt,
+		UpdatedAt: updatedTag.UpdatedAt,
+		Name:      updatedTag.Name,
+	})
+}
+
+// DELETE /api/tags
+func (cfg *apiConfig) handlerDeleteTag(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersTag
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	tagID, err := convertIdToPgtype(params.TagID)
+	if err != nil {
+		respondWithError(w, 400, "tag_id not in good format", err)
+		return
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Call query function, media lose the tag with it
+	count, err := cfg.db.DeleteTag(r.Context(), database.DeleteTagParams{
+		ID:     tagID,
+		UserID: userID,
+	})
+	if err != nil {
+		respondWithError(w, 500, "couldn't delete tag in database", err)
+		return
+	}
+	if count == 0 {
+		respondWithError(w, 404, "no tag found with given id for user", nil)
+		return
+	}
+
+	// Respond
+	w.WriteHeader(200)
+}
+
+type responseGroupMedia struct {
+	Count int64 `json:"count"`
+}
+
+// POST /api/tags/media
+func (cfg *apiConfig) handlerTagMedia(w http.ResponseWriter, r *http.Request) {
+	cfg.changeMediaTags(w, r, true)
+}
+
+// DELETE /api/tags/media
+func (cfg *apiConfig) handlerUntagMedia(w http.ResponseWriter, r *http.Request) {
+	cfg.changeMediaTags(w, r, false)
+}
+
+// Put several tags on several media at once, or take them off
+func (cfg *apiConfig) changeMediaTags(w http.ResponseWriter, r *http.Request, add bool) {
+
+	// Parse data from request body
+	var params parametersTagMedia
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	// Check if all required fields are provided
+	if len(params.TagIDs) == 0 || len(params.MediumIDs) == 0 {
+		respondWithError(w, 400, "tag_ids and medium_ids must be provided", errors.New("empty tag_ids or medium_ids"))
+		return
+	}
+
+	// Every tag and medium is checked before any change is made
+	tags := make([]database.Tag, 0, len(params.TagIDs))
+	for _, stringID := range params.TagIDs {
+		tag, ok := cfg.getUserTag(w, r, stringID)
+		if !ok {
+			return
+		}
+		tags = append(tags, tag)
+	}
+	mediumIDs, ok := cfg.getUserMediumIDs(w, r, params.MediumIDs, add)
+	if !ok {
+		return
+	}
+
+	var response responseGroupMedia
+	for _, tag := range tags {
+		var count int64
+		if add {
+			count, err = cfg.db.TagMedia(r.Context(), database.TagMediaParams{
+				TagID:    tag.ID,
+				MediaIds: mediumIDs,
+			})
+		} else {
+			count, err = cfg.db.UntagMedia(r.Context(), database.UntagMediaParams{
+				TagID:    tag.ID,
+				MediaIds: mediumIDs,
+			})
+		}
+		if err != nil {
+			respondWithError(w, 500, "couldn't change media's tags in database", err)
+			return
+		}
+		response.Count += count
+	}
+
+	// Respond
+	respondWithJson(w, 200, response)
+}
+
+// Get one of the logged user's tags, respond with an error if there is none
+func (cfg *apiConfig) getUserTag(w http.ResponseWriter, r *http.Request, stringID string) (database.Tag, bool) {
+	tagID, err := convertIdToPgtype(stringID)
+	if err != nil {
+		respondWithError(w, 400, "tag_id not in good format", err)
+		return database.Tag{}, false
+	}
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	tag, err := cfg.db.GetUserTagByID(r.Context(), database.GetUserTagByIDParams{
+		ID:     tagID,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondWithError(w, 404, "no tag found with given id for user", err)
+			return database.Tag{}, false
+		}
+		respondWithError(w, 500, "couldn't get tag in database", err)
+		return database.Tag{}, false
+	}
+	return tag, true
+}
+
+// Convert media IDs, following merge redirects
+// Media being grouped must be in the logged user's shelf, ungrouped ones may have left it since
+func (cfg *apiConfig) getUserMediumIDs(w http.ResponseWriter, r *http.Request, stringIDs []string, inShelf bool) ([]pgtype.UUID, bool) {
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	mediumIDs := make([]pgtype.UUID, 0, len(stringIDs))
+	for _, stringID := range stringIDs {
+		mediumID, err := convertIdToPgtype(stringID)
+		if err != nil {
+			respondWithError(w, 400, "medium_id not in good format", err)
+			return nil, false
+		}
+		mediumID, err = cfg.resolveMediumID(r.Context(), mediumID)
+		if err != nil {
+			respondWithError(w, 500, "couldn't get medium redirect in database", err)
+			return nil, false
+		}
+		if inShelf {
+			count, err := cfg.db.CountUserRecordsByMediumID(r.Context(), database.CountUserRecordsByMediumIDParams{
+				MediaID: mediumID,
+				UserID:  userID,
+			})
+			if err != nil {
+				respondWithError(w, 500, "couldn't count user's records in database", err)
+				return nil, false
+			}
+			if count == 0 {
+				respondWithError(w, 404, "no medium found with given id in user's shelf", errors.New("user has no record for this medium"))
+				return nil, false
+			}
+		}
+		mediumIDs = append(mediumIDs, mediumID)
+	}
+	return mediumIDs, true
+}
+
+// User's tags names and custom shelves IDs, by medium
+type mediaGroups struct {
+	tags     map[pgtype.UUID][]string
+	shelfIDs map[pgtype.UUID][]pgtype.UUID
+}
+
+func (cfg *apiConfig) getUserMediaGroups(ctx context.Context, userID pgtype.UUID) (mediaGroups, error) {
+	groups := mediaGroups{
+		tags:     make(map[pgtype.UUID][]string),
+		shelfIDs: make(map[pgtype.UUID][]pgtype.UUID),
+	}
+
+	mediaTags, err := cfg.db.GetUserMediaTags(ctx, userID)
+	if err != nil {
+		return mediaGroups{}, err
+	}
+	for _, row := range mediaTags {
+		groups.tags[row.MediaID] = append(groups.tags[row.MediaID], row.Name)
+	}
+
+	shelvesMedia, err := cfg.db.GetUserShelvesMedia(ctx, userID)
+	if err != nil {
+		return mediaGroups{}, err
+	}
+	for _, row := range shelvesMedia {
+		groups.shelfIDs[row.MediaID] = append(groups.shelfIDs[row.MediaID], row.ShelfID)
+	}
+	return groups, nil
+}
+
+func (groups mediaGroups) fill(mediumRecord *MediumWithRecord) {
+	mediumRecord.Tags = groups.tags[mediumRecord.MediaID]
+	mediumRecord.ShelfIDs = groups.shelfIDs[mediumRecord.MediaID]
+}
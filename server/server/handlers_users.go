@@ -78,6 +78,7 @@ func (cfg *apiConfig) handlerCreateUser(w http.ResponseWriter, r *http.Request)
 			UpdatedAt: user.UpdatedAt,
 			Username:  user.Username,
 			Email:     user.Email,
+			Role:      user.Role,
 		},
 	})
 }
@@ -111,6 +112,7 @@ func (cfg *apiConfig) handlerGetUserByID(w http.ResponseWriter, r *http.Request)
 			UpdatedAt: user.UpdatedAt,
 			Username:  user.Username,
 			Email:     user.Email,
+			Role:      user.Role,
 		},
 	})
 
@@ -198,6 +200,7 @@ func (cfg *apiConfig) handlerUpdateUser(w http.ResponseWriter, r *http.Request)
 			UpdatedAt: user.UpdatedAt,
 			Username:  user.Username,
 			Email:     user.Email,
+			Role:      user.Role,
 		},
 	})
 }
@@ -0,0 +1,352 @@
+package server
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Record statuses of the games still to play, wished for games not being got yet
+var videogameBacklogStatuses = []string{
+	database.RecordStatusPlanned,
+	database.RecordStatusInProgress,
+	database.RecordStatusPaused,
+}
+
+// Platforms a videogame was released on, from its metadata
+func metadataPlatforms(metadata []byte) []string {
+	metadataMap, err := bytesToMap(metadata)
+	if err != nil {
+		return nil
+	}
+	values, _ := metadataMap["platforms"].([]interface{})
+	var platforms []string
+	for _, value := range values {
+		if platform, ok := value.(string); ok && strings.TrimSpace(platform) != "" {
+			platforms = append(platforms, platform)
+		}
+	}
+	return platforms
+}
+
+// Platform a record was played on, falling back on medium's only platform.
+// It is empty when it isn't known
+func recordPlatform(platform pgtype.Text, metadata []byte) string {
+	if platform.Valid && platform.String != "" {
+		return platform.String
+	}
+	if platforms := metadataPlatforms(metadata); len(platforms) == 1 {
+		return platforms[0]
+	}
+	return ""
+}
+
+// Check a videogame record's details and turn them into the store's parameters.
+// A platform of the medium's metadata is spelled as there, so that stats don't split it.
+// An error is returned when the details aren't valid, to be sent back as it is
+func (params parametersRecordVideogame) toUpsertParams(medium database.Medium) (database.UpsertRecordVideogameParams, error) {
+	platform := strings.TrimSpace(params.Platform)
+	for _, known := range metadataPlatforms(medium.Metadata) {
+		if strings.EqualFold(known, platform) {
+			platform = known
+		}
+	}
+
+	var hoursPlayed pgtype.Float8
+	if params.HoursPlayed != nil {
+		if *params.HoursPlayed < 0 {
+			return database.UpsertRecordVideogameParams{}, errors.New("hours_played can't be negative")
+		}
+		hoursPlayed = pgtype.Float8{Float64: *params.HoursPlayed, Valid: true}
+	}
+
+	if params.Completion != "" && !slices.Contains(database.VideogameCompletions, params.Completion) {
+		return database.UpsertRecordVideogameParams{}, fmt.Errorf("completion must be one of %s", strings.Join(database.VideogameCompletions, ", "))
+	}
+
+	var earned, total pgtype.Int4
+	if params.AchievementsEarned != nil {
+		if *params.AchievementsEarned < 0 {
+			return database.UpsertRecordVideogameParams{}, errors.New("achievements_earned can't be negative")
+		}
+		earned = pgtype.Int4{Int32: *params.AchievementsEarned, Valid: true}
+	}
+	if params.AchievementsTotal != nil {
+		if *params.AchievementsTotal < 0 {
+			return database.UpsertRecordVideogameParams{}, errors.New("achievements_total can't be negative")
+		}
+		total = pgtype.Int4{Int32: *params.AchievementsTotal, Valid: true}
+	}
+	if earned.Valid && total.Valid && earned.Int32 > total.Int32 {
+		return database.UpsertRecordVideogameParams{}, errors.New("achievements_earned can't be more than achievements_total")
+	}
+
+	return database.UpsertRecordVideogameParams{
+		Platform:           platform,
+		HoursPlayed:        hoursPlayed,
+		Completion:         params.Completion,
+		AchievementsEarned: earned,
+		AchievementsTotal:  total,
+	}, nil
+}
+
+func recordVideogameResponse(details database.RecordsVideogame) RecordVideogame {
+	return RecordVideogame{
+		RecordID:           details.RecordID,
+		UpdatedAt:          details.UpdatedAt,
+		Platform:           details.Platform,
+		HoursPlayed:        details.HoursPlayed,
+		Completion:         details.Completion,
+		AchievementsEarned: details.AchievementsEarned,
+		AchievementsTotal:  details.AchievementsTotal,
+	}
+}
+
+// Logged user's record of a videogame with its medium, responding with an error if it isn't one
+func (cfg *apiConfig) getVideogameRecord(w http.ResponseWriter, r *http.Request, stringID string) (database.UsersMediaRecord, database.Medium, bool) {
+	record, ok := cfg.getUserRecord(w, r, stringID)
+	if !ok {
+		return database.UsersMediaRecord{}, database.Medium{}, false
+	}
+	medium, ok := cfg.getMedium(w, r, record.MediaID)
+	if !ok {
+		return database.UsersMediaRecord{}, database.Medium{}, false
+	}
+	if medium.MediaType != "videogame" {
+		respondWithError(w, 400, "only records of videogames have videogame details", errors.New("record of a medium not a videogame"))
+		return database.UsersMediaRecord{}, database.Medium{}, false
+	}
+	return record, medium, true
+}
+
+// PUT /api/records/videogame
+func (cfg *apiConfig) handlerUpdateRecordVideogame(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersRecordVideogame
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	record, medium, ok := cfg.getVideogameRecord(w, r, params.RecordID)
+	if !ok {
+		return
+	}
+	upsertParams, err := params.toUpsertParams(medium)
+	if err != nil {
+		respondWithError(w, 400, err.Error(), err)
+		return
+	}
+
+	// Call query function, every detail is replaced
+	upsertParams.RecordID = record.ID
+	details, err := cfg.db.UpsertRecordVideogame(r.Context(), upsertParams)
+	if err != nil {
+		respondWithError(w, 500, "couldn't update record's videogame details in database", err)
+		return
+	}
+
+	// Respond
+	respondWithJson(w, 200, recordVideogameResponse(details))
+}
+
+// GET /api/records/videogame
+func (cfg *apiConfig) handlerGetRecordVideogame(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersGetRecordVideogame
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	record, _, ok := cfg.getVideogameRecord(w, r, params.RecordID)
+	if !ok {
+		return
+	}
+
+	// Call query function, a record without details yet gets empty ones
+	details, err := cfg.db.GetRecordVideogame(r.Context(), record.ID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			respondWithError(w, 500, "couldn't get record's videogame details in database", err)
+			return
+		}
+		details = database.RecordsVideogame{RecordID: record.ID}
+	}
+
+	// Respond
+	respondWithJson(w, 200, recordVideogameResponse(details))
+}
+
+// Stats of the given videogame records by platform, most played platform first and unknown platform last.
+// Platforms are told apart case insensitively
+func platformStats(rows []database.GetVideogameRecordsByUserIDRow) []PlatformStats {
+	var stats []PlatformStats
+	indexes := make(map[string]int)
+	for _, row := range rows {
+		platform := recordPlatform(row.Platform, row.Metadata)
+		key := strings.ToLower(platform)
+		i, ok := indexes[key]
+		if !ok {
+			i = len(stats)
+			indexes[key] = i
+			stats = append(stats, PlatformStats{
+				Platform:    platform,
+				Completions: map[string]int64{},
+			})
+			for _, completion := range database.VideogameCompletions {
+				stats[i].Completions[completion] = 0
+			}
+		}
+
+		stats[i].GamesCount++
+		switch {
+		case row.Status == database.RecordStatusFinished:
+			stats[i].FinishedCount++
+		case slices.Contains(videogameBacklogStatuses, row.Status):
+			stats[i].BacklogCount++
+		}
+		if row.HoursPlayed.Valid {
+			stats[i].HoursPlayed += row.HoursPlayed.Float64
+		}
+		if row.Completion.Valid && row.Completion.String != "" {
+			stats[i].Completions[row.Completion.String]++
+		}
+		if row.AchievementsEarned.Valid {
+			stats[i].AchievementsEarned += int64(row.AchievementsEarned.Int32)
+		}
+		if row.AchievementsTotal.Valid {
+			stats[i].AchievementsTotal += int64(row.AchievementsTotal.Int32)
+		}
+	}
+
+	slices.SortStableFunc(stats, func(a, b PlatformStats) int {
+		if (a.Platform == "") != (b.Platform == "") {
+			if a.Platform == "" {
+				return 1
+			}
+			return -1
+		}
+		if a.HoursPlayed != b.HoursPlayed {
+			if a.HoursPlayed > b.HoursPlayed {
+				return -1
+			}
+			return 1
+		}
+		if a.GamesCount != b.GamesCount {
+			return int(b.GamesCount - a.GamesCount)
+		}
+		return strings.Compare(strings.ToLower(a.Platform), strings.ToLower(b.Platform))
+	})
+	return stats
+}
+
+type responseGetPlatformStats struct {
+	Platforms []PlatformStats `json:"platforms"`
+}
+
+// GET /api/videogames/platforms
+func (cfg *apiConfig) handlerGetPlatformStats(w http.ResponseWriter, r *http.Request) {
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Call query function
+	rows, err := cfg.db.GetVideogameRecordsByUserID(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get user's videogame records in database", err)
+		return
+	}
+
+	response := responseGetPlatformStats{
+		Platforms: platformStats(rows),
+	}
+	if response.Platforms == nil {
+		response.Platforms = []PlatformStats{}
+	}
+
+	// Respond
+	respondWithJson(w, 200, response)
+}
+
+type responseGetVideogameBacklog struct {
+	Platforms []PlatformBacklog `json:"platforms"`
+}
+
+// GET /api/videogames/backlog
+func (cfg *apiConfig) handlerGetVideogameBacklog(w http.ResponseWriter, r *http.Request) {
+
+	// Parse data from request body
+	var params parametersGetVideogameBacklog
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+	params.Platform = strings.TrimSpace(params.Platform)
+
+	// Get userID from access token
+	userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+	// Call query function
+	rows, err := cfg.db.GetVideogameRecordsByUserID(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, 500, "couldn't get user's videogame records in database", err)
+		return
+	}
+
+	// Games keep their title order within a platform
+	response := responseGetVideogameBacklog{
+		Platforms: []PlatformBacklog{},
+	}
+	indexes := make(map[string]int)
+	for _, row := range rows {
+		if !slices.Contains(videogameBacklogStatuses, row.Status) {
+			continue
+		}
+		platform := recordPlatform(row.Platform, row.Metadata)
+		if params.Platform != "" && !strings.EqualFold(platform, params.Platform) {
+			continue
+		}
+		key := strings.ToLower(platform)
+		i, ok := indexes[key]
+		if !ok {
+			i = len(response.Platforms)
+			indexes[key] = i
+			response.Platforms = append(response.Platforms, PlatformBacklog{Platform: platform})
+		}
+		response.Platforms[i].Games = append(response.Platforms[i].Games, BacklogGame{
+			RecordID:    row.ID,
+			MediumID:    row.MediaID,
+			Title:       row.Title,
+			ImageUrl:    row.ImageUrl,
+			Status:      row.Status,
+			HoursPlayed: row.HoursPlayed,
+		})
+	}
+
+	// Platforms by name, unknown platform last
+	slices.SortFunc(response.Platforms, func(a, b PlatformBacklog) int {
+		if (a.Platform == "") != (b.Platform == "") {
+			if a.Platform == "" {
+				return 1
+			}
+			return -1
+		}
+		return strings.Compare(strings.ToLower(a.Platform), strings.ToLower(b.Platform))
+	})
+
+	// Respond
+	respondWithJson(w, 200, response)
+}
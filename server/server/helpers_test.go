@@ -1,9 +1,11 @@
 package server
 
 import (
+	"math"
 	"testing"
 	"time"
 
+	"github.com/VincNT21/kallaxy/server/internal/database"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -89,3 +91,462 @@ func TestCalculateDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestEstimateFinishDate(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(t time.Time) pgtype.Timestamp { return pgtype.Timestamp{Time: t, Valid: true} }
+	completion := func(days int, percent float64) RecordProgress {
+		return RecordProgress{LoggedAt: at(start.AddDate(0, 0, days)), Completion: pgtype.Float8{Float64: percent, Valid: true}}
+	}
+
+	// Create tests table
+	tests := []struct {
+		name     string
+		status   string
+		progress []RecordProgress
+		wantPace float64
+		want     pgtype.Timestamp
+	}{
+		{name: "No progress"},
+		{name: "Half way in ten days", status: database.RecordStatusInProgress, progress: []RecordProgress{completion(0, 0), completion(10, 50)}, wantPace: 5, want: at(start.AddDate(0, 0, 20))},
+		{name: "Finished", status: database.RecordStatusFinished, progress: []RecordProgress{completion(0, 0), completion(10, 50)}, wantPace: 5},
+		{name: "No progress made", status: database.RecordStatusInProgress, progress: []RecordProgress{completion(0, 50), completion(10, 50)}},
+		{name: "Finish too far away", status: database.RecordStatusInProgress, progress: []RecordProgress{completion(0, 0), completion(10, 0.001)}, wantPace: 0.0001},
+		{name: "Tiny pace", status: database.RecordStatusInProgress, progress: []RecordProgress{completion(0, 0), completion(3650, 1e-12)}, wantPace: 1e-12 / 3650},
+	}
+
+	// Test loop
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := database.UsersMediaRecord{Status: tt.status}
+			pace, got := estimateFinishDate(record, tt.progress)
+			if math.Abs(pace-tt.wantPace) > 1e-9 {
+				t.Errorf("estimateFinishDate() pace = %v, want %v", pace, tt.wantPace)
+			}
+			if got != tt.want {
+				t.Errorf("estimateFinishDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateActiveDuration(t *testing.T) {
+	day := func(d int) pgtype.Timestamp {
+		return pgtype.Timestamp{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, d), Valid: true}
+	}
+	pause := func(from int, to pgtype.Timestamp) database.RecordsPause {
+		return database.RecordsPause{PausedAt: day(from), ResumedAt: to}
+	}
+
+	// Create tests table
+	tests := []struct {
+		name           string
+		pauses         []database.RecordsPause
+		wantDaysResult int32
+	}{
+		{
+			name:           "No pause",
+			wantDaysResult: 30,
+		},
+		{
+			name:           "Two pauses",
+			pauses:         []database.RecordsPause{pause(5, day(10)), pause(20, day(22))},
+			wantDaysResult: 23,
+		},
+		{
+			name:           "Pause still open when finished",
+			pauses:         []database.RecordsPause{pause(25, pgtype.Timestamp{})},
+			wantDaysResult: 25,
+		},
+		{
+			name:           "Pause before start date",
+			pauses:         []database.RecordsPause{pause(-10, day(2))},
+			wantDaysResult: 28,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			interval, err := calculateActiveDuration(day(0), day(30), tc.pauses)
+			if err != nil {
+				t.Fatalf("calculateActiveDuration() error = %v", err)
+			}
+			if !interval.Valid || interval.Days != tc.wantDaysResult {
+				t.Errorf("calculateActiveDuration() interval = %+v, wantDaysResult = %v", interval, tc.wantDaysResult)
+			}
+		})
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	// Create tests table
+	tests := []struct {
+		name     string
+		oldText  string
+		newText  string
+		wantDiff string
+	}{
+		{
+			name:     "First revision",
+			newText:  "a\nb",
+			wantDiff: "+ a\n+ b",
+		},
+		{
+			name:     "Line changed in the middle",
+			oldText:  "a\nb\nc",
+			newText:  "a\nB\nc",
+			wantDiff: "  a\n- b\n+ B\n  c",
+		},
+		{
+			name:     "Lines added and removed",
+			oldText:  "a\nb\nc\nd",
+			newText:  "b\nc\ne\nd",
+			wantDiff: "- a\n  b\n  c\n+ e\n  d",
+		},
+		{
+			name:     "Same text",
+			oldText:  "a\nb",
+			newText:  "a\nb",
+			wantDiff: "  a\n  b",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := diffLines(tc.oldText, tc.newText); diff != tc.wantDiff {
+				t.Errorf("diffLines() = %q, wantDiff = %q", diff, tc.wantDiff)
+			}
+		})
+	}
+}
+
+func TestSplitReviewBody(t *testing.T) {
+	blocks := splitReviewBody("Intro\n:::spoiler Twist\nHidden\n:::\nOutro\n:::SPOILER\nUnclosed")
+	want := []reviewBlock{
+		{text: "Intro"},
+		{spoiler: true, label: "Twist", text: "Hidden"},
+		{text: "Outro"},
+		{spoiler: true, text: "Unclosed"},
+	}
+	if len(blocks) != len(want) {
+		t.Fatalf("splitReviewBody() = %+v, want %+v", blocks, want)
+	}
+	for i := range want {
+		if blocks[i] != want[i] {
+			t.Errorf("splitReviewBody() block %d = %+v, want %+v", i, blocks[i], want[i])
+		}
+	}
+}
+
+func TestDaysOverdue(t *testing.T) {
+	now := time.Date(2025, 5, 10, 12, 0, 0, 0, time.UTC)
+	at := func(t time.Time) pgtype.Timestamp { return pgtype.Timestamp{Time: t, Valid: true} }
+
+	// Create tests table
+	tests := []struct {
+		name       string
+		dueAt      pgtype.Timestamp
+		returnedAt pgtype.Timestamp
+		want       int32
+	}{
+		{name: "No due date", want: 0},
+		{name: "Due later", dueAt: at(now.AddDate(0, 0, 1)), want: 0},
+		{name: "Due an hour ago", dueAt: at(now.Add(-time.Hour)), want: 0},
+		{name: "Due 36 hours ago", dueAt: at(now.Add(-36 * time.Hour)), want: 1},
+		{name: "Due three days ago", dueAt: at(now.AddDate(0, 0, -3)), want: 3},
+		{name: "Returned late", dueAt: at(now.AddDate(0, 0, -3)), returnedAt: at(now), want: 0},
+	}
+
+	// Test loop
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := daysOverdue(tt.dueAt, tt.returnedAt, now); got != tt.want {
+				t.Errorf("daysOverdue() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPriceToCents(t *testing.T) {
+	// Create tests table
+	tests := []struct {
+		name    string
+		price   float64
+		want    int64
+		wantErr bool
+	}{
+		{name: "Whole price", price: 20, want: 2000},
+		{name: "Price with cents", price: 24.99, want: 2499},
+		{name: "Rounded to nearest cent", price: 0.125, want: 13},
+		{name: "Free", price: 0, want: 0},
+		{name: "Negative", price: -0.01, wantErr: true},
+		{name: "Too high", price: 2e12, wantErr: true},
+	}
+
+	// Test loop
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := priceToCents(tt.price)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("priceToCents() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("priceToCents() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShelvingUnitDetails(t *testing.T) {
+	// Create tests table
+	tests := []struct {
+		name    string
+		params  parametersShelvingUnitDetails
+		want    parametersShelvingUnitDetails
+		wantErr bool
+	}{
+		{name: "Kallax cube by default", params: parametersShelvingUnitDetails{Name: " Living room ", Rows: 4, Columns: 4}, want: parametersShelvingUnitDetails{Name: "Living room", Rows: 4, Columns: 4, CellWidthCm: 33, CellHeightCm: 33, CellDepthCm: 39}},
+		{name: "Given dimensions kept", params: parametersShelvingUnitDetails{Name: "Billy", Rows: 6, Columns: 1, CellWidthCm: 76, CellDepthCm: 26}, want: parametersShelvingUnitDetails{Name: "Billy", Rows: 6, Columns: 1, CellWidthCm: 76, CellHeightCm: 33, CellDepthCm: 26}},
+		{name: "Largest grid", params: parametersShelvingUnitDetails{Name: "Wall", Rows: 20, Columns: 20}, want: parametersShelvingUnitDetails{Name: "Wall", Rows: 20, Columns: 20, CellWidthCm: 33, CellHeightCm: 33, CellDepthCm: 39}},
+		{name: "Missing name", params: parametersShelvingUnitDetails{Rows: 4, Columns: 4}, wantErr: true},
+		{name: "No columns", params: parametersShelvingUnitDetails{Name: "Kallax", Rows: 4}, wantErr: true},
+		{name: "Too many rows", params: parametersShelvingUnitDetails{Name: "Kallax", Rows: 21, Columns: 4}, wantErr: true},
+		{name: "Negative dimension", params: parametersShelvingUnitDetails{Name: "Kallax", Rows: 4, Columns: 4, CellHeightCm: -33}, wantErr: true},
+	}
+
+	// Test loop
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.params.toShelvingUnitDetails()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("toShelvingUnitDetails() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("toShelvingUnitDetails() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHIndex(t *testing.T) {
+	// Create tests table
+	tests := []struct {
+		name       string
+		counts     []int64
+		wantH      int64
+		wantToNext int64
+	}{
+		{name: "No play", counts: nil, wantH: 0, wantToNext: 1},
+		{name: "One game played once", counts: []int64{1}, wantH: 1, wantToNext: 3},
+		{name: "One game played a lot", counts: []int64{50}, wantH: 1, wantToNext: 2},
+		{name: "Exact square", counts: []int64{3, 3, 3}, wantH: 3, wantToNext: 7},
+		{name: "Long tail", counts: []int64{9, 6, 4, 2, 1, 1}, wantH: 3, wantToNext: 2},
+	}
+
+	// Test loop
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := hIndex(tt.counts)
+			if h != tt.wantH {
+				t.Fatalf("hIndex() = %d, want %d", h, tt.wantH)
+			}
+			if got := playsToNextHIndex(tt.counts, h); got != tt.wantToNext {
+				t.Errorf("playsToNextHIndex() = %d, want %d", got, tt.wantToNext)
+			}
+		})
+	}
+}
+
+func TestDefaultWinners(t *testing.T) {
+	score := func(value int32) pgtype.Int4 { return pgtype.Int4{Int32: value, Valid: true} }
+
+	// Create tests table
+	tests := []struct {
+		name    string
+		players []database.SavePlayPlayerParams
+		want    []bool
+	}{
+		{name: "Best score wins", players: []database.SavePlayPlayerParams{{Score: score(7)}, {Score: score(12)}, {Score: score(9)}}, want: []bool{false, true, false}},
+		{name: "Tie at the top", players: []database.SavePlayPlayerParams{{Score: score(12)}, {Score: score(12)}, {}}, want: []bool{true, true, false}},
+		{name: "Negative scores", players: []database.SavePlayPlayerParams{{Score: score(-4)}, {Score: score(-1)}}, want: []bool{false, true}},
+		{name: "Given winner kept", players: []database.SavePlayPlayerParams{{Score: score(7), IsWinner: true}, {Score: score(12)}}, want: []bool{true, false}},
+		{name: "No score", players: []database.SavePlayPlayerParams{{}, {}}, want: []bool{false, false}},
+	}
+
+	// Test loop
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defaultWinners(tt.players)
+			for i, player := range tt.players {
+				if player.IsWinner != tt.want[i] {
+					t.Errorf("defaultWinners() player %d IsWinner = %v, want %v", i, player.IsWinner, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRecordPlatform(t *testing.T) {
+	platform := func(value string) pgtype.Text { return pgtype.Text{String: value, Valid: true} }
+
+	// Create tests table
+	tests := []struct {
+		name     string
+		platform pgtype.Text
+		metadata string
+		want     string
+	}{
+		{name: "Given platform", platform: platform("PC"), metadata: `{"platforms": ["Nintendo Switch"]}`, want: "PC"},
+		{name: "Only platform of medium", platform: pgtype.Text{}, metadata: `{"platforms": ["PC"]}`, want: "PC"},
+		{name: "Empty platform falls back", platform: platform(""), metadata: `{"platforms": ["PC"]}`, want: "PC"},
+		{name: "Several platforms of medium", platform: pgtype.Text{}, metadata: `{"platforms": ["PC", "Nintendo Switch"]}`, want: ""},
+		{name: "No metadata", platform: pgtype.Text{}, metadata: `{}`, want: ""},
+	}
+
+	// Test loop
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := recordPlatform(tt.platform, []byte(tt.metadata)); got != tt.want {
+				t.Errorf("recordPlatform() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextEpisode(t *testing.T) {
+	seasons := metadataSeasons([]byte(`{"number_of_episodes_per_season": ["Season 1 counts 3 episodes", "Season 2 counts 2 episodes"]}`))
+	watched := func(keys ...[2]int32) []database.RecordsEpisode {
+		var episodes []database.RecordsEpisode
+		for _, key := range keys {
+			episodes = append(episodes, database.RecordsEpisode{SeasonNumber: key[0], EpisodeNumber: key[1]})
+		}
+		return episodes
+	}
+
+	// Create tests table
+	tests := []struct {
+		name     string
+		seasons  []SeriesSeason
+		episodes []database.RecordsEpisode
+		want     [2]int32
+		wantOk   bool
+	}{
+		{name: "Not started", seasons: seasons, want: [2]int32{1, 1}, wantOk: true},
+		{name: "Within a season", seasons: seasons, episodes: watched([2]int32{1, 1}), want: [2]int32{1, 2}, wantOk: true},
+		{name: "Skipped episodes are left behind", seasons: seasons, episodes: watched([2]int32{1, 2}), want: [2]int32{1, 3}, wantOk: true},
+		{name: "Next season", seasons: seasons, episodes: watched([2]int32{1, 1}, [2]int32{1, 2}, [2]int32{1, 3}), want: [2]int32{2, 1}, wantOk: true},
+		{name: "Every episode watched", seasons: seasons, episodes: watched([2]int32{2, 2}), wantOk: false},
+		{name: "Seasons unknown", episodes: watched([2]int32{3, 7}), want: [2]int32{3, 8}, wantOk: true},
+	}
+
+	// Test loop
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			season, episode, ok := nextEpisode(tt.seasons, tt.episodes)
+			if ok != tt.wantOk || (ok && [2]int32{season, episode} != tt.want) {
+				t.Errorf("nextEpisode() = S%dE%d %v, want S%dE%d %v", season, episode, ok, tt.want[0], tt.want[1], tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestStatusFromEpisodes(t *testing.T) {
+	// Create tests table
+	tests := []struct {
+		name       string
+		previous   string
+		watched    int32
+		total      int32
+		unwatching bool
+		want       string
+	}{
+		{name: "First episode starts record", previous: "planned", watched: 1, total: 10, want: "in_progress"},
+		{name: "Episode resumes paused record", previous: "paused", watched: 4, total: 10, want: "in_progress"},
+		{name: "Last episode finishes record", previous: "in_progress", watched: 10, total: 10, want: "finished"},
+		{name: "Total unknown", previous: "in_progress", watched: 10, total: 0, want: "in_progress"},
+		{name: "Finished record stays finished", previous: "finished", watched: 1, total: 10, want: "finished"},
+		{name: "Unwatching reopens finished record", previous: "finished", watched: 9, total: 10, unwatching: true, want: "in_progress"},
+		{name: "Unwatching keeps in progress record", previous: "in_progress", watched: 0, total: 10, unwatching: true, want: "in_progress"},
+		{name: "Abandoned record", previous: "abandoned", watched: 10, total: 10, want: "abandoned"},
+	}
+
+	// Test loop
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := statusFromEpisodes(tt.previous, tt.watched, tt.total, tt.unwatching); got != tt.want {
+				t.Errorf("statusFromEpisodes() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoalPace(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	halfway := start.Add(end.Sub(start) / 2)
+
+	// Create tests table
+	tests := []struct {
+		name         string
+		progress     float64
+		now          time.Time
+		wantExpected float64
+		wantStatus   string
+	}{
+		{name: "Ahead", progress: 30, now: halfway, wantExpected: 25, wantStatus: "ahead"},
+		{name: "On pace", progress: 25, now: halfway, wantExpected: 25, wantStatus: "ahead"},
+		{name: "Behind", progress: 10, now: halfway, wantExpected: 25, wantStatus: "behind"},
+		{name: "Completed", progress: 50, now: halfway, wantExpected: 25, wantStatus: "completed"},
+		{name: "Not started yet", progress: 0, now: start.AddDate(0, -1, 0), wantExpected: 0, wantStatus: "upcoming"},
+		{name: "Period over", progress: 49, now: end, wantExpected: 50, wantStatus: "missed"},
+		{name: "Completed after period", progress: 52, now: end.AddDate(0, 1, 0), wantExpected: 50, wantStatus: "completed"},
+	}
+
+	// Test loop
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected, status := goalPace(50, tt.progress, start, end, tt.now)
+			if expected != tt.wantExpected || status != tt.wantStatus {
+				t.Errorf("goalPace() = %v %q, want %v %q", expected, status, tt.wantExpected, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestActivityStreak(t *testing.T) {
+	// Wednesday
+	now := time.Date(2025, 6, 11, 15, 0, 0, 0, time.UTC)
+	days := func(offsets ...int) []time.Time {
+		var result []time.Time
+		for _, offset := range offsets {
+			result = append(result, time.Date(2025, 6, 11-offset, 0, 0, 0, 0, time.UTC))
+		}
+		return result
+	}
+
+	// Create tests table
+	tests := []struct {
+		name          string
+		days          []time.Time
+		unit          string
+		wantCurrent   int
+		wantActiveNow bool
+		wantLongest   int
+	}{
+		{name: "No activity", unit: "day"},
+		{name: "Active today", days: days(0, 1, 2, 5), unit: "day", wantCurrent: 3, wantActiveNow: true, wantLongest: 3},
+		{name: "Active until yesterday", days: days(1, 2, 4, 5, 6, 7), unit: "day", wantCurrent: 2, wantLongest: 4},
+		{name: "Streak broken", days: days(2, 3), unit: "day", wantCurrent: 0, wantLongest: 2},
+		{name: "Weeks", days: days(0, 3, 8, 20), unit: "week", wantCurrent: 2, wantActiveNow: true, wantLongest: 2},
+		{name: "Active last week", days: days(4, 11), unit: "week", wantCurrent: 2, wantLongest: 2},
+	}
+
+	// Test loop
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := activityStreak(tt.days, tt.unit, now)
+			if got.Current != tt.wantCurrent || got.ActiveNow != tt.wantActiveNow || got.Longest != tt.wantLongest {
+				t.Errorf("activityStreak() = current %d active %v longest %d, want current %d active %v longest %d",
+					got.Current, got.ActiveNow, got.Longest, tt.wantCurrent, tt.wantActiveNow, tt.wantLongest)
+			}
+		})
+	}
+}
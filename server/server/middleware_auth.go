@@ -2,9 +2,12 @@ package server
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"net/http"
 
 	"github.com/VincNT21/kallaxy/server/internal/auth"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type contextKey string
@@ -28,8 +31,48 @@ func (cfg *apiConfig) authMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
+		// User is checked on every request, so a deactivated account loses access right away
+		user, err := cfg.db.GetUserByID(r.Context(), userID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				respondWithError(w, 401, "user linked to access token doesn't exist anymore", err)
+				return
+			}
+			respondWithError(w, 500, "couldn't get user info from DB", err)
+			return
+		}
+		if user.DeactivatedAt.Valid {
+			respondWithError(w, 403, "this account has been deactivated", errors.New("user account is deactivated"))
+			return
+		}
+
 		// Add user ID to request context
 		ctx := context.WithValue(r.Context(), userIDKey, userID)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// Same as authMiddleware, but only lets active admins through
+func (cfg *apiConfig) adminMiddleware(next http.Handler) http.Handler {
+
+	return cfg.authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID := r.Context().Value(userIDKey).(pgtype.UUID)
+
+		// Role is checked on every request, so a demoted admin loses access right away
+		user, err := cfg.db.GetUserByID(r.Context(), userID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				respondWithError(w, 401, "user linked to access token doesn't exist anymore", err)
+				return
+			}
+			respondWithError(w, 500, "couldn't get user info from DB", err)
+			return
+		}
+		if user.Role != roleAdmin {
+			respondWithError(w, 403, "admin role required", errors.New("user is not an active admin"))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	}))
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/VincNT21/kallaxy/database/schema"
+	"github.com/VincNT21/kallaxy/server/internal/config"
+	"github.com/VincNT21/kallaxy/server/internal/migrate"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/joho/godotenv"
+)
+
+// Migrate runs the "migrate up|down|status [flags]" command against the database configured as for the server
+func Migrate(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: migrate up|down|status [flags]")
+	}
+
+	// Load the .env file into the environement
+	err := godotenv.Load(".env")
+	if err != nil {
+		log.Printf("Warning: .env file not found, using environment variables")
+	}
+
+	// Load config from flags, env. variables and optional config file
+	serverCfg, err := config.Load(args[1:], os.LookupEnv)
+	if err != nil {
+		return err
+	}
+
+	// Open a connection to database
+	dbConnection, err := pgxpool.New(context.Background(), serverCfg.DBURL)
+	if err != nil {
+		return fmt.Errorf("couldn't open a connection to db: %w", err)
+	}
+	defer dbConnection.Close()
+
+	migrator, err := migrate.New(dbConnection, schema.FS)
+	if err != nil {
+		return fmt.Errorf("couldn't load migrations: %w", err)
+	}
+
+	switch args[0] {
+	case "up":
+		err = migrator.Up(context.Background())
+	case "down":
+		err = migrator.Down(context.Background())
+	case "status":
+		// Handled below
+	default:
+		return fmt.Errorf("unknown migrate command %q, usage: migrate up|down|status [flags]", args[0])
+	}
+	if err != nil {
+		return err
+	}
+
+	// Always print status at the end
+	statuses, err := migrator.Status(context.Background())
+	for _, status := range statuses {
+		appliedAt := "Pending"
+		if status.Applied {
+			appliedAt = status.AppliedAt.Time.Format("2006-01-02 15:04:05")
+		}
+		fmt.Printf("%-20s %03d_%s\n", appliedAt, status.Version, status.Name)
+	}
+	return err
+}
+
+// Apply pending migrations, refusing to go on if database schema is newer than this binary
+func migrateOnStartup(dbConnection *pgxpool.Pool) error {
+	migrator, err := migrate.New(dbConnection, schema.FS)
+	if err != nil {
+		return fmt.Errorf("couldn't load migrations: %w", err)
+	}
+	err = migrator.Up(context.Background())
+	if err != nil {
+		return err
+	}
+	log.Printf("--INFO-- Database schema is up to date (version %d)", migrator.Latest())
+	return nil
+}
@@ -19,17 +19,21 @@ type parametersConfirmPassword struct {
 
 // Media
 type parametersCreateMedium struct {
-	Title     string                 `json:"title"`
-	MediaType string                 `json:"media_type"`
-	Creator   string                 `json:"creator"`
-	PubDate   string                 `json:"pub_date"`
-	ImageUrl  string                 `json:"image_url"`
-	Metadata  map[string]interface{} `json:"metadata"`
+	Title       string                 `json:"title"`
+	MediaType   string                 `json:"media_type"`
+	Creator     string                 `json:"creator"`
+	PubDate     string                 `json:"pub_date"`
+	ImageUrl    string                 `json:"image_url"`
+	Metadata    map[string]interface{} `json:"metadata"`
+	ExternalIDs map[string]string      `json:"external_ids"`
 }
 
-type parametersGetMediumByTitleAndType struct {
-	Title     string `json:"title"`
-	MediaType string `json:"media_type"`
+type parametersGetMediaByTitleAndType struct {
+	Title       string            `json:"title"`
+	MediaType   string            `json:"media_type"`
+	Creator     string            `json:"creator"`
+	PubDate     string            `json:"pub_date"`
+	ExternalIDs map[string]string `json:"external_ids"`
 }
 
 type parametersGetMediaByType struct {
@@ -37,29 +41,411 @@ type parametersGetMediaByType struct {
 }
 
 type parametersUpdateMedium struct {
-	MediumID string                 `json:"medium_id"`
-	Title    string                 `json:"title"`
-	Creator  string                 `json:"creator"`
-	PubDate  string                 `json:"pub_date"`
-	ImageUrl string                 `json:"image_url"`
-	Metadata map[string]interface{} `json:"metadata"`
+	MediumID    string                 `json:"medium_id"`
+	Title       string                 `json:"title"`
+	Creator     string                 `json:"creator"`
+	PubDate     string                 `json:"pub_date"`
+	ImageUrl    string                 `json:"image_url"`
+	Metadata    map[string]interface{} `json:"metadata"`
+	ExternalIDs map[string]string      `json:"external_ids"`
+}
+
+type parametersRefreshMedium struct {
+	Apply bool `json:"apply"`
 }
 
 type parametersDeleteMedium struct {
 	MediumID string `json:"medium_id"`
 }
 
+type parametersGetMediumRating struct {
+	MediumID string `json:"medium_id"`
+}
+
 // Records
 type parametersCreateUserMediumRecord struct {
-	MediumID  string `json:"medium_id"`
-	StartDate string `json:"start_date"`
-	EndDate   string `json:"end_date"`
-	Comments  string `json:"comments"`
+	MediumID    string   `json:"medium_id"`
+	StartDate   string   `json:"start_date"`
+	EndDate     string   `json:"end_date"`
+	Comments    string   `json:"comments"`
+	Rating      *float64 `json:"rating"`
+	RatingScale string   `json:"rating_scale"`
+	// Set from dates when omitted, see statusFromDates
+	Status        string  `json:"status"`
+	AbandonReason *string `json:"abandon_reason"`
 }
 
 type parametersUpdateRecord struct {
-	RecordID  string `json:"record_id"`
-	StartDate string `json:"start_date"`
-	EndDate   string `json:"end_date"`
-	Comments  string `json:"comments"`
+	RecordID    string                    `json:"record_id"`
+	StartDate   string                    `json:"start_date"`
+	EndDate     string                    `json:"end_date"`
+	Comments    string                    `json:"comments"`
+	Progress    *parametersRecordProgress `json:"progress"`
+	Rating      *float64                  `json:"rating"`
+	RatingScale string                    `json:"rating_scale"`
+	// Rating is kept when omitted, unless it is removed
+	RemoveRating bool `json:"remove_rating"`
+	// Set from dates when omitted, see statusFromDates
+	Status string `json:"status"`
+	// Kept when omitted, only abandoned records have one
+	AbandonReason *string `json:"abandon_reason"`
+}
+
+// Fields accepted depend on medium's type, see progressFieldsByMediaType
+type parametersRecordProgress struct {
+	Pages    *int32   `json:"pages"`
+	Percent  *float64 `json:"percent"`
+	Total    *int32   `json:"total"`
+	LoggedAt string   `json:"logged_at"`
+}
+
+type parametersGetRecordPauses struct {
+	RecordID string `json:"record_id"`
+}
+
+type parametersGetRecordProgress struct {
+	RecordID string `json:"record_id"`
+}
+
+type parametersSearchMediaRecords struct {
+	MediaTypes    []string                   `json:"media_types"`
+	Status        string                     `json:"status"`
+	Creator       string                     `json:"creator"`
+	StartDateFrom string                     `json:"start_date_from"`
+	StartDateTo   string                     `json:"start_date_to"`
+	EndDateFrom   string                     `json:"end_date_from"`
+	EndDateTo     string                     `json:"end_date_to"`
+	MinDuration   *int32                     `json:"min_duration"`
+	MaxDuration   *int32                     `json:"max_duration"`
+	MinRating     *float64                   `json:"min_rating"`
+	MaxRating     *float64                   `json:"max_rating"`
+	RatingScale   string                     `json:"rating_scale"`
+	Comments      string                     `json:"comments"`
+	Tags          []string                   `json:"tags"`
+	ShelfID       string                     `json:"shelf_id"`
+	Owned         *bool                      `json:"owned"`
+	CopyFormat    string                     `json:"copy_format"`
+	CopyCondition string                     `json:"copy_condition"`
+	CopyLanguage  string                     `json:"copy_language"`
+	Platform      string                     `json:"platform"`
+	Completion    string                     `json:"completion"`
+	Metadata      []parametersMetadataFilter `json:"metadata"`
+	Sort          []parametersSort           `json:"sort"`
+	Limit         int32                      `json:"limit"`
+	Cursor        string                     `json:"cursor"`
+}
+
+type parametersMetadataFilter struct {
+	Key   string      `json:"key"`
+	Op    string      `json:"op"`
+	Value interface{} `json:"value"`
+}
+
+type parametersSort struct {
+	Key   string `json:"key"`
+	Order string `json:"order"`
+}
+
+type parametersGetStats struct {
+	Period string `json:"period"`
+	Date   string `json:"date"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Bucket string `json:"bucket"`
+	Top    int32  `json:"top"`
+}
+
+type parametersCreateShare struct {
+	RecipientUsername string `json:"recipient_username"`
+	RecordID          string `json:"record_id"`
+	MediaType         string `json:"media_type"`
+}
+
+type parametersShare struct {
+	ShareID string `json:"share_id"`
+}
+
+type parametersAddSharedRecord struct {
+	ShareID  string `json:"share_id"`
+	RecordID string `json:"record_id"`
+}
+
+type parametersCreateTag struct {
+	Name string `json:"name"`
+}
+
+type parametersUpdateTag struct {
+	TagID string `json:"tag_id"`
+	Name  string `json:"name"`
+}
+
+type parametersTag struct {
+	TagID string `json:"tag_id"`
+}
+
+type parametersTagMedia struct {
+	TagIDs    []string `json:"tag_ids"`
+	MediumIDs []string `json:"medium_ids"`
+}
+
+type parametersCreateShelf struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type parametersUpdateShelf struct {
+	ShelfID     string  `json:"shelf_id"`
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+}
+
+type parametersShelf struct {
+	ShelfID string `json:"shelf_id"`
+}
+
+type parametersShelfMedia struct {
+	ShelfID   string   `json:"shelf_id"`
+	MediumIDs []string `json:"medium_ids"`
+}
+
+type parametersCreateReview struct {
+	RecordID    string `json:"record_id"`
+	Title       string `json:"title"`
+	Body        string `json:"body"`
+	IsPublished bool   `json:"is_published"`
+}
+
+// Omitted fields are kept
+type parametersUpdateReview struct {
+	RecordID    string  `json:"record_id"`
+	Title       *string `json:"title"`
+	Body        *string `json:"body"`
+	IsPublished *bool   `json:"is_published"`
+}
+
+type parametersReview struct {
+	RecordID string `json:"record_id"`
+}
+
+type parametersGetMediumReviews struct {
+	MediumID     string `json:"medium_id"`
+	ShowSpoilers bool   `json:"show_spoilers"`
+}
+
+// Loans
+type parametersCreateLoan struct {
+	MediumID         string `json:"medium_id"`
+	BorrowerUsername string `json:"borrower_username"`
+	BorrowerName     string `json:"borrower_name"`
+	LentAt           string `json:"lent_at"`
+	DueAt            string `json:"due_at"`
+}
+
+type parametersUpdateLoan struct {
+	LoanID string `json:"loan_id"`
+	DueAt  string `json:"due_at"`
+}
+
+type parametersReturnLoan struct {
+	LoanID     string `json:"loan_id"`
+	ReturnedAt string `json:"returned_at"`
+}
+
+type parametersLoan struct {
+	LoanID string `json:"loan_id"`
+}
+
+type parametersGetMediumLoans struct {
+	MediumID string `json:"medium_id"`
+}
+
+// Owned copies
+type parametersCopyDetails struct {
+	Format       string   `json:"format"`
+	Condition    string   `json:"condition"`
+	Language     string   `json:"language"`
+	PurchaseDate string   `json:"purchase_date"`
+	Price        *float64 `json:"price"`
+	Currency     string   `json:"currency"`
+	Store        string   `json:"store"`
+}
+
+type parametersCreateCopy struct {
+	MediumID string `json:"medium_id"`
+	parametersCopyDetails
+}
+
+type parametersUpdateCopy struct {
+	CopyID string `json:"copy_id"`
+	parametersCopyDetails
+}
+
+type parametersCopy struct {
+	CopyID string `json:"copy_id"`
+}
+
+type parametersGetMediumCopies struct {
+	MediumID string `json:"medium_id"`
+}
+
+// Shelving units
+type parametersShelvingUnitDetails struct {
+	Name         string `json:"name"`
+	Rows         int32  `json:"rows"`
+	Columns      int32  `json:"columns"`
+	CellWidthCm  int32  `json:"cell_width_cm"`
+	CellHeightCm int32  `json:"cell_height_cm"`
+	CellDepthCm  int32  `json:"cell_depth_cm"`
+}
+
+type parametersCreateShelvingUnit struct {
+	parametersShelvingUnitDetails
+}
+
+type parametersUpdateShelvingUnit struct {
+	UnitID string `json:"unit_id"`
+	parametersShelvingUnitDetails
+}
+
+type parametersShelvingUnit struct {
+	UnitID string `json:"unit_id"`
+}
+
+type parametersPlaceShelvingItem struct {
+	MediumID string `json:"medium_id"`
+	UnitID   string `json:"unit_id"`
+	Row      int32  `json:"row"`
+	Column   int32  `json:"column"`
+}
+
+type parametersShelvingItem struct {
+	MediumID string `json:"medium_id"`
+}
+
+type parametersWhereIsMedium struct {
+	MediumID string `json:"medium_id"`
+	Title    string `json:"title"`
+}
+
+// Boardgame plays
+type parametersPlayer struct {
+	// A Kallaxy user OR a guest named freely
+	Username string `json:"username"`
+	Name     string `json:"name"`
+	Score    *int32 `json:"score"`
+	IsWinner bool   `json:"is_winner"`
+}
+
+type parametersPlayDetails struct {
+	PlayedAt        string             `json:"played_at"`
+	DurationMinutes *int32             `json:"duration_minutes"`
+	Location        string             `json:"location"`
+	Expansions      []string           `json:"expansions"`
+	Players         []parametersPlayer `json:"players"`
+}
+
+type parametersCreatePlay struct {
+	MediumID string `json:"medium_id"`
+	parametersPlayDetails
+}
+
+type parametersUpdatePlay struct {
+	PlayID string `json:"play_id"`
+	parametersPlayDetails
+}
+
+type parametersPlay struct {
+	PlayID string `json:"play_id"`
+}
+
+type parametersGetPlays struct {
+	MediumID string `json:"medium_id"`
+}
+
+// Videogames
+type parametersRecordVideogame struct {
+	RecordID           string   `json:"record_id"`
+	Platform           string   `json:"platform"`
+	HoursPlayed        *float64 `json:"hours_played"`
+	Completion         string   `json:"completion"`
+	AchievementsEarned *int32   `json:"achievements_earned"`
+	AchievementsTotal  *int32   `json:"achievements_total"`
+}
+
+type parametersGetRecordVideogame struct {
+	RecordID string `json:"record_id"`
+}
+
+type parametersGetVideogameBacklog struct {
+	Platform string `json:"platform"`
+}
+
+// Episodes
+type parametersEpisode struct {
+	SeasonNumber  int32 `json:"season_number"`
+	EpisodeNumber int32 `json:"episode_number"`
+}
+
+type parametersMarkEpisodes struct {
+	RecordID    string              `json:"record_id"`
+	Episodes    []parametersEpisode `json:"episodes"`
+	WatchedAt   string              `json:"watched_at"`
+	Rating      *float64            `json:"rating"`
+	RatingScale string              `json:"rating_scale"`
+}
+
+type parametersUnmarkEpisodes struct {
+	RecordID string              `json:"record_id"`
+	Episodes []parametersEpisode `json:"episodes"`
+}
+
+type parametersGetRecordEpisodes struct {
+	RecordID string `json:"record_id"`
+}
+
+// Goals
+type parametersGoalDetails struct {
+	Name      string   `json:"name"`
+	MediaType string   `json:"media_type"`
+	Metric    string   `json:"metric"`
+	Target    int32    `json:"target"`
+	Period    string   `json:"period"`
+	Date      string   `json:"date"`
+	From      string   `json:"from"`
+	To        string   `json:"to"`
+	TagIDs    []string `json:"tag_ids"`
+}
+
+type parametersUpdateGoal struct {
+	GoalID string `json:"goal_id"`
+	parametersGoalDetails
+}
+
+type parametersGoal struct {
+	GoalID string `json:"goal_id"`
+}
+
+type parametersGetGoals struct {
+	CurrentOnly bool `json:"current_only"`
+}
+
+type parametersGetStreak struct {
+	Unit      string `json:"unit"`
+	MediaType string `json:"media_type"`
+}
+
+// Admin
+type parametersAdminGetUsers struct {
+	Search string `json:"search"`
+	Limit  int32  `json:"limit"`
+	Offset int32  `json:"offset"`
+}
+
+type parametersAdminUser struct {
+	UserID string `json:"user_id"`
+}
+
+type parametersAdminMergeMedia struct {
+	TargetID string `json:"target_id"`
+	SourceID string `json:"source_id"`
 }
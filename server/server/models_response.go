@@ -6,6 +6,7 @@ type ClientUser struct {
 	UpdatedAt string `json:"updated_at"`
 	Username  string `json:"username"`
 	Email     string `json:"email"`
+	Role      string `json:"role"`
 }
 
 type ClientTokens struct {
@@ -24,15 +25,33 @@ type ClientTokensAndUser struct {
 }
 
 type ClientMedium struct {
-	ID        string                 `json:"id"`
-	MediaType string                 `json:"media_type"`
-	CreatedAt string                 `json:"created_at"`
-	UpdatedAt string                 `json:"updated_at"`
-	Title     string                 `json:"title"`
-	Creator   string                 `json:"creator"`
-	PubDate   string                 `json:"pub_date"`
-	ImageUrl  string                 `json:"image_url"`
-	Metadata  map[string]interface{} `json:"metadata"`
+	ID          string                 `json:"id"`
+	MediaType   string                 `json:"media_type"`
+	CreatedAt   string                 `json:"created_at"`
+	UpdatedAt   string                 `json:"updated_at"`
+	Title       string                 `json:"title"`
+	Creator     string                 `json:"creator"`
+	PubDate     string                 `json:"pub_date"`
+	ImageUrl    string                 `json:"image_url"`
+	Metadata    map[string]interface{} `json:"metadata"`
+	CreatedBy   string                 `json:"created_by"`
+	ExternalIDs map[string]string      `json:"external_ids"`
+}
+
+type ClientDeleteMedium struct {
+	MediumDeleted bool `json:"medium_deleted"`
+	RecordDeleted bool `json:"record_deleted"`
+}
+
+type ClientRefreshMedium struct {
+	Medium   ClientMedium `json:"medium"`
+	Provider string       `json:"provider"`
+	Changes  []struct {
+		Field   string      `json:"field"`
+		Current interface{} `json:"current"`
+		Fetched interface{} `json:"fetched"`
+	} `json:"changes"`
+	Applied bool `json:"applied"`
 }
 
 type ClientListMedia struct {
@@ -40,19 +59,482 @@ type ClientListMedia struct {
 }
 
 type ClientRecord struct {
-	ID         string `json:"id"`
-	CreatedAt  string `json:"created_at"`
-	UpdatedAt  string `json:"updated_at"`
-	UserID     string `json:"user_id"`
-	MediaID    string `json:"media_id"`
-	IsFinished bool   `json:"is_finished"`
-	StartDate  string `json:"start_date"`
-	EndDate    string `json:"end_date"`
-	Duration   int32  `json:"duration"`
+	ID            string `json:"id"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
+	UserID        string `json:"user_id"`
+	MediaID       string `json:"media_id"`
+	IsFinished    bool   `json:"is_finished"`
+	StartDate     string `json:"start_date"`
+	EndDate       string `json:"end_date"`
+	Duration      int32  `json:"duration"`
+	Comments      string `json:"comments"`
+	Rating        *int16 `json:"rating"`
+	Status        string `json:"status"`
+	AbandonReason string `json:"abandon_reason"`
+}
+
+type ClientRecordPauses struct {
+	RecordID string `json:"record_id"`
+	Status   string `json:"status"`
+	Pauses   []struct {
+		ID        string `json:"id"`
+		PausedAt  string `json:"paused_at"`
+		ResumedAt string `json:"resumed_at"`
+	} `json:"pauses"`
+}
+
+type ClientReview struct {
+	ID          string `json:"id"`
+	RecordID    string `json:"record_id"`
+	Title       string `json:"title"`
+	Body        string `json:"body"`
+	HTML        string `json:"html"`
+	HasSpoilers bool   `json:"has_spoilers"`
+	IsPublished bool   `json:"is_published"`
+	PublishedAt string `json:"published_at"`
+	Revision    int32  `json:"revision"`
+}
+
+type ClientReviewRevisions struct {
+	ReviewID  string `json:"review_id"`
+	Revisions []struct {
+		Revision int32  `json:"revision"`
+		Title    string `json:"title"`
+		Body     string `json:"body"`
+		Diff     string `json:"diff"`
+	} `json:"revisions"`
+}
+
+type ClientMediumReviews struct {
+	MediumID string `json:"medium_id"`
+	Reviews  []struct {
+		ID          string `json:"id"`
+		Username    string `json:"username"`
+		Title       string `json:"title"`
+		Body        string `json:"body"`
+		HTML        string `json:"html"`
+		HasSpoilers bool   `json:"has_spoilers"`
+	} `json:"reviews"`
+}
+
+type ClientLoan struct {
+	ID           string `json:"id"`
+	OwnerID      string `json:"owner_id"`
+	MediumID     string `json:"medium_id"`
+	Title        string `json:"title"`
+	BorrowerID   string `json:"borrower_id"`
+	BorrowerName string `json:"borrower_name"`
+	DueAt        string `json:"due_at"`
+	ReturnedAt   string `json:"returned_at"`
+	IsOverdue    bool   `json:"is_overdue"`
+	DaysOverdue  int32  `json:"days_overdue"`
+}
+
+type ClientLoans struct {
+	LentOut  []ClientLoan `json:"lent_out"`
+	Borrowed []ClientLoan `json:"borrowed"`
+}
+
+type ClientMediumLoans struct {
+	MediumID  string       `json:"medium_id"`
+	IsLentOut bool         `json:"is_lent_out"`
+	Loans     []ClientLoan `json:"loans"`
+}
+
+type ClientOwnedCopy struct {
+	ID           string   `json:"id"`
+	MediumID     string   `json:"medium_id"`
+	MediaType    string   `json:"media_type"`
+	Title        string   `json:"title"`
+	Format       string   `json:"format"`
+	Condition    string   `json:"condition"`
+	Language     string   `json:"language"`
+	PurchaseDate string   `json:"purchase_date"`
+	Price        *float64 `json:"price"`
+	Currency     string   `json:"currency"`
+	Store        string   `json:"store"`
+}
+
+type ClientOwnedCopies struct {
+	Copies []ClientOwnedCopy `json:"copies"`
+}
+
+type ClientMediumCopies struct {
+	MediumID string            `json:"medium_id"`
+	Copies   []ClientOwnedCopy `json:"copies"`
+}
+
+type ClientCollectionValue struct {
+	MediaType   string  `json:"media_type"`
+	Currency    string  `json:"currency"`
+	CopiesCount int64   `json:"copies_count"`
+	Value       float64 `json:"value"`
+}
+
+type ClientCollectionValues struct {
+	CopiesCount   int64                   `json:"copies_count"`
+	UnpricedCount int64                   `json:"unpriced_count"`
+	ByMediaType   []ClientCollectionValue `json:"by_media_type"`
+	Totals        []ClientCollectionValue `json:"totals"`
+}
+
+type ClientShelvingUnit struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Rows         int32  `json:"rows"`
+	Columns      int32  `json:"columns"`
+	CellWidthCm  int32  `json:"cell_width_cm"`
+	CellHeightCm int32  `json:"cell_height_cm"`
+	CellDepthCm  int32  `json:"cell_depth_cm"`
+	ItemsCount   int64  `json:"items_count"`
+}
+
+type ClientShelvingUnits struct {
+	Units []ClientShelvingUnit `json:"units"`
+}
+
+type ClientShelvingItem struct {
+	MediumID string `json:"medium_id"`
+	Title    string `json:"title"`
+	UnitID   string `json:"unit_id"`
+	UnitName string `json:"unit_name"`
+	Row      int32  `json:"row"`
+	Column   int32  `json:"column"`
+}
+
+type ClientShelvingCell struct {
+	Row    int32                `json:"row"`
+	Column int32                `json:"column"`
+	Items  []ClientShelvingItem `json:"items"`
+}
+
+type ClientShelvingUnitGrid struct {
+	Unit  ClientShelvingUnit   `json:"unit"`
+	Cells []ClientShelvingCell `json:"cells"`
+}
+
+type ClientShelvingLocations struct {
+	Locations []ClientShelvingItem `json:"locations"`
+}
+
+type ClientPlayPlayer struct {
+	PlayerID   *string `json:"player_id"`
+	PlayerName string  `json:"player_name"`
+	Score      *int32  `json:"score"`
+	IsWinner   bool    `json:"is_winner"`
+}
+
+type ClientPlay struct {
+	ID              string             `json:"id"`
+	MediumID        string             `json:"medium_id"`
+	Title           string             `json:"title"`
+	PlayedAt        string             `json:"played_at"`
+	DurationMinutes *int32             `json:"duration_minutes"`
+	Location        string             `json:"location"`
+	Expansions      []string           `json:"expansions"`
+	Players         []ClientPlayPlayer `json:"players"`
+}
+
+type ClientPlays struct {
+	Plays []ClientPlay `json:"plays"`
+}
+
+type ClientGamePlays struct {
+	MediumID     string `json:"medium_id"`
+	Title        string `json:"title"`
+	PlaysCount   int64  `json:"plays_count"`
+	TotalMinutes int64  `json:"total_minutes"`
+}
+
+type ClientPlayCounts struct {
+	PlaysCount   int64             `json:"plays_count"`
+	TotalMinutes int64             `json:"total_minutes"`
+	Games        []ClientGamePlays `json:"games"`
+}
+
+type ClientPlayerWinRate struct {
+	PlayerID   *string `json:"player_id"`
+	PlayerName string  `json:"player_name"`
+	PlaysCount int64   `json:"plays_count"`
+	WinsCount  int64   `json:"wins_count"`
+	WinRate    float64 `json:"win_rate"`
+}
+
+type ClientPlayerWinRates struct {
+	PlaysCount int64                 `json:"plays_count"`
+	Players    []ClientPlayerWinRate `json:"players"`
+}
+
+type ClientPlaysHIndex struct {
+	HIndex      int64 `json:"h_index"`
+	PlaysToNext int64 `json:"plays_to_next"`
+	GamesCount  int64 `json:"games_count"`
+	PlaysCount  int64 `json:"plays_count"`
+}
+
+type ClientRecordVideogame struct {
+	RecordID           string   `json:"record_id"`
+	Platform           string   `json:"platform"`
+	HoursPlayed        *float64 `json:"hours_played"`
+	Completion         string   `json:"completion"`
+	AchievementsEarned *int32   `json:"achievements_earned"`
+	AchievementsTotal  *int32   `json:"achievements_total"`
+}
+
+type ClientPlatformStats struct {
+	Platform           string           `json:"platform"`
+	GamesCount         int64            `json:"games_count"`
+	FinishedCount      int64            `json:"finished_count"`
+	BacklogCount       int64            `json:"backlog_count"`
+	HoursPlayed        float64          `json:"hours_played"`
+	Completions        map[string]int64 `json:"completions"`
+	AchievementsEarned int64            `json:"achievements_earned"`
+	AchievementsTotal  int64            `json:"achievements_total"`
+}
+
+type ClientPlatforms struct {
+	Platforms []ClientPlatformStats `json:"platforms"`
+}
+
+type ClientPlatformBacklog struct {
+	Platform string `json:"platform"`
+	Games    []struct {
+		RecordID string `json:"record_id"`
+		Title    string `json:"title"`
+		Status   string `json:"status"`
+	} `json:"games"`
+}
+
+type ClientVideogameBacklog struct {
+	Platforms []ClientPlatformBacklog `json:"platforms"`
+}
+
+type ClientSeriesSeason struct {
+	SeasonNumber int32 `json:"season_number"`
+	EpisodeCount int32 `json:"episode_count"`
+	WatchedCount int32 `json:"watched_count"`
+}
+
+type ClientNextEpisode struct {
+	RecordID      string `json:"record_id"`
+	Title         string `json:"title"`
+	SeasonNumber  int32  `json:"season_number"`
+	EpisodeNumber int32  `json:"episode_number"`
+}
+
+type ClientRecordEpisodes struct {
+	RecordID      string               `json:"record_id"`
+	Status        string               `json:"status"`
+	WatchedCount  int32                `json:"watched_count"`
+	TotalEpisodes *int32               `json:"total_episodes"`
+	Seasons       []ClientSeriesSeason `json:"seasons"`
+	Episodes      []struct {
+		SeasonNumber  int32  `json:"season_number"`
+		EpisodeNumber int32  `json:"episode_number"`
+		Rating        *int16 `json:"rating"`
+	} `json:"episodes"`
+	NextEpisode *ClientNextEpisode `json:"next_episode"`
+}
+
+type ClientNextEpisodes struct {
+	NextEpisodes []ClientNextEpisode `json:"next_episodes"`
+}
+
+type ClientGoal struct {
+	ID           string      `json:"id"`
+	Name         string      `json:"name"`
+	MediaType    string      `json:"media_type"`
+	Metric       string      `json:"metric"`
+	Target       int32       `json:"target"`
+	Period       string      `json:"period"`
+	PeriodStart  string      `json:"period_start"`
+	PeriodEnd    string      `json:"period_end"`
+	Tags         []ClientTag `json:"tags"`
+	RecordsCount int         `json:"records_count"`
+	Progress     float64     `json:"progress"`
+	Percent      float64     `json:"percent"`
+	Expected     float64     `json:"expected"`
+	Pace         float64     `json:"pace"`
+	Status       string      `json:"status"`
+}
+
+type ClientGoals struct {
+	Goals []ClientGoal `json:"goals"`
+}
+
+type ClientStreak struct {
+	Unit      string `json:"unit"`
+	MediaType string `json:"media_type"`
+	Current   int    `json:"current"`
+	ActiveNow bool   `json:"active_now"`
+	Longest   int    `json:"longest"`
+}
+
+type ClientRecordProgress struct {
+	ID         string   `json:"id"`
+	LoggedAt   string   `json:"logged_at"`
+	Pages      *int32   `json:"pages"`
+	Percent    *float64 `json:"percent"`
+	Total      *int32   `json:"total"`
+	Completion *float64 `json:"completion"`
+}
+
+type ClientRecordWithProgress struct {
+	ClientRecord
+	Progress *ClientRecordProgress `json:"progress"`
+}
+
+type ClientRecordProgressCurve struct {
+	RecordID            string                 `json:"record_id"`
+	MediaType           string                 `json:"media_type"`
+	Progress            []ClientRecordProgress `json:"progress"`
+	Pace                float64                `json:"pace"`
+	EstimatedFinishDate string                 `json:"estimated_finish_date"`
 }
 
 type ClientRecords struct {
 	Records []ClientRecord `json:"records"`
 }
 
+type ClientMediumWithRecord struct {
+	ID         string                 `json:"record_id"`
+	MediaID    string                 `json:"medium_id"`
+	IsFinished bool                   `json:"is_finished"`
+	StartDate  string                 `json:"start_date"`
+	EndDate    string                 `json:"end_date"`
+	Duration   int32                  `json:"duration"`
+	Comments   string                 `json:"comments"`
+	Rating     *int16                 `json:"rating"`
+	Status     string                 `json:"status"`
+	MediaType  string                 `json:"media_type"`
+	Title      string                 `json:"title"`
+	Creator    string                 `json:"creator"`
+	Metadata   map[string]interface{} `json:"metadata"`
+
+	ConsumptionCount int            `json:"consumption_count"`
+	History          []ClientRecord `json:"history"`
+	RatingAverage    float64        `json:"rating_average"`
+	RatingCount      int64          `json:"rating_count"`
+	Tags             []string       `json:"tags"`
+	ShelfIDs         []string       `json:"shelf_ids"`
+}
+
+type ClientMediumRating struct {
+	MediaID string  `json:"medium_id"`
+	Average float64 `json:"average"`
+	Count   int64   `json:"count"`
+}
+
+type ClientMediaRecords struct {
+	Records map[string][]ClientMediumWithRecord `json:"records"`
+}
+
+type ClientSearchMediaRecords struct {
+	Records    []ClientMediumWithRecord `json:"records"`
+	NextCursor string                   `json:"next_cursor"`
+}
+
+type ClientShare struct {
+	ID                string `json:"id"`
+	OwnerUsername     string `json:"owner_username"`
+	RecipientUsername string `json:"recipient_username"`
+	RecordID          string `json:"record_id"`
+	MediaType         string `json:"media_type"`
+	Title             string `json:"title"`
+}
+
+type ClientShares struct {
+	SharedByMe   []ClientShare `json:"shared_by_me"`
+	SharedWithMe []ClientShare `json:"shared_with_me"`
+}
+
+type ClientTag struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	MediaCount int64  `json:"media_count"`
+}
+
+type ClientTags struct {
+	Tags []ClientTag `json:"tags"`
+}
+
+type ClientShelf struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	MediaCount  int64  `json:"media_count"`
+}
+
+type ClientShelves struct {
+	Shelves []ClientShelf `json:"shelves"`
+}
+
+type ClientGroupMedia struct {
+	Count int64 `json:"count"`
+}
+
+type ClientStatsSummary struct {
+	MediaType      string  `json:"media_type"`
+	Started        int64   `json:"started"`
+	Finished       int64   `json:"finished"`
+	AvgDuration    float64 `json:"avg_duration"`
+	MedianDuration float64 `json:"median_duration"`
+}
+
+type ClientStatsCompletion struct {
+	Title    string `json:"title"`
+	Duration int32  `json:"duration"`
+}
+
+type ClientStatsCount struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+type ClientStatsBucket struct {
+	BucketStart string `json:"bucket_start"`
+	Started     int64  `json:"started"`
+	Finished    int64  `json:"finished"`
+}
+
+type ClientStats struct {
+	From        string                  `json:"from"`
+	To          string                  `json:"to"`
+	Bucket      string                  `json:"bucket"`
+	Totals      ClientStatsSummary      `json:"totals"`
+	ByMediaType []ClientStatsSummary    `json:"by_media_type"`
+	Longest     []ClientStatsCompletion `json:"longest"`
+	Shortest    []ClientStatsCompletion `json:"shortest"`
+	TopCreators []ClientStatsCount      `json:"top_creators"`
+	TopGenres   []ClientStatsCount      `json:"top_genres"`
+	Series      []ClientStatsBucket     `json:"series"`
+}
+
 // isoFormat := time.Now().UTC().Format("2006-01-02T15:04:05.999999")
+
+type ClientAdminUser struct {
+	ClientUser
+	DeactivatedAt string `json:"deactivated_at"`
+}
+
+type ClientAdminUsers struct {
+	Users []ClientAdminUser `json:"users"`
+}
+
+type ClientAdminCounts struct {
+	Users            int64            `json:"users"`
+	DeactivatedUsers int64            `json:"deactivated_users"`
+	Admins           int64            `json:"admins"`
+	Media            int64            `json:"media"`
+	MediaByType      map[string]int64 `json:"media_by_type"`
+	Records          int64            `json:"records"`
+	Shares           int64            `json:"shares"`
+}
+
+type ClientAdminMergeMedia struct {
+	Medium        ClientMedium `json:"medium"`
+	MovedRecords  int64        `json:"moved_records"`
+	MergedRecords int64        `json:"merged_records"`
+	ClosedLoans   int64        `json:"closed_loans"`
+}
@@ -10,23 +10,38 @@ type User struct {
 	UpdatedAt pgtype.Timestamp `json:"updated_at"`
 	Username  string           `json:"username"`
 	Email     string           `json:"email"`
+	Role      string           `json:"role"`
 }
 
+// User as seen by admins
+type AdminUser struct {
+	User
+	DeactivatedAt pgtype.Timestamp `json:"deactivated_at"`
+}
+
+// Users roles, admins can manage every medium and account through /admin endpoints
+const (
+	roleUser  = "user"
+	roleAdmin = "admin"
+)
+
 type Tokens struct {
 	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token"`
 }
 
 type Medium struct {
-	ID        pgtype.UUID            `json:"id"`
-	MediaType string                 `json:"media_type"`
-	CreatedAt pgtype.Timestamp       `json:"created_at"`
-	UpdatedAt pgtype.Timestamp       `json:"updated_at"`
-	Title     string                 `json:"title"`
-	Creator   string                 `json:"creator"`
-	PubDate   string                 `json:"pub_date"`
-	ImageUrl  string                 `json:"image_url"`
-	Metadata  map[string]interface{} `json:"metadata"`
+	ID          pgtype.UUID            `json:"id"`
+	MediaType   string                 `json:"media_type"`
+	CreatedAt   pgtype.Timestamp       `json:"created_at"`
+	UpdatedAt   pgtype.Timestamp       `json:"updated_at"`
+	Title       string                 `json:"title"`
+	Creator     string                 `json:"creator"`
+	PubDate     string                 `json:"pub_date"`
+	ImageUrl    string                 `json:"image_url"`
+	Metadata    map[string]interface{} `json:"metadata"`
+	CreatedBy   pgtype.UUID            `json:"created_by"`
+	ExternalIDs map[string]string      `json:"external_ids"`
 }
 
 type Record struct {
@@ -40,21 +55,380 @@ type Record struct {
 	EndDate    pgtype.Timestamp `json:"end_date"`
 	Duration   int32            `json:"duration"`
 	Comments   string           `json:"comments"`
+	// On the 0-100 scale, null if unrated
+	Rating        pgtype.Int2 `json:"rating"`
+	Status        string      `json:"status"`
+	AbandonReason pgtype.Text `json:"abandon_reason"`
+}
+
+// One update of a record's progress
+type RecordProgress struct {
+	ID       pgtype.UUID      `json:"id"`
+	LoggedAt pgtype.Timestamp `json:"logged_at"`
+	Pages    pgtype.Int4      `json:"pages"`
+	Percent  pgtype.Float8    `json:"percent"`
+	Total    pgtype.Int4      `json:"total"`
+	// Percent of the medium consumed, null when it can't be told (e.g. pages of a book of unknown length)
+	Completion pgtype.Float8 `json:"completion"`
 }
 
 type MediumWithRecord struct {
-	ID         pgtype.UUID            `json:"record_id"`
-	UserID     pgtype.UUID            `json:"user_id"`
-	MediaID    pgtype.UUID            `json:"medium_id"`
-	IsFinished pgtype.Bool            `json:"is_finished"`
-	StartDate  pgtype.Timestamp       `json:"start_date"`
-	EndDate    pgtype.Timestamp       `json:"end_date"`
-	Duration   int32                  `json:"duration"`
-	Comments   string                 `json:"comments"`
-	MediaType  string                 `json:"media_type"`
-	Title      string                 `json:"title"`
-	Creator    string                 `json:"creator"`
-	PubDate    string                 `json:"pub_date"`
-	ImageUrl   string                 `json:"image_url"`
-	Metadata   map[string]interface{} `json:"metadata"`
+	ID         pgtype.UUID      `json:"record_id"`
+	UserID     pgtype.UUID      `json:"user_id"`
+	MediaID    pgtype.UUID      `json:"medium_id"`
+	IsFinished pgtype.Bool      `json:"is_finished"`
+	StartDate  pgtype.Timestamp `json:"start_date"`
+	EndDate    pgtype.Timestamp `json:"end_date"`
+	Duration   int32            `json:"duration"`
+	Comments   string           `json:"comments"`
+	Rating     pgtype.Int2      `json:"rating"`
+	Status     string           `json:"status"`
+	// Only set for abandoned records
+	AbandonReason pgtype.Text            `json:"abandon_reason"`
+	MediaType     string                 `json:"media_type"`
+	Title         string                 `json:"title"`
+	Creator       string                 `json:"creator"`
+	PubDate       string                 `json:"pub_date"`
+	ImageUrl      string                 `json:"image_url"`
+	Metadata      map[string]interface{} `json:"metadata"`
+	// Only listed by GET /api/media_records, where a medium's consumptions are grouped
+	ConsumptionCount int      `json:"consumption_count,omitempty"`
+	History          []Record `json:"history,omitempty"`
+	// Average of all users' ratings of the medium, each user counting once
+	RatingAverage *float64 `json:"rating_average,omitempty"`
+	RatingCount   *int64   `json:"rating_count,omitempty"`
+	// Names of user's tags on the medium, and IDs of user's custom shelves holding it
+	Tags     []string      `json:"tags,omitempty"`
+	ShelfIDs []pgtype.UUID `json:"shelf_ids,omitempty"`
+}
+
+type Tag struct {
+	ID         pgtype.UUID      `json:"id"`
+	CreatedAt  pgtype.Timestamp `json:"created_at"`
+	UpdatedAt  pgtype.Timestamp `json:"updated_at"`
+	Name       string           `json:"name"`
+	MediaCount int64            `json:"media_count"`
+}
+
+type Shelf struct {
+	ID          pgtype.UUID      `json:"id"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	MediaCount  int64            `json:"media_count"`
+}
+
+type StatsSummary struct {
+	Started        int64   `json:"started"`
+	Finished       int64   `json:"finished"`
+	AvgDuration    float64 `json:"avg_duration"`
+	MedianDuration float64 `json:"median_duration"`
+}
+
+type StatsByMediaType struct {
+	MediaType string `json:"media_type"`
+	StatsSummary
+}
+
+type StatsCompletion struct {
+	RecordID  pgtype.UUID      `json:"record_id"`
+	MediaID   pgtype.UUID      `json:"medium_id"`
+	MediaType string           `json:"media_type"`
+	Title     string           `json:"title"`
+	Creator   string           `json:"creator"`
+	StartDate pgtype.Timestamp `json:"start_date"`
+	EndDate   pgtype.Timestamp `json:"end_date"`
+	Duration  int32            `json:"duration"`
+}
+
+type StatsCount struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+type StatsBucket struct {
+	BucketStart pgtype.Timestamp `json:"bucket_start"`
+	Started     int64            `json:"started"`
+	Finished    int64            `json:"finished"`
+}
+
+type Share struct {
+	ID                pgtype.UUID      `json:"id"`
+	CreatedAt         pgtype.Timestamp `json:"created_at"`
+	OwnerID           pgtype.UUID      `json:"owner_id"`
+	OwnerUsername     string           `json:"owner_username"`
+	RecipientID       pgtype.UUID      `json:"recipient_id"`
+	RecipientUsername string           `json:"recipient_username"`
+	RecordID          pgtype.UUID      `json:"record_id"`
+	MediaType         string           `json:"media_type"`
+	Title             string           `json:"title"`
+}
+
+type Review struct {
+	ID          pgtype.UUID      `json:"id"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
+	RecordID    pgtype.UUID      `json:"record_id"`
+	Title       string           `json:"title"`
+	Body        string           `json:"body"`
+	HTML        string           `json:"html"`
+	HasSpoilers bool             `json:"has_spoilers"`
+	IsPublished bool             `json:"is_published"`
+	PublishedAt pgtype.Timestamp `json:"published_at"`
+	Revision    int32            `json:"revision"`
+}
+
+// Review as other users see it, Markdown body is left out as it holds spoilers
+type PublishedReview struct {
+	ID          pgtype.UUID      `json:"id"`
+	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
+	RecordID    pgtype.UUID      `json:"record_id"`
+	UserID      pgtype.UUID      `json:"user_id"`
+	Username    string           `json:"username"`
+	Title       string           `json:"title"`
+	HTML        string           `json:"html"`
+	HasSpoilers bool             `json:"has_spoilers"`
+	PublishedAt pgtype.Timestamp `json:"published_at"`
+}
+
+// A medium lent by its owner, to another user (borrower_id) or to someone named freely
+type Loan struct {
+	ID            pgtype.UUID      `json:"id"`
+	CreatedAt     pgtype.Timestamp `json:"created_at"`
+	UpdatedAt     pgtype.Timestamp `json:"updated_at"`
+	OwnerID       pgtype.UUID      `json:"owner_id"`
+	OwnerUsername string           `json:"owner_username"`
+	MediumID      pgtype.UUID      `json:"medium_id"`
+	MediaType     string           `json:"media_type"`
+	Title         string           `json:"title"`
+	BorrowerID    pgtype.UUID      `json:"borrower_id"`
+	BorrowerName  string           `json:"borrower_name"`
+	LentAt        pgtype.Timestamp `json:"lent_at"`
+	DueAt         pgtype.Timestamp `json:"due_at"`
+	ReturnedAt    pgtype.Timestamp `json:"returned_at"`
+	IsOverdue     bool             `json:"is_overdue"`
+	DaysOverdue   int32            `json:"days_overdue"`
+}
+
+type OwnedCopy struct {
+	ID           pgtype.UUID      `json:"id"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+	UserID       pgtype.UUID      `json:"user_id"`
+	MediumID     pgtype.UUID      `json:"medium_id"`
+	MediaType    string           `json:"media_type"`
+	Title        string           `json:"title"`
+	Format       string           `json:"format"`
+	Condition    string           `json:"condition"`
+	Language     string           `json:"language"`
+	PurchaseDate pgtype.Timestamp `json:"purchase_date"`
+	Price        *float64         `json:"price"`
+	Currency     string           `json:"currency"`
+	Store        string           `json:"store"`
+}
+
+// Sum of copies prices in a currency, for a media type or for the whole collection
+type CollectionValue struct {
+	MediaType   string  `json:"media_type,omitempty"`
+	Currency    string  `json:"currency"`
+	CopiesCount int64   `json:"copies_count"`
+	Value       float64 `json:"value"`
+}
+
+type ShelvingUnit struct {
+	ID           pgtype.UUID      `json:"id"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+	Name         string           `json:"name"`
+	Rows         int32            `json:"rows"`
+	Columns      int32            `json:"columns"`
+	CellWidthCm  int32            `json:"cell_width_cm"`
+	CellHeightCm int32            `json:"cell_height_cm"`
+	CellDepthCm  int32            `json:"cell_depth_cm"`
+	ItemsCount   int64            `json:"items_count"`
+}
+
+// A medium in a cell of a shelving unit, rows and columns counted from 1 at the top left
+type ShelvingItem struct {
+	MediumID  pgtype.UUID      `json:"medium_id"`
+	MediaType string           `json:"media_type"`
+	Title     string           `json:"title"`
+	ImageUrl  string           `json:"image_url"`
+	UnitID    pgtype.UUID      `json:"unit_id"`
+	UnitName  string           `json:"unit_name"`
+	Row       int32            `json:"row"`
+	Column    int32            `json:"column"`
+	PlacedAt  pgtype.Timestamp `json:"placed_at"`
+}
+
+type ShelvingCell struct {
+	Row    int32          `json:"row"`
+	Column int32          `json:"column"`
+	Items  []ShelvingItem `json:"items"`
+}
+
+type Play struct {
+	ID              pgtype.UUID      `json:"id"`
+	CreatedAt       pgtype.Timestamp `json:"created_at"`
+	UpdatedAt       pgtype.Timestamp `json:"updated_at"`
+	MediumID        pgtype.UUID      `json:"medium_id"`
+	Title           string           `json:"title"`
+	PlayedAt        pgtype.Timestamp `json:"played_at"`
+	DurationMinutes pgtype.Int4      `json:"duration_minutes"`
+	Location        string           `json:"location"`
+	Expansions      []string         `json:"expansions"`
+	Players         []PlayPlayer     `json:"players"`
+}
+
+// A player of a play, player_id is null for guests
+type PlayPlayer struct {
+	PlayerID   pgtype.UUID `json:"player_id"`
+	PlayerName string      `json:"player_name"`
+	Score      pgtype.Int4 `json:"score"`
+	IsWinner   bool        `json:"is_winner"`
+}
+
+type GamePlays struct {
+	MediumID     pgtype.UUID      `json:"medium_id"`
+	Title        string           `json:"title"`
+	PlaysCount   int64            `json:"plays_count"`
+	TotalMinutes int64            `json:"total_minutes"`
+	LastPlayedAt pgtype.Timestamp `json:"last_played_at"`
+}
+
+// Wins of a player among the plays they took part in, win_rate going from 0 to 1
+type PlayerWinRate struct {
+	PlayerID   pgtype.UUID `json:"player_id"`
+	PlayerName string      `json:"player_name"`
+	PlaysCount int64       `json:"plays_count"`
+	WinsCount  int64       `json:"wins_count"`
+	WinRate    float64     `json:"win_rate"`
+}
+
+// How a videogame record was played, completion is empty and other details null until given
+type RecordVideogame struct {
+	RecordID           pgtype.UUID      `json:"record_id"`
+	UpdatedAt          pgtype.Timestamp `json:"updated_at"`
+	Platform           string           `json:"platform"`
+	HoursPlayed        pgtype.Float8    `json:"hours_played"`
+	Completion         string           `json:"completion"`
+	AchievementsEarned pgtype.Int4      `json:"achievements_earned"`
+	AchievementsTotal  pgtype.Int4      `json:"achievements_total"`
+}
+
+// User's videogame records on a platform, platform is empty for records it isn't known of
+type PlatformStats struct {
+	Platform      string  `json:"platform"`
+	GamesCount    int64   `json:"games_count"`
+	FinishedCount int64   `json:"finished_count"`
+	BacklogCount  int64   `json:"backlog_count"`
+	HoursPlayed   float64 `json:"hours_played"`
+	// Records by completion level
+	Completions        map[string]int64 `json:"completions"`
+	AchievementsEarned int64            `json:"achievements_earned"`
+	AchievementsTotal  int64            `json:"achievements_total"`
+}
+
+type BacklogGame struct {
+	RecordID    pgtype.UUID   `json:"record_id"`
+	MediumID    pgtype.UUID   `json:"medium_id"`
+	Title       string        `json:"title"`
+	ImageUrl    string        `json:"image_url"`
+	Status      string        `json:"status"`
+	HoursPlayed pgtype.Float8 `json:"hours_played"`
+}
+
+type PlatformBacklog struct {
+	Platform string        `json:"platform"`
+	Games    []BacklogGame `json:"games"`
+}
+
+// A season of a series, episode count is 0 when metadata doesn't know the season
+type SeriesSeason struct {
+	SeasonNumber int32 `json:"season_number"`
+	EpisodeCount int32 `json:"episode_count"`
+	WatchedCount int32 `json:"watched_count"`
+}
+
+type RecordEpisode struct {
+	SeasonNumber  int32            `json:"season_number"`
+	EpisodeNumber int32            `json:"episode_number"`
+	WatchedAt     pgtype.Timestamp `json:"watched_at"`
+	// On the 0-100 scale
+	Rating pgtype.Int2 `json:"rating"`
+}
+
+type NextEpisode struct {
+	RecordID      pgtype.UUID `json:"record_id"`
+	MediumID      pgtype.UUID `json:"medium_id"`
+	Title         string      `json:"title"`
+	ImageUrl      string      `json:"image_url"`
+	SeasonNumber  int32       `json:"season_number"`
+	EpisodeNumber int32       `json:"episode_number"`
+	// Null for a series not started yet
+	LastWatchedAt pgtype.Timestamp `json:"last_watched_at"`
+}
+
+// Episodes watched of a series record, total is null when medium's metadata doesn't tell
+type RecordEpisodes struct {
+	RecordID      pgtype.UUID     `json:"record_id"`
+	Status        string          `json:"status"`
+	WatchedCount  int32           `json:"watched_count"`
+	TotalEpisodes pgtype.Int4     `json:"total_episodes"`
+	Seasons       []SeriesSeason  `json:"seasons"`
+	Episodes      []RecordEpisode `json:"episodes"`
+	// Null once every episode is watched
+	NextEpisode *NextEpisode `json:"next_episode"`
+}
+
+type GoalTag struct {
+	ID   pgtype.UUID `json:"id"`
+	Name string      `json:"name"`
+}
+
+// A goal with its progress, computed from the records finished in its period
+type Goal struct {
+	ID          pgtype.UUID      `json:"id"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
+	Name        string           `json:"name"`
+	MediaType   string           `json:"media_type"`
+	Metric      string           `json:"metric"`
+	Target      int32            `json:"target"`
+	Period      string           `json:"period"`
+	PeriodStart pgtype.Timestamp `json:"period_start"`
+	PeriodEnd   pgtype.Timestamp `json:"period_end"`
+	Tags        []GoalTag        `json:"tags"`
+	// Finished records counted, progress is their count or the sum of their pages or hours
+	RecordsCount int     `json:"records_count"`
+	Progress     float64 `json:"progress"`
+	Percent      float64 `json:"percent"`
+	// Progress expected by now at a steady pace, pace is how far ahead (positive) or behind (negative) of it progress is
+	Expected float64 `json:"expected"`
+	Pace     float64 `json:"pace"`
+	// "upcoming", "ahead", "behind", "completed" or "missed"
+	Status string `json:"status"`
+}
+
+// Consecutive days or weeks with activity
+type Streak struct {
+	Unit      string `json:"unit"`
+	MediaType string `json:"media_type"`
+	// Streak still running: its last day or week is the current one or the one before
+	Current int `json:"current"`
+	// Whether the current day or week already has activity
+	ActiveNow    bool             `json:"active_now"`
+	Longest      int              `json:"longest"`
+	LongestStart pgtype.Timestamp `json:"longest_start"`
+	LastActiveAt pgtype.Timestamp `json:"last_active_at"`
+}
+
+type ReviewRevision struct {
+	Revision  int32            `json:"revision"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+	Title     string           `json:"title"`
+	Body      string           `json:"body"`
+	// Changes from previous revision, line by line
+	Diff string `json:"diff"`
 }
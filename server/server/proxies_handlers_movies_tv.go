@@ -8,10 +8,17 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+
+	"github.com/VincNT21/kallaxy/server/internal/database"
 )
 
 // GET /external_api/movie_tv/search_movie
 func (cfg *apiConfig) handlerMovieSearch(w http.ResponseWriter, r *http.Request) {
+	// Check upstream API is available
+	if !checkAPIKey(w, cfg.moviedbKey, "The Movie DB") {
+		return
+	}
+
 	const movieDbSearchUrl = "https://api.themoviedb.org/3/search/movie"
 
 	// Get query parameters
@@ -43,6 +50,11 @@ func (cfg *apiConfig) handlerMovieSearch(w http.ResponseWriter, r *http.Request)
 
 // GET /external_api/movie_tv/search_tv
 func (cfg *apiConfig) handlerTVSearch(w http.ResponseWriter, r *http.Request) {
+	// Check upstream API is available
+	if !checkAPIKey(w, cfg.moviedbKey, "The Movie DB") {
+		return
+	}
+
 	const movieDbSearchUrl = "https://api.themoviedb.org/3/search/tv"
 
 	// Get query parameters
@@ -74,6 +86,11 @@ func (cfg *apiConfig) handlerTVSearch(w http.ResponseWriter, r *http.Request) {
 
 // GET /external_api/movie/search
 func (cfg *apiConfig) handlerMultiSearch(w http.ResponseWriter, r *http.Request) {
+	// Check upstream API is available
+	if !checkAPIKey(w, cfg.moviedbKey, "The Movie DB") {
+		return
+	}
+
 	const movieDbSearchUrl = "https://api.themoviedb.org/3/search/multi"
 
 	// Get query parameters
@@ -111,6 +128,11 @@ type parametersMovieDetails struct {
 
 // GET /external_api/movie_tv
 func (cfg *apiConfig) handlerMovieTvDetails(w http.ResponseWriter, r *http.Request) {
+	// Check upstream API is available
+	if !checkAPIKey(w, cfg.moviedbKey, "The Movie DB") {
+		return
+	}
+
 	const movieDbMovieDetailsUrl = "https://api.themoviedb.org/3/movie"
 	const movieDbTvDetailsUrl = "https://api.themoviedb.org/3/tv"
 
@@ -154,8 +176,89 @@ func (cfg *apiConfig) handlerMovieTvDetails(w http.ResponseWriter, r *http.Reque
 	io.Copy(w, resp.Body)
 }
 
+type parametersTvSeasonDetails struct {
+	TvID         string `json:"tv_id"`
+	MediumID     string `json:"medium_id"`
+	SeasonNumber int    `json:"season_number"`
+	Language     string `json:"language"`
+}
+
+// GET /external_api/movie_tv/tv_season
+// The series can be given by its TMDB ID, or by a medium holding it in its external IDs
+func (cfg *apiConfig) handlerTvSeasonDetails(w http.ResponseWriter, r *http.Request) {
+	// Check upstream API is available
+	if !checkAPIKey(w, cfg.moviedbKey, "The Movie DB") {
+		return
+	}
+
+	const movieDbTvDetailsUrl = "https://api.themoviedb.org/3/tv"
+
+	// Parse data from request body
+	var params parametersTvSeasonDetails
+	err := json.NewDecoder(r.Body).Decode(&params)
+	if err != nil {
+		respondWithError(w, 500, "couldn't decode body from request", err)
+		return
+	}
+
+	tvID := params.TvID
+	if tvID == "" && params.MediumID != "" {
+		mediumID, err := convertIdToPgtype(params.MediumID)
+		if err != nil {
+			respondWithError(w, 400, "medium_id not in good format", err)
+			return
+		}
+		medium, ok := cfg.getMedium(w, r, mediumID)
+		if !ok {
+			return
+		}
+		externalIDs, err := database.DecodeExternalIDs(medium.ExternalIds)
+		if err != nil {
+			respondWithError(w, 500, "couldn't decode medium's external IDs", err)
+			return
+		}
+		tvID = externalIDs["tmdb_tv"]
+	}
+	if tvID == "" {
+		respondWithError(w, 400, "no tv id in request body, or medium has none", errors.New("field 'tv_id' is empty and medium has no 'tmdb_tv' external ID"))
+		return
+	}
+	if params.SeasonNumber < 0 {
+		respondWithError(w, 400, "season_number can't be negative", errors.New("negative season_number"))
+		return
+	}
+
+	// Create request
+	apiURL := fmt.Sprintf("%s/%s/season/%d?language=%s", movieDbTvDetailsUrl, url.PathEscape(tvID), params.SeasonNumber, url.QueryEscape(params.Language))
+	log.Printf("--DEBUG-- Making external request to %s", apiURL)
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		respondWithError(w, 500, "couldn't create Get request for The Movie DB API", err)
+		return
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", cfg.moviedbKey))
+
+	// Make request to external API
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		respondWithError(w, 500, "failed to fetch data", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	// Pass through the response
+	w.Header().Set("Content-Type", "application/json")
+	io.Copy(w, resp.Body)
+}
+
 // GET /external_api/movie_tv/movie_credits (query parameters: "?movie_id=xxxx")
 func (cfg *apiConfig) handlerMovieCreditsDetails(w http.ResponseWriter, r *http.Request) {
+	// Check upstream API is available
+	if !checkAPIKey(w, cfg.moviedbKey, "The Movie DB") {
+		return
+	}
+
 	const movieDbMovieDetailsUrl = "https://api.themoviedb.org/3/movie"
 	// const movieDbTvCreditsDetailsUrl = " https://api.themoviedb.org/3/tv/{series_id}/season/{season_number}/credits"
 
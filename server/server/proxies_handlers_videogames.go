@@ -8,6 +8,11 @@ import (
 
 // GET /external_api/videogame/search (query parameter: ?search=<title>&platforms=<platformsID>)
 func (cfg *apiConfig) handlerVideoGameSearch(w http.ResponseWriter, r *http.Request) {
+	// Check upstream API is available
+	if !checkAPIKey(w, cfg.rawgKey, "RAWG") {
+		return
+	}
+
 	const RAWGSearchUrl = "https://api.rawg.io/api/games"
 
 	// Get query parameters
@@ -38,6 +43,11 @@ func (cfg *apiConfig) handlerVideoGameSearch(w http.ResponseWriter, r *http.Requ
 
 // GET /external_api/videogame (query parameter: ?id=<gameID>)
 func (cfg *apiConfig) handlerVideoGameDetails(w http.ResponseWriter, r *http.Request) {
+	// Check upstream API is available
+	if !checkAPIKey(w, cfg.rawgKey, "RAWG") {
+		return
+	}
+
 	const RAWGUrl = "https://api.rawg.io/api/games"
 
 	// Get game ID from request query parameter
@@ -0,0 +1,442 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/clbanning/mxj/v2"
+)
+
+// Medium's info as given by a provider, in server's medium format
+type providerMedium struct {
+	Title    string
+	Creator  string
+	PubDate  string
+	ImageUrl string
+	Metadata map[string]interface{}
+}
+
+// Fetch a medium's details from a provider, by the medium's ID on this provider
+type mediumProvider func(ctx context.Context, id string) (providerMedium, error)
+
+var (
+	errProviderNotFound      = errors.New("provider has no item with this id")
+	errProviderNotConfigured = errors.New("provider API key is not configured on this server")
+)
+
+// Providers media can be refreshed from, by external_ids key
+func (cfg *apiConfig) defaultMediumProviders() map[string]mediumProvider {
+	return map[string]mediumProvider{
+		"openlibrary_work": cfg.fetchOpenLibraryWork,
+		"isbn13":           cfg.fetchOpenLibraryISBN,
+		"tmdb_movie":       cfg.fetchTMDBMovie,
+		"tmdb_tv":          cfg.fetchTMDBTv,
+		"rawg":             cfg.fetchRAWGGame,
+		"bgg":              cfg.fetchBGGBoardgame,
+	}
+}
+
+// Make a GET request to a provider, and return response body if status is OK
+func fetchFromProvider(ctx context.Context, apiURL string, headers map[string]string) ([]byte, error) {
+	log.Printf("--DEBUG-- Making external request to %s", apiURL)
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 404 {
+		return nil, errProviderNotFound
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("request to %s return status code: %v", apiURL, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// Open Library descriptions are either a string or a {"type", "value"} object
+type openLibraryText string
+
+func (t *openLibraryText) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*t = openLibraryText(text)
+		return nil
+	}
+	var object struct {
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	*t = openLibraryText(object.Value)
+	return nil
+}
+
+// Get authors' names from their Open Library keys ("/authors/OL26320A")
+func (cfg *apiConfig) fetchOpenLibraryAuthors(ctx context.Context, keys []string) (string, error) {
+	names := []string{}
+	for _, key := range keys {
+		body, err := fetchFromProvider(ctx, "https://openlibrary.org"+key+".json", map[string]string{"User-Agent": cfg.openlibraryUA})
+		if err != nil {
+			return "", err
+		}
+		var author responseBookAuthor
+		if err := json.Unmarshal(body, &author); err != nil {
+			return "", err
+		}
+		names = append(names, author.Name)
+	}
+	return strings.Join(names, ", "), nil
+}
+
+func openLibraryCover(covers []int) string {
+	if len(covers) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("https://covers.openlibrary.org/b/id/%d-M.jpg", covers[0])
+}
+
+// https://openlibrary.org/works/<id>.json
+func (cfg *apiConfig) fetchOpenLibraryWork(ctx context.Context, id string) (providerMedium, error) {
+	type responseWork struct {
+		Title   string `json:"title"`
+		Authors []struct {
+			Author struct {
+				Key string `json:"key"`
+			} `json:"author"`
+		} `json:"authors"`
+		Description      openLibraryText `json:"description"`
+		Subjects         []string        `json:"subjects"`
+		Covers           []int           `json:"covers"`
+		FirstPublishDate string          `json:"first_publish_date"`
+	}
+
+	body, err := fetchFromProvider(ctx, "https://openlibrary.org/works/"+id+".json", map[string]string{"User-Agent": cfg.openlibraryUA})
+	if err != nil {
+		return providerMedium{}, err
+	}
+	var work responseWork
+	if err := json.Unmarshal(body, &work); err != nil {
+		return providerMedium{}, err
+	}
+
+	authorKeys := []string{}
+	for _, author := range work.Authors {
+		authorKeys = append(authorKeys, author.Author.Key)
+	}
+	authors, err := cfg.fetchOpenLibraryAuthors(ctx, authorKeys)
+	if err != nil {
+		return providerMedium{}, err
+	}
+
+	return providerMedium{
+		Title:    work.Title,
+		Creator:  authors,
+		PubDate:  work.FirstPublishDate,
+		ImageUrl: openLibraryCover(work.Covers),
+		Metadata: map[string]interface{}{
+			"description": string(work.Description),
+			"subjects":    work.Subjects,
+		},
+	}, nil
+}
+
+// https://openlibrary.org/isbn/<isbn>.json
+func (cfg *apiConfig) fetchOpenLibraryISBN(ctx context.Context, isbn string) (providerMedium, error) {
+	type responseEdition struct {
+		Title         string   `json:"title"`
+		FullTitle     string   `json:"full_title"`
+		PublishDate   string   `json:"publish_date"`
+		NumberOfPages int      `json:"number_of_pages"`
+		Publishers    []string `json:"publishers"`
+		Isbn10        []string `json:"isbn_10"`
+		Isbn13        []string `json:"isbn_13"`
+		Covers        []int    `json:"covers"`
+		Authors       []struct {
+			Key string `json:"key"`
+		} `json:"authors"`
+	}
+
+	body, err := fetchFromProvider(ctx, "https://openlibrary.org/isbn/"+isbn+".json", map[string]string{"User-Agent": cfg.openlibraryUA})
+	if err != nil {
+		return providerMedium{}, err
+	}
+	var edition responseEdition
+	if err := json.Unmarshal(body, &edition); err != nil {
+		return providerMedium{}, err
+	}
+
+	authorKeys := []string{}
+	for _, author := range edition.Authors {
+		authorKeys = append(authorKeys, author.Key)
+	}
+	authors, err := cfg.fetchOpenLibraryAuthors(ctx, authorKeys)
+	if err != nil {
+		return providerMedium{}, err
+	}
+
+	metadata := map[string]interface{}{
+		"page_count": edition.NumberOfPages,
+		"publishers": edition.Publishers,
+	}
+	if len(edition.Isbn13) != 0 {
+		metadata["isbn13"] = edition.Isbn13[0]
+	}
+	if len(edition.Isbn10) != 0 {
+		metadata["isbn10"] = edition.Isbn10[0]
+	}
+
+	return providerMedium{
+		Title:    firstNonEmptyString(edition.FullTitle, edition.Title),
+		Creator:  authors,
+		PubDate:  edition.PublishDate,
+		ImageUrl: openLibraryCover(edition.Covers),
+		Metadata: metadata,
+	}, nil
+}
+
+// https://api.themoviedb.org/3/movie/<id>
+func (cfg *apiConfig) fetchTMDBMovie(ctx context.Context, id string) (providerMedium, error) {
+	if cfg.moviedbKey == "" {
+		return providerMedium{}, errProviderNotConfigured
+	}
+	headers := map[string]string{"Authorization": fmt.Sprintf("Bearer %s", cfg.moviedbKey)}
+
+	body, err := fetchFromProvider(ctx, "https://api.themoviedb.org/3/movie/"+id, headers)
+	if err != nil {
+		return providerMedium{}, err
+	}
+	var movie responseMovieDetails
+	if err := json.Unmarshal(body, &movie); err != nil {
+		return providerMedium{}, err
+	}
+	body, err = fetchFromProvider(ctx, "https://api.themoviedb.org/3/movie/"+id+"/credits", headers)
+	if err != nil {
+		return providerMedium{}, err
+	}
+	var credits responseMovieCredits
+	if err := json.Unmarshal(body, &credits); err != nil {
+		return providerMedium{}, err
+	}
+
+	directors := []string{}
+	for _, crewMember := range credits.Crew {
+		if crewMember.Job == "Director" {
+			directors = append(directors, crewMember.Name)
+		}
+	}
+	cast := []string{}
+	for _, castMember := range credits.Cast {
+		if len(cast) == 3 {
+			break
+		}
+		cast = append(cast, castMember.Name)
+	}
+	genres := []string{}
+	for _, genre := range movie.Genres {
+		genres = append(genres, genre.Name)
+	}
+	productionCompanies := []string{}
+	for _, company := range movie.ProductionCompanies {
+		productionCompanies = append(productionCompanies, company.Name)
+	}
+
+	return providerMedium{
+		Title:    movie.Title,
+		Creator:  strings.Join(directors, ", "),
+		PubDate:  movie.ReleaseDate,
+		ImageUrl: tmdbPoster(movie.PosterPath),
+		Metadata: map[string]interface{}{
+			"imdb_id":              movie.ImdbID,
+			"overview":             movie.Overview,
+			"production_companies": productionCompanies,
+			"runtime":              movie.Runtime,
+			"genres":               genres,
+			"cast":                 cast,
+			"original_language":    movie.OriginalLanguage,
+		},
+	}, nil
+}
+
+// https://api.themoviedb.org/3/tv/<id>
+func (cfg *apiConfig) fetchTMDBTv(ctx context.Context, id string) (providerMedium, error) {
+	if cfg.moviedbKey == "" {
+		return providerMedium{}, errProviderNotConfigured
+	}
+
+	body, err := fetchFromProvider(ctx, "https://api.themoviedb.org/3/tv/"+id, map[string]string{"Authorization": fmt.Sprintf("Bearer %s", cfg.moviedbKey)})
+	if err != nil {
+		return providerMedium{}, err
+	}
+	var tv responseTvDetails
+	if err := json.Unmarshal(body, &tv); err != nil {
+		return providerMedium{}, err
+	}
+
+	creators := []string{}
+	for _, creator := range tv.CreatedBy {
+		creators = append(creators, creator.Name)
+	}
+	genres := []string{}
+	for _, genre := range tv.Genres {
+		genres = append(genres, genre.Name)
+	}
+	productionCompanies := []string{}
+	for _, company := range tv.ProductionCompanies {
+		productionCompanies = append(productionCompanies, company.Name)
+	}
+
+	return providerMedium{
+		Title:    tv.Name,
+		Creator:  strings.Join(creators, ", "),
+		PubDate:  tv.FirstAirDate,
+		ImageUrl: tmdbPoster(tv.PosterPath),
+		Metadata: map[string]interface{}{
+			"overview":             tv.Overview,
+			"status":               tv.Status,
+			"number_of_seasons":    tv.NumberOfSeasons,
+			"number_of_episodes":   tv.NumberOfEpisodes,
+			"original_language":    tv.OriginalLanguage,
+			"production_companies": productionCompanies,
+			"genres":               genres,
+		},
+	}, nil
+}
+
+func tmdbPoster(posterPath string) string {
+	if posterPath == "" {
+		return ""
+	}
+	return "https://image.tmdb.org/t/p/w200" + posterPath
+}
+
+// https://api.rawg.io/api/games/<id>
+func (cfg *apiConfig) fetchRAWGGame(ctx context.Context, id string) (providerMedium, error) {
+	if cfg.rawgKey == "" {
+		return providerMedium{}, errProviderNotConfigured
+	}
+
+	body, err := fetchFromProvider(ctx, "https://api.rawg.io/api/games/"+id+"?key="+cfg.rawgKey, nil)
+	if err != nil {
+		return providerMedium{}, err
+	}
+	var game responseVideogameDetails
+	if err := json.Unmarshal(body, &game); err != nil {
+		return providerMedium{}, err
+	}
+
+	developers := []string{}
+	for _, developer := range game.Developers {
+		developers = append(developers, developer.Name)
+	}
+	platforms := []string{}
+	for _, platform := range game.Platforms {
+		platforms = append(platforms, platform.Platform.Name)
+	}
+	genres := []string{}
+	for _, genre := range game.Genres {
+		genres = append(genres, genre.Name)
+	}
+	publishers := []string{}
+	for _, publisher := range game.Publishers {
+		publishers = append(publishers, publisher.Name)
+	}
+
+	return providerMedium{
+		Title:    game.Name,
+		Creator:  strings.Join(developers, ", "),
+		PubDate:  game.Released,
+		ImageUrl: game.BackgroundImage,
+		Metadata: map[string]interface{}{
+			"description": game.DescriptionRaw,
+			"metacritic":  game.Metacritic,
+			"platforms":   platforms,
+			"genres":      genres,
+			"publishers":  publishers,
+		},
+	}, nil
+}
+
+// https://boardgamegeek.com/xmlapi2/thing?id=<id>
+func (cfg *apiConfig) fetchBGGBoardgame(ctx context.Context, id string) (providerMedium, error) {
+	xmlData, err := fetchFromProvider(ctx, "https://boardgamegeek.com/xmlapi2/thing?id="+id, nil)
+	if err != nil {
+		return providerMedium{}, err
+	}
+
+	// Convert XML response to JSON, as boardgame details proxy does
+	mxj.PrependAttrWithHyphen(false)
+	mv, err := mxj.NewMapXml(xmlData)
+	if err != nil {
+		return providerMedium{}, err
+	}
+	jsonData, err := mv.Json()
+	if err != nil {
+		return providerMedium{}, err
+	}
+	var boardgame responseBoardgameDetails
+	if err := json.Unmarshal(jsonData, &boardgame); err != nil {
+		return providerMedium{}, err
+	}
+	item := boardgame.Items.Item
+	if item.ID == "" {
+		// BGG answers 200 with an empty list for unknown IDs
+		return providerMedium{}, errProviderNotFound
+	}
+
+	title := ""
+	for _, name := range item.Name {
+		if name.Type == "primary" {
+			title = name.Value
+		}
+	}
+	links := map[string][]string{}
+	for _, link := range item.Link {
+		links[link.Type] = append(links[link.Type], link.Value)
+	}
+	publishers := links["boardgamepublisher"]
+	if len(publishers) > 3 {
+		publishers = publishers[:3]
+	}
+
+	return providerMedium{
+		Title:    title,
+		Creator:  strings.Join(links["boardgamedesigner"], ", "),
+		PubDate:  item.Yearpublished.Value,
+		ImageUrl: item.Image,
+		Metadata: map[string]interface{}{
+			"categories":      links["boardgamecategory"],
+			"expansions":      links["boardgameexpansion"],
+			"implementations": links["boardgameimplementation"],
+			"artists":         links["boardgameartist"],
+			"main_publishers": publishers,
+			"min_players":     item.Minplayers.Value,
+			"max_players":     item.Maxplayers.Value,
+		},
+	}, nil
+}
+
+func firstNonEmptyString(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -2,69 +2,64 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/VincNT21/kallaxy/server/internal/config"
 	"github.com/VincNT21/kallaxy/server/internal/database"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
 )
 
-func Start(envVars ...map[string]string) {
-	const port = "8080"
-
-	// Env variables are provided for testing cases
-	// Check for them
-	if len(envVars) > 0 && envVars[0] != nil {
-		// Testing case: Use provided environment variables
-		for key, value := range envVars[0] {
-			os.Setenv(key, value)
-		}
-	} else {
-		// Normal case: Load the .env file into the environement
-		err := godotenv.Load(".env")
-		if err != nil {
-			log.Printf("Warning: .env file not found, using environment variables")
-		}
+func Start(args []string) {
+	// Load the .env file into the environement
+	err := godotenv.Load(".env")
+	if err != nil {
+		log.Printf("Warning: .env file not found, using environment variables")
 	}
 
-	// Get info from .env
-	dbUrl := os.Getenv("DB_URL")
-	if dbUrl == "" {
-		log.Fatal("--FATAL ERROR-- DB_URL env. variable must be set")
-	}
-	jwtsecret := os.Getenv("SECRET")
-	if jwtsecret == "" {
-		log.Fatal("--FATAL ERROR-- SECRET env. variable must be set")
-	}
-	openLibraryUA := os.Getenv("OPEN_LIBRARY_USER_AGENT")
-	if openLibraryUA == "" {
-		log.Fatal("--FATAL ERROR-- OPEN LIBRARY USER AGENT env. variable must be set")
-	}
-	moviedbAPIKey := os.Getenv("MOVIEDB_KEY")
-	if moviedbAPIKey == "" {
-		log.Fatal("--FATAL ERROR-- MOVIE DB KEY env. variable must be set")
+	// Load config from flags, env. variables and optional config file
+	serverCfg, err := config.Load(args, os.LookupEnv)
+	if err != nil {
+		log.Fatalf("--FATAL ERROR-- %v", err)
 	}
-	rawgKey := os.Getenv("RAWG_KEY")
-	if rawgKey == "" {
-		log.Fatal("--FATAL ERROR-- RAWG_KEY env. variable must be set")
+	for _, api := range serverCfg.MissingAPIKeys() {
+		log.Printf("--WARNING-- No API key for %s, its proxy endpoints will respond with 503", api)
 	}
 
-	serverVersion := "v1.0.0"
-
 	// Open a connection to database
-	dbConnection, err := pgxpool.New(context.Background(), dbUrl)
+	poolCfg, err := pgxpool.ParseConfig(serverCfg.DBURL)
+	if err != nil {
+		log.Fatalf("--FATAL ERROR-- Invalid DB_URL: %v", err)
+	}
+	poolCfg.MaxConns = serverCfg.DB.MaxConns
+	poolCfg.MinConns = serverCfg.DB.MinConns
+	poolCfg.MaxConnLifetime = serverCfg.DB.MaxConnLifetime
+	poolCfg.MaxConnIdleTime = serverCfg.DB.MaxConnIdleTime
+	dbConnection, err := pgxpool.NewWithConfig(context.Background(), poolCfg)
 	if err != nil {
 		log.Fatalf("--FATAL ERROR-- Couldn't open a connection to db: %v", err)
 	}
 	defer dbConnection.Close()
 
-	// Create a *database.Queries to store in config struct
+	// Apply schema migrations embedded in the binary
+	err = migrateOnStartup(dbConnection)
+	if err != nil {
+		log.Fatalf("--FATAL ERROR-- Couldn't migrate database schema: %v", err)
+	}
+
+	// Create a *database.Queries, our database.Store, to store in config struct
 	db := database.New(dbConnection)
 
+	// Give admin role to configured users
+	promoteAdminsOnStartup(db, serverCfg.AdminUsers)
+
 	// Init apiCfg
-	apiCfg := newAPIConfig(db, jwtsecret, openLibraryUA, moviedbAPIKey, rawgKey, serverVersion)
+	apiCfg := newAPIConfig(db, serverCfg)
 
 	// Delete revoked refresh token in database
 	apiCfg.CleanRefreshTokens()
@@ -89,17 +84,120 @@ func Start(envVars ...map[string]string) {
 
 	// Media endpoints
 	mux.Handle("POST /api/media", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerCreateMedium)))
-	mux.Handle("GET /api/media", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetMediumByTitleAndType)))
+	mux.Handle("GET /api/media", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetMediaByTitleAndType)))
 	mux.Handle("GET /api/media/type", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetMediaByType)))
+	mux.Handle("GET /api/media/rating", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetMediumRating)))
+	mux.Handle("GET /api/media/reviews", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetMediumReviews)))
+	mux.Handle("GET /api/media/loans", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetMediumLoans)))
+	mux.Handle("GET /api/media/copies", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetMediumCopies)))
 	mux.Handle("GET /api/media_records", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetRecordsAndMediaByUserID)))
+	mux.Handle("GET /api/media_records/search", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerSearchMediaRecords)))
 	mux.Handle("PUT /api/media", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerUpdateMedium)))
 	mux.Handle("DELETE /api/media", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerDeleteMedium)))
+	mux.Handle("POST /api/media/{id}/refresh", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerRefreshMedium)))
 
 	// Records endpoints
 	mux.Handle("POST /api/records", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerCreateUserMediumRecord)))
 	mux.Handle("GET /api/records", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetRecordsByUserID)))
 	mux.Handle("PUT /api/records", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerUpdateRecord)))
 	mux.Handle("DELETE /api/records", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerDeleteRecord)))
+	mux.Handle("GET /api/records/progress", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetRecordProgress)))
+	mux.Handle("GET /api/records/pauses", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetRecordPauses)))
+
+	// Reviews endpoints
+	mux.Handle("POST /api/reviews", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerCreateReview)))
+	mux.Handle("GET /api/reviews", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetReview)))
+	mux.Handle("PUT /api/reviews", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerUpdateReview)))
+	mux.Handle("DELETE /api/reviews", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerDeleteReview)))
+	mux.Handle("GET /api/reviews/revisions", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetReviewRevisions)))
+
+	// Loans endpoints
+	mux.Handle("POST /api/loans", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerCreateLoan)))
+	mux.Handle("GET /api/loans", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetLoans)))
+	mux.Handle("PUT /api/loans", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerUpdateLoan)))
+	mux.Handle("DELETE /api/loans", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerDeleteLoan)))
+	mux.Handle("GET /api/loans/overdue", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetOverdueLoans)))
+	mux.Handle("PUT /api/loans/return", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerReturnLoan)))
+
+	// Owned copies endpoints
+	mux.Handle("POST /api/copies", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerCreateCopy)))
+	mux.Handle("GET /api/copies", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetCopies)))
+	mux.Handle("PUT /api/copies", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerUpdateCopy)))
+	mux.Handle("DELETE /api/copies", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerDeleteCopy)))
+	mux.Handle("GET /api/copies/value", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetCollectionValue)))
+
+	// Shelving units endpoints
+	mux.Handle("POST /api/shelving_units", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerCreateShelvingUnit)))
+	mux.Handle("GET /api/shelving_units", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetShelvingUnits)))
+	mux.Handle("PUT /api/shelving_units", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerUpdateShelvingUnit)))
+	mux.Handle("DELETE /api/shelving_units", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerDeleteShelvingUnit)))
+	mux.Handle("GET /api/shelving_units/grid", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetShelvingUnitGrid)))
+	mux.Handle("PUT /api/shelving_units/items", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerPlaceShelvingItem)))
+	mux.Handle("DELETE /api/shelving_units/items", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerRemoveShelvingItem)))
+	mux.Handle("GET /api/shelving_units/where", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerWhereIsMedium)))
+
+	// Boardgame plays endpoints
+	mux.Handle("POST /api/plays", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerCreatePlay)))
+	mux.Handle("GET /api/plays", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetPlays)))
+	mux.Handle("PUT /api/plays", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerUpdatePlay)))
+	mux.Handle("DELETE /api/plays", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerDeletePlay)))
+	mux.Handle("GET /api/plays/counts", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetPlayCounts)))
+	mux.Handle("GET /api/plays/win_rates", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetPlayerWinRates)))
+	mux.Handle("GET /api/plays/h_index", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetPlaysHIndex)))
+
+	// Videogames endpoints
+	mux.Handle("PUT /api/records/videogame", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerUpdateRecordVideogame)))
+	mux.Handle("GET /api/records/videogame", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetRecordVideogame)))
+	mux.Handle("GET /api/videogames/platforms", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetPlatformStats)))
+	mux.Handle("GET /api/videogames/backlog", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetVideogameBacklog)))
+
+	// Episodes endpoints
+	mux.Handle("PUT /api/records/episodes", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerMarkEpisodes)))
+	mux.Handle("DELETE /api/records/episodes", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerUnmarkEpisodes)))
+	mux.Handle("GET /api/records/episodes", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetRecordEpisodes)))
+	mux.Handle("GET /api/records/episodes/next", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetNextEpisodes)))
+
+	// Goals endpoints
+	mux.Handle("POST /api/goals", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerCreateGoal)))
+	mux.Handle("GET /api/goals", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetGoals)))
+	mux.Handle("PUT /api/goals", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerUpdateGoal)))
+	mux.Handle("DELETE /api/goals", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerDeleteGoal)))
+	mux.Handle("GET /api/goals/streak", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetStreak)))
+
+	// Shares endpoints
+	mux.Handle("POST /api/shares", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerCreateShare)))
+	mux.Handle("GET /api/shares", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetShares)))
+	mux.Handle("GET /api/shares/records", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetSharedMediaRecords)))
+	mux.Handle("POST /api/shares/records", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerAddSharedRecord)))
+	mux.Handle("DELETE /api/shares", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerDeleteShare)))
+
+	// Tags endpoints
+	mux.Handle("POST /api/tags", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerCreateTag)))
+	mux.Handle("GET /api/tags", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetTags)))
+	mux.Handle("PUT /api/tags", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerUpdateTag)))
+	mux.Handle("DELETE /api/tags", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerDeleteTag)))
+	mux.Handle("POST /api/tags/media", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerTagMedia)))
+	mux.Handle("DELETE /api/tags/media", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerUntagMedia)))
+
+	// Custom shelves endpoints
+	mux.Handle("POST /api/shelves", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerCreateShelf)))
+	mux.Handle("GET /api/shelves", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetShelves)))
+	mux.Handle("PUT /api/shelves", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerUpdateShelf)))
+	mux.Handle("DELETE /api/shelves", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerDeleteShelf)))
+	mux.Handle("POST /api/shelves/media", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerAddMediaToShelf)))
+	mux.Handle("DELETE /api/shelves/media", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerRemoveMediaFromShelf)))
+
+	// Stats endpoints
+	mux.Handle("GET /api/stats", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerGetStats)))
+
+	// Admin endpoints
+	mux.Handle("GET /admin/users", apiCfg.adminMiddleware(http.HandlerFunc(apiCfg.handlerAdminGetUsers)))
+	mux.Handle("PUT /admin/users/deactivate", apiCfg.adminMiddleware(http.HandlerFunc(apiCfg.handlerAdminDeactivateUser)))
+	mux.Handle("PUT /admin/users/reactivate", apiCfg.adminMiddleware(http.HandlerFunc(apiCfg.handlerAdminReactivateUser)))
+	mux.Handle("POST /admin/users/logout", apiCfg.adminMiddleware(http.HandlerFunc(apiCfg.handlerAdminLogoutUser)))
+	mux.Handle("GET /admin/counts", apiCfg.adminMiddleware(http.HandlerFunc(apiCfg.handlerAdminGetCounts)))
+	mux.Handle("PUT /admin/media", apiCfg.adminMiddleware(http.HandlerFunc(apiCfg.handlerUpdateMedium)))
+	mux.Handle("POST /admin/media/merge", apiCfg.adminMiddleware(http.HandlerFunc(apiCfg.handlerAdminMergeMedia)))
 
 	// Reset Password endpoints
 	mux.HandleFunc("POST /auth/password_reset", apiCfg.handlerPasswordResetRequest)
@@ -112,6 +210,8 @@ func Start(envVars ...map[string]string) {
 		mux.HandleFunc("GET /admin/user", apiCfg.handlerCheckUserExists)
 		mux.HandleFunc("GET /admin/medium", apiCfg.handlerCheckMediumExists)
 		mux.HandleFunc("GET /admin/record", apiCfg.handlerCheckRecordExists)
+		mux.HandleFunc("PUT /admin/user/role", apiCfg.handlerSetUserRole)
+		mux.HandleFunc("PUT /admin/user/deactivated", apiCfg.handlerSetUserDeactivated)
 	*/
 
 	// Communicate server version to client
@@ -129,6 +229,7 @@ func Start(envVars ...map[string]string) {
 	mux.Handle("GET /external_api/movie_tv/search_tv", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerTVSearch)))
 	mux.Handle("GET /external_api/movie_tv/search", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerMultiSearch)))
 	mux.Handle("GET /external_api/movie_tv", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerMovieTvDetails)))
+	mux.Handle("GET /external_api/movie_tv/tv_season", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerTvSeasonDetails)))
 	mux.Handle("GET /external_api/movie_tv/movie_credits", apiCfg.authMiddleware(http.HandlerFunc(apiCfg.handlerMovieCreditsDetails)))
 
 	// Videogames
@@ -140,18 +241,41 @@ func Start(envVars ...map[string]string) {
 
 	// Create a http server that listens on defined port and use multiplexer
 	srv := &http.Server{
-		Addr:    ":" + port,
-		Handler: mux,
+		Addr:         fmt.Sprintf(":%d", serverCfg.Port),
+		Handler:      mux,
+		ReadTimeout:  serverCfg.HTTP.ReadTimeout,
+		WriteTimeout: serverCfg.HTTP.WriteTimeout,
+		IdleTimeout:  serverCfg.HTTP.IdleTimeout,
 	}
 
-	log.Printf("--DEBUG-- Server started on port %s\n", port)
+	// Start the server in background, so we can wait for a shutdown signal
+	serverErr := make(chan error, 1)
+	go func() {
+		log.Printf("--DEBUG-- Server %s started on port %d\n", serverCfg.ServerVersion, serverCfg.Port)
+		serverErr <- srv.ListenAndServe()
+	}()
 
-	// Start the server and log a fatal error if it fails to start
-	err = srv.ListenAndServe()
-	if err != nil {
-		log.Fatal(err)
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+
+	select {
+	case err = <-serverErr:
+		// Server failed to start
+		dbConnection.Close()
+		log.Fatalf("--FATAL ERROR-- %v", err)
+	case sig := <-stop:
+		log.Printf("--INFO-- Received %v, shutting down", sig)
 	}
 
+	// Let in-flight requests finish before database pool gets closed
+	ctx, cancel := context.WithTimeout(context.Background(), serverCfg.HTTP.ShutdownTimeout)
+	defer cancel()
+	err = srv.Shutdown(ctx)
+	if err != nil {
+		log.Printf("--ERROR-- Server didn't shut down cleanly: %v", err)
+		return
+	}
+	log.Println("--INFO-- Server stopped")
 }
 
 // Delete any revoked Refresh token
@@ -5,7 +5,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -133,6 +136,9 @@ func TestGetUser(t *testing.T) {
 	ctx.CreateTestUser(t)
 	ctx.LoginTestUser(t)
 
+	deactivatedUser := ctx.CreateOtherTestUser(t, "Bob")
+	deactivatedUser.DeactivateTestUser(t)
+
 	testMethod := "GET"
 	testEndpoint := ctx.BaseURL + "/api/users"
 
@@ -169,6 +175,13 @@ func TestGetUser(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Deactivated user's access_token",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", deactivatedUser.UserAcessToken),
+			},
+			expectedStatus: 403,
+		},
 		{
 			name:           "No access_token",
 			expectedStatus: 401,
@@ -579,6 +592,9 @@ func TestRefreshTokens(t *testing.T) {
 	ctx.CreateTestUser(t)
 	ctx.LoginTestUser(t)
 
+	deactivatedUser := ctx.CreateOtherTestUser(t, "Bob")
+	deactivatedUser.DeactivateTestUser(t)
+
 	testMethod := "POST"
 	testEndpoint := ctx.BaseURL + "/auth/refresh"
 
@@ -612,6 +628,13 @@ func TestRefreshTokens(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Deactivated user's Refresh Token",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", deactivatedUser.UserRefreshToken),
+			},
+			expectedStatus: 403,
+		},
 		{
 			name: "Invalid Refresh Token",
 			requestHeaders: map[string]string{
@@ -811,13 +834,79 @@ func TestCreateMedium(t *testing.T) {
 			expectedStatus: 401,
 		},
 		{
-			name: "Duplicate Title",
+			name: "Duplicate title, creator and publication year",
 			requestHeaders: map[string]string{
 				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
 			},
-			requestBody:    testBook,
+			requestBody: parametersCreateMedium{
+				Title:     "the fellowship of the ring",
+				MediaType: "book",
+				Creator:   "j.r.r tolkien",
+				PubDate:   "1954-07-29",
+			},
+			expectedStatus: 409,
+		},
+		{
+			name: "Same title, other creator and publication year",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: parametersCreateMedium{
+				Title:     "The Fellowship of the Ring",
+				MediaType: "book",
+				Creator:   "Someone Else",
+				PubDate:   "2001",
+			},
+			expectedStatus: 201,
+		},
+		{
+			name: "Same title, creator and year, with an external ID",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: parametersCreateMedium{
+				Title:       "The Fellowship of the Ring",
+				MediaType:   "book",
+				Creator:     "J.R.R Tolkien",
+				PubDate:     "1954",
+				ExternalIDs: map[string]string{"openlibrary_work": "OL27479W", "isbn13": ""},
+			},
+			expectedStatus: 201,
+			expectResponse: true,
+			checkResponse: func(t *testing.T, r ClientMedium) {
+				if len(r.ExternalIDs) != 1 || r.ExternalIDs["openlibrary_work"] != "OL27479W" {
+					t.Errorf("'external_ids' response field incorrect: %v", r.ExternalIDs)
+				}
+			},
+		},
+		{
+			name: "Duplicate external ID",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: parametersCreateMedium{
+				Title:       "La Communauté de l'anneau",
+				MediaType:   "book",
+				Creator:     "J.R.R Tolkien",
+				PubDate:     "1972",
+				ExternalIDs: map[string]string{"openlibrary_work": "OL27479W"},
+			},
 			expectedStatus: 409,
 		},
+		{
+			name: "Unknown external ID provider",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: parametersCreateMedium{
+				Title:       "The Lord of the Rings",
+				MediaType:   "movie",
+				Creator:     "Peter Jackson",
+				PubDate:     "2001",
+				ExternalIDs: map[string]string{"imdb": "tt0120737"},
+			},
+			expectedStatus: 400,
+		},
 		{
 			name: "Missing a needed field",
 			requestHeaders: map[string]string{
@@ -896,6 +985,16 @@ func TestGetMediaByTitle(t *testing.T) {
 
 	ctx.CreateTestMediumCustom(t, testBook)
 
+	// Same title, other creator and publication year
+	otherBook := parametersCreateMedium{
+		Title:       "The Fellowship of the Ring",
+		MediaType:   "book",
+		Creator:     "Someone Else",
+		PubDate:     "2001-12-19",
+		ExternalIDs: map[string]string{"openlibrary_work": "OL0000W", "isbn13": "9780000000001"},
+	}
+	ctx.CreateTestMediumCustom(t, otherBook)
+
 	testMethod := "GET"
 	testEndpoint := ctx.BaseURL + "/api/media"
 
@@ -903,10 +1002,10 @@ func TestGetMediaByTitle(t *testing.T) {
 		name           string
 		requestHeaders map[string]string
 		queryParameter string
-		requestBody    map[string]string
+		requestBody    map[string]interface{}
 		expectedStatus int
 		expectResponse bool
-		checkResponse  func(*testing.T, ClientMedium)
+		checkResponse  func(*testing.T, ClientListMedia)
 		checkAfter     func(*testing.T)
 	}{
 		{
@@ -914,10 +1013,21 @@ func TestGetMediaByTitle(t *testing.T) {
 			requestHeaders: map[string]string{
 				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
 			},
-			requestBody:    nil,
+			requestBody: map[string]interface{}{
+				"title":      "The Fellowship of the Ring",
+				"media_type": "book",
+			},
 			expectedStatus: 200,
 			expectResponse: true,
-			checkResponse: func(t *testing.T, m ClientMedium) {
+			checkResponse: func(t *testing.T, r ClientListMedia) {
+				if len(r.Media) != 2 {
+					t.Fatalf("expected 2 candidates, got %d", len(r.Media))
+				}
+				// Candidates are ordered by publication date
+				m := r.Media[0]
+				if r.Media[1].Creator != otherBook.Creator {
+					t.Error("incorrect second candidate")
+				}
 				if m.Title != testBook.Title {
 					t.Error("incorrect Title")
 				}
@@ -944,6 +1054,51 @@ func TestGetMediaByTitle(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Narrowed by creator and publication year",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: map[string]interface{}{
+				"title":      "the fellowship of the ring",
+				"media_type": "book",
+				"creator":    "someone else",
+				"pub_date":   "2001",
+			},
+			expectedStatus: 200,
+			expectResponse: true,
+			checkResponse: func(t *testing.T, r ClientListMedia) {
+				if len(r.Media) != 1 || r.Media[0].PubDate != otherBook.PubDate {
+					t.Errorf("expected only the 2001 medium, got %+v", r.Media)
+				}
+			},
+		},
+		{
+			name: "By external ID",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: map[string]interface{}{
+				"external_ids": map[string]string{"isbn13": "9780000000001", "tmdb_movie": "1"},
+			},
+			expectedStatus: 200,
+			expectResponse: true,
+			checkResponse: func(t *testing.T, r ClientListMedia) {
+				if len(r.Media) != 1 || r.Media[0].ExternalIDs["openlibrary_work"] != otherBook.ExternalIDs["openlibrary_work"] {
+					t.Errorf("expected the medium with given external ID, got %+v", r.Media)
+				}
+			},
+		},
+		{
+			name: "Unknown external IDs",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: map[string]interface{}{
+				"external_ids": map[string]string{"openlibrary_work": "OL1W"},
+			},
+			expectedStatus: 404,
+		},
 		{
 			name:           "No access_token",
 			expectedStatus: 401,
@@ -953,16 +1108,22 @@ func TestGetMediaByTitle(t *testing.T) {
 			requestHeaders: map[string]string{
 				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
 			},
-			queryParameter: "?title=The+Fellowshi+Of+The+Ring",
+			requestBody: map[string]interface{}{
+				"title":      "The Fellowshi Of The Ring",
+				"media_type": "book",
+			},
 			expectedStatus: 404,
 		},
 		{
-			name: "Invalid query parameter",
+			name: "Invalid body parameter",
 			requestHeaders: map[string]string{
 				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
 			},
-			queryParameter: "?til=The+Fellowshi+Of+The+Ring",
-			expectedStatus: 400,
+			requestBody: map[string]interface{}{
+				"til":        "The Fellowship of the Ring",
+				"media_type": "book",
+			},
+			expectedStatus: 404,
 		},
 	}
 	for _, tc := range tests {
@@ -986,7 +1147,7 @@ func TestGetMediaByTitle(t *testing.T) {
 			}
 
 			if tc.expectResponse {
-				var responseBody ClientMedium
+				var responseBody ClientListMedia
 				err := json.NewDecoder(resp.Body).Decode(&responseBody)
 				if err != nil {
 					t.Fatalf("Failed to decode response: %v", err)
@@ -1028,7 +1189,7 @@ func TestGetMediaByType(t *testing.T) {
 	ctx.CreateTestMediumCustom(t, testBook2)
 
 	testMethod := "GET"
-	testEndpoint := ctx.BaseURL + "/api/media"
+	testEndpoint := ctx.BaseURL + "/api/media/type"
 
 	tests := []struct {
 		name           string
@@ -1045,7 +1206,9 @@ func TestGetMediaByType(t *testing.T) {
 			requestHeaders: map[string]string{
 				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
 			},
-			queryParameter: "?type=book",
+			requestBody: map[string]string{
+				"media_type": "book",
+			},
 			expectedStatus: 200,
 			expectResponse: true,
 			checkResponse: func(t *testing.T, r ClientListMedia) {
@@ -1087,16 +1250,20 @@ func TestGetMediaByType(t *testing.T) {
 			requestHeaders: map[string]string{
 				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
 			},
-			queryParameter: "?type=comic",
+			requestBody: map[string]string{
+				"media_type": "comic",
+			},
 			expectedStatus: 404,
 		},
 		{
-			name: "Invalid query parameter",
+			name: "Invalid body parameter",
 			requestHeaders: map[string]string{
 				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
 			},
-			queryParameter: "?typ=book",
-			expectedStatus: 400,
+			requestBody: map[string]string{
+				"typ": "book",
+			},
+			expectedStatus: 404,
 		},
 	}
 	for _, tc := range tests {
@@ -1160,6 +1327,7 @@ func TestUpdateMedium(t *testing.T) {
 
 	mediumId := ctx.CreateTestMediumCustom(t, testBook)
 	ctx.CreateTestMediumCustom(t, testBook2)
+	other := ctx.CreateOtherTestUser(t, "Bob")
 
 	testMethod := "PUT"
 	testEndpoint := ctx.BaseURL + "/api/media"
@@ -1197,21 +1365,37 @@ func TestUpdateMedium(t *testing.T) {
 				if m.Creator != "John Ronald Reuel Tolkien" {
 					t.Error("'creator' field not updated")
 				}
+				if m.CreatedBy == "" {
+					t.Error("Empty 'created_by' field")
+				}
+			},
+		},
+		{
+			name: "Not the medium's creator",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", other.UserAcessToken),
+			},
+			requestBody: parametersUpdateMedium{
+				MediumID: mediumId,
+				Title:    "The Fellowship of the Ring",
+				Creator:  "Someone else",
+				PubDate:  "1954",
 			},
+			expectedStatus: 403,
 		},
 		{
 			name:           "No access_token",
 			expectedStatus: 401,
 		},
 		{
-			name: "Title duplicate",
+			name: "Title, creator and publication year duplicate",
 			requestHeaders: map[string]string{
 				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
 			},
 			requestBody: parametersUpdateMedium{
 				MediumID: mediumId,
 				Title:    "The Two Towers",
-				Creator:  "John Ronald Reuel Tolkien",
+				Creator:  "J.R.R Tolkien",
 				PubDate:  "1954",
 				ImageUrl: "https://upload.wikimedia.org/wikipedia/en/thumb/8/8e/The_Fellowship_of_the_Ring_cover.gif/220px-The_Fellowship_of_the_Ring_cover.gif",
 			},
@@ -1298,6 +1482,14 @@ func TestDeleteMedium(t *testing.T) {
 		ImageUrl:  "https://upload.wikimedia.org/wikipedia/en/8/8e/The_Fellowship_of_the_Ring_cover.gif",
 	}
 	mediumID := ctx.CreateTestMediumCustom(t, testBook)
+	sharedMediumID := ctx.CreateTestMediumRandom(t)
+	ctx.CreateTestRecord(t, sharedMediumID)
+
+	// Bob holds a record on both media, Carol has none
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+	carol := ctx.CreateOtherTestUser(t, "Carol")
+	bobRecordID := bob.CreateTestRecord(t, mediumID)
+	bob.CreateTestRecord(t, sharedMediumID)
 
 	testMethod := "DELETE"
 	testEndpoint := ctx.BaseURL + "/api/media"
@@ -1308,9 +1500,79 @@ func TestDeleteMedium(t *testing.T) {
 		requestBody    parametersDeleteMedium
 		expectedStatus int
 		expectResponse bool
-		checkResponse  func(*testing.T, map[string]string)
+		checkResponse  func(*testing.T, ClientDeleteMedium)
 		checkAfter     func(*testing.T)
 	}{
+		{
+			name: "Not the medium's creator, not in user's shelf",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", carol.UserAcessToken),
+			},
+			requestBody: parametersDeleteMedium{
+				MediumID: mediumID,
+			},
+			expectedStatus: 403,
+			checkAfter: func(t *testing.T) {
+				if !ctx.TestIfMediumExist(mediumID) {
+					t.Error("Medium was deleted by another user")
+				}
+			},
+		},
+		{
+			name: "Not the medium's creator, removed from user's shelf",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody: parametersDeleteMedium{
+				MediumID: mediumID,
+			},
+			expectedStatus: 200,
+			expectResponse: true,
+			checkResponse: func(t *testing.T, resp ClientDeleteMedium) {
+				if resp.MediumDeleted || !resp.RecordDeleted {
+					t.Errorf("Expected only the record to be deleted, got %+v", resp)
+				}
+			},
+			checkAfter: func(t *testing.T) {
+				if !ctx.TestIfMediumExist(mediumID) {
+					t.Error("Medium was deleted by another user")
+				}
+				if ctx.TestIfRecordExist(bobRecordID) {
+					t.Error("Record still exists in database")
+				}
+			},
+		},
+		{
+			name: "Other users still hold records, removed from user's shelf",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: parametersDeleteMedium{
+				MediumID: sharedMediumID,
+			},
+			expectedStatus: 200,
+			expectResponse: true,
+			checkResponse: func(t *testing.T, resp ClientDeleteMedium) {
+				if resp.MediumDeleted || !resp.RecordDeleted {
+					t.Errorf("Expected only the record to be deleted, got %+v", resp)
+				}
+			},
+			checkAfter: func(t *testing.T) {
+				if !ctx.TestIfMediumExist(sharedMediumID) {
+					t.Error("Medium was deleted while other users hold records for it")
+				}
+			},
+		},
+		{
+			name: "Other users still hold records, not in user's shelf",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: parametersDeleteMedium{
+				MediumID: sharedMediumID,
+			},
+			expectedStatus: 403,
+		},
 		{
 			name: "Valid",
 			requestHeaders: map[string]string{
@@ -1320,6 +1582,12 @@ func TestDeleteMedium(t *testing.T) {
 				MediumID: mediumID,
 			},
 			expectedStatus: 200,
+			expectResponse: true,
+			checkResponse: func(t *testing.T, resp ClientDeleteMedium) {
+				if !resp.MediumDeleted {
+					t.Error("Expected the medium to be deleted")
+				}
+			},
 			checkAfter: func(t *testing.T) {
 				if ctx.TestIfMediumExist(mediumID) {
 					t.Error("Medium still exists in database")
@@ -1362,7 +1630,7 @@ func TestDeleteMedium(t *testing.T) {
 			}
 
 			if tc.expectResponse {
-				var responseBody map[string]string
+				var responseBody ClientDeleteMedium
 				err := json.NewDecoder(resp.Body).Decode(&responseBody)
 				if err != nil {
 					t.Fatalf("Failed to decode response: %v", err)
@@ -1378,130 +1646,316 @@ func TestDeleteMedium(t *testing.T) {
 	}
 }
 
-/*
-============================
-TESTS FOR RECORDS ENDPOINTS
-============================
-*/
-
-func TestCreateRecord(t *testing.T) {
+func TestRefreshMedium(t *testing.T) {
 	ctx := SetupTestContext(t)
 	defer ctx.Server.Shutdown(context.Background())
 
 	ctx.CreateTestUser(t)
 	ctx.LoginTestUser(t)
 
-	medium1Id := ctx.CreateTestMediumRandom(t)
-	medium2Id := ctx.CreateTestMediumRandom(t)
-	medium3Id := ctx.CreateTestMediumRandom(t)
-	medium4Id := ctx.CreateTestMediumRandom(t)
-	medium5Id := ctx.CreateTestMediumRandom(t)
-
-	dateNow := time.Now().UTC().Format(time.RFC3339)
-	dateFuture := time.Now().UTC().AddDate(0, 0, 21).Format(time.RFC3339)
-
-	datePast := time.Now().UTC().AddDate(0, 0, -21).Format(time.RFC3339)
-	dateInvalid := "2024_01_01:21:21:21"
+	movieID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{
+		Title:       "Dune Part One",
+		MediaType:   "movie",
+		Creator:     "Denis Villeneuve",
+		PubDate:     "2021",
+		Metadata:    map[string]interface{}{"runtime": 155, "personal_note": "seen at the cinema"},
+		ExternalIDs: map[string]string{"tmdb_movie": "438631"},
+	})
+	unknownID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{
+		Title:       "Unknown movie",
+		MediaType:   "movie",
+		Creator:     "Nobody",
+		PubDate:     "2000",
+		ExternalIDs: map[string]string{"tmdb_movie": "1"},
+	})
+	gameID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{
+		Title:       "Hades",
+		MediaType:   "videogame",
+		Creator:     "Supergiant Games",
+		PubDate:     "2020",
+		ExternalIDs: map[string]string{"rawg": "274755"},
+	})
+	unlinkedID := ctx.CreateTestMediumRandom(t)
+
+	bob := ctx.CreateOtherTestUser(t, "Bob")
 
 	testMethod := "POST"
-	testEndpoint := ctx.BaseURL + "/api/records"
 
 	tests := []struct {
 		name           string
+		mediumID       string
 		requestHeaders map[string]string
-		requestBody    parametersCreateUserMediumRecord
+		requestBody    parametersRefreshMedium
 		expectedStatus int
 		expectResponse bool
-		checkResponse  func(*testing.T, ClientRecord)
-		checkAfter     func(*testing.T)
+		checkResponse  func(*testing.T, ClientRefreshMedium)
 	}{
 		{
-			name: "Valid, start date and end date",
+			name:     "Preview changes",
+			mediumID: movieID,
 			requestHeaders: map[string]string{
-				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
-			},
-			requestBody: parametersCreateUserMediumRecord{
-				MediumID:  medium1Id,
-				StartDate: dateNow,
-				EndDate:   dateFuture,
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
 			},
-			expectedStatus: 201,
+			expectedStatus: 200,
 			expectResponse: true,
-			checkResponse: func(t *testing.T, r ClientRecord) {
-				if r.ID == "" {
-					t.Error("'id' response field missing")
-				}
-				if r.CreatedAt == "" {
-					t.Error("'created_at' response field missing")
-				}
-				if r.UpdatedAt == "" {
-					t.Error("'updated_at' response field missing")
-				}
-				if r.UserID != ctx.UserID.String() {
-					t.Error("'user_id' response field incorrect")
+			checkResponse: func(t *testing.T, resp ClientRefreshMedium) {
+				if resp.Applied || resp.Provider != "tmdb_movie" {
+					t.Errorf("Expected a tmdb_movie preview, got provider %s and applied %v", resp.Provider, resp.Applied)
 				}
-				if r.MediaID != medium1Id {
-					t.Error("'media_id' response field incorrect")
-				}
-				if !r.IsFinished {
-					t.Error("'is_finished' response field incorrect")
-				}
-				if r.StartDate == "" {
-					t.Error("'start_date' response field missing")
+				fields := []string{}
+				for _, change := range resp.Changes {
+					fields = append(fields, change.Field)
 				}
-				if r.EndDate == "" {
-					t.Error("'end_date_date' response field missing")
+				expected := []string{"title", "pub_date", "image_url", "metadata.genres"}
+				if !reflect.DeepEqual(fields, expected) {
+					t.Errorf("Expected changes on %v, got %v", expected, fields)
 				}
-				if r.Duration != 21 {
-					t.Error("'duration' response field incorrect")
+				if resp.Medium.Title != "Dune Part One" {
+					t.Errorf("Expected medium to be unchanged, got title %s", resp.Medium.Title)
 				}
 			},
 		},
 		{
-			name: "Valid, no end date",
+			name:     "Apply changes, not the medium's creator",
+			mediumID: movieID,
 			requestHeaders: map[string]string{
-				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
 			},
-			requestBody: parametersCreateUserMediumRecord{
-				MediumID:  medium2Id,
-				StartDate: dateNow,
-				EndDate:   "",
+			requestBody:    parametersRefreshMedium{Apply: true},
+			expectedStatus: 403,
+		},
+		{
+			name:     "Apply changes",
+			mediumID: movieID,
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
 			},
-			expectedStatus: 201,
+			requestBody:    parametersRefreshMedium{Apply: true},
+			expectedStatus: 200,
 			expectResponse: true,
-			checkResponse: func(t *testing.T, r ClientRecord) {
-				if r.ID == "" {
-					t.Error("'id' response field missing")
-				}
-				if r.StartDate == "" {
-					t.Error("'start_date' response field missing")
-				}
-				if r.EndDate != "" {
-					t.Error("'end_date' response field incorrect")
+			checkResponse: func(t *testing.T, resp ClientRefreshMedium) {
+				if !resp.Applied || len(resp.Changes) != 4 {
+					t.Errorf("Expected 4 applied changes, got %d and applied %v", len(resp.Changes), resp.Applied)
 				}
-				if r.IsFinished {
-					t.Error("'is_finished' response field incorrect")
+				if resp.Medium.Title != "Dune" || resp.Medium.PubDate != "2021-09-15" {
+					t.Errorf("Expected fetched title and date, got %s and %s", resp.Medium.Title, resp.Medium.PubDate)
 				}
-				if r.Duration != 0 {
-					t.Error("'duration' response field incorrect")
+				if resp.Medium.Metadata["personal_note"] != "seen at the cinema" {
+					t.Errorf("Expected metadata unknown to provider to be kept, got %v", resp.Medium.Metadata)
 				}
 			},
 		},
 		{
-			name: "Valid, no start date",
+			name:     "Already up to date",
+			mediumID: movieID,
 			requestHeaders: map[string]string{
 				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
 			},
-			requestBody: parametersCreateUserMediumRecord{
-				MediumID:  medium3Id,
-				StartDate: "",
-				EndDate:   dateNow,
-			},
-			expectedStatus: 201,
+			requestBody:    parametersRefreshMedium{Apply: true},
+			expectedStatus: 200,
 			expectResponse: true,
-			checkResponse: func(t *testing.T, r ClientRecord) {
-				if r.ID == "" {
-					t.Error("'id' response field missing")
+			checkResponse: func(t *testing.T, resp ClientRefreshMedium) {
+				if resp.Applied || len(resp.Changes) != 0 {
+					t.Errorf("Expected no change, got %d", len(resp.Changes))
+				}
+			},
+		},
+		{
+			name:     "Not linked to any provider",
+			mediumID: unlinkedID,
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			expectedStatus: 400,
+		},
+		{
+			name:     "Unknown ID on provider",
+			mediumID: unknownID,
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			expectedStatus: 404,
+		},
+		{
+			name:     "Provider not configured",
+			mediumID: gameID,
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			expectedStatus: 503,
+		},
+		{
+			name:     "Wrong medium ID",
+			mediumID: "40e6215d-b5c6-4896-987c-f30f3678f608",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			expectedStatus: 404,
+		},
+		{
+			name:           "No access_token",
+			mediumID:       movieID,
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, ctx.BaseURL+"/api/media/"+tc.mediumID+"/refresh", bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			if tc.requestHeaders != nil {
+				for headerKey, headerValue := range tc.requestHeaders {
+					req.Header.Set(headerKey, headerValue)
+				}
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.expectResponse {
+				var responseBody ClientRefreshMedium
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				if tc.checkResponse != nil {
+					tc.checkResponse(t, responseBody)
+				}
+			}
+		})
+	}
+}
+
+/*
+============================
+TESTS FOR RECORDS ENDPOINTS
+============================
+*/
+
+func TestCreateRecord(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+
+	medium1Id := ctx.CreateTestMediumRandom(t)
+	medium2Id := ctx.CreateTestMediumRandom(t)
+	medium3Id := ctx.CreateTestMediumRandom(t)
+	medium4Id := ctx.CreateTestMediumRandom(t)
+	medium5Id := ctx.CreateTestMediumRandom(t)
+
+	dateNow := time.Now().UTC().Format(time.RFC3339)
+	dateFuture := time.Now().UTC().AddDate(0, 0, 21).Format(time.RFC3339)
+
+	datePast := time.Now().UTC().AddDate(0, 0, -21).Format(time.RFC3339)
+	dateInvalid := "2024_01_01:21:21:21"
+
+	testMethod := "POST"
+	testEndpoint := ctx.BaseURL + "/api/records"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersCreateUserMediumRecord
+		expectedStatus int
+		expectResponse bool
+		checkResponse  func(*testing.T, ClientRecord)
+		checkAfter     func(*testing.T)
+	}{
+		{
+			name: "Valid, start date and end date",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: parametersCreateUserMediumRecord{
+				MediumID:  medium1Id,
+				StartDate: dateNow,
+				EndDate:   dateFuture,
+			},
+			expectedStatus: 201,
+			expectResponse: true,
+			checkResponse: func(t *testing.T, r ClientRecord) {
+				if r.ID == "" {
+					t.Error("'id' response field missing")
+				}
+				if r.CreatedAt == "" {
+					t.Error("'created_at' response field missing")
+				}
+				if r.UpdatedAt == "" {
+					t.Error("'updated_at' response field missing")
+				}
+				if r.UserID != ctx.UserID.String() {
+					t.Error("'user_id' response field incorrect")
+				}
+				if r.MediaID != medium1Id {
+					t.Error("'media_id' response field incorrect")
+				}
+				if !r.IsFinished {
+					t.Error("'is_finished' response field incorrect")
+				}
+				if r.StartDate == "" {
+					t.Error("'start_date' response field missing")
+				}
+				if r.EndDate == "" {
+					t.Error("'end_date_date' response field missing")
+				}
+				if r.Duration != 21 {
+					t.Error("'duration' response field incorrect")
+				}
+			},
+		},
+		{
+			name: "Valid, no end date",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: parametersCreateUserMediumRecord{
+				MediumID:  medium2Id,
+				StartDate: dateNow,
+				EndDate:   "",
+			},
+			expectedStatus: 201,
+			expectResponse: true,
+			checkResponse: func(t *testing.T, r ClientRecord) {
+				if r.ID == "" {
+					t.Error("'id' response field missing")
+				}
+				if r.StartDate == "" {
+					t.Error("'start_date' response field missing")
+				}
+				if r.EndDate != "" {
+					t.Error("'end_date' response field incorrect")
+				}
+				if r.IsFinished {
+					t.Error("'is_finished' response field incorrect")
+				}
+				if r.Duration != 0 {
+					t.Error("'duration' response field incorrect")
+				}
+			},
+		},
+		{
+			name: "Valid, no start date",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: parametersCreateUserMediumRecord{
+				MediumID:  medium3Id,
+				StartDate: "",
+				EndDate:   dateNow,
+			},
+			expectedStatus: 201,
+			expectResponse: true,
+			checkResponse: func(t *testing.T, r ClientRecord) {
+				if r.ID == "" {
+					t.Error("'id' response field missing")
 				}
 				if r.StartDate != "" {
 					t.Error("'start_date' response field incorrect")
@@ -1576,7 +2030,7 @@ func TestCreateRecord(t *testing.T) {
 			expectedStatus: 400,
 		},
 		{
-			name: "Conflict, try to create Record with same user-medium couple",
+			name: "Another consumption of the same medium",
 			requestHeaders: map[string]string{
 				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
 			},
@@ -1585,7 +2039,7 @@ func TestCreateRecord(t *testing.T) {
 				StartDate: dateNow,
 				EndDate:   dateFuture,
 			},
-			expectedStatus: 409,
+			expectedStatus: 201,
 		},
 	}
 	for _, tc := range tests {
@@ -1872,6 +2326,7 @@ func TestDeleteRecord(t *testing.T) {
 	ctx.LoginTestUser(t)
 	mediumID := ctx.CreateTestMediumRandom(t)
 	recordID := ctx.CreateTestRecord(t, mediumID)
+	rereadID := ctx.CreateTestRecord(t, mediumID)
 
 	testMethod := "DELETE"
 	testEndpoint := ctx.BaseURL + "/api/records"
@@ -1891,7 +2346,7 @@ func TestDeleteRecord(t *testing.T) {
 				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
 			},
 			requestBody: parametersDeleteRecord{
-				MediumID: recordID,
+				RecordID: recordID,
 			},
 			expectedStatus: 200,
 			expectResponse: false,
@@ -1899,7 +2354,20 @@ func TestDeleteRecord(t *testing.T) {
 				if ctx.TestIfRecordExist(recordID) {
 					t.Error("record still exists")
 				}
+				if !ctx.TestIfRecordExist(rereadID) {
+					t.Error("Other record of the same medium was deleted")
+				}
+			},
+		},
+		{
+			name: "Already deleted",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: parametersDeleteRecord{
+				RecordID: recordID,
 			},
+			expectedStatus: 404,
 		},
 		{
 			name:           "No access_token",
@@ -1911,7 +2379,7 @@ func TestDeleteRecord(t *testing.T) {
 				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
 			},
 			requestBody: parametersDeleteRecord{
-				MediumID: "ba983bd8-36ce-4d1b-ad24-2b65240f9921",
+				RecordID: "ba983bd8-36ce-4d1b-ad24-2b65240f9921",
 			},
 			expectedStatus: 404,
 		},
@@ -1921,7 +2389,7 @@ func TestDeleteRecord(t *testing.T) {
 				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
 			},
 			requestBody: parametersDeleteRecord{
-				MediumID: "wrongID",
+				RecordID: "wrongID",
 			},
 			expectedStatus: 400,
 		},
@@ -1963,6 +2431,9040 @@ func TestDeleteRecord(t *testing.T) {
 	}
 }
 
+func TestRecordProgress(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+
+	// A 400 pages book started ten days ago, an unstarted videogame, a series and a movie
+	startDate := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -10)
+	dayAfterStart := func(days int) string {
+		return startDate.AddDate(0, 0, days).Format(time.RFC3339)
+	}
+	bookID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Alpha", MediaType: "book", Creator: "Jane Austen", PubDate: "1815", Metadata: map[string]interface{}{"page_count": 400}})
+	videogameID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Bravo", MediaType: "videogame", Creator: "Nintendo", PubDate: "2017"})
+	seriesID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Delta", MediaType: "series", Creator: "HBO", PubDate: "2008", Metadata: map[string]interface{}{"number_of_episodes": 10}})
+	movieID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Charlie", MediaType: "movie", Creator: "Ridley Scott", PubDate: "1979"})
+	bookRecordID := ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: bookID, StartDate: dayAfterStart(0)})
+	videogameRecordID := ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: videogameID})
+	seriesRecordID := ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: seriesID})
+	movieRecordID := ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: movieID})
+
+	// Send a request with given body and return its status code, decoding response's body if any
+	send := func(t *testing.T, method, endpoint string, body map[string]interface{}, v any) int {
+		requestBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest(method, ctx.BaseURL+endpoint, bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.UserAcessToken))
+		resp, err := ctx.Client.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode == 200 && v != nil {
+			if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+		}
+		return resp.StatusCode
+	}
+
+	updates := []struct {
+		name           string
+		recordID       string
+		progress       map[string]interface{}
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientRecordWithProgress)
+	}{
+		{
+			name:           "Valid, pages of a book",
+			recordID:       bookRecordID,
+			progress:       map[string]interface{}{"pages": 100, "logged_at": dayAfterStart(2)},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cr ClientRecordWithProgress) {
+				if cr.Progress == nil || cr.Progress.Total == nil || *cr.Progress.Total != 400 || cr.Progress.Completion == nil || *cr.Progress.Completion != 25 {
+					t.Errorf("Expected 25%% of 400 pages, got %+v", cr.Progress)
+				}
+			},
+		},
+		{
+			name:           "Valid, later pages of a book",
+			recordID:       bookRecordID,
+			progress:       map[string]interface{}{"pages": 200, "logged_at": dayAfterStart(4)},
+			expectedStatus: 200,
+		},
+		{
+			name:           "Valid, completion percent of an unstarted videogame",
+			recordID:       videogameRecordID,
+			progress:       map[string]interface{}{"percent": 20, "logged_at": dayAfterStart(3)},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cr ClientRecordWithProgress) {
+				if !strings.HasPrefix(cr.StartDate, startDate.AddDate(0, 0, 3).Format("2006-01-02")) {
+					t.Errorf("Expected record started on its first progress, got start_date %s", cr.StartDate)
+				}
+				if cr.Progress == nil || cr.Progress.Completion == nil || *cr.Progress.Completion != 20 {
+					t.Errorf("Expected 20%% completion, got %+v", cr.Progress)
+				}
+			},
+		},
+		{
+			name:           "Pages of a videogame",
+			recordID:       videogameRecordID,
+			progress:       map[string]interface{}{"pages": 3},
+			expectedStatus: 400,
+		},
+		{
+			name:           "Hours of a videogame, kept with its play details",
+			recordID:       videogameRecordID,
+			progress:       map[string]interface{}{"hours": 5.5},
+			expectedStatus: 400,
+		},
+		{
+			name:           "Episodes of a series, told by its watched episodes",
+			recordID:       seriesRecordID,
+			progress:       map[string]interface{}{"episodes": 3},
+			expectedStatus: 400,
+		},
+		{
+			name:           "More pages than the book has",
+			recordID:       bookRecordID,
+			progress:       map[string]interface{}{"pages": 401},
+			expectedStatus: 400,
+		},
+		{
+			name:           "Percent above 100",
+			recordID:       bookRecordID,
+			progress:       map[string]interface{}{"percent": 150},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No progress field",
+			recordID:       bookRecordID,
+			progress:       map[string]interface{}{"total": 300},
+			expectedStatus: 400,
+		},
+		{
+			name:           "Logged in the future",
+			recordID:       bookRecordID,
+			progress:       map[string]interface{}{"pages": 300, "logged_at": time.Now().AddDate(0, 0, 2).Format(time.RFC3339)},
+			expectedStatus: 400,
+		},
+		{
+			name:           "Logged before record's start",
+			recordID:       bookRecordID,
+			progress:       map[string]interface{}{"pages": 10, "logged_at": dayAfterStart(-1)},
+			expectedStatus: 400,
+		},
+		{
+			name:           "Media type without progress",
+			recordID:       movieRecordID,
+			progress:       map[string]interface{}{"percent": 50},
+			expectedStatus: 400,
+		},
+	}
+	for _, tc := range updates {
+		t.Run(tc.name, func(t *testing.T) {
+			var responseBody ClientRecordWithProgress
+			status := send(t, "PUT", "/api/records", map[string]interface{}{"record_id": tc.recordID, "progress": tc.progress}, &responseBody)
+			if status != tc.expectedStatus {
+				t.Fatalf("Expected status code %d, got %d", tc.expectedStatus, status)
+			}
+			if tc.checkResponse != nil {
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+
+	t.Run("Progress curve and estimated finish date", func(t *testing.T) {
+		var curve ClientRecordProgressCurve
+		if status := send(t, "GET", "/api/records/progress", map[string]interface{}{"record_id": bookRecordID}, &curve); status != 200 {
+			t.Fatalf("Expected status code 200, got %d", status)
+		}
+		if curve.MediaType != "book" || len(curve.Progress) != 2 || *curve.Progress[0].Pages != 100 || *curve.Progress[1].Completion != 50 {
+			t.Fatalf("Unexpected curve %+v", curve)
+		}
+		// 50% in 4 days since record's start: 4 more days to go
+		if curve.Pace != 12.5 || !strings.HasPrefix(curve.EstimatedFinishDate, startDate.AddDate(0, 0, 8).Format("2006-01-02T15:04:05")) {
+			t.Errorf("Expected 12.5%%/day and finish on day 8, got %v and %s", curve.Pace, curve.EstimatedFinishDate)
+		}
+	})
+
+	t.Run("No estimate from a single update", func(t *testing.T) {
+		var curve ClientRecordProgressCurve
+		send(t, "GET", "/api/records/progress", map[string]interface{}{"record_id": videogameRecordID}, &curve)
+		if len(curve.Progress) != 1 || curve.EstimatedFinishDate != "" {
+			t.Errorf("Unexpected curve %+v", curve)
+		}
+	})
+
+	t.Run("Another user's record", func(t *testing.T) {
+		other := ctx.CreateOtherTestUser(t, "progress_other")
+		requestBody, _ := json.Marshal(map[string]interface{}{"record_id": bookRecordID})
+		req, _ := http.NewRequest("GET", ctx.BaseURL+"/api/records/progress", bytes.NewBuffer(requestBody))
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", other.UserAcessToken))
+		resp, err := ctx.Client.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != 404 {
+			t.Errorf("Expected status code 404, got %d", resp.StatusCode)
+		}
+	})
+}
+
+func TestRecordRating(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+
+	// A book read twice by user and once by Bob, and a movie nobody rated
+	alphaID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Alpha", MediaType: "book", Creator: "Jane Austen", PubDate: "1815"})
+	bravoID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Bravo", MediaType: "movie", Creator: "Ridley Scott", PubDate: "1979"})
+	bravoRecordID := ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: bravoID})
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+	stars := 3.0
+	bob.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: alphaID, Rating: &stars, RatingScale: "5"})
+
+	// Send a request with given body and return its status code, decoding response's body if any
+	send := func(t *testing.T, method, endpoint string, body map[string]interface{}, v any) int {
+		requestBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest(method, ctx.BaseURL+endpoint, bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.UserAcessToken))
+		resp, err := ctx.Client.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		defer resp.Body.Close()
+		if (resp.StatusCode == 200 || resp.StatusCode == 201) && v != nil {
+			if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+		}
+		return resp.StatusCode
+	}
+
+	creations := []struct {
+		name           string
+		rating         interface{}
+		scale          string
+		expectedStatus int
+		expectedRating int16
+	}{
+		{name: "Valid, half stars", rating: 4.5, scale: "5", expectedStatus: 201, expectedRating: 90},
+		{name: "Valid, out of 10", rating: 7, scale: "10", expectedStatus: 201, expectedRating: 70},
+		{name: "Not a half star", rating: 4.3, scale: "5", expectedStatus: 400},
+		{name: "Above the scale", rating: 11, scale: "10", expectedStatus: 400},
+		{name: "Negative", rating: -1, scale: "100", expectedStatus: 400},
+		{name: "Unknown scale", rating: 3, scale: "7", expectedStatus: 400},
+	}
+	var alphaRecordIDs []string
+	for _, tc := range creations {
+		t.Run(tc.name, func(t *testing.T) {
+			var responseBody ClientRecord
+			status := send(t, "POST", "/api/records", map[string]interface{}{"medium_id": alphaID, "rating": tc.rating, "rating_scale": tc.scale}, &responseBody)
+			if status != tc.expectedStatus {
+				t.Fatalf("Expected status code %d, got %d", tc.expectedStatus, status)
+			}
+			if status != 201 {
+				return
+			}
+			if responseBody.Rating == nil || *responseBody.Rating != tc.expectedRating {
+				t.Errorf("Expected rating %d, got %v", tc.expectedRating, responseBody.Rating)
+			}
+			alphaRecordIDs = append(alphaRecordIDs, responseBody.ID)
+		})
+	}
+	if len(alphaRecordIDs) != 2 {
+		t.Fatalf("Expected two rated records, got %d", len(alphaRecordIDs))
+	}
+
+	t.Run("Rating kept when omitted", func(t *testing.T) {
+		var responseBody ClientRecord
+		if status := send(t, "PUT", "/api/records", map[string]interface{}{"record_id": alphaRecordIDs[0], "comments": "Loved it"}, &responseBody); status != 200 {
+			t.Fatalf("Expected status code 200, got %d", status)
+		}
+		if responseBody.Rating == nil || *responseBody.Rating != 90 {
+			t.Errorf("Expected rating 90 to be kept, got %v", responseBody.Rating)
+		}
+	})
+
+	t.Run("Rating changed and removed", func(t *testing.T) {
+		var responseBody ClientRecord
+		send(t, "PUT", "/api/records", map[string]interface{}{"record_id": bravoRecordID, "rating": 35}, &responseBody)
+		if responseBody.Rating == nil || *responseBody.Rating != 35 {
+			t.Fatalf("Expected rating 35 on default scale, got %v", responseBody.Rating)
+		}
+		send(t, "PUT", "/api/records", map[string]interface{}{"record_id": bravoRecordID, "remove_rating": true}, &responseBody)
+		if responseBody.Rating != nil {
+			t.Errorf("Expected rating to be removed, got %v", *responseBody.Rating)
+		}
+	})
+
+	t.Run("Medium's average, each user counting once", func(t *testing.T) {
+		// User's ratings average to 80, Bob's is 60
+		var rating ClientMediumRating
+		if status := send(t, "GET", "/api/media/rating", map[string]interface{}{"medium_id": alphaID}, &rating); status != 200 {
+			t.Fatalf("Expected status code 200, got %d", status)
+		}
+		if rating.Average != 70 || rating.Count != 2 {
+			t.Errorf("Expected average 70 from 2 users, got %+v", rating)
+		}
+
+		var mediaRecords ClientMediaRecords
+		send(t, "GET", "/api/media_records", map[string]interface{}{}, &mediaRecords)
+		for _, mediumRecord := range append(mediaRecords.Records["book"], mediaRecords.Records["movie"]...) {
+			switch mediumRecord.MediaID {
+			case alphaID:
+				if mediumRecord.RatingAverage != 70 || mediumRecord.RatingCount != 2 {
+					t.Errorf("Expected average 70 from 2 users, got %v from %d", mediumRecord.RatingAverage, mediumRecord.RatingCount)
+				}
+			case bravoID:
+				if mediumRecord.RatingCount != 0 || mediumRecord.Rating != nil {
+					t.Errorf("Expected unrated movie, got %+v", mediumRecord)
+				}
+			}
+		}
+	})
+
+	t.Run("Filter and sort by rating", func(t *testing.T) {
+		var responseBody ClientSearchMediaRecords
+		status := send(t, "GET", "/api/media_records/search", map[string]interface{}{
+			"min_rating":   4,
+			"rating_scale": "5",
+			"sort":         []map[string]string{{"key": "rating", "order": "desc"}},
+		}, &responseBody)
+		if status != 200 {
+			t.Fatalf("Expected status code 200, got %d", status)
+		}
+		if len(responseBody.Records) != 1 || *responseBody.Records[0].Rating != 90 {
+			t.Errorf("Expected only the record rated 90, got %+v", responseBody.Records)
+		}
+
+		send(t, "GET", "/api/media_records/search", map[string]interface{}{
+			"sort": []map[string]string{{"key": "rating", "order": "asc"}},
+		}, &responseBody)
+		if len(responseBody.Records) != 3 || *responseBody.Records[0].Rating != 70 || responseBody.Records[2].Rating != nil {
+			t.Errorf("Expected records sorted by rating with unrated last, got %+v", responseBody.Records)
+		}
+	})
+
+	t.Run("Rating filter off the scale", func(t *testing.T) {
+		if status := send(t, "GET", "/api/media_records/search", map[string]interface{}{"max_rating": 12, "rating_scale": "10"}, nil); status != 400 {
+			t.Errorf("Expected status code 400, got %d", status)
+		}
+	})
+}
+
+func TestCreateRecordStatus(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+
+	alphaID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Alpha", MediaType: "book", Creator: "Jane Austen", PubDate: "1815"})
+	bravoID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Bravo", MediaType: "movie", Creator: "Ridley Scott", PubDate: "1979"})
+	tooSlow := "Too slow"
+
+	testMethod := "POST"
+	testEndpoint := ctx.BaseURL + "/api/records"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersCreateUserMediumRecord
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientRecord)
+	}{
+		{
+			name: "Valid, wishlist",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateUserMediumRecord{MediumID: alphaID, Status: "wishlist"},
+			expectedStatus: 201,
+			checkResponse: func(t *testing.T, cr ClientRecord) {
+				if cr.Status != "wishlist" || cr.IsFinished || cr.StartDate != "" {
+					t.Errorf("Expected an unstarted wishlist record, got %+v", cr)
+				}
+			},
+		},
+		{
+			name: "Valid, status from dates",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateUserMediumRecord{MediumID: bravoID, StartDate: "2024-01-01T00:00:00Z", EndDate: "2024-01-03T00:00:00Z"},
+			expectedStatus: 201,
+			checkResponse: func(t *testing.T, cr ClientRecord) {
+				if cr.Status != "finished" || !cr.IsFinished {
+					t.Errorf("Expected a finished record, got %+v", cr)
+				}
+			},
+		},
+		{
+			name: "Valid, abandoned with a reason",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateUserMediumRecord{MediumID: bravoID, Status: "abandoned", StartDate: "2024-02-01T00:00:00Z", AbandonReason: &tooSlow},
+			expectedStatus: 201,
+			checkResponse: func(t *testing.T, cr ClientRecord) {
+				if cr.Status != "abandoned" || cr.IsFinished {
+					t.Errorf("Expected an abandoned record, got %+v", cr)
+				}
+				if cr.EndDate == "" || cr.AbandonReason != "Too slow" {
+					t.Errorf("Expected an end date and the abandon reason, got %+v", cr)
+				}
+			},
+		},
+		{
+			name: "Unknown status",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateUserMediumRecord{MediumID: alphaID, Status: "on_hold"},
+			expectedStatus: 400,
+		},
+		{
+			name: "Created paused",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateUserMediumRecord{MediumID: alphaID, Status: "paused"},
+			expectedStatus: 400,
+		},
+		{
+			name: "Wishlist with a start date",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateUserMediumRecord{MediumID: alphaID, Status: "wishlist", StartDate: "2024-01-01T00:00:00Z"},
+			expectedStatus: 400,
+		},
+		{
+			name: "Abandon reason of a planned record",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateUserMediumRecord{MediumID: alphaID, Status: "planned", AbandonReason: &tooSlow},
+			expectedStatus: 400,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientRecord
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestUpdateRecordStatus(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+
+	// The record goes from wishlist to started, paused, resumed then finished, and can't be picked up again
+	recordID := ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: ctx.CreateTestMediumRandom(t), Status: "wishlist"})
+	tooSlow := "Too slow"
+	abandonedID := ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: ctx.CreateTestMediumRandom(t), Status: "abandoned", StartDate: "2024-02-01T00:00:00Z", EndDate: "2024-02-10T00:00:00Z", AbandonReason: &tooSlow})
+
+	testMethod := "PUT"
+	testEndpoint := ctx.BaseURL + "/api/records"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersUpdateRecord
+		expectedStatus int
+		expectedRecord string
+	}{
+		{
+			name: "Paused before being started",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateRecord{RecordID: recordID, Status: "paused"},
+			expectedStatus: 400,
+		},
+		{
+			name: "Started by its start date",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateRecord{RecordID: recordID, StartDate: "2024-01-01T00:00:00Z"},
+			expectedStatus: 200,
+			expectedRecord: "in_progress",
+		},
+		{
+			name: "Paused",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateRecord{RecordID: recordID, Status: "paused"},
+			expectedStatus: 200,
+			expectedRecord: "paused",
+		},
+		{
+			name: "Back to wishlist",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateRecord{RecordID: recordID, Status: "wishlist"},
+			expectedStatus: 400,
+		},
+		{
+			name: "Resumed",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateRecord{RecordID: recordID, Status: "in_progress"},
+			expectedStatus: 200,
+			expectedRecord: "in_progress",
+		},
+		{
+			name: "Finished",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateRecord{RecordID: recordID, Status: "finished"},
+			expectedStatus: 200,
+			expectedRecord: "finished",
+		},
+		{
+			name: "Finished record picked up again",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateRecord{RecordID: recordID, Status: "in_progress"},
+			expectedStatus: 400,
+		},
+		{
+			name: "Abandoned record picked up again",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateRecord{RecordID: abandonedID, Status: "in_progress"},
+			expectedStatus: 400,
+		},
+		{
+			name: "Unknown status",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateRecord{RecordID: recordID, Status: "on_hold"},
+			expectedStatus: 400,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Fatalf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.expectedRecord != "" {
+				var responseBody ClientRecord
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				if responseBody.Status != tc.expectedRecord || responseBody.IsFinished != (tc.expectedRecord == "finished") {
+					t.Errorf("Expected a %s record, got %+v", tc.expectedRecord, responseBody)
+				}
+				if (responseBody.EndDate != "") != (tc.expectedRecord == "finished") {
+					t.Errorf("Expected an end date on finished records only, got %q", responseBody.EndDate)
+				}
+			}
+		})
+	}
+
+	t.Run("Records keep their end date", func(t *testing.T) {
+		record := ctx.UpdateTestRecord(t, parametersUpdateRecord{RecordID: recordID, Comments: "Read it again"})
+		if record.Status != "finished" || record.EndDate == "" {
+			t.Errorf("Expected record to stay finished with its end date, got %+v", record)
+		}
+		record = ctx.UpdateTestRecord(t, parametersUpdateRecord{RecordID: abandonedID, Comments: "Maybe later"})
+		if record.Status != "abandoned" || !strings.HasPrefix(record.EndDate, "2024-02-10") {
+			t.Errorf("Expected record to stay abandoned on 2024-02-10, got %+v", record)
+		}
+	})
+}
+
+func TestGetRecordPauses(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	// A record paused then resumed, and one paused still
+	resumedID := ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: ctx.CreateTestMediumRandom(t), StartDate: "2024-01-01T00:00:00Z"})
+	ctx.UpdateTestRecord(t, parametersUpdateRecord{RecordID: resumedID, Status: "paused"})
+	ctx.UpdateTestRecord(t, parametersUpdateRecord{RecordID: resumedID, Status: "in_progress"})
+	pausedID := ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: ctx.CreateTestMediumRandom(t), StartDate: "2024-01-01T00:00:00Z"})
+	ctx.UpdateTestRecord(t, parametersUpdateRecord{RecordID: pausedID, Status: "paused"})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/records/pauses"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersGetRecordPauses
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientRecordPauses)
+	}{
+		{
+			name: "Valid, resumed record",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGetRecordPauses{RecordID: resumedID},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cp ClientRecordPauses) {
+				if cp.RecordID != resumedID || cp.Status != "in_progress" || len(cp.Pauses) != 1 || cp.Pauses[0].ResumedAt == "" {
+					t.Errorf("Expected one closed pause, got %+v", cp)
+				}
+			},
+		},
+		{
+			name: "Valid, paused record",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGetRecordPauses{RecordID: pausedID},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cp ClientRecordPauses) {
+				if cp.Status != "paused" || len(cp.Pauses) != 1 || cp.Pauses[0].ResumedAt != "" {
+					t.Errorf("Expected one open pause, got %+v", cp)
+				}
+			},
+		},
+		{
+			name: "Other user's record",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersGetRecordPauses{RecordID: pausedID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Invalid record_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGetRecordPauses{RecordID: "1234"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersGetRecordPauses{RecordID: pausedID},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientRecordPauses
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestSearchMediaRecordsByStatus(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+
+	tooSlow := "Too slow"
+	ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: ctx.CreateTestMediumRandom(t), Status: "wishlist"})
+	abandonedID := ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: ctx.CreateTestMediumRandom(t), Status: "abandoned", StartDate: "2024-02-01T00:00:00Z", AbandonReason: &tooSlow})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/media_records/search"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersSearchMediaRecords
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientSearchMediaRecords)
+	}{
+		{
+			name: "Filter on status abandoned",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersSearchMediaRecords{Status: "abandoned"},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientSearchMediaRecords) {
+				if len(cs.Records) != 1 || cs.Records[0].ID != abandonedID || cs.Records[0].Status != "abandoned" {
+					t.Errorf("Expected the abandoned record, got %+v", cs.Records)
+				}
+			},
+		},
+		{
+			name: "Filter on status wishlist",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersSearchMediaRecords{Status: "wishlist"},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientSearchMediaRecords) {
+				if len(cs.Records) != 1 || cs.Records[0].Status != "wishlist" {
+					t.Errorf("Expected the wishlist record, got %+v", cs.Records)
+				}
+			},
+		},
+		{
+			name: "Unknown status",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersSearchMediaRecords{Status: "on_hold"},
+			expectedStatus: 400,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientSearchMediaRecords
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestCreateReview(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	mediumID := ctx.CreateTestMediumRandom(t)
+	recordID := ctx.CreateTestRecord(t, mediumID)
+	bobRecordID := bob.CreateTestRecord(t, mediumID)
+
+	testMethod := "POST"
+	testEndpoint := ctx.BaseURL + "/api/reviews"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersCreateReview
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientReview)
+	}{
+		{
+			name: "Valid draft",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateReview{RecordID: recordID, Title: "A classic", Body: "I *loved* it."},
+			expectedStatus: 201,
+			checkResponse: func(t *testing.T, cr ClientReview) {
+				if cr.Revision != 1 || cr.IsPublished || cr.PublishedAt != "" {
+					t.Errorf("Expected an unpublished first revision, got %+v", cr)
+				}
+				if !strings.Contains(cr.HTML, "<em>loved</em>") {
+					t.Errorf("Expected rendered Markdown, got %q", cr.HTML)
+				}
+			},
+		},
+		{
+			name: "Valid published",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersCreateReview{RecordID: bobRecordID, Title: "Meh", Body: "Not for me", IsPublished: true},
+			expectedStatus: 201,
+			checkResponse: func(t *testing.T, cr ClientReview) {
+				if cr.Revision != 1 || !cr.IsPublished || cr.PublishedAt == "" {
+					t.Errorf("Expected a first revision published with a date, got %+v", cr)
+				}
+			},
+		},
+		{
+			name: "Second review of a record",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateReview{RecordID: recordID, Title: "Again"},
+			expectedStatus: 409,
+		},
+		{
+			name: "Missing title",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateReview{RecordID: recordID, Body: "No title"},
+			expectedStatus: 400,
+		},
+		{
+			name: "Published without body",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateReview{RecordID: recordID, Title: "Empty", IsPublished: true},
+			expectedStatus: 400,
+		},
+		{
+			name: "Other user's record",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersCreateReview{RecordID: recordID, Title: "Mine"},
+			expectedStatus: 404,
+		},
+		{
+			name: "Invalid record_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateReview{RecordID: "1234", Title: "Mine"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersCreateReview{RecordID: recordID, Title: "Mine"},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientReview
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestGetReview(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	recordID := ctx.CreateTestRecord(t, ctx.CreateTestMediumRandom(t))
+	unreviewedID := ctx.CreateTestRecord(t, ctx.CreateTestMediumRandom(t))
+	ctx.CreateTestReview(t, parametersCreateReview{
+		RecordID: recordID,
+		Title:    "A classic",
+		Body:     "I *loved* it.\n\n<script>alert(1)</script>\n\n:::spoiler Ending\nThey marry.\n:::\n\n[link](javascript:alert(1))",
+	})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/reviews"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersReview
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientReview)
+	}{
+		{
+			name: "Valid, rendered and sanitized",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersReview{RecordID: recordID},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cr ClientReview) {
+				if !strings.Contains(cr.HTML, "<em>loved</em>") || !strings.Contains(cr.HTML, "<summary>Spoiler: Ending</summary>") || !cr.HasSpoilers {
+					t.Errorf("Expected rendered Markdown and spoiler, got %q", cr.HTML)
+				}
+				if strings.Contains(cr.HTML, "<script>") || strings.Contains(cr.HTML, "javascript:") {
+					t.Errorf("Expected sanitized HTML, got %q", cr.HTML)
+				}
+			},
+		},
+		{
+			name: "Record without review",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersReview{RecordID: unreviewedID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Other user's record",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersReview{RecordID: recordID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Invalid record_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersReview{RecordID: "1234"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersReview{RecordID: recordID},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientReview
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestUpdateReview(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	recordID := ctx.CreateTestRecord(t, ctx.CreateTestMediumRandom(t))
+	ctx.CreateTestReview(t, parametersCreateReview{RecordID: recordID, Title: "A classic", Body: "I loved it."})
+
+	published := true
+	newBody := "I *loved* it."
+	blankBody := " "
+	newTitle := "A true classic"
+
+	testMethod := "PUT"
+	testEndpoint := ctx.BaseURL + "/api/reviews"
+
+	// Cases run in order, each one on the review left by the previous ones
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersUpdateReview
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientReview)
+	}{
+		{
+			name: "Valid, published",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateReview{RecordID: recordID, IsPublished: &published},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cr ClientReview) {
+				if cr.Revision != 1 || !cr.IsPublished || cr.PublishedAt == "" {
+					t.Errorf("Expected first revision to be published, got %+v", cr)
+				}
+			},
+		},
+		{
+			name: "Valid, body edited",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateReview{RecordID: recordID, Body: &newBody},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cr ClientReview) {
+				if cr.Revision != 2 || cr.Title != "A classic" || !strings.Contains(cr.HTML, "<em>loved</em>") {
+					t.Errorf("Expected a second revision with the new body, got %+v", cr)
+				}
+			},
+		},
+		{
+			name: "Emptied while published",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateReview{RecordID: recordID, Body: &blankBody},
+			expectedStatus: 400,
+		},
+		{
+			name: "Valid, title edited",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateReview{RecordID: recordID, Title: &newTitle},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cr ClientReview) {
+				if cr.Revision != 3 || cr.Title != newTitle || !cr.IsPublished {
+					t.Errorf("Expected a published third revision with the new title, got %+v", cr)
+				}
+			},
+		},
+		{
+			name: "Other user's record",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersUpdateReview{RecordID: recordID, Title: &newTitle},
+			expectedStatus: 404,
+		},
+		{
+			name: "Invalid record_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateReview{RecordID: "1234", Title: &newTitle},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersUpdateReview{RecordID: recordID, Title: &newTitle},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientReview
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestGetReviewRevisions(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	recordID := ctx.CreateTestRecord(t, ctx.CreateTestMediumRandom(t))
+	ctx.CreateTestReview(t, parametersCreateReview{RecordID: recordID, Title: "A classic", Body: "I loved it.\n\n<script>alert(1)</script>"})
+	newBody := "I loved it."
+	newTitle := "A true classic"
+	ctx.UpdateTestReview(t, parametersUpdateReview{RecordID: recordID, Body: &newBody})
+	ctx.UpdateTestReview(t, parametersUpdateReview{RecordID: recordID, Title: &newTitle})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/reviews/revisions"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersReview
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientReviewRevisions)
+	}{
+		{
+			name: "Valid, with diffs",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersReview{RecordID: recordID},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cr ClientReviewRevisions) {
+				if len(cr.Revisions) != 3 {
+					t.Fatalf("Expected 3 revisions, got %+v", cr.Revisions)
+				}
+				if !strings.Contains(cr.Revisions[1].Diff, "- <script>alert(1)</script>") || !strings.Contains(cr.Revisions[2].Diff, "+ # A true classic") {
+					t.Errorf("Expected diffs between revisions, got %+v", cr.Revisions)
+				}
+			},
+		},
+		{
+			name: "Other user's record",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersReview{RecordID: recordID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Invalid record_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersReview{RecordID: "1234"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersReview{RecordID: recordID},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientReviewRevisions
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestGetMediumReviews(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+	carol := ctx.CreateOtherTestUser(t, "Carol")
+
+	// Bob's review is published first, Carol's one stays a draft
+	mediumID := ctx.CreateTestMediumRandom(t)
+	bob.CreateTestReview(t, parametersCreateReview{RecordID: bob.CreateTestRecord(t, mediumID), Title: "Meh", Body: "Not for me", IsPublished: true})
+	carol.CreateTestReview(t, parametersCreateReview{RecordID: carol.CreateTestRecord(t, mediumID), Title: "Draft", Body: "To be written"})
+	ctx.CreateTestReview(t, parametersCreateReview{
+		RecordID:    ctx.CreateTestRecord(t, mediumID),
+		Title:       "A classic",
+		Body:        "I loved it.\n\n:::spoiler Ending\nThey marry.\n:::",
+		IsPublished: true,
+	})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/media/reviews"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersGetMediumReviews
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientMediumReviews)
+	}{
+		{
+			name: "Valid, spoilers hidden",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersGetMediumReviews{MediumID: mediumID},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cr ClientMediumReviews) {
+				if len(cr.Reviews) != 2 || cr.Reviews[0].Username != ctx.UserUsername {
+					t.Fatalf("Expected both published reviews, latest first, got %+v", cr.Reviews)
+				}
+				if strings.Contains(cr.Reviews[0].HTML, "They marry") || cr.Reviews[0].Body != "" || !cr.Reviews[0].HasSpoilers {
+					t.Errorf("Expected spoilers to be hidden, got %+v", cr.Reviews[0])
+				}
+			},
+		},
+		{
+			name: "Valid, spoilers shown",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersGetMediumReviews{MediumID: mediumID, ShowSpoilers: true},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cr ClientMediumReviews) {
+				if len(cr.Reviews) != 2 || !strings.Contains(cr.Reviews[0].HTML, "They marry") {
+					t.Errorf("Expected spoilers to be shown, got %+v", cr.Reviews)
+				}
+			},
+		},
+		{
+			name: "Invalid medium_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGetMediumReviews{MediumID: "1234"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersGetMediumReviews{MediumID: mediumID},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientMediumReviews
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestDeleteReview(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	recordID := ctx.CreateTestRecord(t, ctx.CreateTestMediumRandom(t))
+	ctx.CreateTestReview(t, parametersCreateReview{RecordID: recordID, Title: "A classic", Body: "I loved it."})
+
+	testMethod := "DELETE"
+	testEndpoint := ctx.BaseURL + "/api/reviews"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersReview
+		expectedStatus int
+		checkAfter     func(*testing.T)
+	}{
+		{
+			name: "Other user's record",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersReview{RecordID: recordID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersReview{RecordID: recordID},
+			expectedStatus: 200,
+			checkAfter: func(t *testing.T) {
+				// The record itself is kept
+				if !ctx.TestIfRecordExist(recordID) {
+					t.Error("Record was deleted along with its review")
+				}
+			},
+		},
+		{
+			name: "Review already deleted",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersReview{RecordID: recordID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Invalid record_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersReview{RecordID: "1234"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersReview{RecordID: recordID},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkAfter != nil {
+				tc.checkAfter(t)
+			}
+		})
+	}
+}
+
+func TestCreateLoan(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	ctx.CreateOtherTestUser(t, "Bob")
+
+	gameID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Catan", MediaType: "boardgame", Creator: "Klaus Teuber", PubDate: "1995"})
+	ctx.CreateTestRecord(t, gameID)
+	bookID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Emma", MediaType: "book", Creator: "Jane Austen", PubDate: "1815"})
+	ctx.CreateTestRecord(t, bookID)
+	notOwnedID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Dune", MediaType: "book", Creator: "Frank Herbert", PubDate: "1965"})
+
+	lastWeek := time.Now().UTC().AddDate(0, 0, -7).Format(time.RFC3339)
+	yesterday := time.Now().UTC().AddDate(0, 0, -1).Format(time.RFC3339)
+
+	testMethod := "POST"
+	testEndpoint := ctx.BaseURL + "/api/loans"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersCreateLoan
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientLoan)
+	}{
+		{
+			name: "Valid, lent to a user",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateLoan{MediumID: gameID, BorrowerUsername: "Bob", LentAt: lastWeek, DueAt: yesterday},
+			expectedStatus: 201,
+			checkResponse: func(t *testing.T, cl ClientLoan) {
+				if cl.BorrowerID == "" || cl.Title != "Catan" || cl.DueAt == "" || cl.ReturnedAt != "" {
+					t.Errorf("Expected an open loan to Bob, got %+v", cl)
+				}
+			},
+		},
+		{
+			name: "Valid, lent to a name",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateLoan{MediumID: bookID, BorrowerName: " Jane "},
+			expectedStatus: 201,
+			checkResponse: func(t *testing.T, cl ClientLoan) {
+				if cl.BorrowerID != "" || cl.BorrowerName != "Jane" {
+					t.Errorf("Expected a loan to Jane, got %+v", cl)
+				}
+			},
+		},
+		{
+			name: "Already lent out",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateLoan{MediumID: gameID, BorrowerName: "Jane"},
+			expectedStatus: 409,
+		},
+		{
+			name: "Missing borrower",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateLoan{MediumID: gameID},
+			expectedStatus: 400,
+		},
+		{
+			name: "Both borrowers",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateLoan{MediumID: gameID, BorrowerName: "Jane", BorrowerUsername: "Bob"},
+			expectedStatus: 400,
+		},
+		{
+			name: "Lent to yourself",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateLoan{MediumID: gameID, BorrowerUsername: ctx.UserUsername},
+			expectedStatus: 400,
+		},
+		{
+			name: "Due before lent",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateLoan{MediumID: gameID, BorrowerName: "Jane", LentAt: yesterday, DueAt: lastWeek},
+			expectedStatus: 400,
+		},
+		{
+			name: "Unknown borrower",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateLoan{MediumID: gameID, BorrowerUsername: "Nobody"},
+			expectedStatus: 404,
+		},
+		{
+			name: "Medium not on shelf",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateLoan{MediumID: notOwnedID, BorrowerName: "Jane"},
+			expectedStatus: 404,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersCreateLoan{MediumID: notOwnedID, BorrowerName: "Jane"},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientLoan
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestGetLoans(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	// Catan is lent to Bob and due first, Emma is lent to Jane with no due date
+	gameID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Catan", MediaType: "boardgame", Creator: "Klaus Teuber", PubDate: "1995"})
+	ctx.CreateTestRecord(t, gameID)
+	bookID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Emma", MediaType: "book", Creator: "Jane Austen", PubDate: "1815"})
+	ctx.CreateTestRecord(t, bookID)
+	bobLoanID := ctx.CreateTestLoan(t, parametersCreateLoan{MediumID: gameID, BorrowerUsername: "Bob", DueAt: time.Now().UTC().AddDate(0, 0, 7).Format(time.RFC3339)})
+	ctx.CreateTestLoan(t, parametersCreateLoan{MediumID: bookID, BorrowerName: "Jane"})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/loans"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientLoans)
+	}{
+		{
+			name: "Valid, lender",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cl ClientLoans) {
+				if len(cl.LentOut) != 2 || cl.LentOut[0].ID != bobLoanID || cl.LentOut[1].BorrowerName != "Jane" || cl.LentOut[1].BorrowerID != "" {
+					t.Errorf("Expected both loans, first due first, got %+v", cl.LentOut)
+				}
+				if len(cl.Borrowed) != 0 {
+					t.Errorf("Expected no borrowed media, got %+v", cl.Borrowed)
+				}
+			},
+		},
+		{
+			name: "Valid, borrower",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cl ClientLoans) {
+				if len(cl.LentOut) != 0 || len(cl.Borrowed) != 1 || cl.Borrowed[0].Title != "Catan" {
+					t.Errorf("Expected borrower to see the loan made to them, got %+v", cl)
+				}
+			},
+		},
+		{
+			name:           "No access_token",
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest(testMethod, testEndpoint, nil)
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientLoans
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestGetOverdueLoans(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+
+	// One loan was due yesterday, the other one is due next week
+	overdueMediumID := ctx.CreateTestMediumRandom(t)
+	ctx.CreateTestRecord(t, overdueMediumID)
+	dueMediumID := ctx.CreateTestMediumRandom(t)
+	ctx.CreateTestRecord(t, dueMediumID)
+	lastWeek := time.Now().UTC().AddDate(0, 0, -7).Format(time.RFC3339)
+	overdueID := ctx.CreateTestLoan(t, parametersCreateLoan{
+		MediumID:     overdueMediumID,
+		BorrowerName: "Jane",
+		LentAt:       lastWeek,
+		DueAt:        time.Now().UTC().AddDate(0, 0, -1).Format(time.RFC3339),
+	})
+	ctx.CreateTestLoan(t, parametersCreateLoan{
+		MediumID:     dueMediumID,
+		BorrowerName: "Jane",
+		DueAt:        time.Now().UTC().AddDate(0, 0, 7).Format(time.RFC3339),
+	})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/loans/overdue"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientLoans)
+	}{
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cl ClientLoans) {
+				if len(cl.LentOut) != 1 || cl.LentOut[0].ID != overdueID || !cl.LentOut[0].IsOverdue || cl.LentOut[0].DaysOverdue != 1 {
+					t.Errorf("Expected the loan due yesterday, got %+v", cl.LentOut)
+				}
+			},
+		},
+		{
+			name:           "No access_token",
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest(testMethod, testEndpoint, nil)
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientLoans
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestUpdateLoan(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	mediumID := ctx.CreateTestMediumRandom(t)
+	ctx.CreateTestRecord(t, mediumID)
+	returnedMediumID := ctx.CreateTestMediumRandom(t)
+	ctx.CreateTestRecord(t, returnedMediumID)
+
+	lastWeek := time.Now().UTC().AddDate(0, 0, -7).Format(time.RFC3339)
+	yesterday := time.Now().UTC().AddDate(0, 0, -1).Format(time.RFC3339)
+	loanID := ctx.CreateTestLoan(t, parametersCreateLoan{MediumID: mediumID, BorrowerName: "Jane", LentAt: lastWeek, DueAt: yesterday})
+	returnedID := ctx.CreateTestLoan(t, parametersCreateLoan{MediumID: returnedMediumID, BorrowerName: "Jane"})
+	ctx.ReturnTestLoan(t, returnedID)
+
+	testMethod := "PUT"
+	testEndpoint := ctx.BaseURL + "/api/loans"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersUpdateLoan
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientLoan)
+	}{
+		{
+			name: "Valid, due date removed",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateLoan{LoanID: loanID},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cl ClientLoan) {
+				if cl.DueAt != "" || cl.IsOverdue {
+					t.Errorf("Expected loan to have no due date anymore, got %+v", cl)
+				}
+			},
+		},
+		{
+			name: "Due before lent",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateLoan{LoanID: loanID, DueAt: "2000-01-01T00:00:00Z"},
+			expectedStatus: 400,
+		},
+		{
+			name: "Due date of a returned loan",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateLoan{LoanID: returnedID, DueAt: yesterday},
+			expectedStatus: 400,
+		},
+		{
+			name: "Other user's loan",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersUpdateLoan{LoanID: loanID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Invalid loan_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateLoan{LoanID: "1234"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersUpdateLoan{LoanID: loanID},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientLoan
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestReturnLoan(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	mediumID := ctx.CreateTestMediumRandom(t)
+	ctx.CreateTestRecord(t, mediumID)
+	loanID := ctx.CreateTestLoan(t, parametersCreateLoan{
+		MediumID:     mediumID,
+		BorrowerName: "Jane",
+		LentAt:       time.Now().UTC().AddDate(0, 0, -7).Format(time.RFC3339),
+	})
+
+	testMethod := "PUT"
+	testEndpoint := ctx.BaseURL + "/api/loans/return"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersReturnLoan
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientLoan)
+	}{
+		{
+			name: "Other user's loan",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersReturnLoan{LoanID: loanID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Returned before lent",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersReturnLoan{LoanID: loanID, ReturnedAt: "2000-01-01T00:00:00Z"},
+			expectedStatus: 400,
+		},
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersReturnLoan{LoanID: loanID},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cl ClientLoan) {
+				if cl.ReturnedAt == "" {
+					t.Errorf("Expected loan to be returned, got %+v", cl)
+				}
+			},
+		},
+		{
+			name: "Returned twice",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersReturnLoan{LoanID: loanID},
+			expectedStatus: 400,
+		},
+		{
+			name: "Invalid loan_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersReturnLoan{LoanID: "1234"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersReturnLoan{LoanID: loanID},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientLoan
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestGetMediumLoans(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	// Medium was lent to Bob and returned, then lent to Jane
+	mediumID := ctx.CreateTestMediumRandom(t)
+	ctx.CreateTestRecord(t, mediumID)
+	returnedID := ctx.CreateTestLoan(t, parametersCreateLoan{MediumID: mediumID, BorrowerUsername: "Bob", LentAt: time.Now().UTC().AddDate(0, 0, -7).Format(time.RFC3339)})
+	ctx.ReturnTestLoan(t, returnedID)
+	ctx.CreateTestLoan(t, parametersCreateLoan{MediumID: mediumID, BorrowerName: "Jane"})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/media/loans"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersGetMediumLoans
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientMediumLoans)
+	}{
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGetMediumLoans{MediumID: mediumID},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cm ClientMediumLoans) {
+				if len(cm.Loans) != 2 || !cm.IsLentOut || cm.Loans[1].ID != returnedID || cm.Loans[1].ReturnedAt == "" {
+					t.Errorf("Expected both loans, last one first, got %+v", cm)
+				}
+			},
+		},
+		{
+			name: "Valid, medium not lent by user",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersGetMediumLoans{MediumID: mediumID},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cm ClientMediumLoans) {
+				if len(cm.Loans) != 0 || cm.IsLentOut {
+					t.Errorf("Expected no loan from Bob, got %+v", cm)
+				}
+			},
+		},
+		{
+			name: "Invalid medium_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGetMediumLoans{MediumID: "1234"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersGetMediumLoans{MediumID: mediumID},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientMediumLoans
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestDeleteLoan(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	mediumID := ctx.CreateTestMediumRandom(t)
+	ctx.CreateTestRecord(t, mediumID)
+	loanID := ctx.CreateTestLoan(t, parametersCreateLoan{MediumID: mediumID, BorrowerName: "Jane"})
+
+	testMethod := "DELETE"
+	testEndpoint := ctx.BaseURL + "/api/loans"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersLoan
+		expectedStatus int
+		checkAfter     func(*testing.T)
+	}{
+		{
+			name: "Other user's loan",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersLoan{LoanID: loanID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersLoan{LoanID: loanID},
+			expectedStatus: 200,
+			checkAfter: func(t *testing.T) {
+				// Medium can be lent again
+				ctx.CreateTestLoan(t, parametersCreateLoan{MediumID: mediumID, BorrowerName: "Jane"})
+			},
+		},
+		{
+			name: "Wrong loan ID (already deleted)",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersLoan{LoanID: loanID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Invalid loan_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersLoan{LoanID: "1234"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersLoan{LoanID: loanID},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkAfter != nil {
+				tc.checkAfter(t)
+			}
+		})
+	}
+}
+
+func TestCreateCopy(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+
+	bookID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Emma", MediaType: "book", Creator: "Jane Austen", PubDate: "1815"})
+	ctx.CreateTestRecord(t, bookID)
+	notOwnedID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Dune", MediaType: "book", Creator: "Frank Herbert", PubDate: "1965"})
+
+	price := 24.99
+	negativePrice := -1.0
+
+	testMethod := "POST"
+	testEndpoint := ctx.BaseURL + "/api/copies"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersCreateCopy
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientOwnedCopy)
+	}{
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: parametersCreateCopy{MediumID: bookID, parametersCopyDetails: parametersCopyDetails{
+				Format:       "Hardcover",
+				Condition:    "Like_New",
+				Language:     "English",
+				PurchaseDate: "2024-03-01T00:00:00Z",
+				Price:        &price,
+				Currency:     "eur",
+				Store:        "Shakespeare and Company",
+			}},
+			expectedStatus: 201,
+			checkResponse: func(t *testing.T, co ClientOwnedCopy) {
+				if co.Condition != "like_new" || co.Currency != "EUR" || co.Price == nil || *co.Price != 24.99 {
+					t.Errorf("Expected normalized condition, currency and price, got %+v", co)
+				}
+				if co.Title != "Emma" || co.MediaType != "book" {
+					t.Errorf("Expected copy of Emma, got %+v", co)
+				}
+			},
+		},
+		{
+			name: "Valid, format only",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateCopy{MediumID: bookID, parametersCopyDetails: parametersCopyDetails{Format: "ebook"}},
+			expectedStatus: 201,
+			checkResponse: func(t *testing.T, co ClientOwnedCopy) {
+				if co.Format != "ebook" || co.Price != nil || co.Condition != "" {
+					t.Errorf("Expected an ebook with no other detail, got %+v", co)
+				}
+			},
+		},
+		{
+			name: "Missing format",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateCopy{MediumID: bookID, parametersCopyDetails: parametersCopyDetails{Format: " "}},
+			expectedStatus: 400,
+		},
+		{
+			name: "Unknown condition",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateCopy{MediumID: bookID, parametersCopyDetails: parametersCopyDetails{Format: "hardcover", Condition: "mint"}},
+			expectedStatus: 400,
+		},
+		{
+			name: "Price without currency",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateCopy{MediumID: bookID, parametersCopyDetails: parametersCopyDetails{Format: "hardcover", Price: &price}},
+			expectedStatus: 400,
+		},
+		{
+			name: "Negative price",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateCopy{MediumID: bookID, parametersCopyDetails: parametersCopyDetails{Format: "hardcover", Price: &negativePrice, Currency: "EUR"}},
+			expectedStatus: 400,
+		},
+		{
+			name: "Unknown currency format",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateCopy{MediumID: bookID, parametersCopyDetails: parametersCopyDetails{Format: "hardcover", Price: &price, Currency: "euro"}},
+			expectedStatus: 400,
+		},
+		{
+			name: "Bad purchase date",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateCopy{MediumID: bookID, parametersCopyDetails: parametersCopyDetails{Format: "hardcover", PurchaseDate: "yesterday"}},
+			expectedStatus: 400,
+		},
+		{
+			name: "Medium not on shelf",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateCopy{MediumID: notOwnedID, parametersCopyDetails: parametersCopyDetails{Format: "paperback"}},
+			expectedStatus: 404,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersCreateCopy{MediumID: bookID, parametersCopyDetails: parametersCopyDetails{Format: "paperback"}},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientOwnedCopy
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestGetCopies(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	bookID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Emma", MediaType: "book", Creator: "Jane Austen", PubDate: "1815"})
+	ctx.CreateTestRecord(t, bookID)
+	gameID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Catan", MediaType: "boardgame", Creator: "Klaus Teuber", PubDate: "1995"})
+	ctx.CreateTestRecord(t, gameID)
+	price := 60.0
+	hardcoverID := ctx.CreateTestCopy(t, parametersCreateCopy{MediumID: bookID, parametersCopyDetails: parametersCopyDetails{Format: "hardcover"}})
+	ctx.CreateTestCopy(t, parametersCreateCopy{MediumID: gameID, parametersCopyDetails: parametersCopyDetails{Format: "big-box edition", Price: &price, Currency: "USD"}})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/copies"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientOwnedCopies)
+	}{
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, co ClientOwnedCopies) {
+				if len(co.Copies) != 2 || co.Copies[0].Title != "Catan" || co.Copies[1].ID != hardcoverID || co.Copies[1].Price != nil {
+					t.Errorf("Expected 2 copies ordered by media type and title, got %+v", co.Copies)
+				}
+			},
+		},
+		{
+			name: "Valid, other user",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, co ClientOwnedCopies) {
+				if len(co.Copies) != 0 {
+					t.Errorf("Expected no copy for other user, got %+v", co.Copies)
+				}
+			},
+		},
+		{
+			name:           "No access_token",
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest(testMethod, testEndpoint, nil)
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientOwnedCopies
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestGetCollectionValue(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+
+	// Two priced books in EUR, a priced game in USD and an unpriced movie
+	bookID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Emma", MediaType: "book", Creator: "Jane Austen", PubDate: "1815"})
+	ctx.CreateTestRecord(t, bookID)
+	gameID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Catan", MediaType: "boardgame", Creator: "Klaus Teuber", PubDate: "1995"})
+	ctx.CreateTestRecord(t, gameID)
+	movieID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Alien", MediaType: "movie", Creator: "Ridley Scott", PubDate: "1979"})
+	ctx.CreateTestRecord(t, movieID)
+	hardcoverPrice, ebookPrice, gamePrice := 24.99, 3.5, 60.0
+	ctx.CreateTestCopy(t, parametersCreateCopy{MediumID: bookID, parametersCopyDetails: parametersCopyDetails{Format: "hardcover", Price: &hardcoverPrice, Currency: "EUR"}})
+	ctx.CreateTestCopy(t, parametersCreateCopy{MediumID: bookID, parametersCopyDetails: parametersCopyDetails{Format: "ebook", Price: &ebookPrice, Currency: "EUR"}})
+	ctx.CreateTestCopy(t, parametersCreateCopy{MediumID: gameID, parametersCopyDetails: parametersCopyDetails{Format: "big-box edition", Price: &gamePrice, Currency: "USD"}})
+	ctx.CreateTestCopy(t, parametersCreateCopy{MediumID: movieID, parametersCopyDetails: parametersCopyDetails{Format: "Blu-ray"}})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/copies/value"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientCollectionValues)
+	}{
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cv ClientCollectionValues) {
+				if cv.CopiesCount != 4 || cv.UnpricedCount != 1 {
+					t.Errorf("Expected 4 copies, 1 without price, got %+v", cv)
+				}
+				wantByType := []ClientCollectionValue{
+					{MediaType: "boardgame", Currency: "USD", CopiesCount: 1, Value: 60},
+					{MediaType: "book", Currency: "EUR", CopiesCount: 2, Value: 28.49},
+				}
+				if !reflect.DeepEqual(cv.ByMediaType, wantByType) {
+					t.Errorf("Expected values by media type %+v, got %+v", wantByType, cv.ByMediaType)
+				}
+				wantTotals := []ClientCollectionValue{
+					{Currency: "EUR", CopiesCount: 2, Value: 28.49},
+					{Currency: "USD", CopiesCount: 1, Value: 60},
+				}
+				if !reflect.DeepEqual(cv.Totals, wantTotals) {
+					t.Errorf("Expected totals %+v, got %+v", wantTotals, cv.Totals)
+				}
+			},
+		},
+		{
+			name:           "No access_token",
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest(testMethod, testEndpoint, nil)
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientCollectionValues
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestSearchMediaRecordsByCopies(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+
+	// Emma has an English hardcover and a French ebook, Catan a copy in good condition, Dune no copy
+	bookID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Emma", MediaType: "book", Creator: "Jane Austen", PubDate: "1815"})
+	ctx.CreateTestRecord(t, bookID)
+	gameID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Catan", MediaType: "boardgame", Creator: "Klaus Teuber", PubDate: "1995"})
+	ctx.CreateTestRecord(t, gameID)
+	ctx.CreateTestRecord(t, ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Dune", MediaType: "book", Creator: "Frank Herbert", PubDate: "1965"}))
+	ctx.CreateTestCopy(t, parametersCreateCopy{MediumID: bookID, parametersCopyDetails: parametersCopyDetails{Format: "hardcover", Language: "English"}})
+	ctx.CreateTestCopy(t, parametersCreateCopy{MediumID: bookID, parametersCopyDetails: parametersCopyDetails{Format: "ebook", Language: "French"}})
+	ctx.CreateTestCopy(t, parametersCreateCopy{MediumID: gameID, parametersCopyDetails: parametersCopyDetails{Format: "big-box edition", Condition: "good"}})
+
+	owned := true
+	notOwned := false
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/media_records/search"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersSearchMediaRecords
+		expectedStatus int
+		expectedTitles []string
+	}{
+		{
+			name: "Owned",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersSearchMediaRecords{Owned: &owned},
+			expectedStatus: 200,
+			expectedTitles: []string{"Catan", "Emma"},
+		},
+		{
+			name: "Not owned",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersSearchMediaRecords{Owned: &notOwned},
+			expectedStatus: 200,
+			expectedTitles: []string{"Dune"},
+		},
+		{
+			name: "By format",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersSearchMediaRecords{CopyFormat: "EBOOK"},
+			expectedStatus: 200,
+			expectedTitles: []string{"Emma"},
+		},
+		{
+			name: "By condition",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersSearchMediaRecords{CopyCondition: "good"},
+			expectedStatus: 200,
+			expectedTitles: []string{"Catan"},
+		},
+		{
+			name: "Format and language of the same copy",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersSearchMediaRecords{CopyFormat: "ebook", CopyLanguage: "english"},
+			expectedStatus: 200,
+			expectedTitles: []string{},
+		},
+		{
+			name: "Unknown condition",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersSearchMediaRecords{CopyCondition: "mint"},
+			expectedStatus: 400,
+		},
+		{
+			name: "Copy filter on media not owned",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersSearchMediaRecords{Owned: &notOwned, CopyFormat: "ebook"},
+			expectedStatus: 400,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Fatalf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.expectedTitles != nil {
+				var responseBody ClientSearchMediaRecords
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				titles := []string{}
+				for _, record := range responseBody.Records {
+					titles = append(titles, record.Title)
+				}
+				if !reflect.DeepEqual(titles, tc.expectedTitles) {
+					t.Errorf("Expected records %v, got %v", tc.expectedTitles, titles)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateCopy(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	bookID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Emma", MediaType: "book", Creator: "Jane Austen", PubDate: "1815"})
+	ctx.CreateTestRecord(t, bookID)
+	price := 24.99
+	copyID := ctx.CreateTestCopy(t, parametersCreateCopy{MediumID: bookID, parametersCopyDetails: parametersCopyDetails{
+		Format:    "hardcover",
+		Condition: "like_new",
+		Price:     &price,
+		Currency:  "EUR",
+		Store:     "Shakespeare and Company",
+	}})
+
+	testMethod := "PUT"
+	testEndpoint := ctx.BaseURL + "/api/copies"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersUpdateCopy
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientOwnedCopy)
+	}{
+		{
+			name: "Other user's copy",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersUpdateCopy{CopyID: copyID, parametersCopyDetails: parametersCopyDetails{Format: "hardcover"}},
+			expectedStatus: 404,
+		},
+		{
+			name: "Valid, every detail replaced",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateCopy{CopyID: copyID, parametersCopyDetails: parametersCopyDetails{Format: "hardcover", Condition: "acceptable"}},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, co ClientOwnedCopy) {
+				if co.Condition != "acceptable" || co.Price != nil || co.Store != "" || co.Title != "Emma" {
+					t.Errorf("Expected every detail to be replaced, got %+v", co)
+				}
+			},
+		},
+		{
+			name: "Unknown condition",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateCopy{CopyID: copyID, parametersCopyDetails: parametersCopyDetails{Format: "hardcover", Condition: "mint"}},
+			expectedStatus: 400,
+		},
+		{
+			name: "Invalid copy_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateCopy{CopyID: "1234", parametersCopyDetails: parametersCopyDetails{Format: "hardcover"}},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersUpdateCopy{CopyID: copyID, parametersCopyDetails: parametersCopyDetails{Format: "hardcover"}},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientOwnedCopy
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestGetMediumCopies(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	bookID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Emma", MediaType: "book", Creator: "Jane Austen", PubDate: "1815"})
+	ctx.CreateTestRecord(t, bookID)
+	hardcoverID := ctx.CreateTestCopy(t, parametersCreateCopy{MediumID: bookID, parametersCopyDetails: parametersCopyDetails{Format: "hardcover"}})
+	ctx.CreateTestCopy(t, parametersCreateCopy{MediumID: bookID, parametersCopyDetails: parametersCopyDetails{Format: "ebook"}})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/media/copies"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersGetMediumCopies
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientMediumCopies)
+	}{
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGetMediumCopies{MediumID: bookID},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cm ClientMediumCopies) {
+				if len(cm.Copies) != 2 || cm.Copies[0].ID != hardcoverID {
+					t.Errorf("Expected both copies, first added first, got %+v", cm)
+				}
+			},
+		},
+		{
+			name: "Valid, other user",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersGetMediumCopies{MediumID: bookID},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cm ClientMediumCopies) {
+				if len(cm.Copies) != 0 {
+					t.Errorf("Expected no copy for other user, got %+v", cm)
+				}
+			},
+		},
+		{
+			name: "Invalid medium_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGetMediumCopies{MediumID: "1234"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersGetMediumCopies{MediumID: bookID},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientMediumCopies
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestDeleteCopy(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	bookID := ctx.CreateTestMediumRandom(t)
+	recordID := ctx.CreateTestRecord(t, bookID)
+	copyID := ctx.CreateTestCopy(t, parametersCreateCopy{MediumID: bookID, parametersCopyDetails: parametersCopyDetails{Format: "hardcover"}})
+
+	testMethod := "DELETE"
+	testEndpoint := ctx.BaseURL + "/api/copies"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersCopy
+		expectedStatus int
+		checkAfter     func(*testing.T)
+	}{
+		{
+			name: "Other user's copy",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersCopy{CopyID: copyID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCopy{CopyID: copyID},
+			expectedStatus: 200,
+			checkAfter: func(t *testing.T) {
+				// The record itself is kept
+				if !ctx.TestIfRecordExist(recordID) {
+					t.Error("Record was deleted along with its copy")
+				}
+			},
+		},
+		{
+			name: "Wrong copy ID (already deleted)",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCopy{CopyID: copyID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Invalid copy_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCopy{CopyID: "1234"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersCopy{CopyID: copyID},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkAfter != nil {
+				tc.checkAfter(t)
+			}
+		})
+	}
+}
+
+func TestCreateShelvingUnit(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+
+	testMethod := "POST"
+	testEndpoint := ctx.BaseURL + "/api/shelving_units"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersCreateShelvingUnit
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientShelvingUnit)
+	}{
+		{
+			name: "Valid, Kallax cube dimensions by default",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateShelvingUnit{parametersShelvingUnitDetails{Name: "Living room", Rows: 4, Columns: 4}},
+			expectedStatus: 201,
+			checkResponse: func(t *testing.T, cs ClientShelvingUnit) {
+				if cs.Rows != 4 || cs.Columns != 4 || cs.CellWidthCm != 33 || cs.CellDepthCm != 39 || cs.ItemsCount != 0 {
+					t.Errorf("Expected an empty 4x4 unit with Kallax dimensions, got %+v", cs)
+				}
+			},
+		},
+		{
+			name: "Valid, custom dimensions",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateShelvingUnit{parametersShelvingUnitDetails{Name: "Bedroom", Rows: 6, Columns: 1, CellWidthCm: 76, CellHeightCm: 35, CellDepthCm: 26}},
+			expectedStatus: 201,
+			checkResponse: func(t *testing.T, cs ClientShelvingUnit) {
+				if cs.CellWidthCm != 76 || cs.CellHeightCm != 35 || cs.CellDepthCm != 26 {
+					t.Errorf("Expected given dimensions, got %+v", cs)
+				}
+			},
+		},
+		{
+			name: "Same name",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateShelvingUnit{parametersShelvingUnitDetails{Name: "living ROOM", Rows: 1, Columns: 1}},
+			expectedStatus: 409,
+		},
+		{
+			name: "Missing name",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateShelvingUnit{parametersShelvingUnitDetails{Name: " ", Rows: 4, Columns: 4}},
+			expectedStatus: 400,
+		},
+		{
+			name: "No rows",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateShelvingUnit{parametersShelvingUnitDetails{Name: "Hall", Columns: 4}},
+			expectedStatus: 400,
+		},
+		{
+			name: "Too many columns",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateShelvingUnit{parametersShelvingUnitDetails{Name: "Hall", Rows: 4, Columns: 21}},
+			expectedStatus: 400,
+		},
+		{
+			name: "Negative dimension",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateShelvingUnit{parametersShelvingUnitDetails{Name: "Hall", Rows: 4, Columns: 4, CellDepthCm: -1}},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersCreateShelvingUnit{parametersShelvingUnitDetails{Name: "Hall", Rows: 4, Columns: 4}},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientShelvingUnit
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestGetShelvingUnits(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	kallaxID := ctx.CreateTestShelvingUnit(t, parametersCreateShelvingUnit{parametersShelvingUnitDetails{Name: "Living room", Rows: 4, Columns: 4}})
+	billyID := ctx.CreateTestShelvingUnit(t, parametersCreateShelvingUnit{parametersShelvingUnitDetails{Name: "Bedroom", Rows: 6, Columns: 1}})
+	mediumID := ctx.CreateTestMediumRandom(t)
+	ctx.CreateTestRecord(t, mediumID)
+	ctx.PlaceTestShelvingItem(t, parametersPlaceShelvingItem{MediumID: mediumID, UnitID: kallaxID, Row: 1, Column: 1})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/shelving_units"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientShelvingUnits)
+	}{
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientShelvingUnits) {
+				if len(cs.Units) != 2 || cs.Units[0].ID != billyID || cs.Units[1].ID != kallaxID {
+					t.Fatalf("Expected user's two units by name, got %+v", cs.Units)
+				}
+				if cs.Units[0].ItemsCount != 0 || cs.Units[1].ItemsCount != 1 {
+					t.Errorf("Expected only the living room to hold a medium, got %+v", cs.Units)
+				}
+			},
+		},
+		{
+			name: "Valid, other user",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientShelvingUnits) {
+				if len(cs.Units) != 0 {
+					t.Errorf("Expected no unit for other user, got %+v", cs.Units)
+				}
+			},
+		},
+		{
+			name:           "No access_token",
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest(testMethod, testEndpoint, nil)
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientShelvingUnits
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestUpdateShelvingUnit(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	// Catan sits in the last row of the unit
+	kallaxID := ctx.CreateTestShelvingUnit(t, parametersCreateShelvingUnit{parametersShelvingUnitDetails{Name: "Living room", Rows: 4, Columns: 4}})
+	catanID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Catan", MediaType: "boardgame", Creator: "Klaus Teuber", PubDate: "1995"})
+	ctx.CreateTestRecord(t, catanID)
+	ctx.PlaceTestShelvingItem(t, parametersPlaceShelvingItem{MediumID: catanID, UnitID: kallaxID, Row: 4, Column: 3})
+
+	testMethod := "PUT"
+	testEndpoint := ctx.BaseURL + "/api/shelving_units"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersUpdateShelvingUnit
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientShelvingUnit)
+	}{
+		{
+			name: "Valid, one more column",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateShelvingUnit{UnitID: kallaxID, parametersShelvingUnitDetails: parametersShelvingUnitDetails{Name: "Living room", Rows: 4, Columns: 5}},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientShelvingUnit) {
+				if cs.Columns != 5 || cs.ItemsCount != 1 {
+					t.Errorf("Expected 5 columns holding 1 medium, got %+v", cs)
+				}
+			},
+		},
+		{
+			name: "Shrunk over a medium",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateShelvingUnit{UnitID: kallaxID, parametersShelvingUnitDetails: parametersShelvingUnitDetails{Name: "Living room", Rows: 3, Columns: 4}},
+			expectedStatus: 409,
+		},
+		{
+			name: "Too many columns",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateShelvingUnit{UnitID: kallaxID, parametersShelvingUnitDetails: parametersShelvingUnitDetails{Name: "Living room", Rows: 4, Columns: 21}},
+			expectedStatus: 400,
+		},
+		{
+			name: "Other user's unit",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersUpdateShelvingUnit{UnitID: kallaxID, parametersShelvingUnitDetails: parametersShelvingUnitDetails{Name: "Mine", Rows: 4, Columns: 4}},
+			expectedStatus: 404,
+		},
+		{
+			name: "Invalid unit_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateShelvingUnit{UnitID: "1234", parametersShelvingUnitDetails: parametersShelvingUnitDetails{Name: "Living room", Rows: 4, Columns: 4}},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersUpdateShelvingUnit{UnitID: kallaxID, parametersShelvingUnitDetails: parametersShelvingUnitDetails{Name: "Living room", Rows: 4, Columns: 4}},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientShelvingUnit
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestDeleteShelvingUnit(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	kallaxID := ctx.CreateTestShelvingUnit(t, parametersCreateShelvingUnit{parametersShelvingUnitDetails{Name: "Living room", Rows: 4, Columns: 4}})
+	mediumID := ctx.CreateTestMediumRandom(t)
+	ctx.CreateTestRecord(t, mediumID)
+	ctx.PlaceTestShelvingItem(t, parametersPlaceShelvingItem{MediumID: mediumID, UnitID: kallaxID, Row: 4, Column: 3})
+
+	testMethod := "DELETE"
+	testEndpoint := ctx.BaseURL + "/api/shelving_units"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersShelvingUnit
+		expectedStatus int
+		checkAfter     func(*testing.T)
+	}{
+		{
+			name: "Other user's unit",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersShelvingUnit{UnitID: kallaxID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersShelvingUnit{UnitID: kallaxID},
+			expectedStatus: 200,
+			checkAfter: func(t *testing.T) {
+				// Media of the unit are shelved nowhere anymore
+				locations := ctx.WhereIsTestMedium(t, mediumID)
+				if len(locations.Locations) != 0 {
+					t.Errorf("Expected medium nowhere once its unit is deleted, got %+v", locations.Locations)
+				}
+			},
+		},
+		{
+			name: "Wrong unit ID (already deleted)",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersShelvingUnit{UnitID: kallaxID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Invalid unit_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersShelvingUnit{UnitID: "1234"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersShelvingUnit{UnitID: kallaxID},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkAfter != nil {
+				tc.checkAfter(t)
+			}
+		})
+	}
+}
+
+func TestGetShelvingUnitGrid(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	// Emma then Persuasion share the first cell, Catan is in row 4 column 3
+	kallaxID := ctx.CreateTestShelvingUnit(t, parametersCreateShelvingUnit{parametersShelvingUnitDetails{Name: "Living room", Rows: 4, Columns: 4}})
+	emmaID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Emma", MediaType: "book", Creator: "Jane Austen", PubDate: "1815"})
+	ctx.CreateTestRecord(t, emmaID)
+	persuasionID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Persuasion", MediaType: "book", Creator: "Jane Austen", PubDate: "1817"})
+	ctx.CreateTestRecord(t, persuasionID)
+	catanID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Catan", MediaType: "boardgame", Creator: "Klaus Teuber", PubDate: "1995"})
+	ctx.CreateTestRecord(t, catanID)
+	ctx.PlaceTestShelvingItem(t, parametersPlaceShelvingItem{MediumID: emmaID, UnitID: kallaxID, Row: 1, Column: 1})
+	ctx.PlaceTestShelvingItem(t, parametersPlaceShelvingItem{MediumID: persuasionID, UnitID: kallaxID, Row: 1, Column: 1})
+	ctx.PlaceTestShelvingItem(t, parametersPlaceShelvingItem{MediumID: catanID, UnitID: kallaxID, Row: 4, Column: 3})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/shelving_units/grid"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersShelvingUnit
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientShelvingUnitGrid)
+	}{
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersShelvingUnit{UnitID: kallaxID},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientShelvingUnitGrid) {
+				if len(cs.Cells) != 16 || cs.Unit.ItemsCount != 3 {
+					t.Fatalf("Expected 16 cells holding 3 media, got %d cells and %d media", len(cs.Cells), cs.Unit.ItemsCount)
+				}
+				first, last := cs.Cells[0], cs.Cells[14]
+				if len(first.Items) != 2 || first.Items[0].MediumID != emmaID || first.Items[1].MediumID != persuasionID {
+					t.Errorf("Expected Emma then Persuasion in first cell, got %+v", first)
+				}
+				if last.Row != 4 || last.Column != 3 || len(last.Items) != 1 || last.Items[0].MediumID != catanID {
+					t.Errorf("Expected Catan in row 4 column 3, got %+v", last)
+				}
+			},
+		},
+		{
+			name: "Other user's unit",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersShelvingUnit{UnitID: kallaxID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Invalid unit_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersShelvingUnit{UnitID: "1234"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersShelvingUnit{UnitID: kallaxID},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientShelvingUnitGrid
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestPlaceShelvingItem(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	kallaxID := ctx.CreateTestShelvingUnit(t, parametersCreateShelvingUnit{parametersShelvingUnitDetails{Name: "Living room", Rows: 4, Columns: 4}})
+	billyID := ctx.CreateTestShelvingUnit(t, parametersCreateShelvingUnit{parametersShelvingUnitDetails{Name: "Bedroom", Rows: 6, Columns: 1}})
+	persuasionID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Persuasion", MediaType: "book", Creator: "Jane Austen", PubDate: "1817"})
+	ctx.CreateTestRecord(t, persuasionID)
+	notOwnedID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Dune", MediaType: "book", Creator: "Frank Herbert", PubDate: "1965"})
+
+	testMethod := "PUT"
+	testEndpoint := ctx.BaseURL + "/api/shelving_units/items"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersPlaceShelvingItem
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientShelvingItem)
+	}{
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersPlaceShelvingItem{MediumID: persuasionID, UnitID: kallaxID, Row: 1, Column: 1},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientShelvingItem) {
+				if cs.UnitName != "Living room" || cs.Title != "Persuasion" || cs.Row != 1 || cs.Column != 1 {
+					t.Errorf("Expected Persuasion in Living room's first cell, got %+v", cs)
+				}
+			},
+		},
+		{
+			name: "Valid, moved between units",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersPlaceShelvingItem{MediumID: persuasionID, UnitID: billyID, Row: 6, Column: 1},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientShelvingItem) {
+				if cs.UnitName != "Bedroom" || cs.Row != 6 {
+					t.Errorf("Expected Persuasion in Bedroom's sixth row, got %+v", cs)
+				}
+			},
+		},
+		{
+			name: "Cell outside the grid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersPlaceShelvingItem{MediumID: persuasionID, UnitID: kallaxID, Row: 5, Column: 1},
+			expectedStatus: 400,
+		},
+		{
+			name: "Medium not on shelf",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersPlaceShelvingItem{MediumID: notOwnedID, UnitID: kallaxID, Row: 1, Column: 1},
+			expectedStatus: 404,
+		},
+		{
+			name: "Other user's unit",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersPlaceShelvingItem{MediumID: persuasionID, UnitID: kallaxID, Row: 1, Column: 1},
+			expectedStatus: 404,
+		},
+		{
+			name: "Invalid unit_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersPlaceShelvingItem{MediumID: persuasionID, UnitID: "1234", Row: 1, Column: 1},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersPlaceShelvingItem{MediumID: persuasionID, UnitID: kallaxID, Row: 1, Column: 1},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientShelvingItem
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestRemoveShelvingItem(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+
+	kallaxID := ctx.CreateTestShelvingUnit(t, parametersCreateShelvingUnit{parametersShelvingUnitDetails{Name: "Living room", Rows: 4, Columns: 4}})
+	mediumID := ctx.CreateTestMediumRandom(t)
+	ctx.CreateTestRecord(t, mediumID)
+	ctx.PlaceTestShelvingItem(t, parametersPlaceShelvingItem{MediumID: mediumID, UnitID: kallaxID, Row: 1, Column: 1})
+
+	testMethod := "DELETE"
+	testEndpoint := ctx.BaseURL + "/api/shelving_units/items"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersShelvingItem
+		expectedStatus int
+		checkAfter     func(*testing.T)
+	}{
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersShelvingItem{MediumID: mediumID},
+			expectedStatus: 200,
+			checkAfter: func(t *testing.T) {
+				locations := ctx.WhereIsTestMedium(t, mediumID)
+				if len(locations.Locations) != 0 {
+					t.Errorf("Expected medium nowhere once taken out, got %+v", locations.Locations)
+				}
+			},
+		},
+		{
+			name: "Medium in no unit",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersShelvingItem{MediumID: mediumID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Invalid medium_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersShelvingItem{MediumID: "1234"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersShelvingItem{MediumID: mediumID},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkAfter != nil {
+				tc.checkAfter(t)
+			}
+		})
+	}
+}
+
+func TestWhereIsMedium(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	kallaxID := ctx.CreateTestShelvingUnit(t, parametersCreateShelvingUnit{parametersShelvingUnitDetails{Name: "Living room", Rows: 4, Columns: 4}})
+	billyID := ctx.CreateTestShelvingUnit(t, parametersCreateShelvingUnit{parametersShelvingUnitDetails{Name: "Bedroom", Rows: 6, Columns: 1}})
+	persuasionID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Persuasion", MediaType: "book", Creator: "Jane Austen", PubDate: "1817"})
+	ctx.CreateTestRecord(t, persuasionID)
+	catanID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Catan", MediaType: "boardgame", Creator: "Klaus Teuber", PubDate: "1995"})
+	ctx.CreateTestRecord(t, catanID)
+	ctx.PlaceTestShelvingItem(t, parametersPlaceShelvingItem{MediumID: persuasionID, UnitID: billyID, Row: 6, Column: 1})
+	ctx.PlaceTestShelvingItem(t, parametersPlaceShelvingItem{MediumID: catanID, UnitID: kallaxID, Row: 4, Column: 3})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/shelving_units/where"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersWhereIsMedium
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientShelvingLocations)
+	}{
+		{
+			name: "Valid, by title",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersWhereIsMedium{Title: "SUAS"},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientShelvingLocations) {
+				if len(cs.Locations) != 1 || cs.Locations[0].UnitID != billyID || cs.Locations[0].Row != 6 {
+					t.Errorf("Expected Persuasion in Bedroom, got %+v", cs.Locations)
+				}
+			},
+		},
+		{
+			name: "Valid, by medium_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersWhereIsMedium{MediumID: catanID},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientShelvingLocations) {
+				if len(cs.Locations) != 1 || cs.Locations[0].UnitName != "Living room" || cs.Locations[0].Column != 3 {
+					t.Errorf("Expected Catan in Living room, got %+v", cs.Locations)
+				}
+			},
+		},
+		{
+			name: "Valid, other user",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersWhereIsMedium{MediumID: catanID},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientShelvingLocations) {
+				if len(cs.Locations) != 0 {
+					t.Errorf("Expected Catan nowhere for other user, got %+v", cs.Locations)
+				}
+			},
+		},
+		{
+			name: "Neither medium_id nor title",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersWhereIsMedium{},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersWhereIsMedium{MediumID: catanID},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientShelvingLocations
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestCreatePlay(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	ctx.CreateOtherTestUser(t, "Bob")
+
+	catanID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Catan", MediaType: "boardgame", Creator: "Klaus Teuber", PubDate: "1995"})
+	ctx.CreateTestRecord(t, catanID)
+	emmaID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Emma", MediaType: "book", Creator: "Jane Austen", PubDate: "1815"})
+	ctx.CreateTestRecord(t, emmaID)
+	notOwnedID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Carcassonne", MediaType: "boardgame", Creator: "Klaus-Jürgen Wrede", PubDate: "2000"})
+
+	ten, eight, seven := int32(10), int32(8), int32(7)
+	ninety, negative := int32(90), int32(-5)
+
+	testMethod := "POST"
+	testEndpoint := ctx.BaseURL + "/api/plays"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersCreatePlay
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientPlay)
+	}{
+		{
+			name: "Valid, best score wins by default",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: parametersCreatePlay{MediumID: catanID, parametersPlayDetails: parametersPlayDetails{
+				PlayedAt:        "2025-01-10T20:00:00Z",
+				DurationMinutes: &ninety,
+				Location:        " Home ",
+				Expansions:      []string{"Seafarers", " ", "seafarers"},
+				Players: []parametersPlayer{
+					{Username: ctx.UserUsername, Score: &ten},
+					{Username: "Bob", Score: &eight},
+					{Name: "Alice", Score: &seven},
+				},
+			}},
+			expectedStatus: 201,
+			checkResponse: func(t *testing.T, cp ClientPlay) {
+				if cp.Title != "Catan" || cp.Location != "Home" || len(cp.Expansions) != 1 || cp.DurationMinutes == nil || *cp.DurationMinutes != 90 {
+					t.Errorf("Expected a 90 minutes play at Home with Seafarers, got %+v", cp)
+				}
+				if len(cp.Players) != 3 || !cp.Players[0].IsWinner || cp.Players[1].IsWinner || cp.Players[1].PlayerID == nil || cp.Players[2].PlayerID != nil {
+					t.Errorf("Expected test user to win against Bob and guest Alice, got %+v", cp.Players)
+				}
+			},
+		},
+		{
+			name: "Valid, winner given",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: parametersCreatePlay{MediumID: catanID, parametersPlayDetails: parametersPlayDetails{
+				Players: []parametersPlayer{{Username: ctx.UserUsername, Score: &ten}, {Name: "Alice", IsWinner: true}},
+			}},
+			expectedStatus: 201,
+			checkResponse: func(t *testing.T, cp ClientPlay) {
+				if len(cp.Players) != 2 || cp.Players[0].IsWinner || !cp.Players[1].IsWinner {
+					t.Errorf("Expected guest Alice to win, got %+v", cp.Players)
+				}
+			},
+		},
+		{
+			name: "Not a boardgame",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreatePlay{MediumID: emmaID},
+			expectedStatus: 400,
+		},
+		{
+			name: "Boardgame not on shelf",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreatePlay{MediumID: notOwnedID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Player without name",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreatePlay{MediumID: catanID, parametersPlayDetails: parametersPlayDetails{Players: []parametersPlayer{{Score: &seven}}}},
+			expectedStatus: 400,
+		},
+		{
+			name: "Unknown username",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreatePlay{MediumID: catanID, parametersPlayDetails: parametersPlayDetails{Players: []parametersPlayer{{Username: "Nobody"}}}},
+			expectedStatus: 404,
+		},
+		{
+			name: "Negative duration",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreatePlay{MediumID: catanID, parametersPlayDetails: parametersPlayDetails{DurationMinutes: &negative}},
+			expectedStatus: 400,
+		},
+		{
+			name: "Guest seated twice",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreatePlay{MediumID: catanID, parametersPlayDetails: parametersPlayDetails{Players: []parametersPlayer{{Name: "Alice"}, {Name: "alice"}}}},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersCreatePlay{MediumID: catanID},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientPlay
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestGetPlays(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	// Catan was played twice, Azul once
+	catanID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Catan", MediaType: "boardgame", Creator: "Klaus Teuber", PubDate: "1995"})
+	ctx.CreateTestRecord(t, catanID)
+	azulID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Azul", MediaType: "boardgame", Creator: "Michael Kiesling", PubDate: "2017"})
+	ctx.CreateTestRecord(t, azulID)
+	firstCatanID := ctx.CreateTestPlay(t, parametersCreatePlay{MediumID: catanID, parametersPlayDetails: parametersPlayDetails{PlayedAt: "2025-01-10T20:00:00Z"}})
+	secondCatanID := ctx.CreateTestPlay(t, parametersCreatePlay{MediumID: catanID, parametersPlayDetails: parametersPlayDetails{PlayedAt: "2025-02-10T20:00:00Z"}})
+	ctx.CreateTestPlay(t, parametersCreatePlay{MediumID: azulID, parametersPlayDetails: parametersPlayDetails{PlayedAt: "2025-03-01T20:00:00Z"}})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/plays"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersGetPlays
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientPlays)
+	}{
+		{
+			name: "Valid, every play",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cp ClientPlays) {
+				if len(cp.Plays) != 3 || cp.Plays[0].Title != "Azul" {
+					t.Errorf("Expected 3 plays, last one first, got %+v", cp.Plays)
+				}
+			},
+		},
+		{
+			name: "Valid, plays of a boardgame",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGetPlays{MediumID: catanID},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cp ClientPlays) {
+				if len(cp.Plays) != 2 || cp.Plays[0].ID != secondCatanID || cp.Plays[1].ID != firstCatanID {
+					t.Errorf("Expected 2 plays of Catan, last one first, got %+v", cp.Plays)
+				}
+			},
+		},
+		{
+			name: "Valid, other user",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cp ClientPlays) {
+				if len(cp.Plays) != 0 {
+					t.Errorf("Expected no play logged by Bob, got %+v", cp.Plays)
+				}
+			},
+		},
+		{
+			name: "Invalid medium_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGetPlays{MediumID: "1234"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientPlays
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestUpdatePlay(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	azulID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Azul", MediaType: "boardgame", Creator: "Michael Kiesling", PubDate: "2017"})
+	ctx.CreateTestRecord(t, azulID)
+	playID := ctx.CreateTestPlay(t, parametersCreatePlay{MediumID: azulID, parametersPlayDetails: parametersPlayDetails{
+		PlayedAt: "2025-03-01T20:00:00Z",
+		Players:  []parametersPlayer{{Username: "Bob", IsWinner: true}, {Username: ctx.UserUsername}},
+	}})
+
+	ten, fortyFive := int32(10), int32(45)
+	soloPlay := parametersPlayDetails{
+		PlayedAt:        "2025-03-01T20:00:00Z",
+		DurationMinutes: &fortyFive,
+		Players:         []parametersPlayer{{Username: ctx.UserUsername, Score: &ten}},
+	}
+
+	testMethod := "PUT"
+	testEndpoint := ctx.BaseURL + "/api/plays"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersUpdatePlay
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientPlay)
+	}{
+		{
+			name: "Other user's play",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersUpdatePlay{PlayID: playID, parametersPlayDetails: soloPlay},
+			expectedStatus: 404,
+		},
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdatePlay{PlayID: playID, parametersPlayDetails: soloPlay},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cp ClientPlay) {
+				if cp.Title != "Azul" || cp.DurationMinutes == nil || *cp.DurationMinutes != 45 || len(cp.Players) != 1 || !cp.Players[0].IsWinner {
+					t.Errorf("Expected a solo 45 minutes Azul won by test user, got %+v", cp)
+				}
+			},
+		},
+		{
+			name: "Unknown username",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdatePlay{PlayID: playID, parametersPlayDetails: parametersPlayDetails{Players: []parametersPlayer{{Username: "Nobody"}}}},
+			expectedStatus: 404,
+		},
+		{
+			name: "Invalid play_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdatePlay{PlayID: "1234", parametersPlayDetails: soloPlay},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersUpdatePlay{PlayID: playID, parametersPlayDetails: soloPlay},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientPlay
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestDeletePlay(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	azulID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Azul", MediaType: "boardgame", Creator: "Michael Kiesling", PubDate: "2017"})
+	ctx.CreateTestRecord(t, azulID)
+	playID := ctx.CreateTestPlay(t, parametersCreatePlay{MediumID: azulID})
+
+	testMethod := "DELETE"
+	testEndpoint := ctx.BaseURL + "/api/plays"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersPlay
+		expectedStatus int
+	}{
+		{
+			name: "Other user's play",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersPlay{PlayID: playID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersPlay{PlayID: playID},
+			expectedStatus: 200,
+		},
+		{
+			name: "Wrong play ID (already deleted)",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersPlay{PlayID: playID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Invalid play_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersPlay{PlayID: "1234"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersPlay{PlayID: playID},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestGetPlayCounts(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+
+	// Catan was played twice, once for 90 minutes, Azul once
+	catanID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Catan", MediaType: "boardgame", Creator: "Klaus Teuber", PubDate: "1995"})
+	ctx.CreateTestRecord(t, catanID)
+	azulID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Azul", MediaType: "boardgame", Creator: "Michael Kiesling", PubDate: "2017"})
+	ctx.CreateTestRecord(t, azulID)
+	ninety := int32(90)
+	ctx.CreateTestPlay(t, parametersCreatePlay{MediumID: catanID, parametersPlayDetails: parametersPlayDetails{DurationMinutes: &ninety}})
+	ctx.CreateTestPlay(t, parametersCreatePlay{MediumID: catanID})
+	ctx.CreateTestPlay(t, parametersCreatePlay{MediumID: azulID})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/plays/counts"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientPlayCounts)
+	}{
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cp ClientPlayCounts) {
+				if cp.PlaysCount != 3 || cp.TotalMinutes != 90 || len(cp.Games) != 2 || cp.Games[0].MediumID != catanID || cp.Games[0].PlaysCount != 2 {
+					t.Errorf("Expected Catan played twice then Azul once, got %+v", cp)
+				}
+			},
+		},
+		{
+			name:           "No access_token",
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest(testMethod, testEndpoint, nil)
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientPlayCounts
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestGetPlayerWinRates(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	ctx.CreateOtherTestUser(t, "Bob")
+
+	// Test user won the first Catan, guest Alice the second one and Bob the only Azul
+	catanID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Catan", MediaType: "boardgame", Creator: "Klaus Teuber", PubDate: "1995"})
+	ctx.CreateTestRecord(t, catanID)
+	azulID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Azul", MediaType: "boardgame", Creator: "Michael Kiesling", PubDate: "2017"})
+	ctx.CreateTestRecord(t, azulID)
+	ten, eight, seven := int32(10), int32(8), int32(7)
+	ctx.CreateTestPlay(t, parametersCreatePlay{MediumID: catanID, parametersPlayDetails: parametersPlayDetails{
+		Players: []parametersPlayer{{Username: ctx.UserUsername, Score: &ten}, {Username: "Bob", Score: &eight}, {Name: "Alice", Score: &seven}},
+	}})
+	ctx.CreateTestPlay(t, parametersCreatePlay{MediumID: catanID, parametersPlayDetails: parametersPlayDetails{
+		Players: []parametersPlayer{{Username: ctx.UserUsername, Score: &ten}, {Name: "Alice", IsWinner: true}},
+	}})
+	ctx.CreateTestPlay(t, parametersCreatePlay{MediumID: azulID, parametersPlayDetails: parametersPlayDetails{
+		Players: []parametersPlayer{{Username: "Bob", IsWinner: true}, {Username: ctx.UserUsername, Score: &ten}},
+	}})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/plays/win_rates"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersGetPlays
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientPlayerWinRates)
+	}{
+		{
+			name: "Valid, every boardgame",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cp ClientPlayerWinRates) {
+				if len(cp.Players) != 3 || cp.Players[0].PlayerName != "Alice" || cp.Players[1].PlayerName != "Bob" || cp.Players[2].WinsCount != 1 || cp.Players[2].PlaysCount != 3 {
+					t.Errorf("Expected Alice and Bob winning half their plays before test user, got %+v", cp.Players)
+				}
+			},
+		},
+		{
+			name: "Valid, one boardgame",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGetPlays{MediumID: azulID},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cp ClientPlayerWinRates) {
+				if cp.PlaysCount != 1 || len(cp.Players) != 2 || cp.Players[0].PlayerName != "Bob" || cp.Players[0].WinRate != 1 {
+					t.Errorf("Expected Bob winning every Azul play, got %+v", cp)
+				}
+			},
+		},
+		{
+			name: "Invalid medium_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGetPlays{MediumID: "1234"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientPlayerWinRates
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestGetPlaysHIndex(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	// Catan was played twice and Azul once, one more Azul play reaches an H-index of 2
+	catanID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Catan", MediaType: "boardgame", Creator: "Klaus Teuber", PubDate: "1995"})
+	ctx.CreateTestRecord(t, catanID)
+	azulID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Azul", MediaType: "boardgame", Creator: "Michael Kiesling", PubDate: "2017"})
+	ctx.CreateTestRecord(t, azulID)
+	ctx.CreateTestPlay(t, parametersCreatePlay{MediumID: catanID})
+	ctx.CreateTestPlay(t, parametersCreatePlay{MediumID: catanID})
+	ctx.CreateTestPlay(t, parametersCreatePlay{MediumID: azulID})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/plays/h_index"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientPlaysHIndex)
+	}{
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cp ClientPlaysHIndex) {
+				if cp.HIndex != 1 || cp.PlaysToNext != 1 || cp.GamesCount != 2 || cp.PlaysCount != 3 {
+					t.Errorf("Expected H-index 1, one Azul play away from 2, got %+v", cp)
+				}
+			},
+		},
+		{
+			name: "Valid, no play",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cp ClientPlaysHIndex) {
+				if cp.HIndex != 0 || cp.PlaysToNext != 1 || cp.GamesCount != 0 {
+					t.Errorf("Expected H-index 0, one play away from 1, got %+v", cp)
+				}
+			},
+		},
+		{
+			name:           "No access_token",
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest(testMethod, testEndpoint, nil)
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientPlaysHIndex
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestGetRecordVideogame(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	// Zelda has details, Hades none yet
+	zeldaID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Zelda", MediaType: "videogame", Creator: "Nintendo", PubDate: "2017", Metadata: map[string]interface{}{"platforms": []string{"Nintendo Switch", "Wii U"}}})
+	zeldaRecordID := ctx.CreateTestRecord(t, zeldaID)
+	hadesID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Hades", MediaType: "videogame", Creator: "Supergiant Games", PubDate: "2020", Metadata: map[string]interface{}{"platforms": []string{"PC", "Nintendo Switch"}}})
+	hadesRecordID := ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: hadesID, Status: "planned"})
+	emmaRecordID := ctx.CreateTestRecord(t, ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Emma", MediaType: "book", Creator: "Jane Austen", PubDate: "1815"}))
+	hours := 120.5
+	ctx.UpdateTestRecordVideogame(t, parametersRecordVideogame{RecordID: zeldaRecordID, Platform: "Nintendo Switch", HoursPlayed: &hours, Completion: "main_story"})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/records/videogame"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersGetRecordVideogame
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientRecordVideogame)
+	}{
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGetRecordVideogame{RecordID: zeldaRecordID},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cr ClientRecordVideogame) {
+				if cr.Platform != "Nintendo Switch" || cr.HoursPlayed == nil || *cr.HoursPlayed != 120.5 || cr.Completion != "main_story" {
+					t.Errorf("Expected Zelda's details, got %+v", cr)
+				}
+			},
+		},
+		{
+			name: "Valid, no details yet",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGetRecordVideogame{RecordID: hadesRecordID},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cr ClientRecordVideogame) {
+				if cr.RecordID != hadesRecordID || cr.Platform != "" || cr.HoursPlayed != nil || cr.Completion != "" {
+					t.Errorf("Expected empty details, got %+v", cr)
+				}
+			},
+		},
+		{
+			name: "Not a videogame",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGetRecordVideogame{RecordID: emmaRecordID},
+			expectedStatus: 400,
+		},
+		{
+			name: "Other user's record",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersGetRecordVideogame{RecordID: zeldaRecordID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Invalid record_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGetRecordVideogame{RecordID: "1234"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersGetRecordVideogame{RecordID: zeldaRecordID},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientRecordVideogame
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestUpdateRecordVideogame(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	zeldaID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Zelda", MediaType: "videogame", Creator: "Nintendo", PubDate: "2017", Metadata: map[string]interface{}{"platforms": []string{"Nintendo Switch", "Wii U"}}})
+	zeldaRecordID := ctx.CreateTestRecord(t, zeldaID)
+	hadesID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Hades", MediaType: "videogame", Creator: "Supergiant Games", PubDate: "2020", Metadata: map[string]interface{}{"platforms": []string{"PC", "Nintendo Switch"}}})
+	hadesRecordID := ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: hadesID, Status: "planned"})
+	emmaRecordID := ctx.CreateTestRecord(t, ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Emma", MediaType: "book", Creator: "Jane Austen", PubDate: "1815"}))
+
+	hours, tenHours, negativeHours := 120.5, 10.0, -1.0
+	fifty, ten, twelve := int32(50), int32(10), int32(12)
+
+	testMethod := "PUT"
+	testEndpoint := ctx.BaseURL + "/api/records/videogame"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersRecordVideogame
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientRecordVideogame)
+	}{
+		{
+			name: "Valid, platform matched to medium's ones",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: parametersRecordVideogame{
+				RecordID:           zeldaRecordID,
+				Platform:           " nintendo switch ",
+				HoursPlayed:        &hours,
+				Completion:         "completionist",
+				AchievementsEarned: &fifty,
+				AchievementsTotal:  &fifty,
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cr ClientRecordVideogame) {
+				if cr.Platform != "Nintendo Switch" || cr.Completion != "completionist" || cr.AchievementsEarned == nil || *cr.AchievementsEarned != 50 {
+					t.Errorf("Expected Zelda completed on Nintendo Switch, got %+v", cr)
+				}
+			},
+		},
+		{
+			name: "Valid, other platform",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersRecordVideogame{RecordID: hadesRecordID, Platform: "pc", HoursPlayed: &tenHours},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cr ClientRecordVideogame) {
+				if cr.Platform != "PC" || cr.HoursPlayed == nil || *cr.HoursPlayed != 10 {
+					t.Errorf("Expected 10 hours of Hades on PC, got %+v", cr)
+				}
+			},
+		},
+		{
+			name: "Not a videogame",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersRecordVideogame{RecordID: emmaRecordID, Platform: "PC"},
+			expectedStatus: 400,
+		},
+		{
+			name: "Negative hours",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersRecordVideogame{RecordID: zeldaRecordID, HoursPlayed: &negativeHours},
+			expectedStatus: 400,
+		},
+		{
+			name: "Unknown completion",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersRecordVideogame{RecordID: zeldaRecordID, Completion: "all"},
+			expectedStatus: 400,
+		},
+		{
+			name: "More achievements than total",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersRecordVideogame{RecordID: zeldaRecordID, AchievementsEarned: &twelve, AchievementsTotal: &ten},
+			expectedStatus: 400,
+		},
+		{
+			name: "Other user's record",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersRecordVideogame{RecordID: zeldaRecordID, Platform: "PC"},
+			expectedStatus: 404,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersRecordVideogame{RecordID: zeldaRecordID, Platform: "PC"},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientRecordVideogame
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestGetPlatformStats(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+
+	// Zelda finished on Switch, Hades and Celeste still to play on PC, Portal's record deleted with its details
+	zeldaID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Zelda", MediaType: "videogame", Creator: "Nintendo", PubDate: "2017", Metadata: map[string]interface{}{"platforms": []string{"Nintendo Switch", "Wii U"}}})
+	zeldaRecordID := ctx.CreateTestRecord(t, zeldaID)
+	hadesID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Hades", MediaType: "videogame", Creator: "Supergiant Games", PubDate: "2020", Metadata: map[string]interface{}{"platforms": []string{"PC", "Nintendo Switch"}}})
+	hadesRecordID := ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: hadesID, Status: "planned"})
+	celesteID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Celeste", MediaType: "videogame", Creator: "Maddy Makes Games", PubDate: "2018", Metadata: map[string]interface{}{"platforms": []string{"PC"}}})
+	ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: celesteID, Status: "planned"})
+	portalID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Portal", MediaType: "videogame", Creator: "Valve", PubDate: "2007", Metadata: map[string]interface{}{"platforms": []string{"PC"}}})
+	portalRecordID := ctx.CreateTestRecord(t, portalID)
+
+	zeldaHours, hadesHours, portalHours := 120.5, 10.0, 5.0
+	fifty := int32(50)
+	ctx.UpdateTestRecordVideogame(t, parametersRecordVideogame{RecordID: zeldaRecordID, Platform: "Nintendo Switch", HoursPlayed: &zeldaHours, Completion: "completionist", AchievementsEarned: &fifty, AchievementsTotal: &fifty})
+	ctx.UpdateTestRecordVideogame(t, parametersRecordVideogame{RecordID: hadesRecordID, Platform: "PC", HoursPlayed: &hadesHours})
+	ctx.UpdateTestRecordVideogame(t, parametersRecordVideogame{RecordID: portalRecordID, Platform: "PC", HoursPlayed: &portalHours})
+	ctx.DeleteTestRecord(t, portalRecordID)
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/videogames/platforms"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientPlatforms)
+	}{
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cp ClientPlatforms) {
+				if len(cp.Platforms) != 2 {
+					t.Fatalf("Expected Nintendo Switch and PC, got %+v", cp.Platforms)
+				}
+				switchStats, pcStats := cp.Platforms[0], cp.Platforms[1]
+				if switchStats.Platform != "Nintendo Switch" || switchStats.FinishedCount != 1 || switchStats.HoursPlayed != 120.5 || switchStats.Completions["completionist"] != 1 || switchStats.AchievementsEarned != 50 {
+					t.Errorf("Expected Zelda completed on Switch, got %+v", switchStats)
+				}
+				if pcStats.Platform != "PC" || pcStats.GamesCount != 2 || pcStats.BacklogCount != 2 || pcStats.HoursPlayed != 10 || pcStats.Completions["main_story"] != 0 {
+					t.Errorf("Expected Hades and Celeste to play on PC, got %+v", pcStats)
+				}
+			},
+		},
+		{
+			name:           "No access_token",
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest(testMethod, testEndpoint, nil)
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientPlatforms
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestGetVideogameBacklog(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+
+	// Zelda finished on Switch, Hades and Celeste still to play on PC
+	zeldaID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Zelda", MediaType: "videogame", Creator: "Nintendo", PubDate: "2017", Metadata: map[string]interface{}{"platforms": []string{"Nintendo Switch", "Wii U"}}})
+	zeldaRecordID := ctx.CreateTestRecord(t, zeldaID)
+	hadesID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Hades", MediaType: "videogame", Creator: "Supergiant Games", PubDate: "2020", Metadata: map[string]interface{}{"platforms": []string{"PC", "Nintendo Switch"}}})
+	hadesRecordID := ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: hadesID, Status: "planned"})
+	celesteID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Celeste", MediaType: "videogame", Creator: "Maddy Makes Games", PubDate: "2018", Metadata: map[string]interface{}{"platforms": []string{"PC"}}})
+	ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: celesteID, Status: "planned"})
+	ctx.UpdateTestRecordVideogame(t, parametersRecordVideogame{RecordID: zeldaRecordID, Platform: "Nintendo Switch"})
+	ctx.UpdateTestRecordVideogame(t, parametersRecordVideogame{RecordID: hadesRecordID, Platform: "PC"})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/videogames/backlog"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersGetVideogameBacklog
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientVideogameBacklog)
+	}{
+		{
+			name: "Valid, every platform",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cv ClientVideogameBacklog) {
+				if len(cv.Platforms) != 1 || cv.Platforms[0].Platform != "PC" || len(cv.Platforms[0].Games) != 2 || cv.Platforms[0].Games[0].Title != "Celeste" {
+					t.Errorf("Expected Celeste then Hades to play on PC, got %+v", cv.Platforms)
+				}
+			},
+		},
+		{
+			name: "Valid, one platform",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGetVideogameBacklog{Platform: "nintendo switch"},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cv ClientVideogameBacklog) {
+				if len(cv.Platforms) != 0 {
+					t.Errorf("Expected nothing left to play on Switch, got %+v", cv.Platforms)
+				}
+			},
+		},
+		{
+			name:           "No access_token",
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientVideogameBacklog
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestSearchMediaRecordsByVideogame(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+
+	// Zelda completed on Switch, Hades played on PC, Celeste without details
+	zeldaID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Zelda", MediaType: "videogame", Creator: "Nintendo", PubDate: "2017", Metadata: map[string]interface{}{"platforms": []string{"Nintendo Switch", "Wii U"}}})
+	zeldaRecordID := ctx.CreateTestRecord(t, zeldaID)
+	hadesID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Hades", MediaType: "videogame", Creator: "Supergiant Games", PubDate: "2020", Metadata: map[string]interface{}{"platforms": []string{"PC", "Nintendo Switch"}}})
+	hadesRecordID := ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: hadesID, Status: "planned"})
+	ctx.CreateTestRecord(t, ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Celeste", MediaType: "videogame", Creator: "Maddy Makes Games", PubDate: "2018", Metadata: map[string]interface{}{"platforms": []string{"PC"}}}))
+	ctx.UpdateTestRecordVideogame(t, parametersRecordVideogame{RecordID: zeldaRecordID, Platform: "Nintendo Switch", Completion: "completionist"})
+	ctx.UpdateTestRecordVideogame(t, parametersRecordVideogame{RecordID: hadesRecordID, Platform: "PC"})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/media_records/search"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersSearchMediaRecords
+		expectedStatus int
+		expectedTitles []string
+	}{
+		{
+			name: "By platform",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersSearchMediaRecords{Platform: "PC"},
+			expectedStatus: 200,
+			expectedTitles: []string{"Hades"},
+		},
+		{
+			name: "By completion",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersSearchMediaRecords{Completion: "completionist"},
+			expectedStatus: 200,
+			expectedTitles: []string{"Zelda"},
+		},
+		{
+			name: "Platform and completion",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersSearchMediaRecords{Platform: "PC", Completion: "completionist"},
+			expectedStatus: 200,
+			expectedTitles: []string{},
+		},
+		{
+			name: "Unknown completion",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersSearchMediaRecords{Completion: "all"},
+			expectedStatus: 400,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Fatalf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.expectedTitles != nil {
+				var responseBody ClientSearchMediaRecords
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				titles := []string{}
+				for _, record := range responseBody.Records {
+					titles = append(titles, record.Title)
+				}
+				if !reflect.DeepEqual(titles, tc.expectedTitles) {
+					t.Errorf("Expected records %v, got %v", tc.expectedTitles, titles)
+				}
+			}
+		})
+	}
+}
+
+func TestMarkEpisodes(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	// Dark has two seasons of two episodes, Emma isn't a series
+	darkID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Dark", MediaType: "series", Creator: "Baran bo Odar", PubDate: "2017", Metadata: map[string]interface{}{"number_of_episodes_per_season": []string{"Season 1 counts 2 episodes", "Season 2 counts 2 episodes"}}})
+	darkRecordID := ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: darkID, Status: "planned"})
+	emmaRecordID := ctx.CreateTestRecord(t, ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Emma", MediaType: "book", Creator: "Jane Austen", PubDate: "1815"}))
+	rating := 4.5
+
+	testMethod := "PUT"
+	testEndpoint := ctx.BaseURL + "/api/records/episodes"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersMarkEpisodes
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientRecordEpisodes)
+	}{
+		{
+			name: "Valid, first season starts record",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: parametersMarkEpisodes{
+				RecordID: darkRecordID,
+				Episodes: []parametersEpisode{{SeasonNumber: 1, EpisodeNumber: 1}, {SeasonNumber: 1, EpisodeNumber: 2}},
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cr ClientRecordEpisodes) {
+				if cr.Status != "in_progress" || cr.WatchedCount != 2 {
+					t.Errorf("Expected record in progress with 2 episodes watched, got %+v", cr)
+				}
+				if cr.NextEpisode == nil || cr.NextEpisode.SeasonNumber != 2 || cr.NextEpisode.EpisodeNumber != 1 {
+					t.Errorf("Expected S2E1 to watch next, got %+v", cr.NextEpisode)
+				}
+			},
+		},
+		{
+			name: "Valid, last episodes finish record",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: parametersMarkEpisodes{
+				RecordID:    darkRecordID,
+				Episodes:    []parametersEpisode{{SeasonNumber: 2, EpisodeNumber: 1}, {SeasonNumber: 2, EpisodeNumber: 2}},
+				Rating:      &rating,
+				RatingScale: "5",
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cr ClientRecordEpisodes) {
+				if cr.Status != "finished" || cr.WatchedCount != 4 || cr.NextEpisode != nil {
+					t.Errorf("Expected record finished with nothing left to watch, got %+v", cr)
+				}
+				if len(cr.Episodes) != 4 || cr.Episodes[2].Rating == nil || *cr.Episodes[2].Rating != 90 {
+					t.Errorf("Expected S2E1 rated 90, got %+v", cr.Episodes)
+				}
+			},
+		},
+		{
+			name: "Not a series",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersMarkEpisodes{RecordID: emmaRecordID, Episodes: []parametersEpisode{{SeasonNumber: 1, EpisodeNumber: 1}}},
+			expectedStatus: 400,
+		},
+		{
+			name: "No episode",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersMarkEpisodes{RecordID: darkRecordID, Episodes: []parametersEpisode{}},
+			expectedStatus: 400,
+		},
+		{
+			name: "Unknown season",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersMarkEpisodes{RecordID: darkRecordID, Episodes: []parametersEpisode{{SeasonNumber: 3, EpisodeNumber: 1}}},
+			expectedStatus: 400,
+		},
+		{
+			name: "Unknown episode",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersMarkEpisodes{RecordID: darkRecordID, Episodes: []parametersEpisode{{SeasonNumber: 1, EpisodeNumber: 3}}},
+			expectedStatus: 400,
+		},
+		{
+			name: "Watched in the future",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersMarkEpisodes{RecordID: darkRecordID, Episodes: []parametersEpisode{{SeasonNumber: 1, EpisodeNumber: 1}}, WatchedAt: "2999-01-01T00:00:00Z"},
+			expectedStatus: 400,
+		},
+		{
+			name: "Other user's record",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersMarkEpisodes{RecordID: darkRecordID, Episodes: []parametersEpisode{{SeasonNumber: 1, EpisodeNumber: 1}}},
+			expectedStatus: 404,
+		},
+		{
+			name: "Invalid record_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersMarkEpisodes{RecordID: "1234", Episodes: []parametersEpisode{{SeasonNumber: 1, EpisodeNumber: 1}}},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersMarkEpisodes{RecordID: darkRecordID, Episodes: []parametersEpisode{{SeasonNumber: 1, EpisodeNumber: 1}}},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientRecordEpisodes
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestUnmarkEpisodes(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	// Dark fully watched, Emma isn't a series
+	darkID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Dark", MediaType: "series", Creator: "Baran bo Odar", PubDate: "2017", Metadata: map[string]interface{}{"number_of_episodes_per_season": []string{"Season 1 counts 2 episodes", "Season 2 counts 2 episodes"}}})
+	darkRecordID := ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: darkID, Status: "planned"})
+	emmaRecordID := ctx.CreateTestRecord(t, ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Emma", MediaType: "book", Creator: "Jane Austen", PubDate: "1815"}))
+	ctx.MarkTestEpisodes(t, parametersMarkEpisodes{RecordID: darkRecordID, Episodes: []parametersEpisode{{SeasonNumber: 1, EpisodeNumber: 1}, {SeasonNumber: 1, EpisodeNumber: 2}}})
+	ctx.MarkTestEpisodes(t, parametersMarkEpisodes{RecordID: darkRecordID, Episodes: []parametersEpisode{{SeasonNumber: 2, EpisodeNumber: 1}, {SeasonNumber: 2, EpisodeNumber: 2}}})
+
+	testMethod := "DELETE"
+	testEndpoint := ctx.BaseURL + "/api/records/episodes"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersUnmarkEpisodes
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientRecordEpisodes)
+	}{
+		{
+			name: "Valid, unwatching reopens record",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUnmarkEpisodes{RecordID: darkRecordID, Episodes: []parametersEpisode{{SeasonNumber: 2, EpisodeNumber: 2}}},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cr ClientRecordEpisodes) {
+				if cr.Status != "in_progress" || cr.WatchedCount != 3 {
+					t.Errorf("Expected record back in progress with 3 episodes watched, got %+v", cr)
+				}
+				if cr.NextEpisode == nil || cr.NextEpisode.SeasonNumber != 2 || cr.NextEpisode.EpisodeNumber != 2 {
+					t.Errorf("Expected S2E2 to watch next, got %+v", cr.NextEpisode)
+				}
+			},
+		},
+		{
+			name: "Valid, episode not watched",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUnmarkEpisodes{RecordID: darkRecordID, Episodes: []parametersEpisode{{SeasonNumber: 2, EpisodeNumber: 2}}},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cr ClientRecordEpisodes) {
+				if cr.WatchedCount != 3 {
+					t.Errorf("Expected 3 episodes still watched, got %d", cr.WatchedCount)
+				}
+			},
+		},
+		{
+			name: "Not a series",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUnmarkEpisodes{RecordID: emmaRecordID, Episodes: []parametersEpisode{{SeasonNumber: 1, EpisodeNumber: 1}}},
+			expectedStatus: 400,
+		},
+		{
+			name: "No episode",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUnmarkEpisodes{RecordID: darkRecordID, Episodes: []parametersEpisode{}},
+			expectedStatus: 400,
+		},
+		{
+			name: "Other user's record",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersUnmarkEpisodes{RecordID: darkRecordID, Episodes: []parametersEpisode{{SeasonNumber: 1, EpisodeNumber: 1}}},
+			expectedStatus: 404,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersUnmarkEpisodes{RecordID: darkRecordID, Episodes: []parametersEpisode{{SeasonNumber: 1, EpisodeNumber: 1}}},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientRecordEpisodes
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestGetRecordEpisodes(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	// Dark not watched yet, Emma isn't a series
+	darkID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Dark", MediaType: "series", Creator: "Baran bo Odar", PubDate: "2017", Metadata: map[string]interface{}{"number_of_episodes_per_season": []string{"Season 1 counts 2 episodes", "Season 2 counts 2 episodes"}}})
+	darkRecordID := ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: darkID, Status: "planned"})
+	emmaRecordID := ctx.CreateTestRecord(t, ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Emma", MediaType: "book", Creator: "Jane Austen", PubDate: "1815"}))
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/records/episodes"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersGetRecordEpisodes
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientRecordEpisodes)
+	}{
+		{
+			name: "Valid, not watched yet",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGetRecordEpisodes{RecordID: darkRecordID},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cr ClientRecordEpisodes) {
+				if cr.Status != "planned" || cr.WatchedCount != 0 || cr.TotalEpisodes == nil || *cr.TotalEpisodes != 4 || len(cr.Seasons) != 2 {
+					t.Errorf("Expected 4 episodes in 2 seasons to watch, got %+v", cr)
+				}
+				if cr.NextEpisode == nil || cr.NextEpisode.SeasonNumber != 1 || cr.NextEpisode.EpisodeNumber != 1 {
+					t.Errorf("Expected S1E1 to watch next, got %+v", cr.NextEpisode)
+				}
+			},
+		},
+		{
+			name: "Not a series",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGetRecordEpisodes{RecordID: emmaRecordID},
+			expectedStatus: 400,
+		},
+		{
+			name: "Other user's record",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersGetRecordEpisodes{RecordID: darkRecordID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Invalid record_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGetRecordEpisodes{RecordID: "1234"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersGetRecordEpisodes{RecordID: darkRecordID},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientRecordEpisodes
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestGetNextEpisodes(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	// Dark watched up to S2E1
+	darkID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Dark", MediaType: "series", Creator: "Baran bo Odar", PubDate: "2017", Metadata: map[string]interface{}{"number_of_episodes_per_season": []string{"Season 1 counts 2 episodes", "Season 2 counts 2 episodes"}}})
+	darkRecordID := ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: darkID, Status: "planned"})
+	ctx.MarkTestEpisodes(t, parametersMarkEpisodes{
+		RecordID: darkRecordID,
+		Episodes: []parametersEpisode{{SeasonNumber: 1, EpisodeNumber: 1}, {SeasonNumber: 1, EpisodeNumber: 2}, {SeasonNumber: 2, EpisodeNumber: 1}},
+	})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/records/episodes/next"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientNextEpisodes)
+	}{
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cn ClientNextEpisodes) {
+				if len(cn.NextEpisodes) != 1 || cn.NextEpisodes[0].Title != "Dark" || cn.NextEpisodes[0].SeasonNumber != 2 || cn.NextEpisodes[0].EpisodeNumber != 2 {
+					t.Errorf("Expected S2E2 of Dark, got %+v", cn.NextEpisodes)
+				}
+			},
+		},
+		{
+			name: "Valid, other user",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cn ClientNextEpisodes) {
+				if len(cn.NextEpisodes) != 0 {
+					t.Errorf("Expected nothing for other user, got %+v", cn.NextEpisodes)
+				}
+			},
+		},
+		{
+			name:           "No access_token",
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest(testMethod, testEndpoint, nil)
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientNextEpisodes
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestCreateGoal(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	// Two books and a movie finished in 2024, only Emma is tagged
+	emmaID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Emma", MediaType: "book", Creator: "Jane Austen", PubDate: "1815", Metadata: map[string]interface{}{"page_count": 400}})
+	duneID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Dune", MediaType: "book", Creator: "Frank Herbert", PubDate: "1965", Metadata: map[string]interface{}{"page_count": "600"}})
+	alienID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Alien", MediaType: "movie", Creator: "Ridley Scott", PubDate: "1979", Metadata: map[string]interface{}{"runtime": 117}})
+	ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: emmaID, StartDate: "2024-01-01T00:00:00Z", EndDate: "2024-01-11T00:00:00Z"})
+	ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: duneID, StartDate: "2024-03-01T00:00:00Z", EndDate: "2024-03-20T00:00:00Z"})
+	ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: alienID, StartDate: "2024-03-10T20:00:00Z", EndDate: "2024-03-10T22:00:00Z"})
+	classicsTagID := ctx.CreateTestTag(t, "Classics")
+	ctx.TagTestMedia(t, []string{classicsTagID}, []string{emmaID})
+	bobTagID := bob.CreateTestTag(t, "Classics")
+
+	testMethod := "POST"
+	testEndpoint := ctx.BaseURL + "/api/goals"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersGoalDetails
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientGoal)
+	}{
+		{
+			name: "Valid, books read in a year",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGoalDetails{Name: "Read more", MediaType: "Book", Target: 12, Date: "2024-06-01T00:00:00Z"},
+			expectedStatus: 201,
+			checkResponse: func(t *testing.T, cg ClientGoal) {
+				if cg.MediaType != "book" || cg.PeriodStart != "2024-01-01T00:00:00" || cg.PeriodEnd != "2025-01-01T00:00:00" {
+					t.Errorf("Expected books goal over 2024, got %+v", cg)
+				}
+				if cg.Progress != 2 || cg.Status != "missed" {
+					t.Errorf("Expected 2 books read and goal missed, got %v %s", cg.Progress, cg.Status)
+				}
+			},
+		},
+		{
+			name: "Valid, pages of tagged books",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGoalDetails{MediaType: "book", Metric: "pages", Target: 300, Period: "custom", From: "2024-01-01T00:00:00Z", To: "2025-01-01T00:00:00Z", TagIDs: []string{classicsTagID, classicsTagID}},
+			expectedStatus: 201,
+			checkResponse: func(t *testing.T, cg ClientGoal) {
+				if cg.Progress != 400 || cg.Status != "completed" {
+					t.Errorf("Expected Emma's 400 pages and goal completed, got %v %s", cg.Progress, cg.Status)
+				}
+				if len(cg.Tags) != 1 || cg.Tags[0].Name != "Classics" {
+					t.Errorf("Expected goal tagged Classics once, got %+v", cg.Tags)
+				}
+			},
+		},
+		{
+			name: "Valid, hours of movies in a month",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGoalDetails{MediaType: "movie", Metric: "hours", Target: 10, Period: "month", Date: "2024-03-01T00:00:00Z"},
+			expectedStatus: 201,
+			checkResponse: func(t *testing.T, cg ClientGoal) {
+				if cg.Progress != 1.95 || cg.Status != "missed" {
+					t.Errorf("Expected Alien's 1.95 hours and goal missed, got %v %s", cg.Progress, cg.Status)
+				}
+			},
+		},
+		{
+			name: "Valid, current year",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGoalDetails{MediaType: "book", Target: 5},
+			expectedStatus: 201,
+			checkResponse: func(t *testing.T, cg ClientGoal) {
+				if cg.Progress != 0 || cg.Status != "behind" {
+					t.Errorf("Expected nothing read yet and goal behind, got %v %s", cg.Progress, cg.Status)
+				}
+			},
+		},
+		{
+			name: "No media_type",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGoalDetails{Target: 5},
+			expectedStatus: 400,
+		},
+		{
+			name: "Pages of movies",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGoalDetails{MediaType: "movie", Metric: "pages", Target: 5},
+			expectedStatus: 400,
+		},
+		{
+			name: "No target",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGoalDetails{MediaType: "book"},
+			expectedStatus: 400,
+		},
+		{
+			name: "Unknown period",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGoalDetails{MediaType: "book", Target: 5, Period: "decade"},
+			expectedStatus: 400,
+		},
+		{
+			name: "Other user's tag",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGoalDetails{MediaType: "book", Target: 5, TagIDs: []string{bobTagID}},
+			expectedStatus: 404,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersGoalDetails{MediaType: "book", Target: 5},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientGoal
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestGetGoals(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	// A past goal on tagged books and one for the current year
+	emmaID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Emma", MediaType: "book", Creator: "Jane Austen", PubDate: "1815", Metadata: map[string]interface{}{"page_count": 400}})
+	ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: emmaID, StartDate: "2024-01-01T00:00:00Z", EndDate: "2024-01-11T00:00:00Z"})
+	classicsTagID := ctx.CreateTestTag(t, "Classics")
+	ctx.TagTestMedia(t, []string{classicsTagID}, []string{emmaID})
+	ctx.CreateTestGoal(t, parametersGoalDetails{MediaType: "book", Metric: "pages", Target: 300, Period: "custom", From: "2024-01-01T00:00:00Z", To: "2025-01-01T00:00:00Z", TagIDs: []string{classicsTagID}})
+	ctx.CreateTestGoal(t, parametersGoalDetails{MediaType: "book", Target: 5})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/goals"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersGetGoals
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientGoals)
+	}{
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cg ClientGoals) {
+				if len(cg.Goals) != 2 {
+					t.Fatalf("Expected 2 goals, got %d", len(cg.Goals))
+				}
+				for _, goal := range cg.Goals {
+					if goal.Metric == "pages" && (len(goal.Tags) != 1 || goal.Tags[0].Name != "Classics" || goal.RecordsCount != 1) {
+						t.Errorf("Expected pages goal tagged Classics counting Emma, got %+v", goal)
+					}
+				}
+			},
+		},
+		{
+			name: "Valid, current goals only",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGetGoals{CurrentOnly: true},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cg ClientGoals) {
+				if len(cg.Goals) != 1 || cg.Goals[0].Target != 5 {
+					t.Errorf("Expected current year goal only, got %+v", cg.Goals)
+				}
+			},
+		},
+		{
+			name: "Valid, other user",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cg ClientGoals) {
+				if len(cg.Goals) != 0 {
+					t.Errorf("Expected no goal for other user, got %+v", cg.Goals)
+				}
+			},
+		},
+		{
+			name:           "No access_token",
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientGoals
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestUpdateGoal(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	// Two books read in 2024, for a goal of 12
+	emmaID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Emma", MediaType: "book", Creator: "Jane Austen", PubDate: "1815"})
+	duneID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Dune", MediaType: "book", Creator: "Frank Herbert", PubDate: "1965"})
+	ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: emmaID, StartDate: "2024-01-01T00:00:00Z", EndDate: "2024-01-11T00:00:00Z"})
+	ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: duneID, StartDate: "2024-03-01T00:00:00Z", EndDate: "2024-03-20T00:00:00Z"})
+	goalID := ctx.CreateTestGoal(t, parametersGoalDetails{Name: "Read more", MediaType: "book", Target: 12, Date: "2024-06-01T00:00:00Z"})
+
+	testMethod := "PUT"
+	testEndpoint := ctx.BaseURL + "/api/goals"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersUpdateGoal
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientGoal)
+	}{
+		{
+			name: "Valid, lower target",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateGoal{GoalID: goalID, parametersGoalDetails: parametersGoalDetails{MediaType: "book", Target: 2, Date: "2024-06-01T00:00:00Z"}},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cg ClientGoal) {
+				if cg.Status != "completed" || cg.Percent != 100 || cg.Name != "" {
+					t.Errorf("Expected completed goal with details replaced, got %+v", cg)
+				}
+			},
+		},
+		{
+			name: "Invalid details",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateGoal{GoalID: goalID, parametersGoalDetails: parametersGoalDetails{MediaType: "book", Target: -1}},
+			expectedStatus: 400,
+		},
+		{
+			name: "Other user's goal",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersUpdateGoal{GoalID: goalID, parametersGoalDetails: parametersGoalDetails{MediaType: "book", Target: 1}},
+			expectedStatus: 404,
+		},
+		{
+			name: "Invalid goal_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateGoal{GoalID: "1234", parametersGoalDetails: parametersGoalDetails{MediaType: "book", Target: 1}},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersUpdateGoal{GoalID: goalID, parametersGoalDetails: parametersGoalDetails{MediaType: "book", Target: 1}},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientGoal
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestDeleteGoal(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	goalID := ctx.CreateTestGoal(t, parametersGoalDetails{MediaType: "book", Target: 5})
+
+	testMethod := "DELETE"
+	testEndpoint := ctx.BaseURL + "/api/goals"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersGoal
+		expectedStatus int
+	}{
+		{
+			name: "Other user's goal",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersGoal{GoalID: goalID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGoal{GoalID: goalID},
+			expectedStatus: 200,
+		},
+		{
+			name: "Already deleted",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGoal{GoalID: goalID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Invalid goal_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGoal{GoalID: "1234"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersGoal{GoalID: goalID},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestGetStreak(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+
+	// A movie watched in 2024, Dune started today
+	alienID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Alien", MediaType: "movie", Creator: "Ridley Scott", PubDate: "1979"})
+	duneID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Dune", MediaType: "book", Creator: "Frank Herbert", PubDate: "1965"})
+	ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: alienID, StartDate: "2024-03-10T20:00:00Z", EndDate: "2024-03-10T22:00:00Z"})
+	today := time.Now().UTC().Truncate(24 * time.Hour).Format(time.RFC3339)
+	ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: duneID, StartDate: today})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/goals/streak"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersGetStreak
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientStreak)
+	}{
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientStreak) {
+				if cs.Unit != "day" || cs.Current != 1 || !cs.ActiveNow || cs.Longest != 1 {
+					t.Errorf("Expected a 1 day streak running today, got %+v", cs)
+				}
+			},
+		},
+		{
+			name: "Valid, one media type",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGetStreak{MediaType: "movie"},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientStreak) {
+				if cs.Current != 0 || cs.Longest != 1 {
+					t.Errorf("Expected a past movie streak only, got %+v", cs)
+				}
+			},
+		},
+		{
+			name: "Unknown unit",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersGetStreak{Unit: "month"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientStreak
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestGetRecordsAndMediaByUserID(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+
+	// A book read twice in 2024 with a third read planned, and a movie never started
+	alphaID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Alpha", MediaType: "book", Creator: "Jane Austen", PubDate: "1815"})
+	bravoID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Bravo", MediaType: "movie", Creator: "Ridley Scott", PubDate: "1979"})
+	secondReadID := ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{
+		MediumID:  alphaID,
+		StartDate: "2024-03-01T00:00:00Z",
+		EndDate:   "2024-03-05T00:00:00Z",
+		Comments:  "Even better the second time",
+	})
+	firstReadID := ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{
+		MediumID:  alphaID,
+		StartDate: "2024-01-01T00:00:00Z",
+		EndDate:   "2024-01-11T00:00:00Z",
+	})
+	plannedReadID := ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{
+		MediumID: alphaID,
+	})
+	ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{
+		MediumID: bravoID,
+	})
+
+	// Send a GET request with given body and decode the response
+	get := func(t *testing.T, endpoint string, body map[string]interface{}, v any) {
+		requestBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("GET", ctx.BaseURL+endpoint, bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.UserAcessToken))
+		resp, err := ctx.Client.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != 200 {
+			t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+		}
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+	}
+
+	t.Run("Consumptions grouped per medium", func(t *testing.T) {
+		var responseBody ClientMediaRecords
+		get(t, "/api/media_records", map[string]interface{}{}, &responseBody)
+
+		books := responseBody.Records["book"]
+		if len(books) != 1 || len(responseBody.Records["movie"]) != 1 {
+			t.Fatalf("Expected one book and one movie, got %+v", responseBody.Records)
+		}
+		alpha := books[0]
+		if alpha.MediaID != alphaID || alpha.ConsumptionCount != 3 || len(alpha.History) != 3 {
+			t.Fatalf("Expected 3 consumptions of Alpha, got %+v", alpha)
+		}
+		// Oldest first, the planned read comes last and is the current one
+		gotOrder := []string{alpha.History[0].ID, alpha.History[1].ID, alpha.History[2].ID}
+		if fmt.Sprint(gotOrder) != fmt.Sprint([]string{firstReadID, secondReadID, plannedReadID}) {
+			t.Errorf("Unexpected history order %v", gotOrder)
+		}
+		if alpha.ID != plannedReadID || alpha.StartDate != "" {
+			t.Errorf("Expected top-level record to be the planned read, got %+v", alpha)
+		}
+		if alpha.History[1].Comments != "Even better the second time" || alpha.History[1].Duration != 4 {
+			t.Errorf("Unexpected second read %+v", alpha.History[1])
+		}
+	})
+
+	t.Run("Each completion counted in stats", func(t *testing.T) {
+		var stats ClientStats
+		get(t, "/api/stats", map[string]interface{}{"period": "year", "date": "2024-06-01T00:00:00Z"}, &stats)
+		if stats.Totals.Started != 2 || stats.Totals.Finished != 2 {
+			t.Errorf("Expected Alpha started and finished twice, got %+v", stats.Totals)
+		}
+	})
+}
+
+func TestSearchMediaRecords(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+
+	// A finished book, an in progress boardgame and an unstarted boardgame
+	alphaID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{
+		Title:     "Alpha",
+		MediaType: "book",
+		Creator:   "Jane Austen",
+		PubDate:   "1815",
+		Metadata:  map[string]interface{}{"genres": []string{"Romance", "Classic"}, "pages": 300},
+	})
+	bravoID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{
+		Title:     "Bravo",
+		MediaType: "boardgame",
+		Creator:   "Reiner Knizia",
+		PubDate:   "2000",
+		Metadata:  map[string]interface{}{"min_players": "2"},
+	})
+	charlieID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{
+		Title:     "Charlie",
+		MediaType: "boardgame",
+		Creator:   "Uwe Rosenberg",
+		PubDate:   "2007",
+		Metadata:  map[string]interface{}{"min_players": 5},
+	})
+	ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{
+		MediumID:  alphaID,
+		StartDate: "2024-01-01T00:00:00Z",
+		EndDate:   "2024-01-11T00:00:00Z",
+		Comments:  "Loved it",
+	})
+	ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{
+		MediumID:  bravoID,
+		StartDate: "2024-03-01T00:00:00Z",
+	})
+	ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{
+		MediumID: charlieID,
+	})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/media_records/search"
+
+	// Send a search request with given body
+	search := func(t *testing.T, body map[string]interface{}) ClientSearchMediaRecords {
+		requestBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.UserAcessToken))
+		resp, err := ctx.Client.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		defer resp.Body.Close()
+		var responseBody ClientSearchMediaRecords
+		json.NewDecoder(resp.Body).Decode(&responseBody)
+		return responseBody
+	}
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    map[string]interface{}
+		expectedStatus int
+		expectedTitles []string
+		checkResponse  func(*testing.T, ClientSearchMediaRecords)
+	}{
+		{
+			name: "Valid, no filter",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    map[string]interface{}{},
+			expectedStatus: 200,
+			expectedTitles: []string{"Alpha", "Bravo", "Charlie"},
+			checkResponse: func(t *testing.T, cr ClientSearchMediaRecords) {
+				if cr.NextCursor != "" {
+					t.Error("'next_cursor' should be empty on last page")
+				}
+				if cr.Records[0].Duration != 10 || cr.Records[0].Comments != "Loved it" || cr.Records[0].Creator != "Jane Austen" {
+					t.Errorf("record fields incorrect: %+v", cr.Records[0])
+				}
+			},
+		},
+		{
+			name: "Filter on media type",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    map[string]interface{}{"media_types": []string{"boardgame"}},
+			expectedStatus: 200,
+			expectedTitles: []string{"Bravo", "Charlie"},
+		},
+		{
+			name: "Filter on status finished",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    map[string]interface{}{"status": "finished"},
+			expectedStatus: 200,
+			expectedTitles: []string{"Alpha"},
+		},
+		{
+			name: "Filter on status in progress",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    map[string]interface{}{"status": "in_progress"},
+			expectedStatus: 200,
+			expectedTitles: []string{"Bravo"},
+		},
+		{
+			name: "Filter on status unstarted",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    map[string]interface{}{"status": "unstarted"},
+			expectedStatus: 200,
+			expectedTitles: []string{"Charlie"},
+		},
+		{
+			name: "Filter on creator, case insensitive",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    map[string]interface{}{"creator": "austen"},
+			expectedStatus: 200,
+			expectedTitles: []string{"Alpha"},
+		},
+		{
+			name: "Filter on date ranges",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: map[string]interface{}{
+				"start_date_from": "2023-12-01T00:00:00Z",
+				"end_date_to":     "2024-01-31T00:00:00Z",
+			},
+			expectedStatus: 200,
+			expectedTitles: []string{"Alpha"},
+		},
+		{
+			name: "Filter on duration range",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    map[string]interface{}{"min_duration": 5, "max_duration": 15},
+			expectedStatus: 200,
+			expectedTitles: []string{"Alpha"},
+		},
+		{
+			name: "Filter on comments",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    map[string]interface{}{"comments": "LOVED"},
+			expectedStatus: 200,
+			expectedTitles: []string{"Alpha"},
+		},
+		{
+			name: "Filter on metadata array contains",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: map[string]interface{}{
+				"metadata": []map[string]interface{}{{"key": "genres", "op": "contains", "value": "classic"}},
+			},
+			expectedStatus: 200,
+			expectedTitles: []string{"Alpha"},
+		},
+		{
+			name: "Filter on metadata number, stored as string or number",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: map[string]interface{}{
+				"metadata": []map[string]interface{}{{"key": "min_players", "op": "lte", "value": 4}},
+			},
+			expectedStatus: 200,
+			expectedTitles: []string{"Bravo"},
+		},
+		{
+			name: "Sort descending",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: map[string]interface{}{
+				"sort": []map[string]string{{"key": "title", "order": "desc"}},
+			},
+			expectedStatus: 200,
+			expectedTitles: []string{"Charlie", "Bravo", "Alpha"},
+		},
+		{
+			name: "Sort on several keys",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: map[string]interface{}{
+				"sort": []map[string]string{{"key": "media_type", "order": "desc"}, {"key": "start_date"}},
+			},
+			expectedStatus: 200,
+			expectedTitles: []string{"Alpha", "Bravo", "Charlie"},
+		},
+		{
+			name: "Paginate with cursor",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: map[string]interface{}{
+				"sort":  []map[string]string{{"key": "end_date", "order": "desc"}, {"key": "title"}},
+				"limit": 2,
+			},
+			expectedStatus: 200,
+			// NULL end dates come last in ascending order, first in descending order
+			expectedTitles: []string{"Bravo", "Charlie"},
+			checkResponse: func(t *testing.T, cr ClientSearchMediaRecords) {
+				if cr.NextCursor == "" {
+					t.Fatal("'next_cursor' missing on a full page")
+				}
+				nextPage := search(t, map[string]interface{}{
+					"sort":   []map[string]string{{"key": "end_date", "order": "desc"}, {"key": "title"}},
+					"limit":  2,
+					"cursor": cr.NextCursor,
+				})
+				if len(nextPage.Records) != 1 || nextPage.Records[0].Title != "Alpha" || nextPage.NextCursor != "" {
+					t.Errorf("unexpected next page: %+v", nextPage)
+				}
+			},
+		},
+		{
+			name:           "No access_token",
+			expectedStatus: 401,
+		},
+		{
+			name: "Unknown sort key",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: map[string]interface{}{
+				"sort": []map[string]string{{"key": "color"}},
+			},
+			expectedStatus: 400,
+		},
+		{
+			name: "Unknown metadata operator",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: map[string]interface{}{
+				"metadata": []map[string]interface{}{{"key": "genres", "op": "like", "value": "x"}},
+			},
+			expectedStatus: 400,
+		},
+		{
+			name: "Cursor made for another sort",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody: map[string]interface{}{
+				"cursor": "eyJzIjoidGl0bGU6dHJ1ZSIsInYiOlsiYSIsIjAwMDAwMDAwLTAwMDAtMDAwMC0wMDAwLTAwMDAwMDAwMDAwMCJdfQ",
+			},
+			expectedStatus: 400,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			if tc.requestHeaders != nil {
+				for headerKey, headerValue := range tc.requestHeaders {
+					req.Header.Set(headerKey, headerValue)
+				}
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.expectedStatus == 200 {
+				var responseBody ClientSearchMediaRecords
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				var titles []string
+				for _, record := range responseBody.Records {
+					titles = append(titles, record.Title)
+				}
+				if fmt.Sprint(titles) != fmt.Sprint(tc.expectedTitles) {
+					t.Errorf("Expected titles %v, got %v", tc.expectedTitles, titles)
+				}
+				if tc.checkResponse != nil {
+					tc.checkResponse(t, responseBody)
+				}
+			}
+		})
+	}
+}
+
+func TestRecordsCrossUserAccess(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	mediumID := ctx.CreateTestMediumRandom(t)
+	recordID := ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{
+		MediumID: mediumID,
+		Comments: "Mine",
+	})
+
+	// Bob has no record, every request below targets the first user's record
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	// Get first user's record, to check it is left untouched
+	getRecord := func(t *testing.T) ClientRecord {
+		req, _ := http.NewRequest("GET", ctx.BaseURL+"/api/records", nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.UserAcessToken))
+		resp, err := ctx.Client.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		defer resp.Body.Close()
+		var records ClientRecords
+		if err := json.NewDecoder(resp.Body).Decode(&records); err != nil || len(records.Records) != 1 {
+			t.Fatalf("Failed to get user's record: %v", err)
+		}
+		return records.Records[0]
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		endpoint       string
+		requestBody    interface{}
+		expectedStatus int
+		checkResponse  func(*testing.T, *http.Response)
+		checkAfter     func(*testing.T)
+	}{
+		{
+			name:     "Update another user's record",
+			method:   "PUT",
+			endpoint: "/api/records",
+			requestBody: parametersUpdateRecord{
+				RecordID:  recordID,
+				StartDate: time.Now().AddDate(0, 0, -30).Format(time.RFC3339),
+				Comments:  "Not yours",
+			},
+			expectedStatus: 404,
+			checkAfter: func(t *testing.T) {
+				if record := getRecord(t); record.Comments != "Mine" {
+					t.Errorf("Record was updated by another user, comments = %q", record.Comments)
+				}
+			},
+		},
+		{
+			name:     "Delete another user's record",
+			method:   "DELETE",
+			endpoint: "/api/records",
+			requestBody: parametersDeleteRecord{
+				RecordID: recordID,
+			},
+			expectedStatus: 404,
+			checkAfter: func(t *testing.T) {
+				if !ctx.TestIfRecordExist(recordID) {
+					t.Error("Record was deleted by another user")
+				}
+			},
+		},
+		{
+			name:           "List records",
+			method:         "GET",
+			endpoint:       "/api/records",
+			expectedStatus: 404,
+		},
+		{
+			name:           "List records and media",
+			method:         "GET",
+			endpoint:       "/api/media_records",
+			expectedStatus: 404,
+		},
+		{
+			name:     "Search records",
+			method:   "GET",
+			endpoint: "/api/media_records/search",
+			requestBody: parametersSearchMediaRecords{
+				Comments: "Mine",
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, resp *http.Response) {
+				var result ClientSearchMediaRecords
+				if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				if len(result.Records) != 0 {
+					t.Errorf("Expected no record, got %d", len(result.Records))
+				}
+			},
+		},
+		{
+			name:     "Share another user's record",
+			method:   "POST",
+			endpoint: "/api/shares",
+			requestBody: parametersCreateShare{
+				RecipientUsername: ctx.UserUsername,
+				RecordID:          recordID,
+			},
+			expectedStatus: 404,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(tc.method, ctx.BaseURL+tc.endpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", bob.UserAcessToken))
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+			if tc.checkResponse != nil {
+				tc.checkResponse(t, resp)
+			}
+			if tc.checkAfter != nil {
+				tc.checkAfter(t)
+			}
+		})
+	}
+}
+
+/*
+==========================
+TESTS FOR SHARES ENDPOINTS
+==========================
+*/
+
+func TestCreateShare(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	friend := ctx.CreateOtherTestUser(t, "Friend")
+
+	recordID := ctx.CreateTestRecord(t, ctx.CreateTestMediumRandom(t))
+	friendRecordID := friend.CreateTestRecord(t, friend.CreateTestMediumRandom(t))
+
+	testMethod := "POST"
+	testEndpoint := ctx.BaseURL + "/api/shares"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersCreateShare
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientShare)
+	}{
+		{
+			name: "Valid, record",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateShare{RecipientUsername: friend.UserUsername, RecordID: recordID},
+			expectedStatus: 201,
+			checkResponse: func(t *testing.T, cs ClientShare) {
+				if cs.ID == "" || cs.RecordID != recordID || cs.MediaType != "book" || cs.Title == "" {
+					t.Errorf("unexpected share: %+v", cs)
+				}
+				if cs.OwnerUsername != ctx.UserUsername || cs.RecipientUsername != friend.UserUsername {
+					t.Errorf("unexpected share users: %+v", cs)
+				}
+			},
+		},
+		{
+			name: "Valid, compartment",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateShare{RecipientUsername: friend.UserUsername, MediaType: "book"},
+			expectedStatus: 201,
+			checkResponse: func(t *testing.T, cs ClientShare) {
+				if cs.RecordID != "" || cs.MediaType != "book" {
+					t.Errorf("unexpected share: %+v", cs)
+				}
+			},
+		},
+		{
+			name: "Already shared",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateShare{RecipientUsername: friend.UserUsername, MediaType: "book"},
+			expectedStatus: 409,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersCreateShare{RecipientUsername: friend.UserUsername, MediaType: "movie"},
+			expectedStatus: 401,
+		},
+		{
+			name: "Missing recipient",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateShare{MediaType: "movie"},
+			expectedStatus: 400,
+		},
+		{
+			name: "Both record and compartment",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateShare{RecipientUsername: friend.UserUsername, RecordID: recordID, MediaType: "movie"},
+			expectedStatus: 400,
+		},
+		{
+			name: "Share with yourself",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateShare{RecipientUsername: ctx.UserUsername, MediaType: "movie"},
+			expectedStatus: 400,
+		},
+		{
+			name: "Unknown recipient",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateShare{RecipientUsername: "Nobody", MediaType: "movie"},
+			expectedStatus: 404,
+		},
+		{
+			name: "Record of another user",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateShare{RecipientUsername: friend.UserUsername, RecordID: friendRecordID},
+			expectedStatus: 404,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientShare
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestGetShares(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	friend := ctx.CreateOtherTestUser(t, "Friend")
+	stranger := ctx.CreateOtherTestUser(t, "Stranger")
+
+	// User shares a record with friend, friend shares its boardgames with user
+	recordID := ctx.CreateTestRecord(t, ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Emma", MediaType: "book", Creator: "Jane Austen", PubDate: "1815"}))
+	ctx.CreateTestShare(t, parametersCreateShare{RecipientUsername: friend.UserUsername, RecordID: recordID})
+	friend.CreateTestShare(t, parametersCreateShare{RecipientUsername: ctx.UserUsername, MediaType: "boardgame"})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/shares"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientShares)
+	}{
+		{
+			name: "Valid, owner",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientShares) {
+				if len(cs.SharedByMe) != 1 || cs.SharedByMe[0].Title != "Emma" || cs.SharedByMe[0].RecipientUsername != friend.UserUsername {
+					t.Errorf("unexpected 'shared_by_me': %+v", cs.SharedByMe)
+				}
+				if len(cs.SharedWithMe) != 1 || cs.SharedWithMe[0].MediaType != "boardgame" || cs.SharedWithMe[0].OwnerUsername != friend.UserUsername {
+					t.Errorf("unexpected 'shared_with_me': %+v", cs.SharedWithMe)
+				}
+			},
+		},
+		{
+			name: "Valid, recipient",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", friend.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientShares) {
+				if len(cs.SharedByMe) != 1 || len(cs.SharedWithMe) != 1 || cs.SharedWithMe[0].RecordID != recordID {
+					t.Errorf("unexpected shares: %+v", cs)
+				}
+			},
+		},
+		{
+			name: "Valid, nothing shared",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", stranger.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientShares) {
+				if cs.SharedByMe == nil || cs.SharedWithMe == nil || len(cs.SharedByMe)+len(cs.SharedWithMe) != 0 {
+					t.Errorf("expected empty lists, got %+v", cs)
+				}
+			},
+		},
+		{
+			name:           "No access_token",
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest(testMethod, testEndpoint, nil)
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientShares
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestGetSharedMediaRecords(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	friend := ctx.CreateOtherTestUser(t, "Friend")
+
+	// User has two books and a boardgame, shares its books compartment and its boardgame record
+	emmaID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Emma", MediaType: "book", Creator: "Jane Austen", PubDate: "1815"})
+	ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: emmaID, StartDate: "2024-01-01T00:00:00Z", EndDate: "2024-01-11T00:00:00Z", Comments: "Loved it"})
+	ctx.CreateTestRecord(t, ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Persuasion", MediaType: "book", Creator: "Jane Austen", PubDate: "1817"}))
+	gameRecordID := ctx.CreateTestRecord(t, ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Agricola", MediaType: "boardgame", Creator: "Uwe Rosenberg", PubDate: "2007"}))
+	booksShareID := ctx.CreateTestShare(t, parametersCreateShare{RecipientUsername: friend.UserUsername, MediaType: "book"})
+	gameShareID := ctx.CreateTestShare(t, parametersCreateShare{RecipientUsername: friend.UserUsername, RecordID: gameRecordID})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/shares/records"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersShare
+		expectedStatus int
+		expectedTitles []string
+		checkResponse  func(*testing.T, ClientSearchMediaRecords)
+	}{
+		{
+			name: "Valid, compartment",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", friend.UserAcessToken),
+			},
+			requestBody:    parametersShare{ShareID: booksShareID},
+			expectedStatus: 200,
+			expectedTitles: []string{"Emma", "Persuasion"},
+			checkResponse: func(t *testing.T, cr ClientSearchMediaRecords) {
+				// Sharer's dates and comments are visible
+				if cr.Records[0].Comments != "Loved it" || cr.Records[0].Duration != 10 || !cr.Records[0].IsFinished {
+					t.Errorf("unexpected shared record: %+v", cr.Records[0])
+				}
+			},
+		},
+		{
+			name: "Valid, single record",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", friend.UserAcessToken),
+			},
+			requestBody:    parametersShare{ShareID: gameShareID},
+			expectedStatus: 200,
+			expectedTitles: []string{"Agricola"},
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersShare{ShareID: booksShareID},
+			expectedStatus: 401,
+		},
+		{
+			name: "Invalid share ID",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", friend.UserAcessToken),
+			},
+			requestBody:    parametersShare{ShareID: "not-an-id"},
+			expectedStatus: 400,
+		},
+		{
+			name: "Not the recipient",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersShare{ShareID: booksShareID},
+			expectedStatus: 404,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.expectedStatus == 200 {
+				var responseBody ClientSearchMediaRecords
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				var titles []string
+				for _, record := range responseBody.Records {
+					titles = append(titles, record.Title)
+				}
+				if fmt.Sprint(titles) != fmt.Sprint(tc.expectedTitles) {
+					t.Errorf("Expected titles %v, got %v", tc.expectedTitles, titles)
+				}
+				if tc.checkResponse != nil {
+					tc.checkResponse(t, responseBody)
+				}
+			}
+		})
+	}
+}
+
+func TestAddSharedRecord(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	friend := ctx.CreateOtherTestUser(t, "Friend")
+
+	// User shares its books compartment, but not its boardgame
+	bookRecordID := ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: ctx.CreateTestMediumRandom(t), StartDate: "2024-01-01T00:00:00Z", Comments: "Great"})
+	gameRecordID := ctx.CreateTestRecord(t, ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Agricola", MediaType: "boardgame", Creator: "Uwe Rosenberg", PubDate: "2007"}))
+	shareID := ctx.CreateTestShare(t, parametersCreateShare{RecipientUsername: friend.UserUsername, MediaType: "book"})
+
+	testMethod := "POST"
+	testEndpoint := ctx.BaseURL + "/api/shares/records"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersAddSharedRecord
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientRecord)
+	}{
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", friend.UserAcessToken),
+			},
+			requestBody:    parametersAddSharedRecord{ShareID: shareID, RecordID: bookRecordID},
+			expectedStatus: 201,
+			checkResponse: func(t *testing.T, cr ClientRecord) {
+				// Recipient's new record starts blank
+				if cr.ID == bookRecordID || cr.UserID != fmt.Sprint(friend.UserID) || cr.StartDate != "" || cr.IsFinished {
+					t.Errorf("unexpected new record: %+v", cr)
+				}
+			},
+		},
+		{
+			name: "Already in shelf",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", friend.UserAcessToken),
+			},
+			requestBody:    parametersAddSharedRecord{ShareID: shareID, RecordID: bookRecordID},
+			expectedStatus: 409,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersAddSharedRecord{ShareID: shareID, RecordID: bookRecordID},
+			expectedStatus: 401,
+		},
+		{
+			name: "Record not in share",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", friend.UserAcessToken),
+			},
+			requestBody:    parametersAddSharedRecord{ShareID: shareID, RecordID: gameRecordID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Not the recipient",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersAddSharedRecord{ShareID: shareID, RecordID: bookRecordID},
+			expectedStatus: 404,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientRecord
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestDeleteShare(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	friend := ctx.CreateOtherTestUser(t, "Friend")
+	stranger := ctx.CreateOtherTestUser(t, "Stranger")
+
+	booksShareID := ctx.CreateTestShare(t, parametersCreateShare{RecipientUsername: friend.UserUsername, MediaType: "book"})
+	moviesShareID := ctx.CreateTestShare(t, parametersCreateShare{RecipientUsername: friend.UserUsername, MediaType: "movie"})
+
+	testMethod := "DELETE"
+	testEndpoint := ctx.BaseURL + "/api/shares"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersShare
+		expectedStatus int
+	}{
+		{
+			name: "Not owner nor recipient",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", stranger.UserAcessToken),
+			},
+			requestBody:    parametersShare{ShareID: booksShareID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Valid, owner revokes",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersShare{ShareID: booksShareID},
+			expectedStatus: 200,
+		},
+		{
+			name: "Already revoked",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersShare{ShareID: booksShareID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Valid, recipient dismisses",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", friend.UserAcessToken),
+			},
+			requestBody:    parametersShare{ShareID: moviesShareID},
+			expectedStatus: 200,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersShare{ShareID: moviesShareID},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+
+	// Revoked share isn't visible anymore
+	req, _ := http.NewRequest("GET", ctx.BaseURL+"/api/shares/records", strings.NewReader(fmt.Sprintf(`{"share_id":"%s"}`, booksShareID)))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", friend.UserAcessToken))
+	resp, err := ctx.Client.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 404 {
+		t.Errorf("Expected status code 404 for a revoked share, got %d", resp.StatusCode)
+	}
+}
+
+/*
+=====================================
+TESTS FOR TAGS AND SHELVES ENDPOINTS
+=====================================
+*/
+
+func TestCreateTag(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	testMethod := "POST"
+	testEndpoint := ctx.BaseURL + "/api/tags"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersCreateTag
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientTag)
+	}{
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateTag{Name: " Owned in French "},
+			expectedStatus: 201,
+			checkResponse: func(t *testing.T, ct ClientTag) {
+				if ct.ID == "" {
+					t.Error("invalid 'id' field")
+				}
+				if ct.Name != "Owned in French" {
+					t.Errorf("Expected trimmed tag name, got %q", ct.Name)
+				}
+			},
+		},
+		{
+			name: "Same name, other case",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateTag{Name: "owned in french"},
+			expectedStatus: 409,
+		},
+		{
+			name: "Same name, other user",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersCreateTag{Name: "Owned in French"},
+			expectedStatus: 201,
+		},
+		{
+			name: "Blank name",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateTag{Name: "  "},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersCreateTag{Name: "Gift"},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientTag
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestGetTags(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	// User's two tags are both on a book and a movie, Bob's tag is on nothing
+	alphaID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Alpha", MediaType: "book", Creator: "Jane Austen", PubDate: "1815"})
+	bravoID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Bravo", MediaType: "movie", Creator: "Ridley Scott", PubDate: "1979"})
+	ctx.CreateTestRecord(t, alphaID)
+	ctx.CreateTestRecord(t, bravoID)
+	frenchID := ctx.CreateTestTag(t, "Owned in French")
+	giftID := ctx.CreateTestTag(t, "Gift")
+	ctx.TagTestMedia(t, []string{frenchID, giftID}, []string{alphaID, bravoID})
+	bob.CreateTestTag(t, "Gift")
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/tags"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientTags)
+	}{
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, ct ClientTags) {
+				if len(ct.Tags) != 2 || ct.Tags[0].ID != giftID || ct.Tags[1].ID != frenchID {
+					t.Fatalf("Expected user's two tags by name, got %+v", ct.Tags)
+				}
+				if ct.Tags[0].MediaCount != 2 || ct.Tags[1].MediaCount != 2 {
+					t.Errorf("Expected each tag to be on 2 media, got %+v", ct.Tags)
+				}
+			},
+		},
+		{
+			name: "Valid, other user",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, ct ClientTags) {
+				if len(ct.Tags) != 1 || ct.Tags[0].Name != "Gift" || ct.Tags[0].MediaCount != 0 {
+					t.Errorf("Expected Bob's only tag, on no medium, got %+v", ct.Tags)
+				}
+			},
+		},
+		{
+			name:           "No access_token",
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest(testMethod, testEndpoint, nil)
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientTags
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestUpdateTag(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	ctx.CreateTestTag(t, "Owned in French")
+	giftID := ctx.CreateTestTag(t, "Gift")
+
+	testMethod := "PUT"
+	testEndpoint := ctx.BaseURL + "/api/tags"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersUpdateTag
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientTag)
+	}{
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateTag{TagID: giftID, Name: "Gift from Anna"},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, ct ClientTag) {
+				if ct.ID != giftID || ct.Name != "Gift from Anna" {
+					t.Errorf("Expected renamed tag, got %+v", ct)
+				}
+			},
+		},
+		{
+			name: "Name of another tag",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateTag{TagID: giftID, Name: "OWNED IN FRENCH"},
+			expectedStatus: 409,
+		},
+		{
+			name: "Blank name",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateTag{TagID: giftID, Name: " "},
+			expectedStatus: 400,
+		},
+		{
+			name: "Other user's tag",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersUpdateTag{TagID: giftID, Name: "Mine"},
+			expectedStatus: 404,
+		},
+		{
+			name: "Invalid tag_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateTag{TagID: "1234", Name: "Gift"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersUpdateTag{TagID: giftID, Name: "Gift"},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientTag
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestDeleteTag(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	alphaID := ctx.CreateTestMediumRandom(t)
+	ctx.CreateTestRecord(t, alphaID)
+	frenchID := ctx.CreateTestTag(t, "Owned in French")
+	giftID := ctx.CreateTestTag(t, "Gift")
+	ctx.TagTestMedia(t, []string{frenchID, giftID}, []string{alphaID})
+
+	testMethod := "DELETE"
+	testEndpoint := ctx.BaseURL + "/api/tags"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersTag
+		expectedStatus int
+		checkAfter     func(*testing.T)
+	}{
+		{
+			name: "Other user's tag",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersTag{TagID: frenchID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersTag{TagID: frenchID},
+			expectedStatus: 200,
+			checkAfter: func(t *testing.T) {
+				// Media lose the tag, and keep the other ones
+				books := ctx.GetTestMediaRecords(t).Records["book"]
+				if len(books) != 1 || len(books[0].Tags) != 1 || books[0].Tags[0] != "Gift" {
+					t.Errorf("Expected medium to only hold the remaining tag, got %+v", books)
+				}
+			},
+		},
+		{
+			name: "Wrong tag ID (already deleted)",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersTag{TagID: frenchID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Invalid tag_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersTag{TagID: "1234"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersTag{TagID: giftID},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkAfter != nil {
+				tc.checkAfter(t)
+			}
+		})
+	}
+}
+
+func TestTagMedia(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	// A book and a movie in user's shelf, and a book that isn't
+	alphaID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Alpha", MediaType: "book", Creator: "Jane Austen", PubDate: "1815"})
+	bravoID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Bravo", MediaType: "movie", Creator: "Ridley Scott", PubDate: "1979"})
+	charlieID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Charlie", MediaType: "book", Creator: "Victor Hugo", PubDate: "1862"})
+	ctx.CreateTestRecord(t, alphaID)
+	ctx.CreateTestRecord(t, bravoID)
+	frenchID := ctx.CreateTestTag(t, "Owned in French")
+	giftID := ctx.CreateTestTag(t, "Gift")
+	bobTagID := bob.CreateTestTag(t, "Gift")
+
+	testMethod := "POST"
+	testEndpoint := ctx.BaseURL + "/api/tags/media"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersTagMedia
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientGroupMedia)
+	}{
+		{
+			name: "Valid, two tags on two media",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersTagMedia{TagIDs: []string{frenchID, giftID}, MediumIDs: []string{alphaID, bravoID}},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cg ClientGroupMedia) {
+				if cg.Count != 4 {
+					t.Errorf("Expected 4 media tagged, got %d", cg.Count)
+				}
+			},
+		},
+		{
+			name: "Already tagged",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersTagMedia{TagIDs: []string{frenchID}, MediumIDs: []string{alphaID}},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cg ClientGroupMedia) {
+				if cg.Count != 0 {
+					t.Errorf("Expected no medium tagged, got %d", cg.Count)
+				}
+			},
+		},
+		{
+			name: "Medium not in user's shelf",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersTagMedia{TagIDs: []string{frenchID}, MediumIDs: []string{charlieID}},
+			expectedStatus: 404,
+		},
+		{
+			name: "Other user's tag",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersTagMedia{TagIDs: []string{bobTagID}, MediumIDs: []string{alphaID}},
+			expectedStatus: 404,
+		},
+		{
+			name: "No media",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersTagMedia{TagIDs: []string{frenchID}},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersTagMedia{TagIDs: []string{frenchID}, MediumIDs: []string{alphaID}},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientGroupMedia
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestUntagMedia(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	alphaID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Alpha", MediaType: "book", Creator: "Jane Austen", PubDate: "1815"})
+	bravoID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Bravo", MediaType: "movie", Creator: "Ridley Scott", PubDate: "1979"})
+	ctx.CreateTestRecord(t, alphaID)
+	ctx.CreateTestRecord(t, bravoID)
+	giftID := ctx.CreateTestTag(t, "Gift")
+	ctx.TagTestMedia(t, []string{giftID}, []string{alphaID, bravoID})
+	bobTagID := bob.CreateTestTag(t, "Gift")
+
+	testMethod := "DELETE"
+	testEndpoint := ctx.BaseURL + "/api/tags/media"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersTagMedia
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientGroupMedia)
+		checkAfter     func(*testing.T)
+	}{
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersTagMedia{TagIDs: []string{giftID}, MediumIDs: []string{bravoID}},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cg ClientGroupMedia) {
+				if cg.Count != 1 {
+					t.Errorf("Expected 1 medium untagged, got %d", cg.Count)
+				}
+			},
+			checkAfter: func(t *testing.T) {
+				mediaRecords := ctx.GetTestMediaRecords(t)
+				if books := mediaRecords.Records["book"]; len(books) != 1 || len(books[0].Tags) != 1 {
+					t.Errorf("Expected Alpha to keep its tag, got %+v", books)
+				}
+				if movies := mediaRecords.Records["movie"]; len(movies) != 1 || len(movies[0].Tags) != 0 {
+					t.Errorf("Expected Bravo to hold no tag, got %+v", movies)
+				}
+			},
+		},
+		{
+			name: "Not tagged",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersTagMedia{TagIDs: []string{giftID}, MediumIDs: []string{bravoID}},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cg ClientGroupMedia) {
+				if cg.Count != 0 {
+					t.Errorf("Expected no medium untagged, got %d", cg.Count)
+				}
+			},
+		},
+		{
+			name: "Other user's tag",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersTagMedia{TagIDs: []string{bobTagID}, MediumIDs: []string{alphaID}},
+			expectedStatus: 404,
+		},
+		{
+			name: "No tags",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersTagMedia{MediumIDs: []string{alphaID}},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersTagMedia{TagIDs: []string{giftID}, MediumIDs: []string{alphaID}},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientGroupMedia
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+			if tc.checkAfter != nil {
+				tc.checkAfter(t)
+			}
+		})
+	}
+}
+
+func TestSearchMediaRecordsByTagsAndShelf(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	// Alpha holds both tags and is on the shelf, Bravo only holds one tag
+	alphaID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Alpha", MediaType: "book", Creator: "Jane Austen", PubDate: "1815"})
+	bravoID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Bravo", MediaType: "movie", Creator: "Ridley Scott", PubDate: "1979"})
+	ctx.CreateTestRecord(t, alphaID)
+	ctx.CreateTestRecord(t, bravoID)
+	frenchID := ctx.CreateTestTag(t, "Owned in French")
+	giftID := ctx.CreateTestTag(t, "Gift")
+	ctx.TagTestMedia(t, []string{giftID}, []string{alphaID, bravoID})
+	ctx.TagTestMedia(t, []string{frenchID}, []string{alphaID})
+	shelfID := ctx.CreateTestShelf(t, parametersCreateShelf{Name: "Summer 2025"})
+	ctx.AddTestMediaToShelf(t, shelfID, []string{alphaID})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/media_records/search"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersSearchMediaRecords
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientSearchMediaRecords)
+	}{
+		{
+			name: "Filter on one tag",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersSearchMediaRecords{Tags: []string{"Gift"}},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientSearchMediaRecords) {
+				if len(cs.Records) != 2 {
+					t.Errorf("Expected both media to hold the tag, got %+v", cs.Records)
+				}
+			},
+		},
+		{
+			name: "Filter on all tags, case insensitive",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersSearchMediaRecords{Tags: []string{"owned in french", "GIFT"}},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientSearchMediaRecords) {
+				if len(cs.Records) != 1 || cs.Records[0].MediaID != alphaID {
+					t.Fatalf("Expected only Alpha to hold both tags, got %+v", cs.Records)
+				}
+				if len(cs.Records[0].Tags) != 2 {
+					t.Errorf("Expected Alpha's tags to be listed, got %v", cs.Records[0].Tags)
+				}
+			},
+		},
+		{
+			name: "Blank tag",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersSearchMediaRecords{Tags: []string{""}},
+			expectedStatus: 400,
+		},
+		{
+			name: "Filter on shelf",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersSearchMediaRecords{ShelfID: shelfID},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientSearchMediaRecords) {
+				if len(cs.Records) != 1 || cs.Records[0].MediaID != alphaID {
+					t.Fatalf("Expected only Alpha to be on the shelf, got %+v", cs.Records)
+				}
+				if len(cs.Records[0].ShelfIDs) != 1 || cs.Records[0].ShelfIDs[0] != shelfID {
+					t.Errorf("Expected Alpha's shelves to be listed, got %v", cs.Records[0].ShelfIDs)
+				}
+			},
+		},
+		{
+			name: "Other user's shelf",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersSearchMediaRecords{ShelfID: shelfID},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientSearchMediaRecords) {
+				if len(cs.Records) != 0 {
+					t.Errorf("Expected no record for other user, got %+v", cs.Records)
+				}
+			},
+		},
+		{
+			name: "Invalid shelf_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersSearchMediaRecords{ShelfID: "1234"},
+			expectedStatus: 400,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientSearchMediaRecords
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestCreateShelf(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+
+	testMethod := "POST"
+	testEndpoint := ctx.BaseURL + "/api/shelves"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersCreateShelf
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientShelf)
+	}{
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateShelf{Name: "Summer 2025", Description: "Holidays by the sea"},
+			expectedStatus: 201,
+			checkResponse: func(t *testing.T, cs ClientShelf) {
+				if cs.ID == "" || cs.Name != "Summer 2025" || cs.Description != "Holidays by the sea" {
+					t.Errorf("unexpected shelf: %+v", cs)
+				}
+			},
+		},
+		{
+			name: "Same name, other case",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateShelf{Name: "SUMMER 2025"},
+			expectedStatus: 409,
+		},
+		{
+			name: "No name",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersCreateShelf{Description: "Nameless"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersCreateShelf{Name: "Winter 2025"},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientShelf
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestGetShelves(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	alphaID := ctx.CreateTestMediumRandom(t)
+	bravoID := ctx.CreateTestMediumRandom(t)
+	ctx.CreateTestRecord(t, alphaID)
+	ctx.CreateTestRecord(t, bravoID)
+	summerID := ctx.CreateTestShelf(t, parametersCreateShelf{Name: "Summer 2025", Description: "Holidays by the sea"})
+	ctx.AddTestMediaToShelf(t, summerID, []string{alphaID, bravoID})
+	ctx.CreateTestShelf(t, parametersCreateShelf{Name: "Autumn 2025"})
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/shelves"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientShelves)
+	}{
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientShelves) {
+				if len(cs.Shelves) != 2 || cs.Shelves[0].Name != "Autumn 2025" || cs.Shelves[1].ID != summerID {
+					t.Fatalf("Expected user's two shelves by name, got %+v", cs.Shelves)
+				}
+				if cs.Shelves[0].MediaCount != 0 || cs.Shelves[1].MediaCount != 2 || cs.Shelves[1].Description != "Holidays by the sea" {
+					t.Errorf("unexpected shelves: %+v", cs.Shelves)
+				}
+			},
+		},
+		{
+			name: "Valid, no shelf",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientShelves) {
+				if cs.Shelves == nil || len(cs.Shelves) != 0 {
+					t.Errorf("Expected an empty list, got %+v", cs.Shelves)
+				}
+			},
+		},
+		{
+			name:           "No access_token",
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest(testMethod, testEndpoint, nil)
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientShelves
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestUpdateShelf(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	summerID := ctx.CreateTestShelf(t, parametersCreateShelf{Name: "Summer 2025", Description: "Holidays by the sea"})
+	ctx.CreateTestShelf(t, parametersCreateShelf{Name: "Autumn 2025"})
+	emptyDescription := ""
+
+	testMethod := "PUT"
+	testEndpoint := ctx.BaseURL + "/api/shelves"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersUpdateShelf
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientShelf)
+	}{
+		{
+			name: "Valid, description only",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateShelf{ShelfID: summerID, Description: &emptyDescription},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientShelf) {
+				if cs.Name != "Summer 2025" || cs.Description != "" {
+					t.Errorf("Expected name kept and description cleared, got %+v", cs)
+				}
+			},
+		},
+		{
+			name: "Valid, name only",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateShelf{ShelfID: summerID, Name: "Summer 2026"},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientShelf) {
+				if cs.Name != "Summer 2026" || cs.Description != "" {
+					t.Errorf("Expected renamed shelf, got %+v", cs)
+				}
+			},
+		},
+		{
+			name: "Name of another shelf",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateShelf{ShelfID: summerID, Name: "autumn 2025"},
+			expectedStatus: 409,
+		},
+		{
+			name: "Other user's shelf",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersUpdateShelf{ShelfID: summerID, Name: "Mine"},
+			expectedStatus: 404,
+		},
+		{
+			name: "Invalid shelf_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersUpdateShelf{ShelfID: "1234", Name: "Winter 2025"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersUpdateShelf{ShelfID: summerID, Name: "Winter 2025"},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientShelf
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestDeleteShelf(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	alphaID := ctx.CreateTestMediumRandom(t)
+	bravoID := ctx.CreateTestMediumRandom(t)
+	ctx.CreateTestRecord(t, alphaID)
+	ctx.CreateTestRecord(t, bravoID)
+	shelfID := ctx.CreateTestShelf(t, parametersCreateShelf{Name: "Summer 2025"})
+	ctx.AddTestMediaToShelf(t, shelfID, []string{alphaID, bravoID})
+
+	testMethod := "DELETE"
+	testEndpoint := ctx.BaseURL + "/api/shelves"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersShelf
+		expectedStatus int
+		checkAfter     func(*testing.T)
+	}{
+		{
+			name: "Other user's shelf",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersShelf{ShelfID: shelfID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersShelf{ShelfID: shelfID},
+			expectedStatus: 200,
+			checkAfter: func(t *testing.T) {
+				// Media stay in user's shelf, on no custom shelf
+				books := ctx.GetTestMediaRecords(t).Records["book"]
+				if len(books) != 2 {
+					t.Fatalf("Expected both records to be kept, got %+v", books)
+				}
+				for _, book := range books {
+					if len(book.ShelfIDs) != 0 {
+						t.Errorf("Expected medium to be on no shelf, got %v", book.ShelfIDs)
+					}
+				}
+			},
+		},
+		{
+			name: "Wrong shelf ID (already deleted)",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersShelf{ShelfID: shelfID},
+			expectedStatus: 404,
+		},
+		{
+			name: "Invalid shelf_id",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersShelf{ShelfID: "1234"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersShelf{ShelfID: shelfID},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkAfter != nil {
+				tc.checkAfter(t)
+			}
+		})
+	}
+}
+
+func TestAddMediaToShelf(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	// A book and a movie in user's shelf, and a book that isn't
+	alphaID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Alpha", MediaType: "book", Creator: "Jane Austen", PubDate: "1815"})
+	bravoID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Bravo", MediaType: "movie", Creator: "Ridley Scott", PubDate: "1979"})
+	charlieID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Charlie", MediaType: "book", Creator: "Victor Hugo", PubDate: "1862"})
+	ctx.CreateTestRecord(t, alphaID)
+	ctx.CreateTestRecord(t, bravoID)
+	shelfID := ctx.CreateTestShelf(t, parametersCreateShelf{Name: "Summer 2025"})
+
+	testMethod := "POST"
+	testEndpoint := ctx.BaseURL + "/api/shelves/media"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersShelfMedia
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientGroupMedia)
+	}{
+		{
+			name: "Valid, media of any type",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersShelfMedia{ShelfID: shelfID, MediumIDs: []string{alphaID, bravoID}},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cg ClientGroupMedia) {
+				if cg.Count != 2 {
+					t.Errorf("Expected 2 media added, got %d", cg.Count)
+				}
+			},
+		},
+		{
+			name: "Already on the shelf",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersShelfMedia{ShelfID: shelfID, MediumIDs: []string{alphaID}},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cg ClientGroupMedia) {
+				if cg.Count != 0 {
+					t.Errorf("Expected no medium added, got %d", cg.Count)
+				}
+			},
+		},
+		{
+			name: "Medium not in user's shelf",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersShelfMedia{ShelfID: shelfID, MediumIDs: []string{charlieID}},
+			expectedStatus: 404,
+		},
+		{
+			name: "Other user's shelf",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersShelfMedia{ShelfID: shelfID, MediumIDs: []string{alphaID}},
+			expectedStatus: 404,
+		},
+		{
+			name: "No media",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersShelfMedia{ShelfID: shelfID},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersShelfMedia{ShelfID: shelfID, MediumIDs: []string{alphaID}},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientGroupMedia
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestRemoveMediaFromShelf(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	alphaID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Alpha", MediaType: "book", Creator: "Jane Austen", PubDate: "1815"})
+	bravoID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Bravo", MediaType: "movie", Creator: "Ridley Scott", PubDate: "1979"})
+	ctx.CreateTestRecord(t, alphaID)
+	ctx.CreateTestRecord(t, bravoID)
+	shelfID := ctx.CreateTestShelf(t, parametersCreateShelf{Name: "Summer 2025"})
+	ctx.AddTestMediaToShelf(t, shelfID, []string{alphaID, bravoID})
+
+	testMethod := "DELETE"
+	testEndpoint := ctx.BaseURL + "/api/shelves/media"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersShelfMedia
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientGroupMedia)
+		checkAfter     func(*testing.T)
+	}{
+		{
+			name: "Other user's shelf",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			requestBody:    parametersShelfMedia{ShelfID: shelfID, MediumIDs: []string{bravoID}},
+			expectedStatus: 404,
+		},
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersShelfMedia{ShelfID: shelfID, MediumIDs: []string{bravoID}},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cg ClientGroupMedia) {
+				if cg.Count != 1 {
+					t.Errorf("Expected 1 medium removed, got %d", cg.Count)
+				}
+			},
+			checkAfter: func(t *testing.T) {
+				mediaRecords := ctx.GetTestMediaRecords(t)
+				if books := mediaRecords.Records["book"]; len(books) != 1 || len(books[0].ShelfIDs) != 1 || books[0].ShelfIDs[0] != shelfID {
+					t.Errorf("Expected Alpha to stay on the shelf, got %+v", books)
+				}
+				if movies := mediaRecords.Records["movie"]; len(movies) != 1 || len(movies[0].ShelfIDs) != 0 {
+					t.Errorf("Expected Bravo to be on no shelf, got %+v", movies)
+				}
+			},
+		},
+		{
+			name: "Not on the shelf",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersShelfMedia{ShelfID: shelfID, MediumIDs: []string{bravoID}},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cg ClientGroupMedia) {
+				if cg.Count != 0 {
+					t.Errorf("Expected no medium removed, got %d", cg.Count)
+				}
+			},
+		},
+		{
+			name: "No media",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersShelfMedia{ShelfID: shelfID},
+			expectedStatus: 400,
+		},
+		{
+			name:           "No access_token",
+			requestBody:    parametersShelfMedia{ShelfID: shelfID, MediumIDs: []string{alphaID}},
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.checkResponse != nil {
+				var responseBody ClientGroupMedia
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+			if tc.checkAfter != nil {
+				tc.checkAfter(t)
+			}
+		})
+	}
+}
+
+/*
+==========================
+TESTS FOR STATS ENDPOINTS
+==========================
+*/
+
+func TestGetStats(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+
+	// January 2024 : Alpha, Bravo and Echo are finished, Charlie and Delta are started
+	records := []struct {
+		medium    parametersCreateMedium
+		startDate string
+		endDate   string
+	}{
+		{
+			medium:    parametersCreateMedium{Title: "Alpha", MediaType: "book", Creator: "Jane Austen", PubDate: "1815", Metadata: map[string]interface{}{"genres": []string{"Romance", "Classic"}}},
+			startDate: "2024-01-01T00:00:00Z",
+			endDate:   "2024-01-11T00:00:00Z",
+		},
+		{
+			medium:    parametersCreateMedium{Title: "Bravo", MediaType: "book", Creator: "Jane Austen", PubDate: "1813", Metadata: map[string]interface{}{"subjects": []string{"Classic"}}},
+			startDate: "2024-01-15T00:00:00Z",
+			endDate:   "2024-01-19T00:00:00Z",
+		},
+		{
+			medium:    parametersCreateMedium{Title: "Charlie", MediaType: "boardgame", Creator: "Uwe Rosenberg", PubDate: "2007", Metadata: map[string]interface{}{"categories": []string{"Farming"}}},
+			startDate: "2024-01-20T00:00:00Z",
+			endDate:   "2024-02-05T00:00:00Z",
+		},
+		{
+			medium:    parametersCreateMedium{Title: "Delta", MediaType: "movie", Creator: "Ridley Scott", PubDate: "1979"},
+			startDate: "2024-01-25T00:00:00Z",
+		},
+		{
+			medium:    parametersCreateMedium{Title: "Echo", MediaType: "book", Creator: "Ursula K. Le Guin", PubDate: "1968", Metadata: map[string]interface{}{"subjects": []string{"Fantasy", "Classic", "Classic"}}},
+			startDate: "2023-12-28T00:00:00Z",
+			endDate:   "2024-01-02T00:00:00Z",
+		},
+	}
+	for _, record := range records {
+		mediumID := ctx.CreateTestMediumCustom(t, record.medium)
+		ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{
+			MediumID:  mediumID,
+			StartDate: record.startDate,
+			EndDate:   record.endDate,
+		})
+	}
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/api/stats"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    map[string]interface{}
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientStats)
+	}{
+		{
+			name: "Valid, month",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    map[string]interface{}{"period": "month", "date": "2024-01-15T12:00:00Z"},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientStats) {
+				if !strings.HasPrefix(cs.From, "2024-01-01T00:00:00") || !strings.HasPrefix(cs.To, "2024-02-01T00:00:00") || cs.Bucket != "day" {
+					t.Errorf("unexpected window: %s - %s by %s", cs.From, cs.To, cs.Bucket)
+				}
+				if cs.Totals.Started != 4 || cs.Totals.Finished != 3 || cs.Totals.MedianDuration != 5 || math.Abs(cs.Totals.AvgDuration-19.0/3) > 1e-9 {
+					t.Errorf("unexpected totals: %+v", cs.Totals)
+				}
+				expectedByType := []ClientStatsSummary{
+					{MediaType: "boardgame", Started: 1},
+					{MediaType: "book", Started: 2, Finished: 3, AvgDuration: cs.Totals.AvgDuration, MedianDuration: 5},
+					{MediaType: "movie", Started: 1},
+				}
+				if fmt.Sprint(cs.ByMediaType) != fmt.Sprint(expectedByType) {
+					t.Errorf("Expected by_media_type %+v, got %+v", expectedByType, cs.ByMediaType)
+				}
+				if fmt.Sprint(cs.Longest) != "[{Alpha 10} {Echo 5} {Bravo 4}]" || fmt.Sprint(cs.Shortest) != "[{Bravo 4} {Echo 5} {Alpha 10}]" {
+					t.Errorf("unexpected completions: longest %v, shortest %v", cs.Longest, cs.Shortest)
+				}
+				if fmt.Sprint(cs.TopCreators) != "[{Jane Austen 2} {Ursula K. Le Guin 1}]" {
+					t.Errorf("unexpected top creators: %v", cs.TopCreators)
+				}
+				if fmt.Sprint(cs.TopGenres) != "[{Classic 3} {Fantasy 1} {Romance 1}]" {
+					t.Errorf("unexpected top genres: %v", cs.TopGenres)
+				}
+				if len(cs.Series) != 31 || cs.Series[0].Started != 1 || cs.Series[1].Finished != 1 || !strings.HasPrefix(cs.Series[30].BucketStart, "2024-01-31") {
+					t.Errorf("unexpected series: %+v", cs.Series)
+				}
+			},
+		},
+		{
+			name: "Valid, series by week",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    map[string]interface{}{"period": "month", "date": "2024-01-15T12:00:00Z", "bucket": "week"},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientStats) {
+				// January 1st 2024 is a monday
+				expectedSeries := []ClientStatsBucket{
+					{BucketStart: "2024-01-01T00:00:00", Started: 1, Finished: 1},
+					{BucketStart: "2024-01-08T00:00:00", Finished: 1},
+					{BucketStart: "2024-01-15T00:00:00", Started: 2, Finished: 1},
+					{BucketStart: "2024-01-22T00:00:00", Started: 1},
+					{BucketStart: "2024-01-29T00:00:00"},
+				}
+				if fmt.Sprint(cs.Series) != fmt.Sprint(expectedSeries) {
+					t.Errorf("Expected series %v, got %v", expectedSeries, cs.Series)
+				}
+			},
+		},
+		{
+			name: "Valid, week starting on monday",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    map[string]interface{}{"period": "week", "date": "2024-01-21T12:00:00Z"},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientStats) {
+				if !strings.HasPrefix(cs.From, "2024-01-15T00:00:00") || len(cs.Series) != 7 {
+					t.Errorf("unexpected window: from %s with %d buckets", cs.From, len(cs.Series))
+				}
+				if cs.Totals.Started != 2 || cs.Totals.Finished != 1 {
+					t.Errorf("unexpected totals: %+v", cs.Totals)
+				}
+			},
+		},
+		{
+			name: "Valid, year with top",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    map[string]interface{}{"period": "year", "date": "2024-06-01T00:00:00Z", "top": 1},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientStats) {
+				if cs.Bucket != "month" || len(cs.Series) != 12 {
+					t.Errorf("unexpected series: %s with %d buckets", cs.Bucket, len(cs.Series))
+				}
+				if fmt.Sprint(cs.Longest) != "[{Charlie 16}]" || len(cs.TopCreators) != 1 || len(cs.TopGenres) != 1 {
+					t.Errorf("unexpected top lists: %+v", cs)
+				}
+			},
+		},
+		{
+			name: "Valid, custom period",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    map[string]interface{}{"period": "custom", "from": "2024-02-01T00:00:00Z", "to": "2024-03-01T00:00:00Z"},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientStats) {
+				if cs.Totals.Started != 0 || cs.Totals.Finished != 1 || len(cs.ByMediaType) != 1 || cs.ByMediaType[0].MediaType != "boardgame" {
+					t.Errorf("unexpected stats: %+v", cs)
+				}
+				if fmt.Sprint(cs.TopGenres) != "[{Farming 1}]" {
+					t.Errorf("unexpected top genres: %v", cs.TopGenres)
+				}
+			},
+		},
+		{
+			name: "Valid, nothing in period",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    map[string]interface{}{"period": "month", "date": "2020-01-01T00:00:00Z"},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cs ClientStats) {
+				if cs.Totals.Started != 0 || cs.Totals.Finished != 0 || cs.ByMediaType == nil || len(cs.ByMediaType) != 0 || len(cs.Longest) != 0 {
+					t.Errorf("unexpected stats: %+v", cs)
+				}
+			},
+		},
+		{
+			name:           "No access_token",
+			expectedStatus: 401,
+		},
+		{
+			name: "Unknown period",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    map[string]interface{}{"period": "decade"},
+			expectedStatus: 400,
+		},
+		{
+			name: "Custom period without end",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    map[string]interface{}{"period": "custom", "from": "2024-02-01T00:00:00Z"},
+			expectedStatus: 400,
+		},
+		{
+			name: "Custom period ending before it starts",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    map[string]interface{}{"period": "custom", "from": "2024-02-01T00:00:00Z", "to": "2024-01-01T00:00:00Z"},
+			expectedStatus: 400,
+		},
+		{
+			name: "Unknown bucket",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    map[string]interface{}{"bucket": "hour"},
+			expectedStatus: 400,
+		},
+		{
+			name: "Too many buckets",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    map[string]interface{}{"period": "custom", "from": "1900-01-01T00:00:00Z", "to": "2100-01-01T00:00:00Z", "bucket": "day"},
+			expectedStatus: 400,
+		},
+		{
+			name: "Top out of range",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    map[string]interface{}{"top": 100},
+			expectedStatus: 400,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			if tc.requestHeaders != nil {
+				for headerKey, headerValue := range tc.requestHeaders {
+					req.Header.Set(headerKey, headerValue)
+				}
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.expectedStatus == 200 {
+				var responseBody ClientStats
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+/*
+==========================
+TESTS FOR ADMIN ENDPOINTS
+==========================
+*/
+
+func TestAdminGetUsers(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	ctx.SetTestUserRole(t, "admin")
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+	ctx.CreateOtherTestUser(t, "Carol")
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/admin/users"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		requestBody    parametersAdminGetUsers
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientAdminUsers)
+	}{
+		{
+			name: "Valid, every user",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cu ClientAdminUsers) {
+				if len(cu.Users) != 3 || cu.Users[0].Username != "Bob" || cu.Users[1].Username != "Carol" {
+					t.Errorf("Expected 3 users sorted by username, got %+v", cu.Users)
+				}
+			},
+		},
+		{
+			name: "Valid, search on email",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersAdminGetUsers{Search: "BOB@"},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cu ClientAdminUsers) {
+				if len(cu.Users) != 1 || cu.Users[0].Username != "Bob" || cu.Users[0].Role != "user" || cu.Users[0].DeactivatedAt != "" {
+					t.Errorf("Expected only active user Bob, got %+v", cu.Users)
+				}
+			},
+		},
+		{
+			name: "Valid, paging",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersAdminGetUsers{Limit: 1, Offset: 1},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cu ClientAdminUsers) {
+				if len(cu.Users) != 1 || cu.Users[0].Username != "Carol" {
+					t.Errorf("Expected only Carol, got %+v", cu.Users)
+				}
+			},
+		},
+		{
+			name: "Limit out of range",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			requestBody:    parametersAdminGetUsers{Limit: 1000},
+			expectedStatus: 400,
+		},
+		{
+			name: "Not an admin",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			expectedStatus: 403,
+		},
+		{
+			name:           "No access_token",
+			expectedStatus: 401,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.expectedStatus == 200 {
+				var responseBody ClientAdminUsers
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestAdminDeactivateUser(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	ctx.SetTestUserRole(t, "admin")
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	// Send a request as admin on an /admin/users endpoint, return status code
+	adminRequest := func(t *testing.T, method, endpoint string, accessToken string, userID interface{}) int {
+		requestBody, _ := json.Marshal(map[string]interface{}{"user_id": userID})
+		req, _ := http.NewRequest(method, ctx.BaseURL+endpoint, bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+		resp, err := ctx.Client.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+	login := func(t *testing.T, user *TestContext) int {
+		payload := fmt.Sprintf(`{"username":"%s", "password":"%s"}`, user.UserUsername, user.UserPassword)
+		resp, err := ctx.Client.Post(ctx.BaseURL+"/auth/login", "application/json", strings.NewReader(payload))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	t.Run("Deactivate another user", func(t *testing.T) {
+		if status := adminRequest(t, "PUT", "/admin/users/deactivate", ctx.UserAcessToken, bob.UserID); status != 200 {
+			t.Fatalf("Expected status code 200, got %d", status)
+		}
+		if ctx.TestValidateRefreshToken(bob.UserRefreshToken) {
+			t.Error("Expected Bob's refresh token to be revoked")
+		}
+		if status := login(t, bob); status != 403 {
+			t.Errorf("Expected status code 403 on deactivated user's login, got %d", status)
+		}
+	})
+
+	t.Run("Reactivate user", func(t *testing.T) {
+		if status := adminRequest(t, "PUT", "/admin/users/reactivate", ctx.UserAcessToken, bob.UserID); status != 200 {
+			t.Fatalf("Expected status code 200, got %d", status)
+		}
+		if status := login(t, bob); status != 201 {
+			t.Errorf("Expected status code 201 on reactivated user's login, got %d", status)
+		}
+	})
+
+	t.Run("Force logout", func(t *testing.T) {
+		bob.LoginTestUser(t)
+		if status := adminRequest(t, "POST", "/admin/users/logout", ctx.UserAcessToken, bob.UserID); status != 200 {
+			t.Fatalf("Expected status code 200, got %d", status)
+		}
+		if ctx.TestValidateRefreshToken(bob.UserRefreshToken) {
+			t.Error("Expected Bob's refresh token to be revoked")
+		}
+	})
+
+	t.Run("Admin can't deactivate themself", func(t *testing.T) {
+		if status := adminRequest(t, "PUT", "/admin/users/deactivate", ctx.UserAcessToken, ctx.UserID); status != 400 {
+			t.Errorf("Expected status code 400, got %d", status)
+		}
+	})
+
+	t.Run("Unknown user", func(t *testing.T) {
+		if status := adminRequest(t, "PUT", "/admin/users/deactivate", ctx.UserAcessToken, "00000000-0000-0000-0000-000000000000"); status != 404 {
+			t.Errorf("Expected status code 404, got %d", status)
+		}
+	})
+
+	t.Run("Invalid user_id", func(t *testing.T) {
+		if status := adminRequest(t, "POST", "/admin/users/logout", ctx.UserAcessToken, "1234"); status != 400 {
+			t.Errorf("Expected status code 400, got %d", status)
+		}
+	})
+
+	t.Run("Not an admin", func(t *testing.T) {
+		bob.LoginTestUser(t)
+		if status := adminRequest(t, "PUT", "/admin/users/deactivate", bob.UserAcessToken, ctx.UserID); status != 403 {
+			t.Errorf("Expected status code 403, got %d", status)
+		}
+	})
+
+	t.Run("Demoted admin loses access", func(t *testing.T) {
+		ctx.SetTestUserRole(t, "user")
+		if status := adminRequest(t, "POST", "/admin/users/logout", ctx.UserAcessToken, bob.UserID); status != 403 {
+			t.Errorf("Expected status code 403, got %d", status)
+		}
+	})
+}
+
+func TestAdminGetCounts(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	ctx.SetTestUserRole(t, "admin")
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	bookID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Alpha", MediaType: "book", Creator: "Jane Austen", PubDate: "1979"})
+	ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Bravo", MediaType: "book", Creator: "Jane Austen", PubDate: "1979"})
+	ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "Charlie", MediaType: "movie", Creator: "Ridley Scott", PubDate: "1979"})
+	ctx.CreateTestRecord(t, bookID)
+	bob.CreateTestRecord(t, bookID)
+
+	testMethod := "GET"
+	testEndpoint := ctx.BaseURL + "/admin/counts"
+
+	tests := []struct {
+		name           string
+		requestHeaders map[string]string
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientAdminCounts)
+	}{
+		{
+			name: "Valid",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", ctx.UserAcessToken),
+			},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cc ClientAdminCounts) {
+				if cc.Users != 2 || cc.Admins != 1 || cc.DeactivatedUsers != 0 || cc.Media != 3 || cc.Records != 2 {
+					t.Errorf("unexpected counts: %+v", cc)
+				}
+				if cc.MediaByType["book"] != 2 || cc.MediaByType["movie"] != 1 || len(cc.MediaByType) != 2 {
+					t.Errorf("unexpected media by type: %v", cc.MediaByType)
+				}
+			},
+		},
+		{
+			name: "Not an admin",
+			requestHeaders: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", bob.UserAcessToken),
+			},
+			expectedStatus: 403,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBufferString("{}"))
+			req.Header.Set("Content-Type", "application/json")
+			for headerKey, headerValue := range tc.requestHeaders {
+				req.Header.Set(headerKey, headerValue)
+			}
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.expectedStatus == 200 {
+				var responseBody ClientAdminCounts
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+}
+
+func TestAdminMergeMedia(t *testing.T) {
+	ctx := SetupTestContext(t)
+	defer ctx.Server.Shutdown(context.Background())
+
+	ctx.CreateTestUser(t)
+	ctx.LoginTestUser(t)
+	ctx.SetTestUserRole(t, "admin")
+	bob := ctx.CreateOtherTestUser(t, "Bob")
+
+	targetID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{
+		Title:     "The Hobbit",
+		MediaType: "book",
+		Creator:   "J.R.R. Tolkien",
+		PubDate:   "1937",
+		Metadata:  map[string]interface{}{"genres": []string{"Fantasy"}, "pages": 310},
+	})
+	sourceID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{
+		Title:     "Hobbit, The",
+		MediaType: "book",
+		Creator:   "Tolkien",
+		PubDate:   "1937",
+		ImageUrl:  "https://example.com/hobbit.jpg",
+		Metadata:  map[string]interface{}{"genres": []string{"Fantasy", "Classic"}, "isbn": "9780261103344", "pages": 300},
+	})
+	movieID := ctx.CreateTestMediumCustom(t, parametersCreateMedium{Title: "The Hobbit", MediaType: "movie", Creator: "Peter Jackson", PubDate: "2012"})
+
+	// First user has both media, the source's record is the richer one
+	ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: targetID, Comments: "Bought it twice"})
+	ctx.CreateTestRecordCustom(t, parametersCreateUserMediumRecord{MediumID: sourceID, StartDate: "2024-01-01T00:00:00Z", EndDate: "2024-01-11T00:00:00Z", Comments: "Loved it"})
+	bob.CreateTestRecord(t, sourceID)
+	// First user lent both media out, Bob only the source
+	ctx.CreateTestLoan(t, parametersCreateLoan{MediumID: targetID, BorrowerName: "Jane"})
+	ctx.CreateTestLoan(t, parametersCreateLoan{MediumID: sourceID, BorrowerName: "John"})
+	bob.CreateTestLoan(t, parametersCreateLoan{MediumID: sourceID, BorrowerName: "Jane"})
+
+	testMethod := "POST"
+	testEndpoint := ctx.BaseURL + "/admin/media/merge"
+
+	tests := []struct {
+		name           string
+		accessToken    string
+		requestBody    parametersAdminMergeMedia
+		expectedStatus int
+		checkResponse  func(*testing.T, ClientAdminMergeMedia)
+	}{
+		{
+			name:           "Not an admin",
+			accessToken:    bob.UserAcessToken,
+			requestBody:    parametersAdminMergeMedia{TargetID: targetID, SourceID: sourceID},
+			expectedStatus: 403,
+		},
+		{
+			name:           "Different media types",
+			accessToken:    ctx.UserAcessToken,
+			requestBody:    parametersAdminMergeMedia{TargetID: targetID, SourceID: movieID},
+			expectedStatus: 400,
+		},
+		{
+			name:           "Invalid source_id",
+			accessToken:    ctx.UserAcessToken,
+			requestBody:    parametersAdminMergeMedia{TargetID: targetID, SourceID: "1234"},
+			expectedStatus: 400,
+		},
+		{
+			name:           "Unknown source",
+			accessToken:    ctx.UserAcessToken,
+			requestBody:    parametersAdminMergeMedia{TargetID: targetID, SourceID: "00000000-0000-0000-0000-000000000000"},
+			expectedStatus: 404,
+		},
+		{
+			name:           "Valid",
+			accessToken:    ctx.UserAcessToken,
+			requestBody:    parametersAdminMergeMedia{TargetID: targetID, SourceID: sourceID},
+			expectedStatus: 200,
+			checkResponse: func(t *testing.T, cm ClientAdminMergeMedia) {
+				if cm.Medium.ID != targetID || cm.Medium.Title != "The Hobbit" || cm.Medium.Creator != "J.R.R. Tolkien" {
+					t.Errorf("Expected target medium to be kept, got %+v", cm.Medium)
+				}
+				if cm.Medium.ImageUrl != "https://example.com/hobbit.jpg" {
+					t.Errorf("Expected empty image_url to be filled from source, got %q", cm.Medium.ImageUrl)
+				}
+				if fmt.Sprint(cm.Medium.Metadata["genres"]) != "[Fantasy Classic]" || cm.Medium.Metadata["isbn"] != "9780261103344" || cm.Medium.Metadata["pages"] != float64(310) {
+					t.Errorf("unexpected merged metadata: %v", cm.Medium.Metadata)
+				}
+				if cm.MovedRecords != 2 || cm.MergedRecords != 1 {
+					t.Errorf("Expected 2 moved and 1 merged records, got %d and %d", cm.MovedRecords, cm.MergedRecords)
+				}
+				if cm.ClosedLoans != 1 {
+					t.Errorf("Expected first user's open loan of source to be closed, got %d closed loans", cm.ClosedLoans)
+				}
+			},
+		},
+		{
+			name:           "Source already merged",
+			accessToken:    ctx.UserAcessToken,
+			requestBody:    parametersAdminMergeMedia{TargetID: targetID, SourceID: sourceID},
+			expectedStatus: 400,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequest(testMethod, testEndpoint, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tc.accessToken))
+			resp, err := ctx.Client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatus, resp.StatusCode)
+			}
+
+			if tc.expectedStatus == 200 {
+				var responseBody ClientAdminMergeMedia
+				err := json.NewDecoder(resp.Body).Decode(&responseBody)
+				if err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				tc.checkResponse(t, responseBody)
+			}
+		})
+	}
+
+	t.Run("Records were merged", func(t *testing.T) {
+		if ctx.TestIfMediumExist(sourceID) {
+			t.Error("Expected source medium to be deleted")
+		}
+		req, _ := http.NewRequest("GET", ctx.BaseURL+"/api/records", nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.UserAcessToken))
+		resp, err := ctx.Client.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		defer resp.Body.Close()
+		var records ClientRecords
+		err = json.NewDecoder(resp.Body).Decode(&records)
+		if err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if len(records.Records) != 1 {
+			t.Fatalf("Expected a single record left, got %+v", records.Records)
+		}
+		record := records.Records[0]
+		if record.MediaID != targetID || !record.IsFinished || record.Comments != "Loved it\n\nBought it twice" {
+			t.Errorf("Expected richer record moved to target with both comments, got %+v", record)
+		}
+	})
+
+	t.Run("Old ID resolves to target", func(t *testing.T) {
+		requestBody, _ := json.Marshal(parametersCreateUserMediumRecord{MediumID: sourceID})
+		req, _ := http.NewRequest("POST", ctx.BaseURL+"/api/records", bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", bob.UserAcessToken))
+		resp, err := ctx.Client.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != 201 {
+			t.Fatalf("Expected status code 201 when recording a merged medium's ID, got %d", resp.StatusCode)
+		}
+		var record ClientRecord
+		if err := json.NewDecoder(resp.Body).Decode(&record); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if record.MediaID != targetID {
+			t.Errorf("Expected record to point to target medium, got %s", record.MediaID)
+		}
+	})
+}
+
 /*
 ==================================
 TESTS FOR PASSWORD RESET ENDPOINTS